@@ -10,16 +10,19 @@ import (
 
 	"github.com/go-rod/rod/lib/launcher"
 
-	"xtools/internal/adapters/activity"
-	"xtools/internal/adapters/events"
-	"xtools/internal/adapters/llm"
-	"xtools/internal/adapters/storage"
-	"xtools/internal/adapters/twitter"
-	"xtools/internal/adapters/updater"
-	"xtools/internal/domain"
-	"xtools/internal/handlers"
-	"xtools/internal/services"
-	"xtools/internal/workers"
+	"github.com/luthebao/poly-xtools/internal/adapters/activity"
+	"github.com/luthebao/poly-xtools/internal/adapters/events"
+	"github.com/luthebao/poly-xtools/internal/adapters/llm"
+	"github.com/luthebao/poly-xtools/internal/adapters/remote"
+	"github.com/luthebao/poly-xtools/internal/adapters/scripting"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/adapters/twitter"
+	"github.com/luthebao/poly-xtools/internal/adapters/updater"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/handlers"
+	"github.com/luthebao/poly-xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/services"
+	"github.com/luthebao/poly-xtools/internal/workers"
 )
 
 // App struct holds all application dependencies
@@ -36,8 +39,18 @@ type App struct {
 	configStore      *storage.YAMLConfigStore
 	metricsStore     *storage.SQLiteMetricsStore
 	replyStore       *storage.SQLiteReplyStore
-	polymarketStore  *storage.PolymarketStore
+	polymarketStore  ports.PolymarketStore
 	excelExporter    *storage.ExcelExporter
+	replyDB          *sql.DB
+
+	// At-rest database encryption (nil when no key is configured)
+	dbEncryption       *storage.EncryptedDatabase
+	dbEncryptionStatus domain.DatabaseEncryptionStatus
+	dbRecovery         *domain.DatabaseRecovery // Set when a damaged database was rebuilt at startup
+
+	// Remote-backend mode: the Polymarket watcher runs in an xtools daemon
+	remoteConfig   domain.RemoteBackendConfig
+	remoteFollower *remote.Follower // nil unless remote mode is active
 
 	// Services
 	accountSvc      *services.AccountService
@@ -106,6 +119,37 @@ func (a *App) startup(ctx context.Context) {
 	// Initialize activity logger
 	a.activityLogger = activity.NewInMemoryLogger(a.eventBus)
 
+	// Decrypt the database before any store opens it
+	var unsealErr error
+	dbKey, source := storage.LoadDatabaseKey()
+	if dbKey != "" {
+		a.dbEncryption = storage.NewEncryptedDatabase(dbPath, dbKey)
+		a.dbEncryptionStatus = domain.DatabaseEncryptionStatus{
+			Enabled:       true,
+			KeySource:     source,
+			EncryptedPath: a.dbEncryption.EncryptedPath(),
+		}
+		if unsealErr = a.dbEncryption.Unseal(); unsealErr != nil {
+			println("Failed to decrypt database:", unsealErr.Error())
+			a.dbEncryptionStatus.Error = unsealErr.Error()
+		}
+	}
+
+	// Rebuild a damaged database before any store opens it. A database that could not
+	// be decrypted is never opened: that would leave a new plaintext xtools.db on disk.
+	if unsealErr == nil {
+		if recovery, err := storage.RecoverDatabase(dbPath); err != nil {
+			println("Failed to recover database:", err.Error())
+		} else if recovery != nil {
+			a.dbRecovery = recovery
+			if a.dbEncryption != nil {
+				if err := a.dbEncryption.SealBackup(recovery.BackupPath); err != nil {
+					println("Failed to encrypt damaged database backup:", err.Error())
+				}
+			}
+		}
+	}
+
 	// Initialize storage
 	var err error
 	a.configStore, err = storage.NewYAMLConfigStore(accountsDir)
@@ -113,15 +157,23 @@ func (a *App) startup(ctx context.Context) {
 		println("Failed to initialize config store:", err.Error())
 	}
 
-	a.metricsStore, err = storage.NewSQLiteMetricsStore(dbPath)
-	if err != nil {
-		println("Failed to initialize metrics store:", err.Error())
-	}
-
-	// Open DB for reply store
-	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL")
-	if err == nil {
-		a.replyStore, _ = storage.NewSQLiteReplyStore(db)
+	if unsealErr == nil {
+		a.metricsStore, err = storage.NewSQLiteMetricsStore(dbPath)
+		if err != nil {
+			println("Failed to initialize metrics store:", err.Error())
+		}
+
+		// Open DB for reply store
+		a.replyDB, err = sql.Open("sqlite3", dbPath+"?_journal_mode=WAL")
+		if err == nil {
+			a.replyStore, _ = storage.NewSQLiteReplyStore(a.replyDB)
+		}
+	} else if a.replyDB, err = sql.Open("sqlite3", ":memory:"); err == nil {
+		// Keep Twitter metrics and replies in memory until the database key is fixed; a
+		// single connection keeps every query on the same in-memory database
+		a.replyDB.SetMaxOpenConns(1)
+		a.metricsStore, _ = storage.NewSQLiteMetricsStoreWithDB(a.replyDB)
+		a.replyStore, _ = storage.NewSQLiteReplyStore(a.replyDB)
 	}
 
 	a.excelExporter, err = storage.NewExcelExporter(exportsDir)
@@ -129,9 +181,21 @@ func (a *App) startup(ctx context.Context) {
 		println("Failed to initialize excel exporter:", err.Error())
 	}
 
-	a.polymarketStore, err = storage.NewPolymarketStore(dbPath)
-	if err != nil {
-		println("Failed to initialize polymarket store:", err.Error())
+	// Settings and wallet intel are also encrypted inside the open database
+	var storeError string
+	storeOpts := storage.PolymarketStoreOptions{SettingsKey: dbKey, AnalysisDBPath: storage.LoadAnalysisDBPath(dataDir)}
+	// Only xtools.db is encrypted at rest, so a split-off analysis file would stay plaintext
+	if storeOpts.AnalysisDBPath != "" && a.dbEncryption != nil {
+		println("XTOOLS_ANALYSIS_DB is ignored while the database is encrypted at rest")
+		storeOpts.AnalysisDBPath = ""
+	}
+	if unsealErr != nil {
+		a.polymarketStore = storage.NewMemoryPolymarketStore()
+		storeError = "database could not be decrypted, check the database key; trades are kept in memory only: " + unsealErr.Error()
+	} else if a.polymarketStore, err = storage.NewPolymarketStoreWithOptions(dbPath, storeOpts); err != nil {
+		println("Failed to initialize polymarket store, falling back to in-memory store:", err.Error())
+		a.polymarketStore = storage.NewMemoryPolymarketStore()
+		storeError = "database unavailable, trades are kept in memory only: " + err.Error()
 	}
 
 	// Initialize services
@@ -141,11 +205,29 @@ func (a *App) startup(ctx context.Context) {
 	a.accountSvc = services.NewAccountService(a.configStore, clientFactory, a.eventBus)
 	a.searchSvc = services.NewSearchService(a.accountSvc, a.metricsStore, a.excelExporter, a.eventBus)
 	a.replySvc = services.NewReplyService(a.accountSvc, a.searchSvc, llmFactory, a.replyStore, a.metricsStore, a.eventBus, a.activityLogger)
-	a.polymarketSvc = services.NewPolymarketService(a.polymarketStore, a.eventBus, dbPath)
+	remoteClient := a.connectRemoteBackend(dataDir)
+	if remoteClient == nil {
+		a.polymarketSvc = services.NewPolymarketService(a.polymarketStore, a.eventBus, dbPath)
+		a.polymarketSvc.SetBackupEncryption(a.dbEncryption)
+		a.polymarketSvc.SetStoreError(storeError)
+	}
 	a.notificationSvc = services.NewNotificationService(a.polymarketStore, a.eventBus)
+	if a.polymarketSvc != nil {
+		a.notificationSvc.SetErrorReporter(a.polymarketSvc.ErrorReporter())
+		a.notificationSvc.SetWalletBlacklist(a.polymarketSvc)
+	}
+
+	// Let an optional alerts.star script rewrite or suppress alerts before delivery
+	a.notificationSvc.SetTransformer(scripting.NewAlertTransformer(filepath.Join(dataDir, "alerts.star")))
 
-	// Start notification service to listen for events
-	a.notificationSvc.Start()
+	// Register custom detector scripts (detectors/*.star) and start the notification
+	// service, unless the daemon runs detectors and sends the alerts
+	if a.polymarketSvc != nil {
+		for _, detector := range scripting.LoadDetectors(filepath.Join(dataDir, "detectors")) {
+			a.polymarketSvc.RegisterDetector(detector)
+		}
+		a.notificationSvc.Start()
+	}
 
 	// Initialize worker pool
 	a.workerPool = workers.NewWorkerPool(a.searchSvc, a.replySvc, a.configStore, a.eventBus, a.activityLogger)
@@ -163,6 +245,9 @@ func (a *App) startup(ctx context.Context) {
 		a.excelExporter,
 		a.activityLogger,
 	)
+	if remoteClient != nil {
+		a.handlers.SetPolymarketRemote(remoteClient)
+	}
 
 	// Create example config if no accounts exist
 	accounts, _ := a.configStore.ListAccounts()
@@ -173,6 +258,16 @@ func (a *App) startup(ctx context.Context) {
 
 // domReady is called after front-end resources have been loaded
 func (a App) domReady(ctx context.Context) {
+	// Warn about a rebuilt database once the UI listens
+	if a.dbRecovery != nil {
+		a.eventBus.Emit("database:recovered", *a.dbRecovery)
+	}
+
+	// Forward the daemon's live events once the UI listens
+	if a.remoteFollower != nil {
+		a.remoteFollower.Start()
+	}
+
 	// Start workers for enabled accounts
 	a.workerPool.StartAll()
 }
@@ -196,9 +291,26 @@ func (a *App) shutdown(ctx context.Context) {
 	if a.polymarketSvc != nil {
 		a.polymarketSvc.Close()
 	}
+	if a.remoteFollower != nil {
+		a.remoteFollower.Stop()
+	}
 	if a.notificationSvc != nil {
 		a.notificationSvc.Stop()
 	}
+	// In remote mode no watcher owns the store, but notifications still used it
+	if a.polymarketSvc == nil && a.polymarketStore != nil {
+		a.polymarketStore.Close()
+	}
+	if a.replyDB != nil {
+		a.replyDB.Close()
+	}
+
+	// Encrypt the database once every connection is closed
+	if a.dbEncryption != nil && a.dbEncryptionStatus.Error == "" {
+		if err := a.dbEncryption.Seal(); err != nil {
+			println("Failed to encrypt database:", err.Error())
+		}
+	}
 }
 
 // === Exposed Methods (Wails Bindings) ===
@@ -368,6 +480,20 @@ func (a *App) GetDataDir() string {
 	return getDataDir()
 }
 
+// GetDatabaseEncryptionStatus reports whether the database is encrypted at rest.
+// Set XTOOLS_DB_KEY or store a passphrase in the OS keychain (service "XTools",
+// account "database") to enable encryption.
+func (a *App) GetDatabaseEncryptionStatus() domain.DatabaseEncryptionStatus {
+	return a.dbEncryptionStatus
+}
+
+// GetDatabaseRecoveryStatus returns what was salvaged when a damaged database was
+// rebuilt at startup, or nil if the database opened cleanly. The damaged file is kept
+// at BackupPath.
+func (a *App) GetDatabaseRecoveryStatus() *domain.DatabaseRecovery {
+	return a.dbRecovery
+}
+
 // OpenFolder opens a folder in the system file explorer
 func (a *App) OpenFolder(path string) error {
 	return openFolder(path)
@@ -383,78 +509,79 @@ func (a *App) GetAppVersion() string {
 	return a.handlers.GetAppVersion()
 }
 
-// === Polymarket Bindings ===
+// === Notification Bindings ===
 
-// StartPolymarketWatcher starts the Polymarket WebSocket watcher
-func (a *App) StartPolymarketWatcher() error {
-	return a.handlers.StartPolymarketWatcher()
+// GetNotificationConfig returns the current notification configuration
+func (a *App) GetNotificationConfig() domain.NotificationConfig {
+	return a.handlers.GetNotificationConfig()
 }
 
-// StopPolymarketWatcher stops the Polymarket WebSocket watcher
-func (a *App) StopPolymarketWatcher() {
-	a.handlers.StopPolymarketWatcher()
+// SetNotificationConfig updates the notification configuration
+func (a *App) SetNotificationConfig(config domain.NotificationConfig) error {
+	return a.handlers.SetNotificationConfig(config)
 }
 
-// GetPolymarketWatcherStatus returns the current watcher status
-func (a *App) GetPolymarketWatcherStatus() domain.PolymarketWatcherStatus {
-	return a.handlers.GetPolymarketWatcherStatus()
+// SendTestNotification sends a test notification
+func (a *App) SendTestNotification() error {
+	return a.handlers.SendTestNotification()
 }
 
-// GetPolymarketEvents returns Polymarket events with optional filtering
-func (a *App) GetPolymarketEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
-	return a.handlers.GetPolymarketEvents(filter)
+// SetNotificationMaintenanceMode pauses all outbound notifications while still recording them
+func (a *App) SetNotificationMaintenanceMode(enabled bool, reason string) (domain.MaintenanceStatus, error) {
+	return a.handlers.SetNotificationMaintenanceMode(enabled, reason)
 }
 
-// ClearPolymarketEvents removes all stored Polymarket events
-func (a *App) ClearPolymarketEvents() error {
-	return a.handlers.ClearPolymarketEvents()
+// GetNotificationMaintenanceStatus returns the kill-switch state and suppressed alerts
+func (a *App) GetNotificationMaintenanceStatus() domain.MaintenanceStatus {
+	return a.handlers.GetNotificationMaintenanceStatus()
 }
 
-// GetDatabaseInfo returns database statistics
-func (a *App) GetDatabaseInfo() (*domain.DatabaseInfo, error) {
-	return a.handlers.GetDatabaseInfo()
+// GetPendingTelegramChats returns chats that sent /start to the bot while registration
+// is open and await approval
+func (a *App) GetPendingTelegramChats() []domain.TelegramChatRegistration {
+	return a.handlers.GetPendingTelegramChats()
 }
 
-// SetPolymarketSaveFilter sets the filter for saving events to database
-func (a *App) SetPolymarketSaveFilter(filter domain.PolymarketEventFilter) {
-	a.handlers.SetPolymarketSaveFilter(filter)
+// ApproveTelegramChat adds a chat registered on a bot ("default" for the main token) to that bot's chats
+func (a *App) ApproveTelegramChat(botName, chatID string) error {
+	return a.handlers.ApproveTelegramChat(botName, chatID)
 }
 
-// GetPolymarketSaveFilter returns the current save filter
-func (a *App) GetPolymarketSaveFilter() domain.PolymarketEventFilter {
-	return a.handlers.GetPolymarketSaveFilter()
+// RejectTelegramChat discards a pending chat registration
+func (a *App) RejectTelegramChat(botName, chatID string) error {
+	return a.handlers.RejectTelegramChat(botName, chatID)
 }
 
-// GetPolymarketConfig returns the current Polymarket configuration
-func (a *App) GetPolymarketConfig() domain.PolymarketConfig {
-	return a.handlers.GetPolymarketConfig()
+// GetAlertAcks returns the delivered alerts, newest first, with how many await acknowledgment
+func (a *App) GetAlertAcks(unackedOnly bool) domain.AlertAckStatus {
+	return a.handlers.GetAlertAcks(unackedOnly)
 }
 
-// SetPolymarketConfig updates the Polymarket configuration
-func (a *App) SetPolymarketConfig(config domain.PolymarketConfig) {
-	a.handlers.SetPolymarketConfig(config)
+// AckAlert acknowledges an alert, stopping its re-pings
+func (a *App) AckAlert(id string) (*domain.SentAlert, error) {
+	return a.handlers.AckAlert(id)
 }
 
-// GetPolymarketWallets returns all wallets from the database
-func (a *App) GetPolymarketWallets(limit int) ([]domain.WalletProfile, error) {
-	return a.handlers.GetPolymarketWallets(limit)
+// UnackAlert reopens an acknowledged alert
+func (a *App) UnackAlert(id string) (*domain.SentAlert, error) {
+	return a.handlers.UnackAlert(id)
 }
 
-// === Notification Bindings ===
-
-// GetNotificationConfig returns the current notification configuration
-func (a *App) GetNotificationConfig() domain.NotificationConfig {
-	return a.handlers.GetNotificationConfig()
+// GetNotificationStats counts the items notified over the last days (0 = 30), by type
+// and UTC day, for the "notifications sent" chart
+func (a *App) GetNotificationStats(days int) (*domain.NotificationStats, error) {
+	return a.handlers.GetNotificationStats(days)
 }
 
-// SetNotificationConfig updates the notification configuration
-func (a *App) SetNotificationConfig(config domain.NotificationConfig) error {
-	return a.handlers.SetNotificationConfig(config)
+// CleanupNotified deletes the notified item records older than the configured retention now
+func (a *App) CleanupNotified() (*domain.NotifiedCleanupResult, error) {
+	return a.handlers.CleanupNotified()
 }
 
-// SendTestNotification sends a test notification
-func (a *App) SendTestNotification() error {
-	return a.handlers.SendTestNotification()
+// GetUndeliveredNotifications returns the alerts a channel failed to deliver, with their
+// retry state
+func (a *App) GetUndeliveredNotifications() ([]domain.NotificationDelivery, error) {
+	return a.handlers.GetUndeliveredNotifications()
 }
 
 // GetBrowserPath returns the detected browser path for cookie extraction
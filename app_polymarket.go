@@ -0,0 +1,143 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// === Polymarket Bindings ===
+
+// StartPolymarketWatcher starts the Polymarket WebSocket watcher
+func (a *App) StartPolymarketWatcher() error {
+	return a.handlers.StartPolymarketWatcher()
+}
+
+// StopPolymarketWatcher stops the Polymarket WebSocket watcher
+func (a *App) StopPolymarketWatcher() {
+	a.handlers.StopPolymarketWatcher()
+}
+
+// GetPolymarketWatcherStatus returns the current watcher status
+func (a *App) GetPolymarketWatcherStatus() domain.PolymarketWatcherStatus {
+	return a.handlers.GetPolymarketWatcherStatus()
+}
+
+// GetPolymarketEvents returns Polymarket events with optional filtering
+func (a *App) GetPolymarketEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	return a.handlers.GetPolymarketEvents(filter)
+}
+
+// GetPolymarketEventCount returns how many events match a filter, ignoring its limit and offset
+func (a *App) GetPolymarketEventCount(filter domain.PolymarketEventFilter) (int64, error) {
+	return a.handlers.GetPolymarketEventCount(filter)
+}
+
+// GetPolymarketEventPage returns a page of events with the number, notional and fresh
+// wallet count of all events matching the filter
+func (a *App) GetPolymarketEventPage(filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error) {
+	return a.handlers.GetPolymarketEventPage(filter)
+}
+
+// SearchPolymarketEvents finds stored events whose market name or event title contains
+// every word of the query as a word or prefix, in any order, newest first
+func (a *App) SearchPolymarketEvents(query string, limit int) ([]domain.PolymarketEvent, error) {
+	return a.handlers.SearchPolymarketEvents(query, limit)
+}
+
+// GetPolymarketMarketAggregates returns per-market notional volume, trade counts and
+// fresh-wallet counts per "hour" or "day" (UTC) for the trades matching the filter,
+// so charts don't need the raw events
+func (a *App) GetPolymarketMarketAggregates(filter domain.PolymarketEventFilter, bucket domain.AggregateBucket) ([]domain.MarketAggregate, error) {
+	return a.handlers.GetPolymarketMarketAggregates(filter, bucket)
+}
+
+// ClearPolymarketEvents removes all stored Polymarket events
+func (a *App) ClearPolymarketEvents() error {
+	return a.handlers.ClearPolymarketEvents()
+}
+
+// GetDatabaseInfo returns database statistics
+func (a *App) GetDatabaseInfo() (*domain.DatabaseInfo, error) {
+	return a.handlers.GetDatabaseInfo()
+}
+
+// SetPolymarketSaveFilter sets the filter for saving events to database
+func (a *App) SetPolymarketSaveFilter(filter domain.PolymarketEventFilter) {
+	a.handlers.SetPolymarketSaveFilter(filter)
+}
+
+// GetPolymarketSaveFilter returns the current save filter
+func (a *App) GetPolymarketSaveFilter() domain.PolymarketEventFilter {
+	return a.handlers.GetPolymarketSaveFilter()
+}
+
+// GetPolymarketConfig returns the current Polymarket configuration
+func (a *App) GetPolymarketConfig() domain.PolymarketConfig {
+	return a.handlers.GetPolymarketConfig()
+}
+
+// SetPolymarketConfig updates the Polymarket configuration
+func (a *App) SetPolymarketConfig(config domain.PolymarketConfig) {
+	a.handlers.SetPolymarketConfig(config)
+}
+
+// GetPolymarketWallets returns all wallets from the database
+func (a *App) GetPolymarketWallets(limit int) ([]domain.WalletProfile, error) {
+	return a.handlers.GetPolymarketWallets(limit)
+}
+
+// === Polymarket Filter Preset Bindings ===
+
+// GetPolymarketFilterPresets returns the saved filter presets by name
+func (a *App) GetPolymarketFilterPresets() ([]domain.FilterPreset, error) {
+	return a.handlers.GetPolymarketFilterPresets()
+}
+
+// SavePolymarketFilterPreset saves the filter under a name, replacing the preset with
+// the same name (case-insensitive)
+func (a *App) SavePolymarketFilterPreset(name string, filter domain.PolymarketEventFilter) (*domain.FilterPreset, error) {
+	return a.handlers.SavePolymarketFilterPreset(name, filter)
+}
+
+// RenamePolymarketFilterPreset renames a filter preset
+func (a *App) RenamePolymarketFilterPreset(name, newName string) (*domain.FilterPreset, error) {
+	return a.handlers.RenamePolymarketFilterPreset(name, newName)
+}
+
+// DeletePolymarketFilterPreset deletes a filter preset
+func (a *App) DeletePolymarketFilterPreset(name string) error {
+	return a.handlers.DeletePolymarketFilterPreset(name)
+}
+
+// ApplyPolymarketFilterPreset returns the events matching a preset; limit and offset
+// above 0 override its paging
+func (a *App) ApplyPolymarketFilterPreset(name string, limit, offset int) ([]domain.PolymarketEvent, error) {
+	return a.handlers.ApplyPolymarketFilterPreset(name, limit, offset)
+}
+
+// === Market Images ===
+
+// marketImageHandler serves market thumbnails to the frontend at /market-image?url=,
+// from the local image cache when the watcher runs in this process. Otherwise, e.g. in
+// remote mode, the original URL is redirected to.
+func (a *App) marketImageHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/market-image" {
+			http.NotFound(w, r)
+			return
+		}
+		if a.polymarketSvc != nil {
+			if images := a.polymarketSvc.MarketImages(); images != nil {
+				images.ServeHTTP(w, r)
+				return
+			}
+		}
+		if u, err := url.Parse(r.URL.Query().Get("url")); err == nil && (u.Scheme == "https" || u.Scheme == "http") {
+			http.Redirect(w, r, u.String(), http.StatusFound)
+			return
+		}
+		http.Error(w, "invalid image url", http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,125 @@
+package main
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// === Polymarket Event Tag Bindings ===
+
+// TagPolymarketEvent attaches a label (e.g. "superbowl-insider-case") to a stored event
+func (a *App) TagPolymarketEvent(eventID int64, tag string) error {
+	return a.handlers.TagPolymarketEvent(eventID, tag)
+}
+
+// UntagPolymarketEvent removes a label from a stored event
+func (a *App) UntagPolymarketEvent(eventID int64, tag string) error {
+	return a.handlers.UntagPolymarketEvent(eventID, tag)
+}
+
+// GetPolymarketEventTags returns every event tag in use with its event count, most used first
+func (a *App) GetPolymarketEventTags() ([]domain.EventTagCount, error) {
+	return a.handlers.GetPolymarketEventTags()
+}
+
+// SetPolymarketTagRules replaces the rules that automatically tag incoming events
+func (a *App) SetPolymarketTagRules(rules []domain.EventTagRule) error {
+	return a.handlers.SetPolymarketTagRules(rules)
+}
+
+// GetPolymarketTagRules returns the rules that automatically tag incoming events
+func (a *App) GetPolymarketTagRules() []domain.EventTagRule {
+	return a.handlers.GetPolymarketTagRules()
+}
+
+// === Polymarket Alert Rule Files Bindings ===
+
+// ExportPolymarketAlertRules writes the alert thresholds, tag and late entry rules,
+// watchlist and bot routes to a YAML file in the exports directory, to keep in git or
+// import on another deployment
+func (a *App) ExportPolymarketAlertRules() (*domain.AlertRuleExport, error) {
+	return a.handlers.ExportPolymarketAlertRules()
+}
+
+// PreviewPolymarketAlertRules validates an alert rule file and lists what importing it
+// would change, without applying it
+func (a *App) PreviewPolymarketAlertRules(path string) (*domain.AlertRuleImport, error) {
+	return a.handlers.PreviewPolymarketAlertRules(path)
+}
+
+// ImportPolymarketAlertRules applies an alert rule file; sections left out of the file
+// are kept as they are
+func (a *App) ImportPolymarketAlertRules(path string) (*domain.AlertRuleImport, error) {
+	return a.handlers.ImportPolymarketAlertRules(path)
+}
+
+// === Polymarket Config History Bindings ===
+
+// GetPolymarketConfigSnapshot returns the config and tag rules active when an alert
+// was raised, by the alert's configHash
+func (a *App) GetPolymarketConfigSnapshot(hash string) (*domain.ConfigSnapshot, error) {
+	return a.handlers.GetPolymarketConfigSnapshot(hash)
+}
+
+// GetPolymarketConfigSnapshots returns the config versions alerts were raised under,
+// newest first (default 50)
+func (a *App) GetPolymarketConfigSnapshots(limit int) ([]domain.ConfigSnapshot, error) {
+	return a.handlers.GetPolymarketConfigSnapshots(limit)
+}
+
+// GetPolymarketSettingHistory returns the values a settings key (e.g. "config",
+// "filter" or "event_tag_rules"; empty = every key) held before it was overwritten,
+// newest first
+func (a *App) GetPolymarketSettingHistory(key string, limit int) ([]domain.SettingHistoryEntry, error) {
+	return a.handlers.GetPolymarketSettingHistory(key, limit)
+}
+
+// RollbackPolymarketSetting writes a previous settings value back and applies it
+func (a *App) RollbackPolymarketSetting(id int64) (*domain.SettingHistoryEntry, error) {
+	return a.handlers.RollbackPolymarketSetting(id)
+}
+
+// DiffPolymarketConfigSnapshot lists the thresholds that differ between a config
+// version (old values) and the current config (new values)
+func (a *App) DiffPolymarketConfigSnapshot(hash string) ([]domain.ConfigChange, error) {
+	return a.handlers.DiffPolymarketConfigSnapshot(hash)
+}
+
+// === Polymarket Auto-Tune Bindings ===
+
+// SetPolymarketAutoTune saves the settings for automatically adjusting MinTradeSize and
+// the alert threshold to a target alert rate
+func (a *App) SetPolymarketAutoTune(settings domain.AutoTuneSettings) error {
+	return a.handlers.SetPolymarketAutoTune(settings)
+}
+
+// GetPolymarketAutoTune returns the threshold auto-tune settings
+func (a *App) GetPolymarketAutoTune() domain.AutoTuneSettings {
+	return a.handlers.GetPolymarketAutoTune()
+}
+
+// RunPolymarketAutoTune re-tunes thresholds from trailing trades now
+func (a *App) RunPolymarketAutoTune() (*domain.AutoTuneResult, error) {
+	return a.handlers.RunPolymarketAutoTune()
+}
+
+// GetPolymarketLastAutoTune returns the most recent auto-tune result, or nil
+func (a *App) GetPolymarketLastAutoTune() *domain.AutoTuneResult {
+	return a.handlers.GetPolymarketLastAutoTune()
+}
+
+// GetPolymarketConfigAuditTrail returns threshold changes made by the user or auto-tune, newest first
+func (a *App) GetPolymarketConfigAuditTrail(limit int) []domain.ConfigChange {
+	return a.handlers.GetPolymarketConfigAuditTrail(limit)
+}
+
+// === Polymarket Event Sampling Bindings ===
+
+// SetPolymarketEventSamplingRules replaces the rules that thin out book and price_change
+// updates before storage: keep every Nth update per asset or on a price move of more than
+// a number of ticks. Raw counts are still reported in the watcher status.
+func (a *App) SetPolymarketEventSamplingRules(rules []domain.EventSamplingRule) error {
+	return a.handlers.SetPolymarketEventSamplingRules(rules)
+}
+
+// GetPolymarketEventSamplingRules returns the event sampling rules
+func (a *App) GetPolymarketEventSamplingRules() []domain.EventSamplingRule {
+	return a.handlers.GetPolymarketEventSamplingRules()
+}
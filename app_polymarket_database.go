@@ -0,0 +1,116 @@
+package main
+
+import (
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// === Polymarket Diagnostics Bindings ===
+
+// GetPolymarketSystemStatus returns process RSS, heap and goroutine counts, cache sizes
+// and the database write queue depth, for diagnosing leaks during long runs
+func (a *App) GetPolymarketSystemStatus() (*domain.SystemStatus, error) {
+	return a.handlers.GetPolymarketSystemStatus()
+}
+
+// GetErrorStats returns how many feed, store, notify and upstream rate limit errors
+// were reported since start, with the latest ones. New errors are also emitted live
+// on the "errors" event.
+func (a *App) GetErrorStats() (*domain.ErrorStats, error) {
+	return a.handlers.GetErrorStats()
+}
+
+// WritePolymarketProfile writes a pprof profile ("cpu", "heap", "goroutine", "allocs",
+// "block", "mutex") to the profiles folder next to the database. CPU profiles are
+// sampled for the given seconds (default 30) and block until done.
+func (a *App) WritePolymarketProfile(name string, seconds int) (*domain.ProfileExport, error) {
+	return a.handlers.WritePolymarketProfile(name, seconds)
+}
+
+// === Polymarket Database Maintenance Bindings ===
+
+// OptimizePolymarketDatabase checkpoints the WAL, refreshes planner statistics and
+// vacuums the database to reclaim unused pages ("optimize now"). Blocks until done.
+func (a *App) OptimizePolymarketDatabase() (*domain.DatabaseOptimizeResult, error) {
+	return a.handlers.OptimizePolymarketDatabase()
+}
+
+// CompressPolymarketRawData compresses the raw payloads of events saved before raw data
+// was compressed, then vacuums the database. Blocks until done.
+func (a *App) CompressPolymarketRawData() (*domain.RawDataCompression, error) {
+	return a.handlers.CompressPolymarketRawData()
+}
+
+// CheckPolymarketDatabaseIntegrity runs SQLite's integrity_check and returns "ok" or the
+// problems found; it also runs daily in the background
+func (a *App) CheckPolymarketDatabaseIntegrity() (string, error) {
+	return a.handlers.CheckPolymarketDatabaseIntegrity()
+}
+
+// BackupPolymarketDatabase snapshots the database with SQLite's online backup API while
+// the watcher keeps running. An empty path writes a timestamped file to the backup folder.
+func (a *App) BackupPolymarketDatabase(path string) (*domain.DatabaseBackup, error) {
+	return a.handlers.BackupPolymarketDatabase(path)
+}
+
+// RestorePolymarketDatabase replaces the stored events, wallets and settings with a
+// backup's. Stop the watcher first.
+func (a *App) RestorePolymarketDatabase(path string) error {
+	return a.handlers.RestorePolymarketDatabase(path)
+}
+
+// GetPolymarketBackups lists the backups in the backup folder, newest first
+func (a *App) GetPolymarketBackups() ([]domain.DatabaseBackup, error) {
+	return a.handlers.GetPolymarketBackups()
+}
+
+// SetPolymarketEventRetention saves how long and how many events are kept. Events beyond
+// either limit are pruned hourly; events in investigations are always kept.
+func (a *App) SetPolymarketEventRetention(retention domain.EventRetention) error {
+	return a.handlers.SetPolymarketEventRetention(retention)
+}
+
+// GetPolymarketEventRetention returns the event retention policy
+func (a *App) GetPolymarketEventRetention() domain.EventRetention {
+	return a.handlers.GetPolymarketEventRetention()
+}
+
+// PrunePolymarketEvents deletes events beyond the retention policy now
+func (a *App) PrunePolymarketEvents() (*domain.EventPruneResult, error) {
+	return a.handlers.PrunePolymarketEvents()
+}
+
+// ArchivePolymarketEvents moves events older than before to the archive table now,
+// keeping the events table small while preserving history for research
+func (a *App) ArchivePolymarketEvents(before time.Time) (*domain.EventPruneResult, error) {
+	return a.handlers.ArchivePolymarketEvents(before)
+}
+
+// GetPolymarketArchivedEvents returns archived events, filtered like GetPolymarketEvents
+func (a *App) GetPolymarketArchivedEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	return a.handlers.GetPolymarketArchivedEvents(filter)
+}
+
+// GetPolymarketLastEventPrune returns the most recent pruning result, or nil
+func (a *App) GetPolymarketLastEventPrune() *domain.EventPruneResult {
+	return a.handlers.GetPolymarketLastEventPrune()
+}
+
+// === Polymarket Google Sheets Bindings ===
+
+// SetPolymarketSheetsSink configures appending flagged events to a Google Sheet. Enabling it
+// appends a header row to check that the sheet is shared with the service account
+func (a *App) SetPolymarketSheetsSink(config domain.SheetsSinkConfig) error {
+	return a.handlers.SetPolymarketSheetsSink(config)
+}
+
+// GetPolymarketSheetsSink returns the Google Sheets sink configuration
+func (a *App) GetPolymarketSheetsSink() (*domain.SheetsSinkConfig, error) {
+	return a.handlers.GetPolymarketSheetsSink()
+}
+
+// GetPolymarketSheetsSinkStatus returns pending and appended rows and the last error
+func (a *App) GetPolymarketSheetsSinkStatus() (*domain.SheetsSinkStatus, error) {
+	return a.handlers.GetPolymarketSheetsSinkStatus()
+}
@@ -0,0 +1,126 @@
+package main
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// === Polymarket Investigation Bindings ===
+
+// CreatePolymarketInvestigation opens a new named investigation case
+func (a *App) CreatePolymarketInvestigation(name, description string) (*domain.Investigation, error) {
+	return a.handlers.CreatePolymarketInvestigation(name, description)
+}
+
+// UpdatePolymarketInvestigation changes the name, description or status (open, monitoring, closed) of an investigation
+func (a *App) UpdatePolymarketInvestigation(inv domain.Investigation) (*domain.Investigation, error) {
+	return a.handlers.UpdatePolymarketInvestigation(inv)
+}
+
+// GetPolymarketInvestigation returns an investigation with its wallets, markets, events and notes
+func (a *App) GetPolymarketInvestigation(id int64) (*domain.Investigation, error) {
+	return a.handlers.GetPolymarketInvestigation(id)
+}
+
+// ListPolymarketInvestigations returns investigations, most recently updated first; empty status returns all
+func (a *App) ListPolymarketInvestigations(status domain.InvestigationStatus) ([]domain.Investigation, error) {
+	return a.handlers.ListPolymarketInvestigations(status)
+}
+
+// DeletePolymarketInvestigation removes an investigation with its items and notes
+func (a *App) DeletePolymarketInvestigation(id int64) error {
+	return a.handlers.DeletePolymarketInvestigation(id)
+}
+
+// AddPolymarketInvestigationItem attaches a wallet address, market slug or event ID to an investigation
+func (a *App) AddPolymarketInvestigationItem(id int64, itemType domain.InvestigationItemType, ref string) (*domain.Investigation, error) {
+	return a.handlers.AddPolymarketInvestigationItem(id, itemType, ref)
+}
+
+// RemovePolymarketInvestigationItem detaches a wallet, market or event from an investigation
+func (a *App) RemovePolymarketInvestigationItem(id int64, itemType domain.InvestigationItemType, ref string) (*domain.Investigation, error) {
+	return a.handlers.RemovePolymarketInvestigationItem(id, itemType, ref)
+}
+
+// AddPolymarketInvestigationNote appends a note to an investigation
+func (a *App) AddPolymarketInvestigationNote(id int64, body string) (*domain.Investigation, error) {
+	return a.handlers.AddPolymarketInvestigationNote(id, body)
+}
+
+// DeletePolymarketInvestigationNote removes a note from an investigation
+func (a *App) DeletePolymarketInvestigationNote(id, noteID int64) (*domain.Investigation, error) {
+	return a.handlers.DeletePolymarketInvestigationNote(id, noteID)
+}
+
+// GeneratePolymarketReport writes a self-contained HTML or JSON report for a wallet or investigation,
+// optionally with addresses redacted, and returns the file location
+func (a *App) GeneratePolymarketReport(req domain.ReportRequest) (*domain.ReportFile, error) {
+	return a.handlers.GeneratePolymarketReport(req)
+}
+
+// === Polymarket Case Sync Bindings ===
+
+// SetPolymarketCaseSync configures creating and updating Notion or Airtable records for alerts
+// and wallets in open investigations, with a configurable field to property mapping
+func (a *App) SetPolymarketCaseSync(config domain.CaseSyncConfig) error {
+	return a.handlers.SetPolymarketCaseSync(config)
+}
+
+// GetPolymarketCaseSync returns the case sync configuration
+func (a *App) GetPolymarketCaseSync() (*domain.CaseSyncConfig, error) {
+	return a.handlers.GetPolymarketCaseSync()
+}
+
+// GetPolymarketCaseSyncFields returns the record fields available for mapping per record kind
+func (a *App) GetPolymarketCaseSyncFields() map[domain.CaseRecordKind][]string {
+	return domain.CaseRecordFields
+}
+
+// GetPolymarketCaseSyncStatus returns pending, synced and failed records and the last error
+func (a *App) GetPolymarketCaseSyncStatus() (*domain.CaseSyncStatus, error) {
+	return a.handlers.GetPolymarketCaseSyncStatus()
+}
+
+// SyncPolymarketCasesNow syncs every watched wallet and pending alert immediately
+func (a *App) SyncPolymarketCasesNow() (*domain.CaseSyncStatus, error) {
+	return a.handlers.SyncPolymarketCasesNow()
+}
+
+// === Polymarket Calendar Bindings ===
+
+// GetPolymarketUpcomingResolutions returns end dates of markets with flagged activity or open investigations
+func (a *App) GetPolymarketUpcomingResolutions() ([]domain.MarketResolution, error) {
+	return a.handlers.GetPolymarketUpcomingResolutions()
+}
+
+// ExportPolymarketResolutionCalendar writes upcoming market resolutions to an iCal (.ics) file
+// that can be imported into or subscribed to from Google Calendar and other calendar apps
+func (a *App) ExportPolymarketResolutionCalendar() (*domain.CalendarExport, error) {
+	return a.handlers.ExportPolymarketResolutionCalendar()
+}
+
+// === Polymarket Export Bindings ===
+
+// SavePolymarketEventExport creates or replaces a CSV export definition. Each column is a
+// Go template over the event, e.g. "{{money .Notional}}" or "{{pct .ImpliedProbability}}"
+func (a *App) SavePolymarketEventExport(def domain.EventExportDefinition) error {
+	return a.handlers.SavePolymarketEventExport(def)
+}
+
+// DeletePolymarketEventExport removes a CSV export definition
+func (a *App) DeletePolymarketEventExport(name string) error {
+	return a.handlers.DeletePolymarketEventExport(name)
+}
+
+// GetPolymarketEventExports returns the saved CSV export definitions
+func (a *App) GetPolymarketEventExports() []domain.EventExportDefinition {
+	return a.handlers.GetPolymarketEventExports()
+}
+
+// ExportPolymarketEvents runs a saved CSV export and returns the file location
+func (a *App) ExportPolymarketEvents(name string) (*domain.EventExport, error) {
+	return a.handlers.ExportPolymarketEvents(name)
+}
+
+// ExportPolymarketEventsFile writes every stored event matching the filter (or up to its
+// limit) as "csv" or "jsonl" to the exports folder and returns the file location
+func (a *App) ExportPolymarketEventsFile(filter domain.PolymarketEventFilter, format string) (*domain.EventExport, error) {
+	return a.handlers.ExportPolymarketEventsFile(filter, domain.EventExportFormat(format))
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// === Polymarket Market Mute Bindings ===
+
+// MutePolymarketMarket silences notifications for a market (market or event slug) for the given minutes
+func (a *App) MutePolymarketMarket(slug string, minutes int) (*domain.MarketMute, error) {
+	return a.handlers.MutePolymarketMarket(slug, minutes)
+}
+
+// UnmutePolymarketMarket removes a market mute before it expires
+func (a *App) UnmutePolymarketMarket(slug string) error {
+	return a.handlers.UnmutePolymarketMarket(slug)
+}
+
+// GetPolymarketMutedMarkets returns active market mutes, soonest to expire first
+func (a *App) GetPolymarketMutedMarkets() []domain.MarketMute {
+	return a.handlers.GetPolymarketMutedMarkets()
+}
+
+// === Polymarket Market Group Bindings ===
+
+// GetPolymarketMarketGroups returns multi-market events (grouped by event slug) with the
+// most fresh-wallet flow in the alert window first
+func (a *App) GetPolymarketMarketGroups(limit int) ([]domain.MarketGroup, error) {
+	return a.handlers.GetPolymarketMarketGroups(limit)
+}
+
+// GetPolymarketMarketGroup returns volume and fresh-wallet flow per sub-market of one event
+func (a *App) GetPolymarketMarketGroup(eventSlug string) (*domain.MarketGroup, error) {
+	return a.handlers.GetPolymarketMarketGroup(eventSlug)
+}
+
+// GetPolymarketPriceInconsistencies returns multi-market events whose YES prices have
+// summed outside the configured band (after fees) for several checks in a row
+func (a *App) GetPolymarketPriceInconsistencies() ([]domain.PriceConsistency, error) {
+	return a.handlers.GetPolymarketPriceInconsistencies()
+}
+
+// CheckPolymarketPriceConsistency re-prices the most traded events now instead of
+// waiting for the next 5-minute check, and returns the persistent inconsistencies
+func (a *App) CheckPolymarketPriceConsistency() ([]domain.PriceConsistency, error) {
+	return a.handlers.CheckPolymarketPriceConsistency()
+}
+
+// ResolvePolymarketMarkets checks traded markets for resolutions now instead of waiting
+// for the next background check, and returns how many were newly resolved
+func (a *App) ResolvePolymarketMarkets() (int, error) {
+	return a.handlers.ResolvePolymarketMarkets()
+}
+
+// === Polymarket Late Entry Bindings ===
+
+// SetPolymarketLateEntryRules replaces the rules that flag large positions opened within
+// a window before a market's scheduled end, optionally per market category
+func (a *App) SetPolymarketLateEntryRules(rules []domain.LateEntryRule) error {
+	return a.handlers.SetPolymarketLateEntryRules(rules)
+}
+
+// GetPolymarketLateEntryRules returns the late entry rules
+func (a *App) GetPolymarketLateEntryRules() []domain.LateEntryRule {
+	return a.handlers.GetPolymarketLateEntryRules()
+}
+
+// === Polymarket Market Entities Bindings ===
+
+// GetPolymarketMarketEntities returns the countries, people, companies and tickers a
+// market's title mentions
+func (a *App) GetPolymarketMarketEntities(slug string) ([]domain.MarketEntity, error) {
+	return a.handlers.GetPolymarketMarketEntities(slug)
+}
+
+// GetPolymarketEntityMarkets returns the markets whose titles mention an entity, given
+// by name, alias or ticker ("NVDA", "Venezuela")
+func (a *App) GetPolymarketEntityMarkets(entity string, limit int) ([]domain.EntityMarket, error) {
+	return a.handlers.GetPolymarketEntityMarkets(entity, limit)
+}
+
+// GetPolymarketEntityCounts returns the entities mentioned by the most stored markets
+func (a *App) GetPolymarketEntityCounts(limit int) ([]domain.MarketEntityCount, error) {
+	return a.handlers.GetPolymarketEntityCounts(limit)
+}
+
+// TagPolymarketStoredMarkets finds the entities of every market with stored events,
+// for markets saved before tagging existed
+func (a *App) TagPolymarketStoredMarkets() (*domain.MarketTaggingResult, error) {
+	return a.handlers.TagPolymarketStoredMarkets()
+}
+
+// GetPolymarketEntityFlow sums the trade flow into the markets mentioning each entity
+// between since and until (zero = the last 24 hours): volume, fresh-wallet and
+// smart-money flow, e.g. the suspicious flow across all Trump-related markets today
+func (a *App) GetPolymarketEntityFlow(since, until time.Time, limit int) (*domain.EntityFlowReport, error) {
+	return a.handlers.GetPolymarketEntityFlow(since, until, limit)
+}
+
+// GetPolymarketMarketSnapshots returns the stored snapshots of watched markets, newest
+// first; with a market, until and a limit of 1 it is the market's state as of that time,
+// e.g. at the end of a day
+func (a *App) GetPolymarketMarketSnapshots(filter domain.MarketSnapshotFilter) ([]domain.MarketSnapshot, error) {
+	return a.handlers.GetPolymarketMarketSnapshots(filter)
+}
+
+// CapturePolymarketMarketSnapshots snapshots the prices, volume, liquidity and top
+// holders of every watched market now, outside the schedule
+func (a *App) CapturePolymarketMarketSnapshots() (*domain.MarketSnapshotCapture, error) {
+	return a.handlers.CapturePolymarketMarketSnapshots()
+}
+
+// SetPolymarketEntityAlertRules replaces the rules that alert on trades in any market
+// mentioning an entity
+func (a *App) SetPolymarketEntityAlertRules(rules []domain.EntityAlertRule) error {
+	return a.handlers.SetPolymarketEntityAlertRules(rules)
+}
+
+// GetPolymarketEntityAlertRules returns the entity alert rules
+func (a *App) GetPolymarketEntityAlertRules() []domain.EntityAlertRule {
+	return a.handlers.GetPolymarketEntityAlertRules()
+}
@@ -0,0 +1,62 @@
+package main
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// === Polymarket Detector Bindings ===
+
+// GetPolymarketWashPairs returns the wallets, or pairs of wallets, seen making offsetting
+// trades in the same market within seconds, most matches first. flaggedOnly limits it
+// to pairs that matched often enough for their trades to be tagged "wash-trade".
+func (a *App) GetPolymarketWashPairs(flaggedOnly bool) ([]domain.WashPair, error) {
+	return a.handlers.GetPolymarketWashPairs(flaggedOnly)
+}
+
+// ClearPolymarketWashTrader unflags a wallet wrongly flagged for wash trading, e.g. a
+// market maker, and forgets the pairs it was seen in
+func (a *App) ClearPolymarketWashTrader(address string) error {
+	return a.handlers.ClearPolymarketWashTrader(address)
+}
+
+// GetPolymarketSpoofingSignals returns the order books recently flagged for spoofing or
+// layering: large orders near the touch repeatedly placed and pulled without trading
+func (a *App) GetPolymarketSpoofingSignals() ([]domain.SpoofingSignal, error) {
+	return a.handlers.GetPolymarketSpoofingSignals()
+}
+
+// GetPolymarketQuotes returns the latest best bid and ask of the given outcome tokens,
+// or of every token when none are given
+func (a *App) GetPolymarketQuotes(assetIDs []string) ([]domain.MarketQuote, error) {
+	return a.handlers.GetPolymarketQuotes(assetIDs)
+}
+
+// GetPolymarketSpreadSignals returns the order books whose spread recently jumped far
+// above its usual width, a sign liquidity was pulled
+func (a *App) GetPolymarketSpreadSignals() ([]domain.SpreadSignal, error) {
+	return a.handlers.GetPolymarketSpreadSignals()
+}
+
+// GetPolymarketWithdrawalWatches returns the fresh or investigated wallets that won a
+// market and are monitored on-chain for withdrawals until the window ends
+func (a *App) GetPolymarketWithdrawalWatches() ([]domain.WithdrawalWatch, error) {
+	return a.handlers.GetPolymarketWithdrawalWatches()
+}
+
+// CheckPolymarketWithdrawals scans the monitored wallets on-chain now instead of
+// waiting for the next 15-minute check, and returns the withdrawals found
+func (a *App) CheckPolymarketWithdrawals() ([]domain.WalletWithdrawal, error) {
+	return a.handlers.CheckPolymarketWithdrawals()
+}
+
+// === Polymarket Alert Outcome Bindings ===
+
+// GetPolymarketAlertOutcomes returns recent alerts with the outcome price recorded
+// 15 minutes, 1 hour and 24 hours after each
+func (a *App) GetPolymarketAlertOutcomes(limit int) ([]domain.AlertOutcome, error) {
+	return a.handlers.GetPolymarketAlertOutcomes(limit)
+}
+
+// GetPolymarketSignalQuality reports how often alerts of the last days (default 30)
+// were followed by a price move in the trade's direction
+func (a *App) GetPolymarketSignalQuality(days int) (*domain.SignalQuality, error) {
+	return a.handlers.GetPolymarketSignalQuality(days)
+}
@@ -0,0 +1,39 @@
+package main
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// === Polymarket Wallet Intel Bindings ===
+
+// ExportPolymarketWalletIntel writes the tags, freshness, win rates and flags of the
+// selected wallets (default: every tagged wallet) to a signed JSON file in the exports
+// folder, to share curated wallet lists without the database
+func (a *App) ExportPolymarketWalletIntel(req domain.WalletIntelExportRequest) (*domain.WalletIntelExport, error) {
+	return a.handlers.ExportPolymarketWalletIntel(req)
+}
+
+// ImportPolymarketWalletIntel imports a wallet intel file another user exported,
+// rejecting it if the signature doesn't match or its key isn't a trusted publisher
+func (a *App) ImportPolymarketWalletIntel(path string) (*domain.WalletIntelImportResult, error) {
+	return a.handlers.ImportPolymarketWalletIntel(path)
+}
+
+// GetPolymarketWalletIntel returns what imported wallet intel files say about a wallet
+func (a *App) GetPolymarketWalletIntel(address string) ([]domain.ImportedWalletIntel, error) {
+	return a.handlers.GetPolymarketWalletIntel(address)
+}
+
+// TrustPolymarketIntelPublisher pins the public key of a publisher whose wallet intel
+// files may be imported, e.g. as they shared it out of band
+func (a *App) TrustPolymarketIntelPublisher(publicKey, name string) error {
+	return a.handlers.TrustPolymarketIntelPublisher(publicKey, name)
+}
+
+// UntrustPolymarketIntelPublisher unpins a publisher's key; intel imported from it is kept
+func (a *App) UntrustPolymarketIntelPublisher(publicKey string) error {
+	return a.handlers.UntrustPolymarketIntelPublisher(publicKey)
+}
+
+// GetPolymarketTrustedIntelPublishers returns the trusted wallet intel publishers
+func (a *App) GetPolymarketTrustedIntelPublishers() ([]domain.TrustedIntelPublisher, error) {
+	return a.handlers.GetPolymarketTrustedIntelPublishers()
+}
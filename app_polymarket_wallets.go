@@ -0,0 +1,128 @@
+package main
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// === Polymarket Wallet Bindings ===
+
+// RefreshPolymarketWallets forces re-analysis of the selected wallets ahead of the background queue
+func (a *App) RefreshPolymarketWallets(addresses []string, priority domain.WalletRefreshPriority) (int, error) {
+	return a.handlers.RefreshPolymarketWallets(addresses, priority)
+}
+
+// RecomputePolymarketFreshness re-evaluates stored wallets against the current thresholds
+func (a *App) RecomputePolymarketFreshness() (*domain.FreshnessRecomputeResult, error) {
+	return a.handlers.RecomputePolymarketFreshness()
+}
+
+// AnalyzePolymarketThresholds reports how candidate thresholds would have performed on stored data
+func (a *App) AnalyzePolymarketThresholds(opts domain.ThresholdAnalysisOptions) (*domain.ThresholdAnalysis, error) {
+	return a.handlers.AnalyzePolymarketThresholds(opts)
+}
+
+// QueryPolymarketWallets returns a filtered, sorted page of wallets; pass NextCursor to fetch the next page
+func (a *App) QueryPolymarketWallets(filter domain.WalletFilter) (*domain.WalletPage, error) {
+	return a.handlers.QueryPolymarketWallets(filter)
+}
+
+// SearchPolymarketWallets finds wallets by partial address (including "0x12…ab34" abbreviations), trader name or tag
+func (a *App) SearchPolymarketWallets(query string, limit int) ([]domain.WalletSearchResult, error) {
+	return a.handlers.SearchPolymarketWallets(query, limit)
+}
+
+// GetPolymarketWalletStats returns bet count, join date and freshness distributions of seen wallets
+func (a *App) GetPolymarketWalletStats() (*domain.WalletStats, error) {
+	return a.handlers.GetPolymarketWalletStats()
+}
+
+// GetPolymarketWalletStreak returns a wallet's run of correct bets in resolved markets,
+// including whether it is on a hot hand longshot streak
+func (a *App) GetPolymarketWalletStreak(address string) (*domain.WalletStreak, error) {
+	return a.handlers.GetPolymarketWalletStreak(address)
+}
+
+// GetPolymarketWalletLifecycle returns a wallet's dossier: join date, first bet, latest
+// win and the USDC withdrawals seen after it, and whether the full pattern completed
+func (a *App) GetPolymarketWalletLifecycle(address string) (*domain.WalletLifecycle, error) {
+	return a.handlers.GetPolymarketWalletLifecycle(address)
+}
+
+// GetPolymarketWalletFunding returns the sender of a wallet's first USDC deposit and,
+// when it is a labeled exchange or bridge, its name and kind. Searches the chain when
+// the wallet wasn't looked up yet; nil when no deposit is in the lookback.
+func (a *App) GetPolymarketWalletFunding(address string) (*domain.FundingOrigin, error) {
+	return a.handlers.GetPolymarketWalletFunding(address)
+}
+
+// GetPolymarketWalletActivitySinceAlert summarizes a wallet's stored trades, volume and
+// newly entered markets since its last alert, or returns nil if it was never alerted
+func (a *App) GetPolymarketWalletActivitySinceAlert(address string) (*domain.WalletActivityDiff, error) {
+	return a.handlers.GetPolymarketWalletActivitySinceAlert(address)
+}
+
+// GetPolymarketWalletSizeProfile returns a wallet's typical trade size, which size outlier
+// signals compare new trades against, next to the norm over every wallet
+func (a *App) GetPolymarketWalletSizeProfile(address string) (*domain.WalletSizeProfile, error) {
+	return a.handlers.GetPolymarketWalletSizeProfile(address)
+}
+
+// GetPolymarketWalletCategories returns the market categories of a wallet's stored
+// trades, which category novelty signals compare new trades against
+func (a *App) GetPolymarketWalletCategories(address string) ([]domain.WalletCategoryStats, error) {
+	return a.handlers.GetPolymarketWalletCategories(address)
+}
+
+// GetPolymarketWalletActiveHours returns how many of a wallet's trades fell in each UTC
+// hour, which unusual hour signals compare new trades against
+func (a *App) GetPolymarketWalletActiveHours(address string) (*domain.WalletActiveHours, error) {
+	return a.handlers.GetPolymarketWalletActiveHours(address)
+}
+
+// === Polymarket Wallet Tag, Watchlist and Blacklist Bindings ===
+
+// TagPolymarketWallet attaches a label to a wallet, e.g. "insider" or "market-maker"
+func (a *App) TagPolymarketWallet(address, tag string) error {
+	return a.handlers.TagPolymarketWallet(address, tag)
+}
+
+// UntagPolymarketWallet removes a label from a wallet
+func (a *App) UntagPolymarketWallet(address, tag string) error {
+	return a.handlers.UntagPolymarketWallet(address, tag)
+}
+
+// GetPolymarketWalletTags returns the tags of the given wallets by lowercased address,
+// or of every tagged wallet when none are given
+func (a *App) GetPolymarketWalletTags(addresses []string) (map[string][]string, error) {
+	return a.handlers.GetPolymarketWalletTags(addresses)
+}
+
+// WatchPolymarketWallet adds a wallet to the watchlist: it is refreshed on every analysis
+// cycle whatever its bet count, and each of its trades is alerted
+func (a *App) WatchPolymarketWallet(address string) error {
+	return a.handlers.WatchPolymarketWallet(address)
+}
+
+// UnwatchPolymarketWallet removes a wallet from the watchlist
+func (a *App) UnwatchPolymarketWallet(address string) error {
+	return a.handlers.UnwatchPolymarketWallet(address)
+}
+
+// GetPolymarketWatchedWallets returns the wallets on the watchlist
+func (a *App) GetPolymarketWatchedWallets() ([]string, error) {
+	return a.handlers.GetPolymarketWatchedWallets()
+}
+
+// BlacklistPolymarketWallet leaves a wallet out of analysis and alerts, e.g. a known
+// market maker or bot tripping the fresh-wallet logic; its trades are still recorded
+func (a *App) BlacklistPolymarketWallet(address, reason string) error {
+	return a.handlers.BlacklistPolymarketWallet(address, reason)
+}
+
+// UnblacklistPolymarketWallet removes a wallet from the blacklist
+func (a *App) UnblacklistPolymarketWallet(address string) error {
+	return a.handlers.UnblacklistPolymarketWallet(address)
+}
+
+// GetPolymarketWalletBlacklist returns the blacklisted wallets, most recently added first
+func (a *App) GetPolymarketWalletBlacklist() ([]domain.BlacklistedWallet, error) {
+	return a.handlers.GetPolymarketWalletBlacklist()
+}
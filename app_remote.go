@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/remote"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// connectRemoteBackend loads the remote backend settings and, when remote mode is
+// enabled, returns a client for the daemon and prepares the event stream follower.
+// Returns nil to run the watcher locally.
+func (a *App) connectRemoteBackend(dataDir string) *remote.Client {
+	config, err := storage.LoadRemoteBackendConfig(dataDir)
+	if err != nil {
+		println("Failed to load remote backend config:", err.Error())
+	}
+	a.remoteConfig = config
+	if !config.Enabled {
+		return nil
+	}
+	client, err := remote.NewClient(config)
+	if err != nil {
+		println("Remote backend disabled:", err.Error())
+		return nil
+	}
+	a.remoteFollower = remote.NewFollower(client, func(eventName string, data json.RawMessage) {
+		a.eventBus.Emit(eventName, data)
+	})
+	return client
+}
+
+// === Remote Backend Bindings ===
+
+// GetRemoteBackendConfig returns the saved remote backend settings
+func (a *App) GetRemoteBackendConfig() domain.RemoteBackendConfig {
+	return a.remoteConfig
+}
+
+// SetRemoteBackendConfig saves the remote backend settings. They take effect on the next start.
+func (a *App) SetRemoteBackendConfig(config domain.RemoteBackendConfig) error {
+	if config.Enabled {
+		if _, err := remote.NewClient(config); err != nil {
+			return err
+		}
+	}
+	if err := storage.SaveRemoteBackendConfig(getDataDir(), config); err != nil {
+		return fmt.Errorf("failed to save remote backend config: %w", err)
+	}
+	a.remoteConfig = config
+	return nil
+}
+
+// TestRemoteBackend checks that a daemon answers at the given settings and returns its watcher status
+func (a *App) TestRemoteBackend(config domain.RemoteBackendConfig) (*domain.PolymarketWatcherStatus, error) {
+	client, err := remote.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	status, err := client.Status()
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetRemoteBackendStatus reports whether remote mode is active and its event stream is connected
+func (a *App) GetRemoteBackendStatus() domain.RemoteBackendStatus {
+	if a.remoteFollower == nil {
+		return domain.RemoteBackendStatus{}
+	}
+	return a.remoteFollower.Status()
+}
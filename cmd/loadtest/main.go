@@ -0,0 +1,244 @@
+// Command loadtest pushes synthetic events through the Polymarket pipeline at fixed
+// rates and reports throughput, ingest latency and database write saturation.
+//
+//	go run ./cmd/loadtest -rates 1000,5000,10000 -duration 10s
+//
+// Synthetic trades carry no market slug, so no Gamma API lookups are made and the
+// numbers reflect the local pipeline (filters, detectors, SQLite writes) only.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/scripting"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/services"
+)
+
+// pacingInterval is how often a batch of events is generated
+const pacingInterval = 10 * time.Millisecond
+
+// countingBus is an EventBus that only counts emitted events
+type countingBus struct {
+	emitted atomic.Int64
+}
+
+func (b *countingBus) Emit(event string, _ interface{}) {
+	if event == "polymarket:event" {
+		b.emitted.Add(1)
+	}
+}
+func (b *countingBus) EmitTo(string, string, interface{})          {}
+func (b *countingBus) Subscribe(string, ports.EventHandler) func() { return func() {} }
+func (b *countingBus) Unsubscribe(string, ports.EventHandler)      {}
+
+// result is the outcome of one run at a target rate
+type result struct {
+	target     int
+	ingested   int
+	elapsed    time.Duration
+	drain      time.Duration
+	latencies  []time.Duration
+	emitted    int64
+	stored     int64
+	peakQueue  int64
+	heapAllocs uint64
+}
+
+func main() {
+	rates := flag.String("rates", "1000,2500,5000,10000", "comma-separated target rates in events/s")
+	duration := flag.Duration("duration", 10*time.Second, "duration of each run")
+	dbPath := flag.String("db", "", "SQLite database path (default: a temporary file)")
+	memory := flag.Bool("memory", false, "use the in-memory store instead of SQLite")
+	wallets := flag.Int("wallets", 5000, "number of distinct synthetic wallets")
+	bookShare := flag.Float64("book-share", 0, "share of book/price_change updates among events (0-1)")
+	detectors := flag.String("detectors", "", "directory of detector scripts (*.star) to load")
+	verbose := flag.Bool("v", false, "keep service logs")
+	flag.Parse()
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	targets, err := parseRates(*rates)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var store ports.PolymarketStore
+	if *memory {
+		store = storage.NewMemoryPolymarketStore()
+	} else {
+		if *dbPath == "" {
+			dir, err := os.MkdirTemp("", "xtools-loadtest")
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			defer os.RemoveAll(dir)
+			*dbPath = filepath.Join(dir, "polymarket.db")
+		}
+		if store, err = storage.NewPolymarketStore(*dbPath); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	defer store.Close()
+
+	bus := &countingBus{}
+	svc := services.NewPolymarketService(store, bus, *dbPath)
+	if *detectors != "" {
+		for _, detector := range scripting.LoadDetectors(*detectors) {
+			svc.RegisterDetector(detector)
+		}
+	}
+
+	gen := &generator{rng: rand.New(rand.NewSource(1)), wallets: *wallets, bookShare: *bookShare}
+	fmt.Printf("%8s %9s %9s %9s %9s %9s %9s %10s %9s %8s\n",
+		"target/s", "ingest/s", "p50", "p95", "p99", "written/s", "lost", "peakQueue", "drain", "heapMB")
+	for _, target := range targets {
+		r := run(svc, store, bus, gen, target, *duration)
+		printResult(r)
+	}
+}
+
+// run ingests events at the target rate for the duration, then waits for pending writes
+func run(svc *services.PolymarketService, store ports.PolymarketStore, bus *countingBus, gen *generator, target int, duration time.Duration) result {
+	r := result{target: target, latencies: make([]time.Duration, 0, int(float64(target)*duration.Seconds()))}
+	before := eventCount(store)
+	emittedBefore := bus.emitted.Load()
+
+	ticker := time.NewTicker(pacingInterval)
+	defer ticker.Stop()
+	start := time.Now()
+	for now := range ticker.C {
+		elapsed := now.Sub(start)
+		if elapsed >= duration {
+			break
+		}
+		// Catch up on events owed since start, so slow batches don't lower the target
+		due := float64(target) * elapsed.Seconds()
+		for float64(r.ingested) < due {
+			event := gen.next()
+			t := time.Now()
+			svc.Ingest(event)
+			r.latencies = append(r.latencies, time.Since(t))
+			r.ingested++
+		}
+		if q := svc.GetSystemStatus().WriteQueueDepth; q > r.peakQueue {
+			r.peakQueue = q
+		}
+	}
+	r.elapsed = time.Since(start)
+
+	drainStart := time.Now()
+	for svc.GetSystemStatus().WriteQueueDepth > 0 && time.Since(drainStart) < time.Minute {
+		time.Sleep(5 * time.Millisecond)
+	}
+	r.drain = time.Since(drainStart)
+
+	status := svc.GetSystemStatus()
+	r.heapAllocs = status.HeapAllocBytes
+	r.emitted = bus.emitted.Load() - emittedBefore
+	r.stored = eventCount(store) - before
+	return r
+}
+
+func printResult(r result) {
+	sort.Slice(r.latencies, func(i, j int) bool { return r.latencies[i] < r.latencies[j] })
+	total := (r.elapsed + r.drain).Seconds()
+	fmt.Printf("%8d %9.0f %9s %9s %9s %9.0f %9d %10d %9s %8.1f\n",
+		r.target,
+		float64(r.ingested)/r.elapsed.Seconds(),
+		percentile(r.latencies, 0.50),
+		percentile(r.latencies, 0.95),
+		percentile(r.latencies, 0.99),
+		float64(r.stored)/total,
+		r.emitted-r.stored,
+		r.peakQueue,
+		r.drain.Round(time.Millisecond),
+		float64(r.heapAllocs)/(1<<20),
+	)
+}
+
+// generator produces synthetic trades and, optionally, order book updates
+type generator struct {
+	rng       *rand.Rand
+	wallets   int
+	bookShare float64
+	seq       int
+}
+
+func (g *generator) next() domain.PolymarketEvent {
+	g.seq++
+	asset := fmt.Sprintf("asset-%d", g.rng.Intn(200))
+	price := 0.01 + g.rng.Float64()*0.98
+	event := domain.PolymarketEvent{
+		AssetID:   asset,
+		Timestamp: time.Now(),
+		Price:     strconv.FormatFloat(price, 'f', 4, 64),
+	}
+
+	if g.rng.Float64() < g.bookShare {
+		event.EventType = domain.PolymarketEventPriceChange
+		if g.seq%2 == 0 {
+			event.EventType = domain.PolymarketEventBook
+		}
+		event.Size = strconv.Itoa(1000 + g.rng.Intn(50000))
+		return event
+	}
+
+	notional := 100 + g.rng.ExpFloat64()*2000
+	event.EventType = domain.PolymarketEventTrade
+	event.TradeID = fmt.Sprintf("0xloadtest%d", g.seq)
+	event.WalletAddress = fmt.Sprintf("0x%040x", g.rng.Intn(g.wallets)+1)
+	event.Side = domain.OrderSideBuy
+	if g.rng.Intn(2) == 0 {
+		event.Side = domain.OrderSideSell
+	}
+	event.Size = strconv.FormatFloat(notional/price, 'f', 2, 64)
+	event.Outcome = "Yes"
+	event.MarketName = "Load test market " + asset
+	return event
+}
+
+func eventCount(store ports.PolymarketStore) int64 {
+	info, err := store.GetDatabaseInfo()
+	if err != nil {
+		return 0
+	}
+	return info.EventCount
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[int(float64(len(sorted)-1)*p)].Round(time.Microsecond)
+}
+
+func parseRates(s string) ([]int, error) {
+	var rates []int
+	for _, part := range strings.Split(s, ",") {
+		rate, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate: %q", part)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
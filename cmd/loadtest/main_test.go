@@ -0,0 +1,89 @@
+package main
+
+import (
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/services"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// newBenchPipeline returns a service on a SQLite store in a temporary directory, or on
+// the in-memory store
+func newBenchPipeline(b *testing.B, memory bool) (*services.PolymarketService, ports.PolymarketStore) {
+	b.Helper()
+	var store ports.PolymarketStore = storage.NewMemoryPolymarketStore()
+	dbPath := ""
+	if !memory {
+		dbPath = filepath.Join(b.TempDir(), "polymarket.db")
+		sqlite, err := storage.NewPolymarketStore(dbPath)
+		if err != nil {
+			b.Fatal(err)
+		}
+		store = sqlite
+	}
+	svc := services.NewPolymarketService(store, &countingBus{}, dbPath)
+	b.Cleanup(svc.Close)
+	return svc, store
+}
+
+// BenchmarkPipeline measures Ingest, the time the feed's read loop spends per event;
+// database writes happen behind the write queue
+func BenchmarkPipeline(b *testing.B) {
+	for _, bc := range []struct {
+		name      string
+		memory    bool
+		bookShare float64
+	}{
+		{"memory", true, 0},
+		{"sqlite", false, 0},
+		{"sqlite-books", false, 0.8},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			svc, _ := newBenchPipeline(b, bc.memory)
+			gen := &generator{rng: rand.New(rand.NewSource(1)), wallets: 5000, bookShare: bc.bookShare}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				svc.Ingest(gen.next())
+			}
+		})
+	}
+}
+
+// BenchmarkWriteQueue measures events written to SQLite per second: each iteration
+// ingests a burst and waits for the write queue to drain
+func BenchmarkWriteQueue(b *testing.B) {
+	const burst = 1000
+	svc, store := newBenchPipeline(b, false)
+	gen := &generator{rng: rand.New(rand.NewSource(1)), wallets: 5000}
+	before := eventCount(store)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < burst; j++ {
+			svc.Ingest(gen.next())
+		}
+		for svc.GetSystemStatus().WriteQueueDepth > 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	b.StopTimer()
+
+	written := eventCount(store) - before
+	b.ReportMetric(float64(written)/b.Elapsed().Seconds(), "writes/s")
+	if peak := svc.GetSystemStatus().WriteQueueDepth; peak > 0 {
+		b.Errorf("write queue holds %d events after draining", peak)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/httpapi"
+)
+
+// checkExposure refuses to serve the API without a token on an address other hosts can
+// reach: without tokens every route is open and X-XTools-User picks any user.
+// XTOOLS_ALLOW_UNAUTHENTICATED=true overrides it, e.g. behind an authenticating proxy.
+func checkExposure(listen, token string, userTokens map[string]string) error {
+	if token != "" || len(userTokens) > 0 || loopbackAddr(listen) {
+		return nil
+	}
+	if os.Getenv("XTOOLS_ALLOW_UNAUTHENTICATED") != "true" {
+		return fmt.Errorf("refusing to serve the API on %s without XTOOLS_API_TOKEN or XTOOLS_API_USERS; "+
+			"set a token, listen on 127.0.0.1, or set XTOOLS_ALLOW_UNAUTHENTICATED=true", listen)
+	}
+	log.Printf("[Daemon] WARNING: the API on %s is open to anyone who can reach it: no XTOOLS_API_TOKEN is set "+
+		"and XTOOLS_ALLOW_UNAUTHENTICATED=true", listen)
+	return nil
+}
+
+// loopbackAddr reports whether a listen address only accepts local connections
+func loopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// publicSnapshotRate reads XTOOLS_PUBLIC_SNAPSHOT: "true" enables the public snapshot
+// at the default rate, a number sets the requests per minute per client
+func publicSnapshotRate(value string) (int, bool) {
+	switch value = strings.TrimSpace(value); value {
+	case "", "false", "0":
+		return 0, false
+	case "true":
+		return 30, true
+	}
+	rate, err := strconv.Atoi(value)
+	if err != nil || rate <= 0 {
+		log.Printf("[Daemon] Ignoring invalid XTOOLS_PUBLIC_SNAPSHOT %q: use true or requests per minute", value)
+		return 0, false
+	}
+	return rate, true
+}
+
+// parseUserTokens parses "user=token" pairs separated by commas into token -> user
+func parseUserTokens(value string) (map[string]string, error) {
+	tokens := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		user, token, ok := strings.Cut(pair, "=")
+		user, token = strings.TrimSpace(user), strings.TrimSpace(token)
+		if !ok || user == "" || token == "" {
+			return nil, fmt.Errorf("invalid XTOOLS_API_USERS entry %q: use user=token", pair)
+		}
+		if _, taken := tokens[token]; taken {
+			return nil, fmt.Errorf("XTOOLS_API_USERS: users must not share a token")
+		}
+		tokens[token] = user
+	}
+	return tokens, nil
+}
+
+// apiLimits reads the per-token API limits; unset variables keep the defaults
+func apiLimits() (httpapi.Limits, error) {
+	var limits httpapi.Limits
+	values := []struct {
+		name   string
+		target *int
+	}{
+		{"XTOOLS_API_RATE_LIMIT", &limits.RequestsPerMinute},
+		{"XTOOLS_API_MAX_CONCURRENT", &limits.MaxConcurrent},
+		{"XTOOLS_API_MAX_RESULTS", &limits.MaxResults},
+	}
+	for _, v := range values {
+		value := strings.TrimSpace(os.Getenv(v.name))
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return limits, fmt.Errorf("invalid %s %q: use a positive number", v.name, value)
+		}
+		*v.target = n
+	}
+	if value := strings.TrimSpace(os.Getenv("XTOOLS_API_MAX_BODY_BYTES")); value != "" {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n <= 0 {
+			return limits, fmt.Errorf("invalid XTOOLS_API_MAX_BODY_BYTES %q: use a positive number", value)
+		}
+		limits.MaxBodyBytes = n
+	}
+	return limits, nil
+}
+
+// apiCacheTTL reads how long expensive API reads are cached; unset keeps the default
+func apiCacheTTL() (time.Duration, error) {
+	value := strings.TrimSpace(os.Getenv("XTOOLS_API_CACHE_TTL"))
+	if value == "" {
+		return 5 * time.Second, nil
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("invalid XTOOLS_API_CACHE_TTL %q: use seconds, 0 to disable", value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// envOr returns the environment variable, or fallback when it is unset
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// defaultDataDir is the desktop app's data directory, so both can share a setup
+func defaultDataDir() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "./data"
+	}
+	return filepath.Join(configDir, "XTools")
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/httpapi"
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/scripting"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/services"
+)
+
+// streamedEvents are the event bus events forwarded on /api/stream
+var streamedEvents = []string{
+	"polymarket:event",
+	"polymarket:detector_signal",
+	"polymarket:fresh_wallet_detected",
+	"polymarket:wallet_updated",
+	"polymarket:hot_hand_detected",
+	"polymarket:alert_outcome_updated",
+	"polymarket:backpressure",
+	"polymarket:backpressure_cleared",
+	"polymarket:db_integrity_failed",
+	"notification:alert_ack",
+	"errors",
+}
+
+// run opens the database, wires the watcher, notifications and API server together and
+// serves until interrupted
+func run(dataDir, listen string, watch bool) error {
+	userTokens, err := parseUserTokens(os.Getenv("XTOOLS_API_USERS"))
+	if err != nil {
+		return err
+	}
+	apiToken := os.Getenv("XTOOLS_API_TOKEN")
+	if err := checkExposure(listen, apiToken, userTokens); err != nil {
+		return err
+	}
+	limits, err := apiLimits()
+	if err != nil {
+		return err
+	}
+	cacheTTL, err := apiCacheTTL()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	dbPath := filepath.Join(dataDir, "xtools.db")
+
+	store, encryption, err := openStore(dataDir, dbPath)
+	if err != nil {
+		return err
+	}
+
+	bus := localbus.New()
+	stream := httpapi.NewStream(streamedEvents)
+	bus.Listen(stream.Publish)
+
+	polymarketSvc := services.NewPolymarketService(store, bus, dbPath)
+	polymarketSvc.SetBackupEncryption(encryption)
+	notificationSvc := services.NewNotificationService(store, bus)
+	notificationSvc.SetErrorReporter(polymarketSvc.ErrorReporter())
+	notificationSvc.SetWalletBlacklist(polymarketSvc)
+	notificationSvc.SetTransformer(scripting.NewAlertTransformer(filepath.Join(dataDir, "alerts.star")))
+	for _, detector := range scripting.LoadDetectors(filepath.Join(dataDir, "detectors")) {
+		polymarketSvc.RegisterDetector(detector)
+	}
+	notificationSvc.Start()
+
+	if watch {
+		if err := polymarketSvc.Start(); err != nil {
+			log.Printf("[Daemon] Failed to start watcher: %v", err)
+		}
+	}
+
+	server := httpapi.NewServer(listen, apiToken, polymarketSvc, stream)
+	server.SetUserTokens(userTokens)
+	server.SetLimits(limits)
+	server.SetCacheTTL(cacheTTL)
+	server.SetAlerts(notificationSvc)
+	server.SetSettings(polymarketSvc)
+	server.SetImages(polymarketSvc.MarketImages())
+	bus.Listen(server.Invalidate)
+	if rate, ok := publicSnapshotRate(os.Getenv("XTOOLS_PUBLIC_SNAPSHOT")); ok {
+		server.EnablePublicSnapshot(rate)
+		log.Printf("[Daemon] Serving the public snapshot at /public/snapshot (%d requests/min per client)", rate)
+	}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.ListenAndServe() }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case <-ctx.Done():
+		log.Printf("[Daemon] Shutting down")
+	case err = <-serveErr:
+		log.Printf("[Daemon] API server stopped: %v", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	server.Shutdown(shutdownCtx)
+	notificationSvc.Stop()
+	polymarketSvc.Close()
+
+	if encryption != nil {
+		if sealErr := encryption.Seal(); sealErr != nil {
+			log.Printf("[Daemon] Failed to encrypt database: %v", sealErr)
+		}
+	}
+	return err
+}
+
+// openStore decrypts the database if it is encrypted at rest, rebuilds it if it is
+// damaged and opens it. The returned encryption is nil when the database is plaintext.
+func openStore(dataDir, dbPath string) (ports.PolymarketStore, *storage.EncryptedDatabase, error) {
+	// Decrypt the database before it is opened, and encrypt it again on exit
+	var encryption *storage.EncryptedDatabase
+	dbKey, source := storage.LoadDatabaseKey()
+	if dbKey != "" {
+		encryption = storage.NewEncryptedDatabase(dbPath, dbKey)
+		if err := encryption.Unseal(); err != nil {
+			return nil, nil, fmt.Errorf("failed to decrypt database: %w", err)
+		}
+		log.Printf("[Daemon] Database decrypted (key from %s)", source)
+	}
+
+	if recovery, err := storage.RecoverDatabase(dbPath); err != nil {
+		log.Printf("[Daemon] Failed to recover database: %v", err)
+	} else if recovery != nil {
+		log.Printf("[Daemon] WARNING: damaged database rebuilt (%s), %d rows recovered, damaged copy at %s",
+			recovery.Reason, recovery.RowsRecovered, recovery.BackupPath)
+		if encryption != nil {
+			if err := encryption.SealBackup(recovery.BackupPath); err != nil {
+				log.Printf("[Daemon] Failed to encrypt damaged database backup: %v", err)
+			}
+		}
+	}
+
+	storeOpts := storage.PolymarketStoreOptions{SettingsKey: dbKey, AnalysisDBPath: storage.LoadAnalysisDBPath(dataDir)}
+	// Only xtools.db is encrypted at rest, so a split-off analysis file would stay plaintext
+	if storeOpts.AnalysisDBPath != "" && encryption != nil {
+		log.Printf("[Daemon] XTOOLS_ANALYSIS_DB is ignored while the database is encrypted at rest")
+		storeOpts.AnalysisDBPath = ""
+	}
+	store, err := storage.NewPolymarketStoreWithOptions(dbPath, storeOpts)
+	if err != nil {
+		// A daemon silently running on an empty in-memory store would lose every trade
+		// on restart; let the supervisor see the failure instead
+		if encryption != nil {
+			if sealErr := encryption.Seal(); sealErr != nil {
+				log.Printf("[Daemon] Failed to encrypt database: %v", sealErr)
+			}
+		}
+		return nil, nil, fmt.Errorf("failed to open database %s: %w", dbPath, err)
+	}
+	return store, encryption, nil
+}
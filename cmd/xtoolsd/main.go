@@ -0,0 +1,72 @@
+// Command xtoolsd runs the Polymarket watcher headless, without the desktop GUI, and
+// serves its REST API, a server-sent event stream and Prometheus metrics. It is meant
+// to run 24/7 on a server:
+//
+//	XTOOLS_API_TOKEN=secret xtoolsd -data /var/lib/xtools -listen :8787
+//	xtoolsd -systemd-unit > /etc/systemd/system/xtoolsd.service
+//
+// The listen address can also be set with XTOOLS_LISTEN. Set XTOOLS_API_TOKEN to
+// require "Authorization: Bearer <token>" on every route but /healthz, and
+// XTOOLS_API_USERS (e.g. "alice=token1,bob=token2") to give several people their own
+// token and settings (/api/me/settings). Without either, the daemon only listens on
+// loopback addresses. XTOOLS_PUBLIC_SNAPSHOT=true (or a number of
+// requests per minute per client) serves anonymized aggregate flow at /public/snapshot
+// without a token. Requests are limited per token, see XTOOLS_API_RATE_LIMIT and
+// friends in the README. Alerts go out through the notification settings stored in the database.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	dataDir := flag.String("data", defaultDataDir(), "data directory holding xtools.db, detectors/ and alerts.star")
+	listen := flag.String("listen", envOr("XTOOLS_LISTEN", "127.0.0.1:8787"), "address of the REST API and /metrics")
+	watch := flag.Bool("watch", true, "start watching the trade feed on startup")
+	unit := flag.Bool("systemd-unit", false, "print a systemd unit for this binary and flags, then exit")
+	health := flag.Bool("health", false, "check /healthz of a running daemon and exit non-zero if it is down")
+	flag.Parse()
+
+	if *unit {
+		fmt.Print(systemdUnit(*dataDir, *listen, *watch))
+		return
+	}
+	if *health {
+		if err := checkHealth(*listen); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(*dataDir, *listen, *watch); err != nil {
+		log.Fatalf("[Daemon] %v", err)
+	}
+}
+
+// checkHealth calls /healthz of a daemon listening on addr
+func checkHealth(addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://" + net.JoinHostPort(host, port) + "/healthz")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("healthz returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestCheckExposure(t *testing.T) {
+	t.Setenv("XTOOLS_ALLOW_UNAUTHENTICATED", "")
+	tests := []struct {
+		listen  string
+		token   string
+		users   map[string]string
+		wantErr bool
+	}{
+		{listen: "127.0.0.1:8787"},
+		{listen: "localhost:8787"},
+		{listen: "[::1]:8787"},
+		{listen: "0.0.0.0:8787", wantErr: true},
+		{listen: ":8787", wantErr: true},
+		{listen: "192.168.1.10:8787", wantErr: true},
+		{listen: "0.0.0.0:8787", token: "secret"},
+		{listen: ":8787", users: map[string]string{"token1": "alice"}},
+	}
+	for _, tt := range tests {
+		err := checkExposure(tt.listen, tt.token, tt.users)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkExposure(%q, token %q, %d users) = %v, want error %v", tt.listen, tt.token, len(tt.users), err, tt.wantErr)
+		}
+	}
+
+	t.Setenv("XTOOLS_ALLOW_UNAUTHENTICATED", "true")
+	if err := checkExposure("0.0.0.0:8787", "", nil); err != nil {
+		t.Errorf("with XTOOLS_ALLOW_UNAUTHENTICATED: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// systemdUnit renders a service unit running this binary with the given flags. The
+// API token is read from an optional environment file rather than written into the unit.
+func systemdUnit(dataDir, listen string, watch bool) string {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "/usr/local/bin/xtoolsd"
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if abs, err := filepath.Abs(dataDir); err == nil {
+		dataDir = abs
+	}
+	username := "xtools"
+	if u, err := user.Current(); err == nil && u.Username != "root" {
+		username = u.Username
+	}
+
+	args := []string{exe, "-data", quote(dataDir), "-listen", listen}
+	if !watch {
+		args = append(args, "-watch=false")
+	}
+
+	return fmt.Sprintf(`[Unit]
+Description=XTools Polymarket watcher
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+Type=simple
+User=%s
+# XTOOLS_API_TOKEN=... and optionally XTOOLS_DB_KEY=...
+EnvironmentFile=-/etc/xtools/xtoolsd.env
+ExecStart=%s
+Restart=always
+RestartSec=5
+NoNewPrivileges=true
+ProtectSystem=strict
+ProtectHome=read-only
+ReadWritePaths=%s
+PrivateTmp=true
+
+[Install]
+WantedBy=multi-user.target
+`, username, strings.Join(args, " "), quote(dataDir))
+}
+
+// quote wraps a path containing spaces in double quotes, as systemd expects
+func quote(path string) string {
+	if strings.ContainsAny(path, " \t") {
+		return `"` + path + `"`
+	}
+	return path
+}
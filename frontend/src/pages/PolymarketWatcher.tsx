@@ -474,6 +474,13 @@ export default function PolymarketWatcher() {
                     </div>
                 )}
 
+                {status.storeError && (
+                    <div className="flex items-center gap-2 text-destructive">
+                        <AlertTriangle size={16} />
+                        <span className="text-sm font-medium">{status.storeError}</span>
+                    </div>
+                )}
+
                 <div className="ml-auto flex items-center gap-2">
                     <label className="flex items-center gap-2 text-sm cursor-pointer">
                         <input
@@ -287,6 +287,7 @@ export interface PolymarketWatcherStatus {
     errorMessage?: string;
     reconnectCount?: number;
     webSocketEndpoint?: string;
+    storeError?: string;
 }
 
 export interface DatabaseInfo {
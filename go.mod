@@ -1,4 +1,4 @@
-module xtools
+module github.com/luthebao/poly-xtools
 
 go 1.24.0
 
@@ -10,6 +10,7 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.33
 	github.com/wailsapp/wails/v2 v2.11.0
 	github.com/xuri/excelize/v2 v2.10.0
+	go.starlark.net v0.0.0-20250417143717-f57e51f710eb
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -5,8 +5,8 @@ import (
 	"sync"
 	"time"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 const maxLogsPerAccount = 500
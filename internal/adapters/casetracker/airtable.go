@@ -0,0 +1,98 @@
+package casetracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	airtableBaseURL = "https://api.airtable.com/v0/"
+	airtableBatch   = 10 // Records per request allowed by the Airtable API
+)
+
+// AirtableClient upserts records into tables of an Airtable base
+type AirtableClient struct {
+	httpClient *http.Client
+	token      string
+	baseID     string
+}
+
+// NewAirtableClient creates a client for a base using a personal access token
+func NewAirtableClient(token, baseID string) *AirtableClient {
+	return &AirtableClient{
+		httpClient: &http.Client{Timeout: 20 * time.Second},
+		token:      token,
+		baseID:     baseID,
+	}
+}
+
+// Upsert merges records on keyProperty. Values are typecast by Airtable, so select
+// options are created as needed; lists are joined into one comma separated value.
+func (c *AirtableClient) Upsert(ctx context.Context, table, keyProperty string, records []map[string]any) error {
+	for start := 0; start < len(records); start += airtableBatch {
+		end := min(start+airtableBatch, len(records))
+		batch := make([]map[string]any, 0, end-start)
+		for _, record := range records[start:end] {
+			fields := make(map[string]any, len(record))
+			for name, value := range record {
+				fields[name] = airtableValue(value)
+			}
+			batch = append(batch, map[string]any{"fields": fields})
+		}
+		body := map[string]any{
+			"performUpsert": map[string]any{"fieldsToMergeOn": []string{keyProperty}},
+			"records":       batch,
+			"typecast":      true,
+		}
+		if err := c.do(ctx, "PATCH", airtableBaseURL+url.PathEscape(c.baseID)+"/"+url.PathEscape(table), body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *AirtableClient) do(ctx context.Context, method, endpoint string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal airtable request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create airtable request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("airtable request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("airtable returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// airtableValue converts a record value to what Airtable accepts for any field type
+func airtableValue(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		return v.UTC().Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ", ")
+	}
+	return value
+}
@@ -0,0 +1,69 @@
+package casetracker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAirtableUpsertBatchesRecords(t *testing.T) {
+	type request struct {
+		PerformUpsert struct {
+			FieldsToMergeOn []string `json:"fieldsToMergeOn"`
+		} `json:"performUpsert"`
+		Records []struct {
+			Fields map[string]any `json:"fields"`
+		} `json:"records"`
+		Typecast bool `json:"typecast"`
+	}
+	var requests []request
+	client := NewAirtableClient("pat", "app123")
+	client.httpClient = serve(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.EscapedPath() != "/v0/app123/Flagged%20trades" || r.Header.Get("Authorization") != "Bearer pat" {
+			t.Errorf("%s %s, want PATCH of the table with the token", r.Method, r.URL.EscapedPath())
+		}
+		var req request
+		json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		w.Write([]byte(`{"records": []}`))
+	})
+
+	records := make([]map[string]any, 23)
+	for i := range records {
+		records[i] = map[string]any{"Trade": i, "Tags": []string{"insider", "whale"}, "Seen": time.Time{}}
+	}
+	records[0]["Seen"] = time.Date(2026, 2, 3, 4, 5, 6, 0, time.FixedZone("CET", 3600))
+	if err := client.Upsert(context.Background(), "Flagged trades", "Trade", records); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 3 || len(requests[0].Records) != 10 || len(requests[2].Records) != 3 {
+		t.Fatalf("sent %d requests, want batches of 10, 10 and 3", len(requests))
+	}
+	first := requests[0]
+	if !first.Typecast || len(first.PerformUpsert.FieldsToMergeOn) != 1 || first.PerformUpsert.FieldsToMergeOn[0] != "Trade" {
+		t.Errorf("request = %+v, want a typecast upsert merged on Trade", first)
+	}
+	fields := first.Records[0].Fields
+	if fields["Tags"] != "insider, whale" || fields["Seen"] != "2026-02-03T03:05:06Z" {
+		t.Errorf("fields = %v, want joined tags and a UTC time", fields)
+	}
+	if seen, ok := first.Records[1].Fields["Seen"]; !ok || seen != nil {
+		t.Errorf("zero time = %v, want it cleared", seen)
+	}
+}
+
+func TestAirtableReportsErrors(t *testing.T) {
+	client := NewAirtableClient("pat", "app123")
+	client.httpClient = serve(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error": {"type": "UNKNOWN_FIELD_NAME"}}`))
+	})
+	err := client.Upsert(context.Background(), "Trades", "Trade", []map[string]any{{"Trade": 1}})
+	if err == nil || !strings.Contains(err.Error(), "422") || !strings.Contains(err.Error(), "UNKNOWN_FIELD_NAME") {
+		t.Errorf("Upsert() = %v, want the status and Airtable's message", err)
+	}
+}
@@ -0,0 +1,25 @@
+package casetracker
+
+import (
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+// New creates the case tracker of the configured provider
+func New(config domain.CaseSyncConfig) (ports.CaseTracker, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("%s token is required", config.Provider)
+	}
+	switch config.Provider {
+	case domain.CaseSyncNotion:
+		return NewNotionClient(config.Token), nil
+	case domain.CaseSyncAirtable:
+		if config.BaseID == "" {
+			return nil, fmt.Errorf("airtable base ID is required")
+		}
+		return NewAirtableClient(config.Token, config.BaseID), nil
+	}
+	return nil, fmt.Errorf("unknown case tracking provider: %q", config.Provider)
+}
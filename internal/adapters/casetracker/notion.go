@@ -0,0 +1,239 @@
+package casetracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	notionBaseURL = "https://api.notion.com/v1/"
+	notionVersion = "2022-06-28"
+	notionMaxText = 2000 // Notion's limit on one rich text segment
+)
+
+// NotionClient upserts pages into Notion databases shared with an integration
+type NotionClient struct {
+	httpClient *http.Client
+	token      string
+
+	mu      sync.Mutex
+	schemas map[string]map[string]string // Database ID → property name → property type
+}
+
+// NewNotionClient creates a client using an internal integration token
+func NewNotionClient(token string) *NotionClient {
+	return &NotionClient{
+		httpClient: &http.Client{Timeout: 20 * time.Second},
+		token:      token,
+		schemas:    make(map[string]map[string]string),
+	}
+}
+
+// Upsert updates the page whose keyProperty equals the record's key, or creates one.
+// Values are converted to each property's type; properties missing from the database
+// are skipped.
+func (c *NotionClient) Upsert(ctx context.Context, databaseID, keyProperty string, records []map[string]any) error {
+	schema, err := c.schema(ctx, databaseID)
+	if err != nil {
+		return err
+	}
+	keyType := schema[keyProperty]
+	if keyType != "title" && keyType != "rich_text" {
+		return fmt.Errorf("key property %q must be a title or text property", keyProperty)
+	}
+
+	for _, record := range records {
+		properties := make(map[string]any, len(record))
+		for name, value := range record {
+			if propType, ok := schema[name]; ok {
+				if converted, ok := notionProperty(propType, value); ok {
+					properties[name] = converted
+				}
+			}
+		}
+
+		key := fmt.Sprint(record[keyProperty])
+		pageID, err := c.findPage(ctx, databaseID, keyProperty, keyType, key)
+		if err != nil {
+			return err
+		}
+		if pageID != "" {
+			err = c.do(ctx, "PATCH", notionBaseURL+"pages/"+pageID, map[string]any{"properties": properties}, nil)
+		} else {
+			err = c.do(ctx, "POST", notionBaseURL+"pages", map[string]any{
+				"parent":     map[string]string{"database_id": databaseID},
+				"properties": properties,
+			}, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schema returns the property types of a database, cached after the first lookup
+func (c *NotionClient) schema(ctx context.Context, databaseID string) (map[string]string, error) {
+	c.mu.Lock()
+	schema, ok := c.schemas[databaseID]
+	c.mu.Unlock()
+	if ok {
+		return schema, nil
+	}
+
+	var db struct {
+		Properties map[string]struct {
+			Type string `json:"type"`
+		} `json:"properties"`
+	}
+	if err := c.do(ctx, "GET", notionBaseURL+"databases/"+databaseID, nil, &db); err != nil {
+		return nil, err
+	}
+	schema = make(map[string]string, len(db.Properties))
+	for name, prop := range db.Properties {
+		schema[name] = prop.Type
+	}
+
+	c.mu.Lock()
+	c.schemas[databaseID] = schema
+	c.mu.Unlock()
+	return schema, nil
+}
+
+// findPage returns the ID of the page whose key property equals key, "" if none
+func (c *NotionClient) findPage(ctx context.Context, databaseID, keyProperty, keyType, key string) (string, error) {
+	var result struct {
+		Results []struct {
+			ID string `json:"id"`
+		} `json:"results"`
+	}
+	query := map[string]any{
+		"filter":    map[string]any{"property": keyProperty, keyType: map[string]string{"equals": key}},
+		"page_size": 1,
+	}
+	if err := c.do(ctx, "POST", notionBaseURL+"databases/"+databaseID+"/query", query, &result); err != nil {
+		return "", err
+	}
+	if len(result.Results) == 0 {
+		return "", nil
+	}
+	return result.Results[0].ID, nil
+}
+
+func (c *NotionClient) do(ctx context.Context, method, endpoint string, payload, dest any) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal notion request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create notion request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Notion-Version", notionVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notion request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notion returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if dest == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
+
+// notionProperty converts a value to a property value of the given type
+func notionProperty(propType string, value any) (any, bool) {
+	text := notionText(value)
+	switch propType {
+	case "title", "rich_text":
+		if runes := []rune(text); len(runes) > notionMaxText {
+			text = string(runes[:notionMaxText])
+		}
+		return map[string]any{propType: []any{map[string]any{"text": map[string]string{"content": text}}}}, true
+	case "number":
+		n, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, false
+		}
+		return map[string]any{"number": n}, true
+	case "url", "email", "phone_number":
+		if text == "" {
+			return map[string]any{propType: nil}, true
+		}
+		return map[string]any{propType: text}, true
+	case "select", "status":
+		if text == "" {
+			return map[string]any{propType: nil}, true
+		}
+		return map[string]any{propType: map[string]string{"name": strings.ReplaceAll(text, ",", " ")}}, true
+	case "multi_select":
+		options := []any{}
+		for _, name := range notionList(value) {
+			options = append(options, map[string]string{"name": strings.ReplaceAll(name, ",", " ")})
+		}
+		return map[string]any{"multi_select": options}, true
+	case "date":
+		if t, ok := value.(time.Time); ok && !t.IsZero() {
+			return map[string]any{"date": map[string]string{"start": t.UTC().Format(time.RFC3339)}}, true
+		}
+		return map[string]any{"date": nil}, true
+	case "checkbox":
+		b, ok := value.(bool)
+		return map[string]any{"checkbox": b}, ok
+	}
+	return nil, false
+}
+
+// notionText formats a value as plain text
+func notionText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// notionList returns a value as a list of options
+func notionList(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	}
+	return []string{notionText(value)}
+}
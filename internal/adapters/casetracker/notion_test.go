@@ -0,0 +1,115 @@
+package casetracker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// redirect sends every request to the test server, keeping its path
+type redirect struct{ target *url.URL }
+
+func (r redirect) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = r.target.Scheme, r.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// serve starts handler and returns an HTTP client whose requests all reach it
+func serve(t *testing.T, handler http.HandlerFunc) *http.Client {
+	t.Helper()
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	target, _ := url.Parse(ts.URL)
+	return &http.Client{Transport: redirect{target}}
+}
+
+func TestNewRequiresProviderSettings(t *testing.T) {
+	for name, config := range map[string]domain.CaseSyncConfig{
+		"no token": {Provider: domain.CaseSyncNotion},
+		"no base":  {Provider: domain.CaseSyncAirtable, Token: "pat"},
+		"unknown":  {Provider: domain.CaseSyncProvider("jira"), Token: "pat"},
+	} {
+		if _, err := New(config); err == nil {
+			t.Errorf("%s: New succeeded, want an error", name)
+		}
+	}
+	if tracker, err := New(domain.CaseSyncConfig{Provider: domain.CaseSyncNotion, Token: "secret"}); err != nil {
+		t.Errorf("New(notion) = %T, %v", tracker, err)
+	}
+}
+
+func TestNotionUpsertUpdatesOrCreatesPages(t *testing.T) {
+	var schemaLookups int
+	var writes []string
+	client := NewNotionClient("secret")
+	client.httpClient = serve(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Notion-Version") != notionVersion {
+			t.Errorf("%s %s: missing token or Notion-Version", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/databases/db1":
+			schemaLookups++
+			io.WriteString(w, `{"properties": {"Wallet": {"type": "title"}, "Score": {"type": "number"},
+				"Tags": {"type": "multi_select"}, "Seen": {"type": "date"}, "Status": {"type": "select"}}}`)
+		case "POST /v1/databases/db1/query":
+			if strings.Contains(string(body), `"equals":"0xold"`) {
+				io.WriteString(w, `{"results": [{"id": "page-1"}]}`)
+			} else {
+				io.WriteString(w, `{"results": []}`)
+			}
+		default:
+			writes = append(writes, r.Method+" "+r.URL.Path+" "+string(body))
+			io.WriteString(w, `{}`)
+		}
+	})
+
+	seen := time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC)
+	records := []map[string]any{
+		{"Wallet": "0xold", "Score": 0.75, "Tags": []string{"insider", "a,b"}, "Seen": seen, "Unknown": "skipped"},
+		{"Wallet": "0xnew", "Score": "not a number", "Status": ""},
+	}
+	if err := client.Upsert(context.Background(), "db1", "Wallet", records); err != nil {
+		t.Fatal(err)
+	}
+	if len(writes) != 2 || !strings.HasPrefix(writes[0], "PATCH /v1/pages/page-1 ") || !strings.HasPrefix(writes[1], "POST /v1/pages ") {
+		t.Fatalf("writes = %v, want page-1 updated and a page created", writes)
+	}
+
+	var updated struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	json.Unmarshal([]byte(strings.SplitN(writes[0], " ", 3)[2]), &updated)
+	for name, want := range map[string]string{
+		"Score": `{"number":0.75}`,
+		"Tags":  `{"multi_select":[{"name":"insider"},{"name":"a b"}]}`,
+		"Seen":  `{"date":{"start":"2026-02-03T04:05:06Z"}}`,
+	} {
+		if got := string(updated.Properties[name]); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+	if _, ok := updated.Properties["Unknown"]; ok {
+		t.Error("property missing from the database was sent")
+	}
+	created := writes[1]
+	if !strings.Contains(created, `"parent":{"database_id":"db1"}`) || !strings.Contains(created, `"Status":{"select":null}`) || strings.Contains(created, `"Score"`) {
+		t.Errorf("created page = %s, want the parent, a cleared select and no invalid number", created)
+	}
+
+	if err := client.Upsert(context.Background(), "db1", "Score", records); err == nil {
+		t.Error("Upsert keyed on a number property succeeded, want an error")
+	}
+	if schemaLookups != 1 {
+		t.Errorf("looked up the schema %d times, want it cached", schemaLookups)
+	}
+}
@@ -6,7 +6,7 @@ import (
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // WailsEventBus implements EventBus using Wails runtime
@@ -0,0 +1,66 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// Alerts tracks delivered notifications until someone acknowledges them
+type Alerts interface {
+	GetAlertAcks(unackedOnly bool) domain.AlertAckStatus
+	UnackedAlertCount() int
+	AckAlert(id, by string) (*domain.SentAlert, error)
+	UnackAlert(id string) (*domain.SentAlert, error)
+}
+
+// SetAlerts serves alert acknowledgment under /api/alerts and adds the unacknowledged
+// count to /api/status
+func (s *Server) SetAlerts(alerts Alerts) {
+	s.alerts = alerts
+}
+
+// registerAlerts adds the alert acknowledgment routes; they answer 404 until SetAlerts
+func (s *Server) registerAlerts(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/alerts", s.authorized(s.handleAlerts))
+	mux.HandleFunc("POST /api/alerts/{id}/ack", s.authorized(s.handleAckAlert))
+	mux.HandleFunc("DELETE /api/alerts/{id}/ack", s.authorized(s.handleUnackAlert))
+}
+
+// handleAlerts returns the tracked alerts, newest first; unacked=true leaves out the
+// acknowledged ones
+func (s *Server) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	if s.alerts == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.alerts.GetAlertAcks(r.URL.Query().Get("unacked") == "true"))
+}
+
+// handleAckAlert acknowledges an alert on behalf of the calling user
+func (s *Server) handleAckAlert(w http.ResponseWriter, r *http.Request) {
+	if s.alerts == nil {
+		http.NotFound(w, r)
+		return
+	}
+	alert, err := s.alerts.AckAlert(r.PathValue("id"), "api:"+userFrom(r))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, alert)
+}
+
+// handleUnackAlert reopens an acknowledged alert
+func (s *Server) handleUnackAlert(w http.ResponseWriter, r *http.Request) {
+	if s.alerts == nil {
+		http.NotFound(w, r)
+		return
+	}
+	alert, err := s.alerts.UnackAlert(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, alert)
+}
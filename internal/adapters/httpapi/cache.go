@@ -0,0 +1,140 @@
+package httpapi
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCacheTTL is how long a cached response is served, see SetCacheTTL
+	defaultCacheTTL = 5 * time.Second
+
+	// maxCachedResults bounds the cached responses; the cache is reset beyond it
+	maxCachedResults = 500
+)
+
+// invalidations lists, for each event that changes stored data, the route prefixes whose
+// cached responses it makes stale; no prefixes drops every cached response. Newly saved
+// trades don't invalidate: dashboards tolerate them showing up a TTL late.
+var invalidations = map[string][]string{
+	"polymarket:events_cleared":       nil,
+	"polymarket:events_pruned":        nil,
+	"polymarket:database_restored":    nil,
+	"polymarket:freshness_recomputed": {"/api/wallets", "/api/aggregates"},
+	"polymarket:wallet_updated":       {"/api/wallets"},
+	"polymarket:event_tagged":         {"/api/aggregates"},
+	"polymarket:event_untagged":       {"/api/aggregates"},
+	"polymarket:wallet_tagged":        {"/api/wallets"},
+	"polymarket:wallet_untagged":      {"/api/wallets"},
+	"polymarket:market_snapshots":     {"/api/markets/snapshots"},
+}
+
+// cachedResult is a successful response kept for repeated requests
+type cachedResult struct {
+	contentType string
+	body        []byte
+	at          time.Time
+}
+
+// resultCache keeps the responses of expensive read routes for a short TTL, so
+// dashboards refreshing every few seconds don't rerun the same aggregation
+type resultCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration // 0 disables caching
+	results map[string]cachedResult
+	version int // Bumped by every invalidation, so a response computed across one isn't kept
+}
+
+// SetCacheTTL sets how long responses of the aggregate, wallet and database routes are
+// served from the cache (default: 5s). 0 disables the cache.
+func (s *Server) SetCacheTTL(ttl time.Duration) {
+	s.cache.mu.Lock()
+	s.cache.ttl = max(ttl, 0)
+	s.cache.results = make(map[string]cachedResult)
+	s.cache.mu.Unlock()
+}
+
+// Invalidate drops the cached responses made stale by a bus event. Register it as a
+// bus listener.
+func (s *Server) Invalidate(eventName string, _ interface{}) {
+	prefixes, ok := invalidations[eventName]
+	if !ok {
+		return
+	}
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+	s.cache.version++
+	if prefixes == nil {
+		s.cache.results = make(map[string]cachedResult)
+		return
+	}
+	for key := range s.cache.results {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				delete(s.cache.results, key)
+				break
+			}
+		}
+	}
+}
+
+// cached serves a route's successful responses from the cache while they are fresh.
+// Responses are shared by every user, so only routes whose results don't depend on
+// the caller may be cached.
+func (s *Server) cached(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path + "?" + r.URL.Query().Encode() // Encode sorts the parameters
+		c := &s.cache
+		c.mu.Lock()
+		ttl := c.ttl
+		result, ok := c.results[key]
+		version := c.version
+		c.mu.Unlock()
+		if ttl == 0 {
+			next(w, r)
+			return
+		}
+		if ok && time.Since(result.at) < ttl {
+			w.Header().Set("Content-Type", result.contentType)
+			w.Header().Set("X-Cache", "HIT")
+			w.Write(result.body)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		w.Header().Set("X-Cache", "MISS")
+		next(recorder, r)
+		if recorder.status != http.StatusOK {
+			return
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.version != version {
+			return
+		}
+		if len(c.results) >= maxCachedResults {
+			c.results = make(map[string]cachedResult)
+		}
+		c.results[key] = cachedResult{contentType: w.Header().Get("Content-Type"), body: recorder.body.Bytes(), at: time.Now()}
+	}
+}
+
+// responseRecorder passes a response through while keeping a copy of its body
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,77 @@
+package httpapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCachedServesFreshResponses(t *testing.T) {
+	server := NewServer("", "main-token", nil, nil)
+	calls := map[string]int{}
+	handler := server.cached(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		if r.URL.Query().Get("fail") != "" {
+			writeError(w, http.StatusInternalServerError, "boom")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"ok":true}`)
+	})
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", target, nil))
+		return w
+	}
+
+	get("/api/wallets?limit=5&sort=volume")
+	w := get("/api/wallets?sort=volume&limit=5")
+	if calls["/api/wallets"] != 1 || w.Header().Get("X-Cache") != "HIT" || w.Body.String() != `{"ok":true}` || w.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("second request ran the handler %d times, X-Cache %q, want a hit", calls["/api/wallets"], w.Header().Get("X-Cache"))
+	}
+	get("/api/wallets?fail=1")
+	get("/api/wallets?fail=1")
+	if calls["/api/wallets"] != 3 {
+		t.Error("an error response was cached")
+	}
+
+	// Only routes the event makes stale are dropped
+	get("/api/aggregates?bucket=hour")
+	server.Invalidate("polymarket:wallet_tagged", nil)
+	server.Invalidate("polymarket:trade", nil)
+	get("/api/wallets?limit=5&sort=volume")
+	get("/api/aggregates?bucket=hour")
+	if calls["/api/wallets"] != 4 || calls["/api/aggregates"] != 1 {
+		t.Errorf("calls = %v, want only the wallets recomputed", calls)
+	}
+	server.Invalidate("polymarket:events_cleared", nil)
+	get("/api/aggregates?bucket=hour")
+	if calls["/api/aggregates"] != 2 {
+		t.Error("clearing events kept a cached response")
+	}
+
+	server.SetCacheTTL(0)
+	get("/api/aggregates?bucket=hour")
+	if w := get("/api/aggregates?bucket=hour"); calls["/api/aggregates"] != 4 || w.Header().Get("X-Cache") != "" {
+		t.Error("a disabled cache served a response")
+	}
+}
+
+func TestCachedDropsResponsesComputedAcrossAnInvalidation(t *testing.T) {
+	server := NewServer("", "main-token", nil, nil)
+	calls := 0
+	handler := server.cached(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			server.Invalidate("polymarket:wallet_updated", nil) // A write lands mid-query
+		}
+		io.WriteString(w, "[]")
+	})
+	for i := 0; i < 2; i++ {
+		handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/wallets", nil))
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want the stale first response not cached", calls)
+	}
+}
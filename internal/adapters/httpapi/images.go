@@ -0,0 +1,23 @@
+package httpapi
+
+import "net/http"
+
+// SetImages serves cached market thumbnails under /api/images?url=<original URL>.
+// A nil handler disables the route.
+func (s *Server) SetImages(images http.Handler) {
+	s.images = images
+}
+
+// registerImages adds the market thumbnail route; it answers 404 until SetImages
+func (s *Server) registerImages(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/images", s.authorized(s.handleImages))
+}
+
+// handleImages serves a market thumbnail from the image cache
+func (s *Server) handleImages(w http.ResponseWriter, r *http.Request) {
+	if s.images == nil {
+		http.NotFound(w, r)
+		return
+	}
+	s.images.ServeHTTP(w, r)
+}
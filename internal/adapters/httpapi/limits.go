@@ -0,0 +1,134 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/ratelimit"
+)
+
+const (
+	// Limit defaults, used for zero fields of Limits
+	defaultRequestsPerMinute = 600
+	defaultMaxBodyBytes      = 1 << 20
+	defaultMaxConcurrent     = 2
+	defaultMaxResults        = 1000
+
+	// queryWait is how long a query waits for one of its token's slots before it is refused
+	queryWait = 5 * time.Second
+
+	// maxQuotaUsers bounds the per-user quotas when users come from the unauthenticated
+	// X-XTools-User header; the map is reset beyond it
+	maxQuotaUsers = 1000
+)
+
+// Limits bound what each API token can cost the daemon, so a misbehaving dashboard
+// can't starve trade ingestion of CPU or hold the database with expensive queries.
+// Zero fields use the defaults.
+type Limits struct {
+	RequestsPerMinute int   // Requests per token (default: 600)
+	MaxBodyBytes      int64 // Largest request body (default: 1 MiB)
+	MaxConcurrent     int   // Event, wallet and export queries in flight per token (default: 2)
+	MaxResults        int   // Largest limit a query may ask for; exports are streamed and exempt (default: 1000)
+}
+
+// withDefaults fills the zero fields with the defaults
+func (l Limits) withDefaults() Limits {
+	if l.RequestsPerMinute <= 0 {
+		l.RequestsPerMinute = defaultRequestsPerMinute
+	}
+	if l.MaxBodyBytes <= 0 {
+		l.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	if l.MaxConcurrent <= 0 {
+		l.MaxConcurrent = defaultMaxConcurrent
+	}
+	if l.MaxResults <= 0 {
+		l.MaxResults = defaultMaxResults
+	}
+	return l
+}
+
+// quota is one user's request budget and query slots
+type quota struct {
+	requests *ratelimit.TokenBucket
+	slots    chan struct{}
+}
+
+// quotas tracks the budget of every user seen
+type quotas struct {
+	mu     sync.Mutex
+	limits Limits
+	users  map[string]*quota
+}
+
+// SetLimits replaces the per-token limits. Budgets already spent start over.
+func (s *Server) SetLimits(limits Limits) {
+	s.quotas.mu.Lock()
+	s.quotas.limits = limits.withDefaults()
+	s.quotas.users = make(map[string]*quota)
+	s.quotas.mu.Unlock()
+}
+
+// quotaFor returns the user's quota, creating it on first use
+func (q *quotas) quotaFor(user string) (*quota, Limits) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	u, ok := q.users[user]
+	if !ok {
+		if len(q.users) >= maxQuotaUsers {
+			q.users = make(map[string]*quota)
+		}
+		u = &quota{
+			requests: ratelimit.NewTokenBucket(q.limits.RequestsPerMinute, time.Minute),
+			slots:    make(chan struct{}, q.limits.MaxConcurrent),
+		}
+		q.users[user] = u
+	}
+	return u, q.limits
+}
+
+// admit spends a request from the user's budget and bounds the request body. It
+// writes the error and returns false when the budget is spent.
+func (s *Server) admit(w http.ResponseWriter, r *http.Request, user string) bool {
+	u, limits := s.quotas.quotaFor(user)
+	if !u.requests.TryAcquire() {
+		w.Header().Set("Retry-After", strconv.Itoa(int(u.requests.GetStatus().ResetIn.Seconds())+1))
+		writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+		return false
+	}
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, limits.MaxBodyBytes)
+	}
+	return true
+}
+
+// query runs a database-heavy handler in one of the calling user's query slots,
+// refusing the request when none frees up in time
+func (s *Server) query(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		u, _ := s.quotas.quotaFor(userFrom(r))
+		timer := time.NewTimer(queryWait)
+		defer timer.Stop()
+
+		select {
+		case u.slots <- struct{}{}:
+			defer func() { <-u.slots }()
+			next(w, r)
+		case <-timer.C:
+			w.Header().Set("Retry-After", strconv.Itoa(int(queryWait.Seconds())))
+			writeError(w, http.StatusTooManyRequests, "too many queries in flight")
+		case <-r.Context().Done():
+		}
+	}
+}
+
+// capResults bounds a requested result count to the configured maximum
+func (s *Server) capResults(limit int) int {
+	s.quotas.mu.Lock()
+	defer s.quotas.mu.Unlock()
+	return min(limit, s.quotas.limits.MaxResults)
+}
@@ -0,0 +1,83 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/services"
+)
+
+func TestLimitsApplyPerToken(t *testing.T) {
+	svc := services.NewPolymarketService(storage.NewMemoryPolymarketStore(), localbus.New(), "")
+	t.Cleanup(svc.Close)
+	server := NewServer("", "main-token", svc, nil)
+	server.SetUserTokens(map[string]string{"alice-token": "alice", "bob-token": "bob"})
+	server.SetLimits(Limits{RequestsPerMinute: 2, MaxBodyBytes: 64, MaxResults: 50})
+	ts := httptest.NewServer(server.server.Handler)
+	t.Cleanup(ts.Close)
+
+	call := func(method, path, token, body string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	for i := 0; i < 2; i++ {
+		if resp := call("GET", "/api/me", "alice-token", ""); resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d = %d, want 200", i+1, resp.StatusCode)
+		}
+	}
+	if resp := call("GET", "/api/me", "alice-token", ""); resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Errorf("third request = %d, want 429 with Retry-After", resp.StatusCode)
+	}
+	if resp := call("GET", "/api/me", "bob-token", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("bob's request = %d, want his own budget", resp.StatusCode)
+	}
+	if resp := call("PUT", "/api/me/settings", "bob-token", `{"watchlist": ["`+strings.Repeat("0", 100)+`"]}`); resp.StatusCode < 400 {
+		t.Errorf("oversized body = %d, want it refused", resp.StatusCode)
+	}
+	if got := server.capResults(5000); got != 50 {
+		t.Errorf("capResults(5000) = %d, want 50", got)
+	}
+}
+
+func TestQueryWaitsForASlot(t *testing.T) {
+	server := NewServer("", "main-token", nil, nil)
+	server.SetLimits(Limits{MaxConcurrent: 1})
+	release, running := make(chan struct{}), make(chan struct{}, 2)
+	handler := server.query(func(w http.ResponseWriter, r *http.Request) {
+		running <- struct{}{}
+		<-release
+	})
+
+	go handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/events", nil))
+	<-running
+
+	// A second query of the same user waits until its caller gives up
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/events", nil).WithContext(ctx))
+	select {
+	case <-running:
+		t.Error("a second query ran while the only slot was taken")
+	default:
+	}
+
+	close(release)
+	handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/events", nil))
+	if len(running) != 1 {
+		t.Error("the query did not run once the slot was freed")
+	}
+}
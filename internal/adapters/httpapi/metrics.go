@@ -0,0 +1,72 @@
+package httpapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+)
+
+// handleMetrics writes watcher, queue, error and process metrics in the Prometheus
+// text exposition format
+func (s *Server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	status := s.backend.GetStatus()
+	system := s.backend.GetSystemStatus()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	gauge(w, "xtools_watcher_running", "Whether the trade feed watcher is running", boolValue(status.IsRunning))
+	counter(w, "xtools_events_received_total", "Feed events received", float64(status.EventsReceived))
+	counter(w, "xtools_trades_received_total", "Trades received", float64(status.TradesReceived))
+	counter(w, "xtools_fresh_wallets_found_total", "Fresh wallets detected", float64(status.FreshWalletsFound))
+	counter(w, "xtools_feed_reconnects_total", "Trade feed reconnects", float64(status.ReconnectCount))
+	gauge(w, "xtools_write_queue_depth", "Events waiting to be written to the database", float64(system.WriteQueueDepth))
+
+	if status.WriteQueue != nil {
+		header(w, "xtools_write_queue_dropped_total", "Events dropped by the write queue, by type", "counter")
+		for _, t := range sortedKeys(status.WriteQueue.Dropped) {
+			fmt.Fprintf(w, "xtools_write_queue_dropped_total{type=%q} %d\n", t, status.WriteQueue.Dropped[t])
+		}
+	}
+
+	header(w, "xtools_errors_total", "Errors reported, by kind", "counter")
+	for _, kind := range sortedKeys(system.Errors) {
+		fmt.Fprintf(w, "xtools_errors_total{kind=%q} %d\n", kind, system.Errors[kind])
+	}
+
+	counter(w, "xtools_http_cache_hits_total", "Metadata requests served from the disk cache", float64(system.HTTPCacheHits))
+	counter(w, "xtools_http_cache_misses_total", "Metadata requests that downloaded the payload", float64(system.HTTPCacheMisses))
+	gauge(w, "xtools_process_resident_memory_bytes", "Resident set size", float64(system.RSSBytes))
+	gauge(w, "xtools_go_heap_alloc_bytes", "Bytes of allocated heap objects", float64(system.HeapAllocBytes))
+	gauge(w, "xtools_go_goroutines", "Live goroutines", float64(system.Goroutines))
+}
+
+func header(w io.Writer, name, help, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func gauge(w io.Writer, name, help string, value float64) {
+	header(w, name, help, "gauge")
+	fmt.Fprintf(w, "%s %g\n", name, value)
+}
+
+func counter(w io.Writer, name, help string, value float64) {
+	header(w, name, help, "counter")
+	fmt.Fprintf(w, "%s %g\n", name, value)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// sortedKeys returns map keys in order so metric output is stable
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,97 @@
+package httpapi
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/ratelimit"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// publicSnapshotTTL is how long a built snapshot is served before rebuilding it
+	publicSnapshotTTL = 30 * time.Second
+
+	// publicMarkets is how many events a public snapshot lists
+	publicMarkets = 50
+
+	// maxPublicClients bounds the per-client limiters; the map is reset beyond it
+	maxPublicClients = 10000
+)
+
+// publicSnapshot serves the anonymized snapshot without authentication, rate limited
+// per client address
+type publicSnapshot struct {
+	mu       sync.Mutex
+	rate     int // Requests per minute per client; 0 = disabled
+	limiters map[string]*ratelimit.TokenBucket
+	cached   *domain.PublicSnapshot
+	cachedAt time.Time
+}
+
+// EnablePublicSnapshot serves GET /public/snapshot without a token, allowing each
+// client address ratePerMinute requests. The route answers 404 until enabled.
+func (s *Server) EnablePublicSnapshot(ratePerMinute int) {
+	if ratePerMinute <= 0 {
+		ratePerMinute = 30
+	}
+	s.public.mu.Lock()
+	s.public.rate = ratePerMinute
+	s.public.limiters = make(map[string]*ratelimit.TokenBucket)
+	s.public.mu.Unlock()
+}
+
+func (s *Server) handlePublicSnapshot(w http.ResponseWriter, r *http.Request) {
+	p := &s.public
+	p.mu.Lock()
+	if p.rate == 0 {
+		p.mu.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+	client := clientAddress(r)
+	limiter, ok := p.limiters[client]
+	if !ok {
+		if len(p.limiters) >= maxPublicClients {
+			p.limiters = make(map[string]*ratelimit.TokenBucket)
+		}
+		limiter = ratelimit.NewTokenBucket(p.rate, time.Minute)
+		p.limiters[client] = limiter
+	}
+	if !limiter.TryAcquire() {
+		p.mu.Unlock()
+		w.Header().Set("Retry-After", strconv.Itoa(int(limiter.GetStatus().ResetIn.Seconds())+1))
+		writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+		return
+	}
+	if p.cached == nil || time.Since(p.cachedAt) > publicSnapshotTTL {
+		snapshot := s.backend.GetPublicSnapshot(publicMarkets)
+		p.cached, p.cachedAt = &snapshot, time.Now()
+	}
+	snapshot := *p.cached
+	p.mu.Unlock()
+
+	w.Header().Set("Access-Control-Allow-Origin", "*") // For embedding in dashboards
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(publicSnapshotTTL.Seconds())))
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+// clientAddress returns the IP a request came from. Behind a reverse proxy on the same
+// host, the last X-Forwarded-For entry is used instead.
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			parts := strings.Split(forwarded, ",")
+			return strings.TrimSpace(parts[len(parts)-1])
+		}
+	}
+	return host
+}
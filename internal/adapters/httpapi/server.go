@@ -0,0 +1,104 @@
+package httpapi
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// Backend is the watcher the API exposes
+type Backend interface {
+	Start() error
+	Stop()
+	GetStatus() domain.PolymarketWatcherStatus
+	GetEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error)
+	SearchEvents(query string, limit int) ([]domain.PolymarketEvent, error)
+	GetMarketAggregates(filter domain.PolymarketEventFilter, bucket domain.AggregateBucket) ([]domain.MarketAggregate, error)
+	ExportEvents(filter domain.PolymarketEventFilter, format domain.EventExportFormat, w io.Writer) (int, error)
+	GetWallets(limit int) ([]domain.WalletProfile, error)
+	QueryWallets(filter domain.WalletFilter) (*domain.WalletPage, error)
+	SearchWallets(query string, limit int) ([]domain.WalletSearchResult, error)
+	GetWalletTags(addresses []string) (map[string][]string, error)
+	TagWallet(address, tag string) error
+	UntagWallet(address, tag string) error
+	GetEntityFlow(since, until time.Time, limit int) (*domain.EntityFlowReport, error)
+	GetQuotes(assetIDs []string) ([]domain.MarketQuote, error)
+	GetMarketSnapshots(filter domain.MarketSnapshotFilter) ([]domain.MarketSnapshot, error)
+	GetSystemStatus() domain.SystemStatus
+	GetErrorStats() domain.ErrorStats
+	GetDatabaseInfo() (*domain.DatabaseInfo, error)
+	GetUserSettings(user string) (domain.UserSettings, error)
+	SaveUserSettings(user string, settings domain.UserSettings) (*domain.UserSettings, error)
+	GetUserEvents(user string, filter domain.PolymarketEventFilter, watchlistOnly bool) ([]domain.PolymarketEvent, error)
+	GetUserEventCount(user string, filter domain.PolymarketEventFilter) (int64, error)
+	GetUserEventPage(user string, filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error)
+	GetArchivedEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error)
+	GetPublicSnapshot(limit int) domain.PublicSnapshot
+}
+
+// Server serves the watcher's REST API, a server-sent event stream and Prometheus
+// metrics for the headless daemon
+type Server struct {
+	backend  Backend
+	stream   *Stream
+	token    string            // Bearer token required on every route but /healthz; empty disables auth
+	users    map[string]string // Per-user bearer tokens, token -> user ID
+	public   publicSnapshot    // Opt-in anonymized snapshot, see EnablePublicSnapshot
+	quotas   quotas            // Per-token request budgets, see SetLimits
+	cache    resultCache       // Short-lived responses of expensive reads, see SetCacheTTL
+	alerts   Alerts            // Alert acknowledgment, see SetAlerts; nil disables /api/alerts
+	settings Settings          // Watcher settings, see SetSettings; nil disables them
+	images   http.Handler      // Market thumbnail cache, see SetImages; nil disables /api/images
+	server   *http.Server
+}
+
+// NewServer creates an API server listening on addr. stream may be nil to disable
+// /api/stream.
+func NewServer(addr, token string, backend Backend, stream *Stream) *Server {
+	s := &Server{backend: backend, stream: stream, token: token}
+	s.SetLimits(Limits{})
+	s.SetCacheTTL(defaultCacheTTL)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /public/snapshot", s.handlePublicSnapshot)
+	mux.HandleFunc("GET /metrics", s.authorized(s.handleMetrics))
+	s.registerSystem(mux)
+	s.registerEvents(mux)
+	s.registerWallets(mux)
+	s.registerMarkets(mux)
+	s.registerAlerts(mux)
+	s.registerImages(mux)
+	s.registerUsers(mux)
+	s.registerSettings(mux)
+	if stream != nil {
+		mux.HandleFunc("GET /api/stream", s.authorized(stream.ServeHTTP))
+	}
+
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// ListenAndServe serves until Shutdown is called
+func (s *Server) ListenAndServe() error {
+	log.Printf("[HTTPAPI] Listening on %s", s.server.Addr)
+	if err := s.server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting requests and closes open streams
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.stream != nil {
+		s.stream.Close()
+	}
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,147 @@
+package httpapi
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// registerEvents adds the event listing, export and aggregate routes
+func (s *Server) registerEvents(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/events", s.authorized(s.query(s.handleEvents)))
+	mux.HandleFunc("GET /api/events/count", s.authorized(s.query(s.handleEventCount)))
+	mux.HandleFunc("GET /api/events/page", s.authorized(s.query(s.handleEventPage)))
+	mux.HandleFunc("GET /api/events/archive", s.authorized(s.query(s.handleArchivedEvents)))
+	mux.HandleFunc("GET /api/events/export", s.authorized(s.query(s.handleExportEvents)))
+	mux.HandleFunc("GET /api/events/search", s.authorized(s.query(s.handleSearchEvents)))
+	mux.HandleFunc("GET /api/aggregates", s.authorized(s.cached(s.query(s.handleAggregates))))
+}
+
+// handleEvents returns stored events as the calling user sees them. Query parameters:
+// limit, offset, market, minSize, minRiskScore, freshOnly, tag, type, wallet, slug and
+// conditionId (all repeatable), sort (timestamp, notional, risk_score or bet_count),
+// sortDir (asc or desc) and watchlist=true for trades by the user's watched wallets only.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := eventFilter(q, 100)
+	filter.Limit = s.capResults(filter.Limit)
+	events, err := s.backend.GetUserEvents(userFrom(r), filter, q.Get("watchlist") == "true")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if events == nil {
+		events = []domain.PolymarketEvent{}
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// handleEventCount returns {"count": n}, the number of events matching the filter
+// parameters of /api/events as the calling user sees them, ignoring limit and offset.
+// Muted markets and watchlist=true are not applied.
+func (s *Server) handleEventCount(w http.ResponseWriter, r *http.Request) {
+	count, err := s.backend.GetUserEventCount(userFrom(r), eventFilter(r.URL.Query(), 0))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int64{"count": count})
+}
+
+// handleEventPage returns a page of events with the totals of every matching event
+// (total, totalNotional, freshWalletCount), read together so they agree. Takes the
+// filter parameters of /api/events; muted markets and watchlist=true are not applied.
+func (s *Server) handleEventPage(w http.ResponseWriter, r *http.Request) {
+	filter := eventFilter(r.URL.Query(), 100)
+	filter.Limit = s.capResults(filter.Limit)
+	page, err := s.backend.GetUserEventPage(userFrom(r), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// handleArchivedEvents returns archived events, taking the filter parameters of /api/events
+func (s *Server) handleArchivedEvents(w http.ResponseWriter, r *http.Request) {
+	filter := eventFilter(r.URL.Query(), 100)
+	filter.Limit = s.capResults(filter.Limit)
+	events, err := s.backend.GetArchivedEvents(filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if events == nil {
+		events = []domain.PolymarketEvent{}
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// handleExportEvents streams every stored event matching the filter parameters of
+// /api/events (or up to limit) as format=csv (default) or format=jsonl
+func (s *Server) handleExportEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := domain.EventExportFormat(q.Get("format"))
+	if format == "" {
+		format = domain.EventExportCSV
+	}
+	if !format.Valid() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported export format: %q", format))
+		return
+	}
+
+	contentType := "text/csv"
+	if format == domain.EventExportJSONL {
+		contentType = "application/x-ndjson"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="events-%s.%s"`, time.Now().Format("20060102-150405"), format))
+	if _, err := s.backend.ExportEvents(eventFilter(q, 0), format, w); err != nil {
+		// Headers are already sent; the truncated body is all the client gets
+		log.Printf("[HTTPAPI] Event export failed: %v", err)
+	}
+}
+
+// handleSearchEvents returns the events whose market name or event title contains every
+// word of q, newest first, up to limit (default 50)
+func (s *Server) handleSearchEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	events, err := s.backend.SearchEvents(q.Get("q"), s.capResults(queryInt(q.Get("limit"), 50)))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if events == nil {
+		events = []domain.PolymarketEvent{}
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// handleAggregates returns per-market trade totals per bucket=hour (default) or day
+// for the trades matching the filter parameters of /api/events, including since and
+// until as RFC 3339 times
+func (s *Server) handleAggregates(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	bucket := domain.AggregateBucket(q.Get("bucket"))
+	if bucket == "" {
+		bucket = domain.AggregateHour
+	}
+	if !bucket.Valid() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unsupported bucket: %q", bucket))
+		return
+	}
+	filter := eventFilter(q, 1000)
+	filter.Limit = s.capResults(filter.Limit)
+	aggregates, err := s.backend.GetMarketAggregates(filter, bucket)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if aggregates == nil {
+		aggregates = []domain.MarketAggregate{}
+	}
+	writeJSON(w, http.StatusOK, aggregates)
+}
@@ -0,0 +1,108 @@
+package httpapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/services"
+)
+
+func TestExportEventsStreamsFilteredEvents(t *testing.T) {
+	store := storage.NewMemoryPolymarketStore()
+	store.SaveEvents([]domain.PolymarketEvent{
+		{EventType: domain.PolymarketEventTrade, TradeID: "small", AssetID: "111", MarketName: "Fed cut", Price: "0.5", Size: "10"},
+		{EventType: domain.PolymarketEventTrade, TradeID: "large", AssetID: "111", MarketName: "Fed cut", Price: "0.5", Size: "10000"},
+	})
+	svc := services.NewPolymarketService(store, localbus.New(), "")
+	t.Cleanup(svc.Close)
+	ts := httptest.NewServer(NewServer("", "main-token", svc, nil).server.Handler)
+	t.Cleanup(ts.Close)
+
+	get := func(query string) (*http.Response, string) {
+		t.Helper()
+		req, _ := http.NewRequest("GET", ts.URL+"/api/events/export"+query, nil)
+		req.Header.Set("Authorization", "Bearer main-token")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	resp, body := get("?minSize=1000")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/csv" ||
+		!strings.Contains(resp.Header.Get("Content-Disposition"), ".csv") {
+		t.Fatalf("CSV export = %d %v, want a CSV attachment", resp.StatusCode, resp.Header)
+	}
+	if lines := strings.Split(strings.TrimSpace(body), "\n"); len(lines) != 2 || !strings.Contains(lines[1], "large") {
+		t.Errorf("CSV export = %q, want the header and the large trade", body)
+	}
+
+	resp, body = get("?format=jsonl")
+	if resp.Header.Get("Content-Type") != "application/x-ndjson" || strings.Count(body, "\n") != 2 {
+		t.Errorf("JSONL export = %q (%s), want both events", body, resp.Header.Get("Content-Type"))
+	}
+	if resp, _ = get("?format=xlsx"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("xlsx export = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestEventCountIgnoresPaging(t *testing.T) {
+	store := storage.NewMemoryPolymarketStore()
+	var events []domain.PolymarketEvent
+	for i, size := range []string{"10", "2000", "3000", "4000"} {
+		events = append(events, domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: "tx" + size, AssetID: "111", MarketName: "Fed cut",
+			Price: "0.5", Size: size, WalletAddress: []string{"0xa", "0xa", "0xb", "0xc"}[i],
+		})
+	}
+	store.SaveEvents(events)
+	svc := services.NewPolymarketService(store, localbus.New(), "")
+	t.Cleanup(svc.Close)
+	server := NewServer("", "main-token", svc, nil)
+	server.SetUserTokens(map[string]string{"alice-token": "alice"})
+	ts := httptest.NewServer(server.server.Handler)
+	t.Cleanup(ts.Close)
+
+	count := func(token, query string) string {
+		t.Helper()
+		req, _ := http.NewRequest("GET", ts.URL+"/api/events/count"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return strings.TrimSpace(string(body))
+	}
+
+	for query, want := range map[string]string{
+		"":                         `{"count":4}`,
+		"?minSize=1000&limit=1":    `{"count":3}`,
+		"?minSize=1000&offset=2":   `{"count":3}`,
+		"?wallet=0xa":              `{"count":2}`,
+		"?market=election":         `{"count":0}`,
+		"?minSize=1000&wallet=0xa": `{"count":1}`,
+	} {
+		if got := count("main-token", query); got != want {
+			t.Errorf("count%s = %s, want %s", query, got, want)
+		}
+	}
+
+	// A user's default filter fills in what the query leaves out
+	if _, err := svc.SaveUserSettings("alice", domain.UserSettings{Filter: domain.PolymarketEventFilter{MinSize: 1500}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := count("alice-token", ""); got != `{"count":2}` {
+		t.Errorf("alice's count = %s, want her default minimum size applied", got)
+	}
+}
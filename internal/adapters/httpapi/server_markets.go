@@ -0,0 +1,54 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// registerMarkets adds the market snapshot, quote and entity flow routes
+func (s *Server) registerMarkets(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/entities/flow", s.authorized(s.cached(s.query(s.handleEntityFlow))))
+	mux.HandleFunc("GET /api/quotes", s.authorized(s.handleQuotes))
+	mux.HandleFunc("GET /api/markets/snapshots", s.authorized(s.cached(s.query(s.handleMarketSnapshots))))
+}
+
+// handleMarketSnapshots returns the stored snapshots of a market (slug, empty = every
+// market) captured between since and until as RFC 3339 times, newest first, up to
+// limit (default 100); limit=1 with until gives a market's state as of that time
+func (s *Server) handleMarketSnapshots(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	snapshots, err := s.backend.GetMarketSnapshots(domain.MarketSnapshotFilter{
+		MarketSlug: q.Get("slug"),
+		Since:      queryTime(q.Get("since")),
+		Until:      queryTime(q.Get("until")),
+		Limit:      s.capResults(queryInt(q.Get("limit"), 100)),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, snapshots)
+}
+
+// handleEntityFlow returns the trade flow into the markets mentioning each entity
+// between since and until as RFC 3339 times (default the last 24 hours), the most
+// fresh-wallet flow first, up to limit entities (default 100)
+func (s *Server) handleEntityFlow(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	report, err := s.backend.GetEntityFlow(queryTime(q.Get("since")), queryTime(q.Get("until")), s.capResults(queryInt(q.Get("limit"), 100)))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (s *Server) handleQuotes(w http.ResponseWriter, r *http.Request) {
+	quotes, err := s.backend.GetQuotes(r.URL.Query()["asset"])
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, quotes)
+}
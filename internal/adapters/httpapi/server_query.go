@@ -0,0 +1,70 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// eventFilter reads the event filter query parameters shared by the event routes
+func eventFilter(q url.Values, defaultLimit int) domain.PolymarketEventFilter {
+	filter := domain.PolymarketEventFilter{
+		Limit:            queryInt(q.Get("limit"), defaultLimit),
+		Offset:           queryInt(q.Get("offset"), 0),
+		MarketName:       q.Get("market"),
+		MinSize:          queryFloat(q.Get("minSize")),
+		MinRiskScore:     queryFloat(q.Get("minRiskScore")),
+		FreshWalletsOnly: q.Get("freshOnly") == "true",
+		Tag:              q.Get("tag"),
+		Entity:           q.Get("entity"),
+		WalletTag:        q.Get("walletTag"),
+		Since:            queryTime(q.Get("since")),
+		Until:            queryTime(q.Get("until")),
+		SortBy:           domain.EventSortField(q.Get("sort")),
+		SortDir:          domain.SortDirection(q.Get("sortDir")),
+	}
+	for _, t := range q["type"] {
+		filter.EventTypes = append(filter.EventTypes, domain.PolymarketEventType(t))
+	}
+	filter.WalletAddresses = q["wallet"]
+	filter.MarketSlugs = q["slug"]
+	filter.ConditionIDs = q["conditionId"]
+	filter.Outcome = q.Get("outcome")
+	if index, err := strconv.Atoi(q.Get("outcomeIndex")); err == nil && index >= 0 {
+		filter.OutcomeIndex = &index
+	}
+	filter.ExcludeMarketNames = q["excludeMarket"]
+	filter.ExcludeWallets = q["excludeWallet"]
+	return filter
+}
+
+func queryInt(value string, fallback int) int {
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+		return n
+	}
+	return fallback
+}
+
+func queryTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
+
+func queryFloat(value string) float64 {
+	f, _ := strconv.ParseFloat(value, 64)
+	return f
+}
@@ -0,0 +1,60 @@
+package httpapi
+
+import "net/http"
+
+// registerSystem adds the health, watcher control and diagnostics routes
+func (s *Server) registerSystem(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /api/status", s.authorized(s.handleStatus))
+	mux.HandleFunc("POST /api/watcher/start", s.authorized(s.handleStart))
+	mux.HandleFunc("POST /api/watcher/stop", s.authorized(s.handleStop))
+	mux.HandleFunc("GET /api/system", s.authorized(s.handleSystem))
+	mux.HandleFunc("GET /api/errors", s.authorized(s.handleErrors))
+	mux.HandleFunc("GET /api/database", s.authorized(s.cached(s.handleDatabase)))
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"ok": true, "watching": s.backend.GetStatus().IsRunning})
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	status := s.backend.GetStatus()
+	if s.alerts != nil {
+		status.UnackedAlerts = s.alerts.UnackedAlertCount()
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) handleStart(w http.ResponseWriter, _ *http.Request) {
+	if err := s.backend.Start(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, s.backend.GetStatus())
+}
+
+func (s *Server) handleStop(w http.ResponseWriter, _ *http.Request) {
+	s.backend.Stop()
+	writeJSON(w, http.StatusOK, s.backend.GetStatus())
+}
+
+func (s *Server) handleSystem(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.backend.GetSystemStatus())
+}
+
+func (s *Server) handleErrors(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.backend.GetErrorStats())
+}
+
+func (s *Server) handleDatabase(w http.ResponseWriter, _ *http.Request) {
+	info, err := s.backend.GetDatabaseInfo()
+	if info == nil {
+		message := "database info unavailable"
+		if err != nil {
+			message = err.Error()
+		}
+		writeError(w, http.StatusInternalServerError, message)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
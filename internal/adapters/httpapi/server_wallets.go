@@ -0,0 +1,101 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// registerWallets adds the wallet listing, search and tagging routes
+func (s *Server) registerWallets(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/wallets", s.authorized(s.cached(s.query(s.handleWallets))))
+	mux.HandleFunc("GET /api/wallets/search", s.authorized(s.query(s.handleSearchWallets)))
+	mux.HandleFunc("GET /api/wallets/tags", s.authorized(s.handleWalletTags))
+	mux.HandleFunc("PUT /api/wallets/{address}/tags/{tag}", s.authorized(s.handleTagWallet))
+	mux.HandleFunc("DELETE /api/wallets/{address}/tags/{tag}", s.authorized(s.handleUntagWallet))
+}
+
+// handleWallets returns the most recently seen wallets, only those carrying a wallet
+// tag with tag=, up to limit (default 100)
+func (s *Server) handleWallets(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit := s.capResults(queryInt(q.Get("limit"), 100))
+	var wallets []domain.WalletProfile
+	var err error
+	if tag := q.Get("tag"); tag != "" {
+		var page *domain.WalletPage
+		if page, err = s.backend.QueryWallets(domain.WalletFilter{Tag: tag, Limit: limit}); err == nil {
+			wallets = page.Wallets
+		}
+	} else {
+		wallets, err = s.backend.GetWallets(limit)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if wallets == nil {
+		wallets = []domain.WalletProfile{}
+	}
+	writeJSON(w, http.StatusOK, wallets)
+}
+
+// handleWalletTags returns the tags of the wallet= addresses (repeatable) by lowercased
+// address, or of every tagged wallet without wallet=
+func (s *Server) handleWalletTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := s.backend.GetWalletTags(r.URL.Query()["wallet"])
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, tags)
+}
+
+// handleTagWallet attaches a tag to a wallet and returns the wallet's tags; tagging
+// twice is a no-op
+func (s *Server) handleTagWallet(w http.ResponseWriter, r *http.Request) {
+	address := r.PathValue("address")
+	if err := s.backend.TagWallet(address, r.PathValue("tag")); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.writeWalletTags(w, address)
+}
+
+// handleUntagWallet removes a tag from a wallet and returns the wallet's tags
+func (s *Server) handleUntagWallet(w http.ResponseWriter, r *http.Request) {
+	address := r.PathValue("address")
+	if err := s.backend.UntagWallet(address, r.PathValue("tag")); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.writeWalletTags(w, address)
+}
+
+// writeWalletTags writes a wallet's tags as {"address", "tags"}
+func (s *Server) writeWalletTags(w http.ResponseWriter, address string) {
+	address = strings.ToLower(address)
+	tags, err := s.backend.GetWalletTags([]string{address})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"address": address, "tags": append([]string{}, tags[address]...)})
+}
+
+// handleSearchWallets returns the wallets whose address, trader name or tag matches q,
+// best match first, up to limit (default 50). q may be a partial address or an
+// abbreviation like "0x12…ab34" as shown in alerts.
+func (s *Server) handleSearchWallets(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	results, err := s.backend.SearchWallets(q.Get("q"), s.capResults(queryInt(q.Get("limit"), 50)))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if results == nil {
+		results = []domain.WalletSearchResult{}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
@@ -0,0 +1,113 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/remote"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/services"
+)
+
+func TestRemoteWalletSearch(t *testing.T) {
+	store := storage.NewMemoryPolymarketStore()
+	for _, address := range []string{testWallet, "0x1122000000000000000000000000000000000000", "0x2222222222222222222222222222222222222222"} {
+		store.SaveWallet(domain.WalletProfile{Address: address})
+	}
+	store.SetWalletTraderName("0x2222222222222222222222222222222222222222", "Theo4")
+	svc := services.NewPolymarketService(store, localbus.New(), "")
+	t.Cleanup(svc.Close)
+	ts := httptest.NewServer(NewServer("", "main-token", svc, nil).server.Handler)
+	t.Cleanup(ts.Close)
+
+	client, err := remote.NewClient(domain.RemoteBackendConfig{Enabled: true, URL: ts.URL, Token: "main-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results, err := client.SearchWallets("0x11", 0); err != nil || len(results) != 2 {
+		t.Errorf("0x11 = %+v, %v, want both wallets starting with it", results, err)
+	}
+	if results, err := client.SearchWallets("0x11", 1); err != nil || len(results) != 1 {
+		t.Errorf("0x11 with limit 1 = %+v, %v, want 1 wallet", results, err)
+	}
+	if results, err := client.SearchWallets("theo", 0); err != nil || len(results) != 1 || results[0].TraderName != "Theo4" {
+		t.Errorf("theo = %+v, %v, want the wallet by its trader name", results, err)
+	}
+	if results, err := client.SearchWallets("nobody", 0); err != nil || results == nil || len(results) != 0 {
+		t.Errorf("nobody = %#v, %v, want an empty list", results, err)
+	}
+
+	unauthorized, _ := remote.NewClient(domain.RemoteBackendConfig{Enabled: true, URL: ts.URL, Token: "stolen"})
+	if _, err := unauthorized.SearchWallets("0x11", 0); err == nil {
+		t.Error("searched wallets with an unknown token")
+	}
+}
+
+func TestWalletTagsOverTheAPI(t *testing.T) {
+	store := storage.NewMemoryPolymarketStore()
+	other := "0x2222222222222222222222222222222222222222"
+	store.SaveEvents([]domain.PolymarketEvent{
+		{EventType: domain.PolymarketEventTrade, TradeID: "tagged", WalletAddress: testWallet, AssetID: "1", Price: "0.5", Size: "10"},
+		{EventType: domain.PolymarketEventTrade, TradeID: "other", WalletAddress: other, AssetID: "1", Price: "0.5", Size: "10"},
+	})
+	svc := services.NewPolymarketService(store, localbus.New(), "")
+	t.Cleanup(svc.Close)
+	ts := httptest.NewServer(NewServer("", "main-token", svc, nil).server.Handler)
+	t.Cleanup(ts.Close)
+
+	call := func(method, path string, out any) int {
+		t.Helper()
+		req, _ := http.NewRequest(method, ts.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer main-token")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if out != nil {
+			json.NewDecoder(resp.Body).Decode(out)
+		}
+		return resp.StatusCode
+	}
+
+	var tagged struct {
+		Address string   `json:"address"`
+		Tags    []string `json:"tags"`
+	}
+	if code := call("PUT", "/api/wallets/0xnope/tags/insider", nil); code != http.StatusBadRequest {
+		t.Errorf("tagging an invalid address = %d, want 400", code)
+	}
+	if code := call("PUT", "/api/wallets/"+testWallet+"/tags/MM%20bot", &tagged); code != http.StatusOK || tagged.Address != testWallet || !slices.Equal(tagged.Tags, []string{"mm-bot"}) {
+		t.Fatalf("tagging = %d %+v, want the normalized tag", code, tagged)
+	}
+	call("PUT", "/api/wallets/"+testWallet+"/tags/follow", nil)
+
+	var tags map[string][]string
+	if call("GET", "/api/wallets/tags", &tags); len(tags) != 1 || len(tags[testWallet]) != 2 {
+		t.Errorf("tags = %v, want both tags of the one wallet", tags)
+	}
+	var wallets []domain.WalletProfile
+	if call("GET", "/api/wallets?tag=follow", &wallets); len(wallets) != 1 || wallets[0].Address != testWallet {
+		t.Errorf("wallets tagged follow = %+v, want the tagged wallet", wallets)
+	}
+
+	client, err := remote.NewClient(domain.RemoteBackendConfig{Enabled: true, URL: ts.URL, Token: "main-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events, err := client.Events(domain.PolymarketEventFilter{WalletTag: "mm bot", Limit: 10}); err != nil || len(events) != 1 || events[0].TradeID != "tagged" {
+		t.Errorf("events of mm-bot wallets = %+v, %v, want the tagged wallet's trade", events, err)
+	}
+
+	if code := call("DELETE", "/api/wallets/"+testWallet+"/tags/mm-bot", &tagged); code != http.StatusOK || !slices.Equal(tagged.Tags, []string{"follow"}) {
+		t.Errorf("untagging = %d %+v, want only follow left", code, tagged)
+	}
+	if call("DELETE", "/api/wallets/"+testWallet+"/tags/follow", &tagged); tagged.Tags == nil || len(tagged.Tags) != 0 {
+		t.Errorf("tags after removing the last = %#v, want an empty list", tagged.Tags)
+	}
+}
@@ -0,0 +1,201 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// Settings manages the daemon's watcher: configuration, rules, mutes, the watchlist, the
+// blacklist and filter presets. The desktop app's remote mode changes them through these
+// routes.
+type Settings interface {
+	GetConfig() domain.PolymarketConfig
+	UpdateConfig(config domain.PolymarketConfig)
+	GetSaveFilter() domain.PolymarketEventFilter
+	SetSaveFilter(filter domain.PolymarketEventFilter)
+	GetMutedMarkets() []domain.MarketMute
+	MuteMarket(slug string, duration time.Duration) (*domain.MarketMute, error)
+	UnmuteMarket(slug string) error
+	GetTagRules() []domain.EventTagRule
+	SetTagRules(rules []domain.EventTagRule) error
+	GetLateEntryRules() []domain.LateEntryRule
+	SetLateEntryRules(rules []domain.LateEntryRule) error
+	GetEntityAlertRules() []domain.EntityAlertRule
+	SetEntityAlertRules(rules []domain.EntityAlertRule) error
+	GetEventSamplingRules() []domain.EventSamplingRule
+	SetEventSamplingRules(rules []domain.EventSamplingRule) error
+	GetEventRetention() domain.EventRetention
+	SetEventRetention(retention domain.EventRetention) error
+	GetAutoTune() domain.AutoTuneSettings
+	SetAutoTune(settings domain.AutoTuneSettings) error
+	GetWatchedWallets() []string
+	WatchWallet(address string) error
+	UnwatchWallet(address string) error
+	GetWalletBlacklist() []domain.BlacklistedWallet
+	BlacklistWallet(address, reason string) error
+	UnblacklistWallet(address string) error
+	GetFilterPresets() ([]domain.FilterPreset, error)
+	SaveFilterPreset(name string, filter domain.PolymarketEventFilter) (*domain.FilterPreset, error)
+	RenameFilterPreset(name, newName string) (*domain.FilterPreset, error)
+	DeleteFilterPreset(name string) error
+}
+
+// SetSettings serves the watcher settings under /api/config, /api/rules, /api/mutes,
+// /api/watchlist, /api/blacklist and /api/presets. With per-user tokens only the main
+// token may change them.
+func (s *Server) SetSettings(settings Settings) {
+	s.settings = settings
+}
+
+// registerSettings adds the settings routes; they answer 404 until SetSettings
+func (s *Server) registerSettings(mux *http.ServeMux) {
+	setting(s, mux, "/api/config", Settings.GetConfig, func(st Settings, config domain.PolymarketConfig) error {
+		st.UpdateConfig(config)
+		return nil
+	})
+	setting(s, mux, "/api/config/save-filter", Settings.GetSaveFilter, func(st Settings, filter domain.PolymarketEventFilter) error {
+		st.SetSaveFilter(filter)
+		return nil
+	})
+	setting(s, mux, "/api/rules/tags", Settings.GetTagRules, Settings.SetTagRules)
+	setting(s, mux, "/api/rules/late-entry", Settings.GetLateEntryRules, Settings.SetLateEntryRules)
+	setting(s, mux, "/api/rules/entity-alerts", Settings.GetEntityAlertRules, Settings.SetEntityAlertRules)
+	setting(s, mux, "/api/rules/sampling", Settings.GetEventSamplingRules, Settings.SetEventSamplingRules)
+	setting(s, mux, "/api/retention", Settings.GetEventRetention, Settings.SetEventRetention)
+	setting(s, mux, "/api/autotune", Settings.GetAutoTune, Settings.SetAutoTune)
+
+	mux.HandleFunc("GET /api/mutes", s.settingsRoute(false, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.settings.GetMutedMarkets())
+	}))
+	mux.HandleFunc("PUT /api/mutes/{slug}", s.settingsRoute(true, s.handleMuteMarket))
+	mux.HandleFunc("DELETE /api/mutes/{slug}", s.settingsRoute(true, func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, s.settings.UnmuteMarket(r.PathValue("slug")), s.settings.GetMutedMarkets())
+	}))
+
+	mux.HandleFunc("GET /api/watchlist", s.settingsRoute(false, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.settings.GetWatchedWallets())
+	}))
+	mux.HandleFunc("PUT /api/watchlist/{address}", s.settingsRoute(true, func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, s.settings.WatchWallet(r.PathValue("address")), s.settings.GetWatchedWallets())
+	}))
+	mux.HandleFunc("DELETE /api/watchlist/{address}", s.settingsRoute(true, func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, s.settings.UnwatchWallet(r.PathValue("address")), s.settings.GetWatchedWallets())
+	}))
+
+	mux.HandleFunc("GET /api/blacklist", s.settingsRoute(false, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.settings.GetWalletBlacklist())
+	}))
+	mux.HandleFunc("PUT /api/blacklist/{address}", s.settingsRoute(true, s.handleBlacklistWallet))
+	mux.HandleFunc("DELETE /api/blacklist/{address}", s.settingsRoute(true, func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, s.settings.UnblacklistWallet(r.PathValue("address")), s.settings.GetWalletBlacklist())
+	}))
+
+	mux.HandleFunc("GET /api/presets", s.settingsRoute(false, func(w http.ResponseWriter, r *http.Request) {
+		presets, err := s.settings.GetFilterPresets()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, presets)
+	}))
+	mux.HandleFunc("PUT /api/presets/{name}", s.settingsRoute(true, s.handleSaveFilterPreset))
+	mux.HandleFunc("POST /api/presets/{name}/rename", s.settingsRoute(true, s.handleRenameFilterPreset))
+	mux.HandleFunc("DELETE /api/presets/{name}", s.settingsRoute(true, func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, s.settings.DeleteFilterPreset(r.PathValue("name")), map[string]bool{"ok": true})
+	}))
+}
+
+// setting serves a setting at path: GET returns it, PUT replaces it with the JSON body
+// and returns it as saved
+func setting[T any](s *Server, mux *http.ServeMux, path string, get func(Settings) T, set func(Settings, T) error) {
+	mux.HandleFunc("GET "+path, s.settingsRoute(false, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, get(s.settings))
+	}))
+	mux.HandleFunc("PUT "+path, s.settingsRoute(true, func(w http.ResponseWriter, r *http.Request) {
+		var value T
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+			return
+		}
+		writeResult(w, set(s.settings, value), get(s.settings))
+	}))
+}
+
+// settingsRoute authorizes a settings route. Changes need the main token when per-user
+// tokens are configured, since settings apply to every user.
+func (s *Server) settingsRoute(change bool, next http.HandlerFunc) http.HandlerFunc {
+	return s.authorized(func(w http.ResponseWriter, r *http.Request) {
+		if s.settings == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if change && len(s.users) > 0 && userFrom(r) != domain.DefaultUserID {
+			writeError(w, http.StatusForbidden, "changing daemon settings needs the main API token")
+			return
+		}
+		next(w, r)
+	})
+}
+
+// handleMuteMarket mutes a market or event slug for minutes= (default 60) and returns the mute
+func (s *Server) handleMuteMarket(w http.ResponseWriter, r *http.Request) {
+	minutes := queryInt(r.URL.Query().Get("minutes"), 60)
+	mute, err := s.settings.MuteMarket(r.PathValue("slug"), time.Duration(minutes)*time.Minute)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, mute)
+}
+
+// handleBlacklistWallet blacklists a wallet with the optional {"reason"} body and
+// returns the blacklist
+func (s *Server) handleBlacklistWallet(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+			return
+		}
+	}
+	writeResult(w, s.settings.BlacklistWallet(r.PathValue("address"), body.Reason), s.settings.GetWalletBlacklist())
+}
+
+// handleSaveFilterPreset saves the event filter in the body under the preset name and
+// returns the preset
+func (s *Server) handleSaveFilterPreset(w http.ResponseWriter, r *http.Request) {
+	var filter domain.PolymarketEventFilter
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&filter); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid filter: "+err.Error())
+		return
+	}
+	preset, err := s.settings.SaveFilterPreset(r.PathValue("name"), filter)
+	writeResult(w, err, preset)
+}
+
+// handleRenameFilterPreset renames a preset to the {"name"} body and returns it
+func (s *Server) handleRenameFilterPreset(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+		return
+	}
+	preset, err := s.settings.RenameFilterPreset(r.PathValue("name"), body.Name)
+	writeResult(w, err, preset)
+}
+
+// writeResult writes v, or err as a 400 when a change was rejected
+func writeResult(w http.ResponseWriter, err error, v any) {
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
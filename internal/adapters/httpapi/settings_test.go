@@ -0,0 +1,122 @@
+package httpapi
+
+import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/remote"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/services"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+const testWallet = "0x1111111111111111111111111111111111111111"
+
+// newSettingsClient serves a service's settings as the daemon does and returns a remote
+// client calling them with token
+func newSettingsClient(t *testing.T, users map[string]string, token string) *remote.Client {
+	t.Helper()
+	svc := services.NewPolymarketService(storage.NewMemoryPolymarketStore(), localbus.New(), "")
+	t.Cleanup(svc.Close)
+
+	server := NewServer("", "main-token", svc, nil)
+	server.SetUserTokens(users)
+	server.SetSettings(svc)
+	ts := httptest.NewServer(server.server.Handler)
+	t.Cleanup(ts.Close)
+
+	client, err := remote.NewClient(domain.RemoteBackendConfig{Enabled: true, URL: ts.URL, Token: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestRemoteSettingsRoundTrip(t *testing.T) {
+	client := newSettingsClient(t, nil, "main-token")
+
+	config, err := client.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.MinTradeSize = 2500
+	if err := client.SetConfig(config); err != nil {
+		t.Fatal(err)
+	}
+	if config, _ = client.Config(); config.MinTradeSize != 2500 {
+		t.Errorf("MinTradeSize = %v after SetConfig, want 2500", config.MinTradeSize)
+	}
+
+	if err := client.SetTagRules([]domain.EventTagRule{{Tag: "whale", MinNotional: 50000}}); err != nil {
+		t.Fatal(err)
+	}
+	if rules, _ := client.TagRules(); len(rules) != 1 || rules[0].Tag != "whale" {
+		t.Errorf("tag rules = %+v, want the whale rule", rules)
+	}
+
+	if _, err := client.MuteMarket("will-it-rain", 30); err != nil {
+		t.Fatal(err)
+	}
+	if mutes, _ := client.MutedMarkets(); len(mutes) != 1 {
+		t.Errorf("got %d mutes, want 1", len(mutes))
+	}
+	if err := client.UnmuteMarket("will-it-rain"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.WatchWallet(testWallet); err != nil {
+		t.Fatal(err)
+	}
+	if watched, _ := client.WatchedWallets(); len(watched) != 1 || watched[0] != testWallet {
+		t.Errorf("watchlist = %v, want [%s]", watched, testWallet)
+	}
+
+	if err := client.BlacklistWallet(testWallet, "market maker"); err != nil {
+		t.Fatal(err)
+	}
+	if blacklist, _ := client.WalletBlacklist(); len(blacklist) != 1 || blacklist[0].Reason != "market maker" {
+		t.Errorf("blacklist = %+v, want the wallet with its reason", blacklist)
+	}
+	if err := client.UnblacklistWallet(testWallet); err != nil {
+		t.Fatal(err)
+	}
+	if blacklist, _ := client.WalletBlacklist(); len(blacklist) != 0 {
+		t.Errorf("blacklist = %+v after removal, want it empty", blacklist)
+	}
+
+	if _, err := client.SaveFilterPreset("Big fresh", domain.PolymarketEventFilter{MinSize: 5000, FreshWalletsOnly: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.RenameFilterPreset("big fresh", "Whales"); err != nil {
+		t.Fatal(err)
+	}
+	if presets, _ := client.FilterPresets(); len(presets) != 1 || presets[0].Name != "Whales" || presets[0].Filter.MinSize != 5000 {
+		t.Errorf("presets = %+v, want the renamed preset", presets)
+	}
+
+	if err := client.BlacklistWallet("not-a-wallet", ""); err == nil {
+		t.Error("blacklisting an invalid address succeeded")
+	}
+}
+
+func TestSettingsChangesNeedMainToken(t *testing.T) {
+	client := newSettingsClient(t, map[string]string{"alice-token": "alice"}, "alice-token")
+
+	if _, err := client.WatchedWallets(); err != nil {
+		t.Fatalf("reading settings with a user token: %v", err)
+	}
+	err := client.WatchWallet(testWallet)
+	if err == nil || !strings.Contains(err.Error(), "main API token") {
+		t.Errorf("WatchWallet with a user token = %v, want a main token error", err)
+	}
+}
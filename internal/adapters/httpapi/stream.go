@@ -0,0 +1,126 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// streamClientBuffer is how many events a slow client may fall behind before
+	// further events are dropped for it
+	streamClientBuffer = 256
+
+	// streamKeepAlive is how often a comment is sent to keep idle connections open
+	streamKeepAlive = 25 * time.Second
+)
+
+// streamMessage is an encoded server-sent event
+type streamMessage struct {
+	event string
+	data  []byte
+}
+
+// Stream fans out selected event bus events to clients as server-sent events
+type Stream struct {
+	mu      sync.Mutex
+	topics  map[string]bool
+	clients map[chan streamMessage]bool
+	closed  bool
+}
+
+// NewStream creates a stream forwarding the given event names
+func NewStream(topics []string) *Stream {
+	s := &Stream{topics: make(map[string]bool), clients: make(map[chan streamMessage]bool)}
+	for _, t := range topics {
+		s.topics[t] = true
+	}
+	return s
+}
+
+// Publish forwards an event to connected clients if its name is streamed. It never
+// blocks, so it can be registered as an event bus listener.
+func (s *Stream) Publish(eventName string, data interface{}) {
+	if !s.topics[eventName] {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.clients) == 0 {
+		return
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	for client := range s.clients {
+		select {
+		case client <- streamMessage{event: eventName, data: encoded}:
+		default:
+		}
+	}
+}
+
+// Close disconnects every client
+func (s *Stream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for client := range s.clients {
+		close(client)
+		delete(s.clients, client)
+	}
+}
+
+// ServeHTTP streams events until the client disconnects
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+
+	client := make(chan streamMessage, streamClientBuffer)
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		writeError(w, http.StatusServiceUnavailable, "shutting down")
+		return
+	}
+	s.clients[client] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		if s.clients[client] {
+			delete(s.clients, client)
+			close(client)
+		}
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-client:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.event, msg.data)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// userHeader picks the user of a request when the API runs without authentication
+const userHeader = "X-XTools-User"
+
+// userKey is the request context key holding the calling user's ID
+type userKey struct{}
+
+// SetUserTokens gives each user their own bearer token (token -> user ID). Requests
+// with the main token act as domain.DefaultUserID.
+func (s *Server) SetUserTokens(tokens map[string]string) {
+	s.users = tokens
+}
+
+// authorized resolves the calling user and rejects requests without a valid bearer
+// token, when tokens are configured. Without tokens the X-XTools-User header picks the user.
+// Each user's requests are then counted against their limits.
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := domain.DefaultUserID
+		if s.token != "" || len(s.users) > 0 {
+			var ok bool
+			given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if user, ok = s.userForToken(given); !ok {
+				writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+				return
+			}
+		} else if header := r.Header.Get(userHeader); header != "" {
+			user = header
+		}
+		if !s.admit(w, r, user) {
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), userKey{}, user)))
+	}
+}
+
+// userForToken returns the user a bearer token belongs to. Every token is compared in
+// constant time.
+func (s *Server) userForToken(given string) (string, bool) {
+	user, found := "", false
+	if s.token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(s.token)) == 1 {
+		user, found = domain.DefaultUserID, true
+	}
+	for token, id := range s.users {
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+			user, found = id, true
+		}
+	}
+	return user, found
+}
+
+// userFrom returns the calling user resolved by authorized
+func userFrom(r *http.Request) string {
+	if user, ok := r.Context().Value(userKey{}).(string); ok {
+		return user
+	}
+	return domain.DefaultUserID
+}
+
+// registerUsers adds the calling user's own routes
+func (s *Server) registerUsers(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/me", s.authorized(s.handleMe))
+	mux.HandleFunc("GET /api/me/settings", s.authorized(s.handleGetUserSettings))
+	mux.HandleFunc("PUT /api/me/settings", s.authorized(s.handleSaveUserSettings))
+}
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"user": userFrom(r)})
+}
+
+func (s *Server) handleGetUserSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := s.backend.GetUserSettings(userFrom(r))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, settings)
+}
+
+func (s *Server) handleSaveUserSettings(w http.ResponseWriter, r *http.Request) {
+	var settings domain.UserSettings
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&settings); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid settings: "+err.Error())
+		return
+	}
+	saved, err := s.backend.SaveUserSettings(userFrom(r), settings)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, saved)
+}
@@ -0,0 +1,66 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/services"
+)
+
+func TestUserTokensScopeSettings(t *testing.T) {
+	svc := services.NewPolymarketService(storage.NewMemoryPolymarketStore(), localbus.New(), "")
+	t.Cleanup(svc.Close)
+	server := NewServer("", "main-token", svc, nil)
+	server.SetUserTokens(map[string]string{"alice-token": "alice", "bob-token": "bob"})
+	ts := httptest.NewServer(server.server.Handler)
+	t.Cleanup(ts.Close)
+
+	call := func(method, path, token, body string) (int, []byte) {
+		t.Helper()
+		req, _ := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var data json.RawMessage
+		json.NewDecoder(resp.Body).Decode(&data)
+		return resp.StatusCode, data
+	}
+
+	for token, want := range map[string]string{"main-token": domain.DefaultUserID, "alice-token": "alice"} {
+		if code, body := call("GET", "/api/me", token, ""); code != http.StatusOK || !strings.Contains(string(body), `"`+want+`"`) {
+			t.Errorf("/api/me with %s = %d %s, want %s", token, code, body, want)
+		}
+	}
+	if code, _ := call("GET", "/api/me", "stolen", ""); code != http.StatusUnauthorized {
+		t.Errorf("/api/me with an unknown token = %d, want 401", code)
+	}
+
+	if code, body := call("PUT", "/api/me/settings", "alice-token", `{"watchlist": [" `+testWallet+` "], "filter": {"minSize": 5000}}`); code != http.StatusOK {
+		t.Fatalf("saving alice's settings = %d %s", code, body)
+	}
+	var alice, bob domain.UserSettings
+	_, body := call("GET", "/api/me/settings", "alice-token", "")
+	json.Unmarshal(body, &alice)
+	_, body = call("GET", "/api/me/settings", "bob-token", "")
+	json.Unmarshal(body, &bob)
+	if alice.User != "alice" || alice.Filter.MinSize != 5000 || len(alice.Watchlist) != 1 {
+		t.Errorf("alice's settings = %+v, want her filter and watchlist", alice)
+	}
+	if bob.User != "bob" || bob.Filter.MinSize != 0 || len(bob.Watchlist) != 0 {
+		t.Errorf("bob's settings = %+v, want none of alice's", bob)
+	}
+	if watched := svc.GetWatchedWallets(); len(watched) != 0 {
+		t.Errorf("deployment watchlist = %v, want it untouched by alice", watched)
+	}
+}
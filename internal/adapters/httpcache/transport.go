@@ -0,0 +1,212 @@
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// maxEntryAge is how long an entry is kept without being revalidated
+	maxEntryAge = 7 * 24 * time.Hour
+
+	// maxBodySize skips caching responses larger than this
+	maxBodySize = 1 << 20
+)
+
+// entry is a cached response stored as one JSON file
+type entry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	ContentType  string    `json:"contentType,omitempty"`
+	Body         []byte    `json:"body"`
+	StoredAt     time.Time `json:"storedAt"`
+}
+
+// Transport is an http.RoundTripper that keeps GET responses carrying an ETag or
+// Last-Modified header on disk and revalidates them with conditional requests, so
+// unchanged payloads are answered with 304 Not Modified instead of downloaded again.
+// Callers always see a 200 with the full body.
+type Transport struct {
+	dir  string
+	next http.RoundTripper
+
+	hits     atomic.Int64 // Responses served from disk after a 304
+	misses   atomic.Int64 // Full downloads
+	disabled atomic.Bool  // Set by Disable; requests pass straight through
+}
+
+// New creates a caching transport storing entries in dir. Entries not revalidated
+// for a week are removed. If next is nil, http.DefaultTransport is used.
+func New(dir string, next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Printf("[HTTPCache] Failed to create cache directory: %v", err)
+	}
+	t := &Transport{dir: dir, next: next}
+	go t.prune()
+	return t
+}
+
+// Stats returns how many responses were revalidated from disk and fully downloaded
+func (t *Transport) Stats() (hits, misses int64) {
+	return t.hits.Load(), t.misses.Load()
+}
+
+// Disable stops caching and removes the cached entries. The desktop app and daemon call
+// it while the database is encrypted at rest, since the entries hold the same profile and
+// market payloads in plaintext.
+func (t *Transport) Disable() {
+	t.disabled.Store(true)
+	if err := os.RemoveAll(t.dir); err != nil {
+		log.Printf("[HTTPCache] Failed to remove cache directory: %v", err)
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.disabled.Load() || req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	path := t.path(req.URL.String())
+	cached := t.load(path)
+	if cached != nil {
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		t.hits.Add(1)
+		cached.StoredAt = time.Now()
+		t.save(path, cached)
+		return cachedResponse(req, resp, cached), nil
+	}
+
+	t.misses.Add(1)
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") ||
+		strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) <= maxBodySize {
+		t.save(path, &entry{
+			URL:          req.URL.String(),
+			ETag:         etag,
+			LastModified: lastModified,
+			ContentType:  resp.Header.Get("Content-Type"),
+			Body:         body,
+			StoredAt:     time.Now(),
+		})
+	}
+	return resp, nil
+}
+
+// cachedResponse builds a 200 response from a cached entry
+func cachedResponse(req *http.Request, notModified *http.Response, cached *entry) *http.Response {
+	header := notModified.Header.Clone()
+	if cached.ContentType != "" {
+		header.Set("Content-Type", cached.ContentType)
+	}
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
+
+// path returns the file storing the entry for a URL
+func (t *Transport) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(t.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// load reads a cached entry, or nil if there is none
+func (t *Transport) load(path string) *entry {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		os.Remove(path)
+		return nil
+	}
+	return &e
+}
+
+// save writes an entry through a temporary file so readers never see a partial one
+func (t *Transport) save(path string, e *entry) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(t.dir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	tmp.Close()
+	if err != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// prune removes entries not revalidated within maxEntryAge and leftover temporary files
+func (t *Transport) prune() {
+	files, err := os.ReadDir(t.dir)
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-maxEntryAge)
+	removed := 0
+	for _, f := range files {
+		info, err := f.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		if os.Remove(filepath.Join(t.dir, f.Name())) == nil {
+			removed++
+		}
+	}
+	if removed > 0 {
+		log.Printf("[HTTPCache] Removed %d stale entries", removed)
+	}
+}
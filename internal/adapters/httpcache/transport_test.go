@@ -0,0 +1,91 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// newProfileServer answers with an ETag and 304 to requests revalidating it, counting
+// the conditional requests
+func newProfileServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var conditional atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"trades": 40}`)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &conditional
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	return string(body)
+}
+
+func TestNotModifiedIsServedFromDisk(t *testing.T) {
+	ts, conditional := newProfileServer(t)
+	transport := New(t.TempDir(), nil)
+	client := &http.Client{Transport: transport}
+
+	for range 2 {
+		if body := get(t, client, ts.URL); body != `{"trades": 40}` {
+			t.Errorf("body = %q, want the profile", body)
+		}
+	}
+	if conditional.Load() != 1 {
+		t.Errorf("got %d conditional requests, want the second request revalidated", conditional.Load())
+	}
+	if hits, misses := transport.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("Stats() = %d hits, %d misses, want 1 and 1", hits, misses)
+	}
+}
+
+func TestDisableRemovesEntriesAndStopsCaching(t *testing.T) {
+	ts, conditional := newProfileServer(t)
+	dir := filepath.Join(t.TempDir(), "http-cache")
+	transport := New(dir, nil)
+	client := &http.Client{Transport: transport}
+
+	get(t, client, ts.URL)
+	if entries, _ := os.ReadDir(dir); len(entries) == 0 {
+		t.Fatal("response was not cached")
+	}
+
+	transport.Disable()
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("cache directory still exists after Disable: %v", err)
+	}
+	if body := get(t, client, ts.URL); body != `{"trades": 40}` {
+		t.Errorf("body = %q, want the profile", body)
+	}
+	if conditional.Load() != 0 {
+		t.Error("disabled cache revalidated a response")
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("disabled cache wrote to disk: %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package imagecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultMaxBytes bounds the cache directory when no limit is set
+	DefaultMaxBytes = 100 << 20
+
+	// maxImageBytes skips images larger than this
+	maxImageBytes = 2 << 20
+
+	// maxKnownURLs bounds the image URLs the cache may download
+	maxKnownURLs = 20000
+
+	// fetchTimeout bounds a single download
+	fetchTimeout = 15 * time.Second
+)
+
+// imageTypes maps the content types kept to their file extensions. SVG is left out
+// since it can carry scripts.
+var imageTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"image/avif": ".avif",
+}
+
+// Cache downloads market thumbnails to disk and serves them locally, so they keep
+// showing offline and after the CDN links rot. Only URLs announced with Prefetch are
+// ever downloaded; the least recently served images are evicted past the size limit.
+type Cache struct {
+	dir      string
+	client   *http.Client
+	maxBytes atomic.Int64
+	queue    chan string
+
+	mu       sync.Mutex
+	known    map[string]bool          // URLs seen on market data
+	fetching map[string]chan struct{} // Downloads in progress, closed when done
+
+	hits   atomic.Int64 // Images served from disk
+	misses atomic.Int64 // Images downloaded
+}
+
+// New creates an image cache in dir holding at most maxBytes (0 = DefaultMaxBytes)
+// and starts its background downloader
+func New(dir string, maxBytes int64) *Cache {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		log.Printf("[ImageCache] Failed to create cache directory: %v", err)
+	}
+	c := &Cache{
+		dir:      dir,
+		client:   &http.Client{Timeout: fetchTimeout},
+		queue:    make(chan string, 100),
+		known:    make(map[string]bool),
+		fetching: make(map[string]chan struct{}),
+	}
+	c.SetMaxBytes(maxBytes)
+	go c.prefetchWorker()
+	return c
+}
+
+// Prefetch allows an image URL to be cached and downloads it in the background if it
+// isn't cached yet. Invalid URLs are ignored; when the queue is full the image is
+// downloaded on first view instead.
+func (c *Cache) Prefetch(imageURL string) {
+	if !validURL(imageURL) {
+		return
+	}
+	c.mu.Lock()
+	if c.known[imageURL] {
+		c.mu.Unlock()
+		return
+	}
+	if len(c.known) >= maxKnownURLs {
+		c.known = make(map[string]bool)
+	}
+	c.known[imageURL] = true
+	c.mu.Unlock()
+
+	if c.lookup(imageURL) != "" {
+		return
+	}
+	select {
+	case c.queue <- imageURL:
+	default:
+	}
+}
+
+// ServeHTTP serves the image at the url query parameter from the cache, downloading it
+// first if it is known. Unknown URLs are redirected to, so the client loads them itself.
+func (c *Cache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	imageURL := r.URL.Query().Get("url")
+	if !validURL(imageURL) {
+		http.Error(w, "invalid image url", http.StatusBadRequest)
+		return
+	}
+
+	path := c.lookup(imageURL)
+	if path == "" {
+		c.mu.Lock()
+		known := c.known[imageURL]
+		c.mu.Unlock()
+		if !known {
+			http.Redirect(w, r, imageURL, http.StatusFound)
+			return
+		}
+		var err error
+		if path, err = c.fetch(r.Context(), imageURL); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	} else {
+		c.hits.Add(1)
+		now := time.Now()
+		os.Chtimes(path, now, now) // Marks the image recently used for eviction
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		http.Error(w, "image unavailable", http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+	for contentType, ext := range imageTypes {
+		if strings.HasSuffix(path, ext) {
+			w.Header().Set("Content-Type", contentType)
+		}
+	}
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", time.Time{}, f)
+}
+
+// cacheKey names the file of an image URL
+func cacheKey(imageURL string) string {
+	sum := sha256.Sum256([]byte(imageURL))
+	return hex.EncodeToString(sum[:])
+}
+
+// validURL reports whether an image URL is an absolute http(s) URL
+func validURL(imageURL string) bool {
+	u, err := url.Parse(imageURL)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
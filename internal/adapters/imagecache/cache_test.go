@@ -0,0 +1,135 @@
+package imagecache
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// pngImage is a PNG signature padded to a recognizable thumbnail
+var pngImage = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{1}, 64)...)
+
+// newImageServer serves pngImage at /thumb.png and an SVG at /logo.svg, counting requests
+func newImageServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var requests atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/thumb.png":
+			w.Write(pngImage)
+		case "/logo.svg":
+			w.Header().Set("Content-Type", "image/svg+xml")
+			io.WriteString(w, `<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &requests
+}
+
+// serve requests imageURL from the cache without following redirects
+func serve(c *Cache, imageURL string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/market-image?url="+url.QueryEscape(imageURL), nil))
+	return rec
+}
+
+func TestUnknownImagesRedirect(t *testing.T) {
+	ts, requests := newImageServer(t)
+	c := New(t.TempDir(), 0)
+
+	rec := serve(c, ts.URL+"/thumb.png")
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != ts.URL+"/thumb.png" {
+		t.Errorf("unknown image: status %d, location %q, want a redirect to the CDN", rec.Code, rec.Header().Get("Location"))
+	}
+	if requests.Load() != 0 {
+		t.Error("an image never seen on market data was downloaded")
+	}
+	if rec := serve(c, "javascript:alert(1)"); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid url: status %d, want 400", rec.Code)
+	}
+}
+
+func TestKnownImagesAreDownloadedOnceAndServedFromDisk(t *testing.T) {
+	ts, requests := newImageServer(t)
+	c := New(t.TempDir(), 0)
+	imageURL := ts.URL + "/thumb.png"
+	c.mu.Lock()
+	c.known[imageURL] = true
+	c.mu.Unlock()
+
+	for range 2 {
+		rec := serve(c, imageURL)
+		if rec.Code != http.StatusOK || !bytes.Equal(rec.Body.Bytes(), pngImage) {
+			t.Fatalf("status %d, body %d bytes, want the image", rec.Code, rec.Body.Len())
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+			t.Errorf("Content-Type = %q, want image/png", ct)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("image server got %d requests, want 1", requests.Load())
+	}
+	if stats := c.Stats(); stats.Images != 1 || stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Stats() = %+v, want 1 image, 1 hit and 1 miss", stats)
+	}
+}
+
+func TestSVGImagesAreNotCached(t *testing.T) {
+	ts, _ := newImageServer(t)
+	c := New(t.TempDir(), 0)
+	imageURL := ts.URL + "/logo.svg"
+	c.mu.Lock()
+	c.known[imageURL] = true
+	c.mu.Unlock()
+
+	if rec := serve(c, imageURL); rec.Code != http.StatusBadGateway {
+		t.Errorf("status %d, want 502 for an SVG", rec.Code)
+	}
+	if c.lookup(imageURL) != "" {
+		t.Error("SVG was cached")
+	}
+}
+
+func TestEvictRemovesLeastRecentlyUsedFirst(t *testing.T) {
+	dir := t.TempDir()
+	c := New(dir, 0)
+	c.maxBytes.Store(250)
+
+	// Four 100 byte images, image-0 used longest ago
+	now := time.Now()
+	for i, name := range []string{"image-0.png", "image-1.png", "image-2.png", "image-3.png"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, bytes.Repeat([]byte{1}, 100), 0644); err != nil {
+			t.Fatal(err)
+		}
+		usedAt := now.Add(time.Duration(i-4) * time.Hour)
+		os.Chtimes(path, usedAt, usedAt)
+	}
+
+	c.evict()
+	for i, name := range []string{"image-0.png", "image-1.png", "image-2.png", "image-3.png"} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if kept, want := err == nil, i >= 2; kept != want {
+			t.Errorf("%s kept = %v, want %v", name, kept, want)
+		}
+	}
+	if stats := c.Stats(); stats.Bytes > 250*9/10 {
+		t.Errorf("cache holds %d bytes after eviction, want at most %d", stats.Bytes, 250*9/10)
+	}
+}
@@ -0,0 +1,82 @@
+package imagecache
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// cachedFile is an image file with its size and last use
+type cachedFile struct {
+	path   string
+	size   int64
+	usedAt time.Time
+}
+
+// SetMaxBytes changes the size limit (0 = DefaultMaxBytes), evicting images if needed
+func (c *Cache) SetMaxBytes(maxBytes int64) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	if c.maxBytes.Swap(maxBytes) > maxBytes {
+		go c.evict()
+	}
+}
+
+// Stats returns the number and total size of cached images, and how many were served
+// from disk and downloaded
+func (c *Cache) Stats() domain.ImageCacheStats {
+	stats := domain.ImageCacheStats{MaxBytes: c.maxBytes.Load(), Hits: c.hits.Load(), Misses: c.misses.Load()}
+	for _, f := range c.files() {
+		stats.Images++
+		stats.Bytes += f.size
+	}
+	return stats
+}
+
+// files lists the cached images
+func (c *Cache) files() []cachedFile {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil
+	}
+	var files []cachedFile
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || strings.HasSuffix(e.Name(), ".tmp") {
+			continue
+		}
+		files = append(files, cachedFile{path: filepath.Join(c.dir, e.Name()), size: info.Size(), usedAt: info.ModTime()})
+	}
+	return files
+}
+
+// evict removes the least recently used images until the cache is 10% under its limit
+func (c *Cache) evict() {
+	files := c.files()
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	limit := c.maxBytes.Load()
+	if total <= limit {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].usedAt.Before(files[j].usedAt) })
+	removed := 0
+	for _, f := range files {
+		if total <= limit*9/10 {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			total -= f.size
+			removed++
+		}
+	}
+	log.Printf("[ImageCache] Evicted %d images to stay under %d MB", removed, limit>>20)
+}
@@ -0,0 +1,123 @@
+package imagecache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// prefetchWorker downloads queued images one at a time
+func (c *Cache) prefetchWorker() {
+	for imageURL := range c.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		if _, err := c.fetch(ctx, imageURL); err != nil {
+			log.Printf("[ImageCache] Failed to cache %s: %v", imageURL, err)
+		}
+		cancel()
+	}
+}
+
+// fetch downloads an image unless it is cached or being downloaded, and returns its path
+func (c *Cache) fetch(ctx context.Context, imageURL string) (string, error) {
+	key := cacheKey(imageURL)
+	c.mu.Lock()
+	if done, ok := c.fetching[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if path := c.lookup(imageURL); path != "" {
+			return path, nil
+		}
+		return "", fmt.Errorf("image download failed")
+	}
+	if path := c.lookup(imageURL); path != "" {
+		c.mu.Unlock()
+		return path, nil
+	}
+	done := make(chan struct{})
+	c.fetching[key] = done
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.fetching, key)
+		c.mu.Unlock()
+		close(done)
+	}()
+
+	path, err := c.download(ctx, imageURL, key)
+	if err != nil {
+		return "", err
+	}
+	c.misses.Add(1)
+	c.evict()
+	return path, nil
+}
+
+// download saves an image through a temporary file so readers never see a partial one
+func (c *Cache) download(ctx context.Context, imageURL, key string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("image server returned %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if len(body) > maxImageBytes {
+		return "", fmt.Errorf("image is larger than %d bytes", maxImageBytes)
+	}
+	ext, ok := imageTypes[http.DetectContentType(body)]
+	if !ok {
+		// Sniffing doesn't know every format, e.g. AVIF; fall back to the declared type
+		declared, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+		if ext, ok = imageTypes[strings.TrimSpace(declared)]; !ok {
+			return "", fmt.Errorf("not a supported image type")
+		}
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "image-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	_, err = tmp.Write(body)
+	tmp.Close()
+	path := filepath.Join(c.dir, key+ext)
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return path, nil
+}
+
+// lookup returns the file of a cached image, or "" if it isn't cached
+func (c *Cache) lookup(imageURL string) string {
+	key := cacheKey(imageURL)
+	for _, ext := range imageTypes {
+		path := filepath.Join(c.dir, key+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
@@ -10,8 +10,8 @@ import (
 	"strings"
 	"time"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // OpenAIClient implements LLMProvider for OpenAI-compatible APIs
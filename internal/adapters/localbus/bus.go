@@ -0,0 +1,96 @@
+package localbus
+
+import (
+	"sync"
+
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+// Listener receives every emitted event, e.g. to stream them to remote clients
+type Listener func(eventName string, data interface{})
+
+// Bus implements EventBus in process, without a GUI runtime. It is used by the
+// headless daemon in place of the Wails event bus.
+type Bus struct {
+	mu        sync.RWMutex
+	nextID    int
+	handlers  map[string]map[int]ports.EventHandler
+	listeners map[int]Listener
+}
+
+// New creates an in-process event bus
+func New() *Bus {
+	return &Bus{
+		handlers:  make(map[string]map[int]ports.EventHandler),
+		listeners: make(map[int]Listener),
+	}
+}
+
+// Emit notifies the handlers subscribed to the event and every listener
+func (b *Bus) Emit(eventName string, data interface{}) {
+	b.mu.RLock()
+	handlers := make([]ports.EventHandler, 0, len(b.handlers[eventName]))
+	for _, h := range b.handlers[eventName] {
+		handlers = append(handlers, h)
+	}
+	listeners := make([]Listener, 0, len(b.listeners))
+	for _, l := range b.listeners {
+		listeners = append(listeners, l)
+	}
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		go h(data) // Run handlers in goroutines to avoid blocking, like the Wails bus
+	}
+	for _, l := range listeners {
+		l(eventName, data)
+	}
+}
+
+// EmitTo emits the event both with the account prefix and without it
+func (b *Bus) EmitTo(accountID string, eventName string, data interface{}) {
+	b.Emit(accountID+":"+eventName, data)
+	b.Emit(eventName, data)
+}
+
+// Subscribe registers a handler for an event and returns a function removing it
+func (b *Bus) Subscribe(eventName string, handler ports.EventHandler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	if b.handlers[eventName] == nil {
+		b.handlers[eventName] = make(map[int]ports.EventHandler)
+	}
+	b.handlers[eventName][id] = handler
+
+	return func() {
+		b.mu.Lock()
+		delete(b.handlers[eventName], id)
+		b.mu.Unlock()
+	}
+}
+
+// Unsubscribe is a no-op: functions can't be compared, use the function Subscribe returns
+func (b *Bus) Unsubscribe(string, ports.EventHandler) {}
+
+// Listen registers a listener for every event and returns a function removing it.
+// Listeners are called synchronously and must not block.
+func (b *Bus) Listen(listener Listener) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.listeners[id] = listener
+
+	return func() {
+		b.mu.Lock()
+		delete(b.listeners, id)
+		b.mu.Unlock()
+	}
+}
+
+// Ensure Bus implements EventBus interface
+var _ ports.EventBus = (*Bus)(nil)
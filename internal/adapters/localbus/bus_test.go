@@ -0,0 +1,40 @@
+package localbus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmitReachesHandlersAndListeners(t *testing.T) {
+	bus := New()
+	handled := make(chan interface{}, 1)
+	unsubscribe := bus.Subscribe("polymarket:event", func(data interface{}) { handled <- data })
+
+	var heard []string
+	stopListening := bus.Listen(func(name string, data interface{}) { heard = append(heard, name) })
+
+	bus.EmitTo("acct", "polymarket:event", "trade-1")
+	select {
+	case data := <-handled:
+		if data != "trade-1" {
+			t.Errorf("handler got %v, want trade-1", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler was not called")
+	}
+	if len(heard) != 2 || heard[0] != "acct:polymarket:event" || heard[1] != "polymarket:event" {
+		t.Errorf("listener heard %v, want the prefixed and plain events", heard)
+	}
+
+	unsubscribe()
+	stopListening()
+	bus.Emit("polymarket:event", "trade-2")
+	select {
+	case data := <-handled:
+		t.Errorf("removed handler got %v", data)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if len(heard) != 2 {
+		t.Errorf("removed listener heard %v", heard[2:])
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 // TelegramNotifier implements NotificationSender for Telegram
@@ -49,7 +49,11 @@ func (t *TelegramNotifier) Send(ctx context.Context, content domain.Notification
 		return nil // Silently skip if not configured
 	}
 
-	return t.sendMessageToAll(ctx, content.Message)
+	var markup models.ReplyMarkup
+	if content.AlertID != "" {
+		markup = ackKeyboard(content.AlertID)
+	}
+	return t.sendMessageToAll(ctx, content.Message, markup)
 }
 
 // SendTest sends a test notification to verify configuration
@@ -59,7 +63,18 @@ func (t *TelegramNotifier) SendTest(ctx context.Context) error {
 	}
 
 	testContent := domain.NewTestNotification()
-	return t.sendMessageToAll(ctx, testContent.Message)
+	return t.sendMessageToAll(ctx, testContent.Message, nil)
+}
+
+// SendTo sends a plain text message to a single chat, configured or not
+func (t *TelegramNotifier) SendTo(ctx context.Context, chatID, text string) error {
+	if t.bot == nil {
+		return &NotificationError{Message: "Telegram bot not initialized"}
+	}
+	if _, err := t.bot.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text}); err != nil {
+		return &NotificationError{Message: "Failed to send Telegram message", Err: err}
+	}
+	return nil
 }
 
 // IsConfigured returns true if the notifier is properly configured
@@ -79,8 +94,8 @@ func (t *TelegramNotifier) UpdateConfig(botToken string, chatIDs []string) {
 	t.initBot()
 }
 
-// sendMessageToAll sends a message to all configured chat IDs
-func (t *TelegramNotifier) sendMessageToAll(ctx context.Context, text string) error {
+// sendMessageToAll sends a message with optional buttons to all configured chat IDs
+func (t *TelegramNotifier) sendMessageToAll(ctx context.Context, text string, markup models.ReplyMarkup) error {
 	if t.bot == nil {
 		return &NotificationError{Message: "Telegram bot not initialized"}
 	}
@@ -94,9 +109,10 @@ func (t *TelegramNotifier) sendMessageToAll(ctx context.Context, text string) er
 		}
 
 		params := &bot.SendMessageParams{
-			ChatID:    chatID,
-			Text:      text,
-			ParseMode: models.ParseModeHTML,
+			ChatID:      chatID,
+			Text:        text,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: markup,
 		}
 
 		_, err := t.bot.SendMessage(ctx, params)
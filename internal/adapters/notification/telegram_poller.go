@@ -0,0 +1,139 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// ackCallbackPrefix starts the callback data of alert ack buttons, followed by the alert ID
+const ackCallbackPrefix = "ack:"
+
+// TelegramUpdates are the updates a poller handles; nil callbacks are ignored
+type TelegramUpdates struct {
+	// OnStart is called for every chat that sends /start and returns the reply sent back
+	OnStart func(domain.TelegramChatRegistration) string
+	// OnAck is called when someone presses an alert's ack button and returns the answer
+	// shown to them
+	OnAck func(alertID, user string) string
+}
+
+// TelegramPoller long-polls a bot for /start messages, so chats can register
+// themselves, and for presses of alert ack buttons. Polling needs no public URL,
+// which suits a desktop app. Each bot must have a single poller.
+type TelegramPoller struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// StartTelegramPoller starts polling the bot for the updates that have a callback
+func StartTelegramPoller(botToken string, updates TelegramUpdates) (*TelegramPoller, error) {
+	opts := []bot.Option{
+		bot.WithSkipGetMe(),
+		bot.WithDefaultHandler(func(context.Context, *bot.Bot, *models.Update) {}),
+		bot.WithErrorsHandler(func(err error) {
+			log.Printf("[TelegramNotifier] Polling error: %v", err)
+		}),
+	}
+	if updates.OnStart != nil {
+		opts = append(opts, bot.WithMessageTextHandler("/start", bot.MatchTypePrefix, startHandler(updates.OnStart)))
+	}
+	if updates.OnAck != nil {
+		opts = append(opts, bot.WithCallbackQueryDataHandler(ackCallbackPrefix, bot.MatchTypePrefix, ackHandler(updates.OnAck)))
+	}
+	b, err := bot.New(botToken, opts...)
+	if err != nil {
+		return nil, &NotificationError{Message: "Failed to create Telegram bot", Err: err}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &TelegramPoller{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		// Updates can't be polled while a webhook is set on the bot
+		if _, err := b.DeleteWebhook(ctx, &bot.DeleteWebhookParams{}); err != nil {
+			log.Printf("[TelegramNotifier] Failed to remove bot webhook: %v", err)
+		}
+		b.Start(ctx)
+	}()
+	return p, nil
+}
+
+// Stop ends polling and waits for the poller to exit
+func (p *TelegramPoller) Stop() {
+	p.cancel()
+	<-p.done
+}
+
+// startHandler registers the chat that sent /start and replies to it
+func startHandler(onStart func(domain.TelegramChatRegistration) string) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if update.Message == nil {
+			return
+		}
+		chat := update.Message.Chat
+		reply := onStart(domain.TelegramChatRegistration{
+			ChatID:      strconv.FormatInt(chat.ID, 10),
+			ChatType:    string(chat.Type),
+			Name:        chatName(chat),
+			Username:    chat.Username,
+			RequestedAt: time.Now(),
+		})
+		if reply == "" {
+			return
+		}
+		if _, err := b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chat.ID, Text: reply}); err != nil {
+			log.Printf("[TelegramNotifier] Failed to reply to chat %d: %v", chat.ID, err)
+		}
+	}
+}
+
+// ackHandler acknowledges the pressed alert, answers the press and removes the
+// button from the message
+func ackHandler(onAck func(alertID, user string) string) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		query := update.CallbackQuery
+		if query == nil {
+			return
+		}
+		answer := onAck(strings.TrimPrefix(query.Data, ackCallbackPrefix), userName(query.From))
+		if _, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID, Text: answer}); err != nil {
+			log.Printf("[TelegramNotifier] Failed to answer ack: %v", err)
+		}
+		if msg := query.Message.Message; msg != nil {
+			if _, err := b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{ChatID: msg.Chat.ID, MessageID: msg.ID}); err != nil {
+				log.Printf("[TelegramNotifier] Failed to remove ack button: %v", err)
+			}
+		}
+	}
+}
+
+// ackKeyboard is the inline keyboard with an alert's ack button
+func ackKeyboard(alertID string) models.ReplyMarkup {
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{{
+		{Text: "✅ Acknowledge", CallbackData: ackCallbackPrefix + alertID},
+	}}}
+}
+
+// chatName returns a group's title or a user's full name
+func chatName(chat models.Chat) string {
+	if chat.Title != "" {
+		return chat.Title
+	}
+	return strings.TrimSpace(chat.FirstName + " " + chat.LastName)
+}
+
+// userName returns a user's @username, or their full name if they have none
+func userName(user models.User) string {
+	if user.Username != "" {
+		return "@" + user.Username
+	}
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
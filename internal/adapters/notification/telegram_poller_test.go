@@ -0,0 +1,111 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// callsTo returns the calls of one API method, in order
+func (f *fakeTelegram) callsTo(method string) []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []apiCall
+	for _, call := range f.calls {
+		if call.method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func TestStartHandlerRegistersChat(t *testing.T) {
+	api := newFakeTelegram(t)
+	var got []domain.TelegramChatRegistration
+	handler := startHandler(func(chat domain.TelegramChatRegistration) string {
+		got = append(got, chat)
+		return "Waiting for approval"
+	})
+
+	handler(context.Background(), api.bot(t, "1:main"), &models.Update{Message: &models.Message{
+		Text: "/start",
+		Chat: models.Chat{ID: -1001, Type: models.ChatTypeSupergroup, Title: "Desk", Username: "desk_chat"},
+	}})
+	if len(got) != 1 {
+		t.Fatalf("OnStart called %d times, want once", len(got))
+	}
+	if c := got[0]; c.ChatID != "-1001" || c.ChatType != "supergroup" || c.Name != "Desk" || c.Username != "desk_chat" || c.RequestedAt.IsZero() {
+		t.Errorf("registration = %+v, want the group's ID, type, title and username", c)
+	}
+	replies := api.callsTo("sendMessage")
+	if len(replies) != 1 || replies[0].form["chat_id"] != "-1001" || replies[0].form["text"] != "Waiting for approval" {
+		t.Errorf("replies = %+v, want the OnStart answer sent to the chat", replies)
+	}
+
+	// Private chats are named after the user, and an empty answer is not sent
+	handler = startHandler(func(chat domain.TelegramChatRegistration) string {
+		got = append(got, chat)
+		return ""
+	})
+	handler(context.Background(), api.bot(t, "1:main"), &models.Update{Message: &models.Message{
+		Text: "/start",
+		Chat: models.Chat{ID: 42, Type: models.ChatTypePrivate, FirstName: "Ada", LastName: "Lovelace"},
+	}})
+	if got[1].Name != "Ada Lovelace" {
+		t.Errorf("private chat name = %q, want the user's full name", got[1].Name)
+	}
+	if replies := api.callsTo("sendMessage"); len(replies) != 1 {
+		t.Errorf("sent %d replies, want none for an empty answer", len(replies)-1)
+	}
+}
+
+func TestAckHandlerAcknowledgesAndRemovesButton(t *testing.T) {
+	api := newFakeTelegram(t)
+	var alertID, user string
+	handler := ackHandler(func(id, by string) string {
+		alertID, user = id, by
+		return "Acknowledged"
+	})
+
+	handler(context.Background(), api.bot(t, "1:main"), &models.Update{CallbackQuery: &models.CallbackQuery{
+		ID:      "query-1",
+		Data:    ackCallbackPrefix + "alert-7",
+		From:    models.User{Username: "ada", FirstName: "Ada"},
+		Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 9, Chat: models.Chat{ID: 100}}},
+	}})
+	if alertID != "alert-7" || user != "@ada" {
+		t.Errorf("OnAck(%q, %q), want alert-7 by @ada", alertID, user)
+	}
+	answers := api.callsTo("answerCallbackQuery")
+	if len(answers) != 1 || answers[0].form["callback_query_id"] != "query-1" || answers[0].form["text"] != "Acknowledged" {
+		t.Errorf("answers = %+v, want the OnAck answer for query-1", answers)
+	}
+	edits := api.callsTo("editMessageReplyMarkup")
+	if len(edits) != 1 || edits[0].form["chat_id"] != "100" || edits[0].form["message_id"] != "9" {
+		t.Errorf("edits = %+v, want the button removed from message 9", edits)
+	}
+
+	// Users without a username are named, and old messages are left alone
+	handler(context.Background(), api.bot(t, "1:main"), &models.Update{CallbackQuery: &models.CallbackQuery{
+		ID: "query-2", Data: ackCallbackPrefix + "alert-8", From: models.User{FirstName: "Grace", LastName: "Hopper"},
+	}})
+	if user != "Grace Hopper" {
+		t.Errorf("user = %q, want the full name", user)
+	}
+	if edits := api.callsTo("editMessageReplyMarkup"); len(edits) != 1 {
+		t.Errorf("edited %d messages, want none for an inaccessible message", len(edits)-1)
+	}
+}
+
+func TestAckKeyboardCarriesAlertID(t *testing.T) {
+	keyboard, ok := ackKeyboard("alert-7").(*models.InlineKeyboardMarkup)
+	if !ok || len(keyboard.InlineKeyboard) != 1 || len(keyboard.InlineKeyboard[0]) != 1 {
+		t.Fatalf("ackKeyboard() = %#v, want a single button", keyboard)
+	}
+	if data := keyboard.InlineKeyboard[0][0].CallbackData; data != "ack:alert-7" {
+		t.Errorf("callback data = %q, want ack:alert-7", data)
+	}
+}
@@ -0,0 +1,172 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// routedBot is a configured bot with its notifier
+type routedBot struct {
+	config   domain.TelegramBotConfig
+	notifier *TelegramNotifier
+}
+
+// TelegramRouter implements NotificationSender over several Telegram bots, sending each
+// notification through the bots its type is routed to
+type TelegramRouter struct {
+	mu   sync.RWMutex
+	bots []routedBot
+}
+
+// NewTelegramRouter creates a router for the given bots
+func NewTelegramRouter(bots []domain.TelegramBotConfig) *TelegramRouter {
+	r := &TelegramRouter{}
+	r.UpdateBots(bots)
+	return r
+}
+
+// UpdateBots replaces the bots, keeping the clients of bots whose token is unchanged
+func (r *TelegramRouter) UpdateBots(bots []domain.TelegramBotConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing := make(map[string]*TelegramNotifier, len(r.bots))
+	for _, b := range r.bots {
+		existing[b.config.Token] = b.notifier
+	}
+	routed := make([]routedBot, 0, len(bots))
+	for _, config := range bots {
+		notifier, ok := existing[config.Token]
+		if ok {
+			notifier.chatIDs = config.ChatIDs
+			delete(existing, config.Token)
+		} else {
+			notifier = NewTelegramNotifier(config.Token, config.ChatIDs)
+		}
+		routed = append(routed, routedBot{config: config, notifier: notifier})
+	}
+	r.bots = routed
+}
+
+// Send delivers a notification through every bot its type is routed to
+func (r *TelegramRouter) Send(ctx context.Context, content domain.NotificationContent) error {
+	var errs []error
+	for _, b := range r.routed(content.EventType) {
+		if err := b.notifier.Send(ctx, content); err != nil {
+			errs = append(errs, &domain.NotifyError{Channel: b.channel(), Err: rateLimited(err)})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Channels returns the channels a notification type is routed to, one per bot, e.g.
+// "telegram:default"
+func (r *TelegramRouter) Channels(eventType domain.NotificationEventType) []string {
+	var channels []string
+	for _, b := range r.routed(eventType) {
+		channels = append(channels, b.channel())
+	}
+	return channels
+}
+
+// SendVia delivers a notification through one channel returned by Channels
+func (r *TelegramRouter) SendVia(ctx context.Context, channel string, content domain.NotificationContent) error {
+	r.mu.RLock()
+	var notifier *TelegramNotifier
+	for _, b := range r.bots {
+		if b.channel() == channel {
+			notifier = b.notifier
+		}
+	}
+	r.mu.RUnlock()
+	if notifier == nil {
+		return &NotificationError{Message: "Unknown notification channel " + channel}
+	}
+	if err := notifier.Send(ctx, content); err != nil {
+		return &domain.NotifyError{Channel: channel, Err: rateLimited(err)}
+	}
+	return nil
+}
+
+// rateLimited turns a Telegram 429 into domain.UpstreamRateLimited, keeping other errors
+func rateLimited(err error) error {
+	var tooMany *bot.TooManyRequestsError
+	if errors.As(err, &tooMany) {
+		return &domain.UpstreamRateLimited{Service: "telegram", RetryAfter: time.Duration(tooMany.RetryAfter) * time.Second}
+	}
+	return err
+}
+
+// SendTest sends a test notification through every bot
+func (r *TelegramRouter) SendTest(ctx context.Context) error {
+	if !r.IsConfigured() {
+		return &NotificationError{Message: "Telegram is not configured. Please provide a bot token and at least one chat ID."}
+	}
+	var errs []error
+	for _, b := range r.routed(domain.NotificationEventTest) {
+		if !b.notifier.IsConfigured() {
+			continue
+		}
+		if err := b.notifier.SendTest(ctx); err != nil {
+			errs = append(errs, &NotificationError{Message: "Bot " + b.config.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SendTo sends a plain text message to one chat through the named bot
+func (r *TelegramRouter) SendTo(ctx context.Context, botName, chatID, text string) error {
+	r.mu.RLock()
+	var notifier *TelegramNotifier
+	for _, b := range r.bots {
+		if b.config.Name == botName {
+			notifier = b.notifier
+		}
+	}
+	r.mu.RUnlock()
+	if notifier == nil {
+		return &NotificationError{Message: "Unknown Telegram bot " + botName}
+	}
+	return notifier.SendTo(ctx, chatID, text)
+}
+
+// IsConfigured returns true if any bot can send
+func (r *TelegramRouter) IsConfigured() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, b := range r.bots {
+		if b.notifier.IsConfigured() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetChannel returns the notification channel type
+func (r *TelegramRouter) GetChannel() domain.NotificationChannel {
+	return domain.NotificationChannelTelegram
+}
+
+// channel names the bot as a delivery channel
+func (b routedBot) channel() string {
+	return "telegram:" + b.config.Name
+}
+
+// routed returns the bots a notification type is routed to
+func (r *TelegramRouter) routed(eventType domain.NotificationEventType) []routedBot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var bots []routedBot
+	for _, b := range r.bots {
+		if b.config.Routes(eventType) {
+			bots = append(bots, b)
+		}
+	}
+	return bots
+}
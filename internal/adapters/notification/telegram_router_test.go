@@ -0,0 +1,207 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// apiCall is a request the fake Telegram API received
+type apiCall struct {
+	token  string
+	method string
+	form   map[string]string
+}
+
+// fakeTelegram is a Telegram Bot API that records calls and rate limits the chats
+// in limited
+type fakeTelegram struct {
+	mu      sync.Mutex
+	calls   []apiCall
+	limited map[string]bool
+	url     string
+}
+
+func newFakeTelegram(t *testing.T) *fakeTelegram {
+	t.Helper()
+	f := &fakeTelegram{limited: make(map[string]bool)}
+	ts := httptest.NewServer(f)
+	t.Cleanup(ts.Close)
+	f.url = ts.URL
+	return f
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Paths are /bot<token>/<method>
+	token, method, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/bot"), "/")
+	call := apiCall{token: token, method: method, form: make(map[string]string)}
+	if err := r.ParseMultipartForm(1 << 20); err == nil {
+		for key, values := range r.MultipartForm.Value {
+			call.form[key] = values[0]
+		}
+	}
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	limited := f.limited[call.form["chat_id"]]
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case limited:
+		io.WriteString(w, `{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":5}}`)
+	case method == "answerCallbackQuery":
+		io.WriteString(w, `{"ok":true,"result":true}`)
+	default:
+		io.WriteString(w, `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`)
+	}
+}
+
+// bot returns a client for token that talks to the fake API
+func (f *fakeTelegram) bot(t *testing.T, token string) *bot.Bot {
+	t.Helper()
+	b, err := bot.New(token, bot.WithServerURL(f.url), bot.WithSkipGetMe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// sent returns the chats each token sent a message to, in order
+func (f *fakeTelegram) sent() map[string][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sent := make(map[string][]string)
+	for _, call := range f.calls {
+		if call.method == "sendMessage" {
+			sent[call.token] = append(sent[call.token], call.form["chat_id"])
+		}
+	}
+	return sent
+}
+
+func (f *fakeTelegram) reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = nil
+}
+
+// newTestRouter creates a router for bots whose notifiers talk to the fake API
+func newTestRouter(t *testing.T, api *fakeTelegram, bots []domain.TelegramBotConfig) *TelegramRouter {
+	t.Helper()
+	r := NewTelegramRouter(bots)
+	for _, b := range r.bots {
+		b.notifier.bot = api.bot(t, b.config.Token)
+	}
+	return r
+}
+
+var testBots = []domain.TelegramBotConfig{
+	{Name: "default", Token: "1:main", ChatIDs: []string{"100", "101"}},
+	{Name: "whales", Token: "2:whales", ChatIDs: []string{"200"}, EventTypes: []domain.NotificationEventType{domain.NotificationEventBigTrade}},
+}
+
+func TestRouterSendsThroughRoutedBots(t *testing.T) {
+	api := newFakeTelegram(t)
+	r := newTestRouter(t, api, testBots)
+
+	if got := r.Channels(domain.NotificationEventFreshWallet); !slices.Equal(got, []string{"telegram:default"}) {
+		t.Errorf("fresh wallet channels = %v, want only the default bot", got)
+	}
+	if got := r.Channels(domain.NotificationEventBigTrade); !slices.Equal(got, []string{"telegram:default", "telegram:whales"}) {
+		t.Errorf("big trade channels = %v, want both bots", got)
+	}
+
+	if err := r.Send(context.Background(), domain.NotificationContent{EventType: domain.NotificationEventFreshWallet, Message: "fresh"}); err != nil {
+		t.Fatal(err)
+	}
+	sent := api.sent()
+	if !slices.Equal(sent["1:main"], []string{"100", "101"}) || len(sent["2:whales"]) != 0 {
+		t.Errorf("fresh wallet sent to %v, want the default bot's chats only", sent)
+	}
+
+	api.reset()
+	if err := r.Send(context.Background(), domain.NotificationContent{EventType: domain.NotificationEventBigTrade, Message: "big"}); err != nil {
+		t.Fatal(err)
+	}
+	sent = api.sent()
+	if len(sent["1:main"]) != 2 || !slices.Equal(sent["2:whales"], []string{"200"}) {
+		t.Errorf("big trade sent to %v, want every chat of both bots", sent)
+	}
+}
+
+func TestRouterSendVia(t *testing.T) {
+	api := newFakeTelegram(t)
+	r := newTestRouter(t, api, testBots)
+
+	if err := r.SendVia(context.Background(), "telegram:whales", domain.NotificationContent{Message: "retry"}); err != nil {
+		t.Fatal(err)
+	}
+	if sent := api.sent(); len(sent) != 1 || !slices.Equal(sent["2:whales"], []string{"200"}) {
+		t.Errorf("SendVia sent to %v, want the whales bot only", sent)
+	}
+
+	var notificationErr *NotificationError
+	if err := r.SendVia(context.Background(), "telegram:missing", domain.NotificationContent{}); !errors.As(err, &notificationErr) {
+		t.Errorf("SendVia(unknown channel) = %v, want a NotificationError", err)
+	}
+}
+
+func TestRouterReportsRateLimits(t *testing.T) {
+	api := newFakeTelegram(t)
+	api.limited["200"] = true
+	r := newTestRouter(t, api, testBots)
+
+	err := r.Send(context.Background(), domain.NotificationContent{EventType: domain.NotificationEventBigTrade, Message: "big"})
+	var notifyErr *domain.NotifyError
+	if !errors.As(err, &notifyErr) || notifyErr.Channel != "telegram:whales" {
+		t.Fatalf("Send() = %v, want a NotifyError for the whales bot", err)
+	}
+	var limited *domain.UpstreamRateLimited
+	if !errors.As(err, &limited) || limited.Service != "telegram" || limited.RetryAfter != 5*time.Second {
+		t.Errorf("Send() = %v, want telegram rate limited for 5s", err)
+	}
+	if domain.ErrorKindOf(err) != domain.ErrorKindRateLimited {
+		t.Errorf("error kind = %s, want %s", domain.ErrorKindOf(err), domain.ErrorKindRateLimited)
+	}
+	if sent := api.sent(); len(sent["1:main"]) != 2 {
+		t.Errorf("default bot sent to %v, want its chats despite the other bot's limit", sent["1:main"])
+	}
+}
+
+func TestUpdateBotsKeepsUnchangedClients(t *testing.T) {
+	api := newFakeTelegram(t)
+	r := newTestRouter(t, api, testBots)
+	kept, replaced := r.bots[0].notifier, r.bots[1].notifier
+
+	r.UpdateBots([]domain.TelegramBotConfig{
+		{Name: "default", Token: "1:main", ChatIDs: []string{"102"}},
+		{Name: "whales", Token: "3:rotated", ChatIDs: []string{"200"}},
+	})
+	if r.bots[0].notifier != kept || !slices.Equal(kept.chatIDs, []string{"102"}) {
+		t.Error("unchanged token did not keep its client with the new chats")
+	}
+	if r.bots[1].notifier == replaced || r.bots[1].notifier.botToken != "3:rotated" {
+		t.Error("rotated token kept the old client")
+	}
+	if got := r.Channels(domain.NotificationEventFreshWallet); len(got) != 2 {
+		t.Errorf("channels = %v, want the new routes applied", got)
+	}
+}
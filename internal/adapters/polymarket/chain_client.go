@@ -0,0 +1,253 @@
+package polymarket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// transferTopic is the keccak256 hash of Transfer(address,address,uint256)
+	transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+	// logBlockRange is the widest block range per eth_getLogs call public RPCs accept
+	logBlockRange = 2000
+
+	// usdcDecimals is the token precision of both USDC contracts
+	usdcDecimals = 6
+)
+
+// usdcTokens are the Polygon USDC contracts, by lowercase address
+var usdcTokens = map[string]string{
+	"0x2791bca1f2de4661ed88a30c99a7a9449aa84174": "USDC.e", // Bridged USDC, Polymarket's collateral
+	"0x3c499c542cef5e3811e1192ce70d8cc03d5c3359": "USDC",   // Native USDC
+}
+
+// polymarketContracts receive USDC when a wallet trades rather than withdraws
+var polymarketContracts = map[string]bool{
+	"0x4bfb41d5b3570defd03c39a9a4d8de6bd8b8982e": true, // CTF Exchange
+	"0xc5d563a36ae78145c45a50134d48a1215220f80a": true, // Neg Risk CTF Exchange
+	"0xd91e80cf2e7be2e162c6513ced06f1dd0da35296": true, // Neg Risk Adapter
+	"0x4d97dcd97ec945f40cf65f87097ace5ea0476045": true, // Conditional Tokens
+}
+
+// ChainClient reads USDC transfers of wallets from a Polygon JSON-RPC endpoint
+type ChainClient struct {
+	rpcURL     string
+	httpClient *http.Client
+	nextID     atomic.Int64
+}
+
+// NewChainClient creates a Polygon JSON-RPC client
+func NewChainClient(rpcURL string) *ChainClient {
+	return &ChainClient{
+		rpcURL: rpcURL,
+		httpClient: &http.Client{
+			Timeout: 20 * time.Second,
+		},
+	}
+}
+
+// BlockNumber returns the latest block number
+func (c *ChainClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var hex string
+	if err := c.call(ctx, "eth_blockNumber", []any{}, &hex); err != nil {
+		return 0, err
+	}
+	return parseHexUint(hex)
+}
+
+// BlockTime returns the timestamp of a block
+func (c *ChainClient) BlockTime(ctx context.Context, block uint64) (time.Time, error) {
+	var header struct {
+		Timestamp string `json:"timestamp"`
+	}
+	if err := c.call(ctx, "eth_getBlockByNumber", []any{hexUint(block), false}, &header); err != nil {
+		return time.Time{}, err
+	}
+	ts, err := parseHexUint(header.Timestamp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(ts), 0), nil
+}
+
+// Withdrawals returns the USDC transfers out of wallet in [fromBlock, toBlock] to
+// addresses other than Polymarket's contracts, oldest first. Timestamps are left to
+// the caller.
+func (c *ChainClient) Withdrawals(ctx context.Context, wallet string, fromBlock, toBlock uint64) ([]domain.WalletWithdrawal, error) {
+	var withdrawals []domain.WalletWithdrawal
+	err := c.usdcTransfers(ctx, []any{transferTopic, addressTopic(wallet)}, fromBlock, toBlock, func(t usdcTransfer) bool {
+		if !polymarketContracts[t.to] {
+			withdrawals = append(withdrawals, domain.WalletWithdrawal{
+				Wallet:    strings.ToLower(wallet),
+				To:        t.to,
+				Token:     t.token,
+				AmountUSD: t.amount,
+				TxHash:    t.txHash,
+				Block:     t.block,
+			})
+		}
+		return true
+	})
+	return withdrawals, err
+}
+
+// FirstDeposit returns the earliest USDC transfer into wallet in [fromBlock, toBlock]
+// from an address other than Polymarket's contracts, or nil if there is none. Only
+// Wallet, From, Token, AmountUSD, TxHash and Block are set.
+func (c *ChainClient) FirstDeposit(ctx context.Context, wallet string, fromBlock, toBlock uint64) (*domain.FundingOrigin, error) {
+	var first *domain.FundingOrigin
+	err := c.usdcTransfers(ctx, []any{transferTopic, nil, addressTopic(wallet)}, fromBlock, toBlock, func(t usdcTransfer) bool {
+		if polymarketContracts[t.from] {
+			return true // Trade proceeds, not funding
+		}
+		first = &domain.FundingOrigin{
+			Wallet:    strings.ToLower(wallet),
+			From:      t.from,
+			Token:     t.token,
+			AmountUSD: t.amount,
+			TxHash:    t.txHash,
+			Block:     t.block,
+		}
+		return false
+	})
+	return first, err
+}
+
+// usdcTransfer is a decoded USDC Transfer log
+type usdcTransfer struct {
+	from, to, token string
+	amount          float64
+	txHash          string
+	block           uint64
+}
+
+// usdcTransfers calls visit with the USDC transfers matching topics in [fromBlock,
+// toBlock], oldest first, until visit returns false
+func (c *ChainClient) usdcTransfers(ctx context.Context, topics []any, fromBlock, toBlock uint64, visit func(usdcTransfer) bool) error {
+	tokens := make([]string, 0, len(usdcTokens))
+	for token := range usdcTokens {
+		tokens = append(tokens, token)
+	}
+	for start := fromBlock; start <= toBlock; start += logBlockRange {
+		end := min(start+logBlockRange-1, toBlock)
+		var logs []struct {
+			Address     string   `json:"address"`
+			Topics      []string `json:"topics"`
+			Data        string   `json:"data"`
+			BlockNumber string   `json:"blockNumber"`
+			TxHash      string   `json:"transactionHash"`
+		}
+		filter := map[string]any{
+			"fromBlock": hexUint(start),
+			"toBlock":   hexUint(end),
+			"address":   tokens,
+			"topics":    topics,
+		}
+		if err := c.call(ctx, "eth_getLogs", []any{filter}, &logs); err != nil {
+			return err
+		}
+		for _, l := range logs {
+			if len(l.Topics) < 3 {
+				continue
+			}
+			block, _ := parseHexUint(l.BlockNumber)
+			t := usdcTransfer{
+				from:   topicAddress(l.Topics[1]),
+				to:     topicAddress(l.Topics[2]),
+				token:  usdcTokens[strings.ToLower(l.Address)],
+				amount: tokenAmount(l.Data, usdcDecimals),
+				txHash: l.TxHash,
+				block:  block,
+			}
+			if !visit(t) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// call makes a JSON-RPC request and decodes its result into out
+func (c *ChainClient) call(ctx context.Context, method string, params []any, out any) error {
+	body, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": c.nextID.Add(1), "method": method, "params": params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError("polygon rpc", resp)
+	}
+
+	var result struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("%s failed: %s (code %d)", method, result.Error.Message, result.Error.Code)
+	}
+	return json.Unmarshal(result.Result, out)
+}
+
+// addressTopic encodes an address as a 32-byte log topic
+func addressTopic(address string) string {
+	return "0x000000000000000000000000" + strings.TrimPrefix(strings.ToLower(address), "0x")
+}
+
+// topicAddress decodes an address from a 32-byte log topic
+func topicAddress(topic string) string {
+	if len(topic) < 40 {
+		return ""
+	}
+	return "0x" + strings.ToLower(topic[len(topic)-40:])
+}
+
+func hexUint(n uint64) string {
+	return "0x" + strconv.FormatUint(n, 16)
+}
+
+func parseHexUint(hex string) (uint64, error) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(hex, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hex number %q", hex)
+	}
+	return n, nil
+}
+
+// tokenAmount converts a hex-encoded uint256 token amount to units
+func tokenAmount(data string, decimals int) float64 {
+	amount, ok := new(big.Int).SetString(strings.TrimPrefix(data, "0x"), 16)
+	if !ok {
+		return 0
+	}
+	f, _ := new(big.Float).SetInt(amount).Float64()
+	return f / math.Pow10(decimals)
+}
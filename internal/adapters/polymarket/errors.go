@@ -0,0 +1,32 @@
+package polymarket
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// statusError describes a non-200 response, as domain.UpstreamRateLimited for 429
+func statusError(service string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &domain.UpstreamRateLimited{Service: service, RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	}
+	return fmt.Errorf("API returned status %d", resp.StatusCode)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && time.Until(at) > 0 {
+		return time.Until(at).Round(time.Second)
+	}
+	return 0
+}
@@ -0,0 +1,31 @@
+package polymarket
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestStatusErrorReportsRateLimits(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"30"}}}
+	var rateLimited *domain.UpstreamRateLimited
+	if err := statusError("gamma", resp); !errors.As(err, &rateLimited) || rateLimited.Service != "gamma" || rateLimited.RetryAfter != 30*time.Second {
+		t.Errorf("statusError(429) = %v, want a gamma rate limit retrying after 30s", err)
+	}
+	if err := statusError("gamma", &http.Response{StatusCode: http.StatusBadGateway}); errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("statusError(502) = %v, want a plain status error", err)
+	}
+
+	at := time.Now().Add(2 * time.Minute).UTC().Format(http.TimeFormat)
+	for value, want := range map[string]time.Duration{"": 0, "abc": 0, "-5": 0, "Mon, 01 Jan 2001 00:00:00 GMT": 0} {
+		if got := retryAfter(value); got != want {
+			t.Errorf("retryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+	if got := retryAfter(at); got < time.Minute || got > 2*time.Minute {
+		t.Errorf("retryAfter(%q) = %v, want about 2m", at, got)
+	}
+}
@@ -0,0 +1,60 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// defaultFundingLabels are known Polygon addresses that send USDC straight to wallets.
+// Exchanges rotate hot wallets, so the set is meant to be extended, see LoadFundingLabels.
+var defaultFundingLabels = map[string]domain.FundingLabel{
+	"0x0000000000000000000000000000000000000000": {Name: "Polygon bridge", Kind: domain.FundingBridge}, // USDC.e minted by the PoS bridge, USDC by CCTP
+	"0x9295ee1d8c5b022be115a2ad3c30c72e34e7f096": {Name: "Across", Kind: domain.FundingBridge},         // Across SpokePool
+	"0xe7804c37c13166ff0b37f5ae0bb07a3aebb6e245": {Name: "Binance", Kind: domain.FundingExchange},
+	"0xf89d7b9c864f589bbf53a82105107622b35eaa40": {Name: "Bybit", Kind: domain.FundingExchange},
+}
+
+// FundingLabels names known funding addresses, by lowercase address
+type FundingLabels map[string]domain.FundingLabel
+
+// LoadFundingLabels returns the built-in labels extended, or overridden, by the JSON
+// object of address to label in path, e.g. {"0x…": {"name": "Coinbase", "kind": "exchange"}}.
+// A missing file leaves the built-in labels.
+func LoadFundingLabels(path string) (FundingLabels, error) {
+	labels := make(FundingLabels, len(defaultFundingLabels))
+	for address, label := range defaultFundingLabels {
+		labels[address] = label
+	}
+	if path == "" {
+		return labels, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return labels, nil
+	}
+	if err != nil {
+		return labels, err
+	}
+	var custom map[string]domain.FundingLabel
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return labels, fmt.Errorf("invalid funding labels in %s: %w", path, err)
+	}
+	for address, label := range custom {
+		if label.Kind != domain.FundingExchange && label.Kind != domain.FundingBridge {
+			return labels, fmt.Errorf("invalid funding label kind %q for %s: use exchange or bridge", label.Kind, address)
+		}
+		labels[strings.ToLower(strings.TrimSpace(address))] = label
+	}
+	return labels, nil
+}
+
+// Lookup returns the label of an address
+func (l FundingLabels) Lookup(address string) (domain.FundingLabel, bool) {
+	label, ok := l[strings.ToLower(address)]
+	return label, ok
+}
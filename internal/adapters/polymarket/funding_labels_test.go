@@ -0,0 +1,39 @@
+package polymarket
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestLoadFundingLabels(t *testing.T) {
+	dir := t.TempDir()
+	labels, err := LoadFundingLabels(filepath.Join(dir, "missing.json"))
+	if err != nil || len(labels) != len(defaultFundingLabels) {
+		t.Fatalf("labels = %v, %v, want the built-in labels without a file", labels, err)
+	}
+
+	path := filepath.Join(dir, "labels.json")
+	os.WriteFile(path, []byte(`{
+		" 0xABC ": {"name": "Coinbase", "kind": "exchange"},
+		"0xe7804c37c13166ff0b37f5ae0bb07a3aebb6e245": {"name": "Binance 14", "kind": "exchange"}
+	}`), 0644)
+	if labels, err = LoadFundingLabels(path); err != nil {
+		t.Fatal(err)
+	}
+	if label, ok := labels.Lookup("0xAbc"); !ok || label.Name != "Coinbase" || label.Kind != domain.FundingExchange {
+		t.Errorf("0xabc = %+v, want the custom label", label)
+	}
+	if label, _ := labels.Lookup("0xE7804C37C13166FF0B37F5AE0BB07A3AEBB6E245"); label.Name != "Binance 14" {
+		t.Errorf("overridden label = %+v, want the file's", label)
+	}
+
+	for _, content := range []string{"{", `{"0xabc": {"name": "Coinbase", "kind": "bank"}}`} {
+		os.WriteFile(path, []byte(content), 0644)
+		if labels, err := LoadFundingLabels(path); err == nil || len(labels) != len(defaultFundingLabels) {
+			t.Errorf("%s: loaded %d labels, %v, want an error and the built-in labels", content, len(labels), err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package polymarket
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// DefaultUserAgent is sent when no User-Agent is configured; the profile API turns
+// away clients that don't look like a browser
+const DefaultUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36"
+
+// upstreamAPIs names the Polymarket APIs by host, as used in ClientIdentity.APIs
+var upstreamAPIs = map[string]string{
+	"gamma-api.polymarket.com":             "gamma",
+	"clob.polymarket.com":                  "clob",
+	"polymarket.com":                       "profile",
+	"ws-live-data.polymarket.com":          "feed",
+	"ws-subscriptions-clob.polymarket.com": "feed",
+}
+
+// Identity resolves the User-Agent and headers requests to Polymarket are sent with.
+// It is shared by the clients so a config change applies to all of them at once.
+type Identity struct {
+	mu     sync.RWMutex
+	config domain.ClientIdentity
+}
+
+// NewIdentity creates an identity from a config; nil sends DefaultUserAgent only
+func NewIdentity(config *domain.ClientIdentity) *Identity {
+	i := &Identity{}
+	i.Set(config)
+	return i
+}
+
+// Set replaces the config, taking effect on the next request and reconnect
+func (i *Identity) Set(config *domain.ClientIdentity) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if config == nil {
+		i.config = domain.ClientIdentity{}
+		return
+	}
+	i.config = *config
+}
+
+// Headers returns the headers to send to a URL: DefaultUserAgent, overridden by the
+// default identity, then the URL's API, then its longest matching endpoint prefix
+func (i *Identity) Headers(rawURL string) http.Header {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	header := http.Header{}
+	header.Set("User-Agent", DefaultUserAgent)
+	apply := func(identity domain.UpstreamIdentity) {
+		if identity.UserAgent != "" {
+			header.Set("User-Agent", identity.UserAgent)
+		}
+		for name, value := range identity.Headers {
+			if value == "" {
+				header.Del(name)
+			} else {
+				header.Set(name, value)
+			}
+		}
+	}
+
+	apply(i.config.Default)
+	if u, err := url.Parse(rawURL); err == nil {
+		if identity, ok := i.config.APIs[upstreamAPIs[u.Hostname()]]; ok {
+			apply(identity)
+		}
+	}
+	longest := ""
+	for prefix := range i.config.Endpoints {
+		if strings.HasPrefix(rawURL, prefix) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	if longest != "" {
+		apply(i.config.Endpoints[longest])
+	}
+	return header
+}
+
+// Transport wraps next (nil = http.DefaultTransport) so every request carries the
+// identity's headers
+func (i *Identity) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &identityTransport{identity: i, next: next}
+}
+
+// identityTransport sets the identity's headers on outgoing requests
+type identityTransport struct {
+	identity *Identity
+	next     http.RoundTripper
+}
+
+func (t *identityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context()) // RoundTrippers must not modify the caller's request
+	for name, values := range t.identity.Headers(req.URL.String()) {
+		req.Header[name] = values
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,81 @@
+package polymarket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestIdentityHeadersByLevel(t *testing.T) {
+	identity := NewIdentity(&domain.ClientIdentity{
+		Default: domain.UpstreamIdentity{UserAgent: "xtools/1.0", Headers: map[string]string{"X-Client": "xtools", "Referer": "https://polymarket.com"}},
+		APIs: map[string]domain.UpstreamIdentity{
+			"gamma": {UserAgent: "gamma-agent"},
+			"clob":  {Headers: map[string]string{"Referer": ""}},
+		},
+		Endpoints: map[string]domain.UpstreamIdentity{
+			"https://gamma-api.polymarket.com/events":       {Headers: map[string]string{"X-Client": "events"}},
+			"https://gamma-api.polymarket.com/events/slug/": {UserAgent: "slug-agent"},
+		},
+	})
+
+	for _, tc := range []struct {
+		url, userAgent, client, referer string
+	}{
+		{"https://polymarket.com/api/profile", "xtools/1.0", "xtools", "https://polymarket.com"},
+		{"https://gamma-api.polymarket.com/markets", "gamma-agent", "xtools", "https://polymarket.com"},
+		{"https://gamma-api.polymarket.com/events?limit=1", "gamma-agent", "events", "https://polymarket.com"},
+		{"https://gamma-api.polymarket.com/events/slug/fed", "slug-agent", "xtools", "https://polymarket.com"}, // Only the longest prefix applies
+		{"https://clob.polymarket.com/book", "xtools/1.0", "xtools", ""},
+	} {
+		header := identity.Headers(tc.url)
+		if header.Get("User-Agent") != tc.userAgent || header.Get("X-Client") != tc.client || header.Get("Referer") != tc.referer {
+			t.Errorf("%s: headers = %v, want User-Agent %q, X-Client %q and Referer %q", tc.url, header, tc.userAgent, tc.client, tc.referer)
+		}
+		if _, ok := header["Referer"]; tc.referer == "" && ok {
+			t.Errorf("%s: sent an emptied Referer", tc.url)
+		}
+	}
+
+	// Without a config only the default User-Agent is sent
+	identity.Set(nil)
+	if header := identity.Headers("https://gamma-api.polymarket.com/events"); len(header) != 1 || header.Get("User-Agent") != DefaultUserAgent {
+		t.Errorf("headers = %v, want only the default User-Agent", header)
+	}
+}
+
+func TestIdentityTransportSetsHeaders(t *testing.T) {
+	var got http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+	}))
+	defer ts.Close()
+
+	identity := NewIdentity(&domain.ClientIdentity{Default: domain.UpstreamIdentity{UserAgent: "first"}})
+	client := &http.Client{Transport: identity.Transport(nil)}
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got.Get("User-Agent") != "first" || got.Get("Accept") != "application/json" {
+		t.Errorf("headers = %v, want the identity's User-Agent and the request's own headers", got)
+	}
+	if req.Header.Get("User-Agent") != "" {
+		t.Error("modified the caller's request")
+	}
+
+	// A config change applies to the next request
+	identity.Set(&domain.ClientIdentity{Default: domain.UpstreamIdentity{UserAgent: "second"}})
+	if resp, err = client.Get(ts.URL); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got.Get("User-Agent") != "second" {
+		t.Errorf("User-Agent = %q, want the new config's", got.Get("User-Agent"))
+	}
+}
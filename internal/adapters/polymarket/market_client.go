@@ -0,0 +1,250 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// marketCacheTTL bounds how long market metadata is reused before refetching
+	marketCacheTTL = 1 * time.Hour
+
+	// gammaEventsURL is the Gamma API endpoint for events and their markets
+	gammaEventsURL = "https://gamma-api.polymarket.com/events"
+)
+
+// gammaMarket is the subset of a Gamma API market used here
+type gammaMarket struct {
+	Slug     string `json:"slug"`
+	Question string `json:"question"`
+	Category string `json:"category"`
+	EndDate  string `json:"endDate"`
+	Closed   bool   `json:"closed"`
+
+	// Kick-off of a sports market's game, e.g. "2025-01-05 18:00:00+00"
+	GameStartTime string `json:"gameStartTime"`
+
+	// JSON-encoded array of settlement prices per outcome, e.g. "[\"1\", \"0\"]"
+	OutcomePrices string `json:"outcomePrices"`
+
+	// Market state kept by snapshots
+	ConditionID    string  `json:"conditionId"`
+	Outcomes       string  `json:"outcomes"` // JSON-encoded array, e.g. "[\"Yes\", \"No\"]"
+	VolumeNum      float64 `json:"volumeNum"`
+	Volume24hr     float64 `json:"volume24hr"`
+	LiquidityNum   float64 `json:"liquidityNum"`
+	BestBid        float64 `json:"bestBid"`
+	BestAsk        float64 `json:"bestAsk"`
+	LastTradePrice float64 `json:"lastTradePrice"`
+}
+
+// gammaEvent is the subset of a Gamma API event used here
+type gammaEvent struct {
+	Slug    string `json:"slug"`
+	Title   string `json:"title"`
+	NegRisk bool   `json:"negRisk"`
+	Closed  bool   `json:"closed"`
+	Markets []struct {
+		Slug          string  `json:"slug"`
+		Question      string  `json:"question"`
+		Closed        bool    `json:"closed"`
+		OutcomePrices string  `json:"outcomePrices"`
+		BestBid       float64 `json:"bestBid"`
+		BestAsk       float64 `json:"bestAsk"`
+		TakerBaseFee  float64 `json:"takerBaseFee"` // Basis points
+	} `json:"markets"`
+}
+
+type cachedMarket struct {
+	info      *domain.MarketInfo
+	expiresAt time.Time
+}
+
+// MarketClient fetches market metadata from the Gamma API
+type MarketClient struct {
+	mu         sync.Mutex
+	httpClient *http.Client
+	cache      map[string]cachedMarket
+}
+
+// NewMarketClient creates a new Gamma API market client
+func NewMarketClient() *MarketClient {
+	return &MarketClient{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		cache: make(map[string]cachedMarket),
+	}
+}
+
+// SetTransport replaces the HTTP transport, e.g. with a caching one
+func (c *MarketClient) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// GetMarket returns the metadata of a market by slug, or nil if the slug is unknown
+func (c *MarketClient) GetMarket(ctx context.Context, slug string) (*domain.MarketInfo, error) {
+	c.mu.Lock()
+	if cached, ok := c.cache[slug]; ok && time.Now().Before(cached.expiresAt) {
+		c.mu.Unlock()
+		return cached.info, nil
+	}
+	c.mu.Unlock()
+
+	info, err := c.fetchMarket(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.cache[slug] = cachedMarket{info: info, expiresAt: time.Now().Add(marketCacheTTL)}
+	c.mu.Unlock()
+	return info, nil
+}
+
+// CacheSize returns the number of cached markets, including expired ones
+func (c *MarketClient) CacheSize() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.cache)
+}
+
+// fetchMarket queries the Gamma API for a single market slug
+func (c *MarketClient) fetchMarket(ctx context.Context, slug string) (*domain.MarketInfo, error) {
+	m, err := c.queryMarket(ctx, slug)
+	if err != nil || m == nil {
+		return nil, err
+	}
+
+	info := &domain.MarketInfo{Slug: m.Slug, Question: m.Question, Category: m.Category, Closed: m.Closed, WinningOutcome: -1}
+	if m.Closed {
+		info.WinningOutcome = winningOutcome(m.OutcomePrices)
+	}
+	if m.EndDate != "" {
+		if t, err := time.Parse(time.RFC3339, m.EndDate); err == nil {
+			info.EndDate = t
+		} else if t, err := time.Parse("2006-01-02", m.EndDate); err == nil {
+			info.EndDate = t
+		}
+	}
+	info.GameStartTime = parseGameStartTime(m.GameStartTime)
+	return info, nil
+}
+
+// queryMarket returns the Gamma API market of a slug, or nil if the slug is unknown
+func (c *MarketClient) queryMarket(ctx context.Context, slug string) (*gammaMarket, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", gammaAPIURL+"?slug="+url.QueryEscape(slug), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch market: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("gamma", resp)
+	}
+
+	var markets []gammaMarket
+	if err := json.NewDecoder(resp.Body).Decode(&markets); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(markets) == 0 {
+		return nil, nil
+	}
+	return &markets[0], nil
+}
+
+// gameStartLayouts are the formats game start times have been seen in
+var gameStartLayouts = []string{time.RFC3339, "2006-01-02 15:04:05-07", "2006-01-02 15:04:05Z07:00", "2006-01-02T15:04:05-07"}
+
+// parseGameStartTime parses a game start time, zero if it is empty or unrecognized
+func parseGameStartTime(value string) time.Time {
+	for _, layout := range gameStartLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// GetEvent returns the current YES prices of an event's markets by event slug, or nil
+// if the slug is unknown. Prices aren't cached.
+func (c *MarketClient) GetEvent(ctx context.Context, slug string) (*domain.EventMarkets, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", gammaEventsURL+"?slug="+url.QueryEscape(slug), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("gamma", resp)
+	}
+
+	var events []gammaEvent
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	e := events[0]
+	result := &domain.EventMarkets{Slug: e.Slug, Title: e.Title, NegRisk: e.NegRisk, Closed: e.Closed, FetchedAt: time.Now()}
+	for _, m := range e.Markets {
+		price := (m.BestBid + m.BestAsk) / 2
+		if m.BestBid <= 0 || m.BestAsk <= 0 || m.BestAsk < m.BestBid {
+			price = outcomePrice(m.OutcomePrices, 0)
+		}
+		result.Markets = append(result.Markets, domain.EventMarketPrice{
+			Slug:     m.Slug,
+			Question: m.Question,
+			Price:    price,
+			FeeRate:  m.TakerBaseFee / 10000,
+			Closed:   m.Closed,
+		})
+	}
+	return result, nil
+}
+
+// outcomePrice returns the price of outcome i from a JSON-encoded price array, or 0
+func outcomePrice(outcomePrices string, i int) float64 {
+	var prices []string
+	if err := json.Unmarshal([]byte(outcomePrices), &prices); err != nil || i >= len(prices) {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(prices[i], 64)
+	return v
+}
+
+// winningOutcome returns the index of the outcome settled at $1, or -1 if none is
+func winningOutcome(outcomePrices string) int {
+	var prices []string
+	if err := json.Unmarshal([]byte(outcomePrices), &prices); err != nil {
+		return -1
+	}
+	for i, p := range prices {
+		if v, err := strconv.ParseFloat(p, 64); err == nil && v >= 0.99 {
+			return i
+		}
+	}
+	return -1
+}
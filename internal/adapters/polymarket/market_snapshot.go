@@ -0,0 +1,109 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// dataHoldersURL is the data API endpoint for the largest holders of a market's outcomes
+const dataHoldersURL = "https://data-api.polymarket.com/holders"
+
+// dataHolders is the data API's holders of one outcome token
+type dataHolders struct {
+	Token   string `json:"token"`
+	Holders []struct {
+		ProxyWallet  string  `json:"proxyWallet"`
+		Name         string  `json:"name"`
+		Pseudonym    string  `json:"pseudonym"`
+		Amount       float64 `json:"amount"`
+		OutcomeIndex int     `json:"outcomeIndex"`
+	} `json:"holders"`
+}
+
+// GetMarketSnapshot returns the current state of a market by slug, with up to holders
+// top holders per outcome (0 = none), or nil if the slug is unknown. Snapshots aren't
+// cached. Holders are left out when the data API fails, the market state is kept.
+func (c *MarketClient) GetMarketSnapshot(ctx context.Context, slug string, holders int) (*domain.MarketSnapshot, error) {
+	m, err := c.queryMarket(ctx, slug)
+	if err != nil || m == nil {
+		return nil, err
+	}
+
+	snapshot := &domain.MarketSnapshot{
+		MarketSlug:     m.Slug,
+		ConditionID:    m.ConditionID,
+		MarketName:     m.Question,
+		BestBid:        m.BestBid,
+		BestAsk:        m.BestAsk,
+		LastTradePrice: m.LastTradePrice,
+		Volume:         m.VolumeNum,
+		Volume24h:      m.Volume24hr,
+		Liquidity:      m.LiquidityNum,
+		Closed:         m.Closed,
+		CapturedAt:     time.Now(),
+	}
+	_ = json.Unmarshal([]byte(m.Outcomes), &snapshot.Outcomes)
+	var prices []string
+	if err := json.Unmarshal([]byte(m.OutcomePrices), &prices); err == nil {
+		for _, p := range prices {
+			v, _ := strconv.ParseFloat(p, 64)
+			snapshot.Prices = append(snapshot.Prices, v)
+		}
+	}
+
+	if holders > 0 && m.ConditionID != "" {
+		if top, err := c.getHolders(ctx, m.ConditionID, holders); err == nil {
+			snapshot.TopHolders = top
+		}
+	}
+	return snapshot, nil
+}
+
+// getHolders queries the data API for the largest holders of each outcome of a market
+func (c *MarketClient) getHolders(ctx context.Context, conditionID string, limit int) ([]domain.MarketHolder, error) {
+	query := url.Values{"market": {conditionID}, "limit": {strconv.Itoa(limit)}}
+	req, err := http.NewRequestWithContext(ctx, "GET", dataHoldersURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch holders: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("data", resp)
+	}
+
+	var tokens []dataHolders
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	var holders []domain.MarketHolder
+	for _, t := range tokens {
+		for _, h := range t.Holders {
+			name := h.Name
+			if name == "" {
+				name = h.Pseudonym
+			}
+			holders = append(holders, domain.MarketHolder{
+				Wallet:       h.ProxyWallet,
+				Name:         name,
+				OutcomeIndex: h.OutcomeIndex,
+				Amount:       h.Amount,
+			})
+		}
+	}
+	return holders, nil
+}
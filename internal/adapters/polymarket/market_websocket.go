@@ -0,0 +1,273 @@
+package polymarket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// CLOB market channel endpoint (order books, price changes, tick sizes)
+	wsMarketURL = "wss://ws-subscriptions-clob.polymarket.com/ws/market"
+
+	// maxMarketAssets bounds the outcome tokens subscribed at once; the least recently
+	// traded are dropped first
+	maxMarketAssets = 500
+
+	// The market channel expects a text PING and answers PONG
+	marketPingInterval = 10 * time.Second
+
+	// marketSubscribeInterval batches subscriptions of newly tracked assets
+	marketSubscribeInterval = time.Second
+)
+
+// MarketWebSocketClient follows the order books of tracked outcome tokens on the CLOB
+// market channel. It connects once the first asset is tracked.
+type MarketWebSocketClient struct {
+	mu             sync.Mutex
+	conn           *websocket.Conn
+	stopCh         chan struct{}
+	changed        chan struct{} // Signaled when assets are tracked or dropped
+	eventCallback  EventCallback
+	errorCallback  ErrorCallback
+	identity       *Identity
+	reconnectDelay time.Duration
+
+	assets      map[string]time.Time // Tracked asset IDs and when they last traded
+	pending     []string             // Tracked since the last subscription was sent
+	dropped     []string             // Dropped since the last unsubscription was sent
+	subscribed  bool                 // Whether the current connection sent its initial subscription
+	connectedAt time.Time
+
+	// Status tracking
+	eventsReceived int64
+	lastEventAt    time.Time
+	lastError      string
+	reconnectCount int
+}
+
+// NewMarketWebSocketClient creates a market channel client
+func NewMarketWebSocketClient(callback EventCallback) *MarketWebSocketClient {
+	return &MarketWebSocketClient{
+		changed:        make(chan struct{}, 1),
+		eventCallback:  callback,
+		reconnectDelay: initialReconnectDelay,
+		assets:         make(map[string]time.Time),
+	}
+}
+
+// SetIdentity sets the identity whose headers the connection is dialed with
+func (c *MarketWebSocketClient) SetIdentity(identity *Identity) {
+	c.mu.Lock()
+	c.identity = identity
+	c.mu.Unlock()
+}
+
+// SetErrorCallback sets the callback receiving feed errors
+func (c *MarketWebSocketClient) SetErrorCallback(callback ErrorCallback) {
+	c.mu.Lock()
+	c.errorCallback = callback
+	c.mu.Unlock()
+}
+
+// Connect starts following the tracked assets in the background
+func (c *MarketWebSocketClient) Connect() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopCh != nil {
+		return nil
+	}
+	c.stopCh = make(chan struct{})
+	c.reconnectDelay = initialReconnectDelay
+	go c.connectionLoop(c.stopCh)
+	return nil
+}
+
+func (c *MarketWebSocketClient) connect() error {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+
+	c.mu.Lock()
+	header := http.Header{}
+	if c.identity != nil {
+		header = c.identity.Headers(wsMarketURL)
+	}
+	c.mu.Unlock()
+
+	conn, resp, err := dialer.Dial(wsMarketURL, header)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			err = &domain.UpstreamRateLimited{Service: "market feed", RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+		}
+		return fmt.Errorf("dial failed: %w", err)
+	}
+
+	// The initial subscription covers every tracked asset
+	c.mu.Lock()
+	assetIDs := make([]string, 0, len(c.assets))
+	for id := range c.assets {
+		assetIDs = append(assetIDs, id)
+	}
+	c.pending, c.dropped = nil, nil
+	c.mu.Unlock()
+
+	msg, _ := json.Marshal(map[string]any{"assets_ids": assetIDs, "type": "market"})
+	if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+		conn.Close()
+		return fmt.Errorf("subscribe failed: %w", err)
+	}
+	log.Printf("[Polymarket] Market channel subscribed to %d assets", len(assetIDs))
+
+	c.mu.Lock()
+	c.conn = conn
+	c.subscribed = true
+	c.connectedAt = time.Now()
+	c.lastError = ""
+	c.reconnectDelay = initialReconnectDelay
+	c.mu.Unlock()
+	return nil
+}
+
+// readLoop reads market events until the connection fails or the client stops, pinging
+// and sending subscription changes from a writer goroutine
+func (c *MarketWebSocketClient) readLoop(stopCh chan struct{}) {
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		conn.Close()
+		c.mu.Lock()
+		if c.conn == conn {
+			c.conn, c.subscribed = nil, false
+		}
+		c.mu.Unlock()
+	}()
+	go c.writeLoop(conn, stopCh, done)
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(pingTimeout))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			select {
+			case <-stopCh:
+			default:
+				log.Printf("[Polymarket] Market channel read error: %v", err)
+				c.setError(fmt.Sprintf("read error: %v", err))
+				c.reportError(&domain.FeedError{Op: "read", Err: err})
+			}
+			return
+		}
+		c.processMessage(data)
+	}
+}
+
+// writeLoop pings the connection and subscribes newly tracked assets in batches
+func (c *MarketWebSocketClient) writeLoop(conn *websocket.Conn, stopCh, done chan struct{}) {
+	ping := time.NewTicker(marketPingInterval)
+	defer ping.Stop()
+	subscribe := time.NewTicker(marketSubscribeInterval)
+	defer subscribe.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			conn.Close()
+			return
+		case <-done:
+			return
+		case <-ping.C:
+			if err := conn.WriteMessage(websocket.TextMessage, []byte("PING")); err != nil {
+				return
+			}
+		case <-subscribe.C:
+			c.mu.Lock()
+			pending, dropped := c.pending, c.dropped
+			c.pending, c.dropped = nil, nil
+			c.mu.Unlock()
+			if err := writeSubscription(conn, "subscribe", pending); err != nil {
+				return
+			}
+			if err := writeSubscription(conn, "unsubscribe", dropped); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *MarketWebSocketClient) processMessage(data []byte) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("PONG")) {
+		return
+	}
+
+	messages, err := DecodeMessage(data)
+	if err != nil && !errors.Is(err, errUnknownMessage) {
+		log.Printf("[Polymarket] Failed to parse market message: %v", err)
+	}
+	for _, msg := range messages {
+		for _, event := range msg.Events() {
+			c.mu.Lock()
+			c.eventsReceived++
+			c.lastEventAt = time.Now()
+			c.mu.Unlock()
+			if c.eventCallback != nil {
+				c.eventCallback(event)
+			}
+		}
+	}
+}
+
+func (c *MarketWebSocketClient) setError(msg string) {
+	c.mu.Lock()
+	c.lastError = msg
+	c.mu.Unlock()
+}
+
+func (c *MarketWebSocketClient) reportError(err error) {
+	c.mu.Lock()
+	callback := c.errorCallback
+	c.mu.Unlock()
+	if callback != nil {
+		callback(err)
+	}
+}
+
+// Disconnect closes the connection; tracked assets are kept for the next Connect
+func (c *MarketWebSocketClient) Disconnect() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopCh != nil {
+		close(c.stopCh)
+		c.stopCh = nil
+	}
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
+// GetStatus returns the market channel's connection status
+func (c *MarketWebSocketClient) GetStatus() domain.MarketChannelStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return domain.MarketChannelStatus{
+		IsConnected:    c.conn != nil && c.subscribed,
+		ConnectedAt:    c.connectedAt,
+		TrackedAssets:  len(c.assets),
+		EventsReceived: c.eventsReceived,
+		LastEventAt:    c.lastEventAt,
+		ErrorMessage:   c.lastError,
+		ReconnectCount: c.reconnectCount,
+		Endpoint:       wsMarketURL,
+	}
+}
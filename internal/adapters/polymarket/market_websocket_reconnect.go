@@ -0,0 +1,54 @@
+package polymarket
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// connectionLoop connects once assets are tracked and reconnects until stopped
+func (c *MarketWebSocketClient) connectionLoop(stopCh chan struct{}) {
+	for {
+		c.mu.Lock()
+		idle := len(c.assets) == 0
+		c.mu.Unlock()
+		if idle {
+			select {
+			case <-stopCh:
+				return
+			case <-c.changed:
+				continue
+			}
+		}
+
+		if err := c.connect(); err != nil {
+			log.Printf("[Polymarket] Market channel connection failed: %v", err)
+			c.setError(fmt.Sprintf("connection failed: %v", err))
+			c.reportError(&domain.FeedError{Op: "connect", Err: err})
+		} else {
+			c.readLoop(stopCh)
+		}
+
+		select {
+		case <-stopCh:
+			return
+		default:
+			c.waitReconnect(stopCh)
+		}
+	}
+}
+
+func (c *MarketWebSocketClient) waitReconnect(stopCh chan struct{}) {
+	c.mu.Lock()
+	delay := c.reconnectDelay
+	c.reconnectDelay = min(c.reconnectDelay*2, maxReconnectDelay)
+	c.reconnectCount++
+	c.mu.Unlock()
+
+	select {
+	case <-time.After(delay):
+	case <-stopCh:
+	}
+}
@@ -0,0 +1,55 @@
+package polymarket
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Track subscribes to an outcome token's book, or marks a tracked one as recently
+// traded. It never blocks on the connection.
+func (c *MarketWebSocketClient) Track(assetID string) {
+	if assetID == "" {
+		return
+	}
+	c.mu.Lock()
+	_, ok := c.assets[assetID]
+	c.assets[assetID] = time.Now()
+	if !ok {
+		c.pending = append(c.pending, assetID)
+		if len(c.assets) > maxMarketAssets {
+			c.dropOldestLocked()
+		}
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		select {
+		case c.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// dropOldestLocked stops tracking the least recently traded asset
+func (c *MarketWebSocketClient) dropOldestLocked() {
+	var oldest string
+	var oldestAt time.Time
+	for id, at := range c.assets {
+		if oldest == "" || at.Before(oldestAt) {
+			oldest, oldestAt = id, at
+		}
+	}
+	delete(c.assets, oldest)
+	c.dropped = append(c.dropped, oldest)
+}
+
+// writeSubscription changes the subscribed assets of an open connection
+func writeSubscription(conn *websocket.Conn, operation string, assetIDs []string) error {
+	if len(assetIDs) == 0 {
+		return nil
+	}
+	msg, _ := json.Marshal(map[string]any{"assets_ids": assetIDs, "operation": operation})
+	return conn.WriteMessage(websocket.TextMessage, msg)
+}
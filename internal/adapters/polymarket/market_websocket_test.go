@@ -0,0 +1,35 @@
+package polymarket
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTrackDropsLeastRecentlyTradedAsset(t *testing.T) {
+	c := NewMarketWebSocketClient(nil)
+	for i := range maxMarketAssets {
+		c.Track(fmt.Sprintf("asset-%d", i))
+	}
+	c.Track("")
+	c.assets["asset-7"] = time.Now().Add(-time.Hour)
+	c.Track("asset-3") // Already tracked: only refreshed
+
+	select {
+	case <-c.changed:
+	default:
+		t.Fatal("tracking new assets did not signal a subscription change")
+	}
+	if len(c.pending) != maxMarketAssets {
+		t.Fatalf("%d pending subscriptions, want %d", len(c.pending), maxMarketAssets)
+	}
+
+	c.Track("asset-new")
+	if len(c.assets) != maxMarketAssets || !slices.Equal(c.dropped, []string{"asset-7"}) {
+		t.Errorf("tracking %d assets after dropping %v, want %d after dropping asset-7", len(c.assets), c.dropped, maxMarketAssets)
+	}
+	if status := c.GetStatus(); status.TrackedAssets != maxMarketAssets || status.IsConnected {
+		t.Errorf("status = %+v, want %d tracked assets and no connection", status, maxMarketAssets)
+	}
+}
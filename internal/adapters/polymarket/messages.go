@@ -0,0 +1,151 @@
+package polymarket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// errUnknownMessage is returned for messages that are neither an activity payload nor
+// a known market channel event, e.g. subscription acknowledgments
+var errUnknownMessage = errors.New("unknown message format")
+
+// Message is a decoded Polymarket WebSocket message
+type Message interface {
+	// Events converts the message to the events consumers see, with RawData set to the
+	// message as received
+	Events() []domain.PolymarketEvent
+}
+
+// DecodeMessage decodes a WebSocket frame into typed messages. Frames carry an activity
+// payload ({"topic":"activity","type":"trades","payload":{...}}), a market channel
+// event ({"event_type":"book",...}) or an array of market channel events.
+func DecodeMessage(data []byte) ([]Message, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var frames []json.RawMessage
+		if err := json.Unmarshal(data, &frames); err != nil {
+			return nil, err
+		}
+		var messages []Message
+		for _, frame := range frames {
+			decoded, err := DecodeMessage(frame)
+			if err != nil {
+				return messages, err
+			}
+			messages = append(messages, decoded...)
+		}
+		return messages, nil
+	}
+
+	var envelope struct {
+		Payload   json.RawMessage            `json:"payload"`
+		EventType domain.PolymarketEventType `json:"event_type"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	if payload := bytes.TrimSpace(envelope.Payload); len(payload) > 0 && payload[0] == '{' {
+		if bytes.Equal(payload, []byte("{}")) {
+			return nil, nil
+		}
+		return decodeAs[TradeMessage](payload)
+	}
+
+	switch envelope.EventType {
+	case domain.PolymarketEventBook:
+		return decodeAs[BookMessage](data)
+	case domain.PolymarketEventPriceChange:
+		return decodeAs[PriceChangeMessage](data)
+	case domain.PolymarketEventTickSizeChange:
+		return decodeAs[TickSizeChangeMessage](data)
+	case domain.PolymarketEventLastTradePrice:
+		return decodeAs[LastTradePriceMessage](data)
+	}
+	return nil, errUnknownMessage
+}
+
+// rawMessage keeps the compacted JSON a message was decoded from
+type rawMessage struct{ raw string }
+
+func (r *rawMessage) setRaw(data []byte) {
+	var buf bytes.Buffer
+	if json.Compact(&buf, data) == nil {
+		r.raw = buf.String()
+	} else {
+		r.raw = string(data)
+	}
+}
+
+// decodeAs decodes data as a message of type T, keeping the raw JSON
+func decodeAs[T any, M interface {
+	*T
+	Message
+	setRaw([]byte)
+}](data []byte) ([]Message, error) {
+	msg := M(new(T))
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode %T: %w", *new(T), err)
+	}
+	msg.setRaw(data)
+	return []Message{msg}, nil
+}
+
+// number is a JSON number Polymarket sends either as a number or as a string
+type number struct {
+	text   string // As sent, for strings
+	value  float64
+	quoted bool
+	set    bool
+}
+
+func (n *number) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &n.text); err != nil {
+			return err
+		}
+		n.value, _ = strconv.ParseFloat(n.text, 64)
+		n.quoted, n.set = true, n.text != ""
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.value); err != nil {
+		return err
+	}
+	n.set = true
+	return nil
+}
+
+// format returns the number as sent when it was a string, otherwise with the given
+// decimals; "" when absent
+func (n number) format(decimals int) string {
+	switch {
+	case !n.set:
+		return ""
+	case n.quoted:
+		return n.text
+	}
+	return strconv.FormatFloat(n.value, 'f', decimals, 64)
+}
+
+// unixTime is a Unix timestamp in seconds (activity feed) or milliseconds (market
+// channel), as a number or a string
+type unixTime struct{ number }
+
+// Time returns the timestamp, or now when it is absent
+func (t unixTime) Time() time.Time {
+	switch {
+	case !t.set || t.value <= 0:
+		return time.Now()
+	case t.value >= 1e12:
+		return time.UnixMilli(int64(t.value))
+	}
+	return time.Unix(int64(t.value), 0)
+}
@@ -0,0 +1,173 @@
+package polymarket
+
+import (
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// Market channel messages describe an outcome token's order book. Prices and sizes
+// keep the text Polymarket sent.
+
+// BookLevel is a price level of an order book
+type BookLevel struct {
+	Price number `json:"price"`
+	Size  number `json:"size"`
+}
+
+// Values returns the level's price and size
+func (l BookLevel) Values() (price, size float64) {
+	return l.Price.value, l.Size.value
+}
+
+// BookMessage is a full order book snapshot of an outcome token
+type BookMessage struct {
+	rawMessage
+	AssetID   string      `json:"asset_id"`
+	Market    string      `json:"market"` // Condition ID
+	Bids      []BookLevel `json:"bids"`
+	Asks      []BookLevel `json:"asks"`
+	Hash      string      `json:"hash"`
+	Timestamp unixTime    `json:"timestamp"`
+}
+
+// Events returns the snapshot as a book event carrying the best bid and ask
+func (m *BookMessage) Events() []domain.PolymarketEvent {
+	event := marketEvent(domain.PolymarketEventBook, m.AssetID, m.Market, m.Timestamp, m.raw)
+	if bid, ok := bestLevel(m.Bids, func(a, b float64) bool { return a > b }); ok {
+		event.BestBid = bid.Price.format(4)
+	}
+	if ask, ok := bestLevel(m.Asks, func(a, b float64) bool { return a < b }); ok {
+		event.BestAsk = ask.Price.format(4)
+	}
+	return []domain.PolymarketEvent{event}
+}
+
+// DecodeBook decodes the RawData of a book event
+func DecodeBook(rawData string) (*BookMessage, error) {
+	messages, err := decodeAs[BookMessage]([]byte(rawData))
+	if err != nil {
+		return nil, err
+	}
+	return messages[0].(*BookMessage), nil
+}
+
+// PriceLevelChange is the new size of one price level; size 0 removes the level
+type PriceLevelChange struct {
+	AssetID string `json:"asset_id"` // Empty in the legacy format, where it is on the message
+	Price   number `json:"price"`
+	Size    number `json:"size"`
+	Side    string `json:"side"`
+	BestBid number `json:"best_bid"`
+	BestAsk number `json:"best_ask"`
+}
+
+// PriceChangeMessage carries level updates to one or more outcome books of a market
+type PriceChangeMessage struct {
+	rawMessage
+	Market       string             `json:"market"` // Condition ID
+	PriceChanges []PriceLevelChange `json:"price_changes"`
+	Timestamp    unixTime           `json:"timestamp"`
+
+	// Legacy format: one asset per message
+	AssetID string             `json:"asset_id"`
+	Changes []PriceLevelChange `json:"changes"`
+}
+
+// Events returns a price change event per updated level
+func (m *PriceChangeMessage) Events() []domain.PolymarketEvent {
+	changes := m.PriceChanges
+	if len(changes) == 0 {
+		changes = m.Changes
+	}
+	events := make([]domain.PolymarketEvent, 0, len(changes))
+	for _, change := range changes {
+		assetID := change.AssetID
+		if assetID == "" {
+			assetID = m.AssetID
+		}
+		event := marketEvent(domain.PolymarketEventPriceChange, assetID, m.Market, m.Timestamp, m.raw)
+		event.Price = change.Price.format(4)
+		event.Size = change.Size.format(2)
+		event.Side = domain.OrderSide(strings.ToUpper(change.Side))
+		event.BestBid = change.BestBid.format(4)
+		event.BestAsk = change.BestAsk.format(4)
+		events = append(events, event)
+	}
+	return events
+}
+
+// TickSizeChangeMessage announces a new minimum price increment for an outcome token
+type TickSizeChangeMessage struct {
+	rawMessage
+	AssetID     string   `json:"asset_id"`
+	Market      string   `json:"market"` // Condition ID
+	OldTickSize number   `json:"old_tick_size"`
+	NewTickSize number   `json:"new_tick_size"`
+	Timestamp   unixTime `json:"timestamp"`
+}
+
+// Events returns the change as a tick size change event; the sizes are in RawData
+func (m *TickSizeChangeMessage) Events() []domain.PolymarketEvent {
+	return []domain.PolymarketEvent{marketEvent(domain.PolymarketEventTickSizeChange, m.AssetID, m.Market, m.Timestamp, m.raw)}
+}
+
+// TickSize returns the new tick size
+func (m *TickSizeChangeMessage) TickSize() float64 {
+	return m.NewTickSize.value
+}
+
+// DecodeTickSizeChange decodes the RawData of a tick size change event
+func DecodeTickSizeChange(rawData string) (*TickSizeChangeMessage, error) {
+	messages, err := decodeAs[TickSizeChangeMessage]([]byte(rawData))
+	if err != nil {
+		return nil, err
+	}
+	return messages[0].(*TickSizeChangeMessage), nil
+}
+
+// LastTradePriceMessage is a match on an outcome token's book
+type LastTradePriceMessage struct {
+	rawMessage
+	AssetID    string   `json:"asset_id"`
+	Market     string   `json:"market"` // Condition ID
+	Price      number   `json:"price"`
+	Size       number   `json:"size"`
+	Side       string   `json:"side"`
+	FeeRateBps number   `json:"fee_rate_bps"`
+	Timestamp  unixTime `json:"timestamp"`
+}
+
+// Events returns the match as a last trade price event
+func (m *LastTradePriceMessage) Events() []domain.PolymarketEvent {
+	event := marketEvent(domain.PolymarketEventLastTradePrice, m.AssetID, m.Market, m.Timestamp, m.raw)
+	event.Price = m.Price.format(4)
+	event.Size = m.Size.format(2)
+	event.Side = domain.OrderSide(strings.ToUpper(m.Side))
+	event.FeeRateBps = int(m.FeeRateBps.value)
+	return []domain.PolymarketEvent{event}
+}
+
+// marketEvent returns the fields every market channel event shares
+func marketEvent(eventType domain.PolymarketEventType, assetID, market string, ts unixTime, raw string) domain.PolymarketEvent {
+	return domain.PolymarketEvent{
+		EventType:   eventType,
+		AssetID:     assetID,
+		ConditionID: market,
+		Timestamp:   ts.Time(),
+		RawData:     raw,
+	}
+}
+
+// bestLevel returns the level whose price wins under better, regardless of the order
+// the levels were sent in
+func bestLevel(levels []BookLevel, better func(a, b float64) bool) (BookLevel, bool) {
+	var best BookLevel
+	found := false
+	for _, level := range levels {
+		if level.Price.set && (!found || better(level.Price.value, best.Price.value)) {
+			best, found = level, true
+		}
+	}
+	return best, found
+}
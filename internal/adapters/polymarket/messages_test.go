@@ -0,0 +1,266 @@
+package polymarket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// decodeFile decodes a frame from testdata into the events it produces
+func decodeFile(t *testing.T, name string) ([]domain.PolymarketEvent, error) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages, err := DecodeMessage(data)
+	var events []domain.PolymarketEvent
+	for _, msg := range messages {
+		events = append(events, msg.Events()...)
+	}
+	return events, err
+}
+
+func TestDecodeMessage(t *testing.T) {
+	ms := func(v int64) time.Time { return time.UnixMilli(v) }
+	tests := []struct {
+		file string
+		want []domain.PolymarketEvent
+	}{
+		{"trade.json", []domain.PolymarketEvent{{
+			EventType:     domain.PolymarketEventTrade,
+			AssetID:       "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+			ConditionID:   "0x5f65177b394277fd294cd75650044e32ba009a95022d88a0c1d565897d72f8f1",
+			TradeID:       "0xbd1ac84dc7e1b4b0cd1c4ef0a3bb6c3e6d0e0c6c2b1d4ee1f9d6d3c1d5e1a2b3",
+			WalletAddress: "0x6af75d4e4aaf700450efbac3708cce1665810ff1",
+			Side:          domain.OrderSideBuy,
+			Outcome:       "Yes",
+			Price:         "0.270000",
+			Size:          "1250.50",
+			MarketSlug:    "us-recession-in-2025",
+			EventSlug:     "us-recession-in-2025",
+			MarketName:    "US recession in 2025?",
+			EventTitle:    "US recession in 2025?",
+			MarketImage:   "https://polymarket-upload.s3.us-east-2.amazonaws.com/recession.png",
+			MarketLink:    "https://polymarket.com/event/us-recession-in-2025",
+			TraderName:    "Frosty-Beacon",
+			Timestamp:     time.Unix(1760000000, 0), // The payload's seconds, not the envelope's milliseconds
+		}}},
+		{"trade_quoted.json", []domain.PolymarketEvent{{
+			EventType:     domain.PolymarketEventTrade,
+			AssetID:       "111",
+			ConditionID:   "0xabc",
+			TradeID:       "0xdead",
+			WalletAddress: "0x00000000000000000000000000000000000000aa",
+			Side:          domain.OrderSideSell,
+			Outcome:       "No",
+			OutcomeIndex:  1,
+			Price:         "0.035",
+			Size:          "400",
+			MarketSlug:    "fed-cut",
+			MarketName:    "Fed cut?",
+			EventTitle:    "Fed cut?",
+			MarketLink:    "https://polymarket.com/event/fed-cut",
+			TraderName:    "whale",
+			Timestamp:     ms(1760000000000),
+		}}},
+		{"book.json", []domain.PolymarketEvent{{
+			EventType:   domain.PolymarketEventBook,
+			AssetID:     "111",
+			ConditionID: "0xabc",
+			BestBid:     "0.5",
+			BestAsk:     "0.52",
+			Timestamp:   ms(1760000000000),
+		}}},
+		{"price_change.json", []domain.PolymarketEvent{{
+			EventType:   domain.PolymarketEventPriceChange,
+			AssetID:     "111",
+			ConditionID: "0xabc",
+			Price:       "0.5",
+			Size:        "200",
+			Side:        domain.OrderSideBuy,
+			BestBid:     "0.5",
+			BestAsk:     "0.52",
+			Timestamp:   ms(1760000000500),
+		}, {
+			EventType:   domain.PolymarketEventPriceChange,
+			AssetID:     "222",
+			ConditionID: "0xabc",
+			Price:       "0.5",
+			Size:        "0",
+			Side:        domain.OrderSideSell,
+			BestBid:     "0.47",
+			BestAsk:     "0.5",
+			Timestamp:   ms(1760000000500),
+		}}},
+		{"price_change_legacy.json", []domain.PolymarketEvent{{
+			EventType:   domain.PolymarketEventPriceChange,
+			AssetID:     "111",
+			ConditionID: "0xabc",
+			Price:       "0.4000",
+			Size:        "3300.00",
+			Side:        domain.OrderSideBuy,
+			Timestamp:   time.Unix(1760000000, 0),
+		}}},
+		{"tick_size_change.json", []domain.PolymarketEvent{{
+			EventType:   domain.PolymarketEventTickSizeChange,
+			AssetID:     "111",
+			ConditionID: "0xabc",
+			Timestamp:   ms(1760000000000),
+		}}},
+		{"last_trade_price.json", []domain.PolymarketEvent{{
+			EventType:   domain.PolymarketEventLastTradePrice,
+			AssetID:     "111",
+			ConditionID: "0xabc",
+			Price:       "0.456",
+			Size:        "219.22",
+			Side:        domain.OrderSideBuy,
+			Timestamp:   ms(1760000000750),
+		}}},
+		{"array.json", []domain.PolymarketEvent{{
+			EventType:   domain.PolymarketEventBook,
+			AssetID:     "111",
+			ConditionID: "0xabc",
+			BestBid:     "0.5",
+			Timestamp:   ms(1760000000000),
+		}, {
+			EventType:   domain.PolymarketEventBook,
+			AssetID:     "222",
+			ConditionID: "0xabc",
+			BestAsk:     "0.51",
+			Timestamp:   ms(1760000000000),
+		}}},
+		{"empty_payload.json", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			events, err := decodeFile(t, tt.file)
+			if err != nil {
+				t.Fatalf("DecodeMessage: %v", err)
+			}
+			for i := range events {
+				var compact bytes.Buffer
+				raw := events[i].RawData
+				if err := json.Compact(&compact, []byte(raw)); err != nil || compact.String() != raw {
+					t.Errorf("event %d RawData is not compact JSON: %q", i, raw)
+				}
+				events[i].RawData = ""
+			}
+			if !reflect.DeepEqual(events, tt.want) {
+				t.Errorf("events:\n got %+v\nwant %+v", events, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeMessageSubscriptionAck(t *testing.T) {
+	events, err := decodeFile(t, "subscription_ack.json")
+	if !errors.Is(err, errUnknownMessage) {
+		t.Fatalf("got error %v, want errUnknownMessage", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("got %d events from an acknowledgment", len(events))
+	}
+}
+
+func TestDecodeTickSizeChange(t *testing.T) {
+	events, err := decodeFile(t, "tick_size_change.json")
+	if err != nil || len(events) != 1 {
+		t.Fatalf("got %d events, error %v", len(events), err)
+	}
+	msg, err := DecodeTickSizeChange(events[0].RawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.TickSize() != 0.001 || msg.OldTickSize.value != 0.01 {
+		t.Errorf("tick size %g -> %g, want 0.01 -> 0.001", msg.OldTickSize.value, msg.TickSize())
+	}
+}
+
+func TestDecodeBook(t *testing.T) {
+	events, err := decodeFile(t, "book.json")
+	if err != nil || len(events) != 1 {
+		t.Fatalf("got %d events, error %v", len(events), err)
+	}
+	msg, err := DecodeBook(events[0].RawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msg.Bids) != 3 || len(msg.Asks) != 2 {
+		t.Fatalf("got %d bids and %d asks, want 3 and 2", len(msg.Bids), len(msg.Asks))
+	}
+	if price, size := msg.Bids[1].Values(); price != 0.5 || size != 15 {
+		t.Errorf("second bid = %g x %g, want 0.5 x 15", price, size)
+	}
+}
+
+func TestUnixTime(t *testing.T) {
+	tests := []struct {
+		json string
+		want time.Time
+	}{
+		{`1760000000`, time.Unix(1760000000, 0)},
+		{`"1760000000"`, time.Unix(1760000000, 0)},
+		{`1760000000123`, time.UnixMilli(1760000000123)},
+		{`"1760000000123"`, time.UnixMilli(1760000000123)},
+		{`999999999999`, time.Unix(999999999999, 0)}, // Just below the milliseconds cut-off
+	}
+	for _, tt := range tests {
+		var ts unixTime
+		if err := json.Unmarshal([]byte(tt.json), &ts); err != nil {
+			t.Fatalf("unmarshal %s: %v", tt.json, err)
+		}
+		if got := ts.Time(); !got.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.json, got, tt.want)
+		}
+	}
+
+	// Absent, null and zero timestamps fall back to now
+	for _, data := range []string{`null`, `0`, `""`} {
+		var ts unixTime
+		if err := json.Unmarshal([]byte(data), &ts); err != nil {
+			t.Fatalf("unmarshal %s: %v", data, err)
+		}
+		if got := ts.Time(); time.Since(got) > time.Minute {
+			t.Errorf("%s: got %v, want now", data, got)
+		}
+	}
+}
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		json     string
+		decimals int
+		want     string
+		value    float64
+	}{
+		{`0.27`, 6, "0.270000", 0.27},
+		{`"0.27"`, 6, "0.27", 0.27}, // Strings keep the text as sent
+		{`1250`, 2, "1250.00", 1250},
+		{`"1e-3"`, 4, "1e-3", 0.001},
+		{`null`, 4, "", 0},
+		{`""`, 4, "", 0},
+	}
+	for _, tt := range tests {
+		var n number
+		if err := json.Unmarshal([]byte(tt.json), &n); err != nil {
+			t.Fatalf("unmarshal %s: %v", tt.json, err)
+		}
+		if got := n.format(tt.decimals); got != tt.want || n.value != tt.value {
+			t.Errorf("%s: got %q (%g), want %q (%g)", tt.json, got, n.value, tt.want, tt.value)
+		}
+	}
+
+	var n number
+	if err := json.Unmarshal([]byte(`true`), &n); err == nil {
+		t.Errorf("unmarshal true: want an error")
+	}
+}
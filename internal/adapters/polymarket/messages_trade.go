@@ -0,0 +1,75 @@
+package polymarket
+
+import (
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// TradeMessage is a trade from the live data activity feed
+type TradeMessage struct {
+	rawMessage
+	TransactionHash string   `json:"transactionHash"`
+	ConditionID     string   `json:"conditionId"`
+	Asset           string   `json:"asset"` // Outcome token ID
+	ProxyWallet     string   `json:"proxyWallet"`
+	Side            string   `json:"side"`
+	Outcome         string   `json:"outcome"`
+	OutcomeIndex    number   `json:"outcomeIndex"`
+	Price           number   `json:"price"`
+	Size            number   `json:"size"`      // Shares
+	Slug            string   `json:"slug"`      // Market slug
+	EventSlug       string   `json:"eventSlug"` // Slug of the event the market belongs to
+	Title           string   `json:"title"`
+	Icon            string   `json:"icon"` // Market image URL
+	Name            string   `json:"name"`
+	Pseudonym       string   `json:"pseudonym"` // Shown when the trader set no name
+	Timestamp       unixTime `json:"timestamp"`
+}
+
+// DecodeTrade decodes the RawData of a stored trade event
+func DecodeTrade(rawData string) (*TradeMessage, error) {
+	messages, err := decodeAs[TradeMessage]([]byte(rawData))
+	if err != nil {
+		return nil, err
+	}
+	return messages[0].(*TradeMessage), nil
+}
+
+// Events returns the trade as a trade event
+func (m *TradeMessage) Events() []domain.PolymarketEvent {
+	event := domain.PolymarketEvent{
+		EventType:     domain.PolymarketEventTrade,
+		AssetID:       m.ConditionID, // conditionId identifies the market when asset is missing
+		ConditionID:   m.ConditionID,
+		TradeID:       m.TransactionHash,
+		WalletAddress: m.ProxyWallet,
+		Side:          domain.OrderSide(m.Side),
+		Outcome:       m.Outcome,
+		OutcomeIndex:  int(m.OutcomeIndex.value),
+		Price:         m.Price.format(6),
+		Size:          m.Size.format(2),
+		MarketSlug:    m.Slug,
+		EventSlug:     m.EventSlug,
+		MarketName:    m.Title,
+		EventTitle:    m.Title,
+		MarketImage:   m.Icon,
+		TraderName:    m.Name,
+		Timestamp:     m.Timestamp.Time(),
+		RawData:       m.raw,
+	}
+	if m.Asset != "" {
+		event.AssetID = m.Asset
+	}
+	if event.TraderName == "" {
+		event.TraderName = m.Pseudonym
+	}
+
+	// Prefer the event page over the specific market's odds
+	if event.EventSlug != "" {
+		event.MarketLink = fmt.Sprintf("https://polymarket.com/event/%s", event.EventSlug)
+	} else if event.MarketSlug != "" {
+		event.MarketLink = fmt.Sprintf("https://polymarket.com/event/%s", event.MarketSlug)
+	}
+	return []domain.PolymarketEvent{event}
+}
@@ -0,0 +1,87 @@
+package polymarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// CLOB API for historical outcome prices
+	clobPricesHistoryURL = "https://clob.polymarket.com/prices-history"
+
+	// priceLookupWindow is how far from the requested time a price point may be
+	priceLookupWindow = 10 * time.Minute
+)
+
+// pricesHistoryResponse is the CLOB prices-history payload
+type pricesHistoryResponse struct {
+	History []struct {
+		T int64   `json:"t"`
+		P float64 `json:"p"`
+	} `json:"history"`
+}
+
+// PriceClient fetches outcome token prices from the CLOB API
+type PriceClient struct {
+	httpClient *http.Client
+}
+
+// NewPriceClient creates a new CLOB price client
+func NewPriceClient() *PriceClient {
+	return &PriceClient{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// SetTransport replaces the HTTP transport, e.g. with one setting identification headers
+func (c *PriceClient) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// GetPriceAt returns the price of an outcome token closest to the given time.
+// ok is false when no price was recorded within 10 minutes of it.
+func (c *PriceClient) GetPriceAt(ctx context.Context, assetID string, at time.Time) (price float64, ok bool, err error) {
+	query := url.Values{}
+	query.Set("market", assetID)
+	query.Set("startTs", strconv.FormatInt(at.Add(-priceLookupWindow).Unix(), 10))
+	query.Set("endTs", strconv.FormatInt(at.Add(priceLookupWindow).Unix(), 10))
+	query.Set("fidelity", "1")
+
+	req, err := http.NewRequestWithContext(ctx, "GET", clobPricesHistoryURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to fetch prices: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, statusError("clob", resp)
+	}
+
+	var history pricesHistoryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
+		return 0, false, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	best := math.MaxFloat64
+	for _, point := range history.History {
+		if d := math.Abs(float64(point.T - at.Unix())); d < best {
+			best = d
+			price, ok = point.P, true
+		}
+	}
+	return price, ok, nil
+}
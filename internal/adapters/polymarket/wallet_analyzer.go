@@ -7,10 +7,11 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 const (
@@ -34,6 +35,12 @@ const (
 	newbieBonus         = 0.1 // 0-20 bets
 	largeTradeBonus     = 0.1
 	largeTradeThreshold = 10000.0 // $10,000
+
+	// Longshot bias: large bets at extreme prices
+	longshotMaxPrice     = 0.10   // Below 10¢
+	favoriteMinPrice     = 0.90   // Above 90¢
+	longshotMinTradeSize = 1000.0 // $1,000
+	longshotBonus        = 0.15
 )
 
 // ProfileStatsResponse represents the response from Polymarket profile stats API
@@ -56,7 +63,8 @@ type WalletAnalyzer struct {
 	httpClient *http.Client
 	cache      map[string]*cachedProfile
 	config     domain.PolymarketConfig
-	store      WalletStore // Database store for wallet profiles
+	store      WalletStore     // Database store for wallet profiles
+	blacklist  map[string]bool // Lowercased addresses never analyzed, see SetBlacklist
 }
 
 type cachedProfile struct {
@@ -76,12 +84,39 @@ func NewWalletAnalyzer(config domain.PolymarketConfig, store WalletStore) *Walle
 	}
 }
 
+// SetTransport replaces the HTTP transport used for profile requests, e.g. with a caching one
+func (a *WalletAnalyzer) SetTransport(rt http.RoundTripper) {
+	a.httpClient.Transport = rt
+}
+
+// SetBlacklist replaces the wallets that are never analyzed, e.g. known market makers
+// and bots, so they cost no profile API calls
+func (a *WalletAnalyzer) SetBlacklist(addresses []string) {
+	blacklist := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		blacklist[strings.ToLower(address)] = true
+	}
+	a.mu.Lock()
+	a.blacklist = blacklist
+	a.mu.Unlock()
+}
+
+// IsBlacklisted reports whether a wallet is on the blacklist
+func (a *WalletAnalyzer) IsBlacklisted(address string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.blacklist[strings.ToLower(address)]
+}
+
 // AnalyzeWallet retrieves and analyzes a wallet's profile
 // Priority: 1. Memory cache, 2. Database (if analyzed), 3. Polymarket API
 func (a *WalletAnalyzer) AnalyzeWallet(ctx context.Context, address string) (*domain.WalletProfile, error) {
 	if address == "" {
 		return nil, fmt.Errorf("empty wallet address")
 	}
+	if a.IsBlacklisted(address) {
+		return nil, domain.ErrWalletBlacklisted
+	}
 
 	// 1. Check memory cache first (fastest)
 	if profile := a.getFromCache(address); profile != nil {
@@ -92,9 +127,10 @@ func (a *WalletAnalyzer) AnalyzeWallet(ctx context.Context, address string) (*do
 		return profile, nil
 	}
 
-	// 2. Check database - only use if already analyzed (BetCount >= 0)
+	// 2. Check database - only use if already analyzed (BetCount >= 0) from the API;
+	// estimates made offline are retried
 	if a.store != nil {
-		if dbProfile, err := a.store.GetWallet(address); err == nil && dbProfile != nil && dbProfile.BetCount >= 0 {
+		if dbProfile, err := a.store.GetWallet(address); err == nil && dbProfile != nil && dbProfile.BetCount >= 0 && dbProfile.Source != domain.WalletSourceLocal {
 			// Recalculate freshness based on current config thresholds
 			dbProfile.FreshnessLevel = a.determineFreshnessLevel(dbProfile.BetCount)
 			dbProfile.IsFresh = dbProfile.FreshnessLevel != domain.FreshnessNone
@@ -114,6 +150,10 @@ func (a *WalletAnalyzer) AnalyzeWallet(ctx context.Context, address string) (*do
 	stats, err := a.getProfileStats(ctx, address)
 	if err != nil {
 		log.Printf("[WalletAnalyzer] Failed to get profile stats for %s: %v", shortenAddress(address), err)
+		if profile := a.localProfile(address); profile != nil {
+			a.saveLocalProfile(profile)
+			return profile, nil
+		}
 		// Return a default profile with unknown data
 		return &domain.WalletProfile{
 			Address:        address,
@@ -160,7 +200,7 @@ func (a *WalletAnalyzer) AnalyzeWallet(ctx context.Context, address string) (*do
 
 // AnalyzeTrade analyzes a trade event for fresh wallet signals
 func (a *WalletAnalyzer) AnalyzeTrade(ctx context.Context, event *domain.PolymarketEvent) (*domain.FreshWalletSignal, error) {
-	if event.WalletAddress == "" {
+	if event.WalletAddress == "" || a.IsBlacklisted(event.WalletAddress) {
 		return nil, nil
 	}
 
@@ -182,7 +222,8 @@ func (a *WalletAnalyzer) AnalyzeTrade(ctx context.Context, event *domain.Polymar
 	}
 
 	// Calculate confidence score
-	confidence, factors := a.calculateConfidence(profile, tradeSize)
+	price, _ := strconv.ParseFloat(event.Price, 64)
+	confidence, factors := a.calculateConfidence(profile, tradeSize, price)
 
 	signal := &domain.FreshWalletSignal{
 		Confidence: confidence,
@@ -197,7 +238,7 @@ func (a *WalletAnalyzer) AnalyzeTrade(ctx context.Context, event *domain.Polymar
 	event.RiskScore = confidence
 
 	// Add risk signals
-	event.RiskSignals = a.generateRiskSignals(profile, tradeSize)
+	event.RiskSignals = a.generateRiskSignals(profile, tradeSize, price)
 
 	log.Printf("[WalletAnalyzer] Fresh wallet detected: %s bets=%d level=%s confidence=%.2f trade=$%.2f",
 		shortenAddress(event.WalletAddress), profile.BetCount, profile.FreshnessLevel, confidence, tradeSize)
@@ -215,7 +256,7 @@ func (a *WalletAnalyzer) getProfileStats(ctx context.Context, address string) (*
 	}
 
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36")
+	req.Header.Set("User-Agent", DefaultUserAgent) // Replaced by the configured identity, see Identity
 
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
@@ -224,7 +265,7 @@ func (a *WalletAnalyzer) getProfileStats(ctx context.Context, address string) (*
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, statusError("profile", resp)
 	}
 
 	var stats ProfileStatsResponse
@@ -244,6 +285,11 @@ func (a *WalletAnalyzer) getBetCount(ctx context.Context, address string) (int,
 	return stats.Trades, nil
 }
 
+// ClassifyBetCount returns the freshness level for a bet count under the current config
+func (a *WalletAnalyzer) ClassifyBetCount(betCount int) domain.FreshnessLevel {
+	return a.determineFreshnessLevel(betCount)
+}
+
 // determineFreshnessLevel categorizes the wallet based on bet count
 func (a *WalletAnalyzer) determineFreshnessLevel(betCount int) domain.FreshnessLevel {
 	if betCount < 0 {
@@ -284,7 +330,7 @@ func (a *WalletAnalyzer) determineFreshnessLevel(betCount int) domain.FreshnessL
 	return domain.FreshnessNone
 }
 
-func (a *WalletAnalyzer) calculateConfidence(profile *domain.WalletProfile, tradeSize float64) (float64, map[string]float64) {
+func (a *WalletAnalyzer) calculateConfidence(profile *domain.WalletProfile, tradeSize, price float64) (float64, map[string]float64) {
 	factors := make(map[string]float64)
 	confidence := baseConfidence
 	factors["base"] = baseConfidence
@@ -317,6 +363,12 @@ func (a *WalletAnalyzer) calculateConfidence(profile *domain.WalletProfile, trad
 		confidence += largeTradeBonus
 	}
 
+	// Longshot bonus: big money at an extreme price suggests knowledge of a surprise outcome
+	if isLongshotBet(tradeSize, price) {
+		factors["longshot_bet"] = longshotBonus
+		confidence += longshotBonus
+	}
+
 	// Clamp confidence to [0, 1]
 	if confidence > 1.0 {
 		confidence = 1.0
@@ -328,7 +380,7 @@ func (a *WalletAnalyzer) calculateConfidence(profile *domain.WalletProfile, trad
 	return confidence, factors
 }
 
-func (a *WalletAnalyzer) generateRiskSignals(profile *domain.WalletProfile, tradeSize float64) []string {
+func (a *WalletAnalyzer) generateRiskSignals(profile *domain.WalletProfile, tradeSize, price float64) []string {
 	var signals []string
 
 	switch profile.FreshnessLevel {
@@ -345,14 +397,50 @@ func (a *WalletAnalyzer) generateRiskSignals(profile *domain.WalletProfile, trad
 	case domain.FreshnessCustom:
 		signals = append(signals, fmt.Sprintf("✨ Fresher (%d bets)", profile.BetCount))
 	}
+	if profile.IsFresh && profile.Source == domain.WalletSourceLocal {
+		signals = append(signals, "📴 Offline estimate (stored trades only)")
+	}
 
 	if tradeSize >= largeTradeThreshold {
 		signals = append(signals, fmt.Sprintf("💰 Large Position ($%.2f)", tradeSize))
 	}
 
+	if isLongshotBet(tradeSize, price) {
+		signals = append(signals, longshotSignal(tradeSize, price))
+	}
+
 	return signals
 }
 
+// LongshotSignal returns the risk signal for a large bet at an extreme price
+// (below 10¢ or above 90¢), or "" if the trade isn't one
+func LongshotSignal(event domain.PolymarketEvent) string {
+	price, err := strconv.ParseFloat(event.Price, 64)
+	if err != nil {
+		return ""
+	}
+	size, err := strconv.ParseFloat(event.Size, 64)
+	if err != nil {
+		return ""
+	}
+	if !isLongshotBet(price*size, price) {
+		return ""
+	}
+	return longshotSignal(price*size, price)
+}
+
+// isLongshotBet reports whether a trade is large and placed at an extreme price
+func isLongshotBet(tradeSize, price float64) bool {
+	if tradeSize < longshotMinTradeSize || price <= 0 {
+		return false
+	}
+	return price < longshotMaxPrice || price > favoriteMinPrice
+}
+
+func longshotSignal(tradeSize, price float64) string {
+	return fmt.Sprintf("🎯 Longshot Bet ($%.2f at %.1f¢)", tradeSize, price*100)
+}
+
 func (a *WalletAnalyzer) parseTradeSize(event *domain.PolymarketEvent) float64 {
 	if event.Size == "" || event.Price == "" {
 		return 0
@@ -388,6 +476,13 @@ func (a *WalletAnalyzer) getFromCache(address string) *domain.WalletProfile {
 	return cached.profile
 }
 
+// CacheSize returns the number of cached wallet profiles, including expired ones not yet evicted
+func (a *WalletAnalyzer) CacheSize() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return len(a.cache)
+}
+
 func (a *WalletAnalyzer) addToCache(address string, profile *domain.WalletProfile) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -467,12 +562,20 @@ func (a *WalletAnalyzer) FetchAndUpdateWallet(ctx context.Context, address strin
 	if address == "" {
 		return nil, fmt.Errorf("empty wallet address")
 	}
+	if a.IsBlacklisted(address) {
+		return nil, domain.ErrWalletBlacklisted
+	}
 
 	// Always fetch from Polymarket Profile API (bypass cache and DB)
 	stats, err := a.getProfileStats(ctx, address)
 	if err != nil {
 		log.Printf("[WalletAnalyzer] Failed to fetch profile stats for %s: %v", shortenAddress(address), err)
-		return nil, err
+		profile := a.localProfile(address)
+		if profile == nil {
+			return nil, err
+		}
+		a.saveLocalProfile(profile)
+		return profile, nil
 	}
 
 	// Determine freshness level based on current config
@@ -0,0 +1,43 @@
+package polymarket
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestLongshotSignal(t *testing.T) {
+	for _, tc := range []struct {
+		price, size string
+		want        string
+	}{
+		{"0.05", "40000", "🎯 Longshot Bet ($2000.00 at 5.0¢)"},
+		{"0.95", "2000", "🎯 Longshot Bet ($1900.00 at 95.0¢)"},
+		{"0.05", "1000", ""}, // Too small
+		{"0.5", "100000", ""},
+		{"0.10", "100000", ""},
+		{"", "100000", ""},
+	} {
+		got := LongshotSignal(domain.PolymarketEvent{Price: tc.price, Size: tc.size})
+		if got != tc.want {
+			t.Errorf("LongshotSignal(%s x %s) = %q, want %q", tc.size, tc.price, got, tc.want)
+		}
+	}
+}
+
+func TestLongshotBetRaisesConfidence(t *testing.T) {
+	a := NewWalletAnalyzer(domain.PolymarketConfig{}, nil)
+	profile := &domain.WalletProfile{BetCount: 3, FreshnessLevel: domain.FreshnessInsider, IsFresh: true}
+
+	even, _ := a.calculateConfidence(profile, 2000, 0.5)
+	longshot, factors := a.calculateConfidence(profile, 2000, 0.05)
+	if factors["longshot_bet"] != longshotBonus || longshot-even < longshotBonus-1e-9 {
+		t.Errorf("confidence = %.2f at 5¢ and %.2f at 50¢, want the longshot factor on top", longshot, even)
+	}
+
+	signals := a.generateRiskSignals(profile, 2000, 0.05)
+	if len(signals) != 2 || !strings.HasPrefix(signals[1], "🎯 Longshot Bet") {
+		t.Errorf("signals = %v, want the longshot signal after the freshness one", signals)
+	}
+}
@@ -0,0 +1,68 @@
+package polymarket
+
+import (
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// TradeHistoryStore is implemented by wallet stores that keep the trades seen by the
+// monitor; the analyzer estimates profiles from them while the profile API is down
+type TradeHistoryStore interface {
+	GetWalletActivitySince(wallet string, since time.Time) (*domain.WalletActivityDiff, error)
+}
+
+// localProfile estimates a wallet's profile from stored data when OfflineWalletAnalysis
+// is on: the stored trades stand in for the bet count and the first time the wallet was
+// seen for its age. Returns nil when the mode is off or the store has no trade history.
+func (a *WalletAnalyzer) localProfile(address string) *domain.WalletProfile {
+	if !a.config.OfflineWalletAnalysis {
+		return nil
+	}
+	history, ok := a.store.(TradeHistoryStore)
+	if !ok {
+		return nil
+	}
+	activity, err := history.GetWalletActivitySince(address, time.Time{})
+	if err != nil {
+		log.Printf("[WalletAnalyzer] Failed to read stored trades of %s: %v", shortenAddress(address), err)
+		return nil
+	}
+
+	now := time.Now()
+	profile := &domain.WalletProfile{
+		Address:        address,
+		BetCount:       activity.Trades,
+		FirstSeen:      now,
+		AnalyzedAt:     now,
+		FreshThreshold: a.getMaxFreshThreshold(),
+		Source:         domain.WalletSourceLocal,
+		// Backward compatibility
+		Nonce:        activity.Trades,
+		TotalTxCount: activity.Trades,
+		IsBrandNew:   activity.Trades == 0,
+	}
+	if stored, err := a.store.GetWallet(address); err == nil && stored != nil {
+		if !stored.FirstSeen.IsZero() {
+			profile.FirstSeen = stored.FirstSeen
+		}
+		profile.JoinDate = stored.JoinDate
+	}
+	profile.AgeHours = now.Sub(profile.FirstSeen).Hours()
+	profile.FreshnessLevel = a.determineFreshnessLevel(profile.BetCount)
+	profile.IsFresh = profile.FreshnessLevel != domain.FreshnessNone
+
+	log.Printf("[WalletAnalyzer] Estimated wallet offline: %s stored trades=%d fresh=%v level=%s",
+		shortenAddress(address), profile.BetCount, profile.IsFresh, profile.FreshnessLevel)
+	return profile
+}
+
+// saveLocalProfile stores and caches an estimated profile; the refresh worker replaces
+// it with the API's data once the profile API is reachable again
+func (a *WalletAnalyzer) saveLocalProfile(profile *domain.WalletProfile) {
+	if err := a.store.SaveWallet(*profile); err != nil {
+		log.Printf("[WalletAnalyzer] Failed to save wallet to DB: %v", err)
+	}
+	a.addToCache(profile.Address, profile)
+}
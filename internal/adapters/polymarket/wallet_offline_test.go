@@ -0,0 +1,95 @@
+package polymarket
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// tradeHistory is a wallet store that keeps stored trade counts by wallet
+type tradeHistory struct {
+	wallets map[string]domain.WalletProfile
+	trades  map[string]int
+}
+
+func (h *tradeHistory) GetWallet(address string) (*domain.WalletProfile, error) {
+	if profile, ok := h.wallets[address]; ok {
+		return &profile, nil
+	}
+	return nil, nil
+}
+
+func (h *tradeHistory) SaveWallet(profile domain.WalletProfile) error {
+	h.wallets[profile.Address] = profile
+	return nil
+}
+
+func (h *tradeHistory) GetWalletActivitySince(wallet string, since time.Time) (*domain.WalletActivityDiff, error) {
+	return &domain.WalletActivityDiff{Trades: h.trades[wallet]}, nil
+}
+
+// profileAPI answers profile requests with a bet count, or fails while down
+type profileAPI struct {
+	down   bool
+	trades string
+}
+
+func (p *profileAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	if p.down {
+		return nil, errors.New("connection refused")
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"trades": ` + p.trades + `}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestOfflineAnalysisEstimatesFromStoredTrades(t *testing.T) {
+	firstSeen := time.Now().Add(-48 * time.Hour)
+	store := &tradeHistory{
+		wallets: map[string]domain.WalletProfile{"0xa": {Address: "0xa", BetCount: -1, FirstSeen: firstSeen}},
+		trades:  map[string]int{"0xa": 2},
+	}
+	api := &profileAPI{down: true, trades: "50"}
+	config := domain.DefaultPolymarketConfig()
+
+	// Without the mode an unreachable API leaves the wallet unknown
+	a := NewWalletAnalyzer(config, store)
+	a.SetTransport(api)
+	if profile, err := a.AnalyzeWallet(context.Background(), "0xa"); err != nil || profile.BetCount != -1 || profile.Source != "" {
+		t.Fatalf("profile = %+v, %v, want an unknown bet count", profile, err)
+	}
+
+	config.OfflineWalletAnalysis = true
+	a = NewWalletAnalyzer(config, store)
+	a.SetTransport(api)
+	profile, err := a.AnalyzeWallet(context.Background(), "0xa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Source != domain.WalletSourceLocal || profile.BetCount != 2 || !profile.IsFresh || profile.AgeHours < 47 {
+		t.Errorf("profile = %+v, want a fresh local estimate of 2 bets, 2 days old", profile)
+	}
+	if stored := store.wallets["0xa"]; stored.Source != domain.WalletSourceLocal || stored.BetCount != 2 {
+		t.Errorf("stored profile = %+v, want the estimate", stored)
+	}
+	signals := a.generateRiskSignals(profile, 2000, 0.5)
+	if len(signals) == 0 || signals[len(signals)-1] != "📴 Offline estimate (stored trades only)" {
+		t.Errorf("signals = %v, want the estimate marked", signals)
+	}
+
+	// Once the API is back, a stored estimate is replaced rather than trusted
+	api.down = false
+	a = NewWalletAnalyzer(config, store)
+	a.SetTransport(api)
+	if profile, err := a.AnalyzeWallet(context.Background(), "0xa"); err != nil || profile.Source != "" || profile.BetCount != 50 {
+		t.Errorf("profile = %+v, %v, want the API's 50 bets", profile, err)
+	}
+}
@@ -2,6 +2,7 @@ package polymarket
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,7 +13,7 @@ import (
 
 	"github.com/gorilla/websocket"
 
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 const (
@@ -32,6 +33,9 @@ const (
 // EventCallback is called when a new event is received
 type EventCallback func(event domain.PolymarketEvent)
 
+// ErrorCallback is called with a *domain.FeedError when connecting or reading fails
+type ErrorCallback func(err error)
+
 // WebSocketClient handles connection to Polymarket WebSocket
 type WebSocketClient struct {
 	mu             sync.RWMutex
@@ -40,6 +44,8 @@ type WebSocketClient struct {
 	isConnecting   atomic.Bool
 	stopCh         chan struct{}
 	eventCallback  EventCallback
+	errorCallback  ErrorCallback
+	identity       *Identity // Sets the dial headers, nil = none
 	reconnectDelay time.Duration
 
 	// Status tracking
@@ -60,6 +66,20 @@ func NewWebSocketClient(callback EventCallback) *WebSocketClient {
 	}
 }
 
+// SetIdentity sets the identity whose headers the connection is dialed with
+func (c *WebSocketClient) SetIdentity(identity *Identity) {
+	c.mu.Lock()
+	c.identity = identity
+	c.mu.Unlock()
+}
+
+// SetErrorCallback sets the callback receiving feed errors
+func (c *WebSocketClient) SetErrorCallback(callback ErrorCallback) {
+	c.mu.Lock()
+	c.errorCallback = callback
+	c.mu.Unlock()
+}
+
 // Connect establishes connection to Polymarket WebSocket
 // This method returns immediately and runs the connection in the background
 func (c *WebSocketClient) Connect() error {
@@ -95,6 +115,7 @@ func (c *WebSocketClient) connectionLoop() {
 			if err := c.connect(); err != nil {
 				log.Printf("[Polymarket] Connection failed: %v", err)
 				c.setError(fmt.Sprintf("connection failed: %v", err))
+				c.reportError(&domain.FeedError{Op: "connect", Err: err})
 				c.isConnecting.Store(false)
 				c.waitReconnect()
 				continue
@@ -123,10 +144,19 @@ func (c *WebSocketClient) connect() error {
 	}
 
 	log.Printf("[Polymarket] Dialing %s", wsLiveDataURL)
-	conn, resp, err := dialer.Dial(wsLiveDataURL, http.Header{})
+	header := http.Header{}
+	c.mu.RLock()
+	if c.identity != nil {
+		header = c.identity.Headers(wsLiveDataURL)
+	}
+	c.mu.RUnlock()
+	conn, resp, err := dialer.Dial(wsLiveDataURL, header)
 	if err != nil {
 		if resp != nil {
 			log.Printf("[Polymarket] Dial failed with status %d: %v", resp.StatusCode, err)
+			if resp.StatusCode == http.StatusTooManyRequests {
+				err = &domain.UpstreamRateLimited{Service: "trade feed", RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+			}
 		}
 		return fmt.Errorf("dial failed: %w", err)
 	}
@@ -259,6 +289,7 @@ func (c *WebSocketClient) readLoop() {
 		if err != nil {
 			log.Printf("[Polymarket] Read error: %v", err)
 			c.setError(fmt.Sprintf("read error: %v", err))
+			c.reportError(&domain.FeedError{Op: "read", Err: err})
 			return
 		}
 
@@ -284,115 +315,39 @@ func (c *WebSocketClient) processMessage(data []byte) {
 		return
 	}
 
-	var msg map[string]any
-	if err := json.Unmarshal(data, &msg); err != nil {
-		log.Printf("[Polymarket] Failed to parse message: %v", err)
+	messages, err := DecodeMessage(data)
+	if errors.Is(err, errUnknownMessage) {
+		// Log other message types for debugging
+		if len(data) < 200 {
+			log.Printf("[Polymarket] Unknown message format: %s", string(data))
+		}
 		return
 	}
-
-	// Check if this is a trade message - the format is:
-	// {"connection_id":"...", "payload": {...trade data...}}
-	// OR for topic-based: {"topic":"activity", "type":"trades", "payload":{...}}
-	payload, hasPayload := msg["payload"].(map[string]any)
-	if hasPayload {
-		c.processTradePayload(payload)
-		return
+	if err != nil {
+		log.Printf("[Polymarket] Failed to parse message: %v", err)
 	}
 
-	// Log other message types for debugging
-	if len(data) < 200 {
-		log.Printf("[Polymarket] Unknown message format: %s", string(data))
+	for _, msg := range messages {
+		for _, event := range msg.Events() {
+			c.publishEvent(event)
+		}
 	}
 }
 
-func (c *WebSocketClient) processTradePayload(payload map[string]any) {
-	// Skip if payload is empty
-	if len(payload) == 0 {
-		return
-	}
-
-	event := domain.PolymarketEvent{
-		EventType: domain.PolymarketEventTrade,
-		Timestamp: time.Now(),
-	}
-
-	// Extract trade fields
-	if v, ok := payload["transactionHash"].(string); ok {
-		event.TradeID = v
-	}
-	if v, ok := payload["conditionId"].(string); ok {
-		event.ConditionID = v
-		event.AssetID = v // Use conditionId as the primary identifier
-	}
-	if v, ok := payload["asset"].(string); ok {
-		event.AssetID = v
-	}
-	if v, ok := payload["proxyWallet"].(string); ok {
-		event.WalletAddress = v
-	}
-	if v, ok := payload["side"].(string); ok {
-		event.Side = domain.OrderSide(v)
-	}
-	if v, ok := payload["outcome"].(string); ok {
-		event.Outcome = v
-	}
-	if v, ok := payload["outcomeIndex"].(float64); ok {
-		event.OutcomeIndex = int(v)
-	}
-	if v, ok := payload["price"].(float64); ok {
-		event.Price = fmt.Sprintf("%.6f", v)
-	} else if v, ok := payload["price"].(string); ok {
-		event.Price = v
-	}
-	if v, ok := payload["size"].(float64); ok {
-		event.Size = fmt.Sprintf("%.2f", v)
-	} else if v, ok := payload["size"].(string); ok {
-		event.Size = v
-	}
-	if v, ok := payload["slug"].(string); ok {
-		event.MarketSlug = v
-	}
-	if v, ok := payload["eventSlug"].(string); ok {
-		event.EventSlug = v
-	}
-	if v, ok := payload["title"].(string); ok {
-		event.MarketName = v
-		event.EventTitle = v
-	}
-	if v, ok := payload["name"].(string); ok {
-		event.TraderName = v
-	}
-	if v, ok := payload["pseudonym"].(string); ok && event.TraderName == "" {
-		event.TraderName = v
-	}
-
-	// Parse timestamp
-	if ts, ok := payload["timestamp"].(float64); ok {
-		event.Timestamp = time.Unix(int64(ts), 0)
-	}
-
-	// Store raw data
-	if rawBytes, err := json.Marshal(payload); err == nil {
-		event.RawData = string(rawBytes)
-	}
-
-	// Generate market link - prefer EventSlug (event page) over MarketSlug (specific odds)
-	if event.EventSlug != "" {
-		event.MarketLink = fmt.Sprintf("https://polymarket.com/event/%s", event.EventSlug)
-	} else if event.MarketSlug != "" {
-		event.MarketLink = fmt.Sprintf("https://polymarket.com/event/%s", event.MarketSlug)
-	}
-
+// publishEvent counts a decoded event and hands it to the callback
+func (c *WebSocketClient) publishEvent(event domain.PolymarketEvent) {
 	// Update counters
 	c.eventsReceived.Add(1)
-	c.tradesReceived.Add(1)
+	if event.EventType == domain.PolymarketEventTrade {
+		c.tradesReceived.Add(1)
+	}
 
 	c.mu.Lock()
 	c.lastEventAt = time.Now()
 	c.mu.Unlock()
 
 	// Check if this looks like a significant trade (size > 100 shares)
-	if event.Size != "" {
+	if event.EventType == domain.PolymarketEventTrade && event.Size != "" {
 		if size, err := strconv.ParseFloat(event.Size, 64); err == nil && size >= 100 {
 			log.Printf("[Polymarket] Trade: %s %s shares @ %s on %s by %s",
 				event.Side, event.Size, event.Price, event.MarketSlug, shortenAddress(event.WalletAddress))
@@ -435,6 +390,15 @@ func (c *WebSocketClient) setError(msg string) {
 	c.mu.Unlock()
 }
 
+func (c *WebSocketClient) reportError(err error) {
+	c.mu.RLock()
+	callback := c.errorCallback
+	c.mu.RUnlock()
+	if callback != nil {
+		callback(err)
+	}
+}
+
 // Disconnect closes the WebSocket connection
 func (c *WebSocketClient) Disconnect() {
 	c.mu.Lock()
@@ -5,7 +5,7 @@ import (
 	"sync"
 	"time"
 
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // TokenBucket implements a token bucket rate limiter
@@ -0,0 +1,321 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// requestTimeout bounds each API call to the daemon
+const requestTimeout = 15 * time.Second
+
+// Client calls the REST API of an xtools daemon (cmd/xtoolsd)
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the daemon at baseURL
+func NewClient(config domain.RemoteBackendConfig) (*Client, error) {
+	u, err := url.Parse(strings.TrimSpace(config.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid daemon URL %q: use http(s)://host:port", config.URL)
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(u.String(), "/"),
+		token:      config.Token,
+		httpClient: &http.Client{},
+	}, nil
+}
+
+// URL returns the daemon's base URL
+func (c *Client) URL() string {
+	return c.baseURL
+}
+
+// StartWatcher starts the daemon's trade feed watcher
+func (c *Client) StartWatcher() error {
+	return c.do(http.MethodPost, "/api/watcher/start", nil, nil)
+}
+
+// StopWatcher stops the daemon's trade feed watcher
+func (c *Client) StopWatcher() error {
+	return c.do(http.MethodPost, "/api/watcher/stop", nil, nil)
+}
+
+// Status returns the daemon's watcher status
+func (c *Client) Status() (domain.PolymarketWatcherStatus, error) {
+	var status domain.PolymarketWatcherStatus
+	err := c.do(http.MethodGet, "/api/status", nil, &status)
+	return status, err
+}
+
+// Events returns events stored by the daemon
+func (c *Client) Events(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	var events []domain.PolymarketEvent
+	err := c.do(http.MethodGet, "/api/events", eventQuery(filter), &events)
+	return events, err
+}
+
+// EventCount returns how many of the daemon's events match a filter
+func (c *Client) EventCount(filter domain.PolymarketEventFilter) (int64, error) {
+	var result struct {
+		Count int64 `json:"count"`
+	}
+	err := c.do(http.MethodGet, "/api/events/count", eventQuery(filter), &result)
+	return result.Count, err
+}
+
+// EventPage returns a page of the daemon's events with the totals of the filter
+func (c *Client) EventPage(filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error) {
+	var page domain.PolymarketEventPage
+	if err := c.do(http.MethodGet, "/api/events/page", eventQuery(filter), &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// MarketAggregates returns the daemon's per-market trade totals per hour or day
+func (c *Client) MarketAggregates(filter domain.PolymarketEventFilter, bucket domain.AggregateBucket) ([]domain.MarketAggregate, error) {
+	q := eventQuery(filter)
+	q.Set("bucket", string(bucket))
+	var aggregates []domain.MarketAggregate
+	err := c.do(http.MethodGet, "/api/aggregates", q, &aggregates)
+	return aggregates, err
+}
+
+// eventQuery encodes an event filter as the API's query parameters
+func eventQuery(filter domain.PolymarketEventFilter) url.Values {
+	q := url.Values{}
+	setInt(q, "limit", filter.Limit)
+	setInt(q, "offset", filter.Offset)
+	setFloat(q, "minSize", filter.MinSize)
+	setFloat(q, "minRiskScore", filter.MinRiskScore)
+	if filter.MarketName != "" {
+		q.Set("market", filter.MarketName)
+	}
+	if filter.FreshWalletsOnly {
+		q.Set("freshOnly", "true")
+	}
+	if filter.Outcome != "" {
+		q.Set("outcome", filter.Outcome)
+	}
+	if filter.OutcomeIndex != nil {
+		q.Set("outcomeIndex", strconv.Itoa(*filter.OutcomeIndex))
+	}
+	if filter.Tag != "" {
+		q.Set("tag", filter.Tag)
+	}
+	if filter.Entity != "" {
+		q.Set("entity", filter.Entity)
+	}
+	if filter.WalletTag != "" {
+		q.Set("walletTag", filter.WalletTag)
+	}
+	if filter.SortBy != "" {
+		q.Set("sort", string(filter.SortBy))
+	}
+	if filter.SortDir != "" {
+		q.Set("sortDir", string(filter.SortDir))
+	}
+	for _, t := range filter.EventTypes {
+		q.Add("type", string(t))
+	}
+	for _, wallet := range filter.Wallets() {
+		q.Add("wallet", wallet)
+	}
+	for _, slug := range filter.MarketSlugs {
+		q.Add("slug", slug)
+	}
+	for _, id := range filter.ConditionIDs {
+		q.Add("conditionId", id)
+	}
+	for _, name := range filter.ExcludeMarketNames {
+		q.Add("excludeMarket", name)
+	}
+	for _, wallet := range filter.ExcludeWallets {
+		q.Add("excludeWallet", wallet)
+	}
+	if !filter.Since.IsZero() {
+		q.Set("since", filter.Since.Format(time.RFC3339))
+	}
+	if !filter.Until.IsZero() {
+		q.Set("until", filter.Until.Format(time.RFC3339))
+	}
+	return q
+}
+
+// SearchEvents finds the daemon's events by words of their market name or event title
+func (c *Client) SearchEvents(query string, limit int) ([]domain.PolymarketEvent, error) {
+	q := url.Values{}
+	q.Set("q", query)
+	setInt(q, "limit", limit)
+	var events []domain.PolymarketEvent
+	err := c.do(http.MethodGet, "/api/events/search", q, &events)
+	return events, err
+}
+
+// Wallets returns wallets analyzed by the daemon
+func (c *Client) Wallets(limit int) ([]domain.WalletProfile, error) {
+	q := url.Values{}
+	setInt(q, "limit", limit)
+	var wallets []domain.WalletProfile
+	err := c.do(http.MethodGet, "/api/wallets", q, &wallets)
+	return wallets, err
+}
+
+// SearchWallets finds the daemon's wallets by partial address, trader name or tag
+func (c *Client) SearchWallets(query string, limit int) ([]domain.WalletSearchResult, error) {
+	q := url.Values{}
+	q.Set("q", query)
+	setInt(q, "limit", limit)
+	var results []domain.WalletSearchResult
+	err := c.do(http.MethodGet, "/api/wallets/search", q, &results)
+	return results, err
+}
+
+// EntityFlow returns the daemon's trade flow per entity between since and until
+func (c *Client) EntityFlow(since, until time.Time, limit int) (*domain.EntityFlowReport, error) {
+	q := url.Values{}
+	if !since.IsZero() {
+		q.Set("since", since.Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		q.Set("until", until.Format(time.RFC3339))
+	}
+	setInt(q, "limit", limit)
+	var report domain.EntityFlowReport
+	if err := c.do(http.MethodGet, "/api/entities/flow", q, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// MarketSnapshots returns the daemon's stored market snapshots, newest first
+func (c *Client) MarketSnapshots(filter domain.MarketSnapshotFilter) ([]domain.MarketSnapshot, error) {
+	q := url.Values{}
+	if filter.MarketSlug != "" {
+		q.Set("slug", filter.MarketSlug)
+	}
+	if !filter.Since.IsZero() {
+		q.Set("since", filter.Since.Format(time.RFC3339))
+	}
+	if !filter.Until.IsZero() {
+		q.Set("until", filter.Until.Format(time.RFC3339))
+	}
+	setInt(q, "limit", filter.Limit)
+	var snapshots []domain.MarketSnapshot
+	err := c.do(http.MethodGet, "/api/markets/snapshots", q, &snapshots)
+	return snapshots, err
+}
+
+// SystemStatus returns the daemon's process and queue statistics
+func (c *Client) SystemStatus() (*domain.SystemStatus, error) {
+	var status domain.SystemStatus
+	if err := c.do(http.MethodGet, "/api/system", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ErrorStats returns the daemon's error counts and recent errors
+func (c *Client) ErrorStats() (*domain.ErrorStats, error) {
+	var stats domain.ErrorStats
+	if err := c.do(http.MethodGet, "/api/errors", nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// DatabaseInfo returns statistics of the daemon's database
+func (c *Client) DatabaseInfo() (*domain.DatabaseInfo, error) {
+	var info domain.DatabaseInfo
+	if err := c.do(http.MethodGet, "/api/database", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// do sends a request and decodes the JSON response into out, if given
+func (c *Client) do(method, path string, query url.Values, out any) error {
+	return c.send(method, path, query, nil, out)
+}
+
+// send is do with a JSON request body, if body is not nil
+func (c *Client) send(method, path string, query url.Values, body, out any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("daemon unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&failure)
+		if failure.Error == "" {
+			failure.Error = resp.Status
+		}
+		return fmt.Errorf("daemon: %s", failure.Error)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("daemon: invalid response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) authorize(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
+
+func setInt(q url.Values, key string, v int) {
+	if v > 0 {
+		q.Set(key, strconv.Itoa(v))
+	}
+}
+
+func setFloat(q url.Values, key string, v float64) {
+	if v > 0 {
+		q.Set(key, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+}
@@ -0,0 +1,151 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// newDaemon serves handler as the daemon's API and returns a client with token
+func newDaemon(t *testing.T, token string, handler http.HandlerFunc) *Client {
+	t.Helper()
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	client, err := NewClient(domain.RemoteBackendConfig{Enabled: true, URL: ts.URL + "/", Token: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestNewClientRejectsInvalidURLs(t *testing.T) {
+	for _, raw := range []string{"", "localhost:8787", "ftp://daemon:8787", "http://"} {
+		if _, err := NewClient(domain.RemoteBackendConfig{Enabled: true, URL: raw}); err == nil {
+			t.Errorf("NewClient(%q) succeeded, want an error", raw)
+		}
+	}
+	client, err := NewClient(domain.RemoteBackendConfig{Enabled: true, URL: " https://daemon:8787/ "})
+	if err != nil || client.URL() != "https://daemon:8787" {
+		t.Errorf("NewClient = %v, %v, want https://daemon:8787", client, err)
+	}
+}
+
+func TestEventsSendsTokenAndFilter(t *testing.T) {
+	since := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	index := 1
+	client := newDaemon(t, "secret", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/events" || r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		q := r.URL.Query()
+		for key, want := range map[string]string{
+			"limit": "50", "market": "Election", "minSize": "1000.5", "freshOnly": "true",
+			"outcomeIndex": "1", "sort": "notional", "since": since.Format(time.RFC3339),
+		} {
+			if q.Get(key) != want {
+				t.Errorf("query %s = %q, want %q", key, q.Get(key), want)
+			}
+		}
+		if got := strings.Join(q["wallet"], ","); got != "0xaaa,0xbbb" {
+			t.Errorf("wallet = %q, want both wallets", got)
+		}
+		if _, set := q["offset"]; set {
+			t.Error("zero offset was sent")
+		}
+		json.NewEncoder(w).Encode([]domain.PolymarketEvent{{TradeID: "trade-1", MarketName: "Election"}})
+	})
+
+	events, err := client.Events(domain.PolymarketEventFilter{
+		Limit: 50, MarketName: "Election", MinSize: 1000.5, FreshWalletsOnly: true, OutcomeIndex: &index,
+		SortBy: domain.EventSortField("notional"), Since: since,
+		WalletAddress: "0xaaa", WalletAddresses: []string{"0xbbb"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].TradeID != "trade-1" {
+		t.Errorf("events = %+v, want trade-1", events)
+	}
+}
+
+func TestDaemonErrorsAreReported(t *testing.T) {
+	client := newDaemon(t, "", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/watcher/start":
+			w.WriteHeader(http.StatusInternalServerError)
+			io.WriteString(w, `{"error": "feed unreachable"}`)
+		case "/api/status":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			io.WriteString(w, "not json")
+		}
+	})
+
+	if err := client.StartWatcher(); err == nil || !strings.Contains(err.Error(), "feed unreachable") {
+		t.Errorf("StartWatcher() = %v, want the daemon's error message", err)
+	}
+	if _, err := client.Status(); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Errorf("Status() = %v, want the HTTP status", err)
+	}
+	if _, err := client.SystemStatus(); err == nil || !strings.Contains(err.Error(), "invalid response") {
+		t.Errorf("SystemStatus() = %v, want an invalid response error", err)
+	}
+}
+
+func TestFollowerEmitsStreamEvents(t *testing.T) {
+	client := newDaemon(t, "secret", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keepalive\n\n")
+		fmt.Fprint(w, "event: polymarket:event\ndata: {\"tradeId\": \"trade-1\"}\n\n")
+		fmt.Fprint(w, "event: errors\ndata: {\"total\": 2}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	type received struct {
+		name string
+		data string
+	}
+	events := make(chan received, 10)
+	follower := NewFollower(client, func(name string, data json.RawMessage) {
+		events <- received{name, string(data)}
+	})
+	follower.Start()
+	defer follower.Stop()
+
+	for _, want := range []received{
+		{"polymarket:event", `{"tradeId": "trade-1"}`},
+		{"errors", `{"total": 2}`},
+	} {
+		select {
+		case got := <-events:
+			if got != want {
+				t.Errorf("emitted %+v, want %+v", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", want.name)
+		}
+	}
+	if status := follower.Status(); !status.StreamConnected || status.LastError != "" {
+		t.Errorf("Status() = %+v, want connected", status)
+	}
+}
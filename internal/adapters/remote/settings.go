@@ -0,0 +1,198 @@
+package remote
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// Config returns the daemon's watcher configuration
+func (c *Client) Config() (domain.PolymarketConfig, error) {
+	return getSetting[domain.PolymarketConfig](c, "/api/config")
+}
+
+// SetConfig replaces the daemon's watcher configuration
+func (c *Client) SetConfig(config domain.PolymarketConfig) error {
+	return c.send(http.MethodPut, "/api/config", nil, config, nil)
+}
+
+// SaveFilter returns the filter the daemon saves events with
+func (c *Client) SaveFilter() (domain.PolymarketEventFilter, error) {
+	return getSetting[domain.PolymarketEventFilter](c, "/api/config/save-filter")
+}
+
+// SetSaveFilter replaces the filter the daemon saves events with
+func (c *Client) SetSaveFilter(filter domain.PolymarketEventFilter) error {
+	return c.send(http.MethodPut, "/api/config/save-filter", nil, filter, nil)
+}
+
+// MutedMarkets returns the daemon's active market mutes
+func (c *Client) MutedMarkets() ([]domain.MarketMute, error) {
+	return getSetting[[]domain.MarketMute](c, "/api/mutes")
+}
+
+// MuteMarket silences the daemon's notifications for a market or event slug
+func (c *Client) MuteMarket(slug string, minutes int) (*domain.MarketMute, error) {
+	q := url.Values{}
+	q.Set("minutes", strconv.Itoa(minutes))
+	var mute domain.MarketMute
+	if err := c.do(http.MethodPut, "/api/mutes/"+url.PathEscape(slug), q, &mute); err != nil {
+		return nil, err
+	}
+	return &mute, nil
+}
+
+// UnmuteMarket removes a market mute on the daemon
+func (c *Client) UnmuteMarket(slug string) error {
+	return c.do(http.MethodDelete, "/api/mutes/"+url.PathEscape(slug), nil, nil)
+}
+
+// TagRules returns the rules the daemon auto-tags events with
+func (c *Client) TagRules() ([]domain.EventTagRule, error) {
+	return getSetting[[]domain.EventTagRule](c, "/api/rules/tags")
+}
+
+// SetTagRules replaces the daemon's event tag rules
+func (c *Client) SetTagRules(rules []domain.EventTagRule) error {
+	return c.send(http.MethodPut, "/api/rules/tags", nil, rules, nil)
+}
+
+// LateEntryRules returns the daemon's late entry rules
+func (c *Client) LateEntryRules() ([]domain.LateEntryRule, error) {
+	return getSetting[[]domain.LateEntryRule](c, "/api/rules/late-entry")
+}
+
+// SetLateEntryRules replaces the daemon's late entry rules
+func (c *Client) SetLateEntryRules(rules []domain.LateEntryRule) error {
+	return c.send(http.MethodPut, "/api/rules/late-entry", nil, rules, nil)
+}
+
+// EntityAlertRules returns the daemon's entity alert rules
+func (c *Client) EntityAlertRules() ([]domain.EntityAlertRule, error) {
+	return getSetting[[]domain.EntityAlertRule](c, "/api/rules/entity-alerts")
+}
+
+// SetEntityAlertRules replaces the daemon's entity alert rules
+func (c *Client) SetEntityAlertRules(rules []domain.EntityAlertRule) error {
+	return c.send(http.MethodPut, "/api/rules/entity-alerts", nil, rules, nil)
+}
+
+// SamplingRules returns the daemon's event sampling rules
+func (c *Client) SamplingRules() ([]domain.EventSamplingRule, error) {
+	return getSetting[[]domain.EventSamplingRule](c, "/api/rules/sampling")
+}
+
+// SetSamplingRules replaces the daemon's event sampling rules
+func (c *Client) SetSamplingRules(rules []domain.EventSamplingRule) error {
+	return c.send(http.MethodPut, "/api/rules/sampling", nil, rules, nil)
+}
+
+// EventRetention returns the daemon's event retention policy
+func (c *Client) EventRetention() (domain.EventRetention, error) {
+	return getSetting[domain.EventRetention](c, "/api/retention")
+}
+
+// SetEventRetention replaces the daemon's event retention policy
+func (c *Client) SetEventRetention(retention domain.EventRetention) error {
+	return c.send(http.MethodPut, "/api/retention", nil, retention, nil)
+}
+
+// AutoTune returns the daemon's threshold auto-tune settings
+func (c *Client) AutoTune() (domain.AutoTuneSettings, error) {
+	return getSetting[domain.AutoTuneSettings](c, "/api/autotune")
+}
+
+// SetAutoTune replaces the daemon's threshold auto-tune settings
+func (c *Client) SetAutoTune(settings domain.AutoTuneSettings) error {
+	return c.send(http.MethodPut, "/api/autotune", nil, settings, nil)
+}
+
+// WatchedWallets returns the daemon's watchlist
+func (c *Client) WatchedWallets() ([]string, error) {
+	return getSetting[[]string](c, "/api/watchlist")
+}
+
+// WatchWallet adds a wallet to the daemon's watchlist
+func (c *Client) WatchWallet(address string) error {
+	return c.do(http.MethodPut, "/api/watchlist/"+url.PathEscape(address), nil, nil)
+}
+
+// UnwatchWallet removes a wallet from the daemon's watchlist
+func (c *Client) UnwatchWallet(address string) error {
+	return c.do(http.MethodDelete, "/api/watchlist/"+url.PathEscape(address), nil, nil)
+}
+
+// WalletBlacklist returns the daemon's blacklisted wallets
+func (c *Client) WalletBlacklist() ([]domain.BlacklistedWallet, error) {
+	return getSetting[[]domain.BlacklistedWallet](c, "/api/blacklist")
+}
+
+// BlacklistWallet leaves a wallet out of the daemon's analysis and alerts
+func (c *Client) BlacklistWallet(address, reason string) error {
+	body := map[string]string{"reason": reason}
+	return c.send(http.MethodPut, "/api/blacklist/"+url.PathEscape(address), nil, body, nil)
+}
+
+// UnblacklistWallet removes a wallet from the daemon's blacklist
+func (c *Client) UnblacklistWallet(address string) error {
+	return c.do(http.MethodDelete, "/api/blacklist/"+url.PathEscape(address), nil, nil)
+}
+
+// FilterPresets returns the daemon's saved filter presets by name
+func (c *Client) FilterPresets() ([]domain.FilterPreset, error) {
+	return getSetting[[]domain.FilterPreset](c, "/api/presets")
+}
+
+// SaveFilterPreset creates or replaces a filter preset on the daemon
+func (c *Client) SaveFilterPreset(name string, filter domain.PolymarketEventFilter) (*domain.FilterPreset, error) {
+	var preset domain.FilterPreset
+	if err := c.send(http.MethodPut, "/api/presets/"+url.PathEscape(name), nil, filter, &preset); err != nil {
+		return nil, err
+	}
+	return &preset, nil
+}
+
+// RenameFilterPreset renames a filter preset on the daemon
+func (c *Client) RenameFilterPreset(name, newName string) (*domain.FilterPreset, error) {
+	var preset domain.FilterPreset
+	body := map[string]string{"name": newName}
+	if err := c.send(http.MethodPost, "/api/presets/"+url.PathEscape(name)+"/rename", nil, body, &preset); err != nil {
+		return nil, err
+	}
+	return &preset, nil
+}
+
+// DeleteFilterPreset deletes a filter preset on the daemon
+func (c *Client) DeleteFilterPreset(name string) error {
+	return c.do(http.MethodDelete, "/api/presets/"+url.PathEscape(name), nil, nil)
+}
+
+// WalletTags returns the tags of the given wallets on the daemon, or of every tagged wallet
+func (c *Client) WalletTags(addresses []string) (map[string][]string, error) {
+	q := url.Values{}
+	for _, address := range addresses {
+		q.Add("wallet", address)
+	}
+	var tags map[string][]string
+	err := c.do(http.MethodGet, "/api/wallets/tags", q, &tags)
+	return tags, err
+}
+
+// TagWallet attaches a tag to a wallet on the daemon
+func (c *Client) TagWallet(address, tag string) error {
+	return c.do(http.MethodPut, "/api/wallets/"+url.PathEscape(address)+"/tags/"+url.PathEscape(tag), nil, nil)
+}
+
+// UntagWallet removes a tag from a wallet on the daemon
+func (c *Client) UntagWallet(address, tag string) error {
+	return c.do(http.MethodDelete, "/api/wallets/"+url.PathEscape(address)+"/tags/"+url.PathEscape(tag), nil, nil)
+}
+
+// getSetting reads a setting served by the daemon's settings routes
+func getSetting[T any](c *Client, path string) (T, error) {
+	var value T
+	err := c.do(http.MethodGet, path, nil, &value)
+	return value, err
+}
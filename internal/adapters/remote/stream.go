@@ -0,0 +1,151 @@
+package remote
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// minReconnectDelay and maxReconnectDelay bound the backoff between stream reconnects
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+
+	// maxStreamLine bounds a single server-sent event line
+	maxStreamLine = 1 << 20
+)
+
+// EmitFunc receives each event read from the daemon's stream
+type EmitFunc func(eventName string, data json.RawMessage)
+
+// Follower keeps the daemon's event stream (/api/stream) open, reconnecting with
+// backoff, and hands each event to an EmitFunc
+type Follower struct {
+	client *Client
+	emit   EmitFunc
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu          sync.RWMutex
+	connected   bool
+	connectedAt time.Time
+	lastError   string
+}
+
+// NewFollower creates a follower for the client's event stream
+func NewFollower(client *Client, emit EmitFunc) *Follower {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Follower{client: client, emit: emit, ctx: ctx, cancel: cancel}
+}
+
+// Start follows the stream in the background until Stop is called
+func (f *Follower) Start() {
+	go f.run()
+}
+
+// Stop closes the stream
+func (f *Follower) Stop() {
+	f.cancel()
+}
+
+// Status reports the stream's connection state
+func (f *Follower) Status() domain.RemoteBackendStatus {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return domain.RemoteBackendStatus{
+		Active:          true,
+		URL:             f.client.URL(),
+		StreamConnected: f.connected,
+		ConnectedAt:     f.connectedAt,
+		LastError:       f.lastError,
+	}
+}
+
+func (f *Follower) run() {
+	delay := minReconnectDelay
+	for {
+		started := time.Now()
+		err := f.follow()
+		if f.ctx.Err() != nil {
+			f.setDisconnected(nil)
+			return
+		}
+		f.setDisconnected(err)
+
+		// A stream that stayed up a while starts the backoff over
+		if time.Since(started) > maxReconnectDelay {
+			delay = minReconnectDelay
+		}
+		log.Printf("[Remote] Event stream closed: %v, reconnecting in %v", err, delay)
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// follow reads the stream until it ends or Stop is called
+func (f *Follower) follow() error {
+	// The request is cancelled on Stop; the stream has no overall timeout
+	req, err := http.NewRequestWithContext(f.ctx, http.MethodGet, f.client.baseURL+"/api/stream", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	f.client.authorize(req)
+
+	resp, err := f.client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("daemon answered %s", resp.Status)
+	}
+
+	f.mu.Lock()
+	f.connected, f.connectedAt, f.lastError = true, time.Now(), ""
+	f.mu.Unlock()
+	log.Printf("[Remote] Following event stream of %s", f.client.URL())
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxStreamLine)
+	event, data := "", ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if event != "" && data != "" {
+				f.emit(event, json.RawMessage(data))
+			}
+			event, data = "", ""
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data += strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("stream ended")
+}
+
+func (f *Follower) setDisconnected(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.connected = false
+	if err != nil {
+		f.lastError = err.Error()
+	}
+}
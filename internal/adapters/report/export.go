@@ -0,0 +1,109 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// exportFuncs are the helpers available to export column templates
+var exportFuncs = template.FuncMap{
+	"time":  func(t time.Time) string { return t.UTC().Format(time.RFC3339) },
+	"date":  func(t time.Time) string { return t.UTC().Format("2006-01-02") },
+	"money": formatMoney,
+	"price": formatPrice,
+	"pct":   func(v float64) string { return strconv.FormatFloat(math.Round(v*1000)/10, 'f', -1, 64) + "%" },
+	"round": func(v float64, digits int) string { return strconv.FormatFloat(v, 'f', digits, 64) },
+	"mul":   func(a, b float64) float64 { return a * b },
+	"div": func(a, b float64) float64 {
+		if b == 0 {
+			return 0
+		}
+		return a / b
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// ExportColumns are compiled export column templates
+type ExportColumns struct {
+	headers   []string
+	templates []*template.Template
+}
+
+// CompileExportColumns parses the column templates, reporting the first invalid one
+func CompileExportColumns(columns []domain.ExportColumn) (*ExportColumns, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("at least one column is required")
+	}
+	compiled := &ExportColumns{}
+	for i, col := range columns {
+		header := strings.TrimSpace(col.Header)
+		if header == "" {
+			return nil, fmt.Errorf("column %d: header is required", i+1)
+		}
+		tmpl, err := template.New(header).Funcs(exportFuncs).Option("missingkey=error").Parse(col.Template)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", header, err)
+		}
+		// Unknown fields and bad function arguments only fail at execution
+		if err := tmpl.Execute(io.Discard, domain.ExportRow{}); err != nil {
+			return nil, fmt.Errorf("column %q: %w", header, err)
+		}
+		compiled.headers = append(compiled.headers, header)
+		compiled.templates = append(compiled.templates, tmpl)
+	}
+	return compiled, nil
+}
+
+// WriteCSV renders rows into a CSV file under baseDir named after the export.
+// Cells whose template fails are left empty and counted.
+func (c *ExportColumns) WriteCSV(baseDir, name string, rows []domain.ExportRow, now time.Time) (*domain.EventExport, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(c.headers); err != nil {
+		return nil, err
+	}
+
+	failed := 0
+	record := make([]string, len(c.templates))
+	var cell bytes.Buffer
+	for _, row := range rows {
+		for i, tmpl := range c.templates {
+			cell.Reset()
+			if err := tmpl.Execute(&cell, row); err != nil {
+				failed++
+				record[i] = ""
+				continue
+			}
+			record[i] = cell.String()
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	path := filepath.Join(baseDir, fmt.Sprintf("%s-%s.csv", slugify(name), now.Format("20060102-150405")))
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write export: %w", err)
+	}
+	return &domain.EventExport{Path: path, Rows: len(rows), FailedCells: failed}, nil
+}
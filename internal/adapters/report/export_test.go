@@ -0,0 +1,73 @@
+package report
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestCompileExportColumnsRejectsInvalidTemplates(t *testing.T) {
+	for name, columns := range map[string][]domain.ExportColumn{
+		"no columns":    nil,
+		"empty header":  {{Header: " ", Template: "{{.Market}}"}},
+		"syntax error":  {{Header: "Market", Template: "{{.Market"}},
+		"unknown field": {{Header: "Market", Template: "{{.Slug}}"}},
+		"unknown func":  {{Header: "Market", Template: "{{shout .Market}}"}},
+	} {
+		if _, err := CompileExportColumns(columns); err == nil {
+			t.Errorf("%s: compiled, want an error", name)
+		}
+	}
+}
+
+func TestWriteCSVComputesColumns(t *testing.T) {
+	columns, err := CompileExportColumns([]domain.ExportColumn{
+		{Header: "Time", Template: "{{time .Time}}"},
+		{Header: "Market", Template: "{{upper .Market}}"},
+		{Header: "Notional", Template: "{{money .Notional}}"},
+		{Header: "Odds", Template: "{{pct .ImpliedProbability}}"},
+		{Header: "Per bet", Template: "{{round (div .Notional (mul .Size 2)) 2}}"},
+		{Header: "Third tag", Template: "{{if .Tags}}{{index .Tags 2}}{{end}}"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := []domain.ExportRow{
+		{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Market: "Rain, tomorrow?", Notional: 1500, ImpliedProbability: 0.425, Size: 3000, Tags: []string{"a", "b", "c"}},
+		{Market: "Snow", Tags: []string{"only"}},
+	}
+
+	dir := filepath.Join(t.TempDir(), "exports")
+	export, err := columns.WriteCSV(dir, "Weekly whales", rows, time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(export.Path) != "weekly-whales-20260109-000000.csv" || export.Rows != 2 || export.FailedCells != 1 {
+		t.Errorf("export = %+v, want 2 rows with 1 failed cell in weekly-whales-20260109-000000.csv", export)
+	}
+
+	f, err := os.Open(export.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || strings.Join(records[0], "|") != "Time|Market|Notional|Odds|Per bet|Third tag" {
+		t.Fatalf("records = %v, want the header and 2 rows", records)
+	}
+	if want := []string{"2026-01-02T03:04:05Z", "RAIN, TOMORROW?", "$1,500", "42.5%", "0.25", "c"}; !slices.Equal(records[1], want) {
+		t.Errorf("row = %v, want %v", records[1], want)
+	}
+	if records[2][5] != "" || records[2][1] != "SNOW" {
+		t.Errorf("row = %v, want the failed cell left empty", records[2])
+	}
+}
@@ -0,0 +1,107 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// calendarFileName is fixed so calendar apps subscribed to the file pick up re-exports
+const calendarFileName = "polymarket-resolutions.ics"
+
+// icalTime is the UTC date-time format used by iCalendar
+const icalTime = "20060102T150405Z"
+
+// WriteCalendar renders market resolutions as an iCalendar file in the base directory
+func (w *Writer) WriteCalendar(resolutions []domain.MarketResolution, generatedAt time.Time) (string, error) {
+	if err := os.MkdirAll(w.baseDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create calendar directory: %w", err)
+	}
+	path := filepath.Join(w.baseDir, calendarFileName)
+	if err := os.WriteFile(path, RenderCalendar(resolutions, generatedAt), 0644); err != nil {
+		return "", fmt.Errorf("failed to write calendar: %w", err)
+	}
+	return path, nil
+}
+
+// RenderCalendar encodes market resolutions as an iCalendar (RFC 5545) feed with one
+// event per market, importable into Google Calendar, Apple Calendar and Outlook
+func RenderCalendar(resolutions []domain.MarketResolution, generatedAt time.Time) []byte {
+	var b strings.Builder
+	line := func(s string) { b.WriteString(foldICalLine(s) + "\r\n") }
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//XTools//Polymarket Resolutions//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:Polymarket resolutions")
+	for _, r := range resolutions {
+		end := r.EndDate.UTC()
+		line("BEGIN:VEVENT")
+		line("UID:" + r.Slug + "@xtools.polymarket")
+		line("DTSTAMP:" + generatedAt.UTC().Format(icalTime))
+		line("DTSTART:" + end.Format(icalTime))
+		line("DTEND:" + end.Add(time.Hour).Format(icalTime))
+		line("SUMMARY:" + escapeICalText("Resolves: "+r.Name))
+		line("DESCRIPTION:" + escapeICalText(resolutionDescription(r)))
+		if r.Link != "" {
+			line("URL:" + r.Link)
+		}
+		line("BEGIN:VALARM")
+		line("ACTION:DISPLAY")
+		line("TRIGGER:-PT24H")
+		line("DESCRIPTION:" + escapeICalText(r.Name+" resolves in 24 hours"))
+		line("END:VALARM")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// resolutionDescription summarizes why a market is on the calendar
+func resolutionDescription(r domain.MarketResolution) string {
+	var parts []string
+	if !r.GameStartTime.IsZero() {
+		parts = append(parts, "Game starts "+r.GameStartTime.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+	if r.FlaggedTrades > 0 {
+		parts = append(parts, fmt.Sprintf("%d flagged trades, %s total notional, last %s",
+			r.FlaggedTrades, formatMoney(r.FlaggedNotional), r.LastFlaggedAt.UTC().Format("2006-01-02 15:04 UTC")))
+	}
+	if len(r.Investigations) > 0 {
+		parts = append(parts, "Investigations: "+strings.Join(r.Investigations, ", "))
+	}
+	if r.Link != "" {
+		parts = append(parts, r.Link)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// escapeICalText escapes a TEXT property value
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICalLine splits content lines longer than 75 octets, keeping UTF-8 sequences whole
+func foldICalLine(s string) string {
+	if len(s) <= 75 {
+		return s
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		n := len(string(r))
+		if width+n > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
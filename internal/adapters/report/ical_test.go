@@ -0,0 +1,76 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestRenderCalendarEvents(t *testing.T) {
+	end := time.Date(2026, 11, 3, 20, 0, 0, 0, time.FixedZone("EST", -5*3600))
+	cal := string(RenderCalendar([]domain.MarketResolution{{
+		Slug: "us-election", Name: "Who wins; the election, 2026?", Link: "https://polymarket.com/event/us-election",
+		EndDate: end, FlaggedTrades: 3, FlaggedNotional: 52000, LastFlaggedAt: end.Add(-48 * time.Hour),
+		Investigations: []string{"Case 1"},
+	}}, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:us-election@xtools.polymarket\r\n",
+		"DTSTAMP:20261001T000000Z\r\n",
+		"DTSTART:20261104T010000Z\r\n",
+		"DTEND:20261104T020000Z\r\n",
+		`SUMMARY:Resolves: Who wins\; the election\, 2026?` + "\r\n",
+		"TRIGGER:-PT24H\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(cal, want) {
+			t.Errorf("calendar is missing %q", want)
+		}
+	}
+	unfolded := strings.ReplaceAll(cal, "\r\n ", "")
+	if !strings.Contains(unfolded, `3 flagged trades\, $52\,000 total notional`) || !strings.Contains(unfolded, `\nInvestigations: Case 1`) {
+		t.Errorf("description is missing the flagged trades or investigations:\n%s", unfolded)
+	}
+	for _, line := range strings.Split(cal, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets is not folded: %q", len(line), line)
+		}
+	}
+}
+
+func TestFoldICalLineKeepsRunesWhole(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("€", 40)
+	folded := foldICalLine(line)
+	if strings.ReplaceAll(folded, "\r\n ", "") != line {
+		t.Error("unfolding does not restore the line")
+	}
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 || !utf8.ValidString(part) {
+			t.Errorf("folded part %q is too long or splits a rune", part)
+		}
+	}
+}
+
+func TestWriteCalendarOverwritesStablePath(t *testing.T) {
+	w := NewWriter(t.TempDir())
+	first, err := w.WriteCalendar(nil, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := w.WriteCalendar([]domain.MarketResolution{{Slug: "m", Name: "Market", EndDate: time.Now()}}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second || filepath.Base(second) != calendarFileName {
+		t.Errorf("paths = %s, %s, want the same %s", first, second, calendarFileName)
+	}
+	if data, _ := os.ReadFile(second); !strings.Contains(string(data), "UID:m@xtools.polymarket") {
+		t.Error("re-export did not overwrite the calendar")
+	}
+}
@@ -0,0 +1,93 @@
+package report
+
+import (
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// htmlTemplate renders a report as a standalone page with inline styles only
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"time":  func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+	"money": formatMoney,
+	"price": formatPrice,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Roboto,sans-serif;margin:2rem auto;max-width:960px;padding:0 1rem;color:#1f2328;background:#fff}
+h1{margin-bottom:.25rem}h2{margin-top:2rem;border-bottom:1px solid #d0d7de;padding-bottom:.25rem}
+.meta{color:#59636e;font-size:.9rem}
+table{border-collapse:collapse;width:100%;font-size:.9rem}
+th,td{text-align:left;padding:.4rem .5rem;border-bottom:1px solid #eaeef2;vertical-align:top}
+th{background:#f6f8fa}
+code{font-size:.85rem}
+.tag{display:inline-block;background:#ddf4ff;border-radius:1rem;padding:0 .5rem;margin:0 .2rem .2rem 0;font-size:.8rem}
+.note{white-space:pre-wrap;background:#f6f8fa;border-radius:6px;padding:.75rem;margin:.5rem 0}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">{{if .Status}}Status: {{.Status}} · {{end}}Generated {{time .GeneratedAt}}{{if .Redacted}} · Addresses redacted{{end}}</p>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+
+<h2>Wallets</h2>
+{{if .Wallets}}<table>
+<tr><th>Wallet</th><th>Trader</th><th>Bets</th><th>Joined</th><th>Freshness</th><th>Tags</th></tr>
+{{range .Wallets}}<tr>
+<td><code>{{.Label}}</code></td><td>{{.TraderName}}</td>
+<td>{{if lt .BetCount 0}}–{{else}}{{.BetCount}}{{end}}</td>
+<td>{{.JoinDate}}</td><td>{{.FreshnessLevel}}</td>
+<td>{{range .Tags}}<span class="tag">{{.}}</span>{{end}}</td>
+</tr>{{end}}
+</table>{{else}}<p class="meta">No wallets.</p>{{end}}
+
+{{if .Markets}}<h2>Markets</h2>
+<ul>{{range .Markets}}<li>{{.}}</li>{{end}}</ul>{{end}}
+
+<h2>Trades</h2>
+{{if .Trades}}<p class="meta">{{len .Trades}} trades · {{money .TotalNotional}} total notional</p>
+<table>
+<tr><th>Time</th><th>Wallet</th><th>Market</th><th>Side</th><th>Outcome</th><th>Price</th><th>Notional</th><th>Signals</th></tr>
+{{range .Trades}}<tr>
+<td>{{time .Timestamp}}</td><td><code>{{.Wallet}}</code></td>
+<td>{{if .MarketLink}}<a href="{{.MarketLink}}">{{.Market}}</a>{{else}}{{.Market}}{{end}}</td>
+<td>{{.Side}}</td><td>{{.Outcome}}</td><td>{{price .Price}}</td><td>{{money .Notional}}</td>
+<td>{{range .RiskSignals}}<div>{{.}}</div>{{end}}</td>
+</tr>{{end}}
+</table>{{else}}<p class="meta">No trades.</p>{{end}}
+
+{{if .Notes}}<h2>Notes</h2>
+{{range .Notes}}<div class="note"><div class="meta">{{time .Timestamp}}</div>{{.Body}}</div>{{end}}{{end}}
+</body>
+</html>
+`))
+
+// formatMoney formats a USDC amount with thousands separators ("$12,345")
+func formatMoney(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 0, 64)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	if negative {
+		return "-$" + b.String()
+	}
+	return "$" + b.String()
+}
+
+// formatPrice formats an outcome price in cents ("42.5¢")
+func formatPrice(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1000)/10, 'f', -1, 64) + "¢"
+}
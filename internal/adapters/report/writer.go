@@ -0,0 +1,74 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// Writer renders shareable reports into a directory
+type Writer struct {
+	baseDir string
+}
+
+// NewWriter creates a report writer that saves files under baseDir
+func NewWriter(baseDir string) *Writer {
+	return &Writer{baseDir: baseDir}
+}
+
+// Write renders a report and saves it as a single self-contained file
+func (w *Writer) Write(r domain.ShareableReport, format domain.ReportFormat) (*domain.ReportFile, error) {
+	if format == "" {
+		format = domain.ReportFormatHTML
+	}
+	data, err := Render(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(w.baseDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create report directory: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s.%s", slugify(r.Title), r.GeneratedAt.Format("20060102-150405"), format)
+	path := filepath.Join(w.baseDir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return &domain.ReportFile{Path: path, Format: format, Size: int64(len(data))}, nil
+}
+
+// Render encodes a report in the given format
+func Render(r domain.ShareableReport, format domain.ReportFormat) ([]byte, error) {
+	switch format {
+	case domain.ReportFormatJSON:
+		return json.MarshalIndent(r, "", "  ")
+	case domain.ReportFormatHTML:
+		var buf bytes.Buffer
+		if err := htmlTemplate.Execute(&buf, r); err != nil {
+			return nil, fmt.Errorf("failed to render report: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unsupported report format: %q", format)
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify turns a report title into a safe file name
+func slugify(title string) string {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if slug == "" {
+		return "report"
+	}
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+	return slug
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+var testReport = domain.ShareableReport{
+	Kind:        domain.ReportKindInvestigation,
+	Title:       "Election <insiders> & co",
+	Status:      "open",
+	GeneratedAt: time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC),
+	Redacted:    true,
+	Wallets:     []domain.ReportWallet{{Label: "Wallet A", BetCount: -1, Tags: []string{"insider"}}},
+	Trades: []domain.ReportTrade{{
+		Timestamp: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), Wallet: "Wallet A", Market: "Who wins?",
+		MarketLink: "https://polymarket.com/event/who-wins", Price: 0.425, Notional: 1234567,
+	}},
+	TotalNotional: 1234567,
+}
+
+func TestWriteSavesSelfContainedHTML(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+	file, err := NewWriter(dir).Write(testReport, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Format != domain.ReportFormatHTML || filepath.Base(file.Path) != "election-insiders-co-20260304-050607.html" {
+		t.Errorf("file = %+v, want an HTML file named after the title and time", file)
+	}
+	data, err := os.ReadFile(file.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(data)) != file.Size {
+		t.Errorf("size = %d, want %d", file.Size, len(data))
+	}
+	html := string(data)
+	for _, want := range []string{"Election &lt;insiders&gt; &amp; co", "$1,234,567", "42.5¢", "Addresses redacted", `href="https://polymarket.com/event/who-wins"`, "<td>–</td>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report is missing %q", want)
+		}
+	}
+	if strings.Contains(html, "<script") || strings.Contains(html, "<link") {
+		t.Error("report loads external resources")
+	}
+}
+
+func TestRenderJSONAndUnknownFormats(t *testing.T) {
+	data, err := Render(testReport, domain.ReportFormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded domain.ShareableReport
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Title != testReport.Title || len(decoded.Trades) != 1 {
+		t.Errorf("JSON report = %+v, %v, want the report back", decoded, err)
+	}
+	if _, err := Render(testReport, domain.ReportFormat("pdf")); err == nil {
+		t.Error("Render(pdf) succeeded, want an unsupported format error")
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	for v, want := range map[float64]string{0: "$0", 999: "$999", 1000: "$1,000", 1234567.6: "$1,234,568", -52000: "-$52,000"} {
+		if got := formatMoney(v); got != want {
+			t.Errorf("formatMoney(%v) = %q, want %q", v, got, want)
+		}
+	}
+	for title, want := range map[string]string{"": "report", "!!!": "report", "Wallet 0xABC": "wallet-0xabc", strings.Repeat("a b ", 40): strings.TrimRight(strings.Repeat("a-b-", 15), "-")} {
+		if got := slugify(title); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package scripting
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+// AlertTransformer runs a user-provided Starlark script on every alert before delivery.
+// The script defines transform(alert) and returns:
+//   - None or False to suppress the alert
+//   - True to deliver it unchanged
+//   - a dict overriding "title", "message" or "priority" and adding "metadata" fields
+//
+// The script is reloaded automatically when the file changes.
+type AlertTransformer struct {
+	mu          sync.Mutex
+	path        string
+	modTime     time.Time
+	transformFn starlark.Callable
+}
+
+// NewAlertTransformer creates a transformer for the script at path.
+// A missing script delivers alerts unchanged.
+func NewAlertTransformer(path string) *AlertTransformer {
+	return &AlertTransformer{path: path}
+}
+
+// Transform applies the script to an alert. Returns false if the alert should be dropped.
+func (t *AlertTransformer) Transform(content domain.NotificationContent) (domain.NotificationContent, bool, error) {
+	fn := t.load()
+	if fn == nil {
+		return content, true, nil
+	}
+
+	result, err := callScript("alerts", fn, alertToStarlark(content))
+	if err != nil {
+		return content, true, err
+	}
+
+	switch value := result.(type) {
+	case starlark.NoneType:
+		return content, false, nil
+	case starlark.Bool:
+		return content, bool(value), nil
+	case *starlark.Dict:
+		transformed, err := applyAlertOverrides(content, value)
+		if err != nil {
+			return content, true, fmt.Errorf("alerts: %w", err)
+		}
+		return transformed, true, nil
+	default:
+		return content, true, fmt.Errorf("alerts: transform() must return None, a bool or a dict, got %s", result.Type())
+	}
+}
+
+// load returns the transform function, reloading the script if it changed on disk.
+// A broken script keeps the previously loaded version.
+func (t *AlertTransformer) load() starlark.Callable {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stat, err := os.Stat(t.path)
+	if err != nil {
+		if t.transformFn != nil {
+			log.Printf("[Scripting] Alert script %s removed, delivering alerts unchanged", t.path)
+		}
+		t.transformFn = nil
+		t.modTime = time.Time{}
+		return nil
+	}
+	if stat.ModTime().Equal(t.modTime) {
+		return t.transformFn
+	}
+	t.modTime = stat.ModTime()
+
+	globals, err := execScript("alerts", t.path)
+	if err != nil {
+		log.Printf("[Scripting] Failed to load alert script, keeping previous version: %v", err)
+		return t.transformFn
+	}
+	fn, ok := globals["transform"].(starlark.Callable)
+	if !ok {
+		log.Printf("[Scripting] %s: script must define transform(alert), keeping previous version", t.path)
+		return t.transformFn
+	}
+
+	log.Printf("[Scripting] Loaded alert script from %s", t.path)
+	t.transformFn = fn
+	return fn
+}
+
+// alertToStarlark exposes an alert to scripts as a read-only struct
+func alertToStarlark(content domain.NotificationContent) *starlarkstruct.Struct {
+	metadata := starlark.NewDict(len(content.Metadata))
+	for k, v := range content.Metadata {
+		metadata.SetKey(starlark.String(k), starlark.String(v))
+	}
+	metadata.Freeze()
+
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"event_type": starlark.String(content.EventType),
+		"title":      starlark.String(content.Title),
+		"message":    starlark.String(content.Message),
+		"priority":   starlark.String(content.Priority),
+		"timestamp":  starlark.MakeInt64(content.Timestamp.Unix()),
+		"metadata":   metadata,
+	})
+}
+
+// applyAlertOverrides merges the fields returned by transform() into the alert
+func applyAlertOverrides(content domain.NotificationContent, overrides *starlark.Dict) (domain.NotificationContent, error) {
+	for _, field := range []struct {
+		key string
+		dst *string
+	}{
+		{"title", &content.Title},
+		{"message", &content.Message},
+		{"priority", &content.Priority},
+	} {
+		v, found, _ := overrides.Get(starlark.String(field.key))
+		if !found {
+			continue
+		}
+		s, ok := starlark.AsString(v)
+		if !ok {
+			return content, fmt.Errorf("%q must be a string, got %s", field.key, v.Type())
+		}
+		*field.dst = s
+	}
+
+	if v, found, _ := overrides.Get(starlark.String("metadata")); found {
+		extra, err := toStringMap(v)
+		if err != nil {
+			return content, fmt.Errorf(`"metadata": %w`, err)
+		}
+		merged := make(map[string]string, len(content.Metadata)+len(extra))
+		for k, v := range content.Metadata {
+			merged[k] = v
+		}
+		for k, v := range extra {
+			merged[k] = v
+		}
+		content.Metadata = merged
+	}
+
+	return content, nil
+}
+
+// Ensure AlertTransformer implements NotificationTransformer interface
+var _ ports.NotificationTransformer = (*AlertTransformer)(nil)
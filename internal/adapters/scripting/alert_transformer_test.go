@@ -0,0 +1,110 @@
+package scripting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// rewriteScript replaces a script with a newer modification time, so it is reloaded
+func rewriteScript(t *testing.T, path, src string, age time.Duration) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransformWithoutScriptDeliversUnchanged(t *testing.T) {
+	transformer := NewAlertTransformer(filepath.Join(t.TempDir(), "alerts.star"))
+	content := domain.NotificationContent{Title: "Big trade", Message: "$50K on Yes"}
+
+	got, deliver, err := transformer.Transform(content)
+	if err != nil || !deliver || got.Title != content.Title || got.Message != content.Message {
+		t.Errorf("Transform() = %+v, %v, %v, want the alert unchanged", got, deliver, err)
+	}
+}
+
+func TestTransformOverridesAndSuppresses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.star")
+	rewriteScript(t, path, `
+def transform(alert):
+    if alert.priority == "low":
+        return None
+    if alert.event_type == "fresh_wallet":
+        return {"title": "🚨 " + alert.title, "priority": "high", "metadata": {"team": "desk"}}
+    return True
+`, 0)
+	transformer := NewAlertTransformer(path)
+
+	content := domain.NotificationContent{
+		EventType: domain.NotificationEventFreshWallet, Title: "Fresh wallet", Priority: "normal",
+		Metadata: map[string]string{"wallet": "0xabc"},
+	}
+	got, deliver, err := transformer.Transform(content)
+	if err != nil || !deliver {
+		t.Fatalf("Transform() = %v, %v, want the alert delivered", deliver, err)
+	}
+	if got.Title != "🚨 Fresh wallet" || got.Priority != "high" {
+		t.Errorf("transformed alert = %+v, want the new title and priority", got)
+	}
+	if got.Metadata["wallet"] != "0xabc" || got.Metadata["team"] != "desk" {
+		t.Errorf("metadata = %v, want the original fields and the added one", got.Metadata)
+	}
+	if content.Metadata["team"] != "" {
+		t.Error("transform changed the caller's metadata")
+	}
+
+	content.Priority = "low"
+	if _, deliver, err := transformer.Transform(content); err != nil || deliver {
+		t.Errorf("low priority alert: deliver = %v, %v, want it suppressed", deliver, err)
+	}
+	content.EventType, content.Priority = domain.NotificationEventBigTrade, "normal"
+	if got, deliver, err := transformer.Transform(content); err != nil || !deliver || got.Title != "Fresh wallet" {
+		t.Errorf("True result: got %+v, %v, %v, want the alert unchanged", got, deliver, err)
+	}
+}
+
+func TestTransformReloadsChangedScript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.star")
+	rewriteScript(t, path, "def transform(alert):\n    return {\"title\": \"v1\"}\n", 2*time.Minute)
+	transformer := NewAlertTransformer(path)
+	content := domain.NotificationContent{Title: "Big trade"}
+
+	if got, _, _ := transformer.Transform(content); got.Title != "v1" {
+		t.Fatalf("title = %q, want v1", got.Title)
+	}
+
+	rewriteScript(t, path, "def transform(alert):\n    return {\"title\": \"v2\"}\n", time.Minute)
+	if got, _, _ := transformer.Transform(content); got.Title != "v2" {
+		t.Errorf("title = %q after editing the script, want v2", got.Title)
+	}
+
+	// A broken edit keeps the version that worked
+	rewriteScript(t, path, "def transform(alert)\n", 0)
+	if got, _, _ := transformer.Transform(content); got.Title != "v2" {
+		t.Errorf("title = %q after a broken edit, want v2 kept", got.Title)
+	}
+
+	os.Remove(path)
+	if got, deliver, _ := transformer.Transform(content); !deliver || got.Title != "Big trade" {
+		t.Errorf("removed script: got %+v, %v, want the alert unchanged", got, deliver)
+	}
+}
+
+func TestTransformErrorsDeliverTheOriginal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.star")
+	rewriteScript(t, path, "def transform(alert):\n    return {\"title\": 42}\n", 0)
+	content := domain.NotificationContent{Title: "Big trade"}
+
+	got, deliver, err := NewAlertTransformer(path).Transform(content)
+	if err == nil || !deliver || got.Title != "Big trade" {
+		t.Errorf("Transform() = %+v, %v, %v, want an error and the original alert delivered", got, deliver, err)
+	}
+}
@@ -0,0 +1,145 @@
+package scripting
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// eventToStarlark exposes an event to scripts as a read-only struct
+func eventToStarlark(event domain.PolymarketEvent) *starlarkstruct.Struct {
+	price, _ := strconv.ParseFloat(event.Price, 64)
+	size, _ := strconv.ParseFloat(event.Size, 64)
+
+	betCount := -1
+	joinDate := ""
+	freshnessLevel := ""
+	if event.WalletProfile != nil {
+		betCount = event.WalletProfile.BetCount
+		joinDate = event.WalletProfile.JoinDate
+		freshnessLevel = string(event.WalletProfile.FreshnessLevel)
+	}
+
+	signals := make([]starlark.Value, len(event.RiskSignals))
+	for i, s := range event.RiskSignals {
+		signals[i] = starlark.String(s)
+	}
+
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"event_type":      starlark.String(event.EventType),
+		"trade_id":        starlark.String(event.TradeID),
+		"asset_id":        starlark.String(event.AssetID),
+		"condition_id":    starlark.String(event.ConditionID),
+		"market_name":     starlark.String(event.MarketName),
+		"market_slug":     starlark.String(event.MarketSlug),
+		"market_link":     starlark.String(event.MarketLink),
+		"event_slug":      starlark.String(event.EventSlug),
+		"event_title":     starlark.String(event.EventTitle),
+		"outcome":         starlark.String(event.Outcome),
+		"outcome_index":   starlark.MakeInt(event.OutcomeIndex),
+		"side":            starlark.String(event.Side),
+		"price":           starlark.Float(price),
+		"size":            starlark.Float(size),
+		"notional":        starlark.Float(price * size),
+		"wallet_address":  starlark.String(event.WalletAddress),
+		"trader_name":     starlark.String(event.TraderName),
+		"timestamp":       starlark.MakeInt64(event.Timestamp.Unix()),
+		"is_fresh_wallet": starlark.Bool(event.IsFreshWallet),
+		"risk_score":      starlark.Float(event.RiskScore),
+		"risk_signals":    starlark.NewList(signals),
+		"bet_count":       starlark.MakeInt(betCount),
+		"join_date":       starlark.String(joinDate),
+		"freshness_level": starlark.String(freshnessLevel),
+	})
+}
+
+// toStringMap converts a Starlark dict with string keys to a Go map.
+// Values are converted with their string representation.
+func toStringMap(v starlark.Value) (map[string]string, error) {
+	dict, ok := v.(*starlark.Dict)
+	if !ok {
+		return nil, fmt.Errorf("expected dict, got %s", v.Type())
+	}
+
+	result := make(map[string]string, dict.Len())
+	for _, item := range dict.Items() {
+		key, ok := starlark.AsString(item[0])
+		if !ok {
+			return nil, fmt.Errorf("dict keys must be strings, got %s", item[0].Type())
+		}
+		if s, ok := starlark.AsString(item[1]); ok {
+			result[key] = s
+		} else {
+			result[key] = item[1].String()
+		}
+	}
+	return result, nil
+}
+
+// parseSignals converts a detect() result (None, dict or list of dicts) to signals
+func parseSignals(v starlark.Value) ([]domain.DetectorSignal, error) {
+	switch value := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case *starlark.Dict:
+		signal, err := parseSignal(value)
+		if err != nil {
+			return nil, err
+		}
+		return []domain.DetectorSignal{signal}, nil
+	case *starlark.List:
+		var signals []domain.DetectorSignal
+		for i := 0; i < value.Len(); i++ {
+			dict, ok := value.Index(i).(*starlark.Dict)
+			if !ok {
+				return nil, fmt.Errorf("signal %d: expected dict, got %s", i, value.Index(i).Type())
+			}
+			signal, err := parseSignal(dict)
+			if err != nil {
+				return nil, fmt.Errorf("signal %d: %w", i, err)
+			}
+			signals = append(signals, signal)
+		}
+		return signals, nil
+	default:
+		return nil, fmt.Errorf("detect() must return None, a dict or a list of dicts, got %s", v.Type())
+	}
+}
+
+// parseSignal converts a single signal dict ({"signal", "message", "score", "alert", "metadata"})
+func parseSignal(dict *starlark.Dict) (domain.DetectorSignal, error) {
+	var signal domain.DetectorSignal
+
+	if v, found, _ := dict.Get(starlark.String("signal")); found {
+		signal.Signal, _ = starlark.AsString(v)
+	}
+	if signal.Signal == "" {
+		return signal, fmt.Errorf(`missing "signal" name`)
+	}
+	if v, found, _ := dict.Get(starlark.String("message")); found {
+		signal.Message, _ = starlark.AsString(v)
+	}
+	if v, found, _ := dict.Get(starlark.String("score")); found {
+		score, ok := starlark.AsFloat(v)
+		if !ok {
+			return signal, fmt.Errorf(`"score" must be a number, got %s`, v.Type())
+		}
+		signal.Score = score
+	}
+	if v, found, _ := dict.Get(starlark.String("alert")); found {
+		signal.Alert = bool(v.Truth())
+	}
+	if v, found, _ := dict.Get(starlark.String("metadata")); found {
+		metadata, err := toStringMap(v)
+		if err != nil {
+			return signal, fmt.Errorf(`"metadata": %w`, err)
+		}
+		signal.Metadata = metadata
+	}
+
+	return signal, nil
+}
@@ -0,0 +1,144 @@
+package scripting
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.starlark.net/starlark"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+const (
+	// DetectorScriptExt is the file extension of detector scripts
+	DetectorScriptExt = ".star"
+
+	// maxExecutionSteps bounds the work a script may do per call
+	maxExecutionSteps = 1_000_000
+)
+
+// StarlarkDetector runs a user-provided Starlark script as a detector.
+// The script must define detect(event) returning None, a signal dict or a list of them:
+//
+//	def detect(event):
+//	    if event.notional > 50000 and event.bet_count >= 0 and event.bet_count < 5:
+//	        return {"signal": "whale_newbie", "message": "Whale-sized newbie bet", "score": 0.9, "alert": True}
+type StarlarkDetector struct {
+	name     string
+	path     string
+	detectFn starlark.Callable
+}
+
+// LoadStarlarkDetector compiles a detector script. The detector is named after the file.
+func LoadStarlarkDetector(path string) (*StarlarkDetector, error) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	globals, err := execScript(name, path)
+	if err != nil {
+		return nil, err
+	}
+
+	detectFn, ok := globals["detect"].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s: script must define detect(event)", path)
+	}
+
+	return &StarlarkDetector{name: name, path: path, detectFn: detectFn}, nil
+}
+
+// LoadDetectors loads all detector scripts in dir, skipping (and logging) broken ones.
+// A missing directory yields no detectors.
+func LoadDetectors(dir string) []ports.Detector {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+DetectorScriptExt))
+	if err != nil {
+		log.Printf("[Scripting] Failed to list detectors in %s: %v", dir, err)
+		return nil
+	}
+	sort.Strings(paths)
+
+	var detectors []ports.Detector
+	for _, path := range paths {
+		detector, err := LoadStarlarkDetector(path)
+		if err != nil {
+			log.Printf("[Scripting] Skipping detector: %v", err)
+			continue
+		}
+		log.Printf("[Scripting] Loaded detector %s from %s", detector.Name(), path)
+		detectors = append(detectors, detector)
+	}
+	return detectors
+}
+
+// Name returns the detector name
+func (d *StarlarkDetector) Name() string {
+	return d.name
+}
+
+// Detect runs the script's detect() function for an event
+func (d *StarlarkDetector) Detect(event domain.PolymarketEvent) ([]domain.DetectorSignal, error) {
+	result, err := callScript(d.name, d.detectFn, eventToStarlark(event))
+	if err != nil {
+		return nil, err
+	}
+
+	signals, err := parseSignals(result)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", d.name, err)
+	}
+
+	for i := range signals {
+		signals[i].Detector = d.name
+		signals[i].TradeID = event.TradeID
+		signals[i].WalletAddress = event.WalletAddress
+		signals[i].MarketName = event.MarketName
+		signals[i].MarketLink = event.MarketLink
+		signals[i].Timestamp = event.Timestamp
+	}
+	return signals, nil
+}
+
+// execScript runs a script file and returns its frozen globals.
+// Scripts only get the Starlark builtins - no file, network or clock access.
+func execScript(name, path string) (starlark.StringDict, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+
+	thread := newThread(name)
+	globals, err := starlark.ExecFile(thread, path, src, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	globals.Freeze() // Frozen globals are safe to share across concurrent calls
+	return globals, nil
+}
+
+// callScript calls a script function with a fresh, step-limited thread
+func callScript(name string, fn starlark.Callable, args ...starlark.Value) (starlark.Value, error) {
+	result, err := starlark.Call(newThread(name), fn, starlark.Tuple(args), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return result, nil
+}
+
+// newThread creates a sandboxed Starlark thread whose print() goes to the app log
+func newThread(name string) *starlark.Thread {
+	thread := &starlark.Thread{
+		Name: name,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Printf("[Scripting] %s: %s", name, msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(maxExecutionSteps)
+	return thread
+}
+
+// Ensure StarlarkDetector implements Detector interface
+var _ ports.Detector = (*StarlarkDetector)(nil)
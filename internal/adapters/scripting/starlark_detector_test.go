@@ -0,0 +1,107 @@
+package scripting
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// writeScript writes a script into dir and returns its path
+func writeScript(t *testing.T, dir, name, src string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const whaleNewbieScript = `
+def detect(event):
+    if event.notional > 50000 and event.bet_count >= 0 and event.bet_count < 5:
+        return {"signal": "whale_newbie", "message": "Whale-sized newbie bet", "score": 0.9,
+                "alert": True, "metadata": {"outcome": event.outcome}}
+    return None
+`
+
+func TestLoadDetectorsSkipsBrokenScripts(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "whale_newbie.star", whaleNewbieScript)
+	writeScript(t, dir, "syntax_error.star", "def detect(event)\n    return None\n")
+	writeScript(t, dir, "no_detect.star", "def check(event):\n    return None\n")
+	writeScript(t, dir, "notes.txt", "not a script")
+
+	detectors := LoadDetectors(dir)
+	if len(detectors) != 1 || detectors[0].Name() != "whale_newbie" {
+		t.Fatalf("loaded %d detectors, want only whale_newbie", len(detectors))
+	}
+	if detectors := LoadDetectors(filepath.Join(dir, "missing")); len(detectors) != 0 {
+		t.Errorf("missing directory loaded %d detectors, want none", len(detectors))
+	}
+}
+
+func TestDetectReturnsSignalsWithEventContext(t *testing.T) {
+	detector, err := LoadStarlarkDetector(writeScript(t, t.TempDir(), "whale_newbie.star", whaleNewbieScript))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := domain.PolymarketEvent{
+		TradeID: "trade-1", WalletAddress: "0xabc", MarketName: "Will it rain?", Outcome: "Yes",
+		Price: "0.5", Size: "200000", Timestamp: time.Unix(1700000000, 0),
+		WalletProfile: &domain.WalletProfile{BetCount: 2},
+	}
+
+	signals, err := detector.Detect(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signals) != 1 {
+		t.Fatalf("got %d signals, want 1", len(signals))
+	}
+	s := signals[0]
+	if s.Detector != "whale_newbie" || s.Signal != "whale_newbie" || s.Score != 0.9 || !s.Alert {
+		t.Errorf("signal = %+v, want an alerting whale_newbie signal scored 0.9", s)
+	}
+	if s.TradeID != "trade-1" || s.WalletAddress != "0xabc" || !s.Timestamp.Equal(event.Timestamp) {
+		t.Errorf("signal context = %+v, want the event's trade, wallet and time", s)
+	}
+	if s.Metadata["outcome"] != "Yes" {
+		t.Errorf("metadata = %v, want the outcome", s.Metadata)
+	}
+
+	event.WalletProfile.BetCount = 40
+	if signals, err := detector.Detect(event); err != nil || len(signals) != 0 {
+		t.Errorf("seasoned wallet: got %v, %v, want no signals", signals, err)
+	}
+}
+
+func TestDetectErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"runaway":     "def detect(event):\n    for i in range(100000000):\n        pass\n",
+		"no_name":     "def detect(event):\n    return {\"message\": \"unnamed\"}\n",
+		"wrong_type":  "def detect(event):\n    return 42\n",
+		"bad_score":   "def detect(event):\n    return {\"signal\": \"x\", \"score\": \"high\"}\n",
+		"mutates_api": "seen = []\ndef detect(event):\n    seen.append(event.trade_id)\n",
+	} {
+		detector, err := LoadStarlarkDetector(writeScript(t, dir, name+".star", src))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		_, err = detector.Detect(domain.PolymarketEvent{TradeID: "trade-1"})
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("%s: err = %v, want an error naming the detector", name, err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package sheets
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeCredentials writes a service account key file whose token URI is tokenURI
+func writeCredentials(t *testing.T, key *rsa.PrivateKey, tokenURI string) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "xtools@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    tokenURI,
+	})
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// redirect sends every request to the test server, keeping its path and query
+type redirect struct{ target *url.URL }
+
+func (r redirect) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = r.target.Scheme, r.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClientRejectsInvalidCredentials(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"not json":    "{",
+		"no key":      `{"client_email": "a@b.c"}`,
+		"invalid key": `{"client_email": "a@b.c", "private_key": "not a pem block"}`,
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(name, " ", "_")+".json")
+		os.WriteFile(path, []byte(content), 0600)
+		if _, err := NewClient(path); err == nil {
+			t.Errorf("%s: NewClient succeeded, want an error", name)
+		}
+	}
+	if _, err := NewClient(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("missing file: NewClient succeeded, want an error")
+	}
+}
+
+func TestAppendRowsAuthenticatesAsServiceAccount(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tokenRequests int
+	var appended []string
+	reject := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			tokenRequests++
+			r.ParseForm()
+			if err := verifyJWT(&key.PublicKey, r.PostForm.Get("assertion")); err != nil {
+				t.Errorf("assertion: %v", err)
+			}
+			io.WriteString(w, `{"access_token": "token-1", "expires_in": 3600}`)
+			return
+		}
+		if reject {
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, "token revoked")
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			t.Errorf("Authorization = %q, want the exchanged token", r.Header.Get("Authorization"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		appended = append(appended, r.URL.EscapedPath()+"?"+r.URL.RawQuery+" "+string(body))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(writeCredentials(t, key, ts.URL+"/token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, _ := url.Parse(ts.URL)
+	client.httpClient.Transport = redirect{target}
+
+	for i := 0; i < 2; i++ {
+		if err := client.AppendRows(context.Background(), "sheet-id", "Bob's trades", [][]any{{"0xabc", 1500}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("exchanged %d tokens, want the first one cached", tokenRequests)
+	}
+	want := `/v4/spreadsheets/sheet-id/values/%27Bob%27%27s%20trades%27%21A1:append?valueInputOption=USER_ENTERED&insertDataOption=INSERT_ROWS {"values":[["0xabc",1500]]}`
+	if len(appended) != 2 || appended[0] != want {
+		t.Errorf("appended %v, want %s", appended, want)
+	}
+
+	// A rejected token is exchanged again on the next append
+	reject = true
+	if err := client.AppendRows(context.Background(), "sheet-id", "Trades", [][]any{{"x"}}); err == nil || !strings.Contains(err.Error(), "token revoked") {
+		t.Errorf("AppendRows() = %v, want the sheets error", err)
+	}
+	reject = false
+	if err := client.AppendRows(context.Background(), "sheet-id", "Trades", [][]any{{"x"}}); err != nil || tokenRequests != 2 {
+		t.Errorf("AppendRows() = %v after %d token requests, want a fresh token", err, tokenRequests)
+	}
+}
+
+// verifyJWT checks an RS256 assertion's signature and the claims the token exchange needs
+func verifyJWT(key *rsa.PublicKey, assertion string) error {
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("%d parts, want 3", len(parts))
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return err
+	}
+	data, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims struct {
+		Iss   string `json:"iss"`
+		Scope string `json:"scope"`
+		Exp   int64  `json:"exp"`
+		Iat   int64  `json:"iat"`
+	}
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return err
+	}
+	if claims.Iss != "xtools@project.iam.gserviceaccount.com" || claims.Scope != sheetsScope || claims.Exp-claims.Iat != 3600 {
+		return fmt.Errorf("unexpected claims %s", data)
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+const (
+	// Encrypted file layout: magic, salt, nonce prefix, then length-prefixed AES-GCM chunks
+	encryptedMagic     = "XTDBENC1"
+	encryptedSaltSize  = 16
+	encryptedChunkSize = 1 << 20
+	kdfIterations      = 600000
+
+	// Suffixes of the files kept next to the database
+	encryptedSuffix = ".enc"
+	unsealedSuffix  = ".unsealed" // Marks a plaintext copy decrypted by this app
+)
+
+// EncryptedDatabase keeps a SQLite database encrypted at rest. The database is
+// decrypted when the app starts and encrypted again on shutdown, so the plaintext
+// file only exists while the app runs (or after a crash, until the next shutdown).
+type EncryptedDatabase struct {
+	path string
+	key  string
+}
+
+// NewEncryptedDatabase creates an at-rest encryption wrapper for the database at path
+func NewEncryptedDatabase(path, key string) *EncryptedDatabase {
+	return &EncryptedDatabase{path: path, key: key}
+}
+
+// EncryptedPath returns where the encrypted database is stored
+func (d *EncryptedDatabase) EncryptedPath() string {
+	return d.path + encryptedSuffix
+}
+
+// Unseal decrypts the database before it is opened. A plaintext database left by a
+// crash is kept; one created without the key is moved aside so it can't overwrite
+// the encrypted data on the next shutdown.
+func (d *EncryptedDatabase) Unseal() error {
+	if _, err := os.Stat(d.EncryptedPath()); errors.Is(err, os.ErrNotExist) {
+		// First run with a key: the plaintext database is encrypted on shutdown
+		return os.WriteFile(d.path+unsealedSuffix, nil, 0600)
+	}
+
+	if _, err := os.Stat(d.path); err == nil {
+		if _, err := os.Stat(d.path + unsealedSuffix); err == nil {
+			log.Printf("[Storage] Using plaintext database left by an unclean shutdown")
+			return nil
+		}
+		aside := fmt.Sprintf("%s.unencrypted-%s", d.path, time.Now().Format("20060102-150405"))
+		if err := os.Rename(d.path, aside); err != nil {
+			return fmt.Errorf("failed to move unencrypted database aside: %w", err)
+		}
+		log.Printf("[Storage] Moved database created without the key to %s", aside)
+	}
+	removeSidecars(d.path)
+
+	if err := decryptFile(d.EncryptedPath(), d.path, d.key); err != nil {
+		os.Remove(d.path)
+		return err
+	}
+	return os.WriteFile(d.path+unsealedSuffix, nil, 0600)
+}
+
+// Seal checkpoints the WAL, encrypts the database and removes the plaintext files.
+// Every connection to the database should be closed first.
+func (d *EncryptedDatabase) Seal() error {
+	if _, err := os.Stat(d.path + unsealedSuffix); err != nil {
+		return fmt.Errorf("database was not unsealed by this app, refusing to overwrite %s", d.EncryptedPath())
+	}
+
+	db, err := sql.Open("sqlite3", d.path+"?_journal_mode=WAL")
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	_, err = db.Exec("PRAGMA wal_checkpoint(TRUNCATE)")
+	db.Close()
+	if err != nil {
+		return fmt.Errorf("failed to checkpoint WAL: %w", err)
+	}
+
+	tmp := d.EncryptedPath() + ".tmp"
+	if err := encryptFile(d.path, tmp, d.key); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, d.EncryptedPath()); err != nil {
+		return fmt.Errorf("failed to replace encrypted database: %w", err)
+	}
+
+	removeSidecars(d.path)
+	if err := os.Remove(d.path); err != nil {
+		return fmt.Errorf("failed to remove plaintext database: %w", err)
+	}
+	return os.Remove(d.path + unsealedSuffix)
+}
+
+// SealBackup encrypts a database copy kept next to the database, such as a damaged one
+// moved aside by RecoverDatabase, so no plaintext is left behind. The main file and its
+// WAL are encrypted with the database key to files ending in ".enc".
+func (d *EncryptedDatabase) SealBackup(path string) error {
+	for _, file := range []string{path, path + "-wal"} {
+		if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := encryptFile(file, file+encryptedSuffix, d.key); err != nil {
+			os.Remove(file + encryptedSuffix)
+			return err
+		}
+		if err := os.Remove(file); err != nil {
+			return fmt.Errorf("failed to remove plaintext backup: %w", err)
+		}
+	}
+	os.Remove(path + "-shm")
+	return nil
+}
+
+// OpenBackup decrypts a file sealed by SealBackup to dst, leaving the sealed file in place
+func (d *EncryptedDatabase) OpenBackup(path, dst string) error {
+	if err := decryptFile(path, dst, d.key); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	return nil
+}
+
+// removeSidecars deletes SQLite's WAL and shared memory files
+func removeSidecars(path string) {
+	os.Remove(path + "-wal")
+	os.Remove(path + "-shm")
+}
+
+// encryptFile writes src encrypted with a key derived from the passphrase to dst
+func encryptFile(src, dst, passphrase string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create encrypted database: %w", err)
+	}
+	defer out.Close()
+
+	header := make([]byte, len(encryptedMagic)+encryptedSaltSize+4)
+	copy(header, encryptedMagic)
+	if _, err := rand.Read(header[len(encryptedMagic):]); err != nil {
+		return err
+	}
+	salt := header[len(encryptedMagic) : len(encryptedMagic)+encryptedSaltSize]
+	prefix := header[len(encryptedMagic)+encryptedSaltSize:]
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	if _, err := out.Write(header); err != nil {
+		return err
+	}
+
+	buf := make([]byte, encryptedChunkSize)
+	next := make([]byte, encryptedChunkSize)
+	n, err := io.ReadFull(in, buf)
+	for counter := uint64(0); ; counter++ {
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return fmt.Errorf("failed to read database: %w", err)
+		}
+		// Read ahead to know whether this is the last chunk
+		m, nextErr := 0, io.EOF
+		if err == nil {
+			m, nextErr = io.ReadFull(in, next)
+		}
+		last := m == 0 && (nextErr == io.EOF || nextErr == io.ErrUnexpectedEOF)
+
+		sealed := aead.Seal(nil, chunkNonce(prefix, counter), buf[:n], chunkAD(last))
+		var size [4]byte
+		binary.BigEndian.PutUint32(size[:], uint32(len(sealed)))
+		if _, err := out.Write(size[:]); err != nil {
+			return err
+		}
+		if _, err := out.Write(sealed); err != nil {
+			return err
+		}
+		if last {
+			return out.Sync()
+		}
+		buf, next = next, buf
+		n, err = m, nextErr
+	}
+}
+
+// decryptFile restores an encrypted database from src to dst
+func decryptFile(src, dst, passphrase string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open encrypted database: %w", err)
+	}
+	defer in.Close()
+
+	header := make([]byte, len(encryptedMagic)+encryptedSaltSize+4)
+	if _, err := io.ReadFull(in, header); err != nil || string(header[:len(encryptedMagic)]) != encryptedMagic {
+		return fmt.Errorf("%s is not an encrypted database", src)
+	}
+	salt := header[len(encryptedMagic) : len(encryptedMagic)+encryptedSaltSize]
+	prefix := header[len(encryptedMagic)+encryptedSaltSize:]
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create database: %w", err)
+	}
+	defer out.Close()
+
+	for counter := uint64(0); ; counter++ {
+		var size [4]byte
+		if _, err := io.ReadFull(in, size[:]); err != nil {
+			return fmt.Errorf("encrypted database is truncated")
+		}
+		sealed := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(in, sealed); err != nil {
+			return fmt.Errorf("encrypted database is truncated")
+		}
+
+		// A chunk only opens with the right "last" flag, which detects truncation
+		last := true
+		plain, err := aead.Open(nil, chunkNonce(prefix, counter), sealed, chunkAD(last))
+		if err != nil {
+			last = false
+			if plain, err = aead.Open(nil, chunkNonce(prefix, counter), sealed, chunkAD(last)); err != nil {
+				return fmt.Errorf("failed to decrypt database: wrong key or corrupted file")
+			}
+		}
+		if _, err := out.Write(plain); err != nil {
+			return err
+		}
+		if last {
+			return out.Sync()
+		}
+	}
+}
+
+// newAEAD derives an AES-256-GCM cipher from the passphrase
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, kdfIterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce combines the file's random prefix with the chunk counter
+func chunkNonce(prefix []byte, counter uint64) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint64(nonce[4:], counter)
+	return nonce
+}
+
+// chunkAD authenticates whether a chunk is the last one
+func chunkAD(last bool) []byte {
+	if last {
+		return []byte{1}
+	}
+	return []byte{0}
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	// databaseKeyEnv names the environment variable holding the database passphrase
+	databaseKeyEnv = "XTOOLS_DB_KEY"
+
+	// databaseKeyFileEnv names the environment variable pointing at a file holding
+	// the passphrase, e.g. a mounted secret
+	databaseKeyFileEnv = "XTOOLS_DB_KEY_FILE"
+
+	// Keychain entry holding the database passphrase
+	keychainService = "XTools"
+	keychainAccount = "database"
+)
+
+// Database key sources
+const (
+	DatabaseKeySourceEnv      = "env"
+	DatabaseKeySourceFile     = "file"
+	DatabaseKeySourceKeychain = "keychain"
+)
+
+// LoadDatabaseKey returns the database passphrase and where it came from: the
+// XTOOLS_DB_KEY environment variable, else the file named by XTOOLS_DB_KEY_FILE, else
+// the OS keychain (macOS Keychain or the Linux Secret Service, service "XTools",
+// account "database"). An empty key means the database is not encrypted.
+func LoadDatabaseKey() (key, source string) {
+	if key := os.Getenv(databaseKeyEnv); key != "" {
+		return key, DatabaseKeySourceEnv
+	}
+	if path := os.Getenv(databaseKeyFileEnv); path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			if key := strings.TrimRight(string(data), "\r\n"); key != "" {
+				return key, DatabaseKeySourceFile
+			}
+		}
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keychainService, "-a", keychainAccount, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", keychainService, "account", keychainAccount)
+	default:
+		return "", ""
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", ""
+	}
+	if key := strings.TrimRight(string(out), "\r\n"); key != "" {
+		return key, DatabaseKeySourceKeychain
+	}
+	return "", ""
+}
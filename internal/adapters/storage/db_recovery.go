@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// recoveryMarkerSuffix marks a database to rebuild on the next start, e.g. after
+	// a failed integrity check while the app was running
+	recoveryMarkerSuffix = ".needs-recovery"
+
+	// openMarkerSuffix marks a database as open by a store; left behind by a crash or
+	// power loss, it makes the next RecoverDatabase run quick_check
+	openMarkerSuffix = ".open"
+
+	// salvageBatchSize is how many rows are read per query while salvaging a table
+	salvageBatchSize = 500
+
+	// maxSalvageSkip bounds how far past an unreadable row a salvage scan jumps
+	maxSalvageSkip = int64(1) << 40
+)
+
+// MarkForRecovery makes the next RecoverDatabase rebuild the database at path
+func MarkForRecovery(path, reason string) error {
+	return os.WriteFile(path+recoveryMarkerSuffix, []byte(reason), 0600)
+}
+
+// RecoverDatabase checks the database at path before any connection opens it. When it
+// was marked for recovery, or was left open by an unclean shutdown and then is not a
+// database or fails quick_check, the damaged file is moved aside and every readable row
+// is copied into a fresh database at path, so the app can start on what survived.
+// Returns nil when the database is healthy or doesn't exist yet. Other errors, e.g. the
+// database being locked by another process, are returned and the file is left alone.
+func RecoverDatabase(path string) (*domain.DatabaseRecovery, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		os.Remove(path + recoveryMarkerSuffix)
+		os.Remove(path + openMarkerSuffix)
+		return nil, nil
+	}
+
+	reason := ""
+	if marker, err := os.ReadFile(path + recoveryMarkerSuffix); err == nil {
+		reason = "marked for recovery: " + strings.TrimSpace(string(marker))
+	} else if _, err := os.Stat(path + openMarkerSuffix); err == nil {
+		// quick_check reads the whole file, so only a database that was not closed
+		// cleanly is checked
+		problem, err := quickCheck(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check database: %w", err)
+		}
+		reason = problem
+	}
+	if reason == "" {
+		return nil, nil
+	}
+	log.Printf("[Storage] Database is damaged (%s), rebuilding", reason)
+
+	backup := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+	if err := os.Rename(path, backup); err != nil {
+		return nil, fmt.Errorf("failed to move damaged database aside: %w", err)
+	}
+	// The WAL holds committed pages not yet in the main file; keep it with the backup
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Rename(path+suffix, backup+suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("[Storage] Failed to move %s aside: %v", suffix, err)
+		}
+	}
+	os.Remove(path + recoveryMarkerSuffix)
+
+	recovery := &domain.DatabaseRecovery{Reason: reason, BackupPath: backup, RecoveredAt: time.Now()}
+	tables, err := salvage(backup, path)
+	recovery.Tables = tables
+	for _, t := range tables {
+		recovery.RowsRecovered += t.Rows
+	}
+	if err != nil {
+		recovery.Error = err.Error()
+	}
+	log.Printf("[Storage] Rebuilt database: %d rows from %d tables recovered, damaged copy kept at %s",
+		recovery.RowsRecovered, len(tables), backup)
+	return recovery, nil
+}
+
+// quickCheck returns why the database is damaged, or "" if it passes quick_check.
+// Errors that don't mean damage, e.g. SQLITE_BUSY, are returned instead.
+func quickCheck(path string) (string, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRow("PRAGMA quick_check(1)").Scan(&result); err != nil {
+		if isCorrupt(err) {
+			return "unreadable: " + err.Error(), nil
+		}
+		return "", err
+	}
+	if result != "ok" {
+		return "integrity check failed: " + result, nil
+	}
+	return "", nil
+}
+
+// isCorrupt reports whether an SQLite error means the file is damaged or not a database
+func isCorrupt(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrCorrupt || sqliteErr.Code == sqlite3.ErrNotADB)
+}
+
+// markOpen records that a store has the database at path open until markClosed
+func markOpen(path string) {
+	if err := os.WriteFile(path+openMarkerSuffix, nil, 0600); err != nil {
+		log.Printf("[Storage] Failed to mark database as open: %v", err)
+	}
+}
+
+// markClosed records that the database at path was closed cleanly
+func markClosed(path string) {
+	os.Remove(path + openMarkerSuffix)
+}
+
+// salvage recreates the schema of src in a fresh database at dst and copies every
+// readable row. Indexes are rebuilt after the data.
+func salvage(src, dst string) ([]domain.TableRecovery, error) {
+	from, err := sql.Open("sqlite3", "file:"+src+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer from.Close()
+	to, err := sql.Open("sqlite3", dst+"?_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
+	defer to.Close()
+
+	rows, err := from.Query(`SELECT type, name, sql FROM sqlite_master
+		WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%'
+		ORDER BY CASE type WHEN 'table' THEN 0 ELSE 1 END`)
+	if err != nil {
+		return nil, fmt.Errorf("schema is unreadable, starting with an empty database: %w", err)
+	}
+	type object struct{ kind, name, sql string }
+	var objects []object
+	for rows.Next() {
+		var o object
+		if err := rows.Scan(&o.kind, &o.name, &o.sql); err == nil {
+			objects = append(objects, o)
+		}
+	}
+	schemaErr := rows.Err()
+	rows.Close()
+
+	var tables []domain.TableRecovery
+	for _, o := range objects {
+		if strings.HasPrefix(o.name, eventSearchTable) {
+			continue // Full-text index and its shadow tables; rebuilt when the store opens
+		}
+		if o.kind != "table" {
+			if _, err := to.Exec(o.sql); err != nil {
+				log.Printf("[Storage] Failed to recreate %s %s: %v", o.kind, o.name, err)
+			}
+			continue
+		}
+		t := domain.TableRecovery{Name: o.name}
+		if _, err := to.Exec(o.sql); err != nil {
+			t.Error = err.Error()
+		} else if err := copyTable(from, to, &t); err != nil {
+			t.Error = err.Error()
+		}
+		tables = append(tables, t)
+	}
+	if schemaErr != nil {
+		return tables, fmt.Errorf("schema was partly unreadable: %w", schemaErr)
+	}
+	return tables, nil
+}
+
+// copyTable copies the readable rows of a table in rowid order. A batch that hits a
+// damaged page is retried further along, jumping farther each time until rows read again.
+func copyTable(from, to *sql.DB, t *domain.TableRecovery) error {
+	name := `"` + strings.ReplaceAll(t.Name, `"`, `""`) + `"`
+	tx, err := to.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	last, skip := int64(math.MinInt64), int64(1)
+	for {
+		rows, err := from.Query(fmt.Sprintf("SELECT rowid, * FROM %s WHERE rowid > ? ORDER BY rowid LIMIT %d", name, salvageBatchSize), last)
+		if err != nil && t.Rows == 0 && t.SkippedRanges == 0 && strings.Contains(err.Error(), "no such column") {
+			// WITHOUT ROWID table: copy what a plain scan returns
+			return copyRows(from, tx, name, t)
+		}
+		read := 0
+		if err == nil {
+			read, last, err = insertRows(rows, tx, name, last, t)
+		}
+		if err != nil {
+			if last > math.MaxInt64-skip || skip > maxSalvageSkip {
+				break
+			}
+			t.SkippedRanges++
+			last += skip
+			skip *= 16
+			continue
+		}
+		if read == 0 {
+			break
+		}
+		skip = 1
+	}
+	return tx.Commit()
+}
+
+// copyRows copies a table with a single scan, keeping the rows read before any error
+func copyRows(from *sql.DB, tx *sql.Tx, name string, t *domain.TableRecovery) error {
+	rows, err := from.Query("SELECT NULL, * FROM " + name)
+	if err != nil {
+		return err
+	}
+	_, _, err = insertRows(rows, tx, name, 0, t)
+	if err != nil {
+		t.SkippedRanges++
+	}
+	return tx.Commit()
+}
+
+// insertRows copies rows whose first column is the rowid, returning how many were
+// read and the last rowid seen. Rows keep their rowid unless it is NULL.
+func insertRows(rows *sql.Rows, tx *sql.Tx, name string, last int64, t *domain.TableRecovery) (int, int64, error) {
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, last, err
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)-1), ", ")
+	insert, err := tx.Prepare(fmt.Sprintf("INSERT INTO %s VALUES (%s)", name, placeholders))
+	if err != nil {
+		return 0, last, err
+	}
+	defer insert.Close()
+
+	values := make([]any, len(columns))
+	ptrs := make([]any, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	read := 0
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return read, last, err
+		}
+		read++
+		if rowid, ok := values[0].(int64); ok {
+			last = rowid
+		}
+		if _, err := insert.Exec(values[1:]...); err == nil {
+			t.Rows++
+		}
+	}
+	return read, last, rows.Err()
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newDatabase creates a small database at path and closes it
+func newDatabase(t *testing.T, path string) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT); INSERT INTO items (name) VALUES ('a'), ('b')`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecoverDatabaseChecksOnlyAfterUncleanShutdown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xtools.db")
+	if err := os.WriteFile(path, []byte("this is not an sqlite database, just some text"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closed cleanly: the file is not read at startup
+	if recovery, err := RecoverDatabase(path); recovery != nil || err != nil {
+		t.Fatalf("RecoverDatabase after a clean shutdown = %+v, %v, want no check", recovery, err)
+	}
+
+	markOpen(path)
+	recovery, err := RecoverDatabase(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovery == nil {
+		t.Fatal("a damaged database left open was not recovered")
+	}
+	if _, err := os.Stat(recovery.BackupPath); err != nil {
+		t.Errorf("damaged copy not kept: %v", err)
+	}
+}
+
+func TestRecoverDatabaseKeepsHealthyDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xtools.db")
+	newDatabase(t, path)
+	markOpen(path)
+
+	if recovery, err := RecoverDatabase(path); recovery != nil || err != nil {
+		t.Fatalf("RecoverDatabase on a healthy database = %+v, %v", recovery, err)
+	}
+}
+
+func TestRecoverDatabaseLeavesLockedDatabaseAlone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xtools.db")
+	newDatabase(t, path)
+	markOpen(path)
+
+	// Another process writing holds an exclusive lock
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	conn, err := db.Conn(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(t.Context(), "BEGIN EXCLUSIVE"); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.ExecContext(t.Context(), "ROLLBACK")
+
+	recovery, err := RecoverDatabase(path)
+	if err == nil || recovery != nil {
+		t.Fatalf("RecoverDatabase on a locked database = %+v, %v, want an error", recovery, err)
+	}
+	if _, statErr := os.Stat(path); statErr != nil {
+		t.Errorf("locked database was moved aside: %v", statErr)
+	}
+}
+
+func TestStoreMarksDatabaseOpenUntilClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xtools.db")
+	store, err := NewPolymarketStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + openMarkerSuffix); err != nil {
+		t.Errorf("open store left no marker: %v", err)
+	}
+	store.Close()
+	if _, err := os.Stat(path + openMarkerSuffix); !os.IsNotExist(err) {
+		t.Errorf("marker still there after Close: %v", err)
+	}
+}
@@ -9,8 +9,8 @@ import (
 
 	"github.com/xuri/excelize/v2"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // ExcelExporter implements ExcelExporter interface
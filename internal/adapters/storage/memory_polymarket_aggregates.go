@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetMarketAggregates totals the trades matching the filter per market and time
+// bucket, oldest bucket first and the biggest markets first within a bucket
+func (s *MemoryPolymarketStore) GetMarketAggregates(filter domain.PolymarketEventFilter, bucket domain.AggregateBucket) ([]domain.MarketAggregate, error) {
+	if !bucket.Valid() {
+		return nil, fmt.Errorf("invalid aggregate bucket: %q", bucket)
+	}
+	filter.EventTypes = []domain.PolymarketEventType{domain.PolymarketEventTrade}
+	type key struct {
+		market string
+		start  time.Time
+	}
+	type totals struct {
+		aggregate domain.MarketAggregate
+		wallets   map[string]bool
+		fresh     map[string]bool
+	}
+
+	s.mu.RLock()
+	groups := make(map[key]*totals)
+	for _, e := range s.events {
+		if !s.eventMatches(e, filter) {
+			continue
+		}
+		market := e.MarketSlug
+		if market == "" {
+			market = e.AssetID
+		}
+		k := key{market, bucket.Truncate(e.Timestamp)}
+		t, ok := groups[k]
+		if !ok {
+			t = &totals{
+				aggregate: domain.MarketAggregate{MarketSlug: market, BucketStart: k.start},
+				wallets:   make(map[string]bool),
+				fresh:     make(map[string]bool),
+			}
+			groups[k] = t
+		}
+		price, _ := strconv.ParseFloat(e.Price, 64)
+		size, _ := strconv.ParseFloat(e.Size, 64)
+		t.aggregate.Volume += price * size
+		t.aggregate.Trades++
+		if e.MarketName > t.aggregate.MarketName {
+			t.aggregate.MarketName = e.MarketName // MAX(market_name), like the SQL store
+		}
+		if e.WalletAddress != "" {
+			t.wallets[e.WalletAddress] = true
+			if e.IsFreshWallet {
+				t.fresh[e.WalletAddress] = true
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	aggregates := make([]domain.MarketAggregate, 0, len(groups))
+	for _, t := range groups {
+		t.aggregate.Wallets = len(t.wallets)
+		t.aggregate.FreshWallets = len(t.fresh)
+		aggregates = append(aggregates, t.aggregate)
+	}
+	sort.Slice(aggregates, func(i, j int) bool {
+		if !aggregates[i].BucketStart.Equal(aggregates[j].BucketStart) {
+			return aggregates[i].BucketStart.Before(aggregates[j].BucketStart)
+		}
+		return aggregates[i].Volume > aggregates[j].Volume
+	})
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+	if len(aggregates) > limit {
+		aggregates = aggregates[:limit]
+	}
+	return aggregates, nil
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveAlertOutcome starts tracking an alerted trade and returns its ID
+func (s *MemoryPolymarketStore) SaveAlertOutcome(outcome domain.AlertOutcome) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	outcome.ID = int64(len(s.alertOutcomes) + 1)
+	outcome.RiskSignals = append([]string{}, outcome.RiskSignals...)
+	outcome.Prices = make(map[domain.AlertHorizon]float64)
+	s.alertOutcomes = append(s.alertOutcomes, outcome)
+	return outcome.ID, nil
+}
+
+// RecordAlertPrice stores the follow-up price of an alert at a horizon
+func (s *MemoryPolymarketStore) RecordAlertPrice(id int64, horizon domain.AlertHorizon, price float64) error {
+	if horizon.Duration() == 0 {
+		return fmt.Errorf("invalid alert horizon: %q", horizon)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 1 || id > int64(len(s.alertOutcomes)) {
+		return nil
+	}
+	s.alertOutcomes[id-1].Prices[horizon] = price
+	return nil
+}
+
+// GetPendingAlertOutcomes returns alerts since the given time that are still missing
+// a follow-up price, oldest first
+func (s *MemoryPolymarketStore) GetPendingAlertOutcomes(since time.Time, limit int) ([]domain.AlertOutcome, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	outcomes := []domain.AlertOutcome{}
+	for _, o := range s.alertOutcomes {
+		if len(outcomes) >= limit {
+			break
+		}
+		if o.AlertedAt.Before(since) || len(o.Prices) == len(domain.AlertHorizons) {
+			continue
+		}
+		outcomes = append(outcomes, copyAlertOutcome(o))
+	}
+	return outcomes, nil
+}
+
+// GetAlertOutcomes returns alerts since the given time, newest first
+func (s *MemoryPolymarketStore) GetAlertOutcomes(since time.Time, limit int) ([]domain.AlertOutcome, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	outcomes := []domain.AlertOutcome{}
+	for i := len(s.alertOutcomes) - 1; i >= 0 && len(outcomes) < limit; i-- {
+		if !s.alertOutcomes[i].AlertedAt.Before(since) {
+			outcomes = append(outcomes, copyAlertOutcome(s.alertOutcomes[i]))
+		}
+	}
+	return outcomes, nil
+}
+
+// GetLastWalletAlert returns the wallet's most recent alert, or nil if it was never alerted
+func (s *MemoryPolymarketStore) GetLastWalletAlert(wallet string) (*domain.AlertOutcome, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var last *domain.AlertOutcome
+	for i := range s.alertOutcomes {
+		o := &s.alertOutcomes[i]
+		if o.WalletAddress == wallet && (last == nil || !o.AlertedAt.Before(last.AlertedAt)) {
+			last = o
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	outcome := copyAlertOutcome(*last)
+	return &outcome, nil
+}
+
+// copyAlertOutcome returns a copy that callers can modify without touching the store
+func copyAlertOutcome(o domain.AlertOutcome) domain.AlertOutcome {
+	prices := make(map[domain.AlertHorizon]float64, len(o.Prices))
+	for h, p := range o.Prices {
+		prices[h] = p
+	}
+	o.Prices = prices
+	o.RiskSignals = append([]string{}, o.RiskSignals...)
+	return o
+}
@@ -0,0 +1,42 @@
+package storage
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// SaveConfigSnapshot stores a config version unless one with its hash exists
+func (s *MemoryPolymarketStore) SaveConfigSnapshot(snapshot domain.ConfigSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.snapshots {
+		if existing.Hash == snapshot.Hash {
+			return nil
+		}
+	}
+	s.snapshots = append(s.snapshots, snapshot)
+	return nil
+}
+
+// GetConfigSnapshot returns the config version with the hash, or nil if none is stored
+func (s *MemoryPolymarketStore) GetConfigSnapshot(hash string) (*domain.ConfigSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, snapshot := range s.snapshots {
+		if snapshot.Hash == hash {
+			return &snapshot, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetConfigSnapshots returns the stored config versions, newest first
+func (s *MemoryPolymarketStore) GetConfigSnapshots(limit int) ([]domain.ConfigSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := []domain.ConfigSnapshot{}
+	for i := len(s.snapshots) - 1; i >= 0 && len(snapshots) < limit; i-- {
+		snapshots = append(snapshots, s.snapshots[i])
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// memoryEventMatches mirrors the SQL conditions of PolymarketStore.GetEvents
+func memoryEventMatches(e domain.PolymarketEvent, filter domain.PolymarketEventFilter) bool {
+	if len(filter.EventTypes) > 0 {
+		found := false
+		for _, et := range filter.EventTypes {
+			if et == e.EventType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if filter.MarketName != "" {
+		name := strings.ToLower(filter.MarketName)
+		if !strings.Contains(strings.ToLower(e.MarketName), name) && !strings.Contains(strings.ToLower(e.EventTitle), name) {
+			return false
+		}
+	}
+
+	price, _ := strconv.ParseFloat(e.Price, 64)
+	size, _ := strconv.ParseFloat(e.Size, 64)
+	if filter.MinPrice > 0 && price < filter.MinPrice {
+		return false
+	}
+	if filter.MaxPrice > 0 && price > filter.MaxPrice {
+		return false
+	}
+	if filter.Side != "" && e.Side != filter.Side {
+		return false
+	}
+	if !filter.MatchesOutcome(e) {
+		return false
+	}
+	if filter.MinSize > 0 && price*size < filter.MinSize {
+		return false
+	}
+	if filter.FreshWalletsOnly && !e.IsFreshWallet {
+		return false
+	}
+	if filter.MinRiskScore > 0 && e.RiskScore < filter.MinRiskScore {
+		return false
+	}
+	if filter.MaxWalletNonce > 0 && (e.WalletProfile == nil || e.WalletProfile.BetCount > filter.MaxWalletNonce) {
+		return false
+	}
+	if filter.Tag != "" && !containsTag(e.Tags, domain.NormalizeTag(filter.Tag)) {
+		return false
+	}
+	if wallets := filter.Wallets(); len(wallets) > 0 && !slices.Contains(wallets, e.WalletAddress) {
+		return false
+	}
+	if len(filter.MarketSlugs) > 0 || len(filter.ConditionIDs) > 0 {
+		if !slices.Contains(filter.MarketSlugs, e.MarketSlug) && !slices.Contains(filter.MarketSlugs, e.EventSlug) &&
+			!slices.Contains(filter.ConditionIDs, e.ConditionID) {
+			return false
+		}
+	}
+	if filter.Excludes(e) {
+		return false
+	}
+	if !filter.Since.IsZero() && e.Timestamp.Before(filter.Since) {
+		return false
+	}
+	if !filter.Until.IsZero() && !e.Timestamp.Before(filter.Until) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"strconv"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetEventPage returns a page of events with the number matching the filter, their
+// total notional and how many are by fresh wallets, under one lock
+func (s *MemoryPolymarketStore) GetEventPage(filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error) {
+	var page domain.PolymarketEventPage
+	var events []domain.PolymarketEvent
+
+	s.mu.RLock()
+	for _, e := range s.events {
+		if !s.eventMatches(e, filter) {
+			continue
+		}
+		events = append(events, e)
+		price, _ := strconv.ParseFloat(e.Price, 64)
+		size, _ := strconv.ParseFloat(e.Size, 64)
+		page.TotalNotional += price * size
+		if e.IsFreshWallet {
+			page.FreshWalletCount++
+		}
+	}
+	s.mu.RUnlock()
+
+	page.Total = int64(len(events))
+	page.Events = pageMemoryEvents(events, filter)
+	return &page, nil
+}
@@ -0,0 +1,24 @@
+package storage
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// SearchEvents finds events whose market name or event title contains every word of
+// the query, in any order, as a word or word prefix. Newest first. Unlike the SQLite
+// index, diacritics are not folded.
+func (s *MemoryPolymarketStore) SearchEvents(query string, limit int) ([]domain.PolymarketEvent, error) {
+	terms := searchTerms(query)
+	if limit <= 0 {
+		limit = 50
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := []domain.PolymarketEvent{}
+	for i := len(s.events) - 1; i >= 0 && len(terms) > 0 && len(events) < limit; i-- {
+		if e := s.events[i]; matchesSearchTerms(terms, e.MarketName, e.EventTitle) {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
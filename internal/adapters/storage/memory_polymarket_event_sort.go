@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// sortMemoryEvents orders events like eventOrderBy
+func sortMemoryEvents(events []domain.PolymarketEvent, filter domain.PolymarketEventFilter) {
+	desc := filter.SortDir != domain.SortAsc
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		keyA, okA := memoryEventSortKey(a, filter.SortBy)
+		keyB, okB := memoryEventSortKey(b, filter.SortBy)
+		switch {
+		case okA != okB:
+			return okA
+		case keyA != keyB:
+			return (keyA < keyB) != desc
+		case !a.Timestamp.Equal(b.Timestamp):
+			return a.Timestamp.After(b.Timestamp)
+		}
+		return a.ID > b.ID
+	})
+}
+
+// memoryEventSortKey returns the value an event is sorted by, and false when it has
+// none (a bet count that was never analyzed)
+func memoryEventSortKey(e domain.PolymarketEvent, sortBy domain.EventSortField) (float64, bool) {
+	switch sortBy {
+	case domain.EventSortNotional:
+		price, _ := strconv.ParseFloat(e.Price, 64)
+		size, _ := strconv.ParseFloat(e.Size, 64)
+		return price * size, true
+	case domain.EventSortRiskScore:
+		return e.RiskScore, true
+	case domain.EventSortBetCount:
+		if e.WalletProfile == nil {
+			return 0, false
+		}
+		if e.WalletProfile.BetCount > 0 {
+			return float64(e.WalletProfile.BetCount), true
+		}
+		return float64(e.WalletProfile.Nonce), true
+	}
+	return float64(e.Timestamp.UnixNano()), true
+}
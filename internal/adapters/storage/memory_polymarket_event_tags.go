@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// TagEvent attaches a label to a stored event; tagging twice is a no-op
+func (s *MemoryPolymarketStore) TagEvent(eventID int64, tag string) error {
+	tag = domain.NormalizeTag(tag)
+	if tag == "" {
+		return fmt.Errorf("tag is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.events {
+		if s.events[i].ID != eventID {
+			continue
+		}
+		if !containsTag(s.events[i].Tags, tag) {
+			tags := append([]string{}, s.events[i].Tags...)
+			s.events[i].Tags = append(tags, tag)
+			sort.Strings(s.events[i].Tags)
+		}
+		break
+	}
+	return nil
+}
+
+// UntagEvent removes a label from a stored event
+func (s *MemoryPolymarketStore) UntagEvent(eventID int64, tag string) error {
+	tag = domain.NormalizeTag(tag)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.events {
+		if s.events[i].ID != eventID {
+			continue
+		}
+		tags := make([]string, 0, len(s.events[i].Tags))
+		for _, t := range s.events[i].Tags {
+			if t != tag {
+				tags = append(tags, t)
+			}
+		}
+		s.events[i].Tags = tags
+		break
+	}
+	return nil
+}
+
+// GetEventTags returns every tag in use with its event count, most used first
+func (s *MemoryPolymarketStore) GetEventTags() ([]domain.EventTagCount, error) {
+	s.mu.RLock()
+	counts := make(map[string]int64)
+	for _, e := range s.events {
+		for _, tag := range e.Tags {
+			counts[tag]++
+		}
+	}
+	s.mu.RUnlock()
+
+	tags := make([]domain.EventTagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, domain.EventTagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	return tags, nil
+}
+
+// normalizeEventTags normalizes, dedupes and sorts tags as the SQL store returns them
+func normalizeEventTags(tags []string) []string {
+	var out []string
+	for _, tag := range tags {
+		if tag = domain.NormalizeTag(tag); tag != "" && !containsTag(out, tag) {
+			out = append(out, tag)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"sort"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveEvent stores a Polymarket event, skipping a trade already stored
+func (s *MemoryPolymarketStore) SaveEvent(event domain.PolymarketEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key := event.TradeKey(); key != "" {
+		if s.tradeKeys[key] {
+			return nil
+		}
+		s.tradeKeys[key] = true
+	}
+	s.nextID++
+	event.ID = s.nextID
+	event.Tags = normalizeEventTags(event.Tags)
+	s.events = append(s.events, event)
+	return nil
+}
+
+// SaveEvents stores events
+func (s *MemoryPolymarketStore) SaveEvents(events []domain.PolymarketEvent) error {
+	for _, event := range events {
+		if err := s.SaveEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetEvents retrieves events with optional filtering, newest first
+func (s *MemoryPolymarketStore) GetEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	s.mu.RLock()
+	var events []domain.PolymarketEvent
+	for _, e := range s.events {
+		if s.eventMatches(e, filter) {
+			events = append(events, e)
+		}
+	}
+	s.mu.RUnlock()
+	return pageMemoryEvents(events, filter), nil
+}
+
+// pageMemoryEvents sorts matching events and returns the filter's page of them
+func pageMemoryEvents(events []domain.PolymarketEvent, filter domain.PolymarketEventFilter) []domain.PolymarketEvent {
+	sortMemoryEvents(events, filter)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	if filter.Offset >= len(events) {
+		return nil
+	}
+	events = events[filter.Offset:]
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events
+}
+
+// GetEventCount returns how many events match a filter, ignoring its limit and offset
+func (s *MemoryPolymarketStore) GetEventCount(filter domain.PolymarketEventFilter) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var count int64
+	for _, e := range s.events {
+		if s.eventMatches(e, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GetEventsByIDs retrieves the given events, newest first; unknown IDs are skipped
+func (s *MemoryPolymarketStore) GetEventsByIDs(ids []int64) ([]domain.PolymarketEvent, error) {
+	wanted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	s.mu.RLock()
+	var events []domain.PolymarketEvent
+	for _, e := range s.events {
+		if wanted[e.ID] {
+			events = append(events, e)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.After(events[j].Timestamp)
+	})
+	return events, nil
+}
+
+// ClearEvents removes all events and wallets
+func (s *MemoryPolymarketStore) ClearEvents() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = nil
+	s.archive = nil
+	s.tradeKeys = make(map[string]bool)
+	s.wallets = make(map[string]*memoryWallet)
+	s.categories = make(map[string]map[string]*domain.WalletCategoryStats)
+	return nil
+}
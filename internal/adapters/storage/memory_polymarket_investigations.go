@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// CreateInvestigation stores a new investigation and returns it with its ID
+func (s *MemoryPolymarketStore) CreateInvestigation(inv domain.Investigation) (*domain.Investigation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.nextCaseID++
+	stored := &domain.Investigation{
+		ID:          s.nextCaseID,
+		Name:        inv.Name,
+		Description: inv.Description,
+		Status:      inv.Status,
+		Items:       []domain.InvestigationItem{},
+		Notes:       []domain.InvestigationNote{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.investigations[stored.ID] = stored
+	return copyInvestigation(stored), nil
+}
+
+// UpdateInvestigation updates the name, description and status of an investigation
+func (s *MemoryPolymarketStore) UpdateInvestigation(inv domain.Investigation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.investigations[inv.ID]
+	if !ok {
+		return domain.ErrInvestigationNotFound
+	}
+	stored.Name = inv.Name
+	stored.Description = inv.Description
+	stored.Status = inv.Status
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+// GetInvestigation retrieves an investigation with its items and notes
+func (s *MemoryPolymarketStore) GetInvestigation(id int64) (*domain.Investigation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.investigations[id]
+	if !ok {
+		return nil, domain.ErrInvestigationNotFound
+	}
+	return copyInvestigation(stored), nil
+}
+
+// ListInvestigations returns investigations, most recently updated first.
+// An empty status returns all of them.
+func (s *MemoryPolymarketStore) ListInvestigations(status domain.InvestigationStatus) ([]domain.Investigation, error) {
+	s.mu.RLock()
+	investigations := []domain.Investigation{}
+	for _, stored := range s.investigations {
+		if status == "" || stored.Status == status {
+			investigations = append(investigations, *copyInvestigation(stored))
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(investigations, func(i, j int) bool {
+		return investigations[i].UpdatedAt.After(investigations[j].UpdatedAt)
+	})
+	return investigations, nil
+}
+
+// DeleteInvestigation removes an investigation with its items and notes
+func (s *MemoryPolymarketStore) DeleteInvestigation(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.investigations[id]; !ok {
+		return domain.ErrInvestigationNotFound
+	}
+	delete(s.investigations, id)
+	return nil
+}
+
+// AddInvestigationItem attaches a wallet, market or event; adding it twice is a no-op
+func (s *MemoryPolymarketStore) AddInvestigationItem(id int64, item domain.InvestigationItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.investigations[id]
+	if !ok {
+		return domain.ErrInvestigationNotFound
+	}
+	stored.UpdatedAt = time.Now()
+	for _, existing := range stored.Items {
+		if existing.Type == item.Type && existing.Ref == item.Ref {
+			return nil
+		}
+	}
+	item.AddedAt = stored.UpdatedAt
+	stored.Items = append(stored.Items, item)
+	return nil
+}
+
+// RemoveInvestigationItem detaches a wallet, market or event
+func (s *MemoryPolymarketStore) RemoveInvestigationItem(id int64, itemType domain.InvestigationItemType, ref string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.investigations[id]
+	if !ok {
+		return domain.ErrInvestigationNotFound
+	}
+	stored.UpdatedAt = time.Now()
+	items := stored.Items[:0:0]
+	for _, existing := range stored.Items {
+		if existing.Type != itemType || existing.Ref != ref {
+			items = append(items, existing)
+		}
+	}
+	stored.Items = items
+	return nil
+}
+
+// AddInvestigationNote appends a note to an investigation
+func (s *MemoryPolymarketStore) AddInvestigationNote(id int64, body string) (*domain.InvestigationNote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.investigations[id]
+	if !ok {
+		return nil, domain.ErrInvestigationNotFound
+	}
+	s.nextCaseNoteID++
+	stored.UpdatedAt = time.Now()
+	note := domain.InvestigationNote{ID: s.nextCaseNoteID, Body: body, CreatedAt: stored.UpdatedAt}
+	stored.Notes = append(stored.Notes, note)
+	return &note, nil
+}
+
+// DeleteInvestigationNote removes a note from an investigation
+func (s *MemoryPolymarketStore) DeleteInvestigationNote(id, noteID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.investigations[id]
+	if !ok {
+		return domain.ErrInvestigationNotFound
+	}
+	stored.UpdatedAt = time.Now()
+	notes := stored.Notes[:0:0]
+	for _, note := range stored.Notes {
+		if note.ID != noteID {
+			notes = append(notes, note)
+		}
+	}
+	stored.Notes = notes
+	return nil
+}
+
+// copyInvestigation returns a copy that callers can modify without touching the store
+func copyInvestigation(inv *domain.Investigation) *domain.Investigation {
+	c := *inv
+	c.Items = append([]domain.InvestigationItem{}, inv.Items...)
+	c.Notes = append([]domain.InvestigationNote{}, inv.Notes...)
+	return &c
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// CheckpointWAL is a no-op for the in-memory store
+func (s *MemoryPolymarketStore) CheckpointWAL() error {
+	return nil
+}
+
+// WALSize is always 0 for the in-memory store
+func (s *MemoryPolymarketStore) WALSize() int64 {
+	return 0
+}
+
+// SetPragmas is a no-op for the in-memory store
+func (s *MemoryPolymarketStore) SetPragmas(busyTimeoutMs int, synchronous string) error {
+	return nil
+}
+
+// CheckIntegrity always reports "ok" for the in-memory store
+func (s *MemoryPolymarketStore) CheckIntegrity() (string, error) {
+	return "ok", nil
+}
+
+// CompressRawData is a no-op for the in-memory store, which keeps payloads as strings
+func (s *MemoryPolymarketStore) CompressRawData() (*domain.RawDataCompression, error) {
+	return &domain.RawDataCompression{}, nil
+}
+
+// Optimize is a no-op for the in-memory store
+func (s *MemoryPolymarketStore) Optimize() error {
+	return nil
+}
+
+// PruneEvents deletes events older than before and beyond the keepNewest newest, except
+// tagged events when keepTagged is set and events added to an investigation
+func (s *MemoryPolymarketStore) PruneEvents(before time.Time, keepNewest int64, keepTagged bool) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	protected := s.investigatedEventRefs()
+
+	// Events are stored oldest first
+	beyond := int64(len(s.events)) - keepNewest
+	kept := s.events[:0]
+	var deleted int64
+	for i, e := range s.events {
+		expired := (!before.IsZero() && e.Timestamp.Before(before)) || (keepNewest > 0 && int64(i) < beyond)
+		if expired && !protected[strconv.FormatInt(e.ID, 10)] && !(keepTagged && len(e.Tags) > 0) {
+			delete(s.tradeKeys, e.TradeKey())
+			deleted++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	s.events = kept
+	return deleted, nil
+}
+
+// ArchiveEvents moves events older than before to the archive, except events added to
+// an investigation
+func (s *MemoryPolymarketStore) ArchiveEvents(before time.Time) (int64, error) {
+	if before.IsZero() {
+		return 0, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	protected := s.investigatedEventRefs()
+	kept := s.events[:0]
+	var archived int64
+	for _, e := range s.events {
+		if e.Timestamp.Before(before) && !protected[strconv.FormatInt(e.ID, 10)] {
+			delete(s.tradeKeys, e.TradeKey())
+			s.archive = append(s.archive, e)
+			archived++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	s.events = kept
+	return archived, nil
+}
+
+// GetArchivedEvents retrieves archived events with the filtering of GetEvents
+func (s *MemoryPolymarketStore) GetArchivedEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	s.mu.RLock()
+	var events []domain.PolymarketEvent
+	for _, e := range s.archive {
+		if s.eventMatches(e, filter) {
+			events = append(events, e)
+		}
+	}
+	s.mu.RUnlock()
+	return pageMemoryEvents(events, filter), nil
+}
+
+// Backup is unsupported by the in-memory store, which has no database file
+func (s *MemoryPolymarketStore) Backup(path string) error {
+	return fmt.Errorf("the in-memory store has no database to back up")
+}
+
+// Restore is unsupported by the in-memory store, which has no database file
+func (s *MemoryPolymarketStore) Restore(path string) error {
+	return fmt.Errorf("the in-memory store can't restore a database backup")
+}
+
+// investigatedEventRefs returns the refs of events added to an investigation; callers
+// hold s.mu
+func (s *MemoryPolymarketStore) investigatedEventRefs() map[string]bool {
+	refs := make(map[string]bool)
+	for _, inv := range s.investigations {
+		for _, item := range inv.Items {
+			if item.Type == domain.InvestigationItemEvent {
+				refs[item.Ref] = true
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// memoryMarketEntities are the entities stored for a market
+type memoryMarketEntities struct {
+	marketName string
+	entities   []domain.MarketEntity
+	taggedAt   time.Time
+}
+
+// SaveMarketEntities replaces the entities stored for a market
+func (s *MemoryPolymarketStore) SaveMarketEntities(slug, marketName string, entities []domain.MarketEntity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(entities) == 0 {
+		delete(s.marketEntities, slug)
+		return nil
+	}
+	s.marketEntities[slug] = &memoryMarketEntities{
+		marketName: marketName,
+		entities:   append([]domain.MarketEntity{}, entities...),
+		taggedAt:   time.Now().UTC(),
+	}
+	return nil
+}
+
+// GetMarketEntities returns the entities stored for a market
+func (s *MemoryPolymarketStore) GetMarketEntities(slug string) ([]domain.MarketEntity, error) {
+	s.mu.RLock()
+	entities := []domain.MarketEntity{}
+	if m, ok := s.marketEntities[slug]; ok {
+		entities = append(entities, m.entities...)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].Type != entities[j].Type {
+			return entities[i].Type < entities[j].Type
+		}
+		return entities[i].Name < entities[j].Name
+	})
+	return entities, nil
+}
+
+// GetEntityMarkets returns the markets whose titles mention an entity, most recently
+// tagged first
+func (s *MemoryPolymarketStore) GetEntityMarkets(name string, limit int) ([]domain.EntityMarket, error) {
+	s.mu.RLock()
+	markets := []domain.EntityMarket{}
+	for slug, m := range s.marketEntities {
+		if m.mentions(name) {
+			markets = append(markets, domain.EntityMarket{MarketSlug: slug, MarketName: m.marketName, TaggedAt: m.taggedAt})
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(markets, func(i, j int) bool {
+		if !markets[i].TaggedAt.Equal(markets[j].TaggedAt) {
+			return markets[i].TaggedAt.After(markets[j].TaggedAt)
+		}
+		return markets[i].MarketSlug < markets[j].MarketSlug
+	})
+	if len(markets) > limit {
+		markets = markets[:limit]
+	}
+	return markets, nil
+}
+
+// GetEntityCounts returns the entities of the stored markets, mentioned by the most
+// markets first
+func (s *MemoryPolymarketStore) GetEntityCounts(limit int) ([]domain.MarketEntityCount, error) {
+	s.mu.RLock()
+	counts := make(map[domain.MarketEntity]int64)
+	for _, m := range s.marketEntities {
+		for _, e := range m.entities {
+			counts[e]++
+		}
+	}
+	s.mu.RUnlock()
+
+	result := []domain.MarketEntityCount{}
+	for e, n := range counts {
+		result = append(result, domain.MarketEntityCount{MarketEntity: e, Markets: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Markets != result[j].Markets {
+			return result[i].Markets > result[j].Markets
+		}
+		return result[i].Name < result[j].Name
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// GetStoredMarketTitles returns the name and event title of every market with stored
+// events
+func (s *MemoryPolymarketStore) GetStoredMarketTitles() ([]domain.MarketTitle, error) {
+	s.mu.RLock()
+	bySlug := make(map[string]*domain.MarketTitle)
+	for _, e := range s.events {
+		if e.MarketSlug == "" {
+			continue
+		}
+		t, ok := bySlug[e.MarketSlug]
+		if !ok {
+			t = &domain.MarketTitle{MarketSlug: e.MarketSlug}
+			bySlug[e.MarketSlug] = t
+		}
+		t.MarketName = max(t.MarketName, e.MarketName)
+		t.EventTitle = max(t.EventTitle, e.EventTitle)
+	}
+	s.mu.RUnlock()
+
+	titles := []domain.MarketTitle{}
+	for _, t := range bySlug {
+		titles = append(titles, *t)
+	}
+	sort.Slice(titles, func(i, j int) bool { return titles[i].MarketSlug < titles[j].MarketSlug })
+	return titles, nil
+}
+
+// GetEntityTradeFlows sums the trades between since and until on the markets mentioning
+// each entity, per entity, market and wallet
+func (s *MemoryPolymarketStore) GetEntityTradeFlows(since, until time.Time) ([]domain.EntityTradeFlow, error) {
+	type key struct {
+		entity domain.MarketEntity
+		market string
+		wallet string
+	}
+
+	s.mu.RLock()
+	groups := make(map[key]*domain.EntityTradeFlow)
+	for _, e := range s.events {
+		if e.EventType != domain.PolymarketEventTrade || e.Timestamp.Before(since) || !e.Timestamp.Before(until) {
+			continue
+		}
+		m, ok := s.marketEntities[e.MarketSlug]
+		if !ok {
+			continue
+		}
+		price, _ := strconv.ParseFloat(e.Price, 64)
+		size, _ := strconv.ParseFloat(e.Size, 64)
+		for _, entity := range m.entities {
+			k := key{entity, e.MarketSlug, e.WalletAddress}
+			f, ok := groups[k]
+			if !ok {
+				f = &domain.EntityTradeFlow{Entity: entity, MarketSlug: e.MarketSlug, Wallet: e.WalletAddress}
+				groups[k] = f
+			}
+			f.Volume += price * size
+			f.Trades++
+			f.Fresh = f.Fresh || e.IsFreshWallet
+		}
+	}
+	s.mu.RUnlock()
+
+	flows := make([]domain.EntityTradeFlow, 0, len(groups))
+	for _, f := range groups {
+		flows = append(flows, *f)
+	}
+	return flows, nil
+}
+
+// eventMatches is memoryEventMatches with the filter's entity and wallet tag, which
+// need the stored market entities and wallets. Caller must hold mu.
+func (s *MemoryPolymarketStore) eventMatches(e domain.PolymarketEvent, filter domain.PolymarketEventFilter) bool {
+	if !memoryEventMatches(e, filter) {
+		return false
+	}
+	if filter.WalletTag != "" {
+		w, ok := s.wallets[strings.ToLower(e.WalletAddress)]
+		if !ok || !w.tags[domain.NormalizeTag(filter.WalletTag)] {
+			return false
+		}
+	}
+	if filter.Entity == "" {
+		return true
+	}
+	m, ok := s.marketEntities[e.MarketSlug]
+	return ok && m.mentions(domain.CanonicalEntity(filter.Entity))
+}
+
+// mentions reports whether the market has an entity by name, ignoring case
+func (m *memoryMarketEntities) mentions(name string) bool {
+	for _, e := range m.entities {
+		if strings.EqualFold(e.Name, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetMarketsToResolve returns slugs of traded markets with no known resolution,
+// skipping unresolved markets checked after checkedBefore
+func (s *MemoryPolymarketStore) GetMarketsToResolve(limit int, checkedBefore time.Time) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var slugs []string
+	for _, e := range s.events {
+		if e.EventType != domain.PolymarketEventTrade || e.MarketSlug == "" || seen[e.MarketSlug] {
+			continue
+		}
+		seen[e.MarketSlug] = true
+		if r, ok := s.resolutions[e.MarketSlug]; ok && (r.Resolved || !r.CheckedAt.Before(checkedBefore)) {
+			continue
+		}
+		slugs = append(slugs, e.MarketSlug)
+		if len(slugs) >= limit {
+			break
+		}
+	}
+	return slugs, nil
+}
+
+// SaveMarketResolution records the latest resolution check of a market
+func (s *MemoryPolymarketStore) SaveMarketResolution(outcome domain.MarketOutcome) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !outcome.Resolved {
+		outcome.ResolvedAt = time.Time{}
+	}
+	s.resolutions[outcome.Slug] = outcome
+	return nil
+}
+
+// GetMarketWallets returns the wallets that bought into a market
+func (s *MemoryPolymarketStore) GetMarketWallets(slug string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var wallets []string
+	for _, e := range s.events {
+		if e.MarketSlug != slug || e.EventType != domain.PolymarketEventTrade || e.Side != domain.OrderSideBuy ||
+			e.WalletAddress == "" || seen[e.WalletAddress] {
+			continue
+		}
+		seen[e.WalletAddress] = true
+		wallets = append(wallets, e.WalletAddress)
+	}
+	return wallets, nil
+}
+
+// GetResolvedBets returns a wallet's bets in resolved markets, oldest resolution first
+func (s *MemoryPolymarketStore) GetResolvedBets(address string) ([]domain.ResolvedBet, error) {
+	s.mu.RLock()
+	var buys []resolvedBuy
+	for _, e := range s.events {
+		if e.WalletAddress != address || e.EventType != domain.PolymarketEventTrade || e.Side != domain.OrderSideBuy {
+			continue
+		}
+		r, ok := s.resolutions[e.MarketSlug]
+		if !ok || !r.Resolved {
+			continue
+		}
+		price, _ := strconv.ParseFloat(e.Price, 64)
+		buys = append(buys, resolvedBuy{
+			slug:           e.MarketSlug,
+			outcomeIndex:   e.OutcomeIndex,
+			price:          price,
+			timestamp:      e.Timestamp,
+			winningOutcome: r.WinningOutcome,
+			resolvedAt:     r.ResolvedAt,
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(buys, func(i, j int) bool {
+		return buys[i].timestamp.Before(buys[j].timestamp)
+	})
+	return groupResolvedBuys(buys), nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveMarketSnapshots stores snapshots
+func (s *MemoryPolymarketStore) SaveMarketSnapshots(snapshots []domain.MarketSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, m := range snapshots {
+		s.nextSnapshotID++
+		m.ID = s.nextSnapshotID
+		s.marketSnapshots = append(s.marketSnapshots, m)
+	}
+	return nil
+}
+
+// GetMarketSnapshots returns the stored snapshots matching a filter, newest first
+func (s *MemoryPolymarketStore) GetMarketSnapshots(filter domain.MarketSnapshotFilter) ([]domain.MarketSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := []domain.MarketSnapshot{}
+	for i := len(s.marketSnapshots) - 1; i >= 0 && len(snapshots) < filter.Limit; i-- {
+		m := s.marketSnapshots[i]
+		if filter.MarketSlug != "" && m.MarketSlug != filter.MarketSlug {
+			continue
+		}
+		if (!filter.Since.IsZero() && m.CapturedAt.Before(filter.Since)) || (!filter.Until.IsZero() && m.CapturedAt.After(filter.Until)) {
+			continue
+		}
+		snapshots = append(snapshots, m)
+	}
+	return snapshots, nil
+}
+
+// PruneMarketSnapshots deletes snapshots captured before a time and returns how many
+func (s *MemoryPolymarketStore) PruneMarketSnapshots(before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.marketSnapshots[:0]
+	for _, m := range s.marketSnapshots {
+		if !m.CapturedAt.Before(before) {
+			kept = append(kept, m)
+		}
+	}
+	deleted := int64(len(s.marketSnapshots) - len(kept))
+	s.marketSnapshots = kept
+	return deleted, nil
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetNotificationStats counts the items notified since a time, by type and UTC day
+func (s *MemoryPolymarketStore) GetNotificationStats(since time.Time) (*domain.NotificationStats, error) {
+	s.mu.RLock()
+	counts := make(map[domain.NotificationDayCount]int64)
+	for key, at := range s.notified {
+		if at.Before(since) {
+			continue
+		}
+		itemType, _, _ := strings.Cut(key, ":")
+		counts[domain.NotificationDayCount{Day: at.UTC().Format("2006-01-02"), ItemType: itemType}]++
+	}
+	s.mu.RUnlock()
+
+	stats := &domain.NotificationStats{Since: since, ByType: map[string]int64{}, Days: []domain.NotificationDayCount{}}
+	for day, count := range counts {
+		day.Count = count
+		stats.Days = append(stats.Days, day)
+		stats.ByType[day.ItemType] += count
+		stats.Total += count
+	}
+	sort.Slice(stats.Days, func(i, j int) bool {
+		if stats.Days[i].Day != stats.Days[j].Day {
+			return stats.Days[i].Day < stats.Days[j].Day
+		}
+		return stats.Days[i].ItemType < stats.Days[j].ItemType
+	})
+	return stats, nil
+}
+
+// DeleteNotifiedBefore deletes the records of items notified before a time, with their
+// channel delivery states
+func (s *MemoryPolymarketStore) DeleteNotifiedBefore(before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, d := range s.deliveries {
+		if d.UpdatedAt.Before(before) {
+			delete(s.deliveries, key)
+		}
+	}
+	var deleted int64
+	for key, at := range s.notified {
+		if at.Before(before) {
+			delete(s.notified, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// SaveNotificationDelivery inserts or replaces the delivery state of an item on a channel
+func (s *MemoryPolymarketStore) SaveNotificationDelivery(delivery domain.NotificationDelivery) error {
+	if delivery.Delivered() {
+		delivery.Content = nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries[delivery.ItemType+":"+delivery.ItemID+":"+delivery.Channel] = delivery
+	return nil
+}
+
+// GetNotificationDeliveries returns the delivery state of an item on each channel it was
+// sent to
+func (s *MemoryPolymarketStore) GetNotificationDeliveries(itemType, itemID string) ([]domain.NotificationDelivery, error) {
+	s.mu.RLock()
+	deliveries := []domain.NotificationDelivery{}
+	for _, d := range s.deliveries {
+		if d.ItemType == itemType && d.ItemID == itemID {
+			deliveries = append(deliveries, d)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].Channel < deliveries[j].Channel })
+	return deliveries, nil
+}
+
+// GetUndeliveredNotifications returns the deliveries that have not succeeded yet, next
+// attempt first
+func (s *MemoryPolymarketStore) GetUndeliveredNotifications(limit int) ([]domain.NotificationDelivery, error) {
+	s.mu.RLock()
+	deliveries := []domain.NotificationDelivery{}
+	for _, d := range s.deliveries {
+		if !d.Delivered() {
+			deliveries = append(deliveries, d)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].NextAttemptAt.Before(deliveries[j].NextAttemptAt) })
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// GetDueNotificationDeliveries returns the undelivered deliveries with fewer than
+// maxAttempts attempts whose next attempt is due, next attempt first
+func (s *MemoryPolymarketStore) GetDueNotificationDeliveries(maxAttempts int, now time.Time, limit int) ([]domain.NotificationDelivery, error) {
+	s.mu.RLock()
+	deliveries := []domain.NotificationDelivery{}
+	for _, d := range s.deliveries {
+		if !d.Delivered() && d.Attempts < maxAttempts && !d.NextAttemptAt.After(now) && d.Content != nil {
+			deliveries = append(deliveries, d)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].NextAttemptAt.Before(deliveries[j].NextAttemptAt) })
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+
+// HasNotified checks if an item has already been notified
+func (s *MemoryPolymarketStore) HasNotified(itemType, itemID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.notified[itemType+":"+itemID]
+	return ok, nil
+}
+
+// MarkNotified marks an item as notified
+func (s *MemoryPolymarketStore) MarkNotified(itemType, itemID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := itemType + ":" + itemID
+	if _, ok := s.notified[key]; !ok {
+		s.notified[key] = time.Now()
+	}
+	return nil
+}
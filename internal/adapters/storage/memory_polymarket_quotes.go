@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"sort"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveQuotes replaces the stored quotes of the given assets. A quote older than the
+// stored one is ignored.
+func (s *MemoryPolymarketStore) SaveQuotes(quotes []domain.MarketQuote) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, q := range quotes {
+		existing, ok := s.quotes[q.AssetID]
+		if ok && q.UpdatedAt.Before(existing.UpdatedAt) {
+			continue
+		}
+		if q.ConditionID == "" {
+			q.ConditionID = existing.ConditionID
+		}
+		q.Spread = domain.QuoteSpread(q.BestBid, q.BestAsk)
+		s.quotes[q.AssetID] = q
+	}
+	return nil
+}
+
+// GetQuotes returns the stored quotes of the given assets, or of every asset when none
+// are given, most recently updated first
+func (s *MemoryPolymarketStore) GetQuotes(assetIDs []string) ([]domain.MarketQuote, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	quotes := []domain.MarketQuote{}
+	if len(assetIDs) == 0 {
+		for _, q := range s.quotes {
+			quotes = append(quotes, q)
+		}
+	} else {
+		for _, id := range assetIDs {
+			if q, ok := s.quotes[id]; ok {
+				quotes = append(quotes, q)
+			}
+		}
+	}
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].UpdatedAt.After(quotes[j].UpdatedAt) })
+	return quotes, nil
+}
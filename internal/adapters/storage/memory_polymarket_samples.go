@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetTradeSamples returns stored trades since the given time, oldest first
+func (s *MemoryPolymarketStore) GetTradeSamples(since time.Time) ([]domain.TradeSample, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var samples []domain.TradeSample
+	for _, e := range s.events {
+		if e.EventType != domain.PolymarketEventTrade || e.WalletAddress == "" || e.Timestamp.Before(since) {
+			continue
+		}
+		price, _ := strconv.ParseFloat(e.Price, 64)
+		size, _ := strconv.ParseFloat(e.Size, 64)
+		sample := domain.TradeSample{
+			Timestamp:     e.Timestamp,
+			AssetID:       e.AssetID,
+			WalletAddress: e.WalletAddress,
+			Side:          e.Side,
+			Price:         price,
+			Notional:      price * size,
+			BetCount:      -1,
+		}
+		if e.WalletProfile != nil {
+			sample.BetCount = e.WalletProfile.BetCount
+		} else if w, ok := s.wallets[e.WalletAddress]; ok && w.analyzed {
+			sample.BetCount = w.profile.BetCount
+		}
+		samples = append(samples, sample)
+	}
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	return samples, nil
+}
+
+// GetWalletActivitySince summarizes the wallet's stored trades after the given time.
+// New markets are those the wallet hadn't traded at or before it.
+func (s *MemoryPolymarketStore) GetWalletActivitySince(wallet string, since time.Time) (*domain.WalletActivityDiff, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	diff := &domain.WalletActivityDiff{WalletAddress: wallet, LastAlertAt: since}
+	before := make(map[string]bool)
+	after := make(map[string]bool)
+	for _, e := range s.events {
+		if e.EventType != domain.PolymarketEventTrade || e.WalletAddress != wallet {
+			continue
+		}
+		market := e.MarketSlug
+		if market == "" {
+			market = e.AssetID
+		}
+		if !e.Timestamp.After(since) {
+			before[market] = true
+			continue
+		}
+		price, _ := strconv.ParseFloat(e.Price, 64)
+		size, _ := strconv.ParseFloat(e.Size, 64)
+		diff.Trades++
+		diff.Volume += price * size
+		after[market] = true
+	}
+	diff.Markets = len(after)
+	for market := range after {
+		if !before[market] {
+			diff.NewMarkets++
+		}
+	}
+	return diff, nil
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"slices"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveSentAlert inserts or replaces a tracked alert and its acknowledgment state
+func (s *MemoryPolymarketStore) SaveSentAlert(alert domain.SentAlert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.sentAlerts {
+		if s.sentAlerts[i].ID == alert.ID {
+			s.sentAlerts[i] = alert
+			return nil
+		}
+	}
+	s.sentAlerts = append(s.sentAlerts, alert)
+	return nil
+}
+
+// GetSentAlerts returns the newest tracked alerts, up to limit, oldest first
+func (s *MemoryPolymarketStore) GetSentAlerts(limit int) ([]domain.SentAlert, error) {
+	s.mu.RLock()
+	alerts := slices.Clone(s.sentAlerts)
+	s.mu.RUnlock()
+
+	slices.SortStableFunc(alerts, func(a, b domain.SentAlert) int { return a.SentAt.Compare(b.SentAt) })
+	if len(alerts) > limit {
+		alerts = alerts[len(alerts)-limit:]
+	}
+	if alerts == nil {
+		alerts = []domain.SentAlert{}
+	}
+	return alerts, nil
+}
+
+// DeleteSentAlert stops tracking an alert
+func (s *MemoryPolymarketStore) DeleteSentAlert(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sentAlerts = slices.DeleteFunc(s.sentAlerts, func(a domain.SentAlert) bool { return a.ID == id })
+	return nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetSettingHistory returns the previous values of a settings key, or of every key
+// when key is empty, newest first
+func (s *MemoryPolymarketStore) GetSettingHistory(key string, limit int) ([]domain.SettingHistoryEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := []domain.SettingHistoryEntry{}
+	for i := len(s.settingHistory) - 1; i >= 0 && len(entries) < limit; i-- {
+		if key == "" || s.settingHistory[i].Key == key {
+			entries = append(entries, s.settingHistory[i])
+		}
+	}
+	return entries, nil
+}
+
+// RestoreSetting writes a previous value back to its key, recording the value it replaces
+func (s *MemoryPolymarketStore) RestoreSetting(id int64) (*domain.SettingHistoryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.settingHistory {
+		if entry.ID == id {
+			s.saveSettingLocked(entry.Key, []byte(entry.Value))
+			return &entry, nil
+		}
+	}
+	return nil, fmt.Errorf("no setting history entry %d", id)
+}
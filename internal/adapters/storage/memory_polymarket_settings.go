@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveSetting stores a JSON-encoded setting
+func (s *MemoryPolymarketStore) SaveSetting(key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.saveSettingLocked(key, data)
+	s.mu.Unlock()
+	return nil
+}
+
+// saveSettingLocked writes a setting, moving a different value it replaces into the history
+func (s *MemoryPolymarketStore) saveSettingLocked(key string, data []byte) {
+	if previous, ok := s.settings[key]; ok && string(previous) != string(data) {
+		var id int64 = 1
+		if n := len(s.settingHistory); n > 0 {
+			id = s.settingHistory[n-1].ID + 1
+		}
+		s.settingHistory = append(s.settingHistory, domain.SettingHistoryEntry{
+			ID: id, Key: key, Value: string(previous), ReplacedAt: time.Now().UTC(),
+		})
+		var kept []domain.SettingHistoryEntry
+		count := 0
+		for i := len(s.settingHistory) - 1; i >= 0; i-- {
+			entry := s.settingHistory[i]
+			if entry.Key == key {
+				if count++; count > settingHistoryPerKey {
+					continue
+				}
+			}
+			kept = append(kept, entry)
+		}
+		slices.Reverse(kept)
+		s.settingHistory = kept
+	}
+	s.settings[key] = data
+}
+
+// LoadSetting decodes a setting, returning sql.ErrNoRows if it was never saved
+func (s *MemoryPolymarketStore) LoadSetting(key string, dest any) error {
+	s.mu.RLock()
+	data, ok := s.settings[key]
+	s.mu.RUnlock()
+	if !ok {
+		return sql.ErrNoRows
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// SaveConfig saves the Polymarket config
+func (s *MemoryPolymarketStore) SaveConfig(config domain.PolymarketConfig) error {
+	return s.SaveSetting("config", config)
+}
+
+// LoadConfig loads the Polymarket config
+func (s *MemoryPolymarketStore) LoadConfig() (domain.PolymarketConfig, error) {
+	var config domain.PolymarketConfig
+	if err := s.LoadSetting("config", &config); err != nil {
+		return domain.DefaultPolymarketConfig(), err
+	}
+	return config, nil
+}
+
+// SaveFilter saves the event filter
+func (s *MemoryPolymarketStore) SaveFilter(filter domain.PolymarketEventFilter) error {
+	return s.SaveSetting("filter", filter)
+}
+
+// LoadFilter loads the event filter
+func (s *MemoryPolymarketStore) LoadFilter() (domain.PolymarketEventFilter, error) {
+	var filter domain.PolymarketEventFilter
+	if err := s.LoadSetting("filter", &filter); err != nil {
+		return domain.PolymarketEventFilter{MinSize: 100}, err
+	}
+	return filter, nil
+}
+
+// SaveNotificationConfig saves the notification config
+func (s *MemoryPolymarketStore) SaveNotificationConfig(config domain.NotificationConfig) error {
+	return s.SaveSetting(domain.NotificationConfigSettingKey, config)
+}
+
+// LoadNotificationConfig loads the notification config
+func (s *MemoryPolymarketStore) LoadNotificationConfig() (domain.NotificationConfig, error) {
+	var config domain.NotificationConfig
+	if err := s.LoadSetting(domain.NotificationConfigSettingKey, &config); err != nil {
+		return domain.DefaultNotificationConfig(), err
+	}
+	return config, nil
+}
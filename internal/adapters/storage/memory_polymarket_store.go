@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+// MemoryPolymarketStore implements PolymarketStore in memory.
+// Useful for tests, demos and embedders that bring their own persistence.
+type MemoryPolymarketStore struct {
+	mu        sync.RWMutex
+	events    []domain.PolymarketEvent
+	tradeKeys map[string]bool // Of stored trades, to skip redelivered ones
+	nextID    int64
+	settings  map[string][]byte
+	wallets   map[string]*memoryWallet
+	notified  map[string]time.Time
+	quotes    map[string]domain.MarketQuote // Latest quote per asset
+
+	resolutions    map[string]domain.MarketOutcome
+	alertOutcomes  []domain.AlertOutcome
+	snapshots      []domain.ConfigSnapshot // Config versions, oldest first
+	investigations map[int64]*domain.Investigation
+	walletIntel    map[string][]domain.ImportedWalletIntel // Imported intel by address
+	archive        []domain.PolymarketEvent                // Archived events, oldest first
+	settingHistory []domain.SettingHistoryEntry            // Overwritten settings values, oldest first
+	nextCaseID     int64
+	nextCaseNoteID int64
+
+	// Market categories traded, by address and then category
+	categories map[string]map[string]*domain.WalletCategoryStats
+
+	// Channel delivery states, by item type, item ID and channel
+	deliveries map[string]domain.NotificationDelivery
+
+	// Delivered alerts tracked for acknowledgment, oldest first
+	sentAlerts []domain.SentAlert
+
+	// Entities found in market titles, by market slug
+	marketEntities map[string]*memoryMarketEntities
+
+	// Scheduled market snapshots, oldest first
+	marketSnapshots []domain.MarketSnapshot
+	nextSnapshotID  int64
+}
+
+// memoryWallet is a stored wallet with its bookkeeping fields
+type memoryWallet struct {
+	profile     domain.WalletProfile
+	analyzed    bool
+	totalTrades int
+	totalVolume float64
+	tags        map[string]bool
+	traderName  string
+}
+
+// NewMemoryPolymarketStore creates an empty in-memory Polymarket store
+func NewMemoryPolymarketStore() *MemoryPolymarketStore {
+	return &MemoryPolymarketStore{
+		tradeKeys: make(map[string]bool),
+		settings:  make(map[string][]byte),
+		wallets:   make(map[string]*memoryWallet),
+		notified:  make(map[string]time.Time),
+		quotes:    make(map[string]domain.MarketQuote),
+
+		resolutions:    make(map[string]domain.MarketOutcome),
+		investigations: make(map[int64]*domain.Investigation),
+		walletIntel:    make(map[string][]domain.ImportedWalletIntel),
+		categories:     make(map[string]map[string]*domain.WalletCategoryStats),
+		deliveries:     make(map[string]domain.NotificationDelivery),
+		marketEntities: make(map[string]*memoryMarketEntities),
+	}
+}
+
+// GetDatabaseInfo returns store statistics
+func (s *MemoryPolymarketStore) GetDatabaseInfo() (*domain.DatabaseInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	info := &domain.DatabaseInfo{
+		SizeFormatted: "in memory",
+		EventCount:    int64(len(s.events)),
+		Path:          ":memory:",
+
+		ArchivedEventCount: int64(len(s.archive)),
+	}
+	for _, e := range s.events {
+		if info.OldestEventAt.IsZero() || e.Timestamp.Before(info.OldestEventAt) {
+			info.OldestEventAt = e.Timestamp
+		}
+		if e.Timestamp.After(info.NewestEventAt) {
+			info.NewestEventAt = e.Timestamp
+		}
+	}
+	return info, nil
+}
+
+// Close is a no-op for the in-memory store
+func (s *MemoryPolymarketStore) Close() error {
+	return nil
+}
+
+// Ensure MemoryPolymarketStore implements PolymarketStore interface
+var _ ports.PolymarketStore = (*MemoryPolymarketStore)(nil)
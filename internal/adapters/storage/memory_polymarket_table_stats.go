@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"sort"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetTableStats returns the row counts of the store's collections, largest first
+func (s *MemoryPolymarketStore) GetTableStats() ([]domain.DatabaseTableStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var stats []domain.DatabaseTableStats
+	var intel int
+	for _, entries := range s.walletIntel {
+		intel += len(entries)
+	}
+	for name, rows := range map[string]int{
+		"polymarket_events":         len(s.events),
+		"polymarket_events_archive": len(s.archive),
+		"polymarket_wallets":        len(s.wallets),
+		"polymarket_settings":       len(s.settings),
+		"notified_items":            len(s.notified),
+		"market_quotes":             len(s.quotes),
+		"market_snapshots":          len(s.marketSnapshots),
+		"market_resolutions":        len(s.resolutions),
+		"alert_outcomes":            len(s.alertOutcomes),
+		"config_snapshots":          len(s.snapshots),
+		"investigations":            len(s.investigations),
+		"wallet_intel":              intel,
+	} {
+		stats = append(stats, domain.DatabaseTableStats{Name: name, Rows: int64(rows)})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.Rows != b.Rows {
+			return a.Rows > b.Rows
+		}
+		return a.Name < b.Name
+	})
+	return stats, nil
+}
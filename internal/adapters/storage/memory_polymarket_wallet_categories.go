@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// RecordWalletCategory counts a wallet's trade in a market category
+func (s *MemoryPolymarketStore) RecordWalletCategory(address, category string, notional float64, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	address = strings.ToLower(address)
+	byCategory, ok := s.categories[address]
+	if !ok {
+		byCategory = make(map[string]*domain.WalletCategoryStats)
+		s.categories[address] = byCategory
+	}
+	at = at.UTC()
+	c, ok := byCategory[category]
+	if !ok {
+		c = &domain.WalletCategoryStats{Category: category, FirstAt: at, LastAt: at}
+		byCategory[category] = c
+	}
+	c.Trades++
+	c.Notional += notional
+	if at.Before(c.FirstAt) {
+		c.FirstAt = at
+	}
+	if at.After(c.LastAt) {
+		c.LastAt = at
+	}
+	return nil
+}
+
+// GetWalletCategories returns the market categories a wallet traded, most traded first
+func (s *MemoryPolymarketStore) GetWalletCategories(address string) ([]domain.WalletCategoryStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	categories := []domain.WalletCategoryStats{}
+	for _, c := range s.categories[strings.ToLower(address)] {
+		categories = append(categories, *c)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Trades != categories[j].Trades {
+			return categories[i].Trades > categories[j].Trades
+		}
+		return categories[i].Category < categories[j].Category
+	})
+	return categories, nil
+}
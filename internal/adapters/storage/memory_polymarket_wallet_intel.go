@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// TagWallet attaches a label to a wallet; tagging twice is a no-op
+func (s *MemoryPolymarketStore) TagWallet(address, tag string) error {
+	tag = domain.NormalizeTag(tag)
+	if tag == "" {
+		return fmt.Errorf("tag is required")
+	}
+	address = strings.ToLower(address)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.wallets[address]
+	if !ok {
+		w = &memoryWallet{profile: domain.WalletProfile{Address: address, BetCount: -1, FirstSeen: time.Now()}}
+		s.wallets[address] = w
+	}
+	if w.tags == nil {
+		w.tags = make(map[string]bool)
+	}
+	w.tags[tag] = true
+	return nil
+}
+
+// UntagWallet removes a label from a wallet
+func (s *MemoryPolymarketStore) UntagWallet(address, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if w, ok := s.wallets[strings.ToLower(address)]; ok {
+		delete(w.tags, domain.NormalizeTag(tag))
+	}
+	return nil
+}
+
+// GetWalletTags returns the tags of the given wallets, or of every tagged wallet when
+// none are given, sorted by tag
+func (s *MemoryPolymarketStore) GetWalletTags(addresses []string) (map[string][]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var wanted map[string]bool
+	if len(addresses) > 0 {
+		wanted = make(map[string]bool, len(addresses))
+		for _, address := range addresses {
+			wanted[strings.ToLower(address)] = true
+		}
+	}
+	tags := make(map[string][]string)
+	for address, w := range s.wallets {
+		if len(w.tags) == 0 || (wanted != nil && !wanted[address]) {
+			continue
+		}
+		for tag := range w.tags {
+			tags[address] = append(tags[address], tag)
+		}
+		sort.Strings(tags[address])
+	}
+	return tags, nil
+}
+
+// SaveImportedWalletIntel stores imported wallet intel, replacing what the same
+// publisher key shared about a wallet before
+func (s *MemoryPolymarketStore) SaveImportedWalletIntel(intel []domain.ImportedWalletIntel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range intel {
+		address := strings.ToLower(item.Address)
+		existing := s.walletIntel[address]
+		replaced := false
+		for i := range existing {
+			if existing[i].PublicKey == item.PublicKey {
+				existing[i], replaced = item, true
+			}
+		}
+		if !replaced {
+			existing = append(existing, item)
+		}
+		s.walletIntel[address] = existing
+	}
+	return nil
+}
+
+// GetImportedWalletIntel returns what other users shared about a wallet, most recently
+// imported first
+func (s *MemoryPolymarketStore) GetImportedWalletIntel(address string) ([]domain.ImportedWalletIntel, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	intel := append([]domain.ImportedWalletIntel{}, s.walletIntel[strings.ToLower(address)]...)
+	sort.Slice(intel, func(i, j int) bool { return intel[i].ImportedAt.After(intel[j].ImportedAt) })
+	return intel, nil
+}
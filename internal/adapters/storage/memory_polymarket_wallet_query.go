@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// QueryWallets retrieves a filtered, sorted page of wallets using keyset pagination
+func (s *MemoryPolymarketStore) QueryWallets(filter domain.WalletFilter) (*domain.WalletPage, error) {
+	limit := walletPageLimit(filter.Limit)
+
+	var after *walletCursor
+	if filter.Cursor != "" {
+		cursor, err := decodeWalletCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	type keyed struct {
+		profile domain.WalletProfile
+		key     string
+	}
+
+	s.mu.RLock()
+	var matches []keyed
+	for _, w := range s.wallets {
+		if !memoryWalletMatches(w, filter) {
+			continue
+		}
+		profile := w.profile
+		profile.Nonce = profile.BetCount // Backward compatibility
+		matches = append(matches, keyed{profile: profile, key: memoryWalletSortKey(w, filter.SortBy)})
+	}
+	s.mu.RUnlock()
+
+	// less orders by sort key, then address, honoring the sort direction
+	less := func(keyA, addrA, keyB, addrB string) bool {
+		if keyA != keyB {
+			return (keyA < keyB) != filter.SortDesc
+		}
+		if addrA == addrB {
+			return false
+		}
+		return (addrA < addrB) != filter.SortDesc
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return less(matches[i].key, matches[i].profile.Address, matches[j].key, matches[j].profile.Address)
+	})
+
+	page := &domain.WalletPage{Wallets: []domain.WalletProfile{}}
+	var lastKey string
+	for _, m := range matches {
+		if after != nil {
+			cursorKey, _ := after.Value.(string)
+			if !less(cursorKey, after.Address, m.key, m.profile.Address) {
+				continue
+			}
+		}
+		if len(page.Wallets) == limit {
+			last := page.Wallets[len(page.Wallets)-1]
+			page.NextCursor = encodeWalletCursor(lastKey, last.Address)
+			break
+		}
+		page.Wallets = append(page.Wallets, m.profile)
+		lastKey = m.key
+	}
+	return page, nil
+}
+
+// memoryWalletSortKey returns a string key that orders like the SQL sort expression
+func memoryWalletSortKey(w *memoryWallet, sortBy domain.WalletSortField) string {
+	profile := w.profile
+	switch sortBy {
+	case domain.WalletSortLastAnalyzed:
+		if profile.AnalyzedAt.IsZero() {
+			return ""
+		}
+		return fmt.Sprintf("%020d", profile.AnalyzedAt.UnixNano())
+	case domain.WalletSortBetCount:
+		return fmt.Sprintf("%011d", profile.BetCount+1) // Unanalyzed wallets (-1) first
+	case domain.WalletSortAddress:
+		return profile.Address
+	case domain.WalletSortVolume:
+		return fmt.Sprintf("%020.6f", w.totalVolume)
+	default:
+		return fmt.Sprintf("%020d", profile.FirstSeen.UnixNano())
+	}
+}
+
+// memoryWalletMatches mirrors the SQL wallet filter
+func memoryWalletMatches(w *memoryWallet, filter domain.WalletFilter) bool {
+	p := w.profile
+
+	if len(filter.FreshnessLevels) > 0 {
+		found := false
+		for _, level := range filter.FreshnessLevels {
+			if level == p.FreshnessLevel {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.MinBetCount > 0 && p.BetCount < filter.MinBetCount {
+		return false
+	}
+	if filter.MaxBetCount > 0 && (p.BetCount < 0 || p.BetCount > filter.MaxBetCount) {
+		return false
+	}
+	if filter.AnalyzedOnly && p.BetCount < 0 {
+		return false
+	}
+	if filter.UnanalyzedOnly && p.BetCount >= 0 {
+		return false
+	}
+	if filter.Tag != "" && !w.tags[domain.NormalizeTag(filter.Tag)] {
+		return false
+	}
+	if !filter.JoinedAfter.IsZero() {
+		joined := parseJoinDate(p.JoinDate)
+		if joined.IsZero() || joined.Year()*100+int(joined.Month()) < filter.JoinedAfter.Year()*100+int(filter.JoinedAfter.Month()) {
+			return false
+		}
+	}
+	if filter.MinVolume > 0 && w.totalVolume < filter.MinVolume {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"sort"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SetWalletTraderName records the name or pseudonym a wallet traded under
+func (s *MemoryPolymarketStore) SetWalletTraderName(address, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if w, ok := s.wallets[address]; ok {
+		w.traderName = name
+	}
+	return nil
+}
+
+// SearchWallets finds wallets by partial address, trader name or tag, best matches first
+func (s *MemoryPolymarketStore) SearchWallets(query string, limit int) ([]domain.WalletSearchResult, error) {
+	q := parseWalletSearch(query)
+	if q.text == "" {
+		return []domain.WalletSearchResult{}, nil
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	s.mu.RLock()
+	var ranked []rankedWallet
+	for _, w := range s.wallets {
+		tags := make([]string, 0, len(w.tags))
+		for tag := range w.tags {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+
+		field, rank, ok := q.match(w.profile.Address, w.traderName, tags)
+		if !ok {
+			continue
+		}
+		profile := w.profile
+		profile.Nonce = profile.BetCount // Backward compatibility
+		ranked = append(ranked, rankedWallet{
+			result: domain.WalletSearchResult{Wallet: profile, TraderName: w.traderName, Tags: tags, MatchedOn: field},
+			rank:   rank,
+		})
+	}
+	s.mu.RUnlock()
+
+	return sortWalletSearchResults(ranked, limit), nil
+}
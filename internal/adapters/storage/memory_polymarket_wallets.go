@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveWallet saves or updates a wallet profile
+func (s *MemoryPolymarketStore) SaveWallet(profile domain.WalletProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.wallets[profile.Address]
+	if !ok {
+		w = &memoryWallet{}
+		w.profile.FirstSeen = time.Now()
+		s.wallets[profile.Address] = w
+	}
+	firstSeen := w.profile.FirstSeen
+	w.profile = profile
+	w.profile.FirstSeen = firstSeen
+	w.analyzed = profile.BetCount >= 0
+	return nil
+}
+
+// GetWallet retrieves a wallet profile, returning sql.ErrNoRows if unknown
+func (s *MemoryPolymarketStore) GetWallet(address string) (*domain.WalletProfile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	w, ok := s.wallets[address]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	profile := w.profile
+	profile.Nonce = profile.BetCount // Backward compatibility
+	return &profile, nil
+}
+
+// GetAllWallets retrieves wallets, most recently seen first
+func (s *MemoryPolymarketStore) GetAllWallets(limit int) ([]domain.WalletProfile, error) {
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	s.mu.RLock()
+	wallets := make([]domain.WalletProfile, 0, len(s.wallets))
+	for _, w := range s.wallets {
+		profile := w.profile
+		profile.Nonce = profile.BetCount // Backward compatibility
+		wallets = append(wallets, profile)
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(wallets, func(i, j int) bool {
+		return wallets[i].FirstSeen.After(wallets[j].FirstSeen)
+	})
+	if len(wallets) > limit {
+		wallets = wallets[:limit]
+	}
+	return wallets, nil
+}
+
+// SaveWalletAddress saves a wallet address without analysis
+// Returns true if this is a new wallet, false if it already exists
+func (s *MemoryPolymarketStore) SaveWalletAddress(address string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.wallets[address]; ok {
+		return false, nil
+	}
+	s.wallets[address] = &memoryWallet{
+		profile: domain.WalletProfile{Address: address, BetCount: -1, FirstSeen: time.Now()},
+	}
+	return true, nil
+}
+
+// UpdateWalletTradeStats counts a trade and its volume towards a wallet's totals
+func (s *MemoryPolymarketStore) UpdateWalletTradeStats(address string, tradeVolume float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if w, ok := s.wallets[address]; ok {
+		w.totalTrades++
+		w.totalVolume += tradeVolume
+	}
+	return nil
+}
+
+// GetWalletsForRefresh returns unanalyzed wallets first, then wallets with <= 50 bets
+// by oldest analysis, leaving out excluded ones
+func (s *MemoryPolymarketStore) GetWalletsForRefresh(limit int, exclude []string) ([]string, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	excluded := make(map[string]bool, len(exclude))
+	for _, address := range exclude {
+		excluded[strings.ToLower(address)] = true
+	}
+
+	s.mu.RLock()
+	var candidates []*memoryWallet
+	for _, w := range s.wallets {
+		if w.profile.BetCount <= 50 && !excluded[strings.ToLower(w.profile.Address)] {
+			candidates = append(candidates, w)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].analyzed != candidates[j].analyzed {
+			return !candidates[i].analyzed
+		}
+		return candidates[i].profile.AnalyzedAt.Before(candidates[j].profile.AnalyzedAt)
+	})
+
+	var addresses []string
+	for _, w := range candidates {
+		if len(addresses) >= limit {
+			break
+		}
+		addresses = append(addresses, w.profile.Address)
+	}
+	return addresses, nil
+}
+
+// GetWalletStats returns the distribution of bet counts, join dates and freshness levels
+func (s *MemoryPolymarketStore) GetWalletStats() (*domain.WalletStats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stats := &domain.WalletStats{BetCounts: newBetCountHistogram()}
+	joinDates := make(map[string]int64)
+	levels := make(map[string]int64)
+
+	for _, w := range s.wallets {
+		stats.TotalWallets++
+		if w.profile.IsFresh {
+			stats.FreshWallets++
+		}
+		if w.profile.JoinDate != "" {
+			joinDates[w.profile.JoinDate]++
+		}
+		if !w.analyzed {
+			stats.PendingWallets++
+			continue
+		}
+		stats.AnalyzedWallets++
+		addToBetCountHistogram(stats.BetCounts, w.profile.BetCount, 1)
+		level := string(w.profile.FreshnessLevel)
+		if level == "" {
+			level = "none"
+		}
+		levels[level]++
+	}
+
+	for label, count := range joinDates {
+		stats.JoinDates = append(stats.JoinDates, domain.HistogramBucket{Label: label, Count: count})
+	}
+	sortJoinDateHistogram(stats.JoinDates)
+
+	for label, count := range levels {
+		stats.FreshnessLevels = append(stats.FreshnessLevels, domain.HistogramBucket{Label: label, Count: count})
+	}
+	sort.SliceStable(stats.FreshnessLevels, func(i, j int) bool {
+		return stats.FreshnessLevels[i].Count > stats.FreshnessLevels[j].Count
+	})
+
+	return stats, nil
+}
+
+// RecomputeFreshness re-evaluates freshness of analyzed wallets and events with a bet count
+func (s *MemoryPolymarketStore) RecomputeFreshness(classify domain.FreshnessClassifier) (*domain.FreshnessRecomputeResult, error) {
+	start := time.Now()
+	result := &domain.FreshnessRecomputeResult{}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, w := range s.wallets {
+		if !w.analyzed {
+			continue
+		}
+		result.WalletsScanned++
+		level := classify(w.profile.BetCount)
+		isFresh := level != domain.FreshnessNone
+		if isFresh {
+			result.FreshWallets++
+		}
+		if level != w.profile.FreshnessLevel || isFresh != w.profile.IsFresh {
+			if level != w.profile.FreshnessLevel {
+				result.Changes = append(result.Changes, domain.WalletFreshnessChange{
+					Address: w.profile.Address, PreviousLevel: w.profile.FreshnessLevel, Level: level,
+				})
+			}
+			w.profile.FreshnessLevel = level
+			w.profile.IsFresh = isFresh
+			result.WalletsChanged++
+		}
+	}
+
+	for i := range s.events {
+		e := &s.events[i]
+		if e.WalletProfile == nil {
+			continue
+		}
+		isFresh := classify(e.WalletProfile.BetCount) != domain.FreshnessNone
+		if isFresh != e.IsFreshWallet {
+			e.IsFreshWallet = isFresh
+			result.EventsUpdated++
+		}
+	}
+
+	result.CompletedAt = time.Now()
+	result.DurationMs = result.CompletedAt.Sub(start).Milliseconds()
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// aggregateFormats are the strftime formats of each bucket's start
+var aggregateFormats = map[domain.AggregateBucket]string{
+	domain.AggregateHour: "%Y-%m-%d %H:00:00",
+	domain.AggregateDay:  "%Y-%m-%d 00:00:00",
+}
+
+// GetMarketAggregates totals the trades matching the filter per market and time
+// bucket, oldest bucket first and the biggest markets first within a bucket. The
+// filter's limit caps the rows returned (default 1000); its event types are ignored.
+func (s *PolymarketStore) GetMarketAggregates(filter domain.PolymarketEventFilter, bucket domain.AggregateBucket) ([]domain.MarketAggregate, error) {
+	format, ok := aggregateFormats[bucket]
+	if !ok {
+		return nil, fmt.Errorf("invalid aggregate bucket: %q", bucket)
+	}
+	filter.EventTypes = []domain.PolymarketEventType{domain.PolymarketEventTrade}
+	conditions, args, err := s.eventConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	rows, err := s.db.Query(`
+		SELECT COALESCE(NULLIF(market_slug, ''), asset_id, '') AS market, COALESCE(MAX(market_name), ''),
+			strftime('`+format+`', timestamp) AS bucket,
+			COALESCE(SUM(CAST(price AS REAL) * CAST(size AS REAL)), 0) AS volume, COUNT(*),
+			COUNT(DISTINCT wallet_address),
+			COUNT(DISTINCT CASE WHEN is_fresh_wallet = 1 THEN wallet_address END)
+		FROM polymarket_events
+		WHERE `+strings.Join(conditions, " AND ")+`
+		GROUP BY market, bucket
+		ORDER BY bucket, volume DESC
+		LIMIT ?`, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aggregates := []domain.MarketAggregate{}
+	for rows.Next() {
+		var a domain.MarketAggregate
+		var start string
+		if err := rows.Scan(&a.MarketSlug, &a.MarketName, &start, &a.Volume, &a.Trades, &a.Wallets, &a.FreshWallets); err != nil {
+			return nil, err
+		}
+		if a.BucketStart, err = time.Parse(time.DateTime, start); err != nil {
+			continue // Unparseable timestamp
+		}
+		aggregates = append(aggregates, a)
+	}
+	return aggregates, rows.Err()
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestMarketAggregatesPerBucket(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	trade := func(id, market, wallet string, fresh bool, size string, at time.Duration) domain.PolymarketEvent {
+		return domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: id, MarketSlug: market, MarketName: "Market " + market,
+			AssetID: "asset-" + market, WalletAddress: wallet, IsFreshWallet: fresh, Price: "0.5", Size: size,
+			Timestamp: day.Add(at),
+		}
+	}
+	events := []domain.PolymarketEvent{
+		trade("t1", "fed", "0xa", true, "2000", 10*time.Minute),
+		trade("t2", "fed", "0xa", true, "4000", 20*time.Minute),
+		trade("t3", "fed", "0xb", false, "1000", 30*time.Minute),
+		trade("t4", "rain", "0xc", false, "100000", 40*time.Minute),
+		trade("t5", "fed", "0xb", false, "1000", 5*time.Hour),
+		trade("t6", "fed", "0xb", false, "1000", 30*time.Hour), // The next day
+		{EventType: domain.PolymarketEventBook, AssetID: "asset-fed", Timestamp: day.Add(time.Minute)},
+	}
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		if err := store.SaveEvents(events); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		hourly, err := store.GetMarketAggregates(domain.PolymarketEventFilter{}, domain.AggregateHour)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(hourly) != 4 || hourly[0].MarketSlug != "rain" || !hourly[0].BucketStart.Equal(day) {
+			t.Fatalf("%s: hourly = %+v, want 4 buckets starting with the biggest market", name, hourly)
+		}
+		if fed := hourly[1]; fed.MarketName != "Market fed" || fed.Volume != 3500 || fed.Trades != 3 || fed.Wallets != 2 || fed.FreshWallets != 1 {
+			t.Errorf("%s: fed's first hour = %+v, want $3,500 over 3 trades by 2 wallets, 1 fresh", name, fed)
+		}
+
+		daily, err := store.GetMarketAggregates(domain.PolymarketEventFilter{MarketName: "fed", Until: day.Add(24 * time.Hour)}, domain.AggregateDay)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(daily) != 1 || daily[0].Trades != 4 || daily[0].Volume != 4000 {
+			t.Errorf("%s: daily = %+v, want fed's 4 trades on the first day", name, daily)
+		}
+
+		if _, err := store.GetMarketAggregates(domain.PolymarketEventFilter{}, "week"); err == nil {
+			t.Errorf("%s: weekly buckets succeeded, want an error", name)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// alertPriceColumns maps follow-up horizons to their price columns
+var alertPriceColumns = map[domain.AlertHorizon]string{
+	domain.AlertHorizon15m: "price_15m",
+	domain.AlertHorizon1h:  "price_1h",
+	domain.AlertHorizon24h: "price_24h",
+}
+
+const alertOutcomeColumns = `id, trade_id, asset_id, wallet_address, market_name, market_link, outcome,
+	side, entry_price, risk_score, risk_signals, alerted_at, price_15m, price_1h, price_24h, config_hash`
+
+// migrateAlertOutcomes creates the alert follow-up table in the analysis database
+func (s *PolymarketStore) migrateAlertOutcomes() error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS alert_outcomes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			trade_id TEXT,
+			asset_id TEXT NOT NULL,
+			wallet_address TEXT,
+			market_name TEXT,
+			market_link TEXT,
+			outcome TEXT,
+			side TEXT,
+			entry_price REAL NOT NULL,
+			risk_score REAL DEFAULT 0,
+			risk_signals TEXT,
+			alerted_at DATETIME NOT NULL,
+			price_15m REAL,
+			price_1h REAL,
+			price_24h REAL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_alert_outcomes_alerted_at ON alert_outcomes(alerted_at DESC)`,
+		`CREATE INDEX IF NOT EXISTS idx_alert_outcomes_pending ON alert_outcomes(alerted_at) WHERE price_24h IS NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_alert_outcomes_wallet ON alert_outcomes(wallet_address, alerted_at DESC)`,
+	}
+	for _, t := range tables {
+		if _, err := s.analysisDB.Exec(t); err != nil {
+			return fmt.Errorf("failed to create alert outcomes table: %w", err)
+		}
+	}
+	// Added with config snapshots (ignore the error if the column exists)
+	s.analysisDB.Exec(`ALTER TABLE alert_outcomes ADD COLUMN config_hash TEXT`)
+	return s.migrateConfigSnapshots()
+}
+
+// SaveAlertOutcome starts tracking an alerted trade and returns its ID
+func (s *PolymarketStore) SaveAlertOutcome(outcome domain.AlertOutcome) (int64, error) {
+	var signals any
+	if len(outcome.RiskSignals) > 0 {
+		if data, err := json.Marshal(outcome.RiskSignals); err == nil {
+			signals = string(data)
+		}
+	}
+	result, err := s.analysisDB.Exec(`
+		INSERT INTO alert_outcomes (trade_id, asset_id, wallet_address, market_name, market_link, outcome,
+			side, entry_price, risk_score, risk_signals, alerted_at, config_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		outcome.TradeID, outcome.AssetID, outcome.WalletAddress, outcome.MarketName, outcome.MarketLink,
+		outcome.Outcome, outcome.Side, outcome.EntryPrice, outcome.RiskScore, signals, outcome.AlertedAt,
+		outcome.ConfigHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// RecordAlertPrice stores the follow-up price of an alert at a horizon
+func (s *PolymarketStore) RecordAlertPrice(id int64, horizon domain.AlertHorizon, price float64) error {
+	column, ok := alertPriceColumns[horizon]
+	if !ok {
+		return fmt.Errorf("invalid alert horizon: %q", horizon)
+	}
+	_, err := s.analysisDB.Exec(`UPDATE alert_outcomes SET `+column+` = ? WHERE id = ?`, price, id)
+	return err
+}
+
+// GetPendingAlertOutcomes returns alerts since the given time that are still missing
+// a follow-up price, oldest first
+func (s *PolymarketStore) GetPendingAlertOutcomes(since time.Time, limit int) ([]domain.AlertOutcome, error) {
+	return s.queryAlertOutcomes(`
+		SELECT `+alertOutcomeColumns+` FROM alert_outcomes
+		WHERE alerted_at >= ? AND (price_15m IS NULL OR price_1h IS NULL OR price_24h IS NULL)
+		ORDER BY alerted_at LIMIT ?`, since, limit)
+}
+
+// GetAlertOutcomes returns alerts since the given time, newest first
+func (s *PolymarketStore) GetAlertOutcomes(since time.Time, limit int) ([]domain.AlertOutcome, error) {
+	return s.queryAlertOutcomes(`
+		SELECT `+alertOutcomeColumns+` FROM alert_outcomes
+		WHERE alerted_at >= ? ORDER BY alerted_at DESC LIMIT ?`, since, limit)
+}
+
+// GetLastWalletAlert returns the wallet's most recent alert, or nil if it was never alerted
+func (s *PolymarketStore) GetLastWalletAlert(wallet string) (*domain.AlertOutcome, error) {
+	outcomes, err := s.queryAlertOutcomes(`
+		SELECT `+alertOutcomeColumns+` FROM alert_outcomes
+		WHERE wallet_address = ? ORDER BY alerted_at DESC LIMIT 1`, wallet)
+	if err != nil || len(outcomes) == 0 {
+		return nil, err
+	}
+	return &outcomes[0], nil
+}
+
+// queryAlertOutcomes runs an alert outcome query and scans the rows
+func (s *PolymarketStore) queryAlertOutcomes(query string, args ...any) ([]domain.AlertOutcome, error) {
+	rows, err := s.analysisDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	outcomes := []domain.AlertOutcome{}
+	for rows.Next() {
+		var o domain.AlertOutcome
+		var tradeID, wallet, marketName, marketLink, outcome, side, signals, configHash sql.NullString
+		var riskScore sql.NullFloat64
+		prices := make([]sql.NullFloat64, len(domain.AlertHorizons))
+		if err := rows.Scan(&o.ID, &tradeID, &o.AssetID, &wallet, &marketName, &marketLink, &outcome,
+			&side, &o.EntryPrice, &riskScore, &signals, &o.AlertedAt, &prices[0], &prices[1], &prices[2], &configHash); err != nil {
+			continue
+		}
+		o.TradeID = tradeID.String
+		o.WalletAddress = wallet.String
+		o.MarketName = marketName.String
+		o.MarketLink = marketLink.String
+		o.Outcome = outcome.String
+		o.Side = domain.OrderSide(side.String)
+		o.RiskScore = riskScore.Float64
+		o.ConfigHash = configHash.String
+		if signals.Valid {
+			json.Unmarshal([]byte(signals.String), &o.RiskSignals)
+		}
+		o.Prices = make(map[domain.AlertHorizon]float64)
+		for i, h := range domain.AlertHorizons {
+			if prices[i].Valid {
+				o.Prices[h] = prices[i].Float64
+			}
+		}
+		outcomes = append(outcomes, o)
+	}
+	return outcomes, rows.Err()
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestAlertOutcomeFollowUps(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	alertedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		old, err := store.SaveAlertOutcome(domain.AlertOutcome{AssetID: "asset", EntryPrice: 0.3, AlertedAt: alertedAt.Add(-72 * time.Hour)})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		id, err := store.SaveAlertOutcome(domain.AlertOutcome{
+			TradeID: "tx", AssetID: "asset", WalletAddress: "0xa", MarketName: "Rain?", Side: domain.OrderSideSell,
+			EntryPrice: 0.6, RiskScore: 0.8, RiskSignals: []string{"🚨 Fresh Insider (0 bets)"}, AlertedAt: alertedAt,
+		})
+		if err != nil || id == old {
+			t.Fatalf("%s: id = %d, %v, want a new alert", name, id, err)
+		}
+
+		if err := store.RecordAlertPrice(id, domain.AlertHorizon15m, 0.5); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := store.RecordAlertPrice(id, "1w", 0.5); err == nil {
+			t.Errorf("%s: recorded a price at an unknown horizon", name)
+		}
+
+		pending, err := store.GetPendingAlertOutcomes(alertedAt.Add(-48*time.Hour), 10)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(pending) != 1 || pending[0].ID != id {
+			t.Fatalf("%s: pending = %+v, want only the recent alert", name, pending)
+		}
+		o := pending[0]
+		if o.TradeID != "tx" || o.Side != domain.OrderSideSell || len(o.RiskSignals) != 1 || len(o.Prices) != 1 || o.Prices[domain.AlertHorizon15m] != 0.5 {
+			t.Errorf("%s: pending alert = %+v, want the saved alert with its 15m price", name, o)
+		}
+		if move, ok := o.Move(domain.AlertHorizon15m); !ok || move < 0.099 || move > 0.101 {
+			t.Errorf("%s: move = %v, %v, want a favorable 0.1 for a sell", name, move, ok)
+		}
+
+		store.RecordAlertPrice(id, domain.AlertHorizon1h, 0.55)
+		store.RecordAlertPrice(id, domain.AlertHorizon24h, 0.7)
+		if pending, _ := store.GetPendingAlertOutcomes(alertedAt.Add(-48*time.Hour), 10); len(pending) != 0 {
+			t.Errorf("%s: pending = %+v, want none once every horizon is recorded", name, pending)
+		}
+		if all, _ := store.GetAlertOutcomes(time.Time{}, 10); len(all) != 2 || all[0].ID != id {
+			t.Errorf("%s: outcomes = %+v, want both alerts, newest first", name, all)
+		}
+	}
+}
+
+func TestWalletActivitySinceLastAlert(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	alertedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		if last, err := store.GetLastWalletAlert("0xa"); err != nil || last != nil {
+			t.Fatalf("%s: last alert = %+v, %v, want none", name, last, err)
+		}
+		for _, at := range []time.Time{alertedAt.Add(-time.Hour), alertedAt} {
+			if _, err := store.SaveAlertOutcome(domain.AlertOutcome{WalletAddress: "0xa", AssetID: "1", MarketName: at.Format("15h"), AlertedAt: at}); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		if last, err := store.GetLastWalletAlert("0xa"); err != nil || last == nil || !last.AlertedAt.Equal(alertedAt) {
+			t.Fatalf("%s: last alert = %+v, %v, want the newer one", name, last, err)
+		}
+
+		trade := func(id, market string, at time.Time) domain.PolymarketEvent {
+			return domain.PolymarketEvent{
+				EventType: domain.PolymarketEventTrade, TradeID: id, WalletAddress: "0xa", AssetID: "1",
+				MarketSlug: market, Price: "0.5", Size: "2000", Timestamp: at,
+			}
+		}
+		store.SaveEvents([]domain.PolymarketEvent{
+			trade("t1", "fed", alertedAt.Add(-time.Hour)),
+			trade("t2", "fed", alertedAt), // The alerted trade itself
+			trade("t3", "fed", alertedAt.Add(time.Hour)),
+			trade("t4", "rain", alertedAt.Add(2*time.Hour)),
+			trade("t5", "snow", alertedAt.Add(3*time.Hour)),
+			{EventType: domain.PolymarketEventTrade, TradeID: "other", WalletAddress: "0xb", AssetID: "1", Price: "0.5", Size: "9", Timestamp: alertedAt.Add(time.Hour)},
+		})
+		diff, err := store.GetWalletActivitySince("0xa", alertedAt)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if diff.Trades != 3 || diff.Volume != 3000 || diff.Markets != 3 || diff.NewMarkets != 2 {
+			t.Errorf("%s: diff = %+v, want 3 trades for $3,000 in 3 markets, 2 of them new", name, diff)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// analysisDBEnv names a separate analysis database, see PolymarketStoreOptions.AnalysisDBPath
+const analysisDBEnv = "XTOOLS_ANALYSIS_DB"
+
+// analysisTables are the tables kept in the analysis database, in an order that copies
+// investigations before their items
+var analysisTables = []string{
+	"polymarket_settings",
+	"settings_history",
+	"polymarket_wallets",
+	"polymarket_wallet_tags",
+	"polymarket_wallet_categories",
+	"wallet_intel",
+	"notified_items",
+	"notification_deliveries",
+	"sent_alerts",
+	"config_snapshots",
+	"alert_outcomes",
+	"investigations",
+	"investigation_items",
+	"investigation_notes",
+}
+
+// analysisImportVersion is the analysis database's user_version once the events
+// database's analysis tables were copied into it
+const analysisImportVersion = 1
+
+// PolymarketStoreOptions configures how the Polymarket store lays out its data
+type PolymarketStoreOptions struct {
+	// AnalysisDBPath moves wallets, settings and notified items into a separate
+	// SQLite file so wallet queries don't contend with the large events table.
+	// Empty (or the events DB path) keeps everything in one file.
+	AnalysisDBPath string
+
+	// SettingsKey encrypts settings and imported wallet intel, which hold bot tokens,
+	// webhook secrets and signing keys, so they stay protected while the database file
+	// is open and in its backups. Usually the database key; empty stores them in the clear.
+	SettingsKey string
+}
+
+// LoadAnalysisDBPath returns the separate analysis database named by XTOOLS_ANALYSIS_DB,
+// relative to dataDir unless absolute, or "" to keep one database
+func LoadAnalysisDBPath(dataDir string) string {
+	path := strings.TrimSpace(os.Getenv(analysisDBEnv))
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dataDir, path)
+}
+
+// isSplit reports whether the options request a separate analysis database
+func (o PolymarketStoreOptions) isSplit(dbPath string) bool {
+	if o.AnalysisDBPath == "" {
+		return false
+	}
+	return filepath.Clean(o.AnalysisDBPath) != filepath.Clean(dbPath)
+}
+
+// isSplit reports whether the store uses a separate analysis database
+func (s *PolymarketStore) isSplit() bool {
+	return s.analysisDB != s.db
+}
+
+// importAnalysisTables copies the analysis tables of the events database into a newly
+// split analysis database, so moving to two files keeps wallets, settings and notified
+// items. It runs once; the copies left in the events database are no longer read.
+func (s *PolymarketStore) importAnalysisTables() error {
+	ctx := context.Background()
+	conn, err := s.analysisDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var version int
+	if err := conn.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read analysis database version: %w", err)
+	}
+	if version >= analysisImportVersion {
+		return nil
+	}
+
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS events", s.dbPath); err != nil {
+		return fmt.Errorf("failed to attach events database: %w", err)
+	}
+	defer conn.ExecContext(ctx, "DETACH DATABASE events")
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var copied int64
+	for _, table := range analysisTables {
+		columns, err := sharedColumns(ctx, tx, table)
+		if err != nil {
+			return err
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		list := `"` + strings.Join(columns, `", "`) + `"`
+		res, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT OR IGNORE INTO main.%s (%s) SELECT %s FROM events.%s", table, list, list, table))
+		if err != nil {
+			return fmt.Errorf("failed to copy %s: %w", table, err)
+		}
+		n, _ := res.RowsAffected()
+		copied += n
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", analysisImportVersion)); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to copy analysis tables: %w", err)
+	}
+	if copied > 0 {
+		log.Printf("[Storage] Copied %d rows of wallets, settings and notified items into %s", copied, s.analysisPath)
+	}
+	return nil
+}
+
+// sharedColumns returns the columns a table has in both the analysis database and the
+// attached events database; tables migrated at different times order them differently.
+// None means the events database lacks the table.
+func sharedColumns(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	columnsOf := func(schema string) ([]string, error) {
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT name FROM pragma_table_info('%s', '%s')", table, schema))
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		var columns []string
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return nil, err
+			}
+			columns = append(columns, name)
+		}
+		return columns, rows.Err()
+	}
+
+	source, err := columnsOf("events")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	inSource := make(map[string]bool, len(source))
+	for _, name := range source {
+		inSource[name] = true
+	}
+	target, err := columnsOf("main")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	var shared []string
+	for _, name := range target {
+		if inSource[name] {
+			shared = append(shared, name)
+		}
+	}
+	return shared, nil
+}
+
+// migrateAnalysis creates the wallet, settings and notified items tables
+func (s *PolymarketStore) migrateAnalysis() error {
+	// Settings table for storing config and filter settings
+	settingsTable := `CREATE TABLE IF NOT EXISTS polymarket_settings (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`
+
+	// Wallets table for caching wallet profiles
+	walletsTable := `CREATE TABLE IF NOT EXISTS polymarket_wallets (
+		address TEXT PRIMARY KEY,
+		bet_count INTEGER NOT NULL DEFAULT -1,
+		join_date TEXT,
+		freshness_level TEXT,
+		is_fresh INTEGER DEFAULT 0,
+		first_seen_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		last_analyzed_at DATETIME,
+		total_trades INTEGER DEFAULT 0,
+		total_volume REAL DEFAULT 0
+	)`
+
+	walletsIndexes := []string{
+		`CREATE INDEX IF NOT EXISTS idx_wallets_bet_count ON polymarket_wallets(bet_count)`,
+		`CREATE INDEX IF NOT EXISTS idx_wallets_is_fresh ON polymarket_wallets(is_fresh) WHERE is_fresh = 1`,
+		`CREATE INDEX IF NOT EXISTS idx_wallets_last_analyzed ON polymarket_wallets(last_analyzed_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_wallets_unanalyzed ON polymarket_wallets(bet_count) WHERE bet_count = -1`,
+	}
+
+	// Add join_date column if it doesn't exist (migration)
+	walletMigrations := []string{
+		`ALTER TABLE polymarket_wallets ADD COLUMN join_date TEXT`,
+		`ALTER TABLE polymarket_wallets ADD COLUMN trader_name TEXT`,
+		`ALTER TABLE polymarket_wallets ADD COLUMN data_source TEXT`,
+	}
+
+	// Create settings table
+	if _, err := s.analysisDB.Exec(settingsTable); err != nil {
+		return fmt.Errorf("failed to create settings table: %w", err)
+	}
+
+	// Create wallets table
+	if _, err := s.analysisDB.Exec(walletsTable); err != nil {
+		return fmt.Errorf("failed to create wallets table: %w", err)
+	}
+
+	// Add wallet indexes
+	for _, idx := range walletsIndexes {
+		s.analysisDB.Exec(idx)
+	}
+
+	// Run wallet table migrations (ignore errors for existing columns)
+	for _, mig := range walletMigrations {
+		s.analysisDB.Exec(mig)
+	}
+
+	// Wallet tags, used for filtering and searching wallets
+	walletTagsTable := `CREATE TABLE IF NOT EXISTS polymarket_wallet_tags (
+		address TEXT NOT NULL,
+		tag TEXT NOT NULL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (address, tag)
+	)`
+	if _, err := s.analysisDB.Exec(walletTagsTable); err != nil {
+		return fmt.Errorf("failed to create wallet tags table: %w", err)
+	}
+	s.analysisDB.Exec(`CREATE INDEX IF NOT EXISTS idx_wallet_tags_tag ON polymarket_wallet_tags(tag)`)
+	s.analysisDB.Exec(`CREATE INDEX IF NOT EXISTS idx_wallets_first_seen ON polymarket_wallets(first_seen_at)`)
+
+	// NOCASE indexes let case-insensitive LIKE prefix searches use an index
+	s.analysisDB.Exec(`CREATE INDEX IF NOT EXISTS idx_wallets_address_nocase ON polymarket_wallets(address COLLATE NOCASE)`)
+	s.analysisDB.Exec(`CREATE INDEX IF NOT EXISTS idx_wallets_trader_name ON polymarket_wallets(trader_name COLLATE NOCASE)`)
+	s.analysisDB.Exec(`CREATE INDEX IF NOT EXISTS idx_wallet_tags_tag_nocase ON polymarket_wallet_tags(tag COLLATE NOCASE)`)
+
+	// Notified items table for tracking sent notifications
+	notifiedItemsTable := `CREATE TABLE IF NOT EXISTS notified_items (
+		item_type TEXT NOT NULL,
+		item_id TEXT NOT NULL,
+		notified_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (item_type, item_id)
+	)`
+	if _, err := s.analysisDB.Exec(notifiedItemsTable); err != nil {
+		return fmt.Errorf("failed to create notified_items table: %w", err)
+	}
+	s.analysisDB.Exec(`CREATE INDEX IF NOT EXISTS idx_notified_items_at ON notified_items(notified_at)`)
+
+	if err := s.migrateInvestigations(); err != nil {
+		return err
+	}
+	if err := s.migrateWalletIntel(); err != nil {
+		return err
+	}
+	if err := s.migrateSettingHistory(); err != nil {
+		return err
+	}
+	if err := s.migrateWalletCategories(); err != nil {
+		return err
+	}
+	if err := s.migrateNotificationDeliveries(); err != nil {
+		return err
+	}
+	if err := s.migrateSentAlerts(); err != nil {
+		return err
+	}
+	return s.migrateAlertOutcomes()
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSplitAnalysisDatabaseCopiesTablesOnce(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "xtools.db")
+
+	store, err := NewPolymarketStore(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveSetting("wallet_watchlist", []string{"0xabc"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.TagWallet("0xabc", "follow"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.MarkNotified("trade", "0xhash"); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	opts := PolymarketStoreOptions{AnalysisDBPath: filepath.Join(dir, "analysis.db")}
+	store, err = NewPolymarketStoreWithOptions(dbPath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var watchlist []string
+	if err := store.LoadSetting("wallet_watchlist", &watchlist); err != nil || len(watchlist) != 1 {
+		t.Errorf("watchlist in the analysis database = %v, %v; want it copied", watchlist, err)
+	}
+	if tags, err := store.GetWalletTags([]string{"0xabc"}); err != nil || len(tags["0xabc"]) != 1 {
+		t.Errorf("wallet tags in the analysis database = %v, %v; want follow", tags["0xabc"], err)
+	}
+	if notified, err := store.HasNotified("trade", "0xhash"); err != nil || !notified {
+		t.Errorf("notified item not copied: %v", err)
+	}
+
+	// Changes made in the analysis database survive a restart: nothing is copied again
+	if err := store.UntagWallet("0xabc", "follow"); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+	store, err = NewPolymarketStoreWithOptions(dbPath, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if tags, _ := store.GetWalletTags([]string{"0xabc"}); len(tags["0xabc"]) != 0 {
+		t.Errorf("wallet tags after restart = %v, want the untag kept", tags["0xabc"])
+	}
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// AnalysisBackupSuffix names the analysis database's copy next to a backup of a split store
+const AnalysisBackupSuffix = ".analysis"
+
+// IsSealedBackup reports whether a backup file was encrypted by SealBackup
+func IsSealedBackup(path string) bool {
+	return strings.HasSuffix(path, encryptedSuffix)
+}
+
+// AnalysisBackupPath returns where the analysis database's copy of a backup is kept,
+// for plain and sealed backups
+func AnalysisBackupPath(path string) string {
+	if IsSealedBackup(path) {
+		return strings.TrimSuffix(path, encryptedSuffix) + AnalysisBackupSuffix + encryptedSuffix
+	}
+	return path + AnalysisBackupSuffix
+}
+
+// Backup copies the databases to path with SQLite's online backup API. Writes continue
+// while it runs; the copy is a consistent snapshot. When the store is split, the
+// analysis database is copied to path + ".analysis".
+func (s *PolymarketStore) Backup(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup target %s already exists", path)
+	}
+	if err := copyDatabase(s.db, path, false); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	if s.isSplit() {
+		if err := copyDatabase(s.analysisDB, path+AnalysisBackupSuffix, false); err != nil {
+			os.Remove(path)
+			os.Remove(path + AnalysisBackupSuffix)
+			return fmt.Errorf("failed to back up analysis database: %w", err)
+		}
+	}
+	return nil
+}
+
+// Restore replaces the databases' contents with a backup made by Backup. The backup
+// is checked first; the live databases are untouched when it fails the check.
+func (s *PolymarketStore) Restore(path string) error {
+	paths := []string{path}
+	if s.isSplit() {
+		paths = append(paths, path+AnalysisBackupSuffix)
+	}
+	for _, p := range paths {
+		if _, err := os.Stat(p); err != nil {
+			return fmt.Errorf("backup not found: %w", err)
+		}
+		problem, err := quickCheck(p)
+		if err != nil {
+			return fmt.Errorf("failed to check backup %s: %w", p, err)
+		}
+		if problem != "" {
+			return fmt.Errorf("backup %s is unusable: %s", p, problem)
+		}
+	}
+	// Settings sealed with another key would be unreadable after the restore
+	if err := s.checkBackupKey(paths[len(paths)-1]); err != nil {
+		return err
+	}
+
+	if err := copyDatabase(s.db, path, true); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	if s.isSplit() {
+		if err := copyDatabase(s.analysisDB, path+AnalysisBackupSuffix, true); err != nil {
+			return fmt.Errorf("failed to restore analysis database: %w", err)
+		}
+	}
+	// Re-run migrations in case the backup predates the current schema
+	if err := s.migrate(); err != nil {
+		return err
+	}
+	if err := s.migrateAnalysis(); err != nil {
+		return err
+	}
+	// The backup has its own settings salt, and values it kept in the clear
+	s.sealer = nil
+	if err := s.initSealer(s.settingsKey); err != nil {
+		return fmt.Errorf("failed to set up the settings key of the restored database: %w", err)
+	}
+	return nil
+}
+
+// checkBackupKey verifies that the settings of the backup at path were sealed with the
+// store's key, if at all. Backups taken before a key was set pass; their values are
+// sealed once restored.
+func (s *PolymarketStore) checkBackupKey(path string) error {
+	if s.settingsKey == "" {
+		return nil
+	}
+	db, err := sql.Open("sqlite3", path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var encodedSalt, check string
+	err = db.QueryRow(`SELECT value FROM polymarket_settings WHERE key = ?`, sealingSaltKey).Scan(&encodedSalt)
+	if err == nil {
+		err = db.QueryRow(`SELECT value FROM polymarket_settings WHERE key = ?`, sealingCheckKey).Scan(&check)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the settings key check of backup %s: %w", path, err)
+	}
+
+	salt, err := hex.DecodeString(encodedSalt)
+	if err != nil {
+		return fmt.Errorf("backup %s has a malformed settings salt", path)
+	}
+	aead, err := newAEAD(s.settingsKey, salt)
+	if err != nil {
+		return err
+	}
+	if value, err := (&valueSealer{aead: aead}).open(check); err != nil || value != sealingCheckValue {
+		return fmt.Errorf("backup %s was encrypted with a different database key", path)
+	}
+	return nil
+}
+
+// copyDatabase copies the live database to the file at path, or the file into the
+// live database when restore is set, in a single backup step
+func copyDatabase(live *sql.DB, path string, restore bool) error {
+	ctx := context.Background()
+	file, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	liveConn, err := live.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer liveConn.Close()
+	fileConn, err := file.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer fileConn.Close()
+
+	src, dst := liveConn, fileConn
+	if restore {
+		src, dst = fileConn, liveConn
+	}
+	return dst.Raw(func(dstDriver any) error {
+		return src.Raw(func(srcDriver any) error {
+			dstSQLite, ok1 := dstDriver.(*sqlite3.SQLiteConn)
+			srcSQLite, ok2 := srcDriver.(*sqlite3.SQLiteConn)
+			if !ok1 || !ok2 {
+				return errors.New("backup needs SQLite connections")
+			}
+			backup, err := dstSQLite.Backup("main", srcSQLite, "main")
+			if err != nil {
+				return err
+			}
+			// One step copies everything under a single read transaction, so in WAL
+			// mode writers aren't blocked and concurrent writes can't restart it
+			if _, err := backup.Step(-1); err != nil {
+				backup.Finish()
+				return err
+			}
+			return backup.Finish()
+		})
+	})
+}
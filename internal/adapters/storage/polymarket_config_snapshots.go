@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateConfigSnapshots creates the table of config versions referenced by alerts
+func (s *PolymarketStore) migrateConfigSnapshots() error {
+	_, err := s.analysisDB.Exec(`CREATE TABLE IF NOT EXISTS config_snapshots (
+		hash TEXT PRIMARY KEY,
+		snapshot TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create config snapshots table: %w", err)
+	}
+	return nil
+}
+
+// SaveConfigSnapshot stores a config version unless one with its hash exists
+func (s *PolymarketStore) SaveConfigSnapshot(snapshot domain.ConfigSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	_, err = s.analysisDB.Exec(`INSERT OR IGNORE INTO config_snapshots (hash, snapshot, created_at) VALUES (?, ?, ?)`,
+		snapshot.Hash, string(data), snapshot.CreatedAt)
+	return err
+}
+
+// GetConfigSnapshot returns the config version with the hash, or nil if none is stored
+func (s *PolymarketStore) GetConfigSnapshot(hash string) (*domain.ConfigSnapshot, error) {
+	var data string
+	err := s.analysisDB.QueryRow(`SELECT snapshot FROM config_snapshots WHERE hash = ?`, hash).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snapshot domain.ConfigSnapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode config snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// GetConfigSnapshots returns the stored config versions, newest first
+func (s *PolymarketStore) GetConfigSnapshots(limit int) ([]domain.ConfigSnapshot, error) {
+	rows, err := s.analysisDB.Query(`SELECT snapshot FROM config_snapshots ORDER BY created_at DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := []domain.ConfigSnapshot{}
+	for rows.Next() {
+		var data string
+		var snapshot domain.ConfigSnapshot
+		if err := rows.Scan(&data); err != nil || json.Unmarshal([]byte(data), &snapshot) != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, rows.Err()
+}
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateEventArchive creates the cold storage table old events are moved to. It has
+// the columns of polymarket_events; archived events keep their IDs, so their tags stay
+// in event_tags.
+func (s *PolymarketStore) migrateEventArchive() error {
+	migrations := []string{
+		`CREATE TABLE IF NOT EXISTS polymarket_events_archive (
+			id INTEGER PRIMARY KEY,
+			event_type TEXT NOT NULL,
+			asset_id TEXT,
+			market_slug TEXT,
+			market_name TEXT,
+			market_image TEXT,
+			market_link TEXT,
+			timestamp DATETIME NOT NULL,
+			raw_data TEXT,
+			price TEXT,
+			size TEXT,
+			side TEXT,
+			best_bid TEXT,
+			best_ask TEXT,
+			fee_rate_bps INTEGER,
+			trade_id TEXT,
+			wallet_address TEXT,
+			outcome TEXT,
+			outcome_index INTEGER,
+			event_slug TEXT,
+			event_title TEXT,
+			trader_name TEXT,
+			condition_id TEXT,
+			is_fresh_wallet INTEGER DEFAULT 0,
+			wallet_nonce INTEGER,
+			risk_score REAL DEFAULT 0,
+			risk_signals TEXT,
+			fresh_wallet_signal TEXT,
+			archived_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_polymarket_archive_timestamp ON polymarket_events_archive(timestamp DESC)`,
+	}
+	for _, m := range migrations {
+		if _, err := s.db.Exec(m); err != nil {
+			return fmt.Errorf("failed to create event archive: %w", err)
+		}
+	}
+	return nil
+}
+
+// ArchiveEvents moves events older than before into polymarket_events_archive, in
+// batches, skipping events added to an investigation. Returns how many events were moved.
+func (s *PolymarketStore) ArchiveEvents(before time.Time) (int64, error) {
+	if before.IsZero() {
+		return 0, nil
+	}
+	protected, err := s.investigatedEventIDs()
+	if err != nil {
+		return 0, err
+	}
+	query := fmt.Sprintf("SELECT id FROM polymarket_events WHERE id > ? AND timestamp < ? ORDER BY id LIMIT %d", pruneBatchSize)
+
+	var archived, after int64
+	for {
+		ids, err := queryIDs(s.db, query, after, before)
+		if err != nil {
+			return archived, fmt.Errorf("failed to select events to archive: %w", err)
+		}
+		if len(ids) == 0 {
+			return archived, nil
+		}
+		after = ids[len(ids)-1]
+
+		var batch []string
+		for _, id := range ids {
+			if !protected[id] {
+				batch = append(batch, strconv.FormatInt(id, 10))
+			}
+		}
+		if len(batch) > 0 {
+			n, err := s.moveToArchive(batch)
+			archived += n
+			if err != nil {
+				return archived, err
+			}
+		}
+		if len(ids) < pruneBatchSize {
+			return archived, nil
+		}
+	}
+}
+
+// moveToArchive copies events to the archive and deletes them in one transaction
+func (s *PolymarketStore) moveToArchive(ids []string) (int64, error) {
+	list := strings.Join(ids, ",")
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO polymarket_events_archive (` + eventColumns + `)
+		SELECT ` + eventColumns + ` FROM polymarket_events WHERE id IN (` + list + `)`); err != nil {
+		return 0, fmt.Errorf("failed to archive events: %w", err)
+	}
+	result, err := tx.Exec("DELETE FROM polymarket_events WHERE id IN (" + list + ")")
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove archived events: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// GetArchivedEvents retrieves archived events with the filtering of GetEvents
+func (s *PolymarketStore) GetArchivedEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	conditions, args, err := s.eventConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + eventColumns + ` FROM polymarket_events_archive`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += eventOrderBy(filter)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query += fmt.Sprintf(" LIMIT %d", limit)
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
+	return s.queryEvents(query, args...)
+}
+
+// archivedEventCount returns how many events are in the archive
+func (s *PolymarketStore) archivedEventCount() (int64, error) {
+	var count int64
+	err := s.db.QueryRow("SELECT COUNT(*) FROM polymarket_events_archive").Scan(&count)
+	return count, err
+}
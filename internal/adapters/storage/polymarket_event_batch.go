@@ -0,0 +1,22 @@
+package storage
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// SaveEvents saves events in one transaction: either all are stored or none are
+func (s *PolymarketStore) SaveEvents(events []domain.PolymarketEvent) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return storeError("save events", err)
+	}
+	defer tx.Rollback()
+
+	insert, tag := tx.Stmt(s.stmts.insertEvent), tx.Stmt(s.stmts.tagEvent)
+	defer insert.Close()
+	defer tag.Close()
+	for _, event := range events {
+		if err := insertEvent(insert, tag, event); err != nil {
+			return err
+		}
+	}
+	return storeError("save events", tx.Commit())
+}
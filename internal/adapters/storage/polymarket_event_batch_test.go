@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestSaveEventsStoresBatchWithTags(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		trade := func(id string, tags ...string) domain.PolymarketEvent {
+			return domain.PolymarketEvent{
+				EventType: domain.PolymarketEventTrade, TradeID: id, WalletAddress: "0xa", AssetID: "111",
+				Price: "0.5", Size: "100", Tags: tags,
+			}
+		}
+		// The redelivered trade is skipped as a single save would skip it
+		batch := []domain.PolymarketEvent{trade("t1", "Insider"), trade("t2", " "), trade("t1", "Insider"), {EventType: domain.PolymarketEventBook, AssetID: "111"}}
+		if err := store.SaveEvents(batch); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if count, _ := store.GetEventCount(domain.PolymarketEventFilter{}); count != 3 {
+			t.Errorf("%s: stored %d events, want 2 trades and the book", name, count)
+		}
+		tagged, err := store.GetEvents(domain.PolymarketEventFilter{Tag: "insider", Limit: 10})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(tagged) != 1 || tagged[0].TradeID != "t1" {
+			t.Errorf("%s: tagged events = %+v, want the first trade", name, tagged)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// exportPageSize is how many events are read per query while exporting
+const exportPageSize = 1000
+
+// eventCSVHeader is the header row of CSV event exports
+var eventCSVHeader = []string{
+	"id", "timestamp", "type", "market_slug", "market_name", "event_slug", "event_title",
+	"wallet", "trader", "side", "outcome", "price", "size", "notional", "risk_score",
+	"fresh_wallet", "tags", "trade_id", "market_link",
+}
+
+// ExportEvents writes the events matching filter to w, newest stored first. A filter
+// limit of 0 exports every match; events are read in pages so memory stays flat.
+func (s *PolymarketStore) ExportEvents(filter domain.PolymarketEventFilter, format domain.EventExportFormat, w io.Writer) (int, error) {
+	enc, err := newEventEncoder(format, w)
+	if err != nil {
+		return 0, err
+	}
+	conditions, args, err := s.eventConditions(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	written, offset, lastID := 0, filter.Offset, int64(0)
+	for filter.Limit <= 0 || written < filter.Limit {
+		page := exportPageSize
+		if filter.Limit > 0 && filter.Limit-written < page {
+			page = filter.Limit - written
+		}
+		where, pageArgs := conditions, args
+		if lastID > 0 {
+			where = append(append([]string{}, conditions...), "id < ?")
+			pageArgs = append(append([]any{}, args...), lastID)
+		}
+		query := `SELECT ` + eventColumns + ` FROM polymarket_events`
+		if len(where) > 0 {
+			query += " WHERE " + strings.Join(where, " AND ")
+		}
+		query += fmt.Sprintf(" ORDER BY id DESC LIMIT %d OFFSET %d", page, offset)
+		offset = 0 // Later pages continue from lastID
+
+		events, err := s.queryEvents(query, pageArgs...)
+		if err != nil {
+			return written, fmt.Errorf("failed to read events: %w", err)
+		}
+		for _, e := range events {
+			if err := enc.encode(e); err != nil {
+				return written, fmt.Errorf("failed to write event: %w", err)
+			}
+			written++
+			lastID = e.ID
+		}
+		if len(events) < page {
+			break
+		}
+	}
+	return written, enc.flush()
+}
+
+// ExportEvents writes the events matching filter to w, newest stored first. A filter
+// limit of 0 exports every match.
+func (s *MemoryPolymarketStore) ExportEvents(filter domain.PolymarketEventFilter, format domain.EventExportFormat, w io.Writer) (int, error) {
+	enc, err := newEventEncoder(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.RLock()
+	var events []domain.PolymarketEvent
+	for _, e := range s.events {
+		if s.eventMatches(e, filter) {
+			events = append(events, e)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(events, func(i, j int) bool { return events[i].ID > events[j].ID })
+	if filter.Offset >= len(events) {
+		events = nil
+	} else {
+		events = events[filter.Offset:]
+	}
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	for i, e := range events {
+		if err := enc.encode(e); err != nil {
+			return i, fmt.Errorf("failed to write event: %w", err)
+		}
+	}
+	return len(events), enc.flush()
+}
+
+// eventEncoder writes events in one export format
+type eventEncoder struct {
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+func newEventEncoder(format domain.EventExportFormat, w io.Writer) (*eventEncoder, error) {
+	switch format {
+	case domain.EventExportCSV:
+		enc := &eventEncoder{csv: csv.NewWriter(w)}
+		return enc, enc.csv.Write(eventCSVHeader)
+	case domain.EventExportJSONL:
+		return &eventEncoder{json: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("unsupported export format: %q", format)
+}
+
+func (e *eventEncoder) encode(event domain.PolymarketEvent) error {
+	if e.json != nil {
+		return e.json.Encode(event)
+	}
+	price, _ := strconv.ParseFloat(event.Price, 64)
+	size, _ := strconv.ParseFloat(event.Size, 64)
+	return e.csv.Write([]string{
+		strconv.FormatInt(event.ID, 10),
+		event.Timestamp.UTC().Format(time.RFC3339),
+		string(event.EventType),
+		event.MarketSlug,
+		event.MarketName,
+		event.EventSlug,
+		event.EventTitle,
+		event.WalletAddress,
+		event.TraderName,
+		string(event.Side),
+		event.Outcome,
+		event.Price,
+		event.Size,
+		strconv.FormatFloat(price*size, 'f', 2, 64),
+		strconv.FormatFloat(event.RiskScore, 'f', 2, 64),
+		strconv.FormatBool(event.IsFreshWallet),
+		strings.Join(event.Tags, ";"),
+		event.TradeID,
+		event.MarketLink,
+	})
+}
+
+func (e *eventEncoder) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	return nil
+}
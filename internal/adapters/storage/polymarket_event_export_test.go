@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestExportEventsPagesThroughMatches(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	// More trades than one export page, every other one too small for the filter
+	total := exportPageSize + 10
+	var events []domain.PolymarketEvent
+	for i := 0; i < total; i++ {
+		size := "100"
+		if i%2 == 1 {
+			size = "10000"
+		}
+		events = append(events, domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("t%d", i), WalletAddress: "0xa",
+			AssetID: "111", MarketName: "Rain, tomorrow?", Price: "0.5", Size: size, Tags: []string{"a", "b"},
+		})
+	}
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		if err := store.SaveEvents(events); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		var buf bytes.Buffer
+		n, err := store.ExportEvents(domain.PolymarketEventFilter{MinSize: 1000}, domain.EventExportCSV, &buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if n != total/2 || len(records) != total/2+1 || records[0][0] != "id" {
+			t.Fatalf("%s: exported %d events in %d records, want %d and a header", name, n, len(records), total/2)
+		}
+		if row := records[1]; row[4] != "Rain, tomorrow?" || row[13] != "5000.00" || row[16] != "a;b" || row[17] != fmt.Sprintf("t%d", total-1) {
+			t.Errorf("%s: first row = %v, want the newest large trade", name, row)
+		}
+
+		buf.Reset()
+		n, err = store.ExportEvents(domain.PolymarketEventFilter{Limit: 2, Offset: 1}, domain.EventExportJSONL, &buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		var second domain.PolymarketEvent
+		if n != 2 || len(lines) != 2 || json.Unmarshal([]byte(lines[1]), &second) != nil || second.TradeID != fmt.Sprintf("t%d", total-3) {
+			t.Errorf("%s: exported %d events as %q, want 2 JSON lines after skipping the newest", name, n, lines)
+		}
+
+		if _, err := store.ExportEvents(domain.PolymarketEventFilter{}, "xlsx", &buf); err == nil {
+			t.Errorf("%s: exporting xlsx succeeded, want an error", name)
+		}
+	}
+}
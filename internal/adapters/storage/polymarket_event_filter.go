@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// eventConditions returns the WHERE conditions and arguments selecting the events
+// matching a filter, ignoring its limit and offset. A wallet tag is looked up in the
+// analysis database, which event queries can't join.
+func (s *PolymarketStore) eventConditions(filter domain.PolymarketEventFilter) ([]string, []any, error) {
+	var conditions []string
+	var args []any
+
+	if len(filter.EventTypes) > 0 {
+		placeholders := make([]string, len(filter.EventTypes))
+		for i, et := range filter.EventTypes {
+			placeholders[i] = "?"
+			args = append(args, et)
+		}
+		conditions = append(conditions, fmt.Sprintf("event_type IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	if filter.MarketName != "" {
+		conditions = append(conditions, "(market_name LIKE ? OR event_title LIKE ?)")
+		args = append(args, "%"+filter.MarketName+"%", "%"+filter.MarketName+"%")
+	}
+
+	if filter.MinPrice > 0 {
+		conditions = append(conditions, "CAST(price AS REAL) >= ?")
+		args = append(args, filter.MinPrice)
+	}
+
+	if filter.MaxPrice > 0 {
+		conditions = append(conditions, "CAST(price AS REAL) <= ?")
+		args = append(args, filter.MaxPrice)
+	}
+
+	if filter.Side != "" {
+		conditions = append(conditions, "side = ?")
+		args = append(args, filter.Side)
+	}
+
+	if filter.Outcome != "" {
+		conditions = append(conditions, "outcome = ? COLLATE NOCASE")
+		args = append(args, filter.Outcome)
+	}
+
+	if filter.OutcomeIndex != nil {
+		// Only trades carry an outcome; other events store index 0
+		conditions = append(conditions, "event_type = ? AND outcome_index = ?")
+		args = append(args, domain.PolymarketEventTrade, *filter.OutcomeIndex)
+	}
+
+	if filter.MinSize > 0 {
+		// Filter by notional value (price * size) instead of just size
+		conditions = append(conditions, "(CAST(price AS REAL) * CAST(size AS REAL)) >= ?")
+		args = append(args, filter.MinSize)
+	}
+
+	if filter.FreshWalletsOnly {
+		conditions = append(conditions, "is_fresh_wallet = 1")
+	}
+
+	if filter.MinRiskScore > 0 {
+		conditions = append(conditions, "risk_score >= ?")
+		args = append(args, filter.MinRiskScore)
+	}
+
+	if filter.MaxWalletNonce > 0 {
+		conditions = append(conditions, "wallet_nonce IS NOT NULL AND wallet_nonce <= ?")
+		args = append(args, filter.MaxWalletNonce)
+	}
+
+	if filter.Tag != "" {
+		conditions = append(conditions, "id IN (SELECT event_id FROM event_tags WHERE tag = ?)")
+		args = append(args, domain.NormalizeTag(filter.Tag))
+	}
+
+	if filter.Entity != "" {
+		conditions = append(conditions, "market_slug IN (SELECT market_slug FROM market_entities WHERE name = ?)")
+		args = append(args, domain.CanonicalEntity(filter.Entity))
+	}
+
+	if wallets := filter.Wallets(); len(wallets) > 0 {
+		conditions = append(conditions, "wallet_address IN "+sqlList(len(wallets)))
+		args = appendStrings(args, wallets)
+	}
+
+	if filter.WalletTag != "" {
+		tagged, err := s.taggedWallets(filter.WalletTag)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(tagged) == 0 {
+			conditions = append(conditions, "1 = 0") // No wallet carries the tag
+		} else {
+			conditions = append(conditions, "wallet_address COLLATE NOCASE IN "+sqlList(len(tagged)))
+			args = appendStrings(args, tagged)
+		}
+	}
+
+	if len(filter.MarketSlugs) > 0 || len(filter.ConditionIDs) > 0 {
+		var markets []string
+		if len(filter.MarketSlugs) > 0 {
+			list := sqlList(len(filter.MarketSlugs))
+			markets = append(markets, "market_slug IN "+list, "event_slug IN "+list)
+			args = appendStrings(appendStrings(args, filter.MarketSlugs), filter.MarketSlugs)
+		}
+		if len(filter.ConditionIDs) > 0 {
+			markets = append(markets, "condition_id IN "+sqlList(len(filter.ConditionIDs)))
+			args = appendStrings(args, filter.ConditionIDs)
+		}
+		conditions = append(conditions, "("+strings.Join(markets, " OR ")+")")
+	}
+
+	for _, name := range filter.ExcludeMarketNames {
+		if name != "" {
+			conditions = append(conditions, "NOT (COALESCE(market_name, '') LIKE ? OR COALESCE(event_title, '') LIKE ?)")
+			args = append(args, "%"+name+"%", "%"+name+"%")
+		}
+	}
+
+	if len(filter.ExcludeWallets) > 0 {
+		wallets := make([]string, len(filter.ExcludeWallets))
+		for i, wallet := range filter.ExcludeWallets {
+			wallets[i] = strings.ToLower(wallet)
+		}
+		conditions = append(conditions, "(wallet_address IS NULL OR LOWER(wallet_address) NOT IN "+sqlList(len(wallets))+")")
+		args = appendStrings(args, wallets)
+	}
+
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, filter.Since)
+	}
+
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, filter.Until)
+	}
+	return conditions, args, nil
+}
+
+// sqlList returns a parenthesized list of n placeholders for an IN condition
+func sqlList(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?,", n), ",") + ")"
+}
+
+// appendStrings appends values to query arguments
+func appendStrings(args []any, values []string) []any {
+	for _, v := range values {
+		args = append(args, v)
+	}
+	return args
+}
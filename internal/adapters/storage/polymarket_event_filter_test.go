@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestGetEventsByWallet(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	var events []domain.PolymarketEvent
+	for _, trade := range []struct{ id, wallet string }{{"a1", "0xa"}, {"a2", "0xa"}, {"b1", "0xb"}, {"c1", "0xc"}} {
+		events = append(events, domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: trade.id, WalletAddress: trade.wallet, AssetID: "1", Price: "0.5", Size: "10",
+		})
+	}
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		if err := store.SaveEvents(events); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, tc := range []struct {
+			filter domain.PolymarketEventFilter
+			want   string
+		}{
+			{domain.PolymarketEventFilter{WalletAddress: "0xa"}, "a1,a2"},
+			{domain.PolymarketEventFilter{WalletAddresses: []string{"0xb", "0xc"}}, "b1,c1"},
+			{domain.PolymarketEventFilter{WalletAddress: "0xa", WalletAddresses: []string{"0xc"}}, "a1,a2,c1"},
+			{domain.PolymarketEventFilter{WalletAddress: "0xd"}, ""},
+			{domain.PolymarketEventFilter{}, "a1,a2,b1,c1"},
+		} {
+			tc.filter.Limit = 10
+			got, err := store.GetEvents(tc.filter)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			var ids []string
+			for _, e := range got {
+				ids = append(ids, e.TradeID)
+			}
+			sort.Strings(ids)
+			if strings.Join(ids, ",") != tc.want {
+				t.Errorf("%s: %+v matched %v, want %s", name, tc.filter, ids, tc.want)
+			}
+			if count, _ := store.GetEventCount(tc.filter); count != int64(len(got)) {
+				t.Errorf("%s: %+v counted %d, want %d", name, tc.filter, count, len(got))
+			}
+		}
+	}
+}
+
+func TestGetEventsByWalletTag(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	var events []domain.PolymarketEvent
+	for _, trade := range []struct{ id, wallet string }{{"a1", "0xA"}, {"a2", "0xa"}, {"b1", "0xb"}, {"c1", "0xc"}} {
+		events = append(events, domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: trade.id, WalletAddress: trade.wallet, AssetID: "1", Price: "0.5", Size: "10",
+		})
+	}
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		if err := store.SaveEvents(events); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		store.TagWallet("0xA", "MM bot")
+		store.TagWallet("0xb", "mm-bot")
+		store.TagWallet("0xc", "follow")
+		store.UntagWallet("0xc", "follow")
+
+		for _, tc := range []struct {
+			filter domain.PolymarketEventFilter
+			want   string
+		}{
+			{domain.PolymarketEventFilter{WalletTag: "mm bot"}, "a1,a2,b1"},
+			{domain.PolymarketEventFilter{WalletTag: "MM-Bot", WalletAddress: "0xb"}, "b1"},
+			{domain.PolymarketEventFilter{WalletTag: "follow"}, ""},
+			{domain.PolymarketEventFilter{WalletTag: "insider?"}, ""},
+		} {
+			tc.filter.Limit = 10
+			got, err := store.GetEvents(tc.filter)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			var ids []string
+			for _, e := range got {
+				ids = append(ids, e.TradeID)
+			}
+			sort.Strings(ids)
+			if strings.Join(ids, ",") != tc.want {
+				t.Errorf("%s: %+v matched %v, want %s", name, tc.filter, ids, tc.want)
+			}
+			if count, _ := store.GetEventCount(tc.filter); count != int64(len(got)) {
+				t.Errorf("%s: %+v counted %d, want %d", name, tc.filter, count, len(got))
+			}
+		}
+	}
+}
+
+func TestGetEventsExcludingMarketsAndWallets(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	var events []domain.PolymarketEvent
+	for _, trade := range []struct{ id, wallet, market, title string }{
+		{"btc", "0xa", "Bitcoin up or down?", "Crypto 15m"},
+		{"eth", "0xB", "Ethereum above 4k?", "Crypto 15m"},
+		{"fed", "0xc", "Fed cuts in March?", "Fed decision"},
+		{"nba", "", "Lakers win?", "NBA"},
+	} {
+		events = append(events, domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: trade.id, WalletAddress: trade.wallet, AssetID: "1",
+			MarketName: trade.market, EventTitle: trade.title, Price: "0.5", Size: "10",
+		})
+	}
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		if err := store.SaveEvents(events); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, tc := range []struct {
+			filter domain.PolymarketEventFilter
+			want   string
+		}{
+			{domain.PolymarketEventFilter{ExcludeMarketNames: []string{"bitcoin"}}, "eth,fed,nba"},
+			{domain.PolymarketEventFilter{ExcludeMarketNames: []string{"CRYPTO 15M"}}, "fed,nba"},
+			{domain.PolymarketEventFilter{ExcludeMarketNames: []string{"crypto", "lakers"}}, "fed"},
+			{domain.PolymarketEventFilter{ExcludeWallets: []string{"0xb", "0xC"}}, "btc,nba"},
+			{domain.PolymarketEventFilter{ExcludeWallets: []string{"0xa"}, ExcludeMarketNames: []string{"fed"}}, "eth,nba"},
+			{domain.PolymarketEventFilter{MarketName: "crypto", ExcludeMarketNames: []string{"ethereum"}}, "btc"},
+			{domain.PolymarketEventFilter{ExcludeMarketNames: []string{""}, ExcludeWallets: []string{}}, "btc,eth,fed,nba"},
+		} {
+			tc.filter.Limit = 10
+			got, err := store.GetEvents(tc.filter)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			var ids []string
+			for _, e := range got {
+				ids = append(ids, e.TradeID)
+			}
+			sort.Strings(ids)
+			if strings.Join(ids, ",") != tc.want {
+				t.Errorf("%s: %+v matched %v, want %s", name, tc.filter, ids, tc.want)
+			}
+			if count, _ := store.GetEventCount(tc.filter); count != int64(len(got)) {
+				t.Errorf("%s: %+v counted %d, want %d", name, tc.filter, count, len(got))
+			}
+		}
+	}
+}
+
+func TestGetEventPageTotalsTheWholeFilter(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	var events []domain.PolymarketEvent
+	for _, trade := range []struct {
+		id, size string
+		fresh    bool
+	}{{"a", "100", true}, {"b", "200", false}, {"c", "300", true}, {"d", "4", true}} {
+		events = append(events, domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: trade.id, AssetID: "1", Price: "0.5", Size: trade.size, IsFreshWallet: trade.fresh,
+		})
+	}
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		if err := store.SaveEvents(events); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		page, err := store.GetEventPage(domain.PolymarketEventFilter{MinSize: 10, Limit: 2, Offset: 1, SortBy: domain.EventSortNotional, SortDir: domain.SortDesc})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if page.Total != 3 || page.TotalNotional != 300 || page.FreshWalletCount != 2 {
+			t.Errorf("%s: totals = %d events, $%v, %d fresh, want 3 events, $300, 2 fresh", name, page.Total, page.TotalNotional, page.FreshWalletCount)
+		}
+		if len(page.Events) != 2 || page.Events[0].TradeID != "b" || page.Events[1].TradeID != "a" {
+			t.Errorf("%s: page = %+v, want b then a", name, page.Events)
+		}
+
+		if page, err := store.GetEventPage(domain.PolymarketEventFilter{WalletAddress: "0xnone"}); err != nil || page.Total != 0 || len(page.Events) != 0 {
+			t.Errorf("%s: empty page = %+v, %v", name, page, err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetEventPage returns a page of events with the number matching the filter, their
+// total notional and how many are by fresh wallets. All are read in one transaction,
+// so events stored in between can't make the totals disagree with the page.
+func (s *PolymarketStore) GetEventPage(filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error) {
+	conditions, args, err := s.eventConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query := `SELECT ` + eventColumns + ` FROM polymarket_events` + where + eventOrderBy(filter) +
+		fmt.Sprintf(" LIMIT %d", limit)
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
+
+	tx, err := s.db.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var page domain.PolymarketEventPage
+	if err := tx.QueryRow(`SELECT COUNT(*),
+			COALESCE(SUM(CAST(price AS REAL) * CAST(size AS REAL)), 0),
+			COALESCE(SUM(is_fresh_wallet), 0)
+		FROM polymarket_events`+where, args...).Scan(&page.Total, &page.TotalNotional, &page.FreshWalletCount); err != nil {
+		return nil, fmt.Errorf("failed to count events: %w", err)
+	}
+	if page.Events, err = queryEventsOn(tx, query, args...); err != nil {
+		return nil, err
+	}
+	return &page, tx.Commit()
+}
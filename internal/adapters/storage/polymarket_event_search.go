@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// eventSearchTable is the full-text index over event market names and titles, kept
+// in sync with polymarket_events by triggers. Its triggers share the name as prefix.
+const eventSearchTable = "polymarket_events_fts"
+
+// eventSearchModules are the full-text modules tried in order. FTS5 needs the
+// sqlite_fts5 build tag; FTS4 is always compiled in and takes the same queries.
+var eventSearchModules = []string{
+	`fts5(market_name, event_title, tokenize = 'unicode61 remove_diacritics 2')`,
+	`fts4(market_name, event_title, tokenize=unicode61 "remove_diacritics=2")`,
+}
+
+// migrateEventSearch creates the full-text index, filling it from the stored events
+// the first time, and the triggers keeping it in sync
+func (s *PolymarketStore) migrateEventSearch() error {
+	var exists int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = ?`, eventSearchTable).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check event search index: %w", err)
+	}
+	if exists == 0 {
+		if err := s.createEventSearch(); err != nil {
+			return err
+		}
+	}
+
+	triggers := []string{
+		`CREATE TRIGGER IF NOT EXISTS ` + eventSearchTable + `_insert AFTER INSERT ON polymarket_events BEGIN
+			INSERT INTO ` + eventSearchTable + `(rowid, market_name, event_title) VALUES (new.id, new.market_name, new.event_title);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS ` + eventSearchTable + `_delete AFTER DELETE ON polymarket_events BEGIN
+			DELETE FROM ` + eventSearchTable + ` WHERE rowid = old.id;
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS ` + eventSearchTable + `_update AFTER UPDATE OF market_name, event_title ON polymarket_events BEGIN
+			UPDATE ` + eventSearchTable + ` SET market_name = new.market_name, event_title = new.event_title WHERE rowid = old.id;
+		END`,
+	}
+	for _, t := range triggers {
+		if _, err := s.db.Exec(t); err != nil {
+			return fmt.Errorf("failed to create event search trigger: %w", err)
+		}
+	}
+	return nil
+}
+
+// createEventSearch creates the index with the best available module and indexes the
+// stored events in the same transaction
+func (s *PolymarketStore) createEventSearch() error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, module := range eventSearchModules {
+		if _, err = tx.Exec(`CREATE VIRTUAL TABLE ` + eventSearchTable + ` USING ` + module); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create event search index: %w", err)
+	}
+	result, err := tx.Exec(`INSERT INTO ` + eventSearchTable + `(rowid, market_name, event_title)
+		SELECT id, market_name, event_title FROM polymarket_events`)
+	if err != nil {
+		return fmt.Errorf("failed to index stored events: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		log.Printf("[Storage] Indexed %d stored events for search", n)
+	}
+	return tx.Commit()
+}
+
+// SearchEvents finds events whose market name or event title contains every word of
+// the query, in any order, as a word or word prefix. Newest first.
+func (s *PolymarketStore) SearchEvents(query string, limit int) ([]domain.PolymarketEvent, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return []domain.PolymarketEvent{}, nil
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	match := make([]string, len(terms))
+	for i, t := range terms {
+		match[i] = t + "*"
+	}
+	return s.queryEvents(`SELECT `+eventColumns+` FROM polymarket_events
+		WHERE id IN (SELECT rowid FROM `+eventSearchTable+` WHERE `+eventSearchTable+` MATCH ?)
+		ORDER BY id DESC LIMIT ?`, strings.Join(match, " "), limit)
+}
+
+// searchTerms splits a query into lowercase words, dropping punctuation so user input
+// can't form full-text query syntax
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// matchesSearchTerms reports whether every term is a prefix of a word in any of the texts
+func matchesSearchTerms(terms []string, texts ...string) bool {
+	words := searchTerms(strings.Join(texts, " "))
+	for _, t := range terms {
+		found := false
+		for _, w := range words {
+			if strings.HasPrefix(w, t) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
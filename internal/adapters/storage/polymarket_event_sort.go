@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// eventSortExprs maps sort fields to the SQL expressions events are ordered by
+var eventSortExprs = map[domain.EventSortField]string{
+	domain.EventSortTimestamp: "timestamp",
+	domain.EventSortNotional:  "(CAST(price AS REAL) * CAST(size AS REAL))",
+	domain.EventSortRiskScore: "risk_score",
+	domain.EventSortBetCount:  "wallet_nonce",
+}
+
+// eventOrderBy returns the ORDER BY clause of a filter's sort. Ties go to the newest
+// event, and events without a bet count sort last in either direction.
+func eventOrderBy(filter domain.PolymarketEventFilter) string {
+	expr, ok := eventSortExprs[filter.SortBy]
+	if !ok {
+		expr = eventSortExprs[domain.EventSortTimestamp]
+	}
+	direction := "DESC"
+	if filter.SortDir == domain.SortAsc {
+		direction = "ASC"
+	}
+	order := fmt.Sprintf(" ORDER BY %s %s, timestamp DESC, id DESC", expr, direction)
+	if filter.SortBy == domain.EventSortBetCount {
+		order = " ORDER BY wallet_nonce IS NULL," + order[len(" ORDER BY"):]
+	}
+	return order
+}
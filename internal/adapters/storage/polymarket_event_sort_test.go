@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestGetEventsSortOrders(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	trade := func(id, size string, risk float64, bets int, at time.Duration) domain.PolymarketEvent {
+		e := domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: id, AssetID: "1", Price: "0.5", Size: size,
+			RiskScore: risk, Timestamp: start.Add(at),
+		}
+		if bets >= 0 {
+			e.WalletProfile = &domain.WalletProfile{BetCount: bets}
+		}
+		return e
+	}
+	events := []domain.PolymarketEvent{
+		trade("a", "100", 0.9, 40, 0),
+		trade("b", "5000", 0.2, -1, time.Minute), // Wallet never analyzed
+		trade("c", "900", 0.5, 2, 2*time.Minute),
+		trade("d", "900", 0.1, 7, 3*time.Minute),
+	}
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		if err := store.SaveEvents(events); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for _, tc := range []struct {
+			sortBy domain.EventSortField
+			dir    domain.SortDirection
+			want   string
+		}{
+			{"", "", "dcba"},
+			{domain.EventSortTimestamp, domain.SortAsc, "abcd"},
+			{domain.EventSortNotional, "", "bdca"}, // Ties go to the newest
+			{domain.EventSortNotional, domain.SortAsc, "adcb"},
+			{domain.EventSortRiskScore, "", "acbd"},
+			{domain.EventSortBetCount, "", "adcb"}, // Unknown bet counts last either way
+			{domain.EventSortBetCount, domain.SortAsc, "cdab"},
+			{"unknown", "", "dcba"},
+		} {
+			got, err := store.GetEvents(domain.PolymarketEventFilter{Limit: 10, SortBy: tc.sortBy, SortDir: tc.dir})
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			var ids strings.Builder
+			for _, e := range got {
+				ids.WriteString(e.TradeID)
+			}
+			if ids.String() != tc.want {
+				t.Errorf("%s: sorted by %q %q = %s, want %s", name, tc.sortBy, tc.dir, ids.String(), tc.want)
+			}
+		}
+	}
+}
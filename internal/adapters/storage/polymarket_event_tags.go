@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// TagEvent attaches a label to a stored event; tagging twice is a no-op
+func (s *PolymarketStore) TagEvent(eventID int64, tag string) error {
+	tag = domain.NormalizeTag(tag)
+	if tag == "" {
+		return fmt.Errorf("tag is required")
+	}
+	_, err := s.db.Exec(`INSERT OR IGNORE INTO event_tags (event_id, tag) VALUES (?, ?)`, eventID, tag)
+	return err
+}
+
+// UntagEvent removes a label from a stored event
+func (s *PolymarketStore) UntagEvent(eventID int64, tag string) error {
+	_, err := s.db.Exec(`DELETE FROM event_tags WHERE event_id = ? AND tag = ?`, eventID, domain.NormalizeTag(tag))
+	return err
+}
+
+// GetEventTags returns every tag in use with its event count, most used first
+func (s *PolymarketStore) GetEventTags() ([]domain.EventTagCount, error) {
+	rows, err := s.db.Query(`SELECT tag, COUNT(*) AS cnt FROM event_tags GROUP BY tag ORDER BY cnt DESC, tag`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []domain.EventTagCount{}
+	for rows.Next() {
+		var t domain.EventTagCount
+		if err := rows.Scan(&t.Tag, &t.Count); err != nil {
+			continue
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// attachEventTags loads the tags of the given events in one query
+func attachEventTags(q rowQuerier, events []domain.PolymarketEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(events))
+	placeholders := make([]string, len(events))
+	args := make([]any, len(events))
+	for i, e := range events {
+		index[e.ID] = i
+		placeholders[i] = "?"
+		args[i] = e.ID
+	}
+
+	rows, err := q.Query(`SELECT event_id, tag FROM event_tags
+		WHERE event_id IN (`+strings.Join(placeholders, ",")+`) ORDER BY tag`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventID int64
+		var tag string
+		if err := rows.Scan(&eventID, &tag); err != nil {
+			continue
+		}
+		if i, ok := index[eventID]; ok {
+			events[i].Tags = append(events[i].Tags, tag)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,270 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveEvent saves a Polymarket event to the database. A trade whose trade ID is already
+// stored is skipped.
+func (s *PolymarketStore) SaveEvent(event domain.PolymarketEvent) error {
+	return insertEvent(s.stmts.insertEvent, s.stmts.tagEvent, event)
+}
+
+// insertEvent inserts an event and its tags with the prepared statements, skipping
+// trades already stored
+func insertEvent(insert, tag *sql.Stmt, event domain.PolymarketEvent) error {
+	// Serialize risk signals and fresh wallet signal
+	var riskSignalsJSON, freshWalletSignalJSON string
+	if len(event.RiskSignals) > 0 {
+		if data, err := json.Marshal(event.RiskSignals); err == nil {
+			riskSignalsJSON = string(data)
+		}
+	}
+	if event.FreshWalletSignal != nil {
+		if data, err := json.Marshal(event.FreshWalletSignal); err == nil {
+			freshWalletSignalJSON = string(data)
+		}
+	}
+
+	var walletNonce *int
+	if event.WalletProfile != nil {
+		// Store betCount (use BetCount if available, fall back to Nonce for backward compatibility)
+		if event.WalletProfile.BetCount > 0 {
+			walletNonce = &event.WalletProfile.BetCount
+		} else {
+			walletNonce = &event.WalletProfile.Nonce
+		}
+	}
+
+	result, err := insert.Exec(
+		event.EventType, event.AssetID, event.MarketSlug, event.MarketName,
+		event.MarketImage, event.MarketLink, event.Timestamp, encodeRawData(event.RawData),
+		event.Price, event.Size, event.Side, event.BestBid, event.BestAsk, event.FeeRateBps,
+		event.TradeID, event.WalletAddress, event.Outcome, event.OutcomeIndex,
+		event.EventSlug, event.EventTitle, event.TraderName, event.ConditionID,
+		event.IsFreshWallet, walletNonce, event.RiskScore,
+		riskSignalsJSON, freshWalletSignalJSON,
+	)
+	if err != nil || len(event.Tags) == 0 {
+		return storeError("save event", err)
+	}
+	if inserted, err := result.RowsAffected(); err != nil || inserted == 0 {
+		return storeError("save event", err) // A duplicate trade; LastInsertId isn't its ID
+	}
+
+	eventID, err := result.LastInsertId()
+	if err != nil {
+		return storeError("save event", err)
+	}
+	for _, name := range event.Tags {
+		if name = domain.NormalizeTag(name); name == "" {
+			continue
+		}
+		if _, err := tag.Exec(eventID, name); err != nil {
+			return storeError("tag event", err)
+		}
+	}
+	return nil
+}
+
+// GetEvents retrieves events with optional filtering
+func (s *PolymarketStore) GetEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	conditions, args, err := s.eventConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + eventColumns + ` FROM polymarket_events`
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += eventOrderBy(filter)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query += fmt.Sprintf(" LIMIT %d", limit)
+
+	if filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+	}
+
+	return s.queryEvents(query, args...)
+}
+
+// eventColumns is the column list scanned by queryEvents
+const eventColumns = `id, event_type, asset_id, market_slug, market_name, market_image, market_link,
+		timestamp, raw_data, price, size, side, best_bid, best_ask, fee_rate_bps,
+		trade_id, wallet_address, outcome, outcome_index, event_slug, event_title,
+		trader_name, condition_id, is_fresh_wallet, wallet_nonce, risk_score,
+		risk_signals, fresh_wallet_signal`
+
+// rowQuerier runs queries on the database or in a transaction
+type rowQuerier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// queryEvents runs an event query selecting eventColumns and attaches event tags
+func (s *PolymarketStore) queryEvents(query string, args ...any) ([]domain.PolymarketEvent, error) {
+	return queryEventsOn(s.db, query, args...)
+}
+
+// queryEventsOn is queryEvents on the database or in a transaction
+func queryEventsOn(q rowQuerier, query string, args ...any) ([]domain.PolymarketEvent, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.PolymarketEvent
+	for rows.Next() {
+		var e domain.PolymarketEvent
+		var assetID, marketSlug, marketName, marketImage, marketLink sql.NullString
+		var rawData, price, size, side, bestBid, bestAsk sql.NullString
+		var feeRateBps sql.NullInt64
+		var tradeID, walletAddress, outcome, eventSlug, eventTitle, traderName, conditionID sql.NullString
+		var outcomeIndex sql.NullInt64
+		var isFreshWallet sql.NullBool
+		var walletNonce sql.NullInt64
+		var riskScore sql.NullFloat64
+		var riskSignals, freshWalletSignal sql.NullString
+
+		if err := rows.Scan(
+			&e.ID, &e.EventType, &assetID, &marketSlug, &marketName,
+			&marketImage, &marketLink, &e.Timestamp, &rawData,
+			&price, &size, &side, &bestBid, &bestAsk, &feeRateBps,
+			&tradeID, &walletAddress, &outcome, &outcomeIndex, &eventSlug, &eventTitle,
+			&traderName, &conditionID, &isFreshWallet, &walletNonce, &riskScore,
+			&riskSignals, &freshWalletSignal,
+		); err != nil {
+			continue
+		}
+
+		e.AssetID = assetID.String
+		e.MarketSlug = marketSlug.String
+		e.MarketName = marketName.String
+		e.MarketImage = marketImage.String
+		e.MarketLink = marketLink.String
+		e.RawData = decodeRawData(rawData.String)
+		e.Price = price.String
+		e.Size = size.String
+		e.Side = domain.OrderSide(side.String)
+		e.BestBid = bestBid.String
+		e.BestAsk = bestAsk.String
+		e.FeeRateBps = int(feeRateBps.Int64)
+		e.TradeID = tradeID.String
+		e.WalletAddress = walletAddress.String
+		e.Outcome = outcome.String
+		e.OutcomeIndex = int(outcomeIndex.Int64)
+		e.EventSlug = eventSlug.String
+		e.EventTitle = eventTitle.String
+		e.TraderName = traderName.String
+		e.ConditionID = conditionID.String
+		e.IsFreshWallet = isFreshWallet.Bool
+		e.RiskScore = riskScore.Float64
+
+		// Parse risk signals
+		if riskSignals.String != "" {
+			json.Unmarshal([]byte(riskSignals.String), &e.RiskSignals)
+		}
+
+		// Parse fresh wallet signal
+		if freshWalletSignal.String != "" {
+			var signal domain.FreshWalletSignal
+			if json.Unmarshal([]byte(freshWalletSignal.String), &signal) == nil {
+				e.FreshWalletSignal = &signal
+			}
+		}
+
+		// Reconstruct wallet profile if we have data
+		if walletNonce.Valid {
+			e.WalletProfile = &domain.WalletProfile{
+				Address:  walletAddress.String,
+				BetCount: int(walletNonce.Int64),
+				Nonce:    int(walletNonce.Int64), // Backward compatibility
+				IsFresh:  isFreshWallet.Bool,
+			}
+		}
+
+		events = append(events, e)
+	}
+
+	if err := attachEventTags(q, events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetEventsByIDs retrieves the given events, newest first; unknown IDs are skipped
+func (s *PolymarketStore) GetEventsByIDs(ids []int64) ([]domain.PolymarketEvent, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	return s.queryEvents(`SELECT `+eventColumns+` FROM polymarket_events
+		WHERE id IN (`+strings.Join(placeholders, ",")+`) ORDER BY timestamp DESC`, args...)
+}
+
+// GetEventCount returns how many events match a filter, ignoring its limit and offset
+func (s *PolymarketStore) GetEventCount(filter domain.PolymarketEventFilter) (int64, error) {
+	conditions, args, err := s.eventConditions(filter)
+	if err != nil {
+		return 0, err
+	}
+	query := "SELECT COUNT(*) FROM polymarket_events"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	var count int64
+	err = s.db.QueryRow(query, args...).Scan(&count)
+	return count, err
+}
+
+// GetFreshWalletCount returns count of fresh wallet events
+func (s *PolymarketStore) GetFreshWalletCount() (int64, error) {
+	var count int64
+	err := s.db.QueryRow("SELECT COUNT(*) FROM polymarket_events WHERE is_fresh_wallet = 1").Scan(&count)
+	return count, err
+}
+
+// ClearEvents removes all Polymarket events and wallets
+func (s *PolymarketStore) ClearEvents() error {
+	// Clear events
+	if _, err := s.db.Exec("DELETE FROM polymarket_events"); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec("DELETE FROM event_tags"); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec("DELETE FROM polymarket_events_archive"); err != nil {
+		return err
+	}
+	// Clear wallets
+	if _, err := s.analysisDB.Exec("DELETE FROM polymarket_wallets"); err != nil {
+		return err
+	}
+	if _, err := s.analysisDB.Exec("DELETE FROM polymarket_wallet_categories"); err != nil {
+		return err
+	}
+	// Vacuum to reclaim space
+	if s.isSplit() {
+		if _, err := s.analysisDB.Exec("VACUUM"); err != nil {
+			return err
+		}
+	}
+	_, err := s.db.Exec("VACUUM")
+	return err
+}
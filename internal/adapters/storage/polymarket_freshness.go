@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// RecomputeFreshness re-evaluates freshness for all analyzed wallets and the
+// fresh wallet flag of events that carry a bet count, using the given classifier.
+// Wallets and events are each updated in a single transaction so readers never
+// see a half-updated table.
+func (s *PolymarketStore) RecomputeFreshness(classify domain.FreshnessClassifier) (*domain.FreshnessRecomputeResult, error) {
+	start := time.Now()
+	result := &domain.FreshnessRecomputeResult{}
+
+	if err := s.recomputeWalletFreshness(classify, result); err != nil {
+		return nil, err
+	}
+	if err := s.recomputeEventFreshness(classify, result); err != nil {
+		return nil, err
+	}
+
+	result.CompletedAt = time.Now()
+	result.DurationMs = result.CompletedAt.Sub(start).Milliseconds()
+	return result, nil
+}
+
+// recomputeWalletFreshness updates freshness_level and is_fresh of analyzed wallets
+func (s *PolymarketStore) recomputeWalletFreshness(classify domain.FreshnessClassifier, result *domain.FreshnessRecomputeResult) error {
+	tx, err := s.analysisDB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	type walletRow struct {
+		address  string
+		betCount int
+		level    string
+		isFresh  bool
+	}
+
+	// Collect rows first - SQLite transactions use a single connection
+	rows, err := tx.Query(`
+		SELECT address, bet_count, COALESCE(freshness_level, ''), is_fresh
+		FROM polymarket_wallets WHERE bet_count >= 0`)
+	if err != nil {
+		return fmt.Errorf("failed to query wallets: %w", err)
+	}
+	var wallets []walletRow
+	for rows.Next() {
+		var w walletRow
+		if err := rows.Scan(&w.address, &w.betCount, &w.level, &w.isFresh); err != nil {
+			continue
+		}
+		wallets = append(wallets, w)
+	}
+	rows.Close()
+
+	for _, w := range wallets {
+		result.WalletsScanned++
+		level := classify(w.betCount)
+		isFresh := level != domain.FreshnessNone
+		if isFresh {
+			result.FreshWallets++
+		}
+		if string(level) == w.level && isFresh == w.isFresh {
+			continue
+		}
+		if _, err := tx.Exec(`UPDATE polymarket_wallets SET freshness_level = ?, is_fresh = ? WHERE address = ?`,
+			string(level), isFresh, w.address); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
+		result.WalletsChanged++
+		if string(level) != w.level {
+			result.Changes = append(result.Changes, domain.WalletFreshnessChange{
+				Address: w.address, PreviousLevel: domain.FreshnessLevel(w.level), Level: level,
+			})
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit wallet freshness: %w", err)
+	}
+	return nil
+}
+
+// recomputeEventFreshness updates is_fresh_wallet of events that carry a bet count
+func (s *PolymarketStore) recomputeEventFreshness(classify domain.FreshnessClassifier, result *domain.FreshnessRecomputeResult) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Events store the bet count seen at trade time in wallet_nonce
+	nonceRows, err := tx.Query(`SELECT DISTINCT wallet_nonce FROM polymarket_events WHERE wallet_nonce IS NOT NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to query event bet counts: %w", err)
+	}
+	var nonces []int
+	for nonceRows.Next() {
+		var n int
+		if err := nonceRows.Scan(&n); err != nil {
+			continue
+		}
+		nonces = append(nonces, n)
+	}
+	nonceRows.Close()
+
+	for _, n := range nonces {
+		isFresh := classify(n) != domain.FreshnessNone
+		res, err := tx.Exec(`UPDATE polymarket_events SET is_fresh_wallet = ? WHERE wallet_nonce = ? AND is_fresh_wallet != ?`,
+			isFresh, n, isFresh)
+		if err != nil {
+			return fmt.Errorf("failed to update events: %w", err)
+		}
+		affected, _ := res.RowsAffected()
+		result.EventsUpdated += affected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit event freshness: %w", err)
+	}
+	return nil
+}
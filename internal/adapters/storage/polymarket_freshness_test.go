@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestRecomputeFreshnessUpdatesWalletsAndEvents(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	// Fresh means fewer than 10 bets
+	classify := func(bets int) domain.FreshnessLevel {
+		if bets < 10 {
+			return domain.FreshnessNewbie
+		}
+		return domain.FreshnessNone
+	}
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		// Classified when fresh meant fewer than 5 bets
+		for _, w := range []domain.WalletProfile{
+			{Address: "0xa", BetCount: 7, FreshnessLevel: domain.FreshnessNone},
+			{Address: "0xb", BetCount: 2, FreshnessLevel: domain.FreshnessNewbie, IsFresh: true},
+			{Address: "0xc", BetCount: 40, FreshnessLevel: domain.FreshnessNone},
+		} {
+			if err := store.SaveWallet(w); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			event := domain.PolymarketEvent{
+				EventType: domain.PolymarketEventTrade, TradeID: "tx-" + w.Address, WalletAddress: w.Address,
+				Price: "0.5", Size: "100", WalletProfile: &w, IsFreshWallet: w.IsFresh,
+			}
+			if err := store.SaveEvent(event); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+
+		result, err := store.RecomputeFreshness(classify)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if result.WalletsScanned != 3 || result.WalletsChanged != 1 || result.FreshWallets != 2 || result.EventsUpdated != 1 {
+			t.Errorf("%s: result = %+v, want 3 scanned, 1 changed, 2 fresh and 1 event updated", name, result)
+		}
+		if len(result.Changes) != 1 || result.Changes[0] != (domain.WalletFreshnessChange{Address: "0xa", PreviousLevel: domain.FreshnessNone, Level: domain.FreshnessNewbie}) {
+			t.Errorf("%s: changes = %+v, want 0xa to become a newbie", name, result.Changes)
+		}
+		if w, _ := store.GetWallet("0xa"); w == nil || !w.IsFresh || w.FreshnessLevel != domain.FreshnessNewbie {
+			t.Errorf("%s: wallet = %+v, want it stored as a fresh newbie", name, w)
+		}
+		if count, _ := store.GetEventCount(domain.PolymarketEventFilter{FreshWalletsOnly: true}); count != 2 {
+			t.Errorf("%s: %d fresh wallet events, want 2", name, count)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateInvestigations creates the investigation tables in the analysis database
+func (s *PolymarketStore) migrateInvestigations() error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS investigations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			description TEXT,
+			status TEXT NOT NULL DEFAULT 'open',
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS investigation_items (
+			investigation_id INTEGER NOT NULL,
+			item_type TEXT NOT NULL,
+			ref TEXT NOT NULL,
+			added_at DATETIME NOT NULL,
+			PRIMARY KEY (investigation_id, item_type, ref)
+		)`,
+		`CREATE TABLE IF NOT EXISTS investigation_notes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			investigation_id INTEGER NOT NULL,
+			body TEXT NOT NULL,
+			created_at DATETIME NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_investigation_items_ref ON investigation_items(item_type, ref)`,
+		`CREATE INDEX IF NOT EXISTS idx_investigation_notes_case ON investigation_notes(investigation_id)`,
+	}
+	for _, t := range tables {
+		if _, err := s.analysisDB.Exec(t); err != nil {
+			return fmt.Errorf("failed to create investigation tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateInvestigation stores a new investigation and returns it with its ID
+func (s *PolymarketStore) CreateInvestigation(inv domain.Investigation) (*domain.Investigation, error) {
+	now := time.Now()
+	result, err := s.analysisDB.Exec(`
+		INSERT INTO investigations (name, description, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)`, inv.Name, inv.Description, inv.Status, now, now)
+	if err != nil {
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return s.GetInvestigation(id)
+}
+
+// UpdateInvestigation updates the name, description and status of an investigation
+func (s *PolymarketStore) UpdateInvestigation(inv domain.Investigation) error {
+	result, err := s.analysisDB.Exec(`
+		UPDATE investigations SET name = ?, description = ?, status = ?, updated_at = ?
+		WHERE id = ?`, inv.Name, inv.Description, inv.Status, time.Now(), inv.ID)
+	if err != nil {
+		return err
+	}
+	return requireInvestigation(result)
+}
+
+// GetInvestigation retrieves an investigation with its items and notes
+func (s *PolymarketStore) GetInvestigation(id int64) (*domain.Investigation, error) {
+	var inv domain.Investigation
+	var description sql.NullString
+	err := s.analysisDB.QueryRow(`
+		SELECT id, name, description, status, created_at, updated_at
+		FROM investigations WHERE id = ?`, id).
+		Scan(&inv.ID, &inv.Name, &description, &inv.Status, &inv.CreatedAt, &inv.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrInvestigationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	inv.Description = description.String
+
+	if err := s.loadInvestigationDetails(&inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// ListInvestigations returns investigations, most recently updated first.
+// An empty status returns all of them.
+func (s *PolymarketStore) ListInvestigations(status domain.InvestigationStatus) ([]domain.Investigation, error) {
+	query := `SELECT id, name, description, status, created_at, updated_at FROM investigations`
+	var args []any
+	if status != "" {
+		query += ` WHERE status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY updated_at DESC`
+
+	rows, err := s.analysisDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	investigations := []domain.Investigation{}
+	for rows.Next() {
+		var inv domain.Investigation
+		var description sql.NullString
+		if err := rows.Scan(&inv.ID, &inv.Name, &description, &inv.Status, &inv.CreatedAt, &inv.UpdatedAt); err != nil {
+			continue
+		}
+		inv.Description = description.String
+		investigations = append(investigations, inv)
+	}
+	rows.Close()
+
+	for i := range investigations {
+		if err := s.loadInvestigationDetails(&investigations[i]); err != nil {
+			return nil, err
+		}
+	}
+	return investigations, nil
+}
+
+// DeleteInvestigation removes an investigation with its items and notes
+func (s *PolymarketStore) DeleteInvestigation(id int64) error {
+	tx, err := s.analysisDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM investigations WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if err := requireInvestigation(result); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM investigation_items WHERE investigation_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM investigation_notes WHERE investigation_id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddInvestigationItem attaches a wallet, market or event; adding it twice is a no-op
+func (s *PolymarketStore) AddInvestigationItem(id int64, item domain.InvestigationItem) error {
+	if err := s.touchInvestigation(id); err != nil {
+		return err
+	}
+	_, err := s.analysisDB.Exec(`
+		INSERT OR IGNORE INTO investigation_items (investigation_id, item_type, ref, added_at)
+		VALUES (?, ?, ?, ?)`, id, item.Type, item.Ref, time.Now())
+	return err
+}
+
+// RemoveInvestigationItem detaches a wallet, market or event
+func (s *PolymarketStore) RemoveInvestigationItem(id int64, itemType domain.InvestigationItemType, ref string) error {
+	if err := s.touchInvestigation(id); err != nil {
+		return err
+	}
+	_, err := s.analysisDB.Exec(`
+		DELETE FROM investigation_items WHERE investigation_id = ? AND item_type = ? AND ref = ?`,
+		id, itemType, ref)
+	return err
+}
+
+// AddInvestigationNote appends a note to an investigation
+func (s *PolymarketStore) AddInvestigationNote(id int64, body string) (*domain.InvestigationNote, error) {
+	if err := s.touchInvestigation(id); err != nil {
+		return nil, err
+	}
+	note := domain.InvestigationNote{Body: body, CreatedAt: time.Now()}
+	result, err := s.analysisDB.Exec(`
+		INSERT INTO investigation_notes (investigation_id, body, created_at) VALUES (?, ?, ?)`,
+		id, note.Body, note.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if note.ID, err = result.LastInsertId(); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// DeleteInvestigationNote removes a note from an investigation
+func (s *PolymarketStore) DeleteInvestigationNote(id, noteID int64) error {
+	if err := s.touchInvestigation(id); err != nil {
+		return err
+	}
+	_, err := s.analysisDB.Exec(`DELETE FROM investigation_notes WHERE investigation_id = ? AND id = ?`, id, noteID)
+	return err
+}
+
+// touchInvestigation bumps updated_at, returning ErrInvestigationNotFound for unknown IDs
+func (s *PolymarketStore) touchInvestigation(id int64) error {
+	result, err := s.analysisDB.Exec(`UPDATE investigations SET updated_at = ? WHERE id = ?`, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	return requireInvestigation(result)
+}
+
+// loadInvestigationDetails fills in the items and notes of an investigation
+func (s *PolymarketStore) loadInvestigationDetails(inv *domain.Investigation) error {
+	inv.Items = []domain.InvestigationItem{}
+	inv.Notes = []domain.InvestigationNote{}
+
+	rows, err := s.analysisDB.Query(`
+		SELECT item_type, ref, added_at FROM investigation_items
+		WHERE investigation_id = ? ORDER BY added_at`, inv.ID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var item domain.InvestigationItem
+		if err := rows.Scan(&item.Type, &item.Ref, &item.AddedAt); err != nil {
+			continue
+		}
+		inv.Items = append(inv.Items, item)
+	}
+	rows.Close()
+
+	rows, err = s.analysisDB.Query(`
+		SELECT id, body, created_at FROM investigation_notes
+		WHERE investigation_id = ? ORDER BY created_at, id`, inv.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var note domain.InvestigationNote
+		if err := rows.Scan(&note.ID, &note.Body, &note.CreatedAt); err != nil {
+			continue
+		}
+		inv.Notes = append(inv.Notes, note)
+	}
+	return rows.Err()
+}
+
+// requireInvestigation maps an update that matched no investigation to ErrInvestigationNotFound
+func requireInvestigation(result sql.Result) error {
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return domain.ErrInvestigationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// maxIntegrityProblems bounds how many integrity_check rows are reported
+const maxIntegrityProblems = 10
+
+// CheckpointWAL copies the write-ahead log into the database and truncates the -wal file
+func (s *PolymarketStore) CheckpointWAL() error {
+	for _, db := range s.databases() {
+		var busy, logFrames, checkpointed int
+		if err := db.QueryRow("PRAGMA wal_checkpoint(TRUNCATE)").Scan(&busy, &logFrames, &checkpointed); err != nil {
+			return fmt.Errorf("failed to checkpoint WAL: %w", err)
+		}
+		if busy != 0 {
+			return fmt.Errorf("WAL checkpoint blocked by active readers (%d of %d frames copied)", checkpointed, logFrames)
+		}
+	}
+	return nil
+}
+
+// CheckIntegrity runs integrity_check and returns "ok" or the problems found
+func (s *PolymarketStore) CheckIntegrity() (string, error) {
+	var problems []string
+	for _, db := range s.databases() {
+		rows, err := db.Query(fmt.Sprintf("PRAGMA integrity_check(%d)", maxIntegrityProblems))
+		if err != nil {
+			return "", fmt.Errorf("failed to check integrity: %w", err)
+		}
+		for rows.Next() {
+			var line string
+			if err := rows.Scan(&line); err != nil {
+				rows.Close()
+				return "", err
+			}
+			if line != "ok" {
+				problems = append(problems, line)
+			}
+		}
+		rows.Close()
+	}
+	if len(problems) == 0 {
+		return "ok", nil
+	}
+	return strings.Join(problems, "; "), nil
+}
+
+// Optimize refreshes query planner statistics and rebuilds the databases to reclaim
+// unused pages, then truncates the WAL the rebuild wrote into
+func (s *PolymarketStore) Optimize() error {
+	for _, db := range s.databases() {
+		if _, err := db.Exec("PRAGMA optimize"); err != nil {
+			return fmt.Errorf("failed to optimize: %w", err)
+		}
+		if _, err := db.Exec("VACUUM"); err != nil {
+			return fmt.Errorf("failed to vacuum: %w", err)
+		}
+	}
+	return s.CheckpointWAL()
+}
+
+// addHealthInfo fills WAL size, page statistics and connection pragmas into database info
+func (s *PolymarketStore) addHealthInfo(info *domain.DatabaseInfo) error {
+	info.WALSizeBytes = s.WALSize()
+
+	pragmas := []struct {
+		name string
+		dest *int64
+	}{
+		{"page_size", &info.PageSize},
+		{"page_count", &info.PageCount},
+		{"freelist_count", &info.FreelistCount},
+		{"busy_timeout", &info.BusyTimeoutMs},
+	}
+	for _, p := range pragmas {
+		if err := s.db.QueryRow("PRAGMA " + p.name).Scan(p.dest); err != nil {
+			return fmt.Errorf("failed to read %s: %w", p.name, err)
+		}
+	}
+	if info.PageCount > 0 {
+		info.Fragmentation = float64(info.FreelistCount) / float64(info.PageCount)
+	}
+
+	var synchronous int
+	if err := s.db.QueryRow("PRAGMA synchronous").Scan(&synchronous); err != nil {
+		return fmt.Errorf("failed to read synchronous: %w", err)
+	}
+	if modes := []string{"OFF", "NORMAL", "FULL", "EXTRA"}; synchronous >= 0 && synchronous < len(modes) {
+		info.Synchronous = modes[synchronous]
+	}
+	return nil
+}
+
+// databases returns the events database and, when split, the analysis database
+func (s *PolymarketStore) databases() []*sql.DB {
+	if s.isSplit() {
+		return []*sql.DB{s.db, s.analysisDB}
+	}
+	return []*sql.DB{s.db}
+}
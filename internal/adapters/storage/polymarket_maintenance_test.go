@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestCheckpointAndOptimizeReclaimSpace(t *testing.T) {
+	store, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	old := time.Now().Add(-48 * time.Hour)
+	var events []domain.PolymarketEvent
+	for i := range 500 {
+		events = append(events, domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("tx-%d", i), WalletAddress: "0xa",
+			Price: "0.5", Size: "100", MarketName: strings.Repeat("m", 500), Timestamp: old,
+		})
+	}
+	if err := store.SaveEvents(events); err != nil {
+		t.Fatal(err)
+	}
+	if store.WALSize() == 0 {
+		t.Fatal("writes did not go through the WAL")
+	}
+	if err := store.CheckpointWAL(); err != nil {
+		t.Fatal(err)
+	}
+	if size := store.WALSize(); size != 0 {
+		t.Errorf("WAL is %d bytes after a checkpoint, want it truncated", size)
+	}
+
+	if _, err := store.PruneEvents(time.Now(), 0, false); err != nil {
+		t.Fatal(err)
+	}
+	info, err := store.GetDatabaseInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.PageCount == 0 || info.PageSize == 0 || info.FreelistCount == 0 || info.Fragmentation <= 0 {
+		t.Errorf("info = %+v, want free pages after pruning", info)
+	}
+
+	if err := store.Optimize(); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := store.GetDatabaseInfo()
+	if after.FreelistCount != 0 || after.PageCount >= info.PageCount || after.WALSizeBytes != 0 {
+		t.Errorf("after optimize = %+v, want fewer pages, no free pages and an empty WAL", after)
+	}
+	if status, err := store.CheckIntegrity(); err != nil || status != "ok" {
+		t.Errorf("CheckIntegrity() = %q, %v, want ok", status, err)
+	}
+}
+
+func TestSetPragmasAppliesToNewConnections(t *testing.T) {
+	store, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	pragmas := func() (busyTimeout, synchronous int) {
+		t.Helper()
+		if err := store.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.db.QueryRow("PRAGMA synchronous").Scan(&synchronous); err != nil {
+			t.Fatal(err)
+		}
+		return busyTimeout, synchronous
+	}
+	if busyTimeout, synchronous := pragmas(); busyTimeout != defaultBusyTimeoutMs || synchronous != 1 {
+		t.Errorf("defaults = busy_timeout %d, synchronous %d, want %d and NORMAL (1)", busyTimeout, synchronous, defaultBusyTimeoutMs)
+	}
+
+	if err := store.SetPragmas(250, " full "); err != nil {
+		t.Fatal(err)
+	}
+	if busyTimeout, synchronous := pragmas(); busyTimeout != 250 || synchronous != 2 {
+		t.Errorf("after SetPragmas = busy_timeout %d, synchronous %d, want 250 and FULL (2)", busyTimeout, synchronous)
+	}
+
+	if err := store.SetPragmas(0, "fast"); err == nil {
+		t.Error("accepted an unknown synchronous mode")
+	}
+	if err := store.SetPragmas(0, ""); err != nil {
+		t.Fatal(err)
+	}
+	if busyTimeout, synchronous := pragmas(); busyTimeout != defaultBusyTimeoutMs || synchronous != 1 {
+		t.Errorf("after reset = busy_timeout %d, synchronous %d, want the defaults", busyTimeout, synchronous)
+	}
+}
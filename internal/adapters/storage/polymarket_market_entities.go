@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateMarketEntities creates the table of entities found in market titles. It lives
+// next to the events so event queries can filter by entity.
+func (s *PolymarketStore) migrateMarketEntities() error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS market_entities (
+			market_slug TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			name TEXT NOT NULL COLLATE NOCASE,
+			market_name TEXT NOT NULL DEFAULT '',
+			tagged_at DATETIME NOT NULL,
+			PRIMARY KEY (market_slug, entity_type, name)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_market_entities_name ON market_entities(name, market_slug)`,
+	}
+	for _, t := range tables {
+		if _, err := s.db.Exec(t); err != nil {
+			return fmt.Errorf("failed to create market entities table: %w", err)
+		}
+	}
+	return nil
+}
+
+// SaveMarketEntities replaces the entities stored for a market
+func (s *PolymarketStore) SaveMarketEntities(slug, marketName string, entities []domain.MarketEntity) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM market_entities WHERE market_slug = ?`, slug); err != nil {
+		return storeError("save market entities", err)
+	}
+	now := time.Now().UTC()
+	for _, e := range entities {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO market_entities (market_slug, entity_type, name, market_name, tagged_at)
+			VALUES (?, ?, ?, ?, ?)`, slug, e.Type, e.Name, marketName, now); err != nil {
+			return storeError("save market entities", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetMarketEntities returns the entities stored for a market
+func (s *PolymarketStore) GetMarketEntities(slug string) ([]domain.MarketEntity, error) {
+	rows, err := s.db.Query(`SELECT entity_type, name FROM market_entities WHERE market_slug = ? ORDER BY entity_type, name`, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entities := []domain.MarketEntity{}
+	for rows.Next() {
+		var e domain.MarketEntity
+		if err := rows.Scan(&e.Type, &e.Name); err != nil {
+			return nil, err
+		}
+		entities = append(entities, e)
+	}
+	return entities, rows.Err()
+}
+
+// GetEntityMarkets returns the markets whose titles mention an entity, most recently
+// tagged first
+func (s *PolymarketStore) GetEntityMarkets(name string, limit int) ([]domain.EntityMarket, error) {
+	rows, err := s.db.Query(`
+		SELECT DISTINCT market_slug, market_name, tagged_at FROM market_entities
+		WHERE name = ? ORDER BY tagged_at DESC, market_slug LIMIT ?`, name, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	markets := []domain.EntityMarket{}
+	for rows.Next() {
+		var m domain.EntityMarket
+		if err := rows.Scan(&m.MarketSlug, &m.MarketName, &m.TaggedAt); err != nil {
+			return nil, err
+		}
+		markets = append(markets, m)
+	}
+	return markets, rows.Err()
+}
+
+// GetEntityCounts returns the entities of the stored markets, mentioned by the most
+// markets first
+func (s *PolymarketStore) GetEntityCounts(limit int) ([]domain.MarketEntityCount, error) {
+	rows, err := s.db.Query(`
+		SELECT entity_type, name, COUNT(*) FROM market_entities
+		GROUP BY entity_type, name ORDER BY 3 DESC, name LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []domain.MarketEntityCount{}
+	for rows.Next() {
+		var c domain.MarketEntityCount
+		if err := rows.Scan(&c.Type, &c.Name, &c.Markets); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// GetStoredMarketTitles returns the name and event title of every market with stored
+// events
+func (s *PolymarketStore) GetStoredMarketTitles() ([]domain.MarketTitle, error) {
+	rows, err := s.db.Query(`
+		SELECT market_slug, MAX(COALESCE(market_name, '')), MAX(COALESCE(event_title, '')) FROM polymarket_events
+		WHERE market_slug IS NOT NULL AND market_slug != '' GROUP BY market_slug`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	titles := []domain.MarketTitle{}
+	for rows.Next() {
+		var t domain.MarketTitle
+		if err := rows.Scan(&t.MarketSlug, &t.MarketName, &t.EventTitle); err != nil {
+			return nil, err
+		}
+		titles = append(titles, t)
+	}
+	return titles, rows.Err()
+}
+
+// GetEntityTradeFlows sums the trades between since and until on the markets mentioning
+// each entity, per entity, market and wallet
+func (s *PolymarketStore) GetEntityTradeFlows(since, until time.Time) ([]domain.EntityTradeFlow, error) {
+	rows, err := s.db.Query(`
+		SELECT m.entity_type, m.name, e.market_slug, COALESCE(e.wallet_address, ''),
+			COALESCE(SUM(CAST(e.price AS REAL) * CAST(e.size AS REAL)), 0), COUNT(*), MAX(COALESCE(e.is_fresh_wallet, 0))
+		FROM market_entities m JOIN polymarket_events e ON e.market_slug = m.market_slug
+		WHERE e.event_type = ? AND e.timestamp >= ? AND e.timestamp < ?
+		GROUP BY m.entity_type, m.name, e.market_slug, e.wallet_address`,
+		domain.PolymarketEventTrade, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	flows := []domain.EntityTradeFlow{}
+	for rows.Next() {
+		var f domain.EntityTradeFlow
+		var fresh int
+		if err := rows.Scan(&f.Entity.Type, &f.Entity.Name, &f.MarketSlug, &f.Wallet, &f.Volume, &f.Trades, &fresh); err != nil {
+			return nil, err
+		}
+		f.Fresh = fresh == 1
+		flows = append(flows, f)
+	}
+	return flows, rows.Err()
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestMarketEntitiesTagAndFilterMarkets(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	nvidia := domain.MarketEntity{Type: domain.MarketEntityCompany, Name: "Nvidia"}
+	nvda := domain.MarketEntity{Type: domain.MarketEntityTicker, Name: "NVDA"}
+	venezuela := domain.MarketEntity{Type: domain.MarketEntityCountry, Name: "Venezuela"}
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		store.SaveMarketEntities("nvda-earnings", "Nvidia beats earnings?", []domain.MarketEntity{nvidia, nvda})
+		store.SaveMarketEntities("nvda-5t", "NVDA above $5T?", []domain.MarketEntity{nvda})
+		store.SaveMarketEntities("maduro", "Maduro out?", []domain.MarketEntity{nvda})
+		store.SaveMarketEntities("maduro", "Maduro out?", []domain.MarketEntity{venezuela}) // Replaces the mistaken tag
+		store.SaveEvents([]domain.PolymarketEvent{
+			{EventType: domain.PolymarketEventTrade, TradeID: "a", AssetID: "1", MarketSlug: "nvda-earnings", Price: "0.5", Size: "10"},
+			{EventType: domain.PolymarketEventTrade, TradeID: "b", AssetID: "1", MarketSlug: "nvda-5t", Price: "0.5", Size: "10"},
+			{EventType: domain.PolymarketEventTrade, TradeID: "c", AssetID: "1", MarketSlug: "maduro", Price: "0.5", Size: "10"},
+		})
+
+		if entities, err := store.GetMarketEntities("maduro"); err != nil || len(entities) != 1 || entities[0] != venezuela {
+			t.Errorf("%s: entities of maduro = %+v, %v, want only Venezuela", name, entities, err)
+		}
+		markets, err := store.GetEntityMarkets("nvda", 10)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var slugs []string
+		for _, m := range markets {
+			slugs = append(slugs, m.MarketSlug)
+		}
+		sort.Strings(slugs)
+		if strings.Join(slugs, ",") != "nvda-5t,nvda-earnings" {
+			t.Errorf("%s: markets mentioning NVDA = %v, want the two Nvidia markets", name, slugs)
+		}
+
+		counts, err := store.GetEntityCounts(10)
+		if err != nil || len(counts) != 3 || counts[0].MarketEntity != nvda || counts[0].Markets != 2 {
+			t.Errorf("%s: counts = %+v, %v, want NVDA first with 2 markets", name, counts, err)
+		}
+
+		// Event filters accept an alias of the entity
+		for entity, want := range map[string]string{"$nvda": "a,b", "Venezuelan": "c", "Nvidia": "a"} {
+			events, err := store.GetEvents(domain.PolymarketEventFilter{Entity: entity, Limit: 10})
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			var ids []string
+			for _, e := range events {
+				ids = append(ids, e.TradeID)
+			}
+			sort.Strings(ids)
+			if strings.Join(ids, ",") != want {
+				t.Errorf("%s: events mentioning %s = %v, want %s", name, entity, ids, want)
+			}
+		}
+	}
+}
+
+func TestGetEntityTradeFlows(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	now := time.Now().UTC()
+	trump := domain.MarketEntity{Type: domain.MarketEntityPerson, Name: "Donald Trump"}
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		store.SaveMarketEntities("tariffs", "Trump tariffs?", []domain.MarketEntity{trump})
+		store.SaveEvents([]domain.PolymarketEvent{
+			{EventType: domain.PolymarketEventTrade, TradeID: "a", AssetID: "1", MarketSlug: "tariffs", WalletAddress: "0xa", Price: "0.5", Size: "100", Timestamp: now.Add(-time.Hour)},
+			{EventType: domain.PolymarketEventTrade, TradeID: "b", AssetID: "1", MarketSlug: "tariffs", WalletAddress: "0xa", Price: "0.5", Size: "300", IsFreshWallet: true, Timestamp: now.Add(-2 * time.Hour)},
+			{EventType: domain.PolymarketEventTrade, TradeID: "c", AssetID: "1", MarketSlug: "tariffs", WalletAddress: "0xb", Price: "0.5", Size: "100", Timestamp: now.Add(-48 * time.Hour)},
+			{EventType: domain.PolymarketEventTrade, TradeID: "d", AssetID: "1", MarketSlug: "untagged", WalletAddress: "0xb", Price: "0.5", Size: "100", Timestamp: now.Add(-time.Hour)},
+		})
+
+		flows, err := store.GetEntityTradeFlows(now.Add(-24*time.Hour), now)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(flows) != 1 {
+			t.Fatalf("%s: flows = %+v, want 0xa's trades on the tagged market", name, flows)
+		}
+		if f := flows[0]; f.Entity != trump || f.MarketSlug != "tariffs" || f.Wallet != "0xa" || f.Volume != 200 || f.Trades != 2 || !f.Fresh {
+			t.Errorf("%s: flow = %+v, want 2 trades for $200, flagged fresh", name, f)
+		}
+	}
+}
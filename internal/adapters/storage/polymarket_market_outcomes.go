@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"database/sql"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetMarketsToResolve returns slugs of traded markets with no known resolution,
+// skipping unresolved markets checked after checkedBefore
+func (s *PolymarketStore) GetMarketsToResolve(limit int, checkedBefore time.Time) ([]string, error) {
+	rows, err := s.db.Query(`
+		SELECT DISTINCT e.market_slug FROM polymarket_events e
+		LEFT JOIN market_resolutions r ON r.slug = e.market_slug
+		WHERE e.event_type = 'trade' AND e.market_slug IS NOT NULL AND e.market_slug != ''
+			AND (r.slug IS NULL OR (r.resolved = 0 AND r.checked_at < ?))
+		LIMIT ?`, checkedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slugs []string
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			continue
+		}
+		slugs = append(slugs, slug)
+	}
+	return slugs, rows.Err()
+}
+
+// SaveMarketResolution records the latest resolution check of a market
+func (s *PolymarketStore) SaveMarketResolution(outcome domain.MarketOutcome) error {
+	var resolvedAt any
+	if outcome.Resolved {
+		resolvedAt = outcome.ResolvedAt
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO market_resolutions (slug, resolved, winning_outcome, resolved_at, checked_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(slug) DO UPDATE SET
+			resolved = excluded.resolved,
+			winning_outcome = excluded.winning_outcome,
+			resolved_at = excluded.resolved_at,
+			checked_at = excluded.checked_at`,
+		outcome.Slug, outcome.Resolved, outcome.WinningOutcome, resolvedAt, outcome.CheckedAt)
+	return err
+}
+
+// GetMarketWallets returns the wallets that bought into a market
+func (s *PolymarketStore) GetMarketWallets(slug string) ([]string, error) {
+	rows, err := s.db.Query(`
+		SELECT DISTINCT wallet_address FROM polymarket_events
+		WHERE market_slug = ? AND event_type = 'trade' AND side = 'BUY'
+			AND wallet_address IS NOT NULL AND wallet_address != ''`, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wallets []string
+	for rows.Next() {
+		var wallet string
+		if err := rows.Scan(&wallet); err != nil {
+			continue
+		}
+		wallets = append(wallets, wallet)
+	}
+	return wallets, rows.Err()
+}
+
+// GetResolvedBets returns a wallet's bets in resolved markets, oldest resolution first
+func (s *PolymarketStore) GetResolvedBets(address string) ([]domain.ResolvedBet, error) {
+	rows, err := s.db.Query(`
+		SELECT e.market_slug, COALESCE(e.outcome_index, 0), e.price, e.timestamp, r.winning_outcome, r.resolved_at
+		FROM polymarket_events e
+		JOIN market_resolutions r ON r.slug = e.market_slug
+		WHERE e.wallet_address = ? AND e.event_type = 'trade' AND e.side = 'BUY' AND r.resolved = 1
+		ORDER BY e.timestamp`, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buys []resolvedBuy
+	for rows.Next() {
+		var b resolvedBuy
+		var price sql.NullString
+		var resolvedAt sql.NullTime
+		if err := rows.Scan(&b.slug, &b.outcomeIndex, &price, &b.timestamp, &b.winningOutcome, &resolvedAt); err != nil {
+			continue
+		}
+		b.price, _ = strconv.ParseFloat(price.String, 64)
+		b.resolvedAt = resolvedAt.Time
+		buys = append(buys, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return groupResolvedBuys(buys), nil
+}
+
+// resolvedBuy is a single buy in a resolved market
+type resolvedBuy struct {
+	slug           string
+	outcomeIndex   int
+	price          float64
+	timestamp      time.Time
+	winningOutcome int
+	resolvedAt     time.Time
+}
+
+// groupResolvedBuys merges buys of the same market outcome into one bet at their
+// average price, ordered by resolution time and then by first buy
+func groupResolvedBuys(buys []resolvedBuy) []domain.ResolvedBet {
+	type key struct {
+		slug    string
+		outcome int
+	}
+	index := make(map[key]int)
+	counts := make(map[key]int)
+	bets := []domain.ResolvedBet{}
+	for _, b := range buys {
+		k := key{b.slug, b.outcomeIndex}
+		i, ok := index[k]
+		if !ok {
+			i = len(bets)
+			index[k] = i
+			bets = append(bets, domain.ResolvedBet{
+				MarketSlug:   b.slug,
+				OutcomeIndex: b.outcomeIndex,
+				Won:          b.outcomeIndex == b.winningOutcome,
+				PlacedAt:     b.timestamp,
+				ResolvedAt:   b.resolvedAt,
+			})
+		}
+		counts[k]++
+		bets[i].Price += (b.price - bets[i].Price) / float64(counts[k])
+		if b.timestamp.Before(bets[i].PlacedAt) {
+			bets[i].PlacedAt = b.timestamp
+		}
+	}
+
+	sort.SliceStable(bets, func(i, j int) bool {
+		if !bets[i].ResolvedAt.Equal(bets[j].ResolvedAt) {
+			return bets[i].ResolvedAt.Before(bets[j].ResolvedAt)
+		}
+		return bets[i].PlacedAt.Before(bets[j].PlacedAt)
+	})
+	return bets
+}
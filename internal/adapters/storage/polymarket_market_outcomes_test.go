@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestGetResolvedBetsGroupsBuysByOutcome(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		for i, trade := range []struct {
+			slug    string
+			outcome int
+			price   string
+			side    domain.OrderSide
+		}{
+			{"rain", 1, "0.2", domain.OrderSideBuy},
+			{"rain", 1, "0.3", domain.OrderSideBuy},
+			{"rain", 1, "0.9", domain.OrderSideSell}, // Sells are not bets
+			{"snow", 0, "0.6", domain.OrderSideBuy},
+			{"hail", 0, "0.1", domain.OrderSideBuy}, // Still open
+		} {
+			event := domain.PolymarketEvent{
+				EventType: domain.PolymarketEventTrade, TradeID: "tx" + string(rune('a'+i)), WalletAddress: "0xa",
+				MarketSlug: trade.slug, OutcomeIndex: trade.outcome, Price: trade.price, Size: "10", Side: trade.side,
+				Timestamp: start.Add(time.Duration(i) * time.Hour),
+			}
+			if err := store.SaveEvent(event); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+
+		toResolve, err := store.GetMarketsToResolve(10, start)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		slices.Sort(toResolve)
+		if !slices.Equal(toResolve, []string{"hail", "rain", "snow"}) {
+			t.Errorf("%s: markets to resolve = %v, want every traded market", name, toResolve)
+		}
+
+		for _, outcome := range []domain.MarketOutcome{
+			{Slug: "snow", Resolved: true, WinningOutcome: 1, ResolvedAt: start.Add(24 * time.Hour), CheckedAt: start},
+			{Slug: "rain", Resolved: true, WinningOutcome: 1, ResolvedAt: start.Add(48 * time.Hour), CheckedAt: start},
+			{Slug: "hail", WinningOutcome: -1, CheckedAt: start},
+		} {
+			if err := store.SaveMarketResolution(outcome); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		if toResolve, _ := store.GetMarketsToResolve(10, start); len(toResolve) != 0 {
+			t.Errorf("%s: markets to resolve = %v, want the recently checked market skipped", name, toResolve)
+		}
+		if toResolve, _ := store.GetMarketsToResolve(10, start.Add(time.Minute)); !slices.Equal(toResolve, []string{"hail"}) {
+			t.Errorf("%s: markets to resolve = %v, want only the unresolved market", name, toResolve)
+		}
+
+		bets, err := store.GetResolvedBets("0xa")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(bets) != 2 {
+			t.Fatalf("%s: bets = %+v, want one per resolved market", name, bets)
+		}
+		if bets[0].MarketSlug != "snow" || bets[0].Won {
+			t.Errorf("%s: first bet = %+v, want the lost bet resolved first", name, bets[0])
+		}
+		if b := bets[1]; b.MarketSlug != "rain" || !b.Won || b.Price < 0.249 || b.Price > 0.251 || !b.PlacedAt.Equal(start) {
+			t.Errorf("%s: second bet = %+v, want a won bet at the average price of 0.25 placed at the first buy", name, b)
+		}
+	}
+}
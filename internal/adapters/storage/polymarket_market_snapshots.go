@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateMarketSnapshots creates the table of scheduled market snapshots. Outcomes,
+// prices and holders are kept as JSON, snapshots are only ever read whole.
+func (s *PolymarketStore) migrateMarketSnapshots() error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS market_snapshots (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			market_slug TEXT NOT NULL,
+			condition_id TEXT NOT NULL DEFAULT '',
+			market_name TEXT NOT NULL DEFAULT '',
+			outcomes TEXT NOT NULL DEFAULT '[]',
+			prices TEXT NOT NULL DEFAULT '[]',
+			best_bid REAL NOT NULL DEFAULT 0,
+			best_ask REAL NOT NULL DEFAULT 0,
+			last_trade_price REAL NOT NULL DEFAULT 0,
+			volume REAL NOT NULL DEFAULT 0,
+			volume_24h REAL NOT NULL DEFAULT 0,
+			liquidity REAL NOT NULL DEFAULT 0,
+			closed INTEGER NOT NULL DEFAULT 0,
+			holders TEXT NOT NULL DEFAULT '[]',
+			captured_at INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_market_snapshots_market ON market_snapshots(market_slug, captured_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_market_snapshots_captured ON market_snapshots(captured_at)`,
+	}
+	for _, t := range tables {
+		if _, err := s.db.Exec(t); err != nil {
+			return fmt.Errorf("failed to create market snapshots table: %w", err)
+		}
+	}
+	return nil
+}
+
+// SaveMarketSnapshots stores snapshots, in one transaction
+func (s *PolymarketStore) SaveMarketSnapshots(snapshots []domain.MarketSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO market_snapshots (market_slug, condition_id, market_name, outcomes, prices,
+		best_bid, best_ask, last_trade_price, volume, volume_24h, liquidity, closed, holders, captured_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, m := range snapshots {
+		outcomes, _ := json.Marshal(m.Outcomes)
+		prices, _ := json.Marshal(m.Prices)
+		holders, _ := json.Marshal(m.TopHolders)
+		if _, err := stmt.Exec(m.MarketSlug, m.ConditionID, m.MarketName, string(outcomes), string(prices),
+			m.BestBid, m.BestAsk, m.LastTradePrice, m.Volume, m.Volume24h, m.Liquidity, m.Closed,
+			string(holders), m.CapturedAt.UnixMilli()); err != nil {
+			return storeError("save market snapshots", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetMarketSnapshots returns the stored snapshots matching a filter, newest first
+func (s *PolymarketStore) GetMarketSnapshots(filter domain.MarketSnapshotFilter) ([]domain.MarketSnapshot, error) {
+	var conditions []string
+	var args []any
+	if filter.MarketSlug != "" {
+		conditions = append(conditions, "market_slug = ?")
+		args = append(args, filter.MarketSlug)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "captured_at >= ?")
+		args = append(args, filter.Since.UnixMilli())
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "captured_at <= ?")
+		args = append(args, filter.Until.UnixMilli())
+	}
+	query := `SELECT id, market_slug, condition_id, market_name, outcomes, prices, best_bid, best_ask,
+		last_trade_price, volume, volume_24h, liquidity, closed, holders, captured_at FROM market_snapshots`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY captured_at DESC, id DESC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := []domain.MarketSnapshot{}
+	for rows.Next() {
+		var m domain.MarketSnapshot
+		var outcomes, prices, holders string
+		var capturedAt int64
+		if err := rows.Scan(&m.ID, &m.MarketSlug, &m.ConditionID, &m.MarketName, &outcomes, &prices,
+			&m.BestBid, &m.BestAsk, &m.LastTradePrice, &m.Volume, &m.Volume24h, &m.Liquidity, &m.Closed,
+			&holders, &capturedAt); err != nil {
+			return nil, err
+		}
+		_ = json.Unmarshal([]byte(outcomes), &m.Outcomes)
+		_ = json.Unmarshal([]byte(prices), &m.Prices)
+		_ = json.Unmarshal([]byte(holders), &m.TopHolders)
+		m.CapturedAt = time.UnixMilli(capturedAt)
+		snapshots = append(snapshots, m)
+	}
+	return snapshots, rows.Err()
+}
+
+// PruneMarketSnapshots deletes snapshots captured before a time and returns how many
+func (s *PolymarketStore) PruneMarketSnapshots(before time.Time) (int64, error) {
+	result, err := s.db.Exec(`DELETE FROM market_snapshots WHERE captured_at < ?`, before.UnixMilli())
+	if err != nil {
+		return 0, storeError("prune market snapshots", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateNotificationDeliveries creates the per-channel delivery ledger of notified items
+func (s *PolymarketStore) migrateNotificationDeliveries() error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS notification_deliveries (
+			item_type TEXT NOT NULL,
+			item_id TEXT NOT NULL,
+			channel TEXT NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			delivered_at DATETIME,
+			next_attempt_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			content TEXT,
+			PRIMARY KEY (item_type, item_id, channel)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_notification_deliveries_pending
+			ON notification_deliveries(next_attempt_at) WHERE delivered_at IS NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_notification_deliveries_updated ON notification_deliveries(updated_at)`,
+	}
+	for _, t := range tables {
+		if _, err := s.analysisDB.Exec(t); err != nil {
+			return fmt.Errorf("failed to create notification deliveries table: %w", err)
+		}
+	}
+	return nil
+}
+
+// SaveNotificationDelivery inserts or replaces the delivery state of an item on a channel.
+// The content is dropped once the item is delivered.
+func (s *PolymarketStore) SaveNotificationDelivery(delivery domain.NotificationDelivery) error {
+	var content sql.NullString
+	if delivery.Content != nil && !delivery.Delivered() {
+		data, err := json.Marshal(delivery.Content)
+		if err != nil {
+			return err
+		}
+		content = sql.NullString{String: string(data), Valid: true}
+	}
+	var deliveredAt sql.NullTime
+	if delivery.DeliveredAt != nil {
+		deliveredAt = sql.NullTime{Time: delivery.DeliveredAt.UTC(), Valid: true}
+	}
+	_, err := s.analysisDB.Exec(`
+		INSERT OR REPLACE INTO notification_deliveries
+			(item_type, item_id, channel, attempts, last_error, delivered_at, next_attempt_at, updated_at, content)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		delivery.ItemType, delivery.ItemID, delivery.Channel, delivery.Attempts, delivery.LastError,
+		deliveredAt, delivery.NextAttemptAt.UTC(), delivery.UpdatedAt.UTC(), content)
+	if err != nil {
+		return storeError("save notification delivery", err)
+	}
+	return nil
+}
+
+// GetNotificationDeliveries returns the delivery state of an item on each channel it was
+// sent to
+func (s *PolymarketStore) GetNotificationDeliveries(itemType, itemID string) ([]domain.NotificationDelivery, error) {
+	return s.queryNotificationDeliveries(`
+		SELECT item_type, item_id, channel, attempts, last_error, delivered_at, next_attempt_at, updated_at, content
+		FROM notification_deliveries WHERE item_type = ? AND item_id = ? ORDER BY channel`, itemType, itemID)
+}
+
+// GetUndeliveredNotifications returns the deliveries that have not succeeded yet, next
+// attempt first
+func (s *PolymarketStore) GetUndeliveredNotifications(limit int) ([]domain.NotificationDelivery, error) {
+	return s.queryNotificationDeliveries(`
+		SELECT item_type, item_id, channel, attempts, last_error, delivered_at, next_attempt_at, updated_at, content
+		FROM notification_deliveries WHERE delivered_at IS NULL ORDER BY next_attempt_at LIMIT ?`, limit)
+}
+
+// GetDueNotificationDeliveries returns the undelivered deliveries with fewer than
+// maxAttempts attempts whose next attempt is due, next attempt first. Deliveries given
+// up on are left out so they cannot crowd retryable ones out of the limit.
+func (s *PolymarketStore) GetDueNotificationDeliveries(maxAttempts int, now time.Time, limit int) ([]domain.NotificationDelivery, error) {
+	return s.queryNotificationDeliveries(`
+		SELECT item_type, item_id, channel, attempts, last_error, delivered_at, next_attempt_at, updated_at, content
+		FROM notification_deliveries
+		WHERE delivered_at IS NULL AND attempts < ? AND next_attempt_at <= ? AND content IS NOT NULL
+		ORDER BY next_attempt_at LIMIT ?`, maxAttempts, now.UTC(), limit)
+}
+
+// queryNotificationDeliveries scans the rows of a delivery query
+func (s *PolymarketStore) queryNotificationDeliveries(query string, args ...any) ([]domain.NotificationDelivery, error) {
+	rows, err := s.analysisDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.NotificationDelivery{}
+	for rows.Next() {
+		var d domain.NotificationDelivery
+		var deliveredAt sql.NullTime
+		var content sql.NullString
+		if err := rows.Scan(&d.ItemType, &d.ItemID, &d.Channel, &d.Attempts, &d.LastError,
+			&deliveredAt, &d.NextAttemptAt, &d.UpdatedAt, &content); err != nil {
+			return nil, err
+		}
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		if content.Valid {
+			var c domain.NotificationContent
+			if err := json.Unmarshal([]byte(content.String), &c); err == nil {
+				d.Content = &c
+			}
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// deleteNotificationDeliveriesBefore deletes the delivery states last updated before a time
+func (s *PolymarketStore) deleteNotificationDeliveriesBefore(before time.Time) error {
+	if _, err := s.analysisDB.Exec(`DELETE FROM notification_deliveries WHERE updated_at < ?`, before.UTC()); err != nil {
+		return storeError("delete notification deliveries", err)
+	}
+	return nil
+}
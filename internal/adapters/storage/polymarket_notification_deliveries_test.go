@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestGivenUpDeliveriesDoNotCrowdOutRetries(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	now := time.Now().UTC()
+	content := &domain.NotificationContent{Title: "Fresh wallet"}
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		// Deliveries given up on long ago sort first by next attempt
+		for i := range 250 {
+			err := store.SaveNotificationDelivery(domain.NotificationDelivery{
+				ItemType: "trade", ItemID: fmt.Sprintf("dead-%d", i), Channel: "main",
+				Attempts: 5, LastError: "chat not found", Content: content,
+				NextAttemptAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Hour),
+			})
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		retry := domain.NotificationDelivery{
+			ItemType: "trade", ItemID: "retry", Channel: "main", Attempts: 1, LastError: "timeout",
+			Content: content, NextAttemptAt: now.Add(-time.Minute), UpdatedAt: now.Add(-time.Minute),
+		}
+		later := retry
+		later.ItemID, later.NextAttemptAt = "later", now.Add(time.Minute)
+		for _, d := range []domain.NotificationDelivery{retry, later} {
+			if err := store.SaveNotificationDelivery(d); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+
+		due, err := store.GetDueNotificationDeliveries(5, now, 200)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(due) != 1 || due[0].ItemID != "retry" || due[0].Content == nil {
+			t.Errorf("%s: due deliveries = %+v, want only the retryable one with its content", name, due)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// notifiedTimeFormat matches CURRENT_TIMESTAMP, which notified_at is set with, so
+// bounds compare as text
+const notifiedTimeFormat = "2006-01-02 15:04:05"
+
+// GetNotificationStats counts the items notified since a time, by type and UTC day
+func (s *PolymarketStore) GetNotificationStats(since time.Time) (*domain.NotificationStats, error) {
+	rows, err := s.analysisDB.Query(`
+		SELECT date(notified_at), item_type, COUNT(*) FROM notified_items
+		WHERE notified_at >= ?
+		GROUP BY 1, 2 ORDER BY 1, 2`, since.UTC().Format(notifiedTimeFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count notified items: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &domain.NotificationStats{Since: since, ByType: map[string]int64{}, Days: []domain.NotificationDayCount{}}
+	for rows.Next() {
+		var day domain.NotificationDayCount
+		if err := rows.Scan(&day.Day, &day.ItemType, &day.Count); err != nil {
+			return nil, fmt.Errorf("failed to count notified items: %w", err)
+		}
+		stats.Days = append(stats.Days, day)
+		stats.ByType[day.ItemType] += day.Count
+		stats.Total += day.Count
+	}
+	return stats, rows.Err()
+}
+
+// DeleteNotifiedBefore deletes the records of items notified before a time, with their
+// channel delivery states
+func (s *PolymarketStore) DeleteNotifiedBefore(before time.Time) (int64, error) {
+	if err := s.deleteNotificationDeliveriesBefore(before); err != nil {
+		return 0, err
+	}
+	result, err := s.analysisDB.Exec(`DELETE FROM notified_items WHERE notified_at < ?`,
+		before.UTC().Format(notifiedTimeFormat))
+	if err != nil {
+		return 0, storeError("delete notified items", err)
+	}
+	return result.RowsAffected()
+}
+
+// HasNotified checks if an item has already been notified
+func (s *PolymarketStore) HasNotified(itemType, itemID string) (bool, error) {
+	var count int
+	err := s.stmts.hasNotified.QueryRow(itemType, itemID).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// MarkNotified marks an item as notified
+func (s *PolymarketStore) MarkNotified(itemType, itemID string) error {
+	_, err := s.stmts.markNotified.Exec(itemType, itemID)
+	return err
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestNotifiedItemStatsAndCleanup(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+	memory := NewMemoryPolymarketStore()
+
+	now := time.Now().UTC()
+	today := now.Format("2006-01-02")
+	tenDaysAgo := now.AddDate(0, 0, -10)
+	// backdate moves an item's notification time, as if it was sent then
+	backdate := map[string]func(itemType, itemID string, at time.Time){
+		"sqlite": func(itemType, itemID string, at time.Time) {
+			sqlite.analysisDB.Exec(`UPDATE notified_items SET notified_at = ? WHERE item_type = ? AND item_id = ?`,
+				at.Format(notifiedTimeFormat), itemType, itemID)
+		},
+		"memory": func(itemType, itemID string, at time.Time) {
+			memory.mu.Lock()
+			memory.notified[itemType+":"+itemID] = at
+			memory.mu.Unlock()
+		},
+	}
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": memory} {
+		for _, item := range [][2]string{{"big_trade", "t1"}, {"big_trade", "t2"}, {"fresh_wallet", "0xa"}, {"big_trade", "old"}} {
+			if err := store.MarkNotified(item[0], item[1]); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		backdate[name]("big_trade", "old", tenDaysAgo)
+
+		stats, err := store.GetNotificationStats(now.AddDate(0, 0, -30))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if stats.Total != 4 || stats.ByType["big_trade"] != 3 || stats.ByType["fresh_wallet"] != 1 || len(stats.Days) != 3 {
+			t.Fatalf("%s: stats = %+v, want 4 items over 3 type-days", name, stats)
+		}
+		if first, last := stats.Days[0], stats.Days[2]; first.Day != tenDaysAgo.Format("2006-01-02") || first.Count != 1 || last.Day != today || last.ItemType != "fresh_wallet" {
+			t.Errorf("%s: days = %+v, want the old item first", name, stats.Days)
+		}
+		if recent, _ := store.GetNotificationStats(now.AddDate(0, 0, -1)); recent.Total != 3 {
+			t.Errorf("%s: %d items in the last day, want 3", name, recent.Total)
+		}
+
+		deleted, err := store.DeleteNotifiedBefore(now.AddDate(0, 0, -7))
+		if err != nil || deleted != 1 {
+			t.Errorf("%s: deleted %d, %v, want the old item", name, deleted, err)
+		}
+		if notified, _ := store.HasNotified("big_trade", "old"); notified {
+			t.Errorf("%s: the deleted item is still notified", name)
+		}
+		if notified, _ := store.HasNotified("big_trade", "t1"); !notified {
+			t.Errorf("%s: a recent item was deleted", name)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	// SQLite connection settings when the config leaves them unset, the driver's defaults
+	defaultBusyTimeoutMs = 5000
+	defaultSynchronous   = "NORMAL"
+
+	// defaultMaxIdleConns is database/sql's idle pool size, restored after SetPragmas
+	defaultMaxIdleConns = 2
+)
+
+// synchronousModes are the accepted values of PRAGMA synchronous
+var synchronousModes = map[string]bool{"OFF": true, "NORMAL": true, "FULL": true, "EXTRA": true}
+
+// sqlitePragmas are the settings every connection of the store runs when it opens
+type sqlitePragmas struct {
+	busyTimeoutMs int
+	synchronous   string
+}
+
+// pragmaConnector opens SQLite connections that run the store's current pragmas
+type pragmaConnector struct {
+	dsn    string
+	driver *sqlite3.SQLiteDriver
+}
+
+func (c *pragmaConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *pragmaConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// openSQLite opens a WAL mode database whose connections run the store's pragmas
+func (s *PolymarketStore) openSQLite(path string) *sql.DB {
+	return sql.OpenDB(&pragmaConnector{
+		dsn:    path + "?_journal_mode=WAL",
+		driver: &sqlite3.SQLiteDriver{ConnectHook: s.applyPragmas},
+	})
+}
+
+// applyPragmas runs the current pragmas on a new connection
+func (s *PolymarketStore) applyPragmas(conn *sqlite3.SQLiteConn) error {
+	s.pragmaMu.RLock()
+	p := s.pragmas
+	s.pragmaMu.RUnlock()
+	_, err := conn.Exec(fmt.Sprintf("PRAGMA busy_timeout = %d; PRAGMA synchronous = %s", p.busyTimeoutMs, p.synchronous), nil)
+	return err
+}
+
+// SetPragmas sets how long statements wait for a locked database and the synchronous
+// mode ("OFF", "NORMAL", "FULL" or "EXTRA"); zero and empty restore the defaults.
+// Idle connections are closed so the pool reopens them with the new settings.
+func (s *PolymarketStore) SetPragmas(busyTimeoutMs int, synchronous string) error {
+	p := sqlitePragmas{busyTimeoutMs: busyTimeoutMs, synchronous: strings.ToUpper(strings.TrimSpace(synchronous))}
+	if p.busyTimeoutMs <= 0 {
+		p.busyTimeoutMs = defaultBusyTimeoutMs
+	}
+	if p.synchronous == "" {
+		p.synchronous = defaultSynchronous
+	}
+	if !synchronousModes[p.synchronous] {
+		return fmt.Errorf("invalid synchronous mode %q (want OFF, NORMAL, FULL or EXTRA)", synchronous)
+	}
+
+	s.pragmaMu.Lock()
+	changed := p != s.pragmas
+	s.pragmas = p
+	s.pragmaMu.Unlock()
+	if !changed {
+		return nil
+	}
+	for _, db := range s.databases() {
+		db.SetMaxIdleConns(0)
+		db.SetMaxIdleConns(defaultMaxIdleConns)
+	}
+	return nil
+}
+
+// WALSize returns the size of the -wal files in bytes
+func (s *PolymarketStore) WALSize() int64 {
+	var size int64
+	for _, path := range []string{s.dbPath, s.analysisPath} {
+		if stat, err := os.Stat(path + "-wal"); err == nil {
+			size += stat.Size()
+		}
+		if !s.isSplit() {
+			break
+		}
+	}
+	return size
+}
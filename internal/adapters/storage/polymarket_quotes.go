@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateQuotes creates the table of the latest best bid and ask per outcome token
+func (s *PolymarketStore) migrateQuotes() error {
+	_, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS market_quotes (
+		asset_id TEXT PRIMARY KEY,
+		condition_id TEXT,
+		best_bid REAL NOT NULL,
+		best_ask REAL NOT NULL,
+		updated_at INTEGER NOT NULL
+	) WITHOUT ROWID`)
+	if err != nil {
+		return fmt.Errorf("failed to create quotes table: %w", err)
+	}
+	return nil
+}
+
+// SaveQuotes replaces the stored quotes of the given assets, in one transaction. A
+// quote older than the stored one is ignored.
+func (s *PolymarketStore) SaveQuotes(quotes []domain.MarketQuote) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO market_quotes (asset_id, condition_id, best_bid, best_ask, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(asset_id) DO UPDATE SET
+			condition_id = COALESCE(NULLIF(excluded.condition_id, ''), condition_id),
+			best_bid = excluded.best_bid,
+			best_ask = excluded.best_ask,
+			updated_at = excluded.updated_at
+		WHERE excluded.updated_at >= market_quotes.updated_at`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, q := range quotes {
+		if _, err := stmt.Exec(q.AssetID, q.ConditionID, q.BestBid, q.BestAsk, q.UpdatedAt.UnixMilli()); err != nil {
+			return fmt.Errorf("failed to save quote of %s: %w", q.AssetID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetQuotes returns the stored quotes of the given assets, or of every asset when none
+// are given, most recently updated first
+func (s *PolymarketStore) GetQuotes(assetIDs []string) ([]domain.MarketQuote, error) {
+	query := `SELECT asset_id, COALESCE(condition_id, ''), best_bid, best_ask, updated_at FROM market_quotes`
+	var args []any
+	if len(assetIDs) > 0 {
+		query += ` WHERE asset_id IN ` + sqlList(len(assetIDs))
+		args = appendStrings(args, assetIDs)
+	}
+	rows, err := s.db.Query(query+` ORDER BY updated_at DESC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	quotes := []domain.MarketQuote{}
+	for rows.Next() {
+		var q domain.MarketQuote
+		var updatedAt int64
+		if err := rows.Scan(&q.AssetID, &q.ConditionID, &q.BestBid, &q.BestAsk, &updatedAt); err != nil {
+			return nil, err
+		}
+		q.Spread = domain.QuoteSpread(q.BestBid, q.BestAsk)
+		q.UpdatedAt = time.UnixMilli(updatedAt)
+		quotes = append(quotes, q)
+	}
+	return quotes, rows.Err()
+}
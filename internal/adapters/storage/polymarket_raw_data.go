@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// rawDataMinCompress is the smallest payload worth compressing; gzip adds ~20 bytes
+	rawDataMinCompress = 256
+
+	// compressBatchSize is how many rows are compressed per transaction
+	compressBatchSize = 500
+)
+
+// gzipMagic starts every gzip stream. JSON payloads never start with it, so stored
+// values are told apart without a flag column.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// encodeRawData returns the value stored for a raw payload: gzip-compressed bytes
+// (stored as a BLOB) when that saves space, otherwise the text unchanged
+func encodeRawData(raw string) any {
+	if len(raw) < rawDataMinCompress {
+		return raw
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(raw)); err != nil || zw.Close() != nil || buf.Len() >= len(raw) {
+		return raw
+	}
+	return buf.Bytes()
+}
+
+// decodeRawData returns the payload of a stored raw_data value, decompressing it if it
+// was compressed. Rows saved before compression are plain text and returned as is.
+func decodeRawData(stored string) string {
+	if len(stored) < len(gzipMagic) || stored[0] != gzipMagic[0] || stored[1] != gzipMagic[1] {
+		return stored
+	}
+	zr, err := gzip.NewReader(bytes.NewReader([]byte(stored)))
+	if err != nil {
+		return stored
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		log.Printf("[Storage] Failed to decompress raw event data: %v", err)
+		return ""
+	}
+	return string(raw)
+}
+
+// CompressRawData compresses the raw payloads stored as plain text, in batches so
+// saving events isn't blocked for long. The freed pages are reclaimed by Optimize.
+func (s *PolymarketStore) CompressRawData() (*domain.RawDataCompression, error) {
+	result := &domain.RawDataCompression{}
+	var after int64
+	for {
+		rows, err := s.db.Query(fmt.Sprintf(`SELECT id, raw_data FROM polymarket_events
+			WHERE id > ? AND typeof(raw_data) = 'text' AND length(raw_data) >= ?
+			ORDER BY id LIMIT %d`, compressBatchSize), after, rawDataMinCompress)
+		if err != nil {
+			return result, fmt.Errorf("failed to read raw data: %w", err)
+		}
+		type payload struct {
+			id  int64
+			raw string
+		}
+		var batch []payload
+		for rows.Next() {
+			var p payload
+			if err := rows.Scan(&p.id, &p.raw); err != nil {
+				rows.Close()
+				return result, fmt.Errorf("failed to read raw data: %w", err)
+			}
+			batch = append(batch, p)
+		}
+		rows.Close()
+		if len(batch) == 0 {
+			return result, nil
+		}
+
+		tx, err := s.db.Begin()
+		if err != nil {
+			return result, err
+		}
+		for _, p := range batch {
+			after = p.id
+			result.RowsScanned++
+			compressed, ok := encodeRawData(p.raw).([]byte)
+			if !ok {
+				continue
+			}
+			if _, err := tx.Exec(`UPDATE polymarket_events SET raw_data = ? WHERE id = ?`, compressed, p.id); err != nil {
+				tx.Rollback()
+				return result, fmt.Errorf("failed to compress raw data: %w", err)
+			}
+			result.RowsCompressed++
+			result.BytesBefore += int64(len(p.raw))
+			result.BytesAfter += int64(len(compressed))
+		}
+		if err := tx.Commit(); err != nil {
+			return result, fmt.Errorf("failed to compress raw data: %w", err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestRawDataIsCompressedTransparently(t *testing.T) {
+	store, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	large := `{"event_type":"book","bids":[` + strings.Repeat(`{"price":"0.50","size":"100"},`, 50) + `{}]}`
+	small := `{"event_type":"trade"}`
+	for i, raw := range []string{large, small, large} {
+		if err := store.SaveEvent(domain.PolymarketEvent{EventType: domain.PolymarketEventBook, AssetID: fmt.Sprint(i), RawData: raw}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The last row was saved before compression existed
+	if _, err := store.db.Exec(`UPDATE polymarket_events SET raw_data = ? WHERE asset_id = '2'`, large); err != nil {
+		t.Fatal(err)
+	}
+
+	types := func() string {
+		var types []string
+		rows, _ := store.db.Query(`SELECT typeof(raw_data) FROM polymarket_events ORDER BY asset_id`)
+		defer rows.Close()
+		for rows.Next() {
+			var typ string
+			rows.Scan(&typ)
+			types = append(types, typ)
+		}
+		return strings.Join(types, ",")
+	}
+	if got := types(); got != "blob,text,text" {
+		t.Fatalf("stored types = %s, want only the new large payload compressed", got)
+	}
+
+	result, err := store.CompressRawData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RowsScanned != 1 || result.RowsCompressed != 1 || result.BytesAfter >= result.BytesBefore/4 {
+		t.Errorf("result = %+v, want the old payload compressed several-fold", result)
+	}
+	if got := types(); got != "blob,text,blob" {
+		t.Errorf("stored types = %s after compressing, want the small payload left as text", got)
+	}
+
+	events, err := store.GetEvents(domain.PolymarketEventFilter{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range events {
+		if want := map[string]string{"0": large, "1": small, "2": large}[e.AssetID]; e.RawData != want {
+			t.Errorf("event %s raw data = %.40q…, want the payload back", e.AssetID, e.RawData)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// pruneBatchSize is how many events are deleted per transaction, so pruning a large
+// backlog doesn't hold the write lock for long
+const pruneBatchSize = 2000
+
+// PruneEvents deletes events older than before and events beyond the keepNewest newest
+// (zero values disable a limit), skipping tagged events when keepTagged is set and
+// events added to an investigation. Returns how many events were deleted.
+func (s *PolymarketStore) PruneEvents(before time.Time, keepNewest int64, keepTagged bool) (int64, error) {
+	// Events older than the keepNewest-th newest go regardless of age
+	var belowID int64
+	if keepNewest > 0 {
+		err := s.db.QueryRow("SELECT id FROM polymarket_events ORDER BY id DESC LIMIT 1 OFFSET ?", keepNewest-1).Scan(&belowID)
+		if err != nil && err != sql.ErrNoRows {
+			return 0, fmt.Errorf("failed to find retention cutoff: %w", err)
+		}
+	}
+	if before.IsZero() && belowID == 0 {
+		return 0, nil
+	}
+
+	protected, err := s.investigatedEventIDs()
+	if err != nil {
+		return 0, err
+	}
+	var limits []string
+	var args []any
+	if !before.IsZero() {
+		limits = append(limits, "timestamp < ?")
+		args = append(args, before)
+	}
+	if belowID > 0 {
+		limits = append(limits, "id < ?")
+		args = append(args, belowID)
+	}
+	query := "SELECT id FROM polymarket_events WHERE id > ? AND (" + strings.Join(limits, " OR ") + ")"
+	if keepTagged {
+		query += " AND id NOT IN (SELECT event_id FROM event_tags)"
+	}
+	query += fmt.Sprintf(" ORDER BY id LIMIT %d", pruneBatchSize)
+
+	var deleted, after int64
+	for {
+		ids, err := queryIDs(s.db, query, append([]any{after}, args...)...)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to select events to prune: %w", err)
+		}
+		if len(ids) == 0 {
+			return deleted, nil
+		}
+		after = ids[len(ids)-1]
+
+		var batch []string
+		for _, id := range ids {
+			if !protected[id] {
+				batch = append(batch, strconv.FormatInt(id, 10))
+			}
+		}
+		if len(batch) > 0 {
+			n, err := s.deleteEvents(batch)
+			deleted += n
+			if err != nil {
+				return deleted, err
+			}
+		}
+		if len(ids) < pruneBatchSize {
+			return deleted, nil
+		}
+	}
+}
+
+// deleteEvents deletes events and their tags by ID in one transaction
+func (s *PolymarketStore) deleteEvents(ids []string) (int64, error) {
+	list := strings.Join(ids, ",")
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM event_tags WHERE event_id IN (" + list + ")"); err != nil {
+		return 0, fmt.Errorf("failed to prune event tags: %w", err)
+	}
+	result, err := tx.Exec("DELETE FROM polymarket_events WHERE id IN (" + list + ")")
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune events: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// investigatedEventIDs returns the IDs of events added to an investigation
+func (s *PolymarketStore) investigatedEventIDs() (map[int64]bool, error) {
+	rows, err := s.analysisDB.Query("SELECT ref FROM investigation_items WHERE item_type = ?", domain.InvestigationItemEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read investigated events: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make(map[int64]bool)
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, err
+		}
+		if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+			ids[id] = true
+		}
+	}
+	return ids, rows.Err()
+}
+
+// queryIDs runs a query selecting a single integer column
+func queryIDs(db *sql.DB, query string, args ...any) ([]int64, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
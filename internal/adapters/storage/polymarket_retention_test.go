@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestPruneEventsKeepsTaggedAndInvestigatedEvents(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	now := time.Now()
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		// tx-0 to tx-4 are 10 days old, tx-5 to tx-9 an hour old
+		var events []domain.PolymarketEvent
+		for i := range 10 {
+			age := time.Hour
+			if i < 5 {
+				age = 10 * 24 * time.Hour
+			}
+			events = append(events, domain.PolymarketEvent{
+				EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("tx-%d", i), WalletAddress: "0xa",
+				Price: "0.5", Size: "10", Timestamp: now.Add(-age),
+			})
+		}
+		if err := store.SaveEvents(events); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		ids := make(map[string]int64)
+		stored, _ := store.GetEvents(domain.PolymarketEventFilter{Limit: 100})
+		for _, e := range stored {
+			ids[e.TradeID] = e.ID
+		}
+
+		store.TagEvent(ids["tx-0"], "evidence")
+		inv, err := store.CreateInvestigation(domain.Investigation{Name: "case", Status: domain.InvestigationOpen})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		store.AddInvestigationItem(inv.ID, domain.InvestigationItem{
+			Type: domain.InvestigationItemEvent, Ref: strconv.FormatInt(ids["tx-1"], 10), AddedAt: now,
+		})
+
+		if deleted, err := store.PruneEvents(time.Time{}, 0, true); err != nil || deleted != 0 {
+			t.Errorf("%s: pruning without limits deleted %d, %v", name, deleted, err)
+		}
+		deleted, err := store.PruneEvents(now.Add(-7*24*time.Hour), 0, true)
+		if err != nil || deleted != 3 {
+			t.Errorf("%s: age limit deleted %d, %v, want the 3 untagged old events", name, deleted, err)
+		}
+
+		// Keeping the 3 newest also prunes younger and tagged events, but never investigated ones
+		deleted, err = store.PruneEvents(time.Time{}, 3, false)
+		if err != nil || deleted != 3 {
+			t.Errorf("%s: count limit deleted %d, %v, want 3", name, deleted, err)
+		}
+		var kept []string
+		stored, _ = store.GetEvents(domain.PolymarketEventFilter{Limit: 100})
+		for _, e := range stored {
+			kept = append(kept, e.TradeID)
+		}
+		slices.Sort(kept)
+		if want := []string{"tx-1", "tx-7", "tx-8", "tx-9"}; !slices.Equal(kept, want) {
+			t.Errorf("%s: kept %v, want %v", name, kept, want)
+		}
+	}
+}
+
+func TestArchiveEventsMovesOldEvents(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	now := time.Now()
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		// tx-0 to tx-2 are 10 days old, tx-3 and tx-4 an hour old
+		var events []domain.PolymarketEvent
+		for i := range 5 {
+			age := time.Hour
+			if i < 3 {
+				age = 10 * 24 * time.Hour
+			}
+			events = append(events, domain.PolymarketEvent{
+				EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("tx-%d", i), WalletAddress: fmt.Sprintf("0x%d", i%2),
+				Price: "0.5", Size: "10", Timestamp: now.Add(-age),
+			})
+		}
+		if err := store.SaveEvents(events); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		ids := make(map[string]int64)
+		stored, _ := store.GetEvents(domain.PolymarketEventFilter{Limit: 100})
+		for _, e := range stored {
+			ids[e.TradeID] = e.ID
+		}
+		inv, err := store.CreateInvestigation(domain.Investigation{Name: "case", Status: domain.InvestigationOpen})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		store.AddInvestigationItem(inv.ID, domain.InvestigationItem{
+			Type: domain.InvestigationItemEvent, Ref: strconv.FormatInt(ids["tx-2"], 10), AddedAt: now,
+		})
+
+		if archived, err := store.ArchiveEvents(time.Time{}); err != nil || archived != 0 {
+			t.Errorf("%s: archiving without a cutoff moved %d, %v", name, archived, err)
+		}
+		archived, err := store.ArchiveEvents(now.Add(-7 * 24 * time.Hour))
+		if err != nil || archived != 2 {
+			t.Fatalf("%s: archived %d, %v, want the 2 old events not under investigation", name, archived, err)
+		}
+
+		var kept []string
+		stored, _ = store.GetEvents(domain.PolymarketEventFilter{Limit: 100})
+		for _, e := range stored {
+			kept = append(kept, e.TradeID)
+		}
+		slices.Sort(kept)
+		if want := []string{"tx-2", "tx-3", "tx-4"}; !slices.Equal(kept, want) {
+			t.Errorf("%s: kept %v, want %v", name, kept, want)
+		}
+
+		// Archived events keep their IDs and can still be filtered
+		archive, err := store.GetArchivedEvents(domain.PolymarketEventFilter{WalletAddress: "0x0", Limit: 10})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(archive) != 1 || archive[0].TradeID != "tx-0" || archive[0].ID != ids["tx-0"] {
+			t.Errorf("%s: archived events of 0x0 = %+v, want tx-0 with its ID", name, archive)
+		}
+		if all, _ := store.GetArchivedEvents(domain.PolymarketEventFilter{}); len(all) != 2 {
+			t.Errorf("%s: archive holds %d events, want 2", name, len(all))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetTradeSamples returns stored trades since the given time, oldest first.
+// The bet count comes from the event when it was recorded at trade time,
+// otherwise from the wallet's latest analysis.
+func (s *PolymarketStore) GetTradeSamples(since time.Time) ([]domain.TradeSample, error) {
+	// Wallets may live in a separate database, so resolve bet counts in Go
+	betCounts, err := s.getWalletBetCounts()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`
+		SELECT timestamp, asset_id, wallet_address, side,
+			COALESCE(CAST(price AS REAL), 0),
+			COALESCE(CAST(price AS REAL) * CAST(size AS REAL), 0),
+			wallet_nonce
+		FROM polymarket_events
+		WHERE event_type = ? AND timestamp >= ?
+			AND wallet_address IS NOT NULL AND wallet_address != ''
+		ORDER BY timestamp ASC`, domain.PolymarketEventTrade, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []domain.TradeSample
+	for rows.Next() {
+		var sample domain.TradeSample
+		var assetID, side sql.NullString
+		var walletNonce sql.NullInt64
+		if err := rows.Scan(&sample.Timestamp, &assetID, &sample.WalletAddress, &side,
+			&sample.Price, &sample.Notional, &walletNonce); err != nil {
+			continue
+		}
+		sample.AssetID = assetID.String
+		sample.Side = domain.OrderSide(side.String)
+		sample.BetCount = -1
+		if walletNonce.Valid {
+			sample.BetCount = int(walletNonce.Int64)
+		} else if betCount, ok := betCounts[sample.WalletAddress]; ok {
+			sample.BetCount = betCount
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples, nil
+}
+
+// getWalletBetCounts returns the latest bet count of every analyzed wallet
+func (s *PolymarketStore) getWalletBetCounts() (map[string]int, error) {
+	rows, err := s.analysisDB.Query(`SELECT address, bet_count FROM polymarket_wallets WHERE bet_count >= 0`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	betCounts := make(map[string]int)
+	for rows.Next() {
+		var address string
+		var betCount int
+		if err := rows.Scan(&address, &betCount); err != nil {
+			continue
+		}
+		betCounts[address] = betCount
+	}
+	return betCounts, nil
+}
+
+// GetWalletActivitySince summarizes the wallet's stored trades after the given time.
+// New markets are those the wallet hadn't traded at or before it.
+func (s *PolymarketStore) GetWalletActivitySince(wallet string, since time.Time) (*domain.WalletActivityDiff, error) {
+	diff := &domain.WalletActivityDiff{WalletAddress: wallet, LastAlertAt: since}
+	err := s.db.QueryRow(`
+		WITH trades AS (
+			SELECT timestamp, COALESCE(NULLIF(market_slug, ''), asset_id) AS market,
+				COALESCE(CAST(price AS REAL) * CAST(size AS REAL), 0) AS notional
+			FROM polymarket_events
+			WHERE wallet_address = ? AND event_type = ?
+		)
+		SELECT COUNT(*), COALESCE(SUM(notional), 0), COUNT(DISTINCT market),
+			COUNT(DISTINCT CASE WHEN market NOT IN (SELECT market FROM trades WHERE timestamp <= ?) THEN market END)
+		FROM trades WHERE timestamp > ?`,
+		wallet, domain.PolymarketEventTrade, since, since).Scan(&diff.Trades, &diff.Volume, &diff.Markets, &diff.NewMarkets)
+	if err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
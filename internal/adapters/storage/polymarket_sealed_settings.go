@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+const (
+	// sealedPrefix marks a value encrypted with the settings key
+	sealedPrefix = "enc1:"
+
+	// Settings rows describing the settings key, kept in the clear
+	sealingSaltKey  = "_sealing_salt"
+	sealingCheckKey = "_sealing_check"
+
+	// sealingCheckValue is sealed under sealingCheckKey to recognize the right key
+	sealingCheckValue = "xtools-settings"
+)
+
+// errSealedValue is returned when reading an encrypted value without the settings key
+var errSealedValue = errors.New("value is encrypted; start with the database key (XTOOLS_DB_KEY) to read it")
+
+// valueSealer encrypts settings and imported wallet intel with AES-256-GCM, so bot
+// tokens, webhook secrets, signing keys and shared intel stay protected in the open
+// database file, its backups and copies left by a crash
+type valueSealer struct {
+	aead cipher.AEAD
+}
+
+// initSealer derives the settings key from a passphrase and the database's salt,
+// checks it against the values sealed before and encrypts values still in the clear.
+// Without a passphrase, values are read and written as they are.
+func (s *PolymarketStore) initSealer(passphrase string) error {
+	if passphrase == "" {
+		var sealed int
+		s.analysisDB.QueryRow(`SELECT COUNT(*) FROM polymarket_settings WHERE value LIKE ?`, sealedPrefix+"%").Scan(&sealed)
+		if sealed > 0 {
+			log.Printf("[Storage] %d settings are encrypted and unreadable without the database key", sealed)
+		}
+		return nil
+	}
+
+	salt, err := s.sealingSalt()
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	sealer := &valueSealer{aead: aead}
+
+	var check string
+	err = s.analysisDB.QueryRow(`SELECT value FROM polymarket_settings WHERE key = ?`, sealingCheckKey).Scan(&check)
+	switch {
+	case err == sql.ErrNoRows:
+		sealedCheck, err := sealer.seal(sealingCheckValue)
+		if err != nil {
+			return err
+		}
+		if err := s.saveRawSetting(sealingCheckKey, sealedCheck); err != nil {
+			return fmt.Errorf("failed to save settings key check: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to read settings key check: %w", err)
+	default:
+		if value, err := sealer.open(check); err != nil || value != sealingCheckValue {
+			return fmt.Errorf("the database key doesn't match the one the settings were encrypted with")
+		}
+	}
+
+	s.sealer = sealer
+	return s.sealPlaintextValues()
+}
+
+// sealingSalt returns the random salt of the settings key, created on first use
+func (s *PolymarketStore) sealingSalt() ([]byte, error) {
+	var encoded string
+	err := s.analysisDB.QueryRow(`SELECT value FROM polymarket_settings WHERE key = ?`, sealingSaltKey).Scan(&encoded)
+	if err == nil {
+		return hex.DecodeString(encoded)
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to read settings salt: %w", err)
+	}
+
+	salt := make([]byte, encryptedSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if err := s.saveRawSetting(sealingSaltKey, hex.EncodeToString(salt)); err != nil {
+		return nil, fmt.Errorf("failed to save settings salt: %w", err)
+	}
+	return salt, nil
+}
+
+// sealPlaintextValues encrypts settings and wallet intel written without the key
+func (s *PolymarketStore) sealPlaintextValues() error {
+	settings, err := s.plaintextRows(`SELECT key, value FROM polymarket_settings WHERE key NOT IN (?, ?)`, sealingSaltKey, sealingCheckKey)
+	if err != nil {
+		return fmt.Errorf("failed to read settings to encrypt: %w", err)
+	}
+	intel, err := s.plaintextRows(`SELECT rowid, intel FROM wallet_intel`)
+	if err != nil {
+		return fmt.Errorf("failed to read wallet intel to encrypt: %w", err)
+	}
+	history, err := s.plaintextRows(`SELECT id, value FROM settings_history`)
+	if err != nil {
+		return fmt.Errorf("failed to read settings history to encrypt: %w", err)
+	}
+	if len(settings) == 0 && len(intel) == 0 && len(history) == 0 {
+		return nil
+	}
+
+	tx, err := s.analysisDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, rows := range []struct {
+		query  string
+		values map[string]string
+	}{
+		{`UPDATE polymarket_settings SET value = ? WHERE key = ?`, settings},
+		{`UPDATE wallet_intel SET intel = ? WHERE rowid = ?`, intel},
+		{`UPDATE settings_history SET value = ? WHERE id = ?`, history},
+	} {
+		for id, value := range rows.values {
+			sealed, err := s.sealer.seal(value)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(rows.query, sealed, id); err != nil {
+				return fmt.Errorf("failed to encrypt stored value: %w", err)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Printf("[Storage] Encrypted %d settings, %d previous settings values and %d wallet intel entries",
+		len(settings), len(history), len(intel))
+	return nil
+}
+
+// plaintextRows returns the values of a two-column query not sealed yet, by the first column
+func (s *PolymarketStore) plaintextRows(query string, args ...any) (map[string]string, error) {
+	rows, err := s.analysisDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make(map[string]string)
+	for rows.Next() {
+		var id, value string
+		if err := rows.Scan(&id, &value); err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(value, sealedPrefix) {
+			values[id] = value
+		}
+	}
+	return values, rows.Err()
+}
+
+// saveRawSetting writes a settings row as is, bypassing the sealer
+func (s *PolymarketStore) saveRawSetting(key, value string) error {
+	_, err := s.analysisDB.Exec(`
+		INSERT INTO polymarket_settings (key, value, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(key) DO UPDATE SET value = ?, updated_at = CURRENT_TIMESTAMP`,
+		key, value, value)
+	return err
+}
+
+// sealValue encrypts a value for storage when the store has a settings key
+func (s *PolymarketStore) sealValue(value string) (string, error) {
+	if s.sealer == nil {
+		return value, nil
+	}
+	return s.sealer.seal(value)
+}
+
+// openValue decrypts a stored value; values written without a key are returned as is
+func (s *PolymarketStore) openValue(value string) (string, error) {
+	if !strings.HasPrefix(value, sealedPrefix) {
+		return value, nil
+	}
+	if s.sealer == nil {
+		return "", errSealedValue
+	}
+	return s.sealer.open(value)
+}
+
+// seal encrypts a value as sealedPrefix followed by the base64 nonce and ciphertext
+func (v *valueSealer) seal(value string) (string, error) {
+	nonce := make([]byte, v.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := v.aead.Seal(nonce, nonce, []byte(value), nil)
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts a value produced by seal
+func (v *valueSealer) open(value string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, sealedPrefix))
+	if err != nil || len(data) < v.aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	plain, err := v.aead.Open(nil, data[:v.aead.NonceSize()], data[v.aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return string(plain), nil
+}
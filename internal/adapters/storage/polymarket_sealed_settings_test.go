@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSettingsKeyEncryptsStoredSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xtools.db")
+	open := func(key string) (*PolymarketStore, error) {
+		return NewPolymarketStoreWithOptions(path, PolymarketStoreOptions{SettingsKey: key})
+	}
+	rawSetting := func(store *PolymarketStore, key string) string {
+		var value string
+		store.analysisDB.QueryRow("SELECT value FROM polymarket_settings WHERE key = ?", key).Scan(&value)
+		return value
+	}
+
+	// Settings written without a key are encrypted once the store opens with one
+	store, err := open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.SaveSetting("telegram", map[string]string{"botToken": "123:secret"})
+	store.Close()
+
+	store, err = open("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw := rawSetting(store, "telegram"); !strings.HasPrefix(raw, sealedPrefix) || strings.Contains(raw, "secret") {
+		t.Errorf("stored setting = %q, want it encrypted", raw)
+	}
+	store.SaveSetting("webhook", "hook-secret")
+	var telegram map[string]string
+	var webhook string
+	if err := store.LoadSetting("telegram", &telegram); err != nil || telegram["botToken"] != "123:secret" {
+		t.Errorf("telegram = %v, %v, want the bot token decrypted", telegram, err)
+	}
+	if err := store.LoadSetting("webhook", &webhook); err != nil || webhook != "hook-secret" {
+		t.Errorf("webhook = %q, %v, want the secret decrypted", webhook, err)
+	}
+	if raw := rawSetting(store, "webhook"); !strings.HasPrefix(raw, sealedPrefix) {
+		t.Errorf("setting saved with a key = %q, want it encrypted", raw)
+	}
+	store.Close()
+
+	// A wrong key is refused, and without one the values can't be read
+	if store, err := open("wrong"); err == nil {
+		store.Close()
+		t.Error("opened with the wrong key")
+	}
+	store, err = open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if err := store.LoadSetting("webhook", &webhook); !errors.Is(err, errSealedValue) {
+		t.Errorf("LoadSetting without the key = %v, want %v", err, errSealedValue)
+	}
+}
+
+func TestLoadDatabaseKeySources(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "db.key")
+	if err := os.WriteFile(keyFile, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(databaseKeyFileEnv, keyFile)
+	t.Setenv(databaseKeyEnv, "from-env")
+	if key, source := LoadDatabaseKey(); key != "from-env" || source != DatabaseKeySourceEnv {
+		t.Errorf("LoadDatabaseKey() = %q, %q, want the environment variable first", key, source)
+	}
+	t.Setenv(databaseKeyEnv, "")
+	if key, source := LoadDatabaseKey(); key != "from-file" || source != DatabaseKeySourceFile {
+		t.Errorf("LoadDatabaseKey() = %q, %q, want the key file without its newline", key, source)
+	}
+}
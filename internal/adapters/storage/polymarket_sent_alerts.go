@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateSentAlerts creates the table of delivered alerts tracked for acknowledgment
+func (s *PolymarketStore) migrateSentAlerts() error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS sent_alerts (
+			id TEXT PRIMARY KEY,
+			event_type TEXT NOT NULL,
+			title TEXT NOT NULL,
+			message TEXT NOT NULL,
+			priority TEXT NOT NULL DEFAULT '',
+			sent_at DATETIME NOT NULL,
+			acked_at DATETIME,
+			acked_by TEXT NOT NULL DEFAULT '',
+			repings INTEGER NOT NULL DEFAULT 0,
+			last_ping_at DATETIME NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_sent_alerts_sent ON sent_alerts(sent_at)`,
+	}
+	for _, t := range tables {
+		if _, err := s.analysisDB.Exec(t); err != nil {
+			return fmt.Errorf("failed to create sent alerts table: %w", err)
+		}
+	}
+	return nil
+}
+
+// SaveSentAlert inserts or replaces a tracked alert and its acknowledgment state
+func (s *PolymarketStore) SaveSentAlert(alert domain.SentAlert) error {
+	var ackedAt sql.NullTime
+	if alert.Acked() {
+		ackedAt = sql.NullTime{Time: alert.AckedAt.UTC(), Valid: true}
+	}
+	_, err := s.analysisDB.Exec(`
+		INSERT OR REPLACE INTO sent_alerts
+			(id, event_type, title, message, priority, sent_at, acked_at, acked_by, repings, last_ping_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		alert.ID, string(alert.EventType), alert.Title, alert.Message, alert.Priority, alert.SentAt.UTC(),
+		ackedAt, alert.AckedBy, alert.Repings, alert.LastPingAt.UTC())
+	if err != nil {
+		return storeError("save sent alert", err)
+	}
+	return nil
+}
+
+// GetSentAlerts returns the newest tracked alerts, up to limit, oldest first
+func (s *PolymarketStore) GetSentAlerts(limit int) ([]domain.SentAlert, error) {
+	rows, err := s.analysisDB.Query(`
+		SELECT id, event_type, title, message, priority, sent_at, acked_at, acked_by, repings, last_ping_at
+		FROM (SELECT rowid AS seq, * FROM sent_alerts ORDER BY sent_at DESC, rowid DESC LIMIT ?)
+		ORDER BY sent_at, seq`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerts := []domain.SentAlert{}
+	for rows.Next() {
+		var a domain.SentAlert
+		var eventType string
+		var ackedAt sql.NullTime
+		if err := rows.Scan(&a.ID, &eventType, &a.Title, &a.Message, &a.Priority, &a.SentAt,
+			&ackedAt, &a.AckedBy, &a.Repings, &a.LastPingAt); err != nil {
+			return nil, err
+		}
+		a.EventType = domain.NotificationEventType(eventType)
+		if ackedAt.Valid {
+			a.AckedAt = ackedAt.Time
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
+
+// DeleteSentAlert stops tracking an alert
+func (s *PolymarketStore) DeleteSentAlert(id string) error {
+	if _, err := s.analysisDB.Exec(`DELETE FROM sent_alerts WHERE id = ?`, id); err != nil {
+		return storeError("delete sent alert", err)
+	}
+	return nil
+}
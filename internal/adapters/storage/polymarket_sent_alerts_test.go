@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestSentAlertsRoundTrip(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	sentAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		for i := range 3 {
+			alert := domain.SentAlert{
+				ID: fmt.Sprintf("alert-%d", i), EventType: domain.NotificationEventBigTrade,
+				Title: "Big trade", Message: "<b>$50K</b>", Priority: "high",
+				SentAt: sentAt.Add(time.Duration(i) * time.Minute), LastPingAt: sentAt,
+			}
+			if err := store.SaveSentAlert(alert); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		acked := domain.SentAlert{
+			ID: "alert-1", EventType: domain.NotificationEventBigTrade, Title: "Big trade",
+			Message: "<b>$50K</b>", Priority: "high", SentAt: sentAt.Add(time.Minute),
+			AckedAt: sentAt.Add(2 * time.Minute), AckedBy: "telegram:@alice", Repings: 1, LastPingAt: sentAt,
+		}
+		if err := store.SaveSentAlert(acked); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := store.DeleteSentAlert("alert-0"); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		alerts, err := store.GetSentAlerts(10)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(alerts) != 2 || alerts[0].ID != "alert-1" || alerts[1].ID != "alert-2" {
+			t.Fatalf("%s: alerts = %+v, want alert-1 and alert-2, oldest first", name, alerts)
+		}
+		if got := alerts[0]; got.AckedBy != "telegram:@alice" || !got.AckedAt.Equal(acked.AckedAt) || got.Repings != 1 {
+			t.Errorf("%s: acked alert = %+v, want the saved acknowledgment", name, got)
+		}
+		if alerts[1].Acked() {
+			t.Errorf("%s: unacknowledged alert came back acknowledged", name)
+		}
+
+		if newest, _ := store.GetSentAlerts(1); len(newest) != 1 || newest[0].ID != "alert-2" {
+			t.Errorf("%s: GetSentAlerts(1) = %+v, want the newest alert", name, newest)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// settingHistoryPerKey is how many previous values are kept per settings key, oldest
+// dropped first
+const settingHistoryPerKey = 50
+
+// migrateSettingHistory creates the table of overwritten settings values
+func (s *PolymarketStore) migrateSettingHistory() error {
+	tables := []string{
+		`CREATE TABLE IF NOT EXISTS settings_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			key TEXT NOT NULL,
+			value TEXT NOT NULL,
+			replaced_at DATETIME NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_settings_history_key ON settings_history(key, id DESC)`,
+	}
+	for _, t := range tables {
+		if _, err := s.analysisDB.Exec(t); err != nil {
+			return fmt.Errorf("failed to create settings history table: %w", err)
+		}
+	}
+	return nil
+}
+
+// saveSettingData writes a setting's JSON, moving the value it replaces into the
+// settings history. Rewriting the same value records nothing.
+func (s *PolymarketStore) saveSettingData(key, data string) error {
+	stored, err := s.sealValue(data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt setting: %w", err)
+	}
+
+	tx, err := s.analysisDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var previous string
+	err = tx.QueryRow(`SELECT value FROM polymarket_settings WHERE key = ?`, key).Scan(&previous)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return fmt.Errorf("failed to read setting %s: %w", key, err)
+	default:
+		// Sealed values differ on every write, so compare what they hold
+		if opened, err := s.openValue(previous); err != nil || opened != data {
+			if _, err := tx.Exec(`INSERT INTO settings_history (key, value, replaced_at) VALUES (?, ?, ?)`,
+				key, previous, time.Now().UTC()); err != nil {
+				return fmt.Errorf("failed to record setting history: %w", err)
+			}
+			if _, err := tx.Exec(`DELETE FROM settings_history WHERE key = ? AND id NOT IN (
+				SELECT id FROM settings_history WHERE key = ? ORDER BY id DESC LIMIT ?)`,
+				key, key, settingHistoryPerKey); err != nil {
+				return fmt.Errorf("failed to prune setting history: %w", err)
+			}
+		}
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO polymarket_settings (key, value, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(key) DO UPDATE SET value = ?, updated_at = CURRENT_TIMESTAMP`,
+		key, stored, stored); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetSettingHistory returns the previous values of a settings key, or of every key
+// when key is empty, newest first
+func (s *PolymarketStore) GetSettingHistory(key string, limit int) ([]domain.SettingHistoryEntry, error) {
+	query := `SELECT id, key, value, replaced_at FROM settings_history`
+	var args []any
+	if key != "" {
+		query += ` WHERE key = ?`
+		args = append(args, key)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := s.analysisDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []domain.SettingHistoryEntry{}
+	for rows.Next() {
+		var entry domain.SettingHistoryEntry
+		if err := rows.Scan(&entry.ID, &entry.Key, &entry.Value, &entry.ReplacedAt); err != nil {
+			return nil, err
+		}
+		if entry.Value, err = s.openValue(entry.Value); err != nil {
+			return nil, fmt.Errorf("setting %s: %w", entry.Key, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// RestoreSetting writes a previous value back to its key. The value it replaces goes
+// into the history, so a restore can be undone the same way.
+func (s *PolymarketStore) RestoreSetting(id int64) (*domain.SettingHistoryEntry, error) {
+	var entry domain.SettingHistoryEntry
+	err := s.analysisDB.QueryRow(`SELECT id, key, value, replaced_at FROM settings_history WHERE id = ?`, id).
+		Scan(&entry.ID, &entry.Key, &entry.Value, &entry.ReplacedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no setting history entry %d", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.Value, err = s.openValue(entry.Value); err != nil {
+		return nil, fmt.Errorf("setting %s: %w", entry.Key, err)
+	}
+	if err := s.saveSettingData(entry.Key, entry.Value); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestSettingHistoryKeepsOverwrittenValues(t *testing.T) {
+	sqlite, err := NewPolymarketStoreWithOptions(filepath.Join(t.TempDir(), "xtools.db"), PolymarketStoreOptions{SettingsKey: "passphrase"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		store.SaveSetting("threshold", 1)
+		store.SaveSetting("threshold", 2)
+		store.SaveSetting("threshold", 2) // Unchanged: not recorded
+		store.SaveSetting("threshold", 3)
+		store.SaveSetting("other", "a")
+		store.SaveSetting("other", "b")
+
+		history, err := store.GetSettingHistory("threshold", 10)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(history) != 2 || history[0].Value != "2" || history[1].Value != "1" || history[0].ReplacedAt.IsZero() {
+			t.Fatalf("%s: history = %+v, want 2 then 1", name, history)
+		}
+		if all, _ := store.GetSettingHistory("", 10); len(all) != 3 || all[0].Key != "other" {
+			t.Errorf("%s: history of every key = %+v, want 3 entries, newest first", name, all)
+		}
+		if limited, _ := store.GetSettingHistory("", 1); len(limited) != 1 {
+			t.Errorf("%s: limited history = %+v, want 1 entry", name, limited)
+		}
+
+		// Restoring writes the old value back and records the one it replaces
+		restored, err := store.RestoreSetting(history[1].ID)
+		if err != nil || restored.Key != "threshold" || restored.Value != "1" {
+			t.Fatalf("%s: restored %+v, %v, want threshold 1", name, restored, err)
+		}
+		var value int
+		if err := store.LoadSetting("threshold", &value); err != nil || value != 1 {
+			t.Errorf("%s: threshold = %d, %v, want 1", name, value, err)
+		}
+		if history, _ := store.GetSettingHistory("threshold", 10); len(history) != 3 || history[0].Value != "3" {
+			t.Errorf("%s: history after restoring = %+v, want 3 recorded", name, history)
+		}
+		if _, err := store.RestoreSetting(9999); err == nil {
+			t.Errorf("%s: restored an unknown entry", name)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveSetting saves a setting to the database, keeping the value it replaces in the
+// settings history
+func (s *PolymarketStore) SaveSetting(key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal setting: %w", err)
+	}
+	return s.saveSettingData(key, string(data))
+}
+
+// LoadSetting loads a setting from the database
+func (s *PolymarketStore) LoadSetting(key string, dest any) error {
+	var value string
+	err := s.analysisDB.QueryRow("SELECT value FROM polymarket_settings WHERE key = ?", key).Scan(&value)
+	if err != nil {
+		return err
+	}
+	if value, err = s.openValue(value); err != nil {
+		return fmt.Errorf("setting %s: %w", key, err)
+	}
+	return json.Unmarshal([]byte(value), dest)
+}
+
+// SaveConfig saves the Polymarket config to the database
+func (s *PolymarketStore) SaveConfig(config domain.PolymarketConfig) error {
+	return s.SaveSetting("config", config)
+}
+
+// LoadConfig loads the Polymarket config from the database
+func (s *PolymarketStore) LoadConfig() (domain.PolymarketConfig, error) {
+	var config domain.PolymarketConfig
+	err := s.LoadSetting("config", &config)
+	if err != nil {
+		return domain.DefaultPolymarketConfig(), err
+	}
+	return config, nil
+}
+
+// SaveFilter saves the event filter to the database
+func (s *PolymarketStore) SaveFilter(filter domain.PolymarketEventFilter) error {
+	return s.SaveSetting("filter", filter)
+}
+
+// LoadFilter loads the event filter from the database
+func (s *PolymarketStore) LoadFilter() (domain.PolymarketEventFilter, error) {
+	var filter domain.PolymarketEventFilter
+	err := s.LoadSetting("filter", &filter)
+	if err != nil {
+		return domain.PolymarketEventFilter{MinSize: 100}, err
+	}
+	return filter, nil
+}
+
+// SaveNotificationConfig saves the notification config to the database
+func (s *PolymarketStore) SaveNotificationConfig(config domain.NotificationConfig) error {
+	return s.SaveSetting(domain.NotificationConfigSettingKey, config)
+}
+
+// LoadNotificationConfig loads the notification config from the database
+func (s *PolymarketStore) LoadNotificationConfig() (domain.NotificationConfig, error) {
+	var config domain.NotificationConfig
+	err := s.LoadSetting(domain.NotificationConfigSettingKey, &config)
+	if err != nil {
+		return domain.DefaultNotificationConfig(), err
+	}
+	return config, nil
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+const insertEventSQL = `
+	INSERT INTO polymarket_events (
+		event_type, asset_id, market_slug, market_name, market_image, market_link,
+		timestamp, raw_data, price, size, side, best_bid, best_ask, fee_rate_bps,
+		trade_id, wallet_address, outcome, outcome_index, event_slug, event_title,
+		trader_name, condition_id, is_fresh_wallet, wallet_nonce, risk_score,
+		risk_signals, fresh_wallet_signal
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	` + tradeIDConflict
+
+// hotStatements are the statements run for every incoming trade, parsed once when the
+// store opens instead of on each call
+type hotStatements struct {
+	insertEvent  *sql.Stmt // On the events database
+	tagEvent     *sql.Stmt // On the events database
+	saveWallet   *sql.Stmt // On the analysis database
+	saveAddress  *sql.Stmt // On the analysis database
+	getWallet    *sql.Stmt // On the analysis database
+	hasNotified  *sql.Stmt // On the analysis database
+	markNotified *sql.Stmt // On the analysis database
+}
+
+// prepareStatements prepares the hot statements. Tables must exist, so it runs after
+// the migrations.
+func (s *PolymarketStore) prepareStatements() error {
+	var err error
+	prepare := func(db *sql.DB, query string) *sql.Stmt {
+		if err != nil {
+			return nil
+		}
+		var stmt *sql.Stmt
+		stmt, err = db.Prepare(query)
+		return stmt
+	}
+
+	s.stmts = hotStatements{
+		insertEvent: prepare(s.db, insertEventSQL),
+		tagEvent:    prepare(s.db, `INSERT OR IGNORE INTO event_tags (event_id, tag) VALUES (?, ?)`),
+		saveWallet: prepare(s.analysisDB, `
+			INSERT INTO polymarket_wallets (address, bet_count, join_date, freshness_level, is_fresh, first_seen_at, last_analyzed_at, data_source)
+			VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?)
+			ON CONFLICT(address) DO UPDATE SET
+				bet_count = ?,
+				join_date = ?,
+				freshness_level = ?,
+				is_fresh = ?,
+				last_analyzed_at = ?,
+				data_source = ?`),
+		saveAddress: prepare(s.analysisDB, `
+			INSERT INTO polymarket_wallets (address, bet_count, first_seen_at)
+			VALUES (?, -1, CURRENT_TIMESTAMP)
+			ON CONFLICT(address) DO NOTHING`),
+		getWallet: prepare(s.analysisDB, `
+			SELECT address, bet_count, join_date, freshness_level, is_fresh, first_seen_at, last_analyzed_at, data_source
+			FROM polymarket_wallets WHERE address = ?`),
+		hasNotified: prepare(s.analysisDB, `
+			SELECT COUNT(*) FROM notified_items
+			WHERE item_type = ? AND item_id = ?`),
+		markNotified: prepare(s.analysisDB, `
+			INSERT OR IGNORE INTO notified_items (item_type, item_id, notified_at)
+			VALUES (?, ?, CURRENT_TIMESTAMP)`),
+	}
+	if err != nil {
+		s.stmts.close()
+		return fmt.Errorf("failed to prepare statements: %w", err)
+	}
+	return nil
+}
+
+// close releases the prepared statements; unprepared ones are nil and skipped. Closed
+// statements stay in place so calls after Close fail with an error rather than panic.
+func (h *hotStatements) close() {
+	for _, stmt := range []*sql.Stmt{h.insertEvent, h.tagEvent, h.saveWallet, h.saveAddress, h.getWallet, h.hasNotified, h.markNotified} {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestHotStatementsInSingleAndSplitStores(t *testing.T) {
+	dir := t.TempDir()
+	single, err := NewPolymarketStore(filepath.Join(dir, "single.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	split, err := NewPolymarketStoreWithOptions(filepath.Join(dir, "events.db"), PolymarketStoreOptions{AnalysisDBPath: filepath.Join(dir, "analysis.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, store := range map[string]*PolymarketStore{"single": single, "split": split} {
+		// Each statement runs more than once, as on the live feed
+		for i, want := range []bool{true, false} {
+			if isNew, err := store.SaveWalletAddress("0xa"); err != nil || isNew != want {
+				t.Errorf("%s: SaveWalletAddress #%d = %v, %v, want %v", name, i+1, isNew, err, want)
+			}
+		}
+		for _, bets := range []int{3, 5} {
+			if err := store.SaveWallet(domain.WalletProfile{Address: "0xa", BetCount: bets, JoinDate: "Jan 2026", IsFresh: true, AnalyzedAt: time.Now()}); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		store.SaveWalletAddress("0xa")
+		if wallet, err := store.GetWallet("0xa"); err != nil || wallet == nil || wallet.BetCount != 5 || !wallet.IsFresh {
+			t.Errorf("%s: wallet = %+v, %v, want the updated profile kept", name, wallet, err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if err := store.MarkNotified("trade", "tx"); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		if notified, err := store.HasNotified("trade", "tx"); err != nil || !notified {
+			t.Errorf("%s: HasNotified = %v, %v, want true", name, notified, err)
+		}
+		for i := 0; i < 2; i++ {
+			store.SaveEvent(domain.PolymarketEvent{EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprint(i), AssetID: "1", Tags: []string{"whale"}})
+		}
+		if count, _ := store.GetEventCount(domain.PolymarketEventFilter{Tag: "whale"}); count != 2 {
+			t.Errorf("%s: %d tagged events, want 2", name, count)
+		}
+
+		store.Close()
+		if err := store.SaveEvent(benchTrade(1)); err == nil {
+			t.Errorf("%s: saved an event after Close", name)
+		}
+	}
+}
+
+// newBenchStore opens a SQLite store in a temporary directory
+func newBenchStore(b *testing.B) *PolymarketStore {
+	b.Helper()
+	store, err := NewPolymarketStore(filepath.Join(b.TempDir(), "xtools.db"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { store.Close() })
+	return store
+}
+
+// benchTrade is a trade as the feed delivers it, unique per i
+func benchTrade(i int) domain.PolymarketEvent {
+	return domain.PolymarketEvent{
+		EventType:     domain.PolymarketEventTrade,
+		AssetID:       "71321045679252212594626385532706912750332728571942532289631379312455583992563",
+		MarketSlug:    "us-recession-in-2025",
+		MarketName:    "US recession in 2025?",
+		Timestamp:     time.Unix(1760000000+int64(i), 0),
+		RawData:       `{"topic":"activity","type":"trades","payload":{"price":0.27,"size":1250.5}}`,
+		Price:         "0.27",
+		Size:          "1250.5",
+		Side:          domain.OrderSideBuy,
+		TradeID:       fmt.Sprintf("0x%064x", i),
+		WalletAddress: fmt.Sprintf("0x%040x", i%5000),
+		Outcome:       "Yes",
+		EventSlug:     "us-recession-in-2025",
+		EventTitle:    "US recession in 2025?",
+		ConditionID:   "0x5f65177b394277fd294cd75650044e32ba009a95022d88a0c1d565897d72f8f1",
+	}
+}
+
+func BenchmarkSaveEvent(b *testing.B) {
+	store := newBenchStore(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := store.SaveEvent(benchTrade(i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSaveWalletAddress(b *testing.B) {
+	store := newBenchStore(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Mostly wallets seen before, as on the live feed
+		if _, err := store.SaveWalletAddress(fmt.Sprintf("0x%040x", i%1000)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHasNotified(b *testing.B) {
+	store := newBenchStore(b)
+	for i := 0; i < 1000; i++ {
+		if err := store.MarkNotified("trade", fmt.Sprint(i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Half the lookups hit
+		if _, err := store.HasNotified("trade", fmt.Sprint(i%2000)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
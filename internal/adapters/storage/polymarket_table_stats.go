@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetTableStats returns the row counts of every table and their sizes when SQLite has
+// the dbstat table, largest first. It scans the whole database, so only the database
+// view asks for it.
+func (s *PolymarketStore) GetTableStats() ([]domain.DatabaseTableStats, error) {
+	var stats []domain.DatabaseTableStats
+	for _, db := range s.databases() {
+		database := ""
+		if db != s.db {
+			database = "analysis"
+		}
+		tables, err := tableStats(db, database)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, tables...)
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.SizeBytes != b.SizeBytes {
+			return a.SizeBytes > b.SizeBytes
+		}
+		return a.Rows > b.Rows
+	})
+	return stats, nil
+}
+
+// addEventTimeRange fills the time range of stored events into database info
+func (s *PolymarketStore) addEventTimeRange(info *domain.DatabaseInfo) error {
+	for _, bound := range []struct {
+		order string
+		dest  *time.Time
+	}{
+		{"ASC", &info.OldestEventAt},
+		{"DESC", &info.NewestEventAt},
+	} {
+		err := s.db.QueryRow("SELECT timestamp FROM polymarket_events ORDER BY timestamp " + bound.order + " LIMIT 1").Scan(bound.dest)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to read event time range: %w", err)
+		}
+	}
+	return nil
+}
+
+// tableStats counts the rows of a database's tables and lists their indexes
+func tableStats(db *sql.DB, database string) ([]domain.DatabaseTableStats, error) {
+	rows, err := db.Query(`SELECT type, name, tbl_name FROM sqlite_master
+		WHERE type IN ('table', 'index') AND tbl_name NOT LIKE 'sqlite_%' ORDER BY type DESC, name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	var tables []domain.DatabaseTableStats
+	index := make(map[string]int)
+	for rows.Next() {
+		var kind, name, table string
+		if err := rows.Scan(&kind, &name, &table); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if kind == "table" {
+			index[name] = len(tables)
+			tables = append(tables, domain.DatabaseTableStats{Name: name, Database: database})
+		} else if i, ok := index[table]; ok {
+			tables[i].Indexes = append(tables[i].Indexes, domain.DatabaseIndexStats{Name: name})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sizes := objectSizes(db)
+	for i := range tables {
+		t := &tables[i]
+		if err := db.QueryRow(`SELECT COUNT(*) FROM "` + strings.ReplaceAll(t.Name, `"`, `""`) + `"`).Scan(&t.Rows); err != nil {
+			return nil, fmt.Errorf("failed to count rows of %s: %w", t.Name, err)
+		}
+		t.SizeBytes = sizes[t.Name]
+		for j := range t.Indexes {
+			t.Indexes[j].SizeBytes = sizes[t.Indexes[j].Name]
+			t.IndexSizeBytes += t.Indexes[j].SizeBytes
+		}
+	}
+	return tables, nil
+}
+
+// objectSizes returns the bytes used by each table and index from the dbstat virtual
+// table, or nil when SQLite was built without it (SQLITE_ENABLE_DBSTAT_VTAB)
+func objectSizes(db *sql.DB) map[string]int64 {
+	rows, err := db.Query("SELECT name, SUM(pgsize) FROM dbstat GROUP BY name")
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	sizes := make(map[string]int64)
+	for rows.Next() {
+		var name string
+		var size int64
+		if rows.Scan(&name, &size) == nil {
+			sizes[name] = size
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// tradeIDIndex makes each fill unique, so trades the feed redelivers after a reconnect
+// are saved once. The trade ID is the transaction hash, which every fill of a matched
+// order shares, so the wallet and outcome token are part of the key. Events without a
+// trade ID (book updates, price changes) are exempt.
+const tradeIDIndex = `CREATE UNIQUE INDEX IF NOT EXISTS idx_polymarket_trade_key
+	ON polymarket_events(trade_id, wallet_address, asset_id)
+	WHERE trade_id IS NOT NULL AND trade_id != ''`
+
+// tradeIDConflict is the ON CONFLICT target matching tradeIDIndex
+const tradeIDConflict = `ON CONFLICT (trade_id, wallet_address, asset_id) WHERE trade_id IS NOT NULL AND trade_id != '' DO NOTHING`
+
+// duplicateTrade is a saved copy of a trade and the first copy it is merged into
+type duplicateTrade struct {
+	id, keep int64
+}
+
+// migrateTradeDedup removes the duplicate trades saved before trades were unique, once,
+// then creates the unique index. Each trade keeps its first copy, which takes over the
+// tags of the others and their place in investigations.
+func (s *PolymarketStore) migrateTradeDedup() error {
+	var exists int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_polymarket_trade_key'`).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check trade key index: %w", err)
+	}
+	if exists > 0 {
+		return nil
+	}
+	// An earlier version keyed trades by transaction hash alone, dropping all but the
+	// first fill of each transaction
+	if _, err := s.db.Exec(`DROP INDEX IF EXISTS idx_polymarket_trade_id`); err != nil {
+		return fmt.Errorf("failed to drop trade ID index: %w", err)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT e.id, d.keep FROM polymarket_events e
+		JOIN (
+			SELECT trade_id, wallet_address, asset_id, MIN(id) AS keep FROM polymarket_events
+			WHERE trade_id IS NOT NULL AND trade_id != ''
+			GROUP BY trade_id, wallet_address, asset_id HAVING COUNT(*) > 1
+		) d ON e.trade_id = d.trade_id AND e.wallet_address IS d.wallet_address AND e.asset_id IS d.asset_id
+		WHERE e.id != d.keep`)
+	if err != nil {
+		return fmt.Errorf("failed to find duplicate trades: %w", err)
+	}
+	var duplicates []duplicateTrade
+	for rows.Next() {
+		var d duplicateTrade
+		if err := rows.Scan(&d.id, &d.keep); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read duplicate trades: %w", err)
+		}
+		duplicates = append(duplicates, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read duplicate trades: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, d := range duplicates {
+		statements := []string{
+			`INSERT OR IGNORE INTO event_tags (event_id, tag, created_at) SELECT ?2, tag, created_at FROM event_tags WHERE event_id = ?1`,
+			`DELETE FROM event_tags WHERE event_id = ?1`,
+			`DELETE FROM polymarket_events WHERE id = ?1`,
+		}
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement, d.id, d.keep); err != nil {
+				return fmt.Errorf("failed to remove duplicate trade %d: %w", d.id, err)
+			}
+		}
+	}
+	if _, err := tx.Exec(tradeIDIndex); err != nil {
+		return fmt.Errorf("failed to create trade key index: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if len(duplicates) > 0 {
+		s.repointInvestigatedTrades(duplicates)
+		log.Printf("[Storage] Removed %d duplicate trades", len(duplicates))
+	}
+	return nil
+}
+
+// repointInvestigatedTrades moves investigation items from removed duplicates to the
+// copy that was kept. The analysis database may be a separate file, so this runs
+// after the events are committed; a failure leaves items pointing at missing events.
+func (s *PolymarketStore) repointInvestigatedTrades(duplicates []duplicateTrade) {
+	for _, d := range duplicates {
+		id, keep := strconv.FormatInt(d.id, 10), strconv.FormatInt(d.keep, 10)
+		if _, err := s.analysisDB.Exec(`UPDATE OR IGNORE investigation_items SET ref = ? WHERE item_type = ? AND ref = ?`,
+			keep, domain.InvestigationItemEvent, id); err != nil {
+			log.Printf("[Storage] Failed to move investigated trade %s to %s: %v", id, keep, err)
+			continue
+		}
+		// Left over where the investigation already held the kept copy
+		s.analysisDB.Exec(`DELETE FROM investigation_items WHERE item_type = ? AND ref = ?`, domain.InvestigationItemEvent, id)
+	}
+}
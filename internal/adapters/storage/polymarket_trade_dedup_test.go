@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestSaveEventKeepsEveryFillOnce(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		fill := func(wallet, asset string) domain.PolymarketEvent {
+			return domain.PolymarketEvent{
+				EventType: domain.PolymarketEventTrade, TradeID: "0xtx", WalletAddress: wallet, AssetID: asset,
+				Price: "0.5", Size: "100",
+			}
+		}
+		// Both sides of a match share the transaction; the first is redelivered
+		for _, e := range []domain.PolymarketEvent{fill("0xa", "111"), fill("0xb", "111"), fill("0xa", "111")} {
+			if err := store.SaveEvent(e); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		count, err := store.GetEventCount(domain.PolymarketEventFilter{})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if count != 2 {
+			t.Errorf("%s: stored %d trades, want both fills once", name, count)
+		}
+	}
+}
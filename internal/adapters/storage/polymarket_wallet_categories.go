@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateWalletCategories creates the table of market categories each wallet traded
+func (s *PolymarketStore) migrateWalletCategories() error {
+	_, err := s.analysisDB.Exec(`CREATE TABLE IF NOT EXISTS polymarket_wallet_categories (
+		address TEXT NOT NULL,
+		category TEXT NOT NULL,
+		trades INTEGER NOT NULL DEFAULT 0,
+		notional REAL NOT NULL DEFAULT 0,
+		first_at DATETIME NOT NULL,
+		last_at DATETIME NOT NULL,
+		PRIMARY KEY (address, category)
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create wallet categories table: %w", err)
+	}
+	return nil
+}
+
+// RecordWalletCategory counts a wallet's trade in a market category
+func (s *PolymarketStore) RecordWalletCategory(address, category string, notional float64, at time.Time) error {
+	at = at.UTC()
+	_, err := s.analysisDB.Exec(`
+		INSERT INTO polymarket_wallet_categories (address, category, trades, notional, first_at, last_at)
+		VALUES (?, ?, 1, ?, ?, ?)
+		ON CONFLICT(address, category) DO UPDATE SET
+			trades = trades + 1,
+			notional = notional + excluded.notional,
+			first_at = MIN(first_at, excluded.first_at),
+			last_at = MAX(last_at, excluded.last_at)`,
+		strings.ToLower(address), category, notional, at, at)
+	return err
+}
+
+// GetWalletCategories returns the market categories a wallet traded, most traded first
+func (s *PolymarketStore) GetWalletCategories(address string) ([]domain.WalletCategoryStats, error) {
+	rows, err := s.analysisDB.Query(`
+		SELECT category, trades, notional, first_at, last_at FROM polymarket_wallet_categories
+		WHERE address = ? ORDER BY trades DESC, category`, strings.ToLower(address))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []domain.WalletCategoryStats{}
+	for rows.Next() {
+		var c domain.WalletCategoryStats
+		if err := rows.Scan(&c.Category, &c.Trades, &c.Notional, &c.FirstAt, &c.LastAt); err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestWalletCategoriesCountTrades(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	at := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		store.RecordWalletCategory("0xA", "Sports", 100, at)
+		store.RecordWalletCategory("0xa", "Sports", 200, at.Add(-time.Hour))
+		store.RecordWalletCategory("0xa", "Politics", 5000, at.Add(time.Hour))
+		store.RecordWalletCategory("0xb", "Crypto", 1, at)
+
+		categories, err := store.GetWalletCategories("0xA")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(categories) != 2 {
+			t.Fatalf("%s: categories = %+v, want Sports and Politics", name, categories)
+		}
+		sports := categories[0]
+		if sports.Category != "Sports" || sports.Trades != 2 || sports.Notional != 300 ||
+			!sports.FirstAt.Equal(at.Add(-time.Hour)) || !sports.LastAt.Equal(at) {
+			t.Errorf("%s: sports = %+v, want 2 trades for $300 from 11:00 to 12:00", name, sports)
+		}
+		if categories[1].Category != "Politics" || categories[1].Trades != 1 {
+			t.Errorf("%s: second category = %+v, want Politics", name, categories[1])
+		}
+		if none, err := store.GetWalletCategories("0xc"); err != nil || len(none) != 0 {
+			t.Errorf("%s: unknown wallet = %+v, %v, want no categories", name, none, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// migrateWalletIntel creates the table of wallet intel imported from other users
+func (s *PolymarketStore) migrateWalletIntel() error {
+	_, err := s.analysisDB.Exec(`CREATE TABLE IF NOT EXISTS wallet_intel (
+		address TEXT NOT NULL,
+		public_key TEXT NOT NULL,
+		publisher TEXT,
+		intel TEXT NOT NULL,
+		imported_at DATETIME NOT NULL,
+		PRIMARY KEY (address, public_key)
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create wallet intel table: %w", err)
+	}
+	return nil
+}
+
+// TagWallet attaches a label to a wallet; tagging twice is a no-op
+func (s *PolymarketStore) TagWallet(address, tag string) error {
+	tag = domain.NormalizeTag(tag)
+	if tag == "" {
+		return fmt.Errorf("tag is required")
+	}
+	_, err := s.analysisDB.Exec(`INSERT OR IGNORE INTO polymarket_wallet_tags (address, tag) VALUES (?, ?)`,
+		strings.ToLower(address), tag)
+	return err
+}
+
+// UntagWallet removes a label from a wallet
+func (s *PolymarketStore) UntagWallet(address, tag string) error {
+	_, err := s.analysisDB.Exec(`DELETE FROM polymarket_wallet_tags WHERE address = ? AND tag = ?`,
+		strings.ToLower(address), domain.NormalizeTag(tag))
+	return err
+}
+
+// GetWalletTags returns the tags of the given wallets, or of every tagged wallet when
+// none are given, sorted by tag
+func (s *PolymarketStore) GetWalletTags(addresses []string) (map[string][]string, error) {
+	query := `SELECT address, tag FROM polymarket_wallet_tags`
+	var args []any
+	if len(addresses) > 0 {
+		lower := make([]string, len(addresses))
+		for i, address := range addresses {
+			lower[i] = strings.ToLower(address)
+		}
+		query += ` WHERE address IN ` + sqlList(len(lower))
+		args = appendStrings(args, lower)
+	}
+	rows, err := s.analysisDB.Query(query+` ORDER BY address, tag`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make(map[string][]string)
+	for rows.Next() {
+		var address, tag string
+		if err := rows.Scan(&address, &tag); err != nil {
+			return nil, err
+		}
+		tags[address] = append(tags[address], tag)
+	}
+	return tags, rows.Err()
+}
+
+// taggedWallets returns the addresses of the wallets carrying a tag
+func (s *PolymarketStore) taggedWallets(tag string) ([]string, error) {
+	rows, err := s.analysisDB.Query(`SELECT address FROM polymarket_wallet_tags WHERE tag = ?`, domain.NormalizeTag(tag))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var addresses []string
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, rows.Err()
+}
+
+// SaveImportedWalletIntel stores imported wallet intel, replacing what the same
+// publisher key shared about a wallet before
+func (s *PolymarketStore) SaveImportedWalletIntel(intel []domain.ImportedWalletIntel) error {
+	tx, err := s.analysisDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO wallet_intel (address, public_key, publisher, intel, imported_at)
+		VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, item := range intel {
+		data, err := json.Marshal(item.WalletIntel)
+		if err != nil {
+			return err
+		}
+		value, err := s.sealValue(string(data))
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.Exec(strings.ToLower(item.Address), item.PublicKey, item.Publisher, value, item.ImportedAt); err != nil {
+			return fmt.Errorf("failed to save intel on %s: %w", item.Address, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetImportedWalletIntel returns what other users shared about a wallet, most recently
+// imported first
+func (s *PolymarketStore) GetImportedWalletIntel(address string) ([]domain.ImportedWalletIntel, error) {
+	rows, err := s.analysisDB.Query(`SELECT public_key, COALESCE(publisher, ''), intel, imported_at FROM wallet_intel
+		WHERE address = ? ORDER BY imported_at DESC`, strings.ToLower(address))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	intel := []domain.ImportedWalletIntel{}
+	for rows.Next() {
+		var item domain.ImportedWalletIntel
+		var data string
+		if err := rows.Scan(&item.PublicKey, &item.Publisher, &data, &item.ImportedAt); err != nil {
+			return nil, err
+		}
+		if data, err = s.openValue(data); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(data), &item.WalletIntel); err != nil {
+			continue
+		}
+		intel = append(intel, item)
+	}
+	return intel, rows.Err()
+}
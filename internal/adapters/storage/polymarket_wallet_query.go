@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// joinMonthExpr converts join_date ("Dec 2025") into a comparable YYYYMM integer
+const joinMonthExpr = `(CAST(substr(join_date, -4) AS INTEGER) * 100 + (instr('JanFebMarAprMayJunJulAugSepOctNovDec', substr(join_date, 1, 3)) + 2) / 3)`
+
+// walletSortExprs maps sort fields to SQL expressions comparable with a cursor value
+var walletSortExprs = map[domain.WalletSortField]string{
+	domain.WalletSortFirstSeen:    "COALESCE(CAST(first_seen_at AS TEXT), '')",
+	domain.WalletSortLastAnalyzed: "COALESCE(CAST(last_analyzed_at AS TEXT), '')",
+	domain.WalletSortBetCount:     "bet_count",
+	domain.WalletSortAddress:      "address",
+	domain.WalletSortVolume:       "COALESCE(total_volume, 0)",
+}
+
+// walletCursor is the keyset position after the last wallet of a page
+type walletCursor struct {
+	Value   any    `json:"v"`
+	Address string `json:"a"`
+}
+
+func encodeWalletCursor(value any, address string) string {
+	data, _ := json.Marshal(walletCursor{Value: value, Address: address})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeWalletCursor(cursor string) (*walletCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet cursor: %w", err)
+	}
+	var c walletCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid wallet cursor: %w", err)
+	}
+	return &c, nil
+}
+
+// walletPageLimit applies the default and maximum page size
+func walletPageLimit(limit int) int {
+	if limit <= 0 {
+		return 100
+	}
+	if limit > 1000 {
+		return 1000
+	}
+	return limit
+}
+
+// QueryWallets retrieves a filtered, sorted page of wallets using keyset pagination
+func (s *PolymarketStore) QueryWallets(filter domain.WalletFilter) (*domain.WalletPage, error) {
+	sortExpr, ok := walletSortExprs[filter.SortBy]
+	if !ok {
+		sortExpr = walletSortExprs[domain.WalletSortFirstSeen]
+	}
+	limit := walletPageLimit(filter.Limit)
+
+	where, args := walletFilterClauses(filter)
+
+	if filter.Cursor != "" {
+		cursor, err := decodeWalletCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cmp := ">"
+		if filter.SortDesc {
+			cmp = "<"
+		}
+		where = append(where, fmt.Sprintf("(%s %s ? OR (%s = ? AND address %s ?))", sortExpr, cmp, sortExpr, cmp))
+		args = append(args, cursor.Value, cursor.Value, cursor.Address)
+	}
+
+	query := `SELECT address, bet_count, join_date, freshness_level, is_fresh, first_seen_at, last_analyzed_at, data_source, ` +
+		sortExpr + ` FROM polymarket_wallets`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, address %s LIMIT ?", sortExpr, direction, direction)
+	args = append(args, limit+1)
+
+	rows, err := s.analysisDB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &domain.WalletPage{Wallets: []domain.WalletProfile{}}
+	var lastSortValue any
+	for rows.Next() {
+		var sortValue any
+		profile, err := scanWalletProfile(rows, &sortValue)
+		if err != nil {
+			continue
+		}
+		if len(page.Wallets) == limit {
+			last := page.Wallets[len(page.Wallets)-1]
+			page.NextCursor = encodeWalletCursor(lastSortValue, last.Address)
+			break
+		}
+		page.Wallets = append(page.Wallets, profile)
+		lastSortValue = sortValue
+	}
+
+	return page, rows.Err()
+}
+
+// walletFilterClauses builds the WHERE conditions for a wallet filter
+func walletFilterClauses(filter domain.WalletFilter) ([]string, []any) {
+	var where []string
+	var args []any
+
+	if len(filter.FreshnessLevels) > 0 {
+		placeholders := make([]string, len(filter.FreshnessLevels))
+		for i, level := range filter.FreshnessLevels {
+			placeholders[i] = "?"
+			args = append(args, string(level))
+		}
+		where = append(where, "COALESCE(freshness_level, '') IN ("+strings.Join(placeholders, ",")+")")
+	}
+	if filter.MinBetCount > 0 {
+		where = append(where, "bet_count >= ?")
+		args = append(args, filter.MinBetCount)
+	}
+	if filter.MaxBetCount > 0 {
+		where = append(where, "bet_count >= 0 AND bet_count <= ?")
+		args = append(args, filter.MaxBetCount)
+	}
+	if filter.AnalyzedOnly {
+		where = append(where, "bet_count >= 0")
+	}
+	if filter.UnanalyzedOnly {
+		where = append(where, "bet_count < 0")
+	}
+	if filter.Tag != "" {
+		where = append(where, "address COLLATE NOCASE IN (SELECT address FROM polymarket_wallet_tags WHERE tag = ?)")
+		args = append(args, domain.NormalizeTag(filter.Tag))
+	}
+	if !filter.JoinedAfter.IsZero() {
+		where = append(where, "join_date IS NOT NULL AND join_date != '' AND "+joinMonthExpr+" >= ?")
+		args = append(args, filter.JoinedAfter.Year()*100+int(filter.JoinedAfter.Month()))
+	}
+	if filter.MinVolume > 0 {
+		where = append(where, "total_volume >= ?")
+		args = append(args, filter.MinVolume)
+	}
+
+	return where, args
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+// queryAddresses returns the addresses of every page of a wallet query, in order
+func queryAddresses(t *testing.T, store ports.PolymarketStore, filter domain.WalletFilter) []string {
+	t.Helper()
+	var addresses []string
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("wallet query does not end")
+		}
+		page, err := store.QueryWallets(filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range page.Wallets {
+			addresses = append(addresses, w.Address)
+		}
+		if page.NextCursor == "" {
+			return addresses
+		}
+		filter.Cursor = page.NextCursor
+	}
+}
+
+func TestQueryWalletsFiltersSortsAndPages(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		for _, w := range []domain.WalletProfile{
+			{Address: "0xa", BetCount: 1, JoinDate: "Jan 2026", FreshnessLevel: domain.FreshnessInsider, IsFresh: true},
+			{Address: "0xb", BetCount: 12, JoinDate: "Nov 2025", FreshnessLevel: domain.FreshnessNewbie, IsFresh: true},
+			{Address: "0xc", BetCount: 12, JoinDate: "Feb 2026", FreshnessLevel: domain.FreshnessNewbie, IsFresh: true},
+			{Address: "0xd", BetCount: 300, JoinDate: "Mar 2021"},
+		} {
+			if err := store.SaveWallet(w); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		store.SaveWalletAddress("0xe")
+
+		for _, tc := range []struct {
+			filter domain.WalletFilter
+			want   []string
+		}{
+			{domain.WalletFilter{SortBy: domain.WalletSortAddress}, []string{"0xa", "0xb", "0xc", "0xd", "0xe"}},
+			{domain.WalletFilter{SortBy: domain.WalletSortBetCount, SortDesc: true, Limit: 2}, []string{"0xd", "0xc", "0xb", "0xa", "0xe"}},
+			{domain.WalletFilter{SortBy: domain.WalletSortBetCount, Limit: 1, AnalyzedOnly: true}, []string{"0xa", "0xb", "0xc", "0xd"}},
+			{domain.WalletFilter{SortBy: domain.WalletSortAddress, UnanalyzedOnly: true}, []string{"0xe"}},
+			{domain.WalletFilter{SortBy: domain.WalletSortAddress, FreshnessLevels: []domain.FreshnessLevel{domain.FreshnessInsider, domain.FreshnessNewbie}}, []string{"0xa", "0xb", "0xc"}},
+			{domain.WalletFilter{SortBy: domain.WalletSortAddress, MinBetCount: 10, MaxBetCount: 100}, []string{"0xb", "0xc"}},
+			{domain.WalletFilter{SortBy: domain.WalletSortAddress, JoinedAfter: time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)}, []string{"0xa", "0xc"}},
+		} {
+			if got := queryAddresses(t, store, tc.filter); !slices.Equal(got, tc.want) {
+				t.Errorf("%s: %+v = %v, want %v", name, tc.filter, got, tc.want)
+			}
+		}
+		if _, err := store.QueryWallets(domain.WalletFilter{Cursor: "not a cursor"}); err == nil {
+			t.Errorf("%s: invalid cursor was accepted", name)
+		}
+	}
+}
+
+func TestQueryWalletsByTradedVolume(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		for address, trades := range map[string][]float64{
+			"0xa": {500, 700},
+			"0xb": {50000},
+			"0xc": {100, 100, 100},
+			"0xd": nil,
+		} {
+			if err := store.SaveWallet(domain.WalletProfile{Address: address, BetCount: 5}); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			for _, volume := range trades {
+				if err := store.UpdateWalletTradeStats(address, volume); err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+			}
+		}
+
+		for _, tc := range []struct {
+			filter domain.WalletFilter
+			want   []string
+		}{
+			{domain.WalletFilter{SortBy: domain.WalletSortVolume, SortDesc: true, Limit: 3}, []string{"0xb", "0xa", "0xc", "0xd"}},
+			{domain.WalletFilter{SortBy: domain.WalletSortVolume, Limit: 1}, []string{"0xd", "0xc", "0xa", "0xb"}},
+			{domain.WalletFilter{SortBy: domain.WalletSortAddress, MinVolume: 1000}, []string{"0xa", "0xb"}},
+		} {
+			if got := queryAddresses(t, store, tc.filter); !slices.Equal(got, tc.want) {
+				t.Errorf("%s: %+v = %v, want %v", name, tc.filter, got, tc.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// walletSearch is a normalized wallet search query
+type walletSearch struct {
+	text   string // Lowercased query
+	prefix string // Address prefix of an abbreviated address ("0x12…ab34")
+	suffix string // Address suffix of an abbreviated address
+}
+
+// parseWalletSearch normalizes a query, splitting abbreviated addresses as shown in screenshots
+func parseWalletSearch(query string) walletSearch {
+	q := walletSearch{text: strings.ToLower(strings.TrimSpace(query))}
+	for _, sep := range []string{"…", ".."} {
+		if i := strings.Index(q.text, sep); i > 0 && strings.HasPrefix(q.text, "0x") {
+			q.prefix = q.text[:i]
+			q.suffix = strings.TrimLeft(q.text[i:], "….")
+			break
+		}
+	}
+	return q
+}
+
+func (q walletSearch) abbreviated() bool {
+	return q.prefix != ""
+}
+
+// match ranks how well a wallet matches the query; lower ranks are better
+func (q walletSearch) match(address, name string, tags []string) (domain.WalletMatchField, int, bool) {
+	address = strings.ToLower(address)
+	name = strings.ToLower(name)
+
+	if q.abbreviated() {
+		if strings.HasPrefix(address, q.prefix) && strings.HasSuffix(address, q.suffix) {
+			return domain.WalletMatchAddress, 1, true
+		}
+		return "", 0, false
+	}
+
+	switch {
+	case address == q.text:
+		return domain.WalletMatchAddress, 0, true
+	case strings.HasPrefix(address, q.text):
+		return domain.WalletMatchAddress, 1, true
+	case name != "" && name == q.text:
+		return domain.WalletMatchName, 2, true
+	case strings.HasPrefix(name, q.text):
+		return domain.WalletMatchName, 3, true
+	}
+	for _, tag := range tags {
+		if tag == q.text {
+			return domain.WalletMatchTag, 4, true
+		}
+	}
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, q.text) {
+			return domain.WalletMatchTag, 5, true
+		}
+	}
+	switch {
+	case strings.Contains(address, q.text):
+		return domain.WalletMatchAddress, 6, true
+	case strings.Contains(name, q.text):
+		return domain.WalletMatchName, 7, true
+	}
+	for _, tag := range tags {
+		if strings.Contains(tag, q.text) {
+			return domain.WalletMatchTag, 8, true
+		}
+	}
+	return "", 0, false
+}
+
+// rankedWallet is a search result with its match rank
+type rankedWallet struct {
+	result domain.WalletSearchResult
+	rank   int
+}
+
+// sortWalletSearchResults orders results by match rank, then most recently seen
+func sortWalletSearchResults(ranked []rankedWallet, limit int) []domain.WalletSearchResult {
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].rank != ranked[j].rank {
+			return ranked[i].rank < ranked[j].rank
+		}
+		return ranked[i].result.Wallet.FirstSeen.After(ranked[j].result.Wallet.FirstSeen)
+	})
+	results := make([]domain.WalletSearchResult, 0, min(len(ranked), limit))
+	for _, r := range ranked {
+		if len(results) == limit {
+			break
+		}
+		results = append(results, r.result)
+	}
+	return results
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// walletSearchStage is one candidate query of a wallet search
+type walletSearchStage struct {
+	where string
+	args  []any
+}
+
+// walletSearchWhere matches a LIKE pattern against address, trader name and tags
+const walletSearchWhere = `(address LIKE ? ESCAPE '\' OR trader_name LIKE ? ESCAPE '\'
+	OR address IN (SELECT address FROM polymarket_wallet_tags WHERE tag LIKE ? ESCAPE '\'))`
+
+// SetWalletTraderName records the name or pseudonym a wallet traded under
+func (s *PolymarketStore) SetWalletTraderName(address, name string) error {
+	_, err := s.analysisDB.Exec(`
+		UPDATE polymarket_wallets SET trader_name = ?
+		WHERE address = ? AND COALESCE(trader_name, '') != ?`, name, address, name)
+	return err
+}
+
+// SearchWallets finds wallets by partial address, trader name or tag, best matches first.
+// Prefix matches are served by the NOCASE indexes; substring matches only run when
+// prefixes don't fill the page.
+func (s *PolymarketStore) SearchWallets(query string, limit int) ([]domain.WalletSearchResult, error) {
+	q := parseWalletSearch(query)
+	if q.text == "" {
+		return []domain.WalletSearchResult{}, nil
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	const selectWallets = `SELECT address, bet_count, join_date, freshness_level, is_fresh, first_seen_at, last_analyzed_at, data_source,
+		COALESCE(trader_name, '') FROM polymarket_wallets WHERE `
+	// Exact hits first so a flood of prefix matches can't push them off the page
+	const order = ` ORDER BY (address = ? COLLATE NOCASE OR trader_name = ? COLLATE NOCASE) DESC, first_seen_at DESC LIMIT ?`
+
+	var stages []walletSearchStage
+	if q.abbreviated() {
+		pattern := escapeLike(q.prefix) + "%" + escapeLike(q.suffix)
+		stages = append(stages, walletSearchStage{`address LIKE ? ESCAPE '\'`, []any{pattern}})
+	} else {
+		prefix := escapeLike(q.text) + "%"
+		contains := "%" + prefix
+		stages = append(stages,
+			walletSearchStage{walletSearchWhere, []any{prefix, prefix, prefix}},
+			walletSearchStage{walletSearchWhere, []any{contains, contains, contains}},
+		)
+	}
+
+	found := make(map[string]*domain.WalletSearchResult)
+	var addresses []string
+	for _, stage := range stages {
+		if len(found) >= limit {
+			break
+		}
+		rows, err := s.analysisDB.Query(selectWallets+stage.where+order, append(stage.args, q.text, q.text, limit)...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var name string
+			profile, err := scanWalletProfile(rows, &name)
+			if err != nil || found[profile.Address] != nil {
+				continue
+			}
+			found[profile.Address] = &domain.WalletSearchResult{Wallet: profile, TraderName: name}
+			addresses = append(addresses, profile.Address)
+		}
+		rows.Close()
+	}
+
+	if err := s.loadWalletTags(found); err != nil {
+		return nil, err
+	}
+
+	ranked := make([]rankedWallet, 0, len(found))
+	for _, address := range addresses {
+		r := found[address]
+		field, rank, ok := q.match(r.Wallet.Address, r.TraderName, r.Tags)
+		if !ok {
+			continue
+		}
+		r.MatchedOn = field
+		ranked = append(ranked, rankedWallet{result: *r, rank: rank})
+	}
+	return sortWalletSearchResults(ranked, limit), nil
+}
+
+// loadWalletTags attaches stored tags to the given results
+func (s *PolymarketStore) loadWalletTags(results map[string]*domain.WalletSearchResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	placeholders := make([]string, 0, len(results))
+	args := make([]any, 0, len(results))
+	for address := range results {
+		placeholders = append(placeholders, "?")
+		args = append(args, address)
+	}
+
+	rows, err := s.analysisDB.Query(`SELECT address, tag FROM polymarket_wallet_tags
+		WHERE address IN (`+strings.Join(placeholders, ",")+`) ORDER BY tag`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var address, tag string
+		if err := rows.Scan(&address, &tag); err != nil {
+			continue
+		}
+		if r := results[address]; r != nil {
+			r.Tags = append(r.Tags, tag)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestSearchWalletsRanksMatches(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	const (
+		whale   = "0x12ab000000000000000000000000000000009f3e"
+		insider = "0x7777000000000000000000000000000000000abc"
+		tagged  = "0x8888000000000000000000000000000000005678"
+	)
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		for i, address := range []string{whale, insider, tagged} {
+			if err := store.SaveWallet(domain.WalletProfile{Address: address, BetCount: i, FirstSeen: time.Now()}); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		store.SetWalletTraderName(whale, "Theo4")
+		store.SetWalletTraderName(insider, "0x12-fan")
+		store.TagWallet(tagged, "theo-cluster")
+
+		search := func(query string) []domain.WalletSearchResult {
+			t.Helper()
+			results, err := store.SearchWallets(query, 10)
+			if err != nil {
+				t.Fatalf("%s: SearchWallets(%q): %v", name, query, err)
+			}
+			return results
+		}
+
+		// An address prefix beats a name that merely contains the query
+		if got := search("0x12"); len(got) != 2 || got[0].Wallet.Address != whale || got[0].MatchedOn != domain.WalletMatchAddress || got[1].MatchedOn != domain.WalletMatchName {
+			t.Errorf("%s: 0x12 = %+v, want the address match first, then the name", name, got)
+		}
+		// A name match beats a tag match
+		if got := search("THEO"); len(got) != 2 || got[0].TraderName != "Theo4" || got[1].Wallet.Address != tagged || got[1].MatchedOn != domain.WalletMatchTag {
+			t.Errorf("%s: THEO = %+v, want the trader name, then the tag", name, got)
+		}
+		// Abbreviated addresses as shown in screenshots and alerts
+		for _, query := range []string{"0x12ab…9f3e", "0x12AB..9F3E"} {
+			if got := search(query); len(got) != 1 || got[0].Wallet.Address != whale {
+				t.Errorf("%s: %s = %+v, want the whale", name, query, got)
+			}
+		}
+		if got := search("5678"); len(got) != 1 || got[0].Wallet.Address != tagged || len(got[0].Tags) != 1 {
+			t.Errorf("%s: 5678 = %+v, want the tagged wallet with its tags", name, got)
+		}
+		if got := search("%"); len(got) != 0 {
+			t.Errorf("%s: %% matched %d wallets, want LIKE wildcards taken literally", name, len(got))
+		}
+		if got := search("  "); got == nil || len(got) != 0 {
+			t.Errorf("%s: blank query = %v, want an empty list", name, got)
+		}
+	}
+}
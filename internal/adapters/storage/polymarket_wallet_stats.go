@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"sort"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// walletBetCountBuckets defines the bet count histogram ranges (Max -1 = unbounded)
+var walletBetCountBuckets = []domain.HistogramBucket{
+	{Label: "0", Min: 0, Max: 0},
+	{Label: "1-3", Min: 1, Max: 3},
+	{Label: "4-10", Min: 4, Max: 10},
+	{Label: "11-20", Min: 11, Max: 20},
+	{Label: "21-50", Min: 21, Max: 50},
+	{Label: "51-100", Min: 51, Max: 100},
+	{Label: "101-500", Min: 101, Max: 500},
+	{Label: "500+", Min: 501, Max: -1},
+}
+
+// GetWalletStats returns the distribution of bet counts, join dates and freshness levels
+func (s *PolymarketStore) GetWalletStats() (*domain.WalletStats, error) {
+	stats := &domain.WalletStats{}
+
+	err := s.analysisDB.QueryRow(`
+		SELECT COUNT(*),
+			COALESCE(SUM(CASE WHEN bet_count >= 0 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN bet_count = -1 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN is_fresh = 1 THEN 1 ELSE 0 END), 0)
+		FROM polymarket_wallets`).
+		Scan(&stats.TotalWallets, &stats.AnalyzedWallets, &stats.PendingWallets, &stats.FreshWallets)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bet count histogram
+	stats.BetCounts = newBetCountHistogram()
+	rows, err := s.analysisDB.Query(`SELECT bet_count, COUNT(*) FROM polymarket_wallets WHERE bet_count >= 0 GROUP BY bet_count`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var betCount int
+		var count int64
+		if err := rows.Scan(&betCount, &count); err != nil {
+			continue
+		}
+		addToBetCountHistogram(stats.BetCounts, betCount, count)
+	}
+	rows.Close()
+
+	// Join date histogram (stored as "MMM YYYY")
+	rows, err = s.analysisDB.Query(`
+		SELECT join_date, COUNT(*) FROM polymarket_wallets
+		WHERE join_date IS NOT NULL AND join_date != ''
+		GROUP BY join_date`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var bucket domain.HistogramBucket
+		if err := rows.Scan(&bucket.Label, &bucket.Count); err != nil {
+			continue
+		}
+		stats.JoinDates = append(stats.JoinDates, bucket)
+	}
+	rows.Close()
+	sortJoinDateHistogram(stats.JoinDates)
+
+	// Freshness level histogram (analyzed wallets only)
+	rows, err = s.analysisDB.Query(`
+		SELECT COALESCE(freshness_level, ''), COUNT(*) FROM polymarket_wallets
+		WHERE bet_count >= 0
+		GROUP BY COALESCE(freshness_level, '')
+		ORDER BY COUNT(*) DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bucket domain.HistogramBucket
+		if err := rows.Scan(&bucket.Label, &bucket.Count); err != nil {
+			continue
+		}
+		if bucket.Label == "" {
+			bucket.Label = "none"
+		}
+		stats.FreshnessLevels = append(stats.FreshnessLevels, bucket)
+	}
+
+	return stats, nil
+}
+
+// newBetCountHistogram returns empty bet count buckets
+func newBetCountHistogram() []domain.HistogramBucket {
+	buckets := make([]domain.HistogramBucket, len(walletBetCountBuckets))
+	copy(buckets, walletBetCountBuckets)
+	return buckets
+}
+
+// addToBetCountHistogram adds count wallets with the given bet count to its bucket
+func addToBetCountHistogram(buckets []domain.HistogramBucket, betCount int, count int64) {
+	for i := range buckets {
+		b := &buckets[i]
+		if betCount >= b.Min && (b.Max < 0 || betCount <= b.Max) {
+			b.Count += count
+			return
+		}
+	}
+}
+
+// sortJoinDateHistogram orders join date buckets chronologically
+func sortJoinDateHistogram(buckets []domain.HistogramBucket) {
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return parseJoinDate(buckets[i].Label).Before(parseJoinDate(buckets[j].Label))
+	})
+}
+
+// parseJoinDate parses a Polymarket join date ("Dec 2025"); unparseable values sort first
+func parseJoinDate(value string) time.Time {
+	t, err := time.Parse("Jan 2006", value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
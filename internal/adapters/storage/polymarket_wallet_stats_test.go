@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestGetWalletStatsDistributions(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		for _, w := range []domain.WalletProfile{
+			{Address: "0xa", BetCount: 0, JoinDate: "Jan 2026", FreshnessLevel: domain.FreshnessInsider, IsFresh: true},
+			{Address: "0xb", BetCount: 2, JoinDate: "Dec 2025", FreshnessLevel: domain.FreshnessInsider, IsFresh: true},
+			{Address: "0xc", BetCount: 15, JoinDate: "Jan 2026", FreshnessLevel: domain.FreshnessNewbie, IsFresh: true},
+			{Address: "0xd", BetCount: 900, JoinDate: "Mar 2021"},
+		} {
+			if err := store.SaveWallet(w); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+		if _, err := store.SaveWalletAddress("0xpending"); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		stats, err := store.GetWalletStats()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if stats.TotalWallets != 5 || stats.AnalyzedWallets != 4 || stats.PendingWallets != 1 || stats.FreshWallets != 3 {
+			t.Errorf("%s: totals = %+v, want 5 wallets, 4 analyzed, 1 pending and 3 fresh", name, stats)
+		}
+		counts := make(map[string]int64)
+		for _, b := range stats.BetCounts {
+			counts[b.Label] = b.Count
+		}
+		if len(stats.BetCounts) != 8 || counts["0"] != 1 || counts["1-3"] != 1 || counts["11-20"] != 1 || counts["500+"] != 1 || counts["4-10"] != 0 {
+			t.Errorf("%s: bet counts = %+v, want one wallet in 0, 1-3, 11-20 and 500+", name, stats.BetCounts)
+		}
+		want := []domain.HistogramBucket{{Label: "Mar 2021", Count: 1}, {Label: "Dec 2025", Count: 1}, {Label: "Jan 2026", Count: 2}}
+		if len(stats.JoinDates) != len(want) {
+			t.Fatalf("%s: join dates = %+v, want %+v", name, stats.JoinDates, want)
+		}
+		for i := range want {
+			if stats.JoinDates[i] != want[i] {
+				t.Errorf("%s: join dates = %+v, want %+v in order", name, stats.JoinDates, want)
+				break
+			}
+		}
+		if len(stats.FreshnessLevels) != 3 || stats.FreshnessLevels[0] != (domain.HistogramBucket{Label: "insider", Count: 2}) {
+			t.Errorf("%s: freshness levels = %+v, want insider first, then newbie and none", name, stats.FreshnessLevels)
+		}
+	}
+}
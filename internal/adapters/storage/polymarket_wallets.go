@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"database/sql"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// SaveWallet saves or updates a wallet profile in the database
+func (s *PolymarketStore) SaveWallet(profile domain.WalletProfile) error {
+	_, err := s.stmts.saveWallet.Exec(
+		profile.Address, profile.BetCount, profile.JoinDate, string(profile.FreshnessLevel), profile.IsFresh, profile.AnalyzedAt, profile.Source,
+		profile.BetCount, profile.JoinDate, string(profile.FreshnessLevel), profile.IsFresh, profile.AnalyzedAt, profile.Source,
+	)
+	return storeError("save wallet", err)
+}
+
+// GetWallet retrieves a wallet profile from the database
+func (s *PolymarketStore) GetWallet(address string) (*domain.WalletProfile, error) {
+	var profile domain.WalletProfile
+	var freshnessLevel sql.NullString
+	var joinDate sql.NullString
+	var isFresh bool
+	var lastAnalyzedAt sql.NullTime
+	var source sql.NullString
+
+	err := s.stmts.getWallet.QueryRow(address).
+		Scan(&profile.Address, &profile.BetCount, &joinDate, &freshnessLevel, &isFresh, &profile.FirstSeen, &lastAnalyzedAt, &source)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.JoinDate = joinDate.String
+	profile.Source = source.String
+	profile.FreshnessLevel = domain.FreshnessLevel(freshnessLevel.String)
+	profile.IsFresh = isFresh
+	profile.Nonce = profile.BetCount // Backward compatibility
+	if lastAnalyzedAt.Valid {
+		profile.AnalyzedAt = lastAnalyzedAt.Time
+	}
+
+	return &profile, nil
+}
+
+// GetFreshWallets retrieves all fresh wallets from the database
+func (s *PolymarketStore) GetFreshWallets(limit int) ([]domain.WalletProfile, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := s.analysisDB.Query(`
+		SELECT address, bet_count, join_date, freshness_level, is_fresh, first_seen_at, last_analyzed_at, data_source
+		FROM polymarket_wallets
+		WHERE is_fresh = 1
+		ORDER BY last_analyzed_at DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanWalletRows(rows)
+}
+
+// GetAllWallets retrieves all wallets from the database
+func (s *PolymarketStore) GetAllWallets(limit int) ([]domain.WalletProfile, error) {
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	rows, err := s.analysisDB.Query(`
+		SELECT address, bet_count, join_date, freshness_level, is_fresh, first_seen_at, last_analyzed_at, data_source
+		FROM polymarket_wallets
+		ORDER BY first_seen_at DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanWalletRows(rows)
+}
+
+func (s *PolymarketStore) scanWalletRows(rows *sql.Rows) ([]domain.WalletProfile, error) {
+	var wallets []domain.WalletProfile
+	for rows.Next() {
+		profile, err := scanWalletProfile(rows)
+		if err != nil {
+			continue
+		}
+		wallets = append(wallets, profile)
+	}
+
+	return wallets, nil
+}
+
+// scanWalletProfile scans the standard wallet columns followed by any extra columns
+func scanWalletProfile(rows *sql.Rows, extra ...any) (domain.WalletProfile, error) {
+	var profile domain.WalletProfile
+	var joinDate sql.NullString
+	var freshnessLevel sql.NullString
+	var isFresh bool
+	var lastAnalyzedAt sql.NullTime
+	var source sql.NullString
+
+	dest := append([]any{&profile.Address, &profile.BetCount, &joinDate, &freshnessLevel, &isFresh, &profile.FirstSeen, &lastAnalyzedAt, &source}, extra...)
+	if err := rows.Scan(dest...); err != nil {
+		return profile, err
+	}
+
+	profile.JoinDate = joinDate.String
+	profile.Source = source.String
+	profile.FreshnessLevel = domain.FreshnessLevel(freshnessLevel.String)
+	profile.IsFresh = isFresh
+	profile.Nonce = profile.BetCount // Backward compatibility
+	if lastAnalyzedAt.Valid {
+		profile.AnalyzedAt = lastAnalyzedAt.Time
+	}
+	return profile, nil
+}
+
+// UpdateWalletTradeStats updates trade statistics for a wallet
+func (s *PolymarketStore) UpdateWalletTradeStats(address string, tradeVolume float64) error {
+	_, err := s.analysisDB.Exec(`
+		UPDATE polymarket_wallets
+		SET total_trades = total_trades + 1, total_volume = total_volume + ?
+		WHERE address = ?`, tradeVolume, address)
+	return err
+}
+
+// SaveWalletAddress saves a wallet address without analysis (for later background processing)
+// Returns true if this is a new wallet, false if it already exists
+func (s *PolymarketStore) SaveWalletAddress(address string) (bool, error) {
+	result, err := s.stmts.saveAddress.Exec(address)
+	if err != nil {
+		return false, storeError("save wallet address", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// GetUnanalyzedWallets returns wallets that haven't been analyzed yet (bet_count = -1)
+func (s *PolymarketStore) GetUnanalyzedWallets(limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := s.analysisDB.Query(`
+		SELECT address FROM polymarket_wallets
+		WHERE bet_count = -1
+		ORDER BY first_seen_at DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var addresses []string
+	for rows.Next() {
+		var addr string
+		if err := rows.Scan(&addr); err != nil {
+			continue
+		}
+		addresses = append(addresses, addr)
+	}
+
+	return addresses, nil
+}
+
+// GetWalletsForRefresh returns wallets that need refresh, prioritizing unanalyzed then oldest analyzed
+// Only returns wallets with bet_count <= 50 (or unanalyzed with bet_count = -1), leaving out excluded ones
+func (s *PolymarketStore) GetWalletsForRefresh(limit int, exclude []string) ([]string, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	var args []any
+	excluded := ""
+	if len(exclude) > 0 {
+		excluded = " AND address COLLATE NOCASE NOT IN " + sqlList(len(exclude))
+		args = appendStrings(args, exclude)
+	}
+
+	// Get unanalyzed wallets first, then wallets with <= 50 trades by oldest last_analyzed_at
+	rows, err := s.analysisDB.Query(`
+		SELECT address FROM polymarket_wallets
+		WHERE (bet_count = -1 OR bet_count <= 50)`+excluded+`
+		ORDER BY
+			CASE WHEN bet_count = -1 THEN 0 ELSE 1 END,
+			COALESCE(last_analyzed_at, '1970-01-01') ASC
+		LIMIT ?`, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var addresses []string
+	for rows.Next() {
+		var addr string
+		if err := rows.Scan(&addr); err != nil {
+			continue
+		}
+		addresses = append(addresses, addr)
+	}
+
+	return addresses, nil
+}
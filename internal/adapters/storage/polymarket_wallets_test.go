@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestGetWalletsForRefreshLeavesOutExcluded(t *testing.T) {
+	sqlite, err := NewPolymarketStore(filepath.Join(t.TempDir(), "xtools.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlite.Close()
+
+	for name, store := range map[string]ports.PolymarketStore{"sqlite": sqlite, "memory": NewMemoryPolymarketStore()} {
+		store.SaveWalletAddress("0xa")
+		store.SaveWalletAddress("0xb")
+		store.SaveWallet(domain.WalletProfile{Address: "0xc", BetCount: 5})
+		store.SaveWallet(domain.WalletProfile{Address: "0xd", BetCount: 500})
+
+		got, err := store.GetWalletsForRefresh(10, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		slices.Sort(got)
+		if want := []string{"0xa", "0xb", "0xc"}; !slices.Equal(got, want) {
+			t.Errorf("%s: wallets for refresh = %v, want %v", name, got, want)
+		}
+		got, _ = store.GetWalletsForRefresh(10, []string{"0xA", "0xc"})
+		if want := []string{"0xb"}; !slices.Equal(got, want) {
+			t.Errorf("%s: wallets for refresh excluding 0xa and 0xc = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// remoteConfigFile holds the remote backend settings. It lives next to the database
+// rather than in it, since remote mode decides whether the local database is used.
+const remoteConfigFile = "remote.json"
+
+// LoadRemoteBackendConfig reads the remote backend settings from the data directory,
+// returning a disabled config if none were saved
+func LoadRemoteBackendConfig(dataDir string) (domain.RemoteBackendConfig, error) {
+	var config domain.RemoteBackendConfig
+	data, err := os.ReadFile(filepath.Join(dataDir, remoteConfigFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	err = json.Unmarshal(data, &config)
+	return config, err
+}
+
+// SaveRemoteBackendConfig writes the remote backend settings to the data directory
+func SaveRemoteBackendConfig(dataDir string, config domain.RemoteBackendConfig) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dataDir, remoteConfigFile), data, 0600)
+}
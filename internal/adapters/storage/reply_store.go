@@ -6,8 +6,8 @@ import (
 	"fmt"
 	"time"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // SQLiteReplyStore implements ReplyStore using SQLite
@@ -7,8 +7,8 @@ import (
 
 	_ "github.com/mattn/go-sqlite3"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // SQLiteMetricsStore implements MetricsStore using SQLite
@@ -23,8 +23,8 @@ func NewSQLiteMetricsStore(dbPath string) (*SQLiteMetricsStore, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	store := &SQLiteMetricsStore{db: db}
-	if err := store.migrate(); err != nil {
+	store, err := NewSQLiteMetricsStoreWithDB(db)
+	if err != nil {
 		db.Close()
 		return nil, err
 	}
@@ -32,6 +32,16 @@ func NewSQLiteMetricsStore(dbPath string) (*SQLiteMetricsStore, error) {
 	return store, nil
 }
 
+// NewSQLiteMetricsStoreWithDB creates a metrics store in an open database, which Close
+// closes
+func NewSQLiteMetricsStoreWithDB(db *sql.DB) (*SQLiteMetricsStore, error) {
+	store := &SQLiteMetricsStore{db: db}
+	if err := store.migrate(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
 func (s *SQLiteMetricsStore) migrate() error {
 	migrations := []string{
 		`CREATE TABLE IF NOT EXISTS profile_snapshots (
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"database/sql"
+	"testing"
+)
+
+// The desktop app keeps Twitter metrics and replies in one in-memory database when the
+// encrypted database cannot be decrypted
+func TestMetricsAndReplyStoresShareMemoryDB(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+
+	metrics, err := NewSQLiteMetricsStoreWithDB(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer metrics.Close()
+	replies, err := NewSQLiteReplyStore(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := metrics.MarkReplied("acct", "tweet-1", "reply-1"); err != nil {
+		t.Fatal(err)
+	}
+	if replied, err := metrics.IsReplied("acct", "tweet-1"); err != nil || !replied {
+		t.Errorf("IsReplied = %v, %v after MarkReplied, want true", replied, err)
+	}
+	if pending, err := replies.GetPendingReplies(""); err != nil || len(pending) != 0 {
+		t.Errorf("GetPendingReplies = %v, %v, want none", pending, err)
+	}
+}
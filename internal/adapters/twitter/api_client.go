@@ -13,8 +13,8 @@ import (
 	"sync"
 	"time"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // decodeBearerToken handles URL-encoded bearer tokens
@@ -9,7 +9,7 @@ import (
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
 
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 // CookieExtractor helps extract Twitter cookies via browser login
@@ -2,8 +2,8 @@ package twitter
 
 import (
 	"fmt"
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // ClientFactory creates Twitter clients based on auth type
@@ -12,7 +12,7 @@ import (
 	"strings"
 	"time"
 
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 // generateOAuthHeader creates an OAuth 1.0a Authorization header
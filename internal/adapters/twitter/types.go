@@ -2,7 +2,7 @@ package twitter
 
 import (
 	"time"
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 // API v2 response structures
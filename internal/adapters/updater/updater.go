@@ -8,7 +8,7 @@ import (
 	"strings"
 	"time"
 
-	"xtools/internal/version"
+	"github.com/luthebao/poly-xtools/internal/version"
 )
 
 // UpdateInfo contains information about available updates
@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// receiver records the last webhook request it got and answers with status
+type receiver struct {
+	status    int
+	body      []byte
+	header    http.Header
+	requested bool
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.body, _ = io.ReadAll(req.Body)
+	r.header = req.Header.Clone()
+	r.requested = true
+	w.WriteHeader(r.status)
+}
+
+func TestPostSignsBodyWithSecret(t *testing.T) {
+	rec := &receiver{status: http.StatusNoContent}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	payload := map[string]any{"type": "wallet.analyzed", "address": "0xabc"}
+	if err := NewClient().Post(context.Background(), ts.URL, "s3cret", payload); err != nil {
+		t.Fatal(err)
+	}
+	if string(rec.body) != `{"address":"0xabc","type":"wallet.analyzed"}` {
+		t.Errorf("body = %s, want the JSON payload", rec.body)
+	}
+	if ct := rec.header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	if sig := rec.header.Get(SignatureHeader); sig != Sign("s3cret", rec.body) || len(sig) != 64 {
+		t.Errorf("%s = %q, want the hex HMAC-SHA256 of the body", SignatureHeader, sig)
+	}
+	if Sign("other", rec.body) == Sign("s3cret", rec.body) {
+		t.Error("signature does not depend on the secret")
+	}
+}
+
+func TestPostWithoutSecretIsUnsigned(t *testing.T) {
+	rec := &receiver{status: http.StatusOK}
+	ts := httptest.NewServer(rec)
+	defer ts.Close()
+
+	if err := NewClient().Post(context.Background(), ts.URL, "", map[string]string{"ok": "yes"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, signed := rec.header[http.CanonicalHeaderKey(SignatureHeader)]; signed {
+		t.Errorf("unsigned webhook carried %s", SignatureHeader)
+	}
+}
+
+func TestPostReportsFailures(t *testing.T) {
+	rec := &receiver{status: http.StatusServiceUnavailable}
+	ts := httptest.NewServer(rec)
+
+	err := NewClient().Post(context.Background(), ts.URL, "", map[string]string{})
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Post() = %v, want the receiver's status", err)
+	}
+
+	ts.Close()
+	if err := NewClient().Post(context.Background(), ts.URL, "", map[string]string{}); err == nil {
+		t.Error("Post() to a closed server succeeded")
+	}
+	if err := NewClient().Post(context.Background(), ts.URL, "", func() {}); err == nil || !strings.Contains(err.Error(), "marshal") {
+		t.Errorf("Post() of an unencodable payload = %v, want a marshal error", err)
+	}
+}
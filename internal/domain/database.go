@@ -0,0 +1,105 @@
+package domain
+
+import "time"
+
+// DatabaseEncryptionStatus reports whether the database is encrypted at rest
+type DatabaseEncryptionStatus struct {
+	Enabled       bool   `json:"enabled"`
+	KeySource     string `json:"keySource,omitempty"`     // "env", "file" or "keychain"
+	EncryptedPath string `json:"encryptedPath,omitempty"` // Encrypted copy written on shutdown
+	Error         string `json:"error,omitempty"`         // Set when decrypting at startup failed
+}
+
+// DatabaseRecovery reports a damaged database that was rebuilt at startup
+type DatabaseRecovery struct {
+	Reason        string          `json:"reason"`     // Why it was rebuilt, e.g. the failed integrity check
+	BackupPath    string          `json:"backupPath"` // Where the damaged file was moved
+	Tables        []TableRecovery `json:"tables"`
+	RowsRecovered int64           `json:"rowsRecovered"`
+	RecoveredAt   time.Time       `json:"recoveredAt"`
+	Error         string          `json:"error,omitempty"` // Set when salvage stopped early; the app runs on what was copied
+}
+
+// TableRecovery is what was salvaged from one table
+type TableRecovery struct {
+	Name          string `json:"name"`
+	Rows          int64  `json:"rows"`
+	SkippedRanges int    `json:"skippedRanges"` // Unreadable stretches jumped over; rows in them are lost
+	Error         string `json:"error,omitempty"`
+}
+
+// DatabaseBackup is a snapshot of the database taken while the app was running
+type DatabaseBackup struct {
+	Path       string    `json:"path"`
+	SizeBytes  int64     `json:"sizeBytes"` // Including the analysis database's copy when split
+	CreatedAt  time.Time `json:"createdAt"`
+	DurationMs int64     `json:"durationMs,omitempty"`
+	Scheduled  bool      `json:"scheduled,omitempty"` // Taken by the backup schedule
+}
+
+// DatabaseInfo represents database statistics
+type DatabaseInfo struct {
+	SizeBytes     int64  `json:"sizeBytes"`
+	SizeFormatted string `json:"sizeFormatted"`
+	EventCount    int64  `json:"eventCount"`
+	Path          string `json:"path"`
+
+	ArchivedEventCount int64 `json:"archivedEventCount,omitempty"` // Events moved to cold storage
+
+	// What is stored, to judge when to prune or vacuum
+	Tables        []DatabaseTableStats `json:"tables,omitempty"` // Largest first
+	OldestEventAt time.Time            `json:"oldestEventAt,omitempty"`
+	NewestEventAt time.Time            `json:"newestEventAt,omitempty"`
+
+	// Set when wallets/settings live in a separate analysis database
+	AnalysisPath      string `json:"analysisPath,omitempty"`
+	AnalysisSizeBytes int64  `json:"analysisSizeBytes,omitempty"`
+
+	// SQLite health (events database; the analysis database's WAL is included in WALSizeBytes)
+	WALSizeBytes  int64   `json:"walSizeBytes"`
+	PageSize      int64   `json:"pageSize"`
+	PageCount     int64   `json:"pageCount"`
+	FreelistCount int64   `json:"freelistCount"` // Unused pages that VACUUM would reclaim
+	Fragmentation float64 `json:"fragmentation"` // Share of unused pages (0-1)
+	BusyTimeoutMs int64   `json:"busyTimeoutMs"` // How long statements wait for a locked database
+	Synchronous   string  `json:"synchronous"`   // "OFF", "NORMAL", "FULL" or "EXTRA"
+
+	// Maintenance state, kept in memory by the service
+	LastCheckpointAt   time.Time `json:"lastCheckpointAt,omitempty"`
+	IntegrityStatus    string    `json:"integrityStatus,omitempty"` // "ok" or the problems found by integrity_check
+	IntegrityCheckedAt time.Time `json:"integrityCheckedAt,omitempty"`
+}
+
+// DatabaseTableStats describes a table of the database. Sizes come from SQLite's dbstat
+// table and are 0 when SQLite was built without it.
+type DatabaseTableStats struct {
+	Name           string               `json:"name"`
+	Database       string               `json:"database,omitempty"` // "analysis" for tables in the separate analysis database
+	Rows           int64                `json:"rows"`
+	SizeBytes      int64                `json:"sizeBytes,omitempty"`
+	IndexSizeBytes int64                `json:"indexSizeBytes,omitempty"` // Sum of the table's indexes
+	Indexes        []DatabaseIndexStats `json:"indexes,omitempty"`
+}
+
+// DatabaseIndexStats describes an index of a table
+type DatabaseIndexStats struct {
+	Name      string `json:"name"`
+	SizeBytes int64  `json:"sizeBytes,omitempty"`
+}
+
+// DatabaseOptimizeResult reports an on-demand database optimization
+type DatabaseOptimizeResult struct {
+	BytesBefore int64         `json:"bytesBefore"` // Database and WAL files before
+	BytesAfter  int64         `json:"bytesAfter"`
+	DurationMs  int64         `json:"durationMs"`
+	Info        *DatabaseInfo `json:"info"`
+}
+
+// RawDataCompression reports a run compressing raw event payloads stored as plain text
+type RawDataCompression struct {
+	RowsScanned    int64                   `json:"rowsScanned"`    // Uncompressed payloads large enough to compress
+	RowsCompressed int64                   `json:"rowsCompressed"` // Payloads that came out smaller and were rewritten
+	BytesBefore    int64                   `json:"bytesBefore"`    // Size of the rewritten payloads before and after
+	BytesAfter     int64                   `json:"bytesAfter"`
+	Optimize       *DatabaseOptimizeResult `json:"optimize,omitempty"` // The vacuum that reclaimed the freed pages
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// DetectorSignal is a signal emitted by a custom detector for an event
+type DetectorSignal struct {
+	Detector string            `json:"detector"` // Name of the detector that emitted the signal
+	Signal   string            `json:"signal"`   // Short signal identifier (e.g., "whale_flip")
+	Message  string            `json:"message,omitempty"`
+	Score    float64           `json:"score,omitempty"` // 0-1, merged into the event risk score
+	Alert    bool              `json:"alert"`           // Request a notification
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Event context
+	TradeID       string    `json:"tradeId,omitempty"`
+	WalletAddress string    `json:"walletAddress,omitempty"`
+	MarketName    string    `json:"marketName,omitempty"`
+	MarketLink    string    `json:"marketLink,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// NewDetectorNotification creates a notification for a detector alert
+func NewDetectorNotification(signal DetectorSignal) NotificationContent {
+	metadata := map[string]string{
+		"detector":      signal.Detector,
+		"signal":        signal.Signal,
+		"walletAddress": signal.WalletAddress,
+		"tradeId":       signal.TradeID,
+	}
+	for k, v := range signal.Metadata {
+		if _, exists := metadata[k]; !exists {
+			metadata[k] = v
+		}
+	}
+
+	msg := "<b>🧩 " + escapeHTML(signal.Signal) + "</b>\n\n"
+	if signal.Message != "" {
+		msg += escapeHTML(signal.Message) + "\n\n"
+	}
+	if signal.MarketName != "" {
+		msg += "<b>Market:</b> " + escapeHTML(signal.MarketName) + "\n"
+	}
+	if signal.WalletAddress != "" {
+		msg += "<b>Wallet:</b> <code>" + escapeHTML(shortenAddr(signal.WalletAddress)) + "</code>\n"
+	}
+	if signal.Score > 0 {
+		msg += "<b>Score:</b> " + formatFloat(signal.Score, 2) + "\n"
+	}
+	msg += "<b>Detector:</b> " + escapeHTML(signal.Detector) + "\n"
+	if signal.MarketLink != "" {
+		msg += "\n<a href=\"" + signal.MarketLink + "\">View Market</a>"
+	}
+
+	return NotificationContent{
+		EventType: NotificationEventDetector,
+		Title:     "Detector Alert",
+		Message:   msg,
+		Timestamp: signal.Timestamp,
+		Priority:  "high",
+		Metadata:  metadata,
+	}
+}
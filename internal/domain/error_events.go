@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrorKind classifies errors reported on the "errors" event topic
+type ErrorKind string
+
+const (
+	ErrorKindFeed        ErrorKind = "feed"                  // Live trade feed connection or read failures
+	ErrorKindStore       ErrorKind = "store"                 // Database writes
+	ErrorKindNotify      ErrorKind = "notify"                // Notification delivery
+	ErrorKindRateLimited ErrorKind = "upstream_rate_limited" // An upstream API answered 429
+	ErrorKindOther       ErrorKind = "other"
+)
+
+// FeedError is a failure of the live trade feed
+type FeedError struct {
+	Op  string // e.g. "connect" or "read"
+	Err error
+}
+
+func (e *FeedError) Error() string { return "feed " + e.Op + ": " + e.Err.Error() }
+func (e *FeedError) Unwrap() error { return e.Err }
+
+// StoreError is a failed database operation
+type StoreError struct {
+	Op  string // e.g. "save event"
+	Err error
+}
+
+func (e *StoreError) Error() string { return e.Op + ": " + e.Err.Error() }
+func (e *StoreError) Unwrap() error { return e.Err }
+
+// NotifyError is a notification that could not be delivered through a channel
+type NotifyError struct {
+	Channel string // e.g. "telegram:default"
+	Err     error
+}
+
+func (e *NotifyError) Error() string { return "notify " + e.Channel + ": " + e.Err.Error() }
+func (e *NotifyError) Unwrap() error { return e.Err }
+
+// UpstreamRateLimited is a request an upstream API refused with 429 Too Many Requests
+type UpstreamRateLimited struct {
+	Service    string        // e.g. "gamma" or "telegram"
+	RetryAfter time.Duration // Zero if the API did not say
+}
+
+func (e *UpstreamRateLimited) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s rate limited, retry after %v", e.Service, e.RetryAfter)
+	}
+	return e.Service + " rate limited"
+}
+
+// Is makes UpstreamRateLimited match ErrRateLimited
+func (e *UpstreamRateLimited) Is(target error) bool { return target == ErrRateLimited }
+
+// ErrorKindOf classifies an error. A rate limit wins over the operation it interrupted.
+func ErrorKindOf(err error) ErrorKind {
+	var rateLimited *UpstreamRateLimited
+	var feed *FeedError
+	var store *StoreError
+	var notify *NotifyError
+	switch {
+	case errors.As(err, &rateLimited):
+		return ErrorKindRateLimited
+	case errors.As(err, &feed):
+		return ErrorKindFeed
+	case errors.As(err, &store):
+		return ErrorKindStore
+	case errors.As(err, &notify):
+		return ErrorKindNotify
+	default:
+		return ErrorKindOther
+	}
+}
+
+// ErrorEvent is an error emitted on the "errors" event topic
+type ErrorEvent struct {
+	Kind      ErrorKind `json:"kind"`
+	Source    string    `json:"source"` // Component that hit the error, e.g. "event writer"
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ErrorStats counts reported errors by kind since the app started
+type ErrorStats struct {
+	Counts map[ErrorKind]int64 `json:"counts"`
+	Recent []ErrorEvent        `json:"recent"` // Newest first
+}
@@ -36,6 +36,12 @@ var (
 	ErrStorageWrite        = errors.New("failed to write to storage")
 	ErrStorageRead         = errors.New("failed to read from storage")
 
+	// Investigation errors
+	ErrInvestigationNotFound = errors.New("investigation not found")
+
+	// Wallet analysis errors
+	ErrWalletBlacklisted = errors.New("wallet is blacklisted")
+
 	// Worker errors
 	ErrWorkerAlreadyRunning = errors.New("worker already running")
 	ErrWorkerNotRunning     = errors.New("worker not running")
@@ -16,22 +16,47 @@ type NotificationEventType string
 const (
 	NotificationEventBigTrade     NotificationEventType = "big_trade"
 	NotificationEventFreshWallet  NotificationEventType = "fresh_wallet"
+	NotificationEventDetector     NotificationEventType = "detector_alert"
+	NotificationEventEntityFlow   NotificationEventType = "entity_flow_report"
+	NotificationEventWatched      NotificationEventType = "watched_wallet"
 	NotificationEventTest         NotificationEventType = "test"
 )
 
+// NotificationConfigSettingKey is the settings key the notification config is stored under
+const NotificationConfigSettingKey = "notification_config"
+
 // NotificationConfig holds configuration for notifications
 type NotificationConfig struct {
 	// General settings
 	Enabled bool                `json:"enabled"`
 	Channel NotificationChannel `json:"channel"`
 
-	// Telegram settings
-	TelegramBotToken string   `json:"telegramBotToken"`
-	TelegramChatIDs  []string `json:"telegramChatIDs"`
+	// Telegram settings: the token and chats below form the "default" bot, which
+	// receives every notification type; TelegramBots adds more bots with routing
+	TelegramBotToken string              `json:"telegramBotToken"`
+	TelegramChatIDs  []string            `json:"telegramChatIDs"`
+	TelegramBots     []TelegramBotConfig `json:"telegramBots"`
+
+	// Telegram chat registration: chats that send /start to a bot wait for approval
+	TelegramRegistrationOpen bool                       `json:"telegramRegistrationOpen"`
+	TelegramPendingChats     []TelegramChatRegistration `json:"telegramPendingChats"`
 
 	// Notification type toggles
 	NotifyBigTrades    bool `json:"notifyBigTrades"`
 	NotifyFreshWallets bool `json:"notifyFreshWallets"`
+	NotifyDetectors    bool `json:"notifyDetectors"` // Alerts raised by custom detector scripts
+
+	// Every trade by a wallet on the watchlist, whatever its size
+	NotifyWatchedWallets bool `json:"notifyWatchedWallets"`
+
+	// Acknowledgment: alerts carry an ack button and are tracked until acknowledged;
+	// unacknowledged high-priority alerts are re-pinged every AlertRepingMinutes (0 = never)
+	AlertAcks          bool `json:"alertAcks"`
+	AlertRepingMinutes int  `json:"alertRepingMinutes"`
+
+	// Deduplication records older than this are deleted daily (0 = kept forever). An
+	// item notified before the cutoff may be notified again if it comes back.
+	NotifiedRetentionDays int `json:"notifiedRetentionDays,omitempty"`
 }
 
 // DefaultNotificationConfig returns default notification configuration
@@ -43,6 +68,7 @@ func DefaultNotificationConfig() NotificationConfig {
 		TelegramChatIDs:    []string{},
 		NotifyBigTrades:    false,
 		NotifyFreshWallets: false,
+		NotifyDetectors:    false,
 	}
 }
 
@@ -54,7 +80,12 @@ func (c *NotificationConfig) IsConfigured() bool {
 
 	switch c.Channel {
 	case NotificationChannelTelegram:
-		return c.TelegramBotToken != "" && len(c.TelegramChatIDs) > 0
+		for _, bot := range c.TelegramBotList() {
+			if bot.Token != "" && len(bot.ChatIDs) > 0 {
+				return true
+			}
+		}
+		return false
 	default:
 		return false
 	}
@@ -68,6 +99,17 @@ type NotificationContent struct {
 	Timestamp   time.Time              `json:"timestamp"`
 	Priority    string                 `json:"priority"` // "high", "medium", "low"
 	Metadata    map[string]string      `json:"metadata"`
+	AlertID     string                 `json:"alertId,omitempty"` // Set on tracked alerts, adds an ack button
+}
+
+// MaintenanceStatus reports the notification kill-switch state.
+// While enabled, alerts are recorded here instead of being delivered.
+type MaintenanceStatus struct {
+	Enabled         bool                  `json:"enabled"`
+	Reason          string                `json:"reason,omitempty"`
+	Since           time.Time             `json:"since,omitempty"`
+	SuppressedCount int64                 `json:"suppressedCount"`
+	Suppressed      []NotificationContent `json:"suppressed"` // Most recent first
 }
 
 // NewBigTradeNotification creates a notification for a big trade
@@ -152,6 +194,48 @@ func NewFreshWalletNotification(profile WalletProfile) NotificationContent {
 	}
 }
 
+// NewWatchedWalletNotification creates a notification for a trade by a watched wallet
+func NewWatchedWalletNotification(event PolymarketEvent) NotificationContent {
+	side := "BUY"
+	if event.Side == OrderSideSell {
+		side = "SELL"
+	}
+	var price, size float64
+	parseFloatSimple(event.Price, &price)
+	parseFloatSimple(event.Size, &size)
+
+	msg := "<b>👁 Watched Wallet Trade</b>\n\n"
+	if event.MarketName != "" {
+		msg += "<b>Market:</b> " + escapeHTML(event.MarketName) + "\n"
+	}
+	if event.Outcome != "" {
+		msg += "<b>Outcome:</b> " + escapeHTML(event.Outcome) + "\n"
+	}
+	msg += "<b>Value:</b> $" + formatFloat(price*size, 2) + "\n"
+	msg += "<b>Side:</b> " + side + "\n"
+	msg += "<b>Wallet:</b> <code>" + escapeHTML(shortenAddr(event.WalletAddress)) + "</code>\n"
+	if event.TraderName != "" {
+		msg += "<b>Trader:</b> " + escapeHTML(event.TraderName) + "\n"
+	}
+	msg += "\n<a href=\"https://polymarket.com/profile/" + event.WalletAddress + "\">View Profile</a>"
+	if event.MarketLink != "" {
+		msg += " | <a href=\"" + event.MarketLink + "\">View Market</a>"
+	}
+
+	return NotificationContent{
+		EventType: NotificationEventWatched,
+		Title:     "Watched Wallet Trade",
+		Message:   msg,
+		Timestamp: event.Timestamp,
+		Priority:  "high",
+		Metadata: map[string]string{
+			"walletAddress": event.WalletAddress,
+			"tradeId":       event.TradeID,
+			"marketSlug":    event.MarketSlug,
+		},
+	}
+}
+
 // NewTestNotification creates a test notification
 func NewTestNotification() NotificationContent {
 	return NotificationContent{
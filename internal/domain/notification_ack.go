@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// SentAlert is a delivered notification tracked until someone acknowledges it
+type SentAlert struct {
+	ID         string                `json:"id"`
+	EventType  NotificationEventType `json:"eventType"`
+	Title      string                `json:"title"`
+	Message    string                `json:"message"` // Telegram HTML, resent by re-pings
+	Priority   string                `json:"priority"`
+	SentAt     time.Time             `json:"sentAt"`
+	AckedAt    time.Time             `json:"ackedAt,omitempty"`
+	AckedBy    string                `json:"ackedBy,omitempty"` // e.g. "telegram:@alice" or "api:default"
+	Repings    int                   `json:"repings"`           // Reminders sent while unacknowledged
+	LastPingAt time.Time             `json:"lastPingAt"`
+}
+
+// Acked reports whether the alert was acknowledged
+func (a SentAlert) Acked() bool {
+	return !a.AckedAt.IsZero()
+}
+
+// Critical reports whether the alert is re-pinged until acknowledged
+func (a SentAlert) Critical() bool {
+	return a.Priority == "high"
+}
+
+// AlertAckStatus lists the tracked alerts and how many still await acknowledgment
+type AlertAckStatus struct {
+	Enabled bool        `json:"enabled"`
+	Unacked int         `json:"unacked"`
+	Alerts  []SentAlert `json:"alerts"` // Newest first
+}
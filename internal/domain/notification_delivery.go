@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// NotificationDelivery is the delivery state of one notified item on one channel, e.g.
+// "telegram:default". Items are deduplicated per channel, so a channel that failed is
+// retried on its own without resending to the channels that already got the alert.
+type NotificationDelivery struct {
+	ItemType      string     `json:"itemType"`
+	ItemID        string     `json:"itemId"`
+	Channel       string     `json:"channel"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"lastError,omitempty"`
+	DeliveredAt   *time.Time `json:"deliveredAt,omitempty"`   // Nil until a send succeeds
+	NextAttemptAt time.Time  `json:"nextAttemptAt,omitempty"` // When a failed send is retried
+	UpdatedAt     time.Time  `json:"updatedAt"`
+
+	// Content is the alert as sent, kept until it is delivered so retries resend the
+	// same message
+	Content *NotificationContent `json:"content,omitempty"`
+}
+
+// Delivered returns true once the item reached the channel
+func (d NotificationDelivery) Delivered() bool {
+	return d.DeliveredAt != nil
+}
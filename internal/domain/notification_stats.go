@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// NotificationStats counts the items notified since a time, for a "notifications
+// sent" chart. Counts come from the deduplication records, so each trade, wallet or
+// detector signal counts once however many chats it went to.
+type NotificationStats struct {
+	Since  time.Time              `json:"since"`
+	Total  int64                  `json:"total"`
+	ByType map[string]int64       `json:"byType"` // Item type, e.g. "big_trade", to count
+	Days   []NotificationDayCount `json:"days"`   // Oldest day first
+}
+
+// NotificationDayCount is how many items of a type were notified on a UTC day
+type NotificationDayCount struct {
+	Day      string `json:"day"` // 2006-01-02
+	ItemType string `json:"itemType"`
+	Count    int64  `json:"count"`
+}
+
+// NotifiedCleanupResult reports a cleanup of old notification deduplication records
+type NotifiedCleanupResult struct {
+	Deleted int64     `json:"deleted"`
+	Before  time.Time `json:"before"` // Records notified before this were deleted
+	RunAt   time.Time `json:"runAt"`
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultTelegramBot names the bot configured by TelegramBotToken and TelegramChatIDs
+const DefaultTelegramBot = "default"
+
+// TelegramBotConfig is a Telegram bot with its chats and the notification types routed to it
+type TelegramBotConfig struct {
+	Name       string                  `json:"name"`
+	Token      string                  `json:"token"`
+	ChatIDs    []string                `json:"chatIds"`
+	EventTypes []NotificationEventType `json:"eventTypes,omitempty"` // Routed notification types, empty = all
+}
+
+// Routes reports whether notifications of a type go to the bot. Test notifications go to every bot.
+func (b TelegramBotConfig) Routes(eventType NotificationEventType) bool {
+	if len(b.EventTypes) == 0 || eventType == NotificationEventTest {
+		return true
+	}
+	for _, t := range b.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// TelegramChatRegistration is a chat that sent /start to a bot and awaits approval
+type TelegramChatRegistration struct {
+	Bot         string    `json:"bot"` // Name of the bot that received /start
+	ChatID      string    `json:"chatId"`
+	ChatType    string    `json:"chatType"` // "private", "group", "supergroup" or "channel"
+	Name        string    `json:"name"`     // Group title or the user's name
+	Username    string    `json:"username,omitempty"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+// TelegramBotList returns every configured bot, the default bot first if it has a token
+func (c *NotificationConfig) TelegramBotList() []TelegramBotConfig {
+	bots := make([]TelegramBotConfig, 0, len(c.TelegramBots)+1)
+	if c.TelegramBotToken != "" {
+		bots = append(bots, TelegramBotConfig{Name: DefaultTelegramBot, Token: c.TelegramBotToken, ChatIDs: c.TelegramChatIDs})
+	}
+	return append(bots, c.TelegramBots...)
+}
+
+// ValidateTelegramBots checks that bots have unique names, tokens and known routed types
+func (c *NotificationConfig) ValidateTelegramBots() error {
+	seen := make(map[string]bool)
+	for i, bot := range c.TelegramBotList() {
+		name := strings.ToLower(strings.TrimSpace(bot.Name))
+		if name == "" {
+			return fmt.Errorf("telegram bot %d: name is required", i+1)
+		}
+		if seen[name] {
+			return fmt.Errorf("telegram bot %q: duplicate name", bot.Name)
+		}
+		seen[name] = true
+		if bot.Token == "" {
+			return fmt.Errorf("telegram bot %q: token is required", bot.Name)
+		}
+		for _, t := range bot.EventTypes {
+			switch t {
+			case NotificationEventBigTrade, NotificationEventFreshWallet, NotificationEventDetector, NotificationEventEntityFlow,
+				NotificationEventWatched:
+			default:
+				return fmt.Errorf("telegram bot %q: unknown notification type %q", bot.Name, t)
+			}
+		}
+	}
+	return nil
+}
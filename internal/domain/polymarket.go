@@ -32,6 +32,14 @@ const (
 	FreshnessCustom     FreshnessLevel = "fresher"     // Custom threshold
 )
 
+// WalletRefreshPriority controls how a manual wallet refresh is scheduled
+type WalletRefreshPriority string
+
+const (
+	WalletRefreshPriorityHigh      WalletRefreshPriority = "high"      // Next worker tick, ahead of the background queue
+	WalletRefreshPriorityImmediate WalletRefreshPriority = "immediate" // Right away, independent of the worker
+)
+
 // PolymarketEvent represents a generic event from Polymarket WebSocket
 type PolymarketEvent struct {
 	ID          int64               `json:"id"`
@@ -70,6 +78,26 @@ type PolymarketEvent struct {
 	RiskSignals        []string         `json:"riskSignals,omitempty"`
 	RiskScore          float64          `json:"riskScore,omitempty"`
 	FreshWalletSignal  *FreshWalletSignal `json:"freshWalletSignal,omitempty"`
+
+	// Labels attached manually or by tag rules (stored in event_tags)
+	Tags []string `json:"tags,omitempty"`
+
+	// Set when the event's market is muted; notifications are skipped (not persisted)
+	Muted bool `json:"muted,omitempty"`
+
+	// Names of the entities the market's title mentions, set on live events (not
+	// persisted; stored per market)
+	Entities []string `json:"entities,omitempty"`
+}
+
+// TradeKey identifies a fill: the trade ID is the transaction hash, shared by every
+// fill of a matched order, so the wallet and outcome token are part of the key. Empty
+// for events without a trade ID.
+func (e PolymarketEvent) TradeKey() string {
+	if e.TradeID == "" {
+		return ""
+	}
+	return e.TradeID + "|" + e.WalletAddress + "|" + e.AssetID
 }
 
 // WalletProfile contains analyzed wallet information
@@ -82,6 +110,10 @@ type WalletProfile struct {
 	AnalyzedAt     time.Time      `json:"analyzedAt"`
 	FreshThreshold int            `json:"freshThreshold"`    // Custom threshold used for detection
 
+	// WalletSourceLocal when the profile API was unreachable and the profile was estimated
+	// from stored trades; empty for profiles from the API
+	Source string `json:"source,omitempty"`
+
 	// Deprecated: kept for backward compatibility, use BetCount instead
 	Nonce        int  `json:"nonce,omitempty"`
 	TotalTxCount int  `json:"totalTxCount,omitempty"`
@@ -94,6 +126,10 @@ type WalletProfile struct {
 	BalanceUSDC  string    `json:"balanceUsdc,omitempty"`
 }
 
+// WalletSourceLocal marks a wallet profile estimated from locally stored trades: the bet
+// count is the number of stored trades, a lower bound of the wallet's real count
+const WalletSourceLocal = "local"
+
 // FreshWalletSignal represents a detected fresh wallet trade
 type FreshWalletSignal struct {
 	Confidence float64            `json:"confidence"`
@@ -122,21 +158,6 @@ type RiskAssessment struct {
 	Timestamp         time.Time          `json:"timestamp"`
 }
 
-// PolymarketEventFilter represents filter criteria for events
-type PolymarketEventFilter struct {
-	EventTypes       []PolymarketEventType `json:"eventTypes,omitempty"`
-	MarketName       string                `json:"marketName,omitempty"`
-	MinPrice         float64               `json:"minPrice,omitempty"`
-	MaxPrice         float64               `json:"maxPrice,omitempty"`
-	Side             OrderSide             `json:"side,omitempty"`
-	MinSize          float64               `json:"minSize,omitempty"`
-	Limit            int                   `json:"limit,omitempty"`
-	Offset           int                   `json:"offset,omitempty"`
-	FreshWalletsOnly bool                  `json:"freshWalletsOnly,omitempty"`
-	MinRiskScore     float64               `json:"minRiskScore,omitempty"`
-	MaxWalletNonce   int                   `json:"maxWalletNonce,omitempty"`
-}
-
 // PolymarketWatcherStatus represents the current status of the watcher
 type PolymarketWatcherStatus struct {
 	IsRunning           bool      `json:"isRunning"`
@@ -149,44 +170,30 @@ type PolymarketWatcherStatus struct {
 	ErrorMessage        string    `json:"errorMessage,omitempty"`
 	ReconnectCount      int       `json:"reconnectCount"`
 	WebSocketEndpoint   string    `json:"webSocketEndpoint"`
+	MutedMarkets        []MarketMute `json:"mutedMarkets,omitempty"`
+	EventSampling       []EventSamplingStats `json:"eventSampling,omitempty"` // Raw vs kept counts of sampled event types
+	WriteQueue          *WriteQueueStatus    `json:"writeQueue,omitempty"`    // Database write queue depth and overflow counters
+	UnackedAlerts       int                  `json:"unackedAlerts"`           // Delivered alerts nobody acknowledged yet
+	MarketChannel       *MarketChannelStatus `json:"marketChannel,omitempty"` // Order book feed of the traded outcome tokens
+	StoreError          string               `json:"storeError,omitempty"`    // Set when the database could not be opened and nothing survives a restart
 }
 
-// DatabaseInfo represents database statistics
-type DatabaseInfo struct {
-	SizeBytes     int64  `json:"sizeBytes"`
-	SizeFormatted string `json:"sizeFormatted"`
-	EventCount    int64  `json:"eventCount"`
-	Path          string `json:"path"`
-}
-
-// PolymarketConfig holds configuration for the Polymarket watcher
-type PolymarketConfig struct {
-	Enabled        bool    `json:"enabled"`
-	MinTradeSize   float64 `json:"minTradeSize"`   // Min trade size in USDC to analyze
-	AlertThreshold float64 `json:"alertThreshold"` // Risk score threshold for alerts
-
-	// Fresh wallet detection thresholds (bet count based)
-	FreshInsiderMaxBets int `json:"freshInsiderMaxBets"` // Max bets to be "insider" (default: 3)
-	FreshWalletMaxBets  int `json:"freshWalletMaxBets"`  // Max bets to be "fresh" (default: 10)
-	FreshNewbieMaxBets  int `json:"freshNewbieMaxBets"`  // Max bets to be "newbie" (default: 20)
-	CustomFreshMaxBets  int `json:"customFreshMaxBets"`  // Custom threshold for "fresher" (0 = disabled)
-
-	// Deprecated: RPC-based detection is no longer used
-	PolygonRPCURL       string   `json:"polygonRpcUrl,omitempty"`
-	PolygonRPCURLs      []string `json:"polygonRpcUrls,omitempty"`
-	FreshWalletMaxNonce int      `json:"freshWalletMaxNonce,omitempty"`
-	FreshWalletMaxAge   float64  `json:"freshWalletMaxAge,omitempty"`
+// MarketChannelStatus describes the CLOB market channel connection, which streams book,
+// price change, tick size and last trade price events of the tracked outcome tokens
+type MarketChannelStatus struct {
+	IsConnected    bool      `json:"isConnected"`
+	ConnectedAt    time.Time `json:"connectedAt,omitempty"`
+	TrackedAssets  int       `json:"trackedAssets"`
+	EventsReceived int64     `json:"eventsReceived"`
+	LastEventAt    time.Time `json:"lastEventAt,omitempty"`
+	ErrorMessage   string    `json:"errorMessage,omitempty"`
+	ReconnectCount int       `json:"reconnectCount"`
+	Endpoint       string    `json:"endpoint"`
 }
 
-// DefaultPolymarketConfig returns default configuration
-func DefaultPolymarketConfig() PolymarketConfig {
-	return PolymarketConfig{
-		Enabled:             true,
-		MinTradeSize:        100, // $100 minimum for fresh wallet analysis
-		AlertThreshold:      0.7,
-		FreshInsiderMaxBets: 3,
-		FreshWalletMaxBets:  10,
-		FreshNewbieMaxBets:  20,
-		CustomFreshMaxBets:  0, // Disabled by default
-	}
+// MarketMute silences notifications for a market until a given time
+type MarketMute struct {
+	Slug    string    `json:"slug"`
+	MutedAt time.Time `json:"mutedAt"`
+	Until   time.Time `json:"until"`
 }
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// AggregateBucket is the time bucket trades are totalled over
+type AggregateBucket string
+
+const (
+	AggregateHour AggregateBucket = "hour"
+	AggregateDay  AggregateBucket = "day"
+)
+
+// Valid reports whether the bucket is supported
+func (b AggregateBucket) Valid() bool {
+	return b == AggregateHour || b == AggregateDay
+}
+
+// Truncate returns the start of the bucket holding t, in UTC
+func (b AggregateBucket) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	if b == AggregateDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return t.Truncate(time.Hour)
+}
+
+// MarketAggregate totals one market's trades in one time bucket
+type MarketAggregate struct {
+	MarketSlug   string    `json:"marketSlug"` // Asset ID when the trades had no market slug
+	MarketName   string    `json:"marketName"`
+	BucketStart  time.Time `json:"bucketStart"` // UTC
+	Volume       float64   `json:"volume"`      // Notional volume in USD
+	Trades       int       `json:"trades"`
+	Wallets      int       `json:"wallets"`      // Distinct wallets that traded
+	FreshWallets int       `json:"freshWallets"` // Of those, wallets that were fresh when they traded
+}
@@ -0,0 +1,87 @@
+package domain
+
+import "time"
+
+// AlertHorizon is a delay after an alert at which the market price is recorded
+type AlertHorizon string
+
+const (
+	AlertHorizon15m AlertHorizon = "15m"
+	AlertHorizon1h  AlertHorizon = "1h"
+	AlertHorizon24h AlertHorizon = "24h"
+)
+
+// AlertHorizons lists the follow-up horizons, shortest first
+var AlertHorizons = []AlertHorizon{AlertHorizon15m, AlertHorizon1h, AlertHorizon24h}
+
+// Duration returns how long after the alert the horizon is
+func (h AlertHorizon) Duration() time.Duration {
+	switch h {
+	case AlertHorizon15m:
+		return 15 * time.Minute
+	case AlertHorizon1h:
+		return time.Hour
+	case AlertHorizon24h:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// AlertOutcome tracks how the price of an alerted trade's outcome moved afterwards
+type AlertOutcome struct {
+	ID            int64                    `json:"id"`
+	TradeID       string                   `json:"tradeId,omitempty"`
+	AssetID       string                   `json:"assetId"`
+	WalletAddress string                   `json:"walletAddress,omitempty"`
+	MarketName    string                   `json:"marketName"`
+	MarketLink    string                   `json:"marketLink,omitempty"`
+	Outcome       string                   `json:"outcome,omitempty"`
+	Side          OrderSide                `json:"side"`
+	EntryPrice    float64                  `json:"entryPrice"`
+	RiskScore     float64                  `json:"riskScore"`
+	RiskSignals   []string                 `json:"riskSignals,omitempty"`
+	AlertedAt     time.Time                `json:"alertedAt"`
+	Prices        map[AlertHorizon]float64 `json:"prices"`               // Recorded follow-up prices by horizon
+	ConfigHash    string                   `json:"configHash,omitempty"` // Config version that raised the alert, see ConfigSnapshot
+}
+
+// Move returns the price change at a horizon in the trade's direction (positive when
+// a buy went up or a sell went down), and whether that price was recorded
+func (o AlertOutcome) Move(h AlertHorizon) (float64, bool) {
+	price, ok := o.Prices[h]
+	if !ok {
+		return 0, false
+	}
+	if o.Side == OrderSideSell {
+		return o.EntryPrice - price, true
+	}
+	return price - o.EntryPrice, true
+}
+
+// HorizonQuality aggregates alert outcomes at one horizon
+type HorizonQuality struct {
+	Horizon   AlertHorizon `json:"horizon"`
+	Evaluated int          `json:"evaluated"` // Alerts with a recorded price
+	Favorable int          `json:"favorable"` // Alerts where the price moved in the trade's direction
+	HitRate   float64      `json:"hitRate"`
+	AvgMove   float64      `json:"avgMove"` // Mean price change in the trade's direction
+}
+
+// SignalQuality summarizes how well recent alerts predicted price moves
+type SignalQuality struct {
+	Alerts      int              `json:"alerts"`
+	Horizons    []HorizonQuality `json:"horizons"`
+	Since       time.Time        `json:"since,omitempty"`
+	GeneratedAt time.Time        `json:"generatedAt"`
+}
+
+// WalletActivityDiff summarizes a wallet's stored trades since its last alert
+type WalletActivityDiff struct {
+	WalletAddress   string    `json:"walletAddress"`
+	LastAlertAt     time.Time `json:"lastAlertAt"`
+	LastAlertMarket string    `json:"lastAlertMarket,omitempty"`
+	Trades          int       `json:"trades"`     // Trades after the last alert
+	Volume          float64   `json:"volume"`     // Notional of those trades
+	Markets         int       `json:"markets"`    // Distinct markets traded after the last alert
+	NewMarkets      int       `json:"newMarkets"` // Of those, markets the wallet hadn't traded before it
+}
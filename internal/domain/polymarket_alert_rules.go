@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AlertRuleSetVersion is the format version of alert rule files
+const AlertRuleSetVersion = 1
+
+// AlertRuleSet is the part of the configuration that decides what alerts and where they
+// go, as a YAML document that can be kept in git and shared between deployments. A
+// section left out of a file is not touched when it is imported; an empty one clears
+// it. Bot tokens and chats are not part of it, only which notification types go to
+// which bot.
+type AlertRuleSet struct {
+	Version        int              `yaml:"version" json:"version"`
+	Thresholds     *AlertThresholds `yaml:"thresholds,omitempty" json:"thresholds,omitempty"`
+	TagRules       []EventTagRule   `yaml:"tag_rules" json:"tagRules"`
+	LateEntryRules []LateEntryRule  `yaml:"late_entry_rules" json:"lateEntryRules"`
+	Watchlist      []string         `yaml:"watchlist" json:"watchlist"` // Wallets on the default user's watchlist
+	Routes         []AlertRoute     `yaml:"routes" json:"routes"`
+}
+
+// AlertThresholds are the config thresholds trades must pass to alert
+type AlertThresholds struct {
+	MinTradeSize        float64 `yaml:"min_trade_size" json:"minTradeSize"`
+	AlertThreshold      float64 `yaml:"alert_threshold" json:"alertThreshold"`
+	FreshInsiderMaxBets int     `yaml:"fresh_insider_max_bets" json:"freshInsiderMaxBets"`
+	FreshWalletMaxBets  int     `yaml:"fresh_wallet_max_bets" json:"freshWalletMaxBets"`
+	FreshNewbieMaxBets  int     `yaml:"fresh_newbie_max_bets" json:"freshNewbieMaxBets"`
+	CustomFreshMaxBets  int     `yaml:"custom_fresh_max_bets" json:"customFreshMaxBets"`
+}
+
+// AlertThresholdsOf returns the alert thresholds of a config
+func AlertThresholdsOf(config PolymarketConfig) AlertThresholds {
+	return AlertThresholds{
+		MinTradeSize:        config.MinTradeSize,
+		AlertThreshold:      config.AlertThreshold,
+		FreshInsiderMaxBets: config.FreshInsiderMaxBets,
+		FreshWalletMaxBets:  config.FreshWalletMaxBets,
+		FreshNewbieMaxBets:  config.FreshNewbieMaxBets,
+		CustomFreshMaxBets:  config.CustomFreshMaxBets,
+	}
+}
+
+// Apply copies the thresholds into a config
+func (t AlertThresholds) Apply(config *PolymarketConfig) {
+	config.MinTradeSize = t.MinTradeSize
+	config.AlertThreshold = t.AlertThreshold
+	config.FreshInsiderMaxBets = t.FreshInsiderMaxBets
+	config.FreshWalletMaxBets = t.FreshWalletMaxBets
+	config.FreshNewbieMaxBets = t.FreshNewbieMaxBets
+	config.CustomFreshMaxBets = t.CustomFreshMaxBets
+}
+
+// Validate checks that the thresholds are usable
+func (t AlertThresholds) Validate() error {
+	if t.MinTradeSize < 0 {
+		return fmt.Errorf("thresholds: min_trade_size cannot be negative")
+	}
+	if t.AlertThreshold < 0 || t.AlertThreshold > 1 {
+		return fmt.Errorf("thresholds: alert_threshold must be between 0 and 1")
+	}
+	if t.FreshInsiderMaxBets < 0 || t.FreshWalletMaxBets < 0 || t.FreshNewbieMaxBets < 0 || t.CustomFreshMaxBets < 0 {
+		return fmt.Errorf("thresholds: bet counts cannot be negative")
+	}
+	return nil
+}
+
+// AlertRoute is the notification types sent to one of the configured Telegram bots
+type AlertRoute struct {
+	Bot   string                  `yaml:"bot" json:"bot"`
+	Types []NotificationEventType `yaml:"types" json:"types"` // Empty = all
+}
+
+// AlertRoutesOf returns the routing of the extra bots; the default bot receives everything
+func AlertRoutesOf(config NotificationConfig) []AlertRoute {
+	routes := make([]AlertRoute, 0, len(config.TelegramBots))
+	for _, bot := range config.TelegramBots {
+		routes = append(routes, AlertRoute{Bot: bot.Name, Types: append([]NotificationEventType{}, bot.EventTypes...)})
+	}
+	return routes
+}
+
+// ApplyRoutes sets the routed types of the bots named in the routes. Every route must
+// name a configured bot other than the default one; bots without a route keep theirs.
+func ApplyRoutes(config *NotificationConfig, routes []AlertRoute) error {
+	bots := make([]TelegramBotConfig, len(config.TelegramBots))
+	copy(bots, config.TelegramBots)
+	for _, route := range routes {
+		name := strings.TrimSpace(route.Bot)
+		if strings.EqualFold(name, DefaultTelegramBot) {
+			return fmt.Errorf("routes: the %q bot receives every notification type and can't be routed", DefaultTelegramBot)
+		}
+		found := false
+		for i := range bots {
+			if strings.EqualFold(bots[i].Name, name) {
+				bots[i].EventTypes = append([]NotificationEventType{}, route.Types...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("routes: no telegram bot named %q; add the bot with its token first", route.Bot)
+		}
+	}
+	updated := *config
+	updated.TelegramBots = bots
+	if err := updated.ValidateTelegramBots(); err != nil {
+		return fmt.Errorf("routes: %w", err)
+	}
+	config.TelegramBots = bots
+	return nil
+}
+
+// Alert rule change actions
+const (
+	AlertRuleAdded   = "added"
+	AlertRuleRemoved = "removed"
+	AlertRuleChanged = "changed"
+)
+
+// AlertRuleChange is one difference an import would make, for a preview before applying it
+type AlertRuleChange struct {
+	Section string `json:"section"` // "thresholds", "tag_rules", "late_entry_rules", "watchlist" or "routes"
+	Action  string `json:"action"`  // AlertRuleAdded, AlertRuleRemoved or AlertRuleChanged
+	Item    string `json:"item"`    // The rule, wallet, threshold or bot
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+}
+
+// AlertRuleExport is an alert rule file written to the exports directory
+type AlertRuleExport struct {
+	Path string `json:"path"`
+	YAML string `json:"yaml"`
+}
+
+// AlertRuleImport is the result of previewing or importing an alert rule file
+type AlertRuleImport struct {
+	Path    string            `json:"path"`
+	Changes []AlertRuleChange `json:"changes"`
+	Applied bool              `json:"applied"` // False for a preview
+}
@@ -0,0 +1,100 @@
+package domain
+
+import "time"
+
+// FreshnessClassifier maps a wallet bet count to a freshness level
+type FreshnessClassifier func(betCount int) FreshnessLevel
+
+// FreshnessRecomputeResult summarizes a freshness re-evaluation of stored wallets
+type FreshnessRecomputeResult struct {
+	WalletsScanned int64     `json:"walletsScanned"`
+	WalletsChanged int64     `json:"walletsChanged"`
+	FreshWallets   int64     `json:"freshWallets"`  // Fresh wallets after recompute
+	EventsUpdated  int64     `json:"eventsUpdated"` // Events whose fresh wallet flag changed
+	DurationMs     int64     `json:"durationMs"`
+	CompletedAt    time.Time `json:"completedAt"`
+
+	// Wallets whose freshness level changed, published as wallet updates
+	Changes []WalletFreshnessChange `json:"-"`
+}
+
+// WalletFreshnessChange is a wallet reclassified by a freshness recompute
+type WalletFreshnessChange struct {
+	Address       string
+	PreviousLevel FreshnessLevel
+	Level         FreshnessLevel
+}
+
+// TradeSample is a compact view of a stored trade used for historical analysis
+type TradeSample struct {
+	Timestamp     time.Time `json:"timestamp"`
+	AssetID       string    `json:"assetId"`
+	WalletAddress string    `json:"walletAddress"`
+	Side          OrderSide `json:"side"`
+	Price         float64   `json:"price"`
+	Notional      float64   `json:"notional"`
+	BetCount      int       `json:"betCount"` // Bet count at trade time if known, else current (-1 = unknown)
+}
+
+// ThresholdAnalysisOptions defines the candidate thresholds for a what-if analysis
+type ThresholdAnalysisOptions struct {
+	InsiderMaxBets []int     `json:"insiderMaxBets,omitempty"` // Default: 1-10
+	MinTradeSizes  []float64 `json:"minTradeSizes,omitempty"`  // Default: $100-$10k
+	Days           int       `json:"days,omitempty"`           // Lookback window (default: 30)
+}
+
+// ThresholdScenario reports how one threshold combination would have performed
+type ThresholdScenario struct {
+	InsiderMaxBets int     `json:"insiderMaxBets"`
+	MinTradeSize   float64 `json:"minTradeSize"`
+	Alerts         int     `json:"alerts"`
+	AlertsPerDay   float64 `json:"alertsPerDay"`
+	Evaluated      int     `json:"evaluated"` // Alerts with a later price to compare against
+	Hits           int     `json:"hits"`      // Alerts where the price later moved in the trade's favor
+	HitRate        float64 `json:"hitRate"`
+}
+
+// ThresholdAnalysis is the result of a historical what-if threshold analysis
+type ThresholdAnalysis struct {
+	Scenarios      []ThresholdScenario `json:"scenarios"`
+	TradesAnalyzed int                 `json:"tradesAnalyzed"`
+	Days           float64             `json:"days"`
+	From           time.Time           `json:"from,omitempty"`
+	To             time.Time           `json:"to,omitempty"`
+	GeneratedAt    time.Time           `json:"generatedAt"`
+}
+
+// HistogramBucket is a labelled count in a distribution
+type HistogramBucket struct {
+	Label string `json:"label"`
+	Min   int    `json:"min,omitempty"`
+	Max   int    `json:"max,omitempty"` // -1 = unbounded
+	Count int64  `json:"count"`
+}
+
+// WalletStats describes the distribution of seen wallets
+type WalletStats struct {
+	TotalWallets    int64             `json:"totalWallets"`
+	AnalyzedWallets int64             `json:"analyzedWallets"`
+	PendingWallets  int64             `json:"pendingWallets"` // Not analyzed yet (bet_count = -1)
+	FreshWallets    int64             `json:"freshWallets"`
+	BetCounts       []HistogramBucket `json:"betCounts"`
+	JoinDates       []HistogramBucket `json:"joinDates"` // Chronological, labelled by join month
+	FreshnessLevels []HistogramBucket `json:"freshnessLevels"`
+}
+
+// WalletUpdateType describes why a wallet update was published
+type WalletUpdateType string
+
+const (
+	WalletUpdateAnalyzed         WalletUpdateType = "wallet.analyzed"
+	WalletUpdateFreshnessChanged WalletUpdateType = "wallet.freshness_changed"
+)
+
+// WalletUpdate is published after a wallet analysis completes, for external mirrors
+type WalletUpdate struct {
+	Type                   WalletUpdateType `json:"type"`
+	Wallet                 WalletProfile    `json:"wallet"`
+	PreviousFreshnessLevel FreshnessLevel   `json:"previousFreshnessLevel,omitempty"`
+	Timestamp              time.Time        `json:"timestamp"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// AutoTuneSettings configures automatic adjustment of alert thresholds
+type AutoTuneSettings struct {
+	Enabled             bool    `json:"enabled"`
+	TargetAlertsPerDay  float64 `json:"targetAlertsPerDay"`  // Upper bound on the alert rate (default: 20)
+	LookbackDays        int     `json:"lookbackDays"`        // Trailing window to replay (default: 7)
+	MinTradeSizeFloor   float64 `json:"minTradeSizeFloor"`   // Lowest MinTradeSize auto-tune may set (default: 100)
+	MinTradeSizeCeiling float64 `json:"minTradeSizeCeiling"` // Highest MinTradeSize auto-tune may set (default: 50000)
+
+	// Locked thresholds keep their manual value
+	LockMinTradeSize   bool `json:"lockMinTradeSize"`
+	LockAlertThreshold bool `json:"lockAlertThreshold"`
+}
+
+// DefaultAutoTuneSettings returns auto-tune settings with auto-tune disabled
+func DefaultAutoTuneSettings() AutoTuneSettings {
+	return AutoTuneSettings{
+		TargetAlertsPerDay:  20,
+		LookbackDays:        7,
+		MinTradeSizeFloor:   100,
+		MinTradeSizeCeiling: 50000,
+	}
+}
+
+// AutoTuneResult describes one auto-tune run
+type AutoTuneResult struct {
+	RunAt                 time.Time `json:"runAt"`
+	Days                  float64   `json:"days"`                  // Span of the replayed trades
+	AlertsPerDay          float64   `json:"alertsPerDay"`          // With the thresholds before the run
+	ProjectedAlertsPerDay float64   `json:"projectedAlertsPerDay"` // With the chosen thresholds
+	MinTradeSize          float64   `json:"minTradeSize"`
+	AlertThreshold        float64   `json:"alertThreshold"`
+	Changed               bool      `json:"changed"`
+	TargetMet             bool      `json:"targetMet"` // False if even the strictest allowed thresholds exceed the target
+}
+
+// Sources of a threshold change
+const (
+	ConfigChangeSourceUser     = "user"
+	ConfigChangeSourceAutoTune = "auto-tune"
+)
+
+// ConfigChange is an audit trail entry for a threshold change
+type ConfigChange struct {
+	Timestamp time.Time `json:"timestamp"`
+	Source    string    `json:"source"` // ConfigChangeSourceUser or ConfigChangeSourceAutoTune
+	Field     string    `json:"field"`
+	OldValue  float64   `json:"oldValue"`
+	NewValue  float64   `json:"newValue"`
+	Reason    string    `json:"reason,omitempty"`
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// MarketInfo is market metadata from the Gamma API
+type MarketInfo struct {
+	Slug     string    `json:"slug"`
+	Question string    `json:"question"`
+	Category string    `json:"category,omitempty"`
+	EndDate  time.Time `json:"endDate"` // Zero if the market has no scheduled end
+	Closed   bool      `json:"closed"`
+
+	// Scheduled start of the game a sports market is on, zero for other markets
+	GameStartTime time.Time `json:"gameStartTime,omitempty"`
+
+	// Outcome index that paid out once the market is closed, -1 if not settled
+	WinningOutcome int `json:"winningOutcome"`
+}
+
+// GamePhase is when a trade on a sports market was placed relative to its game. The
+// phases double as the tags put on such trades.
+type GamePhase string
+
+const (
+	GamePhasePreGame GamePhase = "pre-game"
+	GamePhaseInGame  GamePhase = "in-game"
+)
+
+// GamePhaseAt returns the phase of the market's game at a time: before the scheduled
+// start, or within liveFor after it. Empty for markets without a game and after it.
+func (m MarketInfo) GamePhaseAt(at time.Time, liveFor time.Duration) GamePhase {
+	switch {
+	case m.GameStartTime.IsZero():
+		return ""
+	case at.Before(m.GameStartTime):
+		return GamePhasePreGame
+	case at.Before(m.GameStartTime.Add(liveFor)):
+		return GamePhaseInGame
+	}
+	return ""
+}
+
+// MarketResolution is an upcoming resolution of a watched market
+type MarketResolution struct {
+	Slug            string    `json:"slug"`
+	Name            string    `json:"name"`
+	Link            string    `json:"link"`
+	EndDate         time.Time `json:"endDate"`
+	GameStartTime   time.Time `json:"gameStartTime,omitempty"`
+	FlaggedTrades   int       `json:"flaggedTrades"`   // Stored trades with risk signals or fresh wallets
+	FlaggedNotional float64   `json:"flaggedNotional"` // Total notional of those trades
+	LastFlaggedAt   time.Time `json:"lastFlaggedAt,omitempty"`
+	Investigations  []string  `json:"investigations,omitempty"` // Names of open cases that include the market
+}
+
+// CalendarExport is an iCal file of upcoming market resolutions
+type CalendarExport struct {
+	Path    string `json:"path"`    // Stable path; re-exporting overwrites it so subscribed calendars refresh
+	Markets int    `json:"markets"` // Resolutions written to the file
+	Skipped int    `json:"skipped"` // Watched markets without a known future end date
+}
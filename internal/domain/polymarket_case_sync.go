@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+// CaseSyncProvider is the team tool investigations are synced to
+type CaseSyncProvider string
+
+const (
+	CaseSyncNotion   CaseSyncProvider = "notion"
+	CaseSyncAirtable CaseSyncProvider = "airtable"
+)
+
+// CaseRecordKind is what a synced record describes
+type CaseRecordKind string
+
+const (
+	CaseRecordAlert  CaseRecordKind = "alert"  // A flagged trade meeting the alert thresholds
+	CaseRecordWallet CaseRecordKind = "wallet" // A wallet in an investigation that is not closed
+)
+
+// CaseSyncTarget maps one kind of record onto a Notion database or Airtable table.
+// Fields maps record fields (see CaseRecordFields) to property or column names; the
+// "key" field is required and identifies the record when it is updated.
+type CaseSyncTarget struct {
+	Database string            `json:"database"` // Notion database ID or Airtable table name, empty = disabled
+	Fields   map[string]string `json:"fields"`
+}
+
+// CaseSyncConfig configures syncing alerts and watched wallets to Notion or Airtable
+type CaseSyncConfig struct {
+	Enabled  bool             `json:"enabled"`
+	Provider CaseSyncProvider `json:"provider"`
+	Token    string           `json:"token"`            // Notion integration token or Airtable personal access token
+	BaseID   string           `json:"baseId,omitempty"` // Airtable base ID (app...)
+	Alerts   CaseSyncTarget   `json:"alerts"`
+	Wallets  CaseSyncTarget   `json:"wallets"`
+}
+
+// CaseRecord is an alert or wallet with its fields keyed by record field name
+type CaseRecord struct {
+	Kind   CaseRecordKind `json:"kind"`
+	Key    string         `json:"key"`
+	Fields map[string]any `json:"fields"`
+}
+
+// CaseSyncStatus reports the progress of syncing to Notion or Airtable
+type CaseSyncStatus struct {
+	Enabled    bool      `json:"enabled"`
+	Pending    int       `json:"pending"`
+	Synced     int64     `json:"synced"`
+	Failed     int64     `json:"failed"`
+	LastSyncAt time.Time `json:"lastSyncAt,omitempty"`
+	LastError  string    `json:"lastError,omitempty"`
+}
+
+// CaseRecordFields lists the fields available for mapping per record kind
+var CaseRecordFields = map[CaseRecordKind][]string{
+	CaseRecordAlert: {
+		"key", "time", "market", "outcome", "side", "price", "notional",
+		"wallet", "betCount", "freshness", "riskScore", "signals", "link",
+	},
+	CaseRecordWallet: {
+		"key", "wallet", "betCount", "freshness", "joinDate", "investigations",
+		"status", "analyzedAt", "profile",
+	},
+}
+
+// DefaultCaseSyncFields maps every field of a record kind to a property of the same
+// name, with the key going to "Name" (the default title property of a Notion database)
+func DefaultCaseSyncFields(kind CaseRecordKind) map[string]string {
+	fields := make(map[string]string)
+	for _, field := range CaseRecordFields[kind] {
+		if field != "key" {
+			fields[field] = field
+		}
+	}
+	fields["key"] = "Name"
+	return fields
+}
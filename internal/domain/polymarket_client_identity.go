@@ -0,0 +1,16 @@
+package domain
+
+// UpstreamIdentity is how requests identify themselves to an upstream API
+type UpstreamIdentity struct {
+	UserAgent string            `json:"userAgent,omitempty"` // Replaces the User-Agent when set
+	Headers   map[string]string `json:"headers,omitempty"`   // Extra request headers; an empty value removes a header set by a broader level
+}
+
+// ClientIdentity configures the User-Agent and headers sent to Polymarket, for when its
+// bot detection starts rejecting the defaults. Levels apply from broadest to most
+// specific: Default, then the API, then the endpoint.
+type ClientIdentity struct {
+	Default   UpstreamIdentity            `json:"default"`
+	APIs      map[string]UpstreamIdentity `json:"apis,omitempty"`      // By API: "gamma", "clob", "profile" or "feed" (the live data WebSocket)
+	Endpoints map[string]UpstreamIdentity `json:"endpoints,omitempty"` // By URL prefix, e.g. "https://gamma-api.polymarket.com/events"; the longest matching prefix wins
+}
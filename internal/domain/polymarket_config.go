@@ -0,0 +1,123 @@
+package domain
+
+// PolymarketConfig holds configuration for the Polymarket watcher
+type PolymarketConfig struct {
+	Enabled        bool    `json:"enabled"`
+	MinTradeSize   float64 `json:"minTradeSize"`   // Min trade size in USDC to analyze
+	AlertThreshold float64 `json:"alertThreshold"` // Risk score threshold for alerts
+
+	// Fresh wallet detection thresholds (bet count based)
+	FreshInsiderMaxBets int `json:"freshInsiderMaxBets"` // Max bets to be "insider" (default: 3)
+	FreshWalletMaxBets  int `json:"freshWalletMaxBets"`  // Max bets to be "fresh" (default: 10)
+	FreshNewbieMaxBets  int `json:"freshNewbieMaxBets"`  // Max bets to be "newbie" (default: 20)
+	CustomFreshMaxBets  int `json:"customFreshMaxBets"`  // Custom threshold for "fresher" (0 = disabled)
+
+	// Estimate profiles from stored trades while the profile API is unreachable, instead of
+	// leaving wallets unanalyzed; such profiles have source "local"
+	OfflineWalletAnalysis bool `json:"offlineWalletAnalysis,omitempty"`
+
+	// Hot hand detection on resolved bets (0 = default)
+	HotHandMinStreak int     `json:"hotHandMinStreak,omitempty"` // Correct longshots in a row to flag a wallet (default: 6)
+	HotHandMaxPrice  float64 `json:"hotHandMaxPrice,omitempty"`  // Highest entry price that counts as a longshot (default: 0.35)
+
+	// Size outliers: trades far above the wallet's usual size (0 = default)
+	SizeOutlierMinZ      float64 `json:"sizeOutlierMinZ,omitempty"`      // Z-score of log notional vs the wallet's history (default: 3)
+	SizeOutlierMinTrades int     `json:"sizeOutlierMinTrades,omitempty"` // Trades seen before a wallet is judged (default: 5)
+
+	// Category novelty: a large trade in a market category the wallet never traded (0 = default)
+	NoveltyMinUSD    float64 `json:"noveltyMinUsd,omitempty"`    // Smallest trade that is judged (default: 5000)
+	NoveltyMinTrades int     `json:"noveltyMinTrades,omitempty"` // Stored trades in other categories before a wallet is judged (default: 5)
+
+	// Daily report of the trade flow into markets per entity they mention, see GetEntityFlow
+	EntityFlowReport     bool `json:"entityFlowReport,omitempty"`     // Send the report as a notification once a day
+	EntityFlowReportHour int  `json:"entityFlowReportHour,omitempty"` // UTC hour the report is sent at, covering the 24 hours before (0-23)
+
+	// Event-level alerts on fresh-wallet flow across an event's markets (0 = default)
+	GroupFlowMinUSD      float64 `json:"groupFlowMinUsd,omitempty"`      // Fresh-wallet flow into one event that alerts (default: 50000)
+	GroupFlowWindowHours int     `json:"groupFlowWindowHours,omitempty"` // Trailing window the flow is summed over (default: 24)
+
+	// Outcome price consistency across an event's markets (0 = default)
+	PriceSumMin float64 `json:"priceSumMin,omitempty"` // Fee-adjusted sum of YES prices below which an event is underpriced (default: 0.95)
+	PriceSumMax float64 `json:"priceSumMax,omitempty"` // Fee-adjusted sum above which an event is overpriced (default: 1.05)
+
+	// Wash trading: repeated offsetting trades by one wallet or a pair (0 = default)
+	WashWindowSeconds int `json:"washWindowSeconds,omitempty"` // Longest gap between offsetting trades (default: 10)
+	WashMinMatches    int `json:"washMinMatches,omitempty"`    // Offsetting pairs before the wallets are flagged (default: 3)
+
+	// Spoofing: large orders near the touch placed and cancelled without trading (0 = default)
+	SpoofMinUSD          float64 `json:"spoofMinUsd,omitempty"`          // Smallest order that counts (default: 10000)
+	SpoofMinCycles       int     `json:"spoofMinCycles,omitempty"`       // Place-then-cancel cycles within 10 minutes to flag a book (default: 3)
+	SpoofLifetimeSeconds int     `json:"spoofLifetimeSeconds,omitempty"` // Longest an order may rest and still count when cancelled (default: 60)
+	SpoofAlerts          bool    `json:"spoofAlerts,omitempty"`          // Send flagged books as alerts, not just signals
+
+	// Spread widening: a book's spread jumping far above its usual width (0 = default)
+	SpreadWidenRatio float64 `json:"spreadWidenRatio,omitempty"` // Multiple of the usual spread that flags a book (default: 3)
+	SpreadMinWidth   float64 `json:"spreadMinWidth,omitempty"`   // Narrowest spread that can be flagged (default: 0.05)
+	SpreadAlerts     bool    `json:"spreadAlerts,omitempty"`     // Send widened spreads as alerts, not just signals
+
+	// Sports markets: trades timed against the game's scheduled start (0 = default)
+	LiveGameHours    float64 `json:"liveGameHours,omitempty"`    // How long after the start a game counts as live (default: 3)
+	LiveGameAlerts   bool    `json:"liveGameAlerts,omitempty"`   // Notify in-game trades too; by default they are recorded without alerts
+	ScheduleTimezone string  `json:"scheduleTimezone,omitempty"` // IANA time zone game times are shown in, e.g. "Europe/Berlin" (default: the system's)
+
+	// Scheduled online backups (0 interval = disabled)
+	BackupIntervalHours int    `json:"backupIntervalHours,omitempty"` // Hours between backups
+	BackupKeep          int    `json:"backupKeep,omitempty"`          // Scheduled backups kept, oldest deleted first (default: 7)
+	BackupDir           string `json:"backupDir,omitempty"`           // Where backups are written (default: "backups" next to the database)
+
+	// Scheduled snapshots of watched markets, see CaptureMarketSnapshots (0 interval = disabled)
+	MarketSnapshotMinutes  int `json:"marketSnapshotMinutes,omitempty"`  // Minutes between snapshots
+	MarketSnapshotHolders  int `json:"marketSnapshotHolders,omitempty"`  // Top holders kept per outcome (default: 10, -1 = none)
+	MarketSnapshotKeepDays int `json:"marketSnapshotKeepDays,omitempty"` // Days snapshots are kept (default: 90)
+
+	// Batched event writes (0 = default)
+	EventBatchSize       int `json:"eventBatchSize,omitempty"`       // Events per insert transaction (default: 200)
+	EventFlushIntervalMs int `json:"eventFlushIntervalMs,omitempty"` // Longest a queued event waits for its batch to fill (default: 500)
+
+	// SQLite write-ahead log and connection settings (0 = default)
+	WALCheckpointMinutes int    `json:"walCheckpointMinutes,omitempty"` // Minutes between checkpoints that truncate the -wal file (default: 10)
+	WALCheckpointMB      int    `json:"walCheckpointMb,omitempty"`      // Checkpoint early once the -wal files grow past this size (default: 64)
+	BusyTimeoutMs        int    `json:"busyTimeoutMs,omitempty"`        // How long statements wait for a locked database (default: 5000)
+	Synchronous          string `json:"synchronous,omitempty"`          // "OFF", "NORMAL", "FULL" or "EXTRA" (default: NORMAL)
+
+	// Market thumbnails cached on disk (0 = default)
+	ImageCacheMaxMB int `json:"imageCacheMaxMb,omitempty"` // Size limit of the image cache, least recently used images evicted first (default: 100)
+
+	// User-Agent and headers sent to Polymarket (nil = browser User-Agent only)
+	ClientIdentity *ClientIdentity `json:"clientIdentity,omitempty"`
+
+	// Wallet analysis webhook (empty URL = disabled)
+	WalletWebhookURL    string `json:"walletWebhookUrl,omitempty"`
+	WalletWebhookSecret string `json:"walletWebhookSecret,omitempty"` // Signs payloads with HMAC-SHA256
+
+	// TradingView-compatible alert webhook (empty URL = disabled)
+	SignalWebhookURL        string `json:"signalWebhookUrl,omitempty"`
+	SignalWebhookPassphrase string `json:"signalWebhookPassphrase,omitempty"` // Sent in the body, as TradingView bots expect
+
+	// On-chain withdrawal monitoring of flagged winners and funding origin of fresh
+	// wallets (empty URL = disabled, 0 = default)
+	PolygonRPCURL         string  `json:"polygonRpcUrl,omitempty"`         // Polygon JSON-RPC endpoint
+	WithdrawalWindowHours int     `json:"withdrawalWindowHours,omitempty"` // How long after a resolution withdrawals are watched (default: 48)
+	WithdrawalMinUSD      float64 `json:"withdrawalMinUsd,omitempty"`      // Smallest transfer out that counts (default: 500)
+	FundingLookbackHours  int     `json:"fundingLookbackHours,omitempty"`  // How far back a fresh wallet's first USDC deposit is searched (default: 72)
+
+	// Deprecated: RPC-based detection is no longer used
+	PolygonRPCURLs      []string `json:"polygonRpcUrls,omitempty"`
+	FreshWalletMaxNonce int      `json:"freshWalletMaxNonce,omitempty"`
+	FreshWalletMaxAge   float64  `json:"freshWalletMaxAge,omitempty"`
+}
+
+// DefaultPolymarketConfig returns default configuration
+func DefaultPolymarketConfig() PolymarketConfig {
+	return PolymarketConfig{
+		Enabled:             true,
+		MinTradeSize:        100, // $100 minimum for fresh wallet analysis
+		AlertThreshold:      0.7,
+		FreshInsiderMaxBets: 3,
+		FreshWalletMaxBets:  10,
+		FreshNewbieMaxBets:  20,
+		CustomFreshMaxBets:  0, // Disabled by default
+		HotHandMinStreak:    6,
+		HotHandMaxPrice:     0.35,
+	}
+}
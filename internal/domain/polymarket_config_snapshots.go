@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// ConfigSnapshot is a version of the watcher config and tag rules that raised alerts,
+// kept so old alerts can be reviewed against the thresholds active at the time
+type ConfigSnapshot struct {
+	Hash      string           `json:"hash"` // See NewConfigSnapshot
+	Config    PolymarketConfig `json:"config"`
+	TagRules  []EventTagRule   `json:"tagRules"`
+	CreatedAt time.Time        `json:"createdAt"` // First alert raised under this version
+}
+
+// NewConfigSnapshot captures a config and its tag rules, without webhook secrets, RPC
+// endpoints and client headers, hashed so identical versions share a snapshot
+func NewConfigSnapshot(config PolymarketConfig, tagRules []EventTagRule) ConfigSnapshot {
+	config.WalletWebhookURL, config.WalletWebhookSecret = "", ""
+	config.SignalWebhookURL, config.SignalWebhookPassphrase = "", ""
+	config.PolygonRPCURL, config.PolygonRPCURLs = "", nil
+	config.ClientIdentity = nil // Headers may carry cookies
+	if tagRules == nil {
+		tagRules = []EventTagRule{}
+	}
+
+	data, _ := json.Marshal(struct {
+		Config   PolymarketConfig `json:"config"`
+		TagRules []EventTagRule   `json:"tagRules"`
+	}{config, tagRules})
+	sum := sha256.Sum256(data)
+	return ConfigSnapshot{Hash: hex.EncodeToString(sum[:8]), Config: config, TagRules: tagRules}
+}
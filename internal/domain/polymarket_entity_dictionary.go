@@ -0,0 +1,180 @@
+package domain
+
+// entityEntry is a known subject with the words titles name it by. Aliases written in
+// capitals (e.g. "US", "SOL") only match in capitals, so "us" and "sol" don't.
+type entityEntry struct {
+	typ     MarketEntityType
+	name    string
+	ticker  string // Also tags the ticker of a listed company
+	aliases []string
+}
+
+// entityDictionary lists the countries, people, companies and tickers market titles
+// are tagged with. Ambiguous words (Georgia, Jordan, Chad, X) are left out.
+var entityDictionary = []entityEntry{
+	// Countries, with demonyms where they're unambiguous
+	{typ: MarketEntityCountry, name: "United States", aliases: []string{"United States", "USA", "US", "America"}},
+	{typ: MarketEntityCountry, name: "Canada", aliases: []string{"Canada", "Canadian"}},
+	{typ: MarketEntityCountry, name: "Mexico", aliases: []string{"Mexico", "Mexican"}},
+	{typ: MarketEntityCountry, name: "Cuba", aliases: []string{"Cuba", "Cuban"}},
+	{typ: MarketEntityCountry, name: "Venezuela", aliases: []string{"Venezuela", "Venezuelan"}},
+	{typ: MarketEntityCountry, name: "Colombia", aliases: []string{"Colombia", "Colombian"}},
+	{typ: MarketEntityCountry, name: "Brazil", aliases: []string{"Brazil", "Brazilian"}},
+	{typ: MarketEntityCountry, name: "Argentina", aliases: []string{"Argentina", "Argentine", "Argentinian"}},
+	{typ: MarketEntityCountry, name: "Chile", aliases: []string{"Chile", "Chilean"}},
+	{typ: MarketEntityCountry, name: "Peru", aliases: []string{"Peru", "Peruvian"}},
+	{typ: MarketEntityCountry, name: "Ecuador", aliases: []string{"Ecuador", "Ecuadorian"}},
+	{typ: MarketEntityCountry, name: "Bolivia", aliases: []string{"Bolivia", "Bolivian"}},
+	{typ: MarketEntityCountry, name: "Panama", aliases: []string{"Panama", "Panamanian"}},
+	{typ: MarketEntityCountry, name: "Greenland", aliases: []string{"Greenland"}},
+	{typ: MarketEntityCountry, name: "United Kingdom", aliases: []string{"United Kingdom", "UK", "Britain", "Great Britain", "British"}},
+	{typ: MarketEntityCountry, name: "Ireland", aliases: []string{"Ireland", "Irish"}},
+	{typ: MarketEntityCountry, name: "France", aliases: []string{"France", "French"}},
+	{typ: MarketEntityCountry, name: "Germany", aliases: []string{"Germany", "German"}},
+	{typ: MarketEntityCountry, name: "Italy", aliases: []string{"Italy", "Italian"}},
+	{typ: MarketEntityCountry, name: "Spain", aliases: []string{"Spain", "Spanish"}},
+	{typ: MarketEntityCountry, name: "Portugal", aliases: []string{"Portugal", "Portuguese"}},
+	{typ: MarketEntityCountry, name: "Netherlands", aliases: []string{"Netherlands", "Dutch"}},
+	{typ: MarketEntityCountry, name: "Belgium", aliases: []string{"Belgium", "Belgian"}},
+	{typ: MarketEntityCountry, name: "Switzerland", aliases: []string{"Switzerland", "Swiss"}},
+	{typ: MarketEntityCountry, name: "Austria", aliases: []string{"Austria", "Austrian"}},
+	{typ: MarketEntityCountry, name: "Poland", aliases: []string{"Poland", "Polish"}},
+	{typ: MarketEntityCountry, name: "Czech Republic", aliases: []string{"Czech Republic", "Czechia", "Czech"}},
+	{typ: MarketEntityCountry, name: "Hungary", aliases: []string{"Hungary", "Hungarian"}},
+	{typ: MarketEntityCountry, name: "Romania", aliases: []string{"Romania", "Romanian"}},
+	{typ: MarketEntityCountry, name: "Moldova", aliases: []string{"Moldova", "Moldovan"}},
+	{typ: MarketEntityCountry, name: "Serbia", aliases: []string{"Serbia", "Serbian"}},
+	{typ: MarketEntityCountry, name: "Greece", aliases: []string{"Greece", "Greek"}},
+	{typ: MarketEntityCountry, name: "Sweden", aliases: []string{"Sweden", "Swedish"}},
+	{typ: MarketEntityCountry, name: "Norway", aliases: []string{"Norway", "Norwegian"}},
+	{typ: MarketEntityCountry, name: "Denmark", aliases: []string{"Denmark", "Danish"}},
+	{typ: MarketEntityCountry, name: "Finland", aliases: []string{"Finland", "Finnish"}},
+	{typ: MarketEntityCountry, name: "Ukraine", aliases: []string{"Ukraine", "Ukrainian"}},
+	{typ: MarketEntityCountry, name: "Russia", aliases: []string{"Russia", "Russian"}},
+	{typ: MarketEntityCountry, name: "Belarus", aliases: []string{"Belarus", "Belarusian"}},
+	{typ: MarketEntityCountry, name: "Turkey", aliases: []string{"Turkey", "Türkiye", "Turkish"}},
+	{typ: MarketEntityCountry, name: "Israel", aliases: []string{"Israel", "Israeli"}},
+	{typ: MarketEntityCountry, name: "Palestine", aliases: []string{"Palestine", "Palestinian", "Gaza", "West Bank"}},
+	{typ: MarketEntityCountry, name: "Lebanon", aliases: []string{"Lebanon", "Lebanese"}},
+	{typ: MarketEntityCountry, name: "Syria", aliases: []string{"Syria", "Syrian"}},
+	{typ: MarketEntityCountry, name: "Iraq", aliases: []string{"Iraq", "Iraqi"}},
+	{typ: MarketEntityCountry, name: "Iran", aliases: []string{"Iran", "Iranian"}},
+	{typ: MarketEntityCountry, name: "Saudi Arabia", aliases: []string{"Saudi Arabia", "Saudi"}},
+	{typ: MarketEntityCountry, name: "Yemen", aliases: []string{"Yemen", "Yemeni", "Houthi", "Houthis"}},
+	{typ: MarketEntityCountry, name: "Qatar", aliases: []string{"Qatar", "Qatari"}},
+	{typ: MarketEntityCountry, name: "United Arab Emirates", aliases: []string{"United Arab Emirates", "UAE"}},
+	{typ: MarketEntityCountry, name: "Egypt", aliases: []string{"Egypt", "Egyptian"}},
+	{typ: MarketEntityCountry, name: "Libya", aliases: []string{"Libya", "Libyan"}},
+	{typ: MarketEntityCountry, name: "Sudan", aliases: []string{"Sudan", "Sudanese"}},
+	{typ: MarketEntityCountry, name: "Ethiopia", aliases: []string{"Ethiopia", "Ethiopian"}},
+	{typ: MarketEntityCountry, name: "Nigeria", aliases: []string{"Nigeria", "Nigerian"}},
+	{typ: MarketEntityCountry, name: "South Africa", aliases: []string{"South Africa", "South African"}},
+	{typ: MarketEntityCountry, name: "Kenya", aliases: []string{"Kenya", "Kenyan"}},
+	{typ: MarketEntityCountry, name: "Afghanistan", aliases: []string{"Afghanistan", "Afghan"}},
+	{typ: MarketEntityCountry, name: "Pakistan", aliases: []string{"Pakistan", "Pakistani"}},
+	{typ: MarketEntityCountry, name: "India", aliases: []string{"India", "Indian"}},
+	{typ: MarketEntityCountry, name: "Bangladesh", aliases: []string{"Bangladesh", "Bangladeshi"}},
+	{typ: MarketEntityCountry, name: "China", aliases: []string{"China", "Chinese", "PRC"}},
+	{typ: MarketEntityCountry, name: "Taiwan", aliases: []string{"Taiwan", "Taiwanese"}},
+	{typ: MarketEntityCountry, name: "Hong Kong", aliases: []string{"Hong Kong"}},
+	{typ: MarketEntityCountry, name: "Japan", aliases: []string{"Japan", "Japanese"}},
+	{typ: MarketEntityCountry, name: "South Korea", aliases: []string{"South Korea", "South Korean"}},
+	{typ: MarketEntityCountry, name: "North Korea", aliases: []string{"North Korea", "North Korean", "DPRK"}},
+	{typ: MarketEntityCountry, name: "Vietnam", aliases: []string{"Vietnam", "Vietnamese"}},
+	{typ: MarketEntityCountry, name: "Thailand", aliases: []string{"Thailand", "Thai"}},
+	{typ: MarketEntityCountry, name: "Philippines", aliases: []string{"Philippines", "Filipino"}},
+	{typ: MarketEntityCountry, name: "Indonesia", aliases: []string{"Indonesia", "Indonesian"}},
+	{typ: MarketEntityCountry, name: "Australia", aliases: []string{"Australia", "Australian"}},
+	{typ: MarketEntityCountry, name: "New Zealand", aliases: []string{"New Zealand"}},
+
+	// People
+	{typ: MarketEntityPerson, name: "Donald Trump", aliases: []string{"Donald Trump", "Trump"}},
+	{typ: MarketEntityPerson, name: "JD Vance", aliases: []string{"JD Vance", "J.D. Vance", "Vance"}},
+	{typ: MarketEntityPerson, name: "Joe Biden", aliases: []string{"Joe Biden", "Biden"}},
+	{typ: MarketEntityPerson, name: "Kamala Harris", aliases: []string{"Kamala Harris", "Kamala"}},
+	{typ: MarketEntityPerson, name: "Barack Obama", aliases: []string{"Barack Obama", "Obama"}},
+	{typ: MarketEntityPerson, name: "Michelle Obama", aliases: []string{"Michelle Obama"}},
+	{typ: MarketEntityPerson, name: "Gavin Newsom", aliases: []string{"Gavin Newsom", "Newsom"}},
+	{typ: MarketEntityPerson, name: "Ron DeSantis", aliases: []string{"Ron DeSantis", "DeSantis"}},
+	{typ: MarketEntityPerson, name: "Marco Rubio", aliases: []string{"Marco Rubio", "Rubio"}},
+	{typ: MarketEntityPerson, name: "Alexandria Ocasio-Cortez", aliases: []string{"Alexandria Ocasio-Cortez", "Ocasio-Cortez", "AOC"}},
+	{typ: MarketEntityPerson, name: "Bernie Sanders", aliases: []string{"Bernie Sanders", "Bernie"}},
+	{typ: MarketEntityPerson, name: "Zohran Mamdani", aliases: []string{"Zohran Mamdani", "Mamdani"}},
+	{typ: MarketEntityPerson, name: "Robert F. Kennedy Jr.", aliases: []string{"Robert F. Kennedy Jr.", "RFK Jr.", "RFK"}},
+	{typ: MarketEntityPerson, name: "Pete Hegseth", aliases: []string{"Pete Hegseth", "Hegseth"}},
+	{typ: MarketEntityPerson, name: "Jerome Powell", aliases: []string{"Jerome Powell", "Jay Powell", "Powell"}},
+	{typ: MarketEntityPerson, name: "Elon Musk", aliases: []string{"Elon Musk", "Musk", "Elon"}},
+	{typ: MarketEntityPerson, name: "Sam Altman", aliases: []string{"Sam Altman", "Altman"}},
+	{typ: MarketEntityPerson, name: "Mark Zuckerberg", aliases: []string{"Mark Zuckerberg", "Zuckerberg"}},
+	{typ: MarketEntityPerson, name: "Jeff Bezos", aliases: []string{"Jeff Bezos", "Bezos"}},
+	{typ: MarketEntityPerson, name: "Taylor Swift", aliases: []string{"Taylor Swift"}},
+	{typ: MarketEntityPerson, name: "Vladimir Putin", aliases: []string{"Vladimir Putin", "Putin"}},
+	{typ: MarketEntityPerson, name: "Volodymyr Zelensky", aliases: []string{"Volodymyr Zelensky", "Zelensky", "Zelenskyy"}},
+	{typ: MarketEntityPerson, name: "Xi Jinping", aliases: []string{"Xi Jinping"}},
+	{typ: MarketEntityPerson, name: "Kim Jong Un", aliases: []string{"Kim Jong Un", "Kim Jong-un"}},
+	{typ: MarketEntityPerson, name: "Benjamin Netanyahu", aliases: []string{"Benjamin Netanyahu", "Netanyahu", "Bibi"}},
+	{typ: MarketEntityPerson, name: "Ali Khamenei", aliases: []string{"Ali Khamenei", "Khamenei"}},
+	{typ: MarketEntityPerson, name: "Nicolás Maduro", aliases: []string{"Nicolás Maduro", "Nicolas Maduro", "Maduro"}},
+	{typ: MarketEntityPerson, name: "María Corina Machado", aliases: []string{"María Corina Machado", "Maria Corina Machado", "Machado"}},
+	{typ: MarketEntityPerson, name: "Javier Milei", aliases: []string{"Javier Milei", "Milei"}},
+	{typ: MarketEntityPerson, name: "Lula da Silva", aliases: []string{"Lula da Silva", "Lula"}},
+	{typ: MarketEntityPerson, name: "Claudia Sheinbaum", aliases: []string{"Claudia Sheinbaum", "Sheinbaum"}},
+	{typ: MarketEntityPerson, name: "Mark Carney", aliases: []string{"Mark Carney", "Carney"}},
+	{typ: MarketEntityPerson, name: "Emmanuel Macron", aliases: []string{"Emmanuel Macron", "Macron"}},
+	{typ: MarketEntityPerson, name: "Keir Starmer", aliases: []string{"Keir Starmer", "Starmer"}},
+	{typ: MarketEntityPerson, name: "Friedrich Merz", aliases: []string{"Friedrich Merz", "Merz"}},
+	{typ: MarketEntityPerson, name: "Giorgia Meloni", aliases: []string{"Giorgia Meloni", "Meloni"}},
+	{typ: MarketEntityPerson, name: "Recep Tayyip Erdoğan", aliases: []string{"Recep Tayyip Erdoğan", "Erdoğan", "Erdogan"}},
+	{typ: MarketEntityPerson, name: "Narendra Modi", aliases: []string{"Narendra Modi", "Modi"}},
+
+	// Companies and their tickers
+	{typ: MarketEntityCompany, name: "Nvidia", ticker: "NVDA", aliases: []string{"Nvidia"}},
+	{typ: MarketEntityCompany, name: "Apple", ticker: "AAPL", aliases: []string{"Apple"}},
+	{typ: MarketEntityCompany, name: "Microsoft", ticker: "MSFT", aliases: []string{"Microsoft"}},
+	{typ: MarketEntityCompany, name: "Alphabet", ticker: "GOOGL", aliases: []string{"Alphabet", "Google", "GOOG"}},
+	{typ: MarketEntityCompany, name: "Amazon", ticker: "AMZN", aliases: []string{"Amazon"}},
+	{typ: MarketEntityCompany, name: "Meta", ticker: "META", aliases: []string{"Meta", "Facebook"}},
+	{typ: MarketEntityCompany, name: "Tesla", ticker: "TSLA", aliases: []string{"Tesla"}},
+	{typ: MarketEntityCompany, name: "Netflix", ticker: "NFLX", aliases: []string{"Netflix"}},
+	{typ: MarketEntityCompany, name: "AMD", ticker: "AMD", aliases: []string{"Advanced Micro Devices"}},
+	{typ: MarketEntityCompany, name: "Intel", ticker: "INTC", aliases: []string{"Intel"}},
+	{typ: MarketEntityCompany, name: "Broadcom", ticker: "AVGO", aliases: []string{"Broadcom"}},
+	{typ: MarketEntityCompany, name: "TSMC", ticker: "TSM", aliases: []string{"Taiwan Semiconductor"}},
+	{typ: MarketEntityCompany, name: "Oracle", ticker: "ORCL", aliases: []string{"Oracle"}},
+	{typ: MarketEntityCompany, name: "Palantir", ticker: "PLTR", aliases: []string{"Palantir"}},
+	{typ: MarketEntityCompany, name: "Coinbase", ticker: "COIN", aliases: []string{"Coinbase"}},
+	{typ: MarketEntityCompany, name: "MicroStrategy", ticker: "MSTR", aliases: []string{"MicroStrategy"}},
+	{typ: MarketEntityCompany, name: "Robinhood", ticker: "HOOD", aliases: []string{"Robinhood"}},
+	{typ: MarketEntityCompany, name: "GameStop", ticker: "GME", aliases: []string{"GameStop"}},
+	{typ: MarketEntityCompany, name: "Boeing", ticker: "BA", aliases: []string{"Boeing"}},
+	{typ: MarketEntityCompany, name: "Disney", ticker: "DIS", aliases: []string{"Disney"}},
+	{typ: MarketEntityCompany, name: "Walmart", ticker: "WMT", aliases: []string{"Walmart"}},
+	{typ: MarketEntityCompany, name: "JPMorgan", ticker: "JPM", aliases: []string{"JPMorgan", "JP Morgan"}},
+	{typ: MarketEntityCompany, name: "Goldman Sachs", ticker: "GS", aliases: []string{"Goldman Sachs", "Goldman"}},
+	{typ: MarketEntityCompany, name: "Uber", ticker: "UBER", aliases: []string{"Uber"}},
+	{typ: MarketEntityCompany, name: "Spotify", ticker: "SPOT", aliases: []string{"Spotify"}},
+	{typ: MarketEntityCompany, name: "Alibaba", ticker: "BABA", aliases: []string{"Alibaba"}},
+	{typ: MarketEntityCompany, name: "Pfizer", ticker: "PFE", aliases: []string{"Pfizer"}},
+	{typ: MarketEntityCompany, name: "Eli Lilly", ticker: "LLY", aliases: []string{"Eli Lilly"}},
+	{typ: MarketEntityCompany, name: "Novo Nordisk", ticker: "NVO", aliases: []string{"Novo Nordisk"}},
+	{typ: MarketEntityCompany, name: "OpenAI", aliases: []string{"OpenAI", "ChatGPT"}},
+	{typ: MarketEntityCompany, name: "Anthropic", aliases: []string{"Anthropic"}},
+	{typ: MarketEntityCompany, name: "xAI", aliases: []string{"xAI", "Grok"}},
+	{typ: MarketEntityCompany, name: "SpaceX", aliases: []string{"SpaceX", "Starship"}},
+	{typ: MarketEntityCompany, name: "TikTok", aliases: []string{"TikTok", "ByteDance"}},
+	{typ: MarketEntityCompany, name: "Twitter", aliases: []string{"Twitter"}},
+
+	// Crypto assets, tagged by ticker
+	{typ: MarketEntityTicker, name: "BTC", aliases: []string{"Bitcoin"}},
+	{typ: MarketEntityTicker, name: "ETH", aliases: []string{"Ethereum", "Ether"}},
+	{typ: MarketEntityTicker, name: "SOL", aliases: []string{"Solana"}},
+	{typ: MarketEntityTicker, name: "XRP", aliases: []string{"Ripple"}},
+	{typ: MarketEntityTicker, name: "DOGE", aliases: []string{"Dogecoin"}},
+	{typ: MarketEntityTicker, name: "ADA", aliases: []string{"Cardano"}},
+	{typ: MarketEntityTicker, name: "BNB", aliases: []string{"Binance Coin"}},
+	{typ: MarketEntityTicker, name: "LINK", aliases: []string{"Chainlink"}},
+	{typ: MarketEntityTicker, name: "LTC", aliases: []string{"Litecoin"}},
+	{typ: MarketEntityTicker, name: "HYPE", aliases: []string{"Hyperliquid"}},
+	{typ: MarketEntityTicker, name: "USDT", aliases: []string{"Tether"}},
+	{typ: MarketEntityTicker, name: "USDC", aliases: nil},
+}
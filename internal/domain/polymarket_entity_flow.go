@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// EntityTradeFlow is one wallet's trades on one market mentioning an entity, the rows
+// entity flow is summed from
+type EntityTradeFlow struct {
+	Entity     MarketEntity
+	MarketSlug string
+	Wallet     string
+	Volume     float64 // Notional in USDC
+	Trades     int64
+	Fresh      bool // Any of the trades was flagged as from a fresh wallet
+}
+
+// EntityFlow is the trade flow into every market mentioning an entity over a window,
+// e.g. all Trump-related markets today
+type EntityFlow struct {
+	MarketEntity
+	Markets           int     `json:"markets"` // Markets mentioning the entity that were traded
+	Trades            int64   `json:"trades"`
+	Wallets           int     `json:"wallets"`
+	Volume            float64 `json:"volume"`
+	FreshWalletFlow   float64 `json:"freshWalletFlow"` // Volume from wallets flagged as fresh
+	FreshWallets      int     `json:"freshWallets"`
+	SmartMoneyFlow    float64 `json:"smartMoneyFlow"` // Volume from wallets with a winning record
+	SmartMoneyWallets int     `json:"smartMoneyWallets"`
+	FreshShare        float64 `json:"freshShare"` // FreshWalletFlow / Volume, 0-1
+}
+
+// EntityFlowReport is the entity flow over a window, the most fresh-wallet flow first
+type EntityFlowReport struct {
+	Since       time.Time    `json:"since"`
+	Until       time.Time    `json:"until"`
+	GeneratedAt time.Time    `json:"generatedAt"`
+	Entities    []EntityFlow `json:"entities"`
+}
+
+// entityFlowReportLines is how many entities the report notification lists
+const entityFlowReportLines = 10
+
+// NewEntityFlowNotification creates the daily entity flow report notification
+func NewEntityFlowNotification(report EntityFlowReport) NotificationContent {
+	msg := "<b>🌐 Entity Flow Report</b>\n"
+	msg += escapeHTML(report.Since.UTC().Format("Jan 2 15:04")) + " – " + escapeHTML(report.Until.UTC().Format("Jan 2 15:04")) + " UTC\n\n"
+	if len(report.Entities) == 0 {
+		msg += "No trades on tagged markets."
+	}
+	for i, e := range report.Entities {
+		if i == entityFlowReportLines {
+			break
+		}
+		msg += "<b>" + escapeHTML(e.Name) + "</b> (" + strconv.Itoa(e.Markets) + " markets): $" + formatFloat(e.Volume, 0) +
+			", fresh $" + formatFloat(e.FreshWalletFlow, 0) + " from " + strconv.Itoa(e.FreshWallets) + " wallets" +
+			", smart $" + formatFloat(e.SmartMoneyFlow, 0) + "\n"
+	}
+
+	return NotificationContent{
+		EventType: NotificationEventEntityFlow,
+		Title:     "Entity Flow Report",
+		Message:   msg,
+		Timestamp: report.GeneratedAt,
+		Priority:  "low",
+		Metadata: map[string]string{
+			"since":    report.Since.UTC().Format(time.RFC3339),
+			"until":    report.Until.UTC().Format(time.RFC3339),
+			"entities": strconv.Itoa(len(report.Entities)),
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// PolymarketEventFilter represents filter criteria for events
+type PolymarketEventFilter struct {
+	EventTypes         []PolymarketEventType `json:"eventTypes,omitempty"`
+	MarketName         string                `json:"marketName,omitempty"`
+	MinPrice           float64               `json:"minPrice,omitempty"`
+	MaxPrice           float64               `json:"maxPrice,omitempty"`
+	Side               OrderSide             `json:"side,omitempty"`
+	Outcome            string                `json:"outcome,omitempty"`      // Trades on this outcome, e.g. "Yes" (case-insensitive)
+	OutcomeIndex       *int                  `json:"outcomeIndex,omitempty"` // Trades on this outcome index (0 = first outcome, usually Yes); nil = any
+	MinSize            float64               `json:"minSize,omitempty"`
+	Limit              int                   `json:"limit,omitempty"`
+	Offset             int                   `json:"offset,omitempty"`
+	FreshWalletsOnly   bool                  `json:"freshWalletsOnly,omitempty"`
+	MinRiskScore       float64               `json:"minRiskScore,omitempty"`
+	MaxWalletNonce     int                   `json:"maxWalletNonce,omitempty"`
+	Tag                string                `json:"tag,omitempty"`
+	Entity             string                `json:"entity,omitempty"`             // Events on markets whose title mentions this entity, e.g. "NVDA" or "Venezuela"
+	WalletAddress      string                `json:"walletAddress,omitempty"`      // Trades by this wallet only
+	WalletAddresses    []string              `json:"walletAddresses,omitempty"`    // Trades by any of these wallets, combined with WalletAddress
+	WalletTag          string                `json:"walletTag,omitempty"`          // Trades by wallets carrying this wallet tag, e.g. "follow"
+	MarketSlugs        []string              `json:"marketSlugs,omitempty"`        // Events on any of these markets or events (market or event slug)
+	ConditionIDs       []string              `json:"conditionIds,omitempty"`       // Events on any of these markets by condition ID, combined with MarketSlugs
+	ExcludeMarketNames []string              `json:"excludeMarketNames,omitempty"` // Leaves out events whose market name or event title contains any of these
+	ExcludeWallets     []string              `json:"excludeWallets,omitempty"`     // Leaves out trades by any of these wallets
+	Since              time.Time             `json:"since,omitempty"`              // Events at or after, zero = no bound
+	Until              time.Time             `json:"until,omitempty"`              // Events before, zero = no bound
+	SortBy             EventSortField        `json:"sortBy,omitempty"`             // Default: timestamp
+	SortDir            SortDirection         `json:"sortDir,omitempty"`            // Default: desc
+}
+
+// PolymarketEventPage is a page of events with how many match the filter in total, for
+// paginated views. The totals cover every matching event, not just the page, and are
+// read together with the page so they agree with it.
+type PolymarketEventPage struct {
+	Events           []PolymarketEvent `json:"events"`
+	Total            int64             `json:"total"`
+	TotalNotional    float64           `json:"totalNotional"`    // Sum of price × size
+	FreshWalletCount int64             `json:"freshWalletCount"` // Events by fresh wallets
+}
+
+// Wallets returns the wallet addresses a filter selects, or nil when it doesn't filter
+// by wallet
+func (f PolymarketEventFilter) Wallets() []string {
+	if f.WalletAddress == "" {
+		return f.WalletAddresses
+	}
+	return append([]string{f.WalletAddress}, f.WalletAddresses...)
+}
+
+// MatchesOutcome reports whether an event passes the filter's outcome and outcome index
+func (f PolymarketEventFilter) MatchesOutcome(event PolymarketEvent) bool {
+	if f.Outcome != "" && !strings.EqualFold(event.Outcome, f.Outcome) {
+		return false
+	}
+	if f.OutcomeIndex != nil && (event.EventType != PolymarketEventTrade || event.OutcomeIndex != *f.OutcomeIndex) {
+		return false
+	}
+	return true
+}
+
+// Excludes reports whether the filter's exclusions leave an event out. Market names
+// match partially and wallets exactly, both case-insensitively.
+func (f PolymarketEventFilter) Excludes(event PolymarketEvent) bool {
+	for _, wallet := range f.ExcludeWallets {
+		if wallet != "" && strings.EqualFold(event.WalletAddress, wallet) {
+			return true
+		}
+	}
+	if len(f.ExcludeMarketNames) == 0 {
+		return false
+	}
+	marketName, eventTitle := strings.ToLower(event.MarketName), strings.ToLower(event.EventTitle)
+	for _, name := range f.ExcludeMarketNames {
+		name = strings.ToLower(name)
+		if name != "" && (strings.Contains(marketName, name) || strings.Contains(eventTitle, name)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package domain
+
+// EventSortField selects what events are ordered by
+type EventSortField string
+
+const (
+	EventSortTimestamp EventSortField = "timestamp" // Default
+	EventSortNotional  EventSortField = "notional"  // Price x size in USDC
+	EventSortRiskScore EventSortField = "risk_score"
+	EventSortBetCount  EventSortField = "bet_count" // Trader's bet count when the event was saved; events without one sort last
+)
+
+// SortDirection orders results ascending or descending
+type SortDirection string
+
+const (
+	SortDesc SortDirection = "desc" // Default
+	SortAsc  SortDirection = "asc"
+)
@@ -0,0 +1,82 @@
+package domain
+
+import "time"
+
+// ExportColumn is a column of an event export. Template is a Go text/template
+// evaluated for every event against an ExportRow, e.g. "{{money .Notional}}" or
+// "{{pct .ImpliedProbability}}".
+type ExportColumn struct {
+	Header   string `json:"header"`
+	Template string `json:"template"`
+}
+
+// EventExportDefinition is a saved CSV export of stored events
+type EventExportDefinition struct {
+	Name    string                `json:"name"`
+	Filter  PolymarketEventFilter `json:"filter"`
+	Columns []ExportColumn        `json:"columns"`
+}
+
+// ExportRow is the data an export column template sees for one event
+type ExportRow struct {
+	Time               time.Time `json:"time"`
+	Type               string    `json:"type"`
+	Market             string    `json:"market"`
+	MarketLink         string    `json:"marketLink"`
+	Wallet             string    `json:"wallet"`
+	Trader             string    `json:"trader"`
+	Side               string    `json:"side"`
+	Outcome            string    `json:"outcome"`
+	Price              float64   `json:"price"`
+	Size               float64   `json:"size"`
+	Notional           float64   `json:"notional"`           // Price × size in USDC
+	ImpliedProbability float64   `json:"impliedProbability"` // Outcome price read as the market's probability (0-1)
+	BetCount           int       `json:"betCount"`           // -1 if the wallet was not analyzed
+	Freshness          string    `json:"freshness"`          // Freshness level, e.g. "insider"
+	FreshnessLabel     string    `json:"freshnessLabel"`     // Human readable freshness, e.g. "Likely insider"
+	RiskScore          float64   `json:"riskScore"`
+	RiskSignals        []string  `json:"riskSignals"`
+	Tags               []string  `json:"tags"`
+}
+
+// EventExport is a written event export
+type EventExport struct {
+	Path        string `json:"path"`
+	Rows        int    `json:"rows"`
+	FailedCells int    `json:"failedCells"` // Cells left empty because their template failed
+}
+
+// DefaultExportColumns returns the columns of a new export definition
+func DefaultExportColumns() []ExportColumn {
+	return []ExportColumn{
+		{Header: "Time", Template: `{{time .Time}}`},
+		{Header: "Market", Template: `{{.Market}}`},
+		{Header: "Wallet", Template: `{{.Wallet}}`},
+		{Header: "Side", Template: `{{.Side}}`},
+		{Header: "Outcome", Template: `{{.Outcome}}`},
+		{Header: "Notional", Template: `{{round .Notional 2}}`},
+		{Header: "Implied probability", Template: `{{pct .ImpliedProbability}}`},
+		{Header: "Freshness", Template: `{{.FreshnessLabel}}`},
+	}
+}
+
+// FreshnessLabels are the human readable names of freshness levels
+var FreshnessLabels = map[FreshnessLevel]string{
+	FreshnessInsider: "Likely insider",
+	FreshnessWallet:  "Fresh wallet",
+	FreshnessNewbie:  "New user",
+	FreshnessCustom:  "Fresh (custom threshold)",
+}
+
+// EventExportFormat is the encoding of a raw event export
+type EventExportFormat string
+
+const (
+	EventExportCSV   EventExportFormat = "csv"   // One row per event with a header row
+	EventExportJSONL EventExportFormat = "jsonl" // One JSON-encoded event per line
+)
+
+// Valid reports whether the format is supported
+func (f EventExportFormat) Valid() bool {
+	return f == EventExportCSV || f == EventExportJSONL
+}
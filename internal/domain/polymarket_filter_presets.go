@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// FilterPreset is a named event filter saved for quick switching, e.g. "big politics
+// buys" or "all sells"
+type FilterPreset struct {
+	Name      string                `json:"name"` // Unique, case-insensitive
+	Filter    PolymarketEventFilter `json:"filter"`
+	CreatedAt time.Time             `json:"createdAt"`
+	UpdatedAt time.Time             `json:"updatedAt"`
+}
+
+// PageFilter returns the preset's filter, with limit and offset overriding its paging
+// when above 0
+func (p FilterPreset) PageFilter(limit, offset int) PolymarketEventFilter {
+	filter := p.Filter
+	if limit > 0 {
+		filter.Limit = limit
+	}
+	if offset > 0 {
+		filter.Offset = offset
+	}
+	return filter
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// FundingKind is the kind of a labeled address that funds wallets
+type FundingKind string
+
+const (
+	FundingExchange FundingKind = "exchange" // Centralized exchange hot wallet; its users passed KYC
+	FundingBridge   FundingKind = "bridge"   // Bridge or mint; no identity behind the funds
+)
+
+// FundingLabel names a known address that funds wallets
+type FundingLabel struct {
+	Name string      `json:"name"` // e.g. "Binance" or "Across"
+	Kind FundingKind `json:"kind"`
+}
+
+// FundingOrigin is the first USDC deposit into a wallet and, when the sender is a
+// labeled exchange or bridge, who sent it
+type FundingOrigin struct {
+	Wallet    string      `json:"wallet"`
+	From      string      `json:"from"`
+	Name      string      `json:"name,omitempty"` // Label of From, empty when unknown
+	Kind      FundingKind `json:"kind,omitempty"`
+	Token     string      `json:"token"` // "USDC.e" or "USDC"
+	AmountUSD float64     `json:"amountUsd"`
+	TxHash    string      `json:"txHash"`
+	Block     uint64      `json:"block"`
+	At        time.Time   `json:"at"`
+}
+
+// Labeled reports whether the deposit came straight from a known exchange or bridge
+func (o FundingOrigin) Labeled() bool {
+	return o.Name != ""
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// InvestigationStatus is the lifecycle state of an investigation
+type InvestigationStatus string
+
+const (
+	InvestigationOpen       InvestigationStatus = "open"
+	InvestigationMonitoring InvestigationStatus = "monitoring" // Waiting on market resolution or more activity
+	InvestigationClosed     InvestigationStatus = "closed"
+)
+
+// Valid reports whether the status is a known investigation status
+func (s InvestigationStatus) Valid() bool {
+	switch s {
+	case InvestigationOpen, InvestigationMonitoring, InvestigationClosed:
+		return true
+	}
+	return false
+}
+
+// InvestigationItemType identifies what an investigation item refers to
+type InvestigationItemType string
+
+const (
+	InvestigationItemWallet InvestigationItemType = "wallet" // Wallet address
+	InvestigationItemMarket InvestigationItemType = "market" // Market or event slug
+	InvestigationItemEvent  InvestigationItemType = "event"  // Stored event ID
+)
+
+// Valid reports whether the type is a known investigation item type
+func (t InvestigationItemType) Valid() bool {
+	switch t {
+	case InvestigationItemWallet, InvestigationItemMarket, InvestigationItemEvent:
+		return true
+	}
+	return false
+}
+
+// Investigation groups wallets, markets, events and notes into a named case
+type Investigation struct {
+	ID          int64               `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Status      InvestigationStatus `json:"status"`
+	Items       []InvestigationItem `json:"items"`
+	Notes       []InvestigationNote `json:"notes"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+// InvestigationItem is a wallet, market or event attached to an investigation
+type InvestigationItem struct {
+	Type    InvestigationItemType `json:"type"`
+	Ref     string                `json:"ref"` // Address, slug or event ID
+	AddedAt time.Time             `json:"addedAt"`
+}
+
+// InvestigationNote is a free-form note on an investigation
+type InvestigationNote struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+// LateEntryRule flags large positions opened shortly before a market's scheduled end
+type LateEntryRule struct {
+	Category    string  `yaml:"category,omitempty" json:"category,omitempty"` // Gamma market category (e.g. "Politics"), empty = any
+	WindowHours float64 `yaml:"window_hours" json:"windowHours"`              // Hours before the end date
+	MinNotional float64 `yaml:"min_notional" json:"minNotional"`              // Minimum trade notional in USDC
+}
+
+// DefaultLateEntryRules returns the rules used until the user saves their own
+func DefaultLateEntryRules() []LateEntryRule {
+	return []LateEntryRule{
+		{WindowHours: 24, MinNotional: 5000},
+	}
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MarketEntityType is the kind of subject a market title mentions
+type MarketEntityType string
+
+const (
+	MarketEntityCountry MarketEntityType = "country"
+	MarketEntityPerson  MarketEntityType = "person"
+	MarketEntityCompany MarketEntityType = "company"
+	MarketEntityTicker  MarketEntityType = "ticker" // Stock or crypto symbol, e.g. "NVDA" or "BTC"
+)
+
+// MarketEntity is a subject found in a market's title, by its canonical name
+// ("Venezuela", "Donald Trump", "Nvidia", "NVDA") whatever alias the title used
+type MarketEntity struct {
+	Type MarketEntityType `json:"type"`
+	Name string           `json:"name"`
+}
+
+// EntityMarket is a market whose title mentions an entity
+type EntityMarket struct {
+	MarketSlug string    `json:"marketSlug"`
+	MarketName string    `json:"marketName"`
+	TaggedAt   time.Time `json:"taggedAt"`
+}
+
+// MarketEntityCount is an entity with the number of stored markets mentioning it
+type MarketEntityCount struct {
+	MarketEntity
+	Markets int64 `json:"markets"`
+}
+
+// MarketTitle is a stored market's name and the title of its event, the text entities
+// are found in
+type MarketTitle struct {
+	MarketSlug string `json:"marketSlug"`
+	MarketName string `json:"marketName"`
+	EventTitle string `json:"eventTitle,omitempty"`
+}
+
+// EntityAlertRule flags trades on any market mentioning an entity, e.g. "NVDA" or
+// "Venezuela"
+type EntityAlertRule struct {
+	Entity      string  `yaml:"entity" json:"entity"`            // Canonical name or alias, case-insensitive
+	MinNotional float64 `yaml:"min_notional" json:"minNotional"` // Minimum trade notional in USDC, 0 = any trade the save filter keeps
+}
+
+// MarketTaggingResult reports a tagging run over the stored markets
+type MarketTaggingResult struct {
+	Markets  int `json:"markets"`  // Markets read
+	Tagged   int `json:"tagged"`   // Markets with at least one entity
+	Entities int `json:"entities"` // Entities stored over all markets
+}
+
+// entityAlias is one alias of a dictionary entry, split into words
+type entityAlias struct {
+	words []string
+	entry *entityEntry
+}
+
+var (
+	// entityAliases indexes the dictionary's aliases by their lowercased first word,
+	// longest alias first
+	entityAliases = indexEntityAliases()
+
+	// cashtagPattern matches "$NVDA"-style tickers, which are tagged even when unknown
+	cashtagPattern = regexp.MustCompile(`\$([A-Za-z]{1,5})\b`)
+)
+
+// indexEntityAliases builds entityAliases from the dictionary
+func indexEntityAliases() map[string][]entityAlias {
+	index := make(map[string][]entityAlias)
+	for i := range entityDictionary {
+		entry := &entityDictionary[i]
+		names := append([]string{entry.name}, entry.aliases...)
+		if entry.ticker != "" {
+			names = append(names, entry.ticker)
+		}
+		for _, name := range names {
+			words := entityWords(name)
+			if len(words) == 0 {
+				continue
+			}
+			key := strings.ToLower(words[0])
+			index[key] = append(index[key], entityAlias{words: words, entry: entry})
+		}
+	}
+	for _, aliases := range index {
+		sort.SliceStable(aliases, func(i, j int) bool { return len(aliases[i].words) > len(aliases[j].words) })
+	}
+	return index
+}
+
+// entityWords splits text into words, dropping the dots of abbreviations ("U.S." is "US")
+func entityWords(text string) []string {
+	return strings.FieldsFunc(strings.ReplaceAll(text, ".", ""), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// ExtractMarketEntities finds the countries, people, companies and tickers that texts
+// such as a market's name and event title mention, by a dictionary of names and
+// aliases. Names must be capitalized as in a title; aliases in capitals ("US") must
+// match exactly. Listed companies are tagged with their ticker too.
+func ExtractMarketEntities(texts ...string) []MarketEntity {
+	seen := make(map[MarketEntity]bool)
+	var entities []MarketEntity
+	add := func(e MarketEntity) {
+		if !seen[e] {
+			seen[e] = true
+			entities = append(entities, e)
+		}
+	}
+
+	for _, text := range texts {
+		for _, m := range cashtagPattern.FindAllStringSubmatch(text, -1) {
+			add(MarketEntity{Type: MarketEntityTicker, Name: strings.ToUpper(m[1])})
+		}
+		words := entityWords(text)
+		for i := 0; i < len(words); {
+			matched := 0
+			for _, alias := range entityAliases[strings.ToLower(words[i])] {
+				if aliasMatches(alias.words, words[i:]) {
+					add(MarketEntity{Type: alias.entry.typ, Name: alias.entry.name})
+					if alias.entry.ticker != "" {
+						add(MarketEntity{Type: MarketEntityTicker, Name: alias.entry.ticker})
+					}
+					matched = len(alias.words)
+					break
+				}
+			}
+			i += max(matched, 1)
+		}
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].Type != entities[j].Type {
+			return entities[i].Type < entities[j].Type
+		}
+		return entities[i].Name < entities[j].Name
+	})
+	return entities
+}
+
+// aliasMatches reports whether words start with an alias: words in capitals must match
+// exactly, others ignoring case, and the first word must be capitalized like the alias
+func aliasMatches(alias, words []string) bool {
+	if len(words) < len(alias) {
+		return false
+	}
+	for i, a := range alias {
+		w := words[i]
+		if strings.ToUpper(a) == a {
+			if w != a {
+				return false
+			}
+			continue
+		}
+		if !strings.EqualFold(w, a) {
+			return false
+		}
+		if i == 0 && unicode.IsUpper([]rune(a)[0]) && !unicode.IsUpper([]rune(w)[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CanonicalEntity returns the name entities are stored under for a name, alias or
+// ticker ("google" is "Alphabet", "$nvda" is "NVDA"); unknown names come back trimmed
+func CanonicalEntity(name string) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "$")
+	for _, entry := range entityDictionary {
+		if strings.EqualFold(entry.ticker, name) {
+			return entry.ticker
+		}
+		if strings.EqualFold(entry.name, name) {
+			return entry.name
+		}
+		for _, alias := range entry.aliases {
+			if strings.EqualFold(alias, name) {
+				return entry.name
+			}
+		}
+	}
+	return name
+}
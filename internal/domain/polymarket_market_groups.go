@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// MarketGroup aggregates recent trades across the sub-markets of one Polymarket event
+// (e.g. every candidate of "Republican nominee"), grouped by event slug
+type MarketGroup struct {
+	EventSlug       string              `json:"eventSlug"`
+	EventTitle      string              `json:"eventTitle"`
+	Link            string              `json:"link"`
+	Volume          float64             `json:"volume"`          // Notional of tracked trades in the window
+	FreshWalletFlow float64             `json:"freshWalletFlow"` // Notional of those trades by fresh wallets
+	FreshWallets    int                 `json:"freshWallets"`
+	Trades          int                 `json:"trades"`
+	Markets         []MarketGroupMarket `json:"markets"` // Most fresh-wallet flow first
+	WindowHours     int                 `json:"windowHours"`
+	LastTradeAt     time.Time           `json:"lastTradeAt"`
+}
+
+// MarketGroupMarket is one sub-market's share of a group's trades
+type MarketGroupMarket struct {
+	Slug            string  `json:"slug"`
+	Name            string  `json:"name"`
+	Volume          float64 `json:"volume"`
+	FreshWalletFlow float64 `json:"freshWalletFlow"`
+	Trades          int     `json:"trades"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// MarketSnapshot is the state of a market at one moment: outcome prices, top of book,
+// volume, liquidity and, where the data API has them, the largest holders
+type MarketSnapshot struct {
+	ID             int64          `json:"id"`
+	MarketSlug     string         `json:"marketSlug"`
+	ConditionID    string         `json:"conditionId,omitempty"`
+	MarketName     string         `json:"marketName"`
+	Outcomes       []string       `json:"outcomes,omitempty"`       // e.g. ["Yes", "No"]
+	Prices         []float64      `json:"prices,omitempty"`         // Per outcome, in the order of Outcomes
+	BestBid        float64        `json:"bestBid,omitempty"`        // Of the first outcome
+	BestAsk        float64        `json:"bestAsk,omitempty"`        // Of the first outcome
+	LastTradePrice float64        `json:"lastTradePrice,omitempty"` // Of the first outcome
+	Volume         float64        `json:"volume"`                   // Lifetime volume in USDC
+	Volume24h      float64        `json:"volume24h"`
+	Liquidity      float64        `json:"liquidity"`
+	Closed         bool           `json:"closed"`
+	TopHolders     []MarketHolder `json:"topHolders,omitempty"` // Largest first per outcome, empty when unavailable
+	CapturedAt     time.Time      `json:"capturedAt"`
+}
+
+// MarketHolder is a wallet holding shares of a market's outcome
+type MarketHolder struct {
+	Wallet       string  `json:"wallet"`
+	Name         string  `json:"name,omitempty"`
+	OutcomeIndex int     `json:"outcomeIndex"`
+	Amount       float64 `json:"amount"` // Shares held
+}
+
+// MarketSnapshotFilter selects stored snapshots
+type MarketSnapshotFilter struct {
+	MarketSlug string    `json:"marketSlug,omitempty"` // Empty = every market
+	Since      time.Time `json:"since,omitempty"`
+	Until      time.Time `json:"until,omitempty"` // With limit 1, the state of a market as of a time
+	Limit      int       `json:"limit,omitempty"` // 0 = default (100)
+}
+
+// MarketSnapshotCapture reports a snapshot run over the watched markets
+type MarketSnapshotCapture struct {
+	Markets    int       `json:"markets"`  // Watched markets looked up
+	Captured   int       `json:"captured"` // Snapshots stored
+	Failed     int       `json:"failed"`   // Markets the Gamma API returned nothing for
+	Holders    int       `json:"holders"`  // Snapshots with top holders
+	CapturedAt time.Time `json:"capturedAt"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// EventMarkets is the current pricing of a Polymarket event's markets
+type EventMarkets struct {
+	Slug      string             `json:"slug"`
+	Title     string             `json:"title"`
+	NegRisk   bool               `json:"negRisk"` // Outcomes are mutually exclusive, so their YES prices should sum to 1
+	Markets   []EventMarketPrice `json:"markets"`
+	Closed    bool               `json:"closed"`
+	FetchedAt time.Time          `json:"fetchedAt"`
+}
+
+// EventMarketPrice is the YES price of one market in an event
+type EventMarketPrice struct {
+	Slug     string  `json:"slug"`
+	Question string  `json:"question"`
+	Price    float64 `json:"price"`   // Bid/ask midpoint, or the last outcome price without a book
+	FeeRate  float64 `json:"feeRate"` // Taker fee as a fraction of notional
+	Closed   bool    `json:"closed"`
+}
+
+// PriceConsistency is an event whose YES prices stayed outside the configured band
+// over consecutive checks, a sign of mispricing or broken market structure
+type PriceConsistency struct {
+	EventSlug   string             `json:"eventSlug"`
+	EventTitle  string             `json:"eventTitle"`
+	Link        string             `json:"link"`
+	Markets     []EventMarketPrice `json:"markets"`
+	PriceSum    float64            `json:"priceSum"`    // Raw sum of YES prices
+	AdjustedSum float64            `json:"adjustedSum"` // Sum after taker fees, in the direction of the trade that would capture the gap
+	Direction   string             `json:"direction"`   // "under" (buying every outcome pays off) or "over" (selling every outcome does)
+	Checks      int                `json:"checks"`      // Consecutive inconsistent checks
+	Since       time.Time          `json:"since"`
+	CheckedAt   time.Time          `json:"checkedAt"`
+	Alerted     bool               `json:"alerted"`
+}
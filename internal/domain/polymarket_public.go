@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// PublicSnapshot is an anonymized aggregate of recent trade flow, safe to serve on a
+// public dashboard. Wallets only appear as salted hashes.
+type PublicSnapshot struct {
+	GeneratedAt     time.Time           `json:"generatedAt"`
+	WindowHours     int                 `json:"windowHours"`
+	Volume          float64             `json:"volume"`
+	FreshWalletFlow float64             `json:"freshWalletFlow"`
+	SmartMoneyIndex float64             `json:"smartMoneyIndex"` // Share of volume from wallets with a winning record, 0-1
+	Markets         []PublicMarketStats `json:"markets"`         // Most fresh-wallet flow first
+	TopWallets      []PublicWalletStats `json:"topWallets"`      // Largest traders in the window with a few trades or more
+}
+
+// PublicMarketStats is the anonymized flow into one event's markets
+type PublicMarketStats struct {
+	EventSlug       string  `json:"eventSlug"`
+	EventTitle      string  `json:"eventTitle"`
+	Link            string  `json:"link"`
+	Volume          float64 `json:"volume"`
+	Trades          int     `json:"trades"`
+	FreshWalletFlow float64 `json:"freshWalletFlow"`
+	FreshWallets    int     `json:"freshWallets"`
+	SmartMoneyFlow  float64 `json:"smartMoneyFlow"`
+	SmartMoneyIndex float64 `json:"smartMoneyIndex"` // SmartMoneyFlow / Volume
+}
+
+// PublicWalletStats is a wallet's flow in the window under a hashed identity. Exact
+// volumes and trade counts could be matched to on-chain trades, so only a volume range
+// is shown.
+type PublicWalletStats struct {
+	WalletHash   string `json:"walletHash"`
+	VolumeBucket string `json:"volumeBucket"` // e.g. "10k-100k" (USDC)
+	Fresh        bool   `json:"fresh"`
+	Smart        bool   `json:"smart"` // Winning record in resolved markets
+}
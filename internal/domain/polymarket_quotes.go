@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// MarketQuote is the latest best bid and ask of an outcome token
+type MarketQuote struct {
+	AssetID     string    `json:"assetId"`
+	ConditionID string    `json:"conditionId,omitempty"`
+	BestBid     float64   `json:"bestBid"`
+	BestAsk     float64   `json:"bestAsk"`
+	Spread      float64   `json:"spread"` // BestAsk - BestBid
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// QuoteSpread returns the gap between the best ask and bid, without float noise
+func QuoteSpread(bid, ask float64) float64 {
+	return math.Round((ask-bid)*1e6) / 1e6
+}
+
+// SpreadSignal is an outcome's book whose spread suddenly widened far beyond its usual
+// width, typically because liquidity was pulled ahead of news
+type SpreadSignal struct {
+	AssetID     string    `json:"assetId"`
+	ConditionID string    `json:"conditionId,omitempty"`
+	MarketName  string    `json:"marketName,omitempty"`
+	BestBid     float64   `json:"bestBid"`
+	BestAsk     float64   `json:"bestAsk"`
+	Spread      float64   `json:"spread"`
+	Baseline    float64   `json:"baseline"` // Usual spread of the book before it widened
+	Ratio       float64   `json:"ratio"`    // Spread / Baseline
+	DetectedAt  time.Time `json:"detectedAt"`
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+// ReportKind is the subject of a shareable report
+type ReportKind string
+
+const (
+	ReportKindWallet        ReportKind = "wallet"
+	ReportKindInvestigation ReportKind = "investigation"
+)
+
+// ReportFormat is the file format of a shareable report
+type ReportFormat string
+
+const (
+	ReportFormatHTML ReportFormat = "html" // Self-contained page, no external assets
+	ReportFormatJSON ReportFormat = "json"
+)
+
+// ReportRequest selects what a shareable report covers and how it is written
+type ReportRequest struct {
+	Kind            ReportKind   `json:"kind"`
+	Wallet          string       `json:"wallet,omitempty"`          // For wallet reports
+	InvestigationID int64        `json:"investigationId,omitempty"` // For investigation reports
+	Format          ReportFormat `json:"format,omitempty"`          // Default: html
+	RedactAddresses bool         `json:"redactAddresses,omitempty"` // Replace addresses and trader names with labels
+}
+
+// ShareableReport is the content of a report, free of raw payloads and settings
+type ShareableReport struct {
+	Kind          ReportKind     `json:"kind"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description,omitempty"`
+	Status        string         `json:"status,omitempty"` // Investigation status
+	GeneratedAt   time.Time      `json:"generatedAt"`
+	Redacted      bool           `json:"redacted"`
+	Wallets       []ReportWallet `json:"wallets"`
+	Markets       []string       `json:"markets,omitempty"`
+	Trades        []ReportTrade  `json:"trades"`
+	Notes         []ReportNote   `json:"notes,omitempty"`
+	TotalNotional float64        `json:"totalNotional"`
+}
+
+// ReportWallet is a wallet as shown in a report
+type ReportWallet struct {
+	Label          string         `json:"label"` // Address, or "Wallet A" when redacted
+	TraderName     string         `json:"traderName,omitempty"`
+	BetCount       int            `json:"betCount"` // -1 if not analyzed
+	JoinDate       string         `json:"joinDate,omitempty"`
+	FreshnessLevel FreshnessLevel `json:"freshnessLevel,omitempty"`
+	Tags           []string       `json:"tags,omitempty"`
+}
+
+// ReportTrade is a trade as shown in a report
+type ReportTrade struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Wallet      string    `json:"wallet"` // Matches ReportWallet.Label
+	Market      string    `json:"market"`
+	MarketLink  string    `json:"marketLink,omitempty"`
+	Outcome     string    `json:"outcome,omitempty"`
+	Side        OrderSide `json:"side,omitempty"`
+	Price       float64   `json:"price"`
+	Notional    float64   `json:"notional"`
+	RiskScore   float64   `json:"riskScore,omitempty"`
+	RiskSignals []string  `json:"riskSignals,omitempty"`
+}
+
+// ReportNote is an investigation note as shown in a report
+type ReportNote struct {
+	Timestamp time.Time `json:"timestamp"`
+	Body      string    `json:"body"`
+}
+
+// ReportFile is a generated report written to disk
+type ReportFile struct {
+	Path   string       `json:"path"`
+	Format ReportFormat `json:"format"`
+	Size   int64        `json:"size"`
+}
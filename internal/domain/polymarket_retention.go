@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// EventRetention bounds how many events are kept. A background job prunes events
+// beyond either limit; a zero limit is disabled. Events added to an investigation are
+// never pruned.
+type EventRetention struct {
+	MaxAgeDays int   `json:"maxAgeDays"` // Delete events older than this many days (0 = no age limit)
+	MaxEvents  int64 `json:"maxEvents"`  // Keep at most this many of the newest events (0 = no count limit)
+	KeepTagged bool  `json:"keepTagged"` // Never prune tagged events
+	Archive    bool  `json:"archive"`    // Move events past MaxAgeDays to the archive table instead of deleting them
+}
+
+// DefaultEventRetention returns retention with both limits disabled, keeping every event
+func DefaultEventRetention() EventRetention {
+	return EventRetention{KeepTagged: true}
+}
+
+// Enabled reports whether any limit is set
+func (r EventRetention) Enabled() bool {
+	return r.MaxAgeDays > 0 || r.MaxEvents > 0
+}
+
+// EventPruneResult describes one pruning or archiving run
+type EventPruneResult struct {
+	RunAt      time.Time `json:"runAt"`
+	Deleted    int64     `json:"deleted"`
+	Archived   int64     `json:"archived"`
+	Remaining  int64     `json:"remaining"`
+	DurationMs int64     `json:"durationMs"`
+}
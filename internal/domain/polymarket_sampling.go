@@ -0,0 +1,30 @@
+package domain
+
+// DefaultTickSize is the usual Polymarket price tick, used when a sampling rule leaves
+// it unset
+const DefaultTickSize = 0.01
+
+// EventSamplingRule thins out a high-frequency event type before it is stored.
+// Updates are counted per asset; an update is kept when it is the Nth since the
+// last kept one or when the price moved by more than MinTickChange ticks.
+type EventSamplingRule struct {
+	EventType     PolymarketEventType `json:"eventType"`
+	EveryN        int                 `json:"everyN"`             // Keep every Nth update (1 = keep all, 0 = only on price change)
+	MinTickChange int                 `json:"minTickChange"`      // Keep updates moving the price by more than this many ticks (0 = disabled)
+	TickSize      float64             `json:"tickSize,omitempty"` // Price tick until the asset announces its own (default 0.01)
+}
+
+// EventSamplingStats counts the raw and kept events of one type since startup
+type EventSamplingStats struct {
+	EventType PolymarketEventType `json:"eventType"`
+	Received  int64               `json:"received"` // Raw updates received
+	Kept      int64               `json:"kept"`     // Updates passed on for storage
+}
+
+// DefaultEventSamplingRules returns the rules used until the user saves their own
+func DefaultEventSamplingRules() []EventSamplingRule {
+	return []EventSamplingRule{
+		{EventType: PolymarketEventBook, EveryN: 20, MinTickChange: 1, TickSize: DefaultTickSize},
+		{EventType: PolymarketEventPriceChange, EveryN: 10, MinTickChange: 1, TickSize: DefaultTickSize},
+	}
+}
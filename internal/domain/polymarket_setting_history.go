@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// SettingHistoryEntry is a value a settings key held before it was overwritten
+type SettingHistoryEntry struct {
+	ID         int64     `json:"id"`
+	Key        string    `json:"key"`        // e.g. "config", "filter" or "event_tag_rules"
+	Value      string    `json:"value"`      // The previous value as JSON
+	ReplacedAt time.Time `json:"replacedAt"` // When the key was overwritten
+}
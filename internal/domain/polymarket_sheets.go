@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// SheetsSinkConfig configures appending flagged events to a Google Sheet
+type SheetsSinkConfig struct {
+	Enabled         bool   `json:"enabled"`
+	CredentialsFile string `json:"credentialsFile"` // Path to a service account key (JSON)
+	SpreadsheetID   string `json:"spreadsheetId"`   // From the sheet URL: /spreadsheets/d/<id>/edit
+	SheetName       string `json:"sheetName"`       // Tab rows are appended to, "Sheet1" if empty
+	AlertsOnly      bool   `json:"alertsOnly"`      // Only flagged trades meeting the alert thresholds
+}
+
+// SheetsSinkStatus reports the Google Sheets sink's progress
+type SheetsSinkStatus struct {
+	Enabled      bool      `json:"enabled"`
+	ClientEmail  string    `json:"clientEmail,omitempty"` // Share the sheet with this address
+	Pending      int       `json:"pending"`               // Rows waiting for the next append
+	Appended     int64     `json:"appended"`
+	Dropped      int64     `json:"dropped"` // Rows discarded while the sheet was unreachable
+	LastAppendAt time.Time `json:"lastAppendAt,omitempty"`
+	LastError    string    `json:"lastError,omitempty"`
+}
+
+// SheetsSinkColumns are the header of the rows appended by the Google Sheets sink
+var SheetsSinkColumns = []string{
+	"Time", "Market", "Outcome", "Side", "Price", "Size", "Notional",
+	"Wallet", "Bet count", "Freshness", "Risk score", "Signals", "Tags", "Link",
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// WalletSizeProfile summarizes the trade sizes seen from a wallet since the app started.
+// Sizes are compared on a log scale, so the typical size is a geometric mean.
+type WalletSizeProfile struct {
+	Address         string  `json:"address"`
+	Trades          int     `json:"trades"`
+	TypicalNotional float64 `json:"typicalNotional"` // Geometric mean notional in USDC
+	LogStdDev       float64 `json:"logStdDev"`       // Spread of log notional
+	MarketTypical   float64 `json:"marketTypical"`   // Geometric mean notional over every wallet
+	MarketTrades    int     `json:"marketTrades"`
+}
+
+// WalletActiveHours is the hour-of-day profile (UTC) of the trades seen from a wallet
+// since the app started
+type WalletActiveHours struct {
+	Address string  `json:"address"`
+	Trades  int     `json:"trades"`
+	Hours   [24]int `json:"hours"` // Trades per UTC hour
+}
+
+// WalletCategoryStats is a wallet's stored trades in one market category
+type WalletCategoryStats struct {
+	Category string    `json:"category"` // Gamma market category, e.g. "Sports"
+	Trades   int       `json:"trades"`
+	Notional float64   `json:"notional"` // Summed notional in USDC
+	FirstAt  time.Time `json:"firstAt"`
+	LastAt   time.Time `json:"lastAt"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// SpoofingSignal is an outcome's order book where large orders near the touch were
+// repeatedly placed and cancelled without trading, a sign of spoofing or layering
+type SpoofingSignal struct {
+	AssetID     string    `json:"assetId"`
+	MarketSlug  string    `json:"marketSlug,omitempty"`
+	MarketName  string    `json:"marketName,omitempty"`
+	Side        OrderSide `json:"side"`        // Book side the orders rested on: BUY for bids, SELL for asks
+	Cycles      int       `json:"cycles"`      // Place-then-cancel cycles in the window
+	Levels      []float64 `json:"levels"`      // Distinct prices the orders were placed at
+	Notional    float64   `json:"notional"`    // Largest cancelled order, in USD
+	AvgLifetime float64   `json:"avgLifetime"` // Average seconds an order rested before it was cancelled
+	Layering    bool      `json:"layering"`    // Orders were spread over several price levels
+	FirstAt     time.Time `json:"firstAt"`
+	DetectedAt  time.Time `json:"detectedAt"`
+}
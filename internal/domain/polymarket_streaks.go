@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// MarketOutcome is the resolution state of a market, as last checked on the Gamma API
+type MarketOutcome struct {
+	Slug           string    `json:"slug"`
+	Resolved       bool      `json:"resolved"`
+	WinningOutcome int       `json:"winningOutcome"` // Outcome index that paid out, -1 if unresolved
+	ResolvedAt     time.Time `json:"resolvedAt,omitempty"`
+	CheckedAt      time.Time `json:"checkedAt"`
+}
+
+// ResolvedBet is a wallet's position in a market that has since resolved.
+// Several buys of the same outcome count as one bet at their average price.
+type ResolvedBet struct {
+	MarketSlug   string    `json:"marketSlug"`
+	OutcomeIndex int       `json:"outcomeIndex"`
+	Price        float64   `json:"price"` // Average entry price
+	Won          bool      `json:"won"`
+	PlacedAt     time.Time `json:"placedAt"` // First buy
+	ResolvedAt   time.Time `json:"resolvedAt"`
+}
+
+// WalletStreak summarizes a wallet's run of correct resolved bets
+type WalletStreak struct {
+	Address        string    `json:"address"`
+	ResolvedBets   int       `json:"resolvedBets"`
+	Wins           int       `json:"wins"`
+	CurrentStreak  int       `json:"currentStreak"`  // Most recent consecutive correct bets
+	LongshotStreak int       `json:"longshotStreak"` // Most recent consecutive correct longshot bets
+	BestStreak     int       `json:"bestStreak"`
+	LastResolvedAt time.Time `json:"lastResolvedAt,omitempty"`
+	HotHand        bool      `json:"hotHand"` // LongshotStreak reached the configured minimum
+}
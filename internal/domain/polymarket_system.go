@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// SystemStatus is a snapshot of process resource usage for diagnosing leaks during long runs
+type SystemStatus struct {
+	RSSBytes        uint64              `json:"rssBytes"`        // Resident set size (0 where the OS doesn't report it)
+	HeapAllocBytes  uint64              `json:"heapAllocBytes"`  // Bytes of allocated heap objects
+	HeapObjects     uint64              `json:"heapObjects"`     // Number of allocated heap objects
+	SysBytes        uint64              `json:"sysBytes"`        // Memory obtained from the OS by the Go runtime
+	NumGC           uint32              `json:"numGC"`           // Completed GC cycles
+	Goroutines      int                 `json:"goroutines"`      // Live goroutines
+	WriteQueueDepth int64               `json:"writeQueueDepth"` // Events waiting to be written to the database
+	CacheSizes      map[string]int      `json:"cacheSizes"`      // Entries per in-memory cache
+	HTTPCacheHits   int64               `json:"httpCacheHits"`   // Metadata requests answered 304 and served from the disk cache
+	HTTPCacheMisses int64               `json:"httpCacheMisses"` // Metadata requests that downloaded the payload
+	ImageCache      *ImageCacheStats    `json:"imageCache"`      // Market thumbnails on disk, nil without a database path
+	Errors          map[ErrorKind]int64 `json:"errors"`          // Errors reported since start, by kind
+	CollectedAt     time.Time           `json:"collectedAt"`
+}
+
+// ImageCacheStats reports the disk cache of market thumbnails
+type ImageCacheStats struct {
+	Images   int   `json:"images"`
+	Bytes    int64 `json:"bytes"`
+	MaxBytes int64 `json:"maxBytes"`
+	Hits     int64 `json:"hits"`   // Images served from disk
+	Misses   int64 `json:"misses"` // Images downloaded
+}
+
+// ProfileExport is a pprof profile written to disk
+type ProfileExport struct {
+	Profile   string `json:"profile"` // Profile name, e.g. "heap" or "cpu"
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"sizeBytes"`
+}
+
+// WriteQueueStatus reports the bounded queue of events waiting for the database
+type WriteQueueStatus struct {
+	Depth             int                           `json:"depth"`             // Queued events
+	Capacity          int                           `json:"capacity"`          // Queue bound
+	InFlight          int                           `json:"inFlight"`          // Events being written
+	Dropped           map[PolymarketEventType]int64 `json:"dropped"`           // Events dropped on overflow, by type
+	BlockedTrades     int64                         `json:"blockedTrades"`     // Trades that had to wait for space (never dropped)
+	Priority          int64                         `json:"priority"`          // Alert-worthy events written directly, skipping the queue
+	BackPressure      bool                          `json:"backPressure"`      // Set while the queue is near full or overflowing
+	BackPressureSince time.Time                     `json:"backPressureSince"` // Zero when there is no back-pressure
+}
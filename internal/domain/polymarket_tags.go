@@ -0,0 +1,31 @@
+package domain
+
+import "strings"
+
+// EventTagRule automatically tags incoming events that match all of its conditions
+type EventTagRule struct {
+	Tag           string  `yaml:"tag" json:"tag"`
+	MarketName    string  `yaml:"market_name,omitempty" json:"marketName,omitempty"` // Substring of market name or event title
+	MarketSlug    string  `yaml:"market_slug,omitempty" json:"marketSlug,omitempty"` // Market or event slug
+	WalletAddress string  `yaml:"wallet_address,omitempty" json:"walletAddress,omitempty"`
+	MinNotional   float64 `yaml:"min_notional,omitempty" json:"minNotional,omitempty"` // Price * size in USDC
+	MinRiskScore  float64 `yaml:"min_risk_score,omitempty" json:"minRiskScore,omitempty"`
+	Entity        string  `yaml:"entity,omitempty" json:"entity,omitempty"` // Entity the market's title mentions, e.g. "NVDA"
+}
+
+// HasConditions reports whether the rule constrains events at all
+func (r EventTagRule) HasConditions() bool {
+	return r.MarketName != "" || r.MarketSlug != "" || r.WalletAddress != "" ||
+		r.MinNotional > 0 || r.MinRiskScore > 0 || r.Entity != ""
+}
+
+// EventTagCount is a tag with the number of events carrying it
+type EventTagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// NormalizeTag lowercases a tag and joins its words with dashes ("Superbowl Insider" -> "superbowl-insider")
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TradingViewAlert is an alert in the JSON shape commonly configured for TradingView
+// webhooks, so bots built around TradingView alerts can consume Polymarket signals.
+// Fields mirror TradingView placeholders ({{ticker}}, {{close}}, {{strategy.order.action}}, ...).
+type TradingViewAlert struct {
+	Passphrase string              `json:"passphrase,omitempty"`
+	Ticker     string              `json:"ticker"`   // MARKET-SLUG:OUTCOME
+	Exchange   string              `json:"exchange"` // Always "POLYMARKET"
+	Interval   string              `json:"interval"`
+	Time       string              `json:"time"`    // Trade time (RFC 3339)
+	TimeNow    string              `json:"timenow"` // Alert time (RFC 3339)
+	Close      float64             `json:"close"`   // Trade price (0-1)
+	Volume     float64             `json:"volume"`  // Trade size in shares
+	Action     string              `json:"action"`  // "buy" or "sell"
+	Strategy   TradingViewStrategy `json:"strategy"`
+	Message    string              `json:"message"`
+
+	// Polymarket specifics, ignored by bots that don't know them
+	Wallet    string   `json:"wallet,omitempty"`
+	Notional  float64  `json:"notional"`
+	RiskScore float64  `json:"riskScore"`
+	Signals   []string `json:"signals,omitempty"`
+	Link      string   `json:"link,omitempty"`
+}
+
+// TradingViewStrategy mirrors TradingView's {{strategy.*}} placeholders
+type TradingViewStrategy struct {
+	OrderAction    string  `json:"order_action"`
+	OrderContracts float64 `json:"order_contracts"`
+	OrderPrice     float64 `json:"order_price"`
+	OrderID        string  `json:"order_id"`
+	OrderComment   string  `json:"order_comment"`
+	MarketPosition string  `json:"market_position"` // "long" for buys, "short" for sells
+}
+
+// NewTradingViewAlert converts a flagged trade to the TradingView alert shape
+func NewTradingViewAlert(event PolymarketEvent, passphrase string, now time.Time) TradingViewAlert {
+	price, _ := strconv.ParseFloat(event.Price, 64)
+	size, _ := strconv.ParseFloat(event.Size, 64)
+	action, position := "buy", "long"
+	if event.Side == OrderSideSell {
+		action, position = "sell", "short"
+	}
+
+	ticker := event.MarketSlug
+	if ticker == "" {
+		ticker = event.AssetID
+	}
+	ticker = strings.ToUpper(ticker)
+	if event.Outcome != "" {
+		ticker += ":" + strings.ToUpper(event.Outcome)
+	}
+
+	market := event.MarketName
+	if market == "" {
+		market = event.EventTitle
+	}
+	comment := strings.Join(event.RiskSignals, ", ")
+
+	return TradingViewAlert{
+		Passphrase: passphrase,
+		Ticker:     ticker,
+		Exchange:   "POLYMARKET",
+		Interval:   "1",
+		Time:       event.Timestamp.UTC().Format(time.RFC3339),
+		TimeNow:    now.UTC().Format(time.RFC3339),
+		Close:      price,
+		Volume:     size,
+		Action:     action,
+		Strategy: TradingViewStrategy{
+			OrderAction:    action,
+			OrderContracts: size,
+			OrderPrice:     price,
+			OrderID:        event.TradeID,
+			OrderComment:   comment,
+			MarketPosition: position,
+		},
+		Message:   strings.ToUpper(action) + " " + event.Outcome + " @ " + event.Price + " on " + market + " (" + comment + ")",
+		Wallet:    event.WalletAddress,
+		Notional:  price * size,
+		RiskScore: event.RiskScore,
+		Signals:   event.RiskSignals,
+		Link:      event.MarketLink,
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// BlacklistedWallet is a wallet left out of wallet analysis and alerts, e.g. a known
+// market maker or bot that keeps tripping the fresh-wallet logic. Its trades are still
+// recorded.
+type BlacklistedWallet struct {
+	Address string    `json:"address"` // Lowercased
+	Reason  string    `json:"reason,omitempty"`
+	AddedAt time.Time `json:"addedAt"`
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WalletIntelVersion is the format version of wallet intel bundles
+const WalletIntelVersion = 1
+
+// WalletIntel is what one user learned about a wallet, shared without their database
+type WalletIntel struct {
+	Address      string         `json:"address"`
+	Tags         []string       `json:"tags,omitempty"`
+	Freshness    FreshnessLevel `json:"freshness,omitempty"`
+	BetCount     int            `json:"betCount,omitempty"`
+	ResolvedBets int            `json:"resolvedBets,omitempty"`
+	Wins         int            `json:"wins,omitempty"`
+	WinRate      float64        `json:"winRate,omitempty"` // Wins / ResolvedBets
+	HotHand      bool           `json:"hotHand,omitempty"`
+	WashTrader   bool           `json:"washTrader,omitempty"`
+}
+
+// WalletIntelBundle is a signed list of wallet intel. Signature is the Ed25519
+// signature by PublicKey of SignedBytes, so importers can tell who curated a list and
+// that nobody changed it since.
+type WalletIntelBundle struct {
+	Version   int           `json:"version"`
+	Publisher string        `json:"publisher,omitempty"` // Name the publisher chose
+	PublicKey string        `json:"publicKey"`           // Base64
+	CreatedAt time.Time     `json:"createdAt"`
+	Wallets   []WalletIntel `json:"wallets"`
+	Signature string        `json:"signature"` // Base64
+}
+
+// SignedBytes returns the bytes the signature covers: the bundle's JSON without it
+func (b WalletIntelBundle) SignedBytes() ([]byte, error) {
+	b.Signature = ""
+	return json.Marshal(b)
+}
+
+// WalletIntelExportRequest selects the wallets to share
+type WalletIntelExportRequest struct {
+	Addresses []string `json:"addresses,omitempty"` // Wallets to share; empty = every tagged wallet
+	Tag       string   `json:"tag,omitempty"`       // Only wallets with this tag
+	Publisher string   `json:"publisher,omitempty"` // Name shown to importers
+}
+
+// WalletIntelExport is a written wallet intel bundle
+type WalletIntelExport struct {
+	Path      string `json:"path"`
+	Wallets   int    `json:"wallets"`
+	PublicKey string `json:"publicKey"` // Share it so others can recognize your bundles
+}
+
+// ImportedWalletIntel is wallet intel from another user's bundle. It is kept apart
+// from the local analysis, which it never overwrites.
+type ImportedWalletIntel struct {
+	WalletIntel
+	Publisher  string    `json:"publisher,omitempty"`
+	PublicKey  string    `json:"publicKey"`
+	ImportedAt time.Time `json:"importedAt"`
+}
+
+// WalletIntelImportResult summarizes an imported bundle
+type WalletIntelImportResult struct {
+	Publisher  string `json:"publisher,omitempty"`
+	PublicKey  string `json:"publicKey"`
+	Wallets    int    `json:"wallets"`    // Wallets imported
+	NewWallets int    `json:"newWallets"` // Wallets not seen before, queued for analysis
+	Tags       int    `json:"tags"`       // Tags in the bundle, kept with the intel rather than added to the local tags
+	Skipped    int    `json:"skipped"`    // Entries without a valid address
+}
+
+// TrustedIntelPublisher is a public key whose wallet intel bundles may be imported.
+// A valid signature only proves a bundle is unchanged; the key must be pinned too.
+type TrustedIntelPublisher struct {
+	PublicKey string    `json:"publicKey"` // Base64 Ed25519 key, as in bundles
+	Name      string    `json:"name,omitempty"`
+	AddedAt   time.Time `json:"addedAt"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// WalletSortField selects the column wallets are ordered by
+type WalletSortField string
+
+const (
+	WalletSortFirstSeen    WalletSortField = "first_seen" // Default
+	WalletSortLastAnalyzed WalletSortField = "last_analyzed"
+	WalletSortBetCount     WalletSortField = "bet_count"
+	WalletSortAddress      WalletSortField = "address"
+	WalletSortVolume       WalletSortField = "volume"
+)
+
+// WalletFilter narrows, orders and pages the stored wallets
+type WalletFilter struct {
+	FreshnessLevels []FreshnessLevel `json:"freshnessLevels,omitempty"`
+	MinBetCount     int              `json:"minBetCount,omitempty"`    // 0 = no minimum
+	MaxBetCount     int              `json:"maxBetCount,omitempty"`    // 0 = no maximum
+	AnalyzedOnly    bool             `json:"analyzedOnly,omitempty"`   // Only wallets with a bet count
+	UnanalyzedOnly  bool             `json:"unanalyzedOnly,omitempty"` // Only wallets still queued for analysis
+	Tag             string           `json:"tag,omitempty"`
+	JoinedAfter     time.Time        `json:"joinedAfter,omitempty"` // Month granularity, inclusive
+	MinVolume       float64          `json:"minVolume,omitempty"`   // USD traded in trades seen by the monitor, 0 = no minimum
+
+	SortBy   WalletSortField `json:"sortBy,omitempty"`
+	SortDesc bool            `json:"sortDesc,omitempty"`
+	Limit    int             `json:"limit,omitempty"`
+	Cursor   string          `json:"cursor,omitempty"` // NextCursor of the previous page
+}
+
+// WalletPage is one page of a wallet query
+type WalletPage struct {
+	Wallets    []WalletProfile `json:"wallets"`
+	NextCursor string          `json:"nextCursor,omitempty"` // Empty on the last page
+}
+
+// WalletMatchField identifies which part of a wallet matched a search
+type WalletMatchField string
+
+const (
+	WalletMatchAddress WalletMatchField = "address"
+	WalletMatchName    WalletMatchField = "name"
+	WalletMatchTag     WalletMatchField = "tag"
+)
+
+// WalletSearchResult is a wallet matched by a search query, best matches first
+type WalletSearchResult struct {
+	Wallet     WalletProfile    `json:"wallet"`
+	TraderName string           `json:"traderName,omitempty"` // Last name or pseudonym seen on a trade
+	Tags       []string         `json:"tags,omitempty"`
+	MatchedOn  WalletMatchField `json:"matchedOn"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// WashTradeTag is the event tag added to trades by wallets flagged for wash trading
+const WashTradeTag = "wash-trade"
+
+// WashPair is a wallet, or two wallets, seen making offsetting trades in the same
+// market within seconds of each other: a buy matched by a sell of the same outcome,
+// or equal buys of both outcomes
+type WashPair struct {
+	Wallet      string    `json:"wallet"`
+	PairedWith  string    `json:"pairedWith,omitempty"` // Empty when the wallet trades against itself
+	Matches     int       `json:"matches"`              // Offsetting trade pairs seen
+	Volume      float64   `json:"volume"`               // Notional of the matched trades
+	Markets     []string  `json:"markets"`              // Markets the matches were seen in, most recent last
+	FirstSeenAt time.Time `json:"firstSeenAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+	Flagged     bool      `json:"flagged"` // Matched often enough to tag the wallets
+}
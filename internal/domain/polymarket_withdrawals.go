@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// WithdrawalWatch is a flagged wallet that won a market, monitored on-chain for USDC
+// leaving it until the watch window ends
+type WithdrawalWatch struct {
+	Wallet     string    `json:"wallet"`
+	MarketSlug string    `json:"marketSlug"`
+	ResolvedAt time.Time `json:"resolvedAt"`
+	Deadline   time.Time `json:"deadline"`  // ResolvedAt plus the watch window
+	FromBlock  uint64    `json:"fromBlock"` // First block at or before ResolvedAt
+	ScannedTo  uint64    `json:"scannedTo"` // Last block checked, 0 before the first scan
+}
+
+// WalletWithdrawal is a USDC transfer out of a wallet to an address that isn't a
+// Polymarket contract
+type WalletWithdrawal struct {
+	Wallet     string    `json:"wallet"`
+	To         string    `json:"to"`
+	Token      string    `json:"token"` // "USDC.e" or "USDC"
+	AmountUSD  float64   `json:"amountUsd"`
+	TxHash     string    `json:"txHash"`
+	Block      uint64    `json:"block"`
+	At         time.Time `json:"at"`
+	MarketSlug string    `json:"marketSlug,omitempty"` // Won market the withdrawal followed
+	HoursAfter float64   `json:"hoursAfter,omitempty"` // Hours between the resolution and the withdrawal
+}
+
+// WalletLifecycle is a wallet's dossier of the insider pattern: joined, bet, won,
+// then withdrew shortly after. Zero times mean the stage wasn't seen.
+type WalletLifecycle struct {
+	Wallet      string             `json:"wallet"`
+	JoinDate    string             `json:"joinDate,omitempty"` // Polymarket join month, usually the first deposit
+	Funding     *FundingOrigin     `json:"funding,omitempty"`  // Where the first deposit came from, when it was searched
+	FirstBetAt  time.Time          `json:"firstBetAt"`         // Earliest resolved bet
+	WonAt       time.Time          `json:"wonAt"`              // Most recent winning resolution
+	WonMarket   string             `json:"wonMarket,omitempty"`
+	WithdrewAt  time.Time          `json:"withdrewAt"`
+	Withdrawals []WalletWithdrawal `json:"withdrawals"` // Newest first
+	Watching    bool               `json:"watching"`    // A withdrawal watch is still open
+	Complete    bool               `json:"complete"`    // Every stage was seen, in order
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// RemoteBackendConfig points the desktop app at an xtools daemon (cmd/xtoolsd). When
+// enabled, the daemon does the ingestion and the app only shows its data.
+type RemoteBackendConfig struct {
+	Enabled bool   `json:"enabled"`
+	URL     string `json:"url"`             // e.g. "https://vps.example.com:8787"
+	Token   string `json:"token,omitempty"` // The daemon's XTOOLS_API_TOKEN
+}
+
+// RemoteBackendStatus reports whether the app runs in remote mode and its stream state
+type RemoteBackendStatus struct {
+	Active          bool      `json:"active"` // Remote mode was enabled at startup
+	URL             string    `json:"url,omitempty"`
+	StreamConnected bool      `json:"streamConnected"` // Live events are being received
+	ConnectedAt     time.Time `json:"connectedAt,omitempty"`
+	LastError       string    `json:"lastError,omitempty"`
+}
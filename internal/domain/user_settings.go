@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// DefaultUserID is the user of requests without their own identity, e.g. the desktop
+// app or a daemon client using the main API token
+const DefaultUserID = "default"
+
+// UserSettings are one user's view preferences on a deployment shared by several
+// people. They only change what that user sees; the watcher, detectors and alerts
+// keep using the deployment-wide settings.
+type UserSettings struct {
+	User         string                `json:"user"`
+	Filter       PolymarketEventFilter `json:"filter"`       // Defaults for the user's event queries (thresholds, types, tag)
+	MutedMarkets []MarketMute          `json:"mutedMarkets"` // Hidden from the user's event lists
+	Watchlist    []string              `json:"watchlist"`    // Wallet addresses the user follows; the default user's are watched for alerts
+	UpdatedAt    time.Time             `json:"updatedAt,omitempty"`
+}
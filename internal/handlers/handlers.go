@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"time"
 
-	"xtools/internal/adapters/twitter"
-	"xtools/internal/adapters/updater"
-	"xtools/internal/domain"
-	"xtools/internal/ports"
-	"xtools/internal/services"
-	"xtools/internal/workers"
+	"github.com/luthebao/poly-xtools/internal/adapters/twitter"
+	"github.com/luthebao/poly-xtools/internal/adapters/updater"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/services"
+	"github.com/luthebao/poly-xtools/internal/workers"
 )
 
 // NotificationServiceInterface defines methods needed from NotificationService
@@ -18,6 +18,71 @@ type NotificationServiceInterface interface {
 	GetConfig() domain.NotificationConfig
 	UpdateConfig(config domain.NotificationConfig) error
 	SendTestNotification(ctx context.Context) error
+	SetMaintenanceMode(enabled bool, reason string) domain.MaintenanceStatus
+	GetMaintenanceStatus() domain.MaintenanceStatus
+	GetPendingTelegramChats() []domain.TelegramChatRegistration
+	ApproveTelegramChat(botName, chatID string) error
+	RejectTelegramChat(botName, chatID string) error
+	GetAlertAcks(unackedOnly bool) domain.AlertAckStatus
+	UnackedAlertCount() int
+	AckAlert(id, by string) (*domain.SentAlert, error)
+	UnackAlert(id string) (*domain.SentAlert, error)
+	GetNotificationStats(days int) (*domain.NotificationStats, error)
+	CleanupNotified() (*domain.NotifiedCleanupResult, error)
+	GetUndeliveredNotifications() ([]domain.NotificationDelivery, error)
+}
+
+// PolymarketRemote defines the methods served by a remote xtools daemon in remote-backend mode
+type PolymarketRemote interface {
+	StartWatcher() error
+	StopWatcher() error
+	Status() (domain.PolymarketWatcherStatus, error)
+	Events(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error)
+	EventCount(filter domain.PolymarketEventFilter) (int64, error)
+	EventPage(filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error)
+	SearchEvents(query string, limit int) ([]domain.PolymarketEvent, error)
+	MarketAggregates(filter domain.PolymarketEventFilter, bucket domain.AggregateBucket) ([]domain.MarketAggregate, error)
+	Wallets(limit int) ([]domain.WalletProfile, error)
+	SearchWallets(query string, limit int) ([]domain.WalletSearchResult, error)
+	EntityFlow(since, until time.Time, limit int) (*domain.EntityFlowReport, error)
+	MarketSnapshots(filter domain.MarketSnapshotFilter) ([]domain.MarketSnapshot, error)
+	SystemStatus() (*domain.SystemStatus, error)
+	ErrorStats() (*domain.ErrorStats, error)
+	DatabaseInfo() (*domain.DatabaseInfo, error)
+
+	// Watcher settings
+	Config() (domain.PolymarketConfig, error)
+	SetConfig(config domain.PolymarketConfig) error
+	SaveFilter() (domain.PolymarketEventFilter, error)
+	SetSaveFilter(filter domain.PolymarketEventFilter) error
+	MutedMarkets() ([]domain.MarketMute, error)
+	MuteMarket(slug string, minutes int) (*domain.MarketMute, error)
+	UnmuteMarket(slug string) error
+	TagRules() ([]domain.EventTagRule, error)
+	SetTagRules(rules []domain.EventTagRule) error
+	LateEntryRules() ([]domain.LateEntryRule, error)
+	SetLateEntryRules(rules []domain.LateEntryRule) error
+	EntityAlertRules() ([]domain.EntityAlertRule, error)
+	SetEntityAlertRules(rules []domain.EntityAlertRule) error
+	SamplingRules() ([]domain.EventSamplingRule, error)
+	SetSamplingRules(rules []domain.EventSamplingRule) error
+	EventRetention() (domain.EventRetention, error)
+	SetEventRetention(retention domain.EventRetention) error
+	AutoTune() (domain.AutoTuneSettings, error)
+	SetAutoTune(settings domain.AutoTuneSettings) error
+	WatchedWallets() ([]string, error)
+	WatchWallet(address string) error
+	UnwatchWallet(address string) error
+	WalletBlacklist() ([]domain.BlacklistedWallet, error)
+	BlacklistWallet(address, reason string) error
+	UnblacklistWallet(address string) error
+	WalletTags(addresses []string) (map[string][]string, error)
+	TagWallet(address, tag string) error
+	UntagWallet(address, tag string) error
+	FilterPresets() ([]domain.FilterPreset, error)
+	SaveFilterPreset(name string, filter domain.PolymarketEventFilter) (*domain.FilterPreset, error)
+	RenameFilterPreset(name, newName string) (*domain.FilterPreset, error)
+	DeleteFilterPreset(name string) error
 }
 
 // Handlers provides all Wails-bound handler methods
@@ -26,6 +91,7 @@ type Handlers struct {
 	searchSvc       *services.SearchService
 	replySvc        *services.ReplyService
 	polymarketSvc   *services.PolymarketService
+	remote          PolymarketRemote // Set in remote-backend mode; takes over from polymarketSvc
 	notificationSvc NotificationServiceInterface
 	workerPool      *workers.WorkerPool
 	configStore     ports.ConfigStore
@@ -63,6 +129,12 @@ func NewHandlers(
 	}
 }
 
+// SetPolymarketRemote serves the watcher, events, wallets, status and watcher settings
+// from a remote daemon. Other Polymarket features are not available in remote mode.
+func (h *Handlers) SetPolymarketRemote(remote PolymarketRemote) {
+	h.remote = remote
+}
+
 // === Account Handlers ===
 
 // GetAccounts returns all account configurations
@@ -336,117 +408,118 @@ func (h *Handlers) GetAppVersion() string {
 	return h.updater.GetCurrentVersion()
 }
 
-// === Polymarket Handlers ===
+// === Notification Handlers ===
 
-// StartPolymarketWatcher starts the Polymarket WebSocket watcher
-func (h *Handlers) StartPolymarketWatcher() error {
-	if h.polymarketSvc == nil {
-		return fmt.Errorf("polymarket service not initialized")
+// GetNotificationConfig returns the current notification configuration
+func (h *Handlers) GetNotificationConfig() domain.NotificationConfig {
+	if h.notificationSvc == nil {
+		return domain.DefaultNotificationConfig()
 	}
-	return h.polymarketSvc.Start()
+	return h.notificationSvc.GetConfig()
 }
 
-// StopPolymarketWatcher stops the Polymarket WebSocket watcher
-func (h *Handlers) StopPolymarketWatcher() {
-	if h.polymarketSvc != nil {
-		h.polymarketSvc.Stop()
+// SetNotificationConfig updates the notification configuration
+func (h *Handlers) SetNotificationConfig(config domain.NotificationConfig) error {
+	if h.notificationSvc == nil {
+		return fmt.Errorf("notification service not initialized")
 	}
+	return h.notificationSvc.UpdateConfig(config)
 }
 
-// GetPolymarketWatcherStatus returns the current watcher status
-func (h *Handlers) GetPolymarketWatcherStatus() domain.PolymarketWatcherStatus {
-	if h.polymarketSvc == nil {
-		return domain.PolymarketWatcherStatus{}
+// SendTestNotification sends a test notification
+func (h *Handlers) SendTestNotification() error {
+	if h.notificationSvc == nil {
+		return fmt.Errorf("notification service not initialized")
 	}
-	return h.polymarketSvc.GetStatus()
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	return h.notificationSvc.SendTestNotification(ctx)
 }
 
-// GetPolymarketEvents returns Polymarket events with optional filtering
-func (h *Handlers) GetPolymarketEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
-	if h.polymarketSvc == nil {
-		return nil, fmt.Errorf("polymarket service not initialized")
+// SetNotificationMaintenanceMode pauses or resumes all outbound notifications
+func (h *Handlers) SetNotificationMaintenanceMode(enabled bool, reason string) (domain.MaintenanceStatus, error) {
+	if h.notificationSvc == nil {
+		return domain.MaintenanceStatus{}, fmt.Errorf("notification service not initialized")
 	}
-	return h.polymarketSvc.GetEvents(filter)
+	return h.notificationSvc.SetMaintenanceMode(enabled, reason), nil
 }
 
-// ClearPolymarketEvents removes all stored Polymarket events
-func (h *Handlers) ClearPolymarketEvents() error {
-	if h.polymarketSvc == nil {
-		return fmt.Errorf("polymarket service not initialized")
+// GetNotificationMaintenanceStatus returns the kill-switch state
+func (h *Handlers) GetNotificationMaintenanceStatus() domain.MaintenanceStatus {
+	if h.notificationSvc == nil {
+		return domain.MaintenanceStatus{}
 	}
-	return h.polymarketSvc.ClearEvents()
+	return h.notificationSvc.GetMaintenanceStatus()
 }
 
-// GetDatabaseInfo returns database statistics
-func (h *Handlers) GetDatabaseInfo() (*domain.DatabaseInfo, error) {
-	if h.polymarketSvc == nil {
-		return nil, fmt.Errorf("polymarket service not initialized")
+// GetPendingTelegramChats returns chats that sent /start to the bot and await approval
+func (h *Handlers) GetPendingTelegramChats() []domain.TelegramChatRegistration {
+	if h.notificationSvc == nil {
+		return []domain.TelegramChatRegistration{}
 	}
-	return h.polymarketSvc.GetDatabaseInfo()
+	return h.notificationSvc.GetPendingTelegramChats()
 }
 
-// SetPolymarketSaveFilter sets the filter for saving events to database
-func (h *Handlers) SetPolymarketSaveFilter(filter domain.PolymarketEventFilter) {
-	if h.polymarketSvc != nil {
-		h.polymarketSvc.SetSaveFilter(filter)
+// ApproveTelegramChat starts sending a bot's notifications to a registered chat
+func (h *Handlers) ApproveTelegramChat(botName, chatID string) error {
+	if h.notificationSvc == nil {
+		return fmt.Errorf("notification service not initialized")
 	}
+	return h.notificationSvc.ApproveTelegramChat(botName, chatID)
 }
 
-// GetPolymarketSaveFilter returns the current save filter
-func (h *Handlers) GetPolymarketSaveFilter() domain.PolymarketEventFilter {
-	if h.polymarketSvc == nil {
-		return domain.PolymarketEventFilter{}
+// RejectTelegramChat discards a pending chat registration
+func (h *Handlers) RejectTelegramChat(botName, chatID string) error {
+	if h.notificationSvc == nil {
+		return fmt.Errorf("notification service not initialized")
 	}
-	return h.polymarketSvc.GetSaveFilter()
+	return h.notificationSvc.RejectTelegramChat(botName, chatID)
 }
 
-// GetPolymarketConfig returns the current Polymarket configuration
-func (h *Handlers) GetPolymarketConfig() domain.PolymarketConfig {
-	if h.polymarketSvc == nil {
-		return domain.DefaultPolymarketConfig()
+// GetAlertAcks returns the delivered alerts tracked for acknowledgment
+func (h *Handlers) GetAlertAcks(unackedOnly bool) domain.AlertAckStatus {
+	if h.notificationSvc == nil {
+		return domain.AlertAckStatus{Alerts: []domain.SentAlert{}}
 	}
-	return h.polymarketSvc.GetConfig()
+	return h.notificationSvc.GetAlertAcks(unackedOnly)
 }
 
-// SetPolymarketConfig updates the Polymarket configuration
-func (h *Handlers) SetPolymarketConfig(config domain.PolymarketConfig) {
-	if h.polymarketSvc != nil {
-		h.polymarketSvc.UpdateConfig(config)
+// AckAlert acknowledges an alert from the app, stopping its re-pings
+func (h *Handlers) AckAlert(id string) (*domain.SentAlert, error) {
+	if h.notificationSvc == nil {
+		return nil, fmt.Errorf("notification service not initialized")
 	}
+	return h.notificationSvc.AckAlert(id, "app")
 }
 
-// GetPolymarketWallets returns all wallets from the database
-func (h *Handlers) GetPolymarketWallets(limit int) ([]domain.WalletProfile, error) {
-	if h.polymarketSvc == nil {
-		return nil, fmt.Errorf("polymarket service not initialized")
+// UnackAlert reopens an acknowledged alert
+func (h *Handlers) UnackAlert(id string) (*domain.SentAlert, error) {
+	if h.notificationSvc == nil {
+		return nil, fmt.Errorf("notification service not initialized")
 	}
-	return h.polymarketSvc.GetWallets(limit)
+	return h.notificationSvc.UnackAlert(id)
 }
 
-// === Notification Handlers ===
-
-// GetNotificationConfig returns the current notification configuration
-func (h *Handlers) GetNotificationConfig() domain.NotificationConfig {
+// GetNotificationStats counts the items notified over the last days, by type and day
+func (h *Handlers) GetNotificationStats(days int) (*domain.NotificationStats, error) {
 	if h.notificationSvc == nil {
-		return domain.DefaultNotificationConfig()
+		return nil, fmt.Errorf("notification service not initialized")
 	}
-	return h.notificationSvc.GetConfig()
+	return h.notificationSvc.GetNotificationStats(days)
 }
 
-// SetNotificationConfig updates the notification configuration
-func (h *Handlers) SetNotificationConfig(config domain.NotificationConfig) error {
+// CleanupNotified deletes the notified item records older than the configured retention
+func (h *Handlers) CleanupNotified() (*domain.NotifiedCleanupResult, error) {
 	if h.notificationSvc == nil {
-		return fmt.Errorf("notification service not initialized")
+		return nil, fmt.Errorf("notification service not initialized")
 	}
-	return h.notificationSvc.UpdateConfig(config)
+	return h.notificationSvc.CleanupNotified()
 }
 
-// SendTestNotification sends a test notification
-func (h *Handlers) SendTestNotification() error {
+// GetUndeliveredNotifications returns the channel deliveries that have not succeeded yet
+func (h *Handlers) GetUndeliveredNotifications() ([]domain.NotificationDelivery, error) {
 	if h.notificationSvc == nil {
-		return fmt.Errorf("notification service not initialized")
+		return nil, fmt.Errorf("notification service not initialized")
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
-	return h.notificationSvc.SendTestNotification(ctx)
+	return h.notificationSvc.GetUndeliveredNotifications()
 }
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// StartPolymarketWatcher starts the Polymarket WebSocket watcher
+func (h *Handlers) StartPolymarketWatcher() error {
+	if h.remote != nil {
+		return h.remote.StartWatcher()
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.Start()
+}
+
+// StopPolymarketWatcher stops the Polymarket WebSocket watcher
+func (h *Handlers) StopPolymarketWatcher() {
+	if h.remote != nil {
+		if err := h.remote.StopWatcher(); err != nil {
+			log.Printf("[Handlers] Failed to stop remote watcher: %v", err)
+		}
+		return
+	}
+	if h.polymarketSvc != nil {
+		h.polymarketSvc.Stop()
+	}
+}
+
+// GetPolymarketWatcherStatus returns the current watcher status
+func (h *Handlers) GetPolymarketWatcherStatus() domain.PolymarketWatcherStatus {
+	if h.remote != nil {
+		status, err := h.remote.Status()
+		if err != nil {
+			status.ErrorMessage = err.Error()
+		}
+		return status
+	}
+	if h.polymarketSvc == nil {
+		return domain.PolymarketWatcherStatus{}
+	}
+	status := h.polymarketSvc.GetStatus()
+	if h.notificationSvc != nil {
+		status.UnackedAlerts = h.notificationSvc.UnackedAlertCount()
+	}
+	return status
+}
+
+// GetPolymarketEvents returns Polymarket events with optional filtering
+func (h *Handlers) GetPolymarketEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	if h.remote != nil {
+		return h.remote.Events(filter)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetEvents(filter)
+}
+
+// GetPolymarketEventCount returns how many events match a filter, ignoring its limit and offset
+func (h *Handlers) GetPolymarketEventCount(filter domain.PolymarketEventFilter) (int64, error) {
+	if h.remote != nil {
+		return h.remote.EventCount(filter)
+	}
+	if h.polymarketSvc == nil {
+		return 0, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetEventCount(filter)
+}
+
+// GetPolymarketEventPage returns a page of events with the number, notional and fresh
+// wallet count of all events matching the filter
+func (h *Handlers) GetPolymarketEventPage(filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error) {
+	if h.remote != nil {
+		return h.remote.EventPage(filter)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetEventPage(filter)
+}
+
+// SearchPolymarketEvents finds events by words of their market name or event title
+func (h *Handlers) SearchPolymarketEvents(query string, limit int) ([]domain.PolymarketEvent, error) {
+	if h.remote != nil {
+		return h.remote.SearchEvents(query, limit)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SearchEvents(query, limit)
+}
+
+// GetPolymarketMarketAggregates totals stored trades per market and hour or day
+func (h *Handlers) GetPolymarketMarketAggregates(filter domain.PolymarketEventFilter, bucket domain.AggregateBucket) ([]domain.MarketAggregate, error) {
+	if h.remote != nil {
+		return h.remote.MarketAggregates(filter, bucket)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetMarketAggregates(filter, bucket)
+}
+
+// ClearPolymarketEvents removes all stored Polymarket events
+func (h *Handlers) ClearPolymarketEvents() error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ClearEvents()
+}
+
+// GetDatabaseInfo returns database statistics
+func (h *Handlers) GetDatabaseInfo() (*domain.DatabaseInfo, error) {
+	if h.remote != nil {
+		return h.remote.DatabaseInfo()
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetDatabaseInfo()
+}
+
+// SetPolymarketSaveFilter sets the filter for saving events to database
+func (h *Handlers) SetPolymarketSaveFilter(filter domain.PolymarketEventFilter) {
+	if h.remote != nil {
+		if err := h.remote.SetSaveFilter(filter); err != nil {
+			log.Printf("[Handlers] Failed to update remote save filter: %v", err)
+		}
+		return
+	}
+	if h.polymarketSvc != nil {
+		h.polymarketSvc.SetSaveFilter(filter)
+	}
+}
+
+// GetPolymarketSaveFilter returns the current save filter
+func (h *Handlers) GetPolymarketSaveFilter() domain.PolymarketEventFilter {
+	if h.remote != nil {
+		return remoteSetting(h.remote.SaveFilter, domain.PolymarketEventFilter{})
+	}
+	if h.polymarketSvc == nil {
+		return domain.PolymarketEventFilter{}
+	}
+	return h.polymarketSvc.GetSaveFilter()
+}
+
+// GetPolymarketConfig returns the current Polymarket configuration
+func (h *Handlers) GetPolymarketConfig() domain.PolymarketConfig {
+	if h.remote != nil {
+		return remoteSetting(h.remote.Config, domain.DefaultPolymarketConfig())
+	}
+	if h.polymarketSvc == nil {
+		return domain.DefaultPolymarketConfig()
+	}
+	return h.polymarketSvc.GetConfig()
+}
+
+// SetPolymarketConfig updates the Polymarket configuration
+func (h *Handlers) SetPolymarketConfig(config domain.PolymarketConfig) {
+	if h.remote != nil {
+		if err := h.remote.SetConfig(config); err != nil {
+			log.Printf("[Handlers] Failed to update remote config: %v", err)
+		}
+		return
+	}
+	if h.polymarketSvc != nil {
+		h.polymarketSvc.UpdateConfig(config)
+	}
+}
+
+// GetPolymarketWallets returns all wallets from the database
+func (h *Handlers) GetPolymarketWallets(limit int) ([]domain.WalletProfile, error) {
+	if h.remote != nil {
+		return h.remote.Wallets(limit)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWallets(limit)
+}
+
+// GetPolymarketFilterPresets returns the saved filter presets by name
+func (h *Handlers) GetPolymarketFilterPresets() ([]domain.FilterPreset, error) {
+	if h.remote != nil {
+		return h.remote.FilterPresets()
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetFilterPresets()
+}
+
+// SavePolymarketFilterPreset creates or replaces a named filter preset
+func (h *Handlers) SavePolymarketFilterPreset(name string, filter domain.PolymarketEventFilter) (*domain.FilterPreset, error) {
+	if h.remote != nil {
+		return h.remote.SaveFilterPreset(name, filter)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SaveFilterPreset(name, filter)
+}
+
+// RenamePolymarketFilterPreset renames a filter preset
+func (h *Handlers) RenamePolymarketFilterPreset(name, newName string) (*domain.FilterPreset, error) {
+	if h.remote != nil {
+		return h.remote.RenameFilterPreset(name, newName)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.RenameFilterPreset(name, newName)
+}
+
+// DeletePolymarketFilterPreset deletes a filter preset
+func (h *Handlers) DeletePolymarketFilterPreset(name string) error {
+	if h.remote != nil {
+		return h.remote.DeleteFilterPreset(name)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.DeleteFilterPreset(name)
+}
+
+// ApplyPolymarketFilterPreset returns the events matching a preset's filter. In remote
+// mode presets are kept on the daemon, which also runs the query.
+func (h *Handlers) ApplyPolymarketFilterPreset(name string, limit, offset int) ([]domain.PolymarketEvent, error) {
+	if h.remote != nil {
+		presets, err := h.remote.FilterPresets()
+		if err != nil {
+			return nil, err
+		}
+		for _, preset := range presets {
+			if strings.EqualFold(preset.Name, strings.TrimSpace(name)) {
+				return h.remote.Events(preset.PageFilter(limit, offset))
+			}
+		}
+		return nil, fmt.Errorf("no filter preset named %q", strings.TrimSpace(name))
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ApplyFilterPreset(name, limit, offset)
+}
+
+// polymarketUnavailable explains why a Polymarket feature cannot be served: in remote
+// mode only the daemon's events, wallets, status and settings are
+func (h *Handlers) polymarketUnavailable() error {
+	if h.remote != nil {
+		return fmt.Errorf("not available in remote mode: the daemon does not serve it")
+	}
+	return fmt.Errorf("polymarket service not initialized")
+}
+
+// remoteSetting reads a daemon setting for a binding without an error result, logging
+// a failure and returning fallback
+func remoteSetting[T any](read func() (T, error), fallback T) T {
+	value, err := read()
+	if err != nil {
+		log.Printf("[Handlers] Failed to read remote setting: %v", err)
+		return fallback
+	}
+	return value
+}
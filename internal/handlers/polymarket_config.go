@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// TagPolymarketEvent attaches a label to a stored event
+func (h *Handlers) TagPolymarketEvent(eventID int64, tag string) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.TagEvent(eventID, tag)
+}
+
+// UntagPolymarketEvent removes a label from a stored event
+func (h *Handlers) UntagPolymarketEvent(eventID int64, tag string) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.UntagEvent(eventID, tag)
+}
+
+// GetPolymarketEventTags returns every event tag in use with its event count
+func (h *Handlers) GetPolymarketEventTags() ([]domain.EventTagCount, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetEventTags()
+}
+
+// SetPolymarketTagRules replaces the rules that auto-tag incoming events
+func (h *Handlers) SetPolymarketTagRules(rules []domain.EventTagRule) error {
+	if h.remote != nil {
+		return h.remote.SetTagRules(rules)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SetTagRules(rules)
+}
+
+// GetPolymarketTagRules returns the rules that auto-tag incoming events
+func (h *Handlers) GetPolymarketTagRules() []domain.EventTagRule {
+	if h.remote != nil {
+		return remoteSetting(h.remote.TagRules, []domain.EventTagRule{})
+	}
+	if h.polymarketSvc == nil {
+		return []domain.EventTagRule{}
+	}
+	return h.polymarketSvc.GetTagRules()
+}
+
+// ExportPolymarketAlertRules writes the alert rules and bot routes to a YAML file
+func (h *Handlers) ExportPolymarketAlertRules() (*domain.AlertRuleExport, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ExportAlertRules(h.notificationSvc.GetConfig())
+}
+
+// PreviewPolymarketAlertRules lists the changes importing an alert rule file would make
+func (h *Handlers) PreviewPolymarketAlertRules(path string) (*domain.AlertRuleImport, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	_, changes, err := h.polymarketSvc.PreviewAlertRules(path, h.notificationSvc.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AlertRuleImport{Path: path, Changes: changes}, nil
+}
+
+// ImportPolymarketAlertRules applies an alert rule file, its routes to the notification config
+func (h *Handlers) ImportPolymarketAlertRules(path string) (*domain.AlertRuleImport, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	notifications := h.notificationSvc.GetConfig()
+	set, changes, err := h.polymarketSvc.PreviewAlertRules(path, notifications)
+	if err != nil {
+		return nil, err
+	}
+	if set.Routes != nil {
+		if err := domain.ApplyRoutes(&notifications, set.Routes); err != nil {
+			return nil, err
+		}
+		if err := h.notificationSvc.UpdateConfig(notifications); err != nil {
+			return nil, fmt.Errorf("failed to save routes: %w", err)
+		}
+	}
+	if err := h.polymarketSvc.ApplyAlertRules(*set); err != nil {
+		return nil, err
+	}
+	return &domain.AlertRuleImport{Path: path, Changes: changes, Applied: true}, nil
+}
+
+// GetPolymarketConfigSnapshot returns the config version an alert was raised under
+func (h *Handlers) GetPolymarketConfigSnapshot(hash string) (*domain.ConfigSnapshot, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetConfigSnapshot(hash)
+}
+
+// GetPolymarketConfigSnapshots returns the config versions alerts were raised under
+func (h *Handlers) GetPolymarketConfigSnapshots(limit int) ([]domain.ConfigSnapshot, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetConfigSnapshots(limit)
+}
+
+// DiffPolymarketConfigSnapshot lists thresholds changed since a config version
+func (h *Handlers) DiffPolymarketConfigSnapshot(hash string) ([]domain.ConfigChange, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.DiffConfigSnapshot(hash)
+}
+
+// GetPolymarketSettingHistory returns the previous values of a settings key, newest first
+func (h *Handlers) GetPolymarketSettingHistory(key string, limit int) ([]domain.SettingHistoryEntry, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetSettingHistory(key, limit)
+}
+
+// RollbackPolymarketSetting writes a previous settings value back. The notification
+// service keeps its config in memory, so a rolled back notification config is applied to it.
+func (h *Handlers) RollbackPolymarketSetting(id int64) (*domain.SettingHistoryEntry, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	entry, err := h.polymarketSvc.RollbackSetting(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Key == domain.NotificationConfigSettingKey && h.notificationSvc != nil {
+		var config domain.NotificationConfig
+		if err := json.Unmarshal([]byte(entry.Value), &config); err != nil {
+			return nil, fmt.Errorf("failed to read notification config: %w", err)
+		}
+		if err := h.notificationSvc.UpdateConfig(config); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+// SetPolymarketAutoTune saves the threshold auto-tune settings
+func (h *Handlers) SetPolymarketAutoTune(settings domain.AutoTuneSettings) error {
+	if h.remote != nil {
+		return h.remote.SetAutoTune(settings)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SetAutoTune(settings)
+}
+
+// GetPolymarketAutoTune returns the threshold auto-tune settings
+func (h *Handlers) GetPolymarketAutoTune() domain.AutoTuneSettings {
+	if h.remote != nil {
+		return remoteSetting(h.remote.AutoTune, domain.DefaultAutoTuneSettings())
+	}
+	if h.polymarketSvc == nil {
+		return domain.DefaultAutoTuneSettings()
+	}
+	return h.polymarketSvc.GetAutoTune()
+}
+
+// RunPolymarketAutoTune re-tunes thresholds now
+func (h *Handlers) RunPolymarketAutoTune() (*domain.AutoTuneResult, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.RunAutoTune()
+}
+
+// GetPolymarketLastAutoTune returns the most recent auto-tune result
+func (h *Handlers) GetPolymarketLastAutoTune() *domain.AutoTuneResult {
+	if h.polymarketSvc == nil {
+		return nil
+	}
+	return h.polymarketSvc.GetLastAutoTune()
+}
+
+// GetPolymarketConfigAuditTrail returns recorded threshold changes, newest first
+func (h *Handlers) GetPolymarketConfigAuditTrail(limit int) []domain.ConfigChange {
+	if h.polymarketSvc == nil {
+		return []domain.ConfigChange{}
+	}
+	return h.polymarketSvc.GetConfigAuditTrail(limit)
+}
+
+// SetPolymarketEventSamplingRules replaces the per event type sampling rules
+func (h *Handlers) SetPolymarketEventSamplingRules(rules []domain.EventSamplingRule) error {
+	if h.remote != nil {
+		return h.remote.SetSamplingRules(rules)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SetEventSamplingRules(rules)
+}
+
+// GetPolymarketEventSamplingRules returns the per event type sampling rules
+func (h *Handlers) GetPolymarketEventSamplingRules() []domain.EventSamplingRule {
+	if h.remote != nil {
+		return remoteSetting(h.remote.SamplingRules, []domain.EventSamplingRule{})
+	}
+	if h.polymarketSvc == nil {
+		return []domain.EventSamplingRule{}
+	}
+	return h.polymarketSvc.GetEventSamplingRules()
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetPolymarketSystemStatus returns process memory, goroutine, cache and write queue statistics
+func (h *Handlers) GetPolymarketSystemStatus() (*domain.SystemStatus, error) {
+	if h.remote != nil {
+		return h.remote.SystemStatus()
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	status := h.polymarketSvc.GetSystemStatus()
+	return &status, nil
+}
+
+// GetErrorStats returns error counts by kind and the most recent errors
+func (h *Handlers) GetErrorStats() (*domain.ErrorStats, error) {
+	if h.remote != nil {
+		return h.remote.ErrorStats()
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	stats := h.polymarketSvc.GetErrorStats()
+	return &stats, nil
+}
+
+// WritePolymarketProfile writes a pprof profile to disk
+func (h *Handlers) WritePolymarketProfile(name string, seconds int) (*domain.ProfileExport, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.WriteProfile(name, seconds)
+}
+
+// OptimizePolymarketDatabase checkpoints, analyzes and vacuums the database now
+func (h *Handlers) OptimizePolymarketDatabase() (*domain.DatabaseOptimizeResult, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.OptimizeDatabase()
+}
+
+// CompressPolymarketRawData compresses raw event payloads stored uncompressed
+func (h *Handlers) CompressPolymarketRawData() (*domain.RawDataCompression, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.CompressRawData()
+}
+
+// CheckPolymarketDatabaseIntegrity runs an integrity check now
+func (h *Handlers) CheckPolymarketDatabaseIntegrity() (string, error) {
+	if h.polymarketSvc == nil {
+		return "", h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.CheckDatabaseIntegrity()
+}
+
+// BackupPolymarketDatabase snapshots the database while the watcher runs
+func (h *Handlers) BackupPolymarketDatabase(path string) (*domain.DatabaseBackup, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.BackupDatabase(path)
+}
+
+// RestorePolymarketDatabase replaces the stored data with a backup
+func (h *Handlers) RestorePolymarketDatabase(path string) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.RestoreDatabase(path)
+}
+
+// GetPolymarketBackups lists the backups in the backup directory
+func (h *Handlers) GetPolymarketBackups() ([]domain.DatabaseBackup, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetBackups()
+}
+
+// SetPolymarketEventRetention saves the event retention policy
+func (h *Handlers) SetPolymarketEventRetention(retention domain.EventRetention) error {
+	if h.remote != nil {
+		return h.remote.SetEventRetention(retention)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SetEventRetention(retention)
+}
+
+// GetPolymarketEventRetention returns the event retention policy
+func (h *Handlers) GetPolymarketEventRetention() domain.EventRetention {
+	if h.remote != nil {
+		return remoteSetting(h.remote.EventRetention, domain.DefaultEventRetention())
+	}
+	if h.polymarketSvc == nil {
+		return domain.DefaultEventRetention()
+	}
+	return h.polymarketSvc.GetEventRetention()
+}
+
+// PrunePolymarketEvents deletes events beyond the retention policy now
+func (h *Handlers) PrunePolymarketEvents() (*domain.EventPruneResult, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.PruneEvents()
+}
+
+// ArchivePolymarketEvents moves events older than before to the archive table now
+func (h *Handlers) ArchivePolymarketEvents(before time.Time) (*domain.EventPruneResult, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ArchiveEvents(before)
+}
+
+// GetPolymarketArchivedEvents returns archived events with optional filtering
+func (h *Handlers) GetPolymarketArchivedEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetArchivedEvents(filter)
+}
+
+// GetPolymarketLastEventPrune returns the most recent pruning result
+func (h *Handlers) GetPolymarketLastEventPrune() *domain.EventPruneResult {
+	if h.polymarketSvc == nil {
+		return nil
+	}
+	return h.polymarketSvc.GetLastEventPrune()
+}
+
+// SetPolymarketSheetsSink configures appending flagged events to a Google Sheet
+func (h *Handlers) SetPolymarketSheetsSink(config domain.SheetsSinkConfig) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SetSheetsSink(config)
+}
+
+// GetPolymarketSheetsSink returns the Google Sheets sink configuration
+func (h *Handlers) GetPolymarketSheetsSink() (*domain.SheetsSinkConfig, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	config := h.polymarketSvc.GetSheetsSink()
+	return &config, nil
+}
+
+// GetPolymarketSheetsSinkStatus returns the Google Sheets sink's progress
+func (h *Handlers) GetPolymarketSheetsSinkStatus() (*domain.SheetsSinkStatus, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	status := h.polymarketSvc.GetSheetsSinkStatus()
+	return &status, nil
+}
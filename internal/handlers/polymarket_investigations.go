@@ -0,0 +1,173 @@
+package handlers
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// CreatePolymarketInvestigation opens a new named investigation
+func (h *Handlers) CreatePolymarketInvestigation(name, description string) (*domain.Investigation, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.CreateInvestigation(name, description)
+}
+
+// UpdatePolymarketInvestigation changes the name, description or status of an investigation
+func (h *Handlers) UpdatePolymarketInvestigation(inv domain.Investigation) (*domain.Investigation, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.UpdateInvestigation(inv)
+}
+
+// GetPolymarketInvestigation returns an investigation with its items and notes
+func (h *Handlers) GetPolymarketInvestigation(id int64) (*domain.Investigation, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetInvestigation(id)
+}
+
+// ListPolymarketInvestigations returns investigations, optionally filtered by status
+func (h *Handlers) ListPolymarketInvestigations(status domain.InvestigationStatus) ([]domain.Investigation, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ListInvestigations(status)
+}
+
+// DeletePolymarketInvestigation removes an investigation
+func (h *Handlers) DeletePolymarketInvestigation(id int64) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.DeleteInvestigation(id)
+}
+
+// AddPolymarketInvestigationItem attaches a wallet, market or event to an investigation
+func (h *Handlers) AddPolymarketInvestigationItem(id int64, itemType domain.InvestigationItemType, ref string) (*domain.Investigation, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.AddInvestigationItem(id, itemType, ref)
+}
+
+// RemovePolymarketInvestigationItem detaches a wallet, market or event from an investigation
+func (h *Handlers) RemovePolymarketInvestigationItem(id int64, itemType domain.InvestigationItemType, ref string) (*domain.Investigation, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.RemoveInvestigationItem(id, itemType, ref)
+}
+
+// AddPolymarketInvestigationNote appends a note to an investigation
+func (h *Handlers) AddPolymarketInvestigationNote(id int64, body string) (*domain.Investigation, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.AddInvestigationNote(id, body)
+}
+
+// DeletePolymarketInvestigationNote removes a note from an investigation
+func (h *Handlers) DeletePolymarketInvestigationNote(id, noteID int64) (*domain.Investigation, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.DeleteInvestigationNote(id, noteID)
+}
+
+// GeneratePolymarketReport writes a shareable report for a wallet or investigation
+func (h *Handlers) GeneratePolymarketReport(req domain.ReportRequest) (*domain.ReportFile, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GenerateReport(req)
+}
+
+// GetPolymarketUpcomingResolutions returns end dates of markets with flagged activity or open investigations
+func (h *Handlers) GetPolymarketUpcomingResolutions() ([]domain.MarketResolution, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetUpcomingResolutions()
+}
+
+// ExportPolymarketResolutionCalendar writes upcoming market resolutions to an iCal file
+func (h *Handlers) ExportPolymarketResolutionCalendar() (*domain.CalendarExport, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ExportResolutionCalendar()
+}
+
+// SavePolymarketEventExport creates or replaces a CSV export definition
+func (h *Handlers) SavePolymarketEventExport(def domain.EventExportDefinition) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SaveEventExport(def)
+}
+
+// DeletePolymarketEventExport removes a CSV export definition
+func (h *Handlers) DeletePolymarketEventExport(name string) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.DeleteEventExport(name)
+}
+
+// GetPolymarketEventExports returns the saved CSV export definitions
+func (h *Handlers) GetPolymarketEventExports() []domain.EventExportDefinition {
+	if h.polymarketSvc == nil {
+		return []domain.EventExportDefinition{}
+	}
+	return h.polymarketSvc.GetEventExports()
+}
+
+// ExportPolymarketEvents runs a saved CSV export
+func (h *Handlers) ExportPolymarketEvents(name string) (*domain.EventExport, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.RunEventExport(name)
+}
+
+// ExportPolymarketEventsFile writes the events matching a filter to a CSV or JSONL file
+func (h *Handlers) ExportPolymarketEventsFile(filter domain.PolymarketEventFilter, format domain.EventExportFormat) (*domain.EventExport, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ExportEventsFile(filter, format)
+}
+
+// SetPolymarketCaseSync configures syncing alerts and watched wallets to Notion or Airtable
+func (h *Handlers) SetPolymarketCaseSync(config domain.CaseSyncConfig) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SetCaseSync(config)
+}
+
+// GetPolymarketCaseSync returns the case sync configuration
+func (h *Handlers) GetPolymarketCaseSync() (*domain.CaseSyncConfig, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	config := h.polymarketSvc.GetCaseSync()
+	return &config, nil
+}
+
+// GetPolymarketCaseSyncStatus returns the progress of syncing to Notion or Airtable
+func (h *Handlers) GetPolymarketCaseSyncStatus() (*domain.CaseSyncStatus, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	status := h.polymarketSvc.GetCaseSyncStatus()
+	return &status, nil
+}
+
+// SyncPolymarketCasesNow syncs every watched wallet and pending alert immediately
+func (h *Handlers) SyncPolymarketCasesNow() (*domain.CaseSyncStatus, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SyncCasesNow()
+}
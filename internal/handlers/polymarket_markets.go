@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// MutePolymarketMarket silences notifications for a market for the given number of minutes
+func (h *Handlers) MutePolymarketMarket(slug string, minutes int) (*domain.MarketMute, error) {
+	if h.remote != nil {
+		return h.remote.MuteMarket(slug, minutes)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.MuteMarket(slug, time.Duration(minutes)*time.Minute)
+}
+
+// UnmutePolymarketMarket removes a market mute
+func (h *Handlers) UnmutePolymarketMarket(slug string) error {
+	if h.remote != nil {
+		return h.remote.UnmuteMarket(slug)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.UnmuteMarket(slug)
+}
+
+// GetPolymarketMutedMarkets returns active market mutes
+func (h *Handlers) GetPolymarketMutedMarkets() []domain.MarketMute {
+	if h.remote != nil {
+		return remoteSetting(h.remote.MutedMarkets, []domain.MarketMute{})
+	}
+	if h.polymarketSvc == nil {
+		return []domain.MarketMute{}
+	}
+	return h.polymarketSvc.GetMutedMarkets()
+}
+
+// GetPolymarketMarketGroups returns events with the most recent fresh-wallet flow across their markets
+func (h *Handlers) GetPolymarketMarketGroups(limit int) ([]domain.MarketGroup, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetMarketGroups(limit), nil
+}
+
+// GetPolymarketMarketGroup returns the recent trade flow of one event's markets
+func (h *Handlers) GetPolymarketMarketGroup(eventSlug string) (*domain.MarketGroup, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetMarketGroup(eventSlug)
+}
+
+// GetPolymarketPriceInconsistencies returns events whose outcome prices stayed outside the band
+func (h *Handlers) GetPolymarketPriceInconsistencies() ([]domain.PriceConsistency, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetPriceInconsistencies(), nil
+}
+
+// CheckPolymarketPriceConsistency re-prices the most traded events now
+func (h *Handlers) CheckPolymarketPriceConsistency() ([]domain.PriceConsistency, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.CheckPriceConsistency(), nil
+}
+
+// ResolvePolymarketMarkets checks traded markets for resolutions right away
+func (h *Handlers) ResolvePolymarketMarkets() (int, error) {
+	if h.polymarketSvc == nil {
+		return 0, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ResolveMarkets()
+}
+
+// SetPolymarketLateEntryRules replaces the rules that flag large trades close to a market's end
+func (h *Handlers) SetPolymarketLateEntryRules(rules []domain.LateEntryRule) error {
+	if h.remote != nil {
+		return h.remote.SetLateEntryRules(rules)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SetLateEntryRules(rules)
+}
+
+// GetPolymarketLateEntryRules returns the rules that flag large trades close to a market's end
+func (h *Handlers) GetPolymarketLateEntryRules() []domain.LateEntryRule {
+	if h.remote != nil {
+		return remoteSetting(h.remote.LateEntryRules, []domain.LateEntryRule{})
+	}
+	if h.polymarketSvc == nil {
+		return []domain.LateEntryRule{}
+	}
+	return h.polymarketSvc.GetLateEntryRules()
+}
+
+// GetPolymarketMarketEntities returns the entities a market's title mentions
+func (h *Handlers) GetPolymarketMarketEntities(slug string) ([]domain.MarketEntity, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetMarketEntities(slug)
+}
+
+// GetPolymarketEntityMarkets returns the markets whose titles mention an entity
+func (h *Handlers) GetPolymarketEntityMarkets(entity string, limit int) ([]domain.EntityMarket, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetEntityMarkets(entity, limit)
+}
+
+// GetPolymarketEntityCounts returns the most mentioned entities of the stored markets
+func (h *Handlers) GetPolymarketEntityCounts(limit int) ([]domain.MarketEntityCount, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetEntityCounts(limit)
+}
+
+// TagPolymarketStoredMarkets finds the entities of every market with stored events
+func (h *Handlers) TagPolymarketStoredMarkets() (*domain.MarketTaggingResult, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.TagStoredMarkets()
+}
+
+// GetPolymarketEntityFlow sums the trade flow into the markets mentioning each entity
+func (h *Handlers) GetPolymarketEntityFlow(since, until time.Time, limit int) (*domain.EntityFlowReport, error) {
+	if h.remote != nil {
+		return h.remote.EntityFlow(since, until, limit)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetEntityFlow(since, until, limit)
+}
+
+// GetPolymarketMarketSnapshots returns stored market snapshots, newest first
+func (h *Handlers) GetPolymarketMarketSnapshots(filter domain.MarketSnapshotFilter) ([]domain.MarketSnapshot, error) {
+	if h.remote != nil {
+		return h.remote.MarketSnapshots(filter)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetMarketSnapshots(filter)
+}
+
+// CapturePolymarketMarketSnapshots snapshots every watched market now
+func (h *Handlers) CapturePolymarketMarketSnapshots() (*domain.MarketSnapshotCapture, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.CaptureMarketSnapshots()
+}
+
+// SetPolymarketEntityAlertRules replaces the rules that alert on trades in markets mentioning an entity
+func (h *Handlers) SetPolymarketEntityAlertRules(rules []domain.EntityAlertRule) error {
+	if h.remote != nil {
+		return h.remote.SetEntityAlertRules(rules)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SetEntityAlertRules(rules)
+}
+
+// GetPolymarketEntityAlertRules returns the rules that alert on trades in markets mentioning an entity
+func (h *Handlers) GetPolymarketEntityAlertRules() []domain.EntityAlertRule {
+	if h.remote != nil {
+		return remoteSetting(h.remote.EntityAlertRules, []domain.EntityAlertRule{})
+	}
+	if h.polymarketSvc == nil {
+		return []domain.EntityAlertRule{}
+	}
+	return h.polymarketSvc.GetEntityAlertRules()
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// GetPolymarketWashPairs returns wallets seen trading against themselves or each other
+func (h *Handlers) GetPolymarketWashPairs(flaggedOnly bool) ([]domain.WashPair, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWashPairs(flaggedOnly), nil
+}
+
+// ClearPolymarketWashTrader unflags a wallet flagged for wash trading
+func (h *Handlers) ClearPolymarketWashTrader(address string) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ClearWashTrader(address)
+}
+
+// GetPolymarketSpoofingSignals returns the books recently flagged for spoofing
+func (h *Handlers) GetPolymarketSpoofingSignals() ([]domain.SpoofingSignal, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetSpoofingSignals(), nil
+}
+
+// GetPolymarketQuotes returns the latest best bid and ask of the given assets (none = all)
+func (h *Handlers) GetPolymarketQuotes(assetIDs []string) ([]domain.MarketQuote, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetQuotes(assetIDs)
+}
+
+// GetPolymarketSpreadSignals returns the books recently flagged for a widened spread
+func (h *Handlers) GetPolymarketSpreadSignals() ([]domain.SpreadSignal, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetSpreadSignals(), nil
+}
+
+// GetPolymarketWithdrawalWatches returns the winning flagged wallets monitored for withdrawals
+func (h *Handlers) GetPolymarketWithdrawalWatches() ([]domain.WithdrawalWatch, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWithdrawalWatches(), nil
+}
+
+// CheckPolymarketWithdrawals scans the monitored wallets on-chain now
+func (h *Handlers) CheckPolymarketWithdrawals() ([]domain.WalletWithdrawal, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.CheckWithdrawals()
+}
+
+// GetPolymarketAlertOutcomes returns recent alerts with their follow-up prices
+func (h *Handlers) GetPolymarketAlertOutcomes(limit int) ([]domain.AlertOutcome, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetAlertOutcomes(limit)
+}
+
+// GetPolymarketSignalQuality aggregates alert hit rates per follow-up horizon
+func (h *Handlers) GetPolymarketSignalQuality(days int) (*domain.SignalQuality, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetSignalQuality(days)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// ExportPolymarketWalletIntel writes a signed wallet intel bundle
+func (h *Handlers) ExportPolymarketWalletIntel(req domain.WalletIntelExportRequest) (*domain.WalletIntelExport, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ExportWalletIntel(req)
+}
+
+// ImportPolymarketWalletIntel imports a signed wallet intel bundle
+func (h *Handlers) ImportPolymarketWalletIntel(path string) (*domain.WalletIntelImportResult, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.ImportWalletIntel(path)
+}
+
+// GetPolymarketWalletIntel returns the intel other users shared about a wallet
+func (h *Handlers) GetPolymarketWalletIntel(address string) ([]domain.ImportedWalletIntel, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWalletIntel(address)
+}
+
+// TrustPolymarketIntelPublisher pins a wallet intel publisher's public key
+func (h *Handlers) TrustPolymarketIntelPublisher(publicKey, name string) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.TrustIntelPublisher(publicKey, name)
+}
+
+// UntrustPolymarketIntelPublisher unpins a wallet intel publisher's public key
+func (h *Handlers) UntrustPolymarketIntelPublisher(publicKey string) error {
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.UntrustIntelPublisher(publicKey)
+}
+
+// GetPolymarketTrustedIntelPublishers returns the pinned wallet intel publishers
+func (h *Handlers) GetPolymarketTrustedIntelPublishers() ([]domain.TrustedIntelPublisher, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetTrustedIntelPublishers()
+}
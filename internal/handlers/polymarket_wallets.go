@@ -0,0 +1,211 @@
+package handlers
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// RefreshPolymarketWallets forces re-analysis of the selected wallets
+func (h *Handlers) RefreshPolymarketWallets(addresses []string, priority domain.WalletRefreshPriority) (int, error) {
+	if h.polymarketSvc == nil {
+		return 0, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.RefreshWallets(addresses, priority)
+}
+
+// RecomputePolymarketFreshness re-evaluates stored wallets against the current thresholds
+func (h *Handlers) RecomputePolymarketFreshness() (*domain.FreshnessRecomputeResult, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.RecomputeFreshness()
+}
+
+// AnalyzePolymarketThresholds runs a historical what-if analysis over candidate thresholds
+func (h *Handlers) AnalyzePolymarketThresholds(opts domain.ThresholdAnalysisOptions) (*domain.ThresholdAnalysis, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.AnalyzeThresholds(opts)
+}
+
+// GetPolymarketWalletStats returns the distribution of seen wallets
+func (h *Handlers) GetPolymarketWalletStats() (*domain.WalletStats, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWalletStats()
+}
+
+// QueryPolymarketWallets returns a filtered, sorted page of wallets
+func (h *Handlers) QueryPolymarketWallets(filter domain.WalletFilter) (*domain.WalletPage, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.QueryWallets(filter)
+}
+
+// SearchPolymarketWallets finds wallets by partial address, trader name or tag
+func (h *Handlers) SearchPolymarketWallets(query string, limit int) ([]domain.WalletSearchResult, error) {
+	if h.remote != nil {
+		return h.remote.SearchWallets(query, limit)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.SearchWallets(query, limit)
+}
+
+// GetPolymarketWalletStreak returns a wallet's run of correct bets in resolved markets
+func (h *Handlers) GetPolymarketWalletStreak(address string) (*domain.WalletStreak, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWalletStreak(address)
+}
+
+// GetPolymarketWalletLifecycle returns a wallet's join, bet, win and withdrawal history
+func (h *Handlers) GetPolymarketWalletLifecycle(address string) (*domain.WalletLifecycle, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWalletLifecycle(address)
+}
+
+// GetPolymarketWalletFunding returns where a wallet's first USDC deposit came from
+func (h *Handlers) GetPolymarketWalletFunding(address string) (*domain.FundingOrigin, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWalletFunding(address)
+}
+
+// GetPolymarketWalletActivitySinceAlert returns a wallet's trades since its last alert
+func (h *Handlers) GetPolymarketWalletActivitySinceAlert(address string) (*domain.WalletActivityDiff, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWalletActivitySinceAlert(address)
+}
+
+// GetPolymarketWalletSizeProfile returns a wallet's typical trade size and the market norm
+func (h *Handlers) GetPolymarketWalletSizeProfile(address string) (*domain.WalletSizeProfile, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	profile := h.polymarketSvc.GetWalletSizeProfile(address)
+	return &profile, nil
+}
+
+// GetPolymarketWalletCategories returns the market categories a wallet traded
+func (h *Handlers) GetPolymarketWalletCategories(address string) ([]domain.WalletCategoryStats, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWalletCategories(address)
+}
+
+// GetPolymarketWalletActiveHours returns the hour-of-day profile of a wallet's trades
+func (h *Handlers) GetPolymarketWalletActiveHours(address string) (*domain.WalletActiveHours, error) {
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	profile := h.polymarketSvc.GetWalletActiveHours(address)
+	return &profile, nil
+}
+
+// TagPolymarketWallet attaches a label to a wallet
+func (h *Handlers) TagPolymarketWallet(address, tag string) error {
+	if h.remote != nil {
+		return h.remote.TagWallet(address, tag)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.TagWallet(address, tag)
+}
+
+// UntagPolymarketWallet removes a label from a wallet
+func (h *Handlers) UntagPolymarketWallet(address, tag string) error {
+	if h.remote != nil {
+		return h.remote.UntagWallet(address, tag)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.UntagWallet(address, tag)
+}
+
+// GetPolymarketWalletTags returns the tags of the given wallets, or of every tagged wallet
+func (h *Handlers) GetPolymarketWalletTags(addresses []string) (map[string][]string, error) {
+	if h.remote != nil {
+		return h.remote.WalletTags(addresses)
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWalletTags(addresses)
+}
+
+// WatchPolymarketWallet adds a wallet to the watchlist
+func (h *Handlers) WatchPolymarketWallet(address string) error {
+	if h.remote != nil {
+		return h.remote.WatchWallet(address)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.WatchWallet(address)
+}
+
+// UnwatchPolymarketWallet removes a wallet from the watchlist
+func (h *Handlers) UnwatchPolymarketWallet(address string) error {
+	if h.remote != nil {
+		return h.remote.UnwatchWallet(address)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.UnwatchWallet(address)
+}
+
+// GetPolymarketWatchedWallets returns the wallets on the watchlist
+func (h *Handlers) GetPolymarketWatchedWallets() ([]string, error) {
+	if h.remote != nil {
+		return h.remote.WatchedWallets()
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWatchedWallets(), nil
+}
+
+// BlacklistPolymarketWallet leaves a wallet out of analysis and alerts
+func (h *Handlers) BlacklistPolymarketWallet(address, reason string) error {
+	if h.remote != nil {
+		return h.remote.BlacklistWallet(address, reason)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.BlacklistWallet(address, reason)
+}
+
+// UnblacklistPolymarketWallet removes a wallet from the blacklist
+func (h *Handlers) UnblacklistPolymarketWallet(address string) error {
+	if h.remote != nil {
+		return h.remote.UnblacklistWallet(address)
+	}
+	if h.polymarketSvc == nil {
+		return h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.UnblacklistWallet(address)
+}
+
+// GetPolymarketWalletBlacklist returns the blacklisted wallets
+func (h *Handlers) GetPolymarketWalletBlacklist() ([]domain.BlacklistedWallet, error) {
+	if h.remote != nil {
+		return h.remote.WalletBlacklist()
+	}
+	if h.polymarketSvc == nil {
+		return nil, h.polymarketUnavailable()
+	}
+	return h.polymarketSvc.GetWalletBlacklist(), nil
+}
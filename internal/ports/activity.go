@@ -1,6 +1,6 @@
 package ports
 
-import "xtools/internal/domain"
+import "github.com/luthebao/poly-xtools/internal/domain"
 
 // ActivityLogger logs and retrieves activity entries
 type ActivityLogger interface {
@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// CaseTracker creates or updates records in an external case tracking tool
+type CaseTracker interface {
+	// Upsert writes records (property name → value) to a database or table, updating
+	// the record whose keyProperty matches and creating it otherwise
+	Upsert(ctx context.Context, database, keyProperty string, records []map[string]any) error
+}
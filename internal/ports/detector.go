@@ -0,0 +1,12 @@
+package ports
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// Detector inspects enriched Polymarket events and reports custom signals
+type Detector interface {
+	// Name returns the detector name used in signals and logs
+	Name() string
+
+	// Detect returns the signals raised for an event (nil if none)
+	Detect(event domain.PolymarketEvent) ([]domain.DetectorSignal, error)
+}
@@ -2,7 +2,7 @@ package ports
 
 import (
 	"context"
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 // LLMProvider generates replies using AI
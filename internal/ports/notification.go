@@ -2,8 +2,9 @@ package ports
 
 import (
 	"context"
+	"time"
 
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 // NotificationSender defines the interface for sending notifications
@@ -34,4 +35,45 @@ type NotificationStore interface {
 
 	// MarkNotified marks an item as notified
 	MarkNotified(itemType, itemID string) error
+
+	// GetNotificationStats counts the items notified since a time, by type and UTC day
+	GetNotificationStats(since time.Time) (*domain.NotificationStats, error)
+
+	// DeleteNotifiedBefore deletes the records of items notified before a time, with
+	// their channel delivery states
+	DeleteNotifiedBefore(before time.Time) (int64, error)
+
+	// SaveNotificationDelivery inserts or replaces the delivery state of an item on a channel
+	SaveNotificationDelivery(delivery domain.NotificationDelivery) error
+
+	// GetNotificationDeliveries returns the delivery state of an item on each channel
+	GetNotificationDeliveries(itemType, itemID string) ([]domain.NotificationDelivery, error)
+
+	// GetUndeliveredNotifications returns the deliveries not succeeded yet, next attempt first
+	GetUndeliveredNotifications(limit int) ([]domain.NotificationDelivery, error)
+
+	// GetDueNotificationDeliveries returns the undelivered deliveries with fewer than
+	// maxAttempts attempts whose next attempt is due, next attempt first
+	GetDueNotificationDeliveries(maxAttempts int, now time.Time, limit int) ([]domain.NotificationDelivery, error)
+
+	// SaveSentAlert inserts or replaces a tracked alert and its acknowledgment state
+	SaveSentAlert(alert domain.SentAlert) error
+
+	// GetSentAlerts returns the newest tracked alerts, up to limit, oldest first
+	GetSentAlerts(limit int) ([]domain.SentAlert, error)
+
+	// DeleteSentAlert stops tracking an alert
+	DeleteSentAlert(id string) error
+}
+
+// NotificationTransformer rewrites or suppresses notifications before delivery
+type NotificationTransformer interface {
+	// Transform returns the notification to deliver, or false to drop it
+	Transform(content domain.NotificationContent) (domain.NotificationContent, bool, error)
+}
+
+// WalletBlacklist tells which wallets raise no notifications
+type WalletBlacklist interface {
+	// IsWalletBlacklisted reports whether a wallet's trades and signals are left unnotified
+	IsWalletBlacklisted(address string) bool
 }
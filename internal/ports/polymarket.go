@@ -0,0 +1,134 @@
+package ports
+
+import (
+	"io"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// PolymarketStore persists Polymarket events, wallets and settings
+type PolymarketStore interface {
+	// Events
+	SaveEvent(event domain.PolymarketEvent) error
+	SaveEvents(events []domain.PolymarketEvent) error // In one transaction
+	GetEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error)
+	GetEventCount(filter domain.PolymarketEventFilter) (int64, error)
+	GetEventPage(filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error)
+	ExportEvents(filter domain.PolymarketEventFilter, format domain.EventExportFormat, w io.Writer) (int, error) // Every match when the filter has no limit
+	GetEventsByIDs(ids []int64) ([]domain.PolymarketEvent, error)
+	SearchEvents(query string, limit int) ([]domain.PolymarketEvent, error) // Full-text over market names and event titles
+	GetTradeSamples(since time.Time) ([]domain.TradeSample, error)
+	GetMarketAggregates(filter domain.PolymarketEventFilter, bucket domain.AggregateBucket) ([]domain.MarketAggregate, error)
+	ClearEvents() error
+	PruneEvents(before time.Time, keepNewest int64, keepTagged bool) (int64, error)
+	ArchiveEvents(before time.Time) (int64, error)
+	GetArchivedEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error)
+	GetDatabaseInfo() (*domain.DatabaseInfo, error)
+	GetTableStats() ([]domain.DatabaseTableStats, error) // Scans the whole database, for the UI only
+	TagEvent(eventID int64, tag string) error
+	UntagEvent(eventID int64, tag string) error
+	GetEventTags() ([]domain.EventTagCount, error)
+
+	// Market resolutions
+	GetMarketsToResolve(limit int, checkedBefore time.Time) ([]string, error)
+	SaveMarketResolution(outcome domain.MarketOutcome) error
+	GetMarketWallets(slug string) ([]string, error)
+	GetResolvedBets(address string) ([]domain.ResolvedBet, error)
+
+	// Settings
+	SaveConfig(config domain.PolymarketConfig) error
+	LoadConfig() (domain.PolymarketConfig, error)
+	SaveFilter(filter domain.PolymarketEventFilter) error
+	LoadFilter() (domain.PolymarketEventFilter, error)
+	SaveSetting(key string, value any) error
+	LoadSetting(key string, dest any) error
+	GetSettingHistory(key string, limit int) ([]domain.SettingHistoryEntry, error) // Empty key = every key, newest first
+	RestoreSetting(id int64) (*domain.SettingHistoryEntry, error)                  // Writes a previous value back
+
+	// Wallets
+	SaveWallet(profile domain.WalletProfile) error
+	GetWallet(address string) (*domain.WalletProfile, error)
+	GetAllWallets(limit int) ([]domain.WalletProfile, error)
+	QueryWallets(filter domain.WalletFilter) (*domain.WalletPage, error)
+	SearchWallets(query string, limit int) ([]domain.WalletSearchResult, error)
+	SetWalletTraderName(address, name string) error
+	SaveWalletAddress(address string) (bool, error)
+	UpdateWalletTradeStats(address string, tradeVolume float64) error
+	GetWalletsForRefresh(limit int, exclude []string) ([]string, error)
+	GetWalletStats() (*domain.WalletStats, error)
+	RecomputeFreshness(classify domain.FreshnessClassifier) (*domain.FreshnessRecomputeResult, error)
+
+	// Wallet tags and intel imported from other users
+	TagWallet(address, tag string) error
+	UntagWallet(address, tag string) error
+	GetWalletTags(addresses []string) (map[string][]string, error) // Empty = every tagged wallet
+	SaveImportedWalletIntel(intel []domain.ImportedWalletIntel) error
+	GetImportedWalletIntel(address string) ([]domain.ImportedWalletIntel, error)
+
+	// Market categories each wallet traded
+	RecordWalletCategory(address, category string, notional float64, at time.Time) error
+	GetWalletCategories(address string) ([]domain.WalletCategoryStats, error) // Most traded first
+
+	// Alert follow-up prices
+	SaveAlertOutcome(outcome domain.AlertOutcome) (int64, error)
+	RecordAlertPrice(id int64, horizon domain.AlertHorizon, price float64) error
+	GetPendingAlertOutcomes(since time.Time, limit int) ([]domain.AlertOutcome, error)
+	GetAlertOutcomes(since time.Time, limit int) ([]domain.AlertOutcome, error)
+	GetLastWalletAlert(wallet string) (*domain.AlertOutcome, error)
+	GetWalletActivitySince(wallet string, since time.Time) (*domain.WalletActivityDiff, error)
+
+	// Entities found in market titles
+	SaveMarketEntities(slug, marketName string, entities []domain.MarketEntity) error // Replaces the market's entities
+	GetMarketEntities(slug string) ([]domain.MarketEntity, error)
+	GetEntityMarkets(name string, limit int) ([]domain.EntityMarket, error) // Most recently tagged first
+	GetEntityCounts(limit int) ([]domain.MarketEntityCount, error)          // Most mentioned first
+	GetStoredMarketTitles() ([]domain.MarketTitle, error)
+	GetEntityTradeFlows(since, until time.Time) ([]domain.EntityTradeFlow, error) // Per entity, market and wallet
+
+	// Latest best bid and ask per outcome token
+	SaveQuotes(quotes []domain.MarketQuote) error
+	GetQuotes(assetIDs []string) ([]domain.MarketQuote, error) // Empty = all
+
+	// Scheduled snapshots of watched markets
+	SaveMarketSnapshots(snapshots []domain.MarketSnapshot) error
+	GetMarketSnapshots(filter domain.MarketSnapshotFilter) ([]domain.MarketSnapshot, error) // Newest first
+	PruneMarketSnapshots(before time.Time) (int64, error)
+
+	// Config versions that raised alerts
+	SaveConfigSnapshot(snapshot domain.ConfigSnapshot) error
+	GetConfigSnapshot(hash string) (*domain.ConfigSnapshot, error)
+	GetConfigSnapshots(limit int) ([]domain.ConfigSnapshot, error)
+
+	// Notification config and deduplication
+	NotificationStore
+
+	// Investigation cases
+	InvestigationStore
+
+	// Database maintenance
+	CheckpointWAL() error
+	WALSize() int64                                         // Bytes in the -wal files
+	SetPragmas(busyTimeoutMs int, synchronous string) error // Applied to every connection
+	CheckIntegrity() (string, error)
+	Optimize() error
+	CompressRawData() (*domain.RawDataCompression, error)
+	Backup(path string) error  // Online, while writes continue
+	Restore(path string) error // Replaces the stored data with a backup's
+
+	// Cleanup
+	Close() error
+}
+
+// InvestigationStore persists investigation cases with their items and notes
+type InvestigationStore interface {
+	CreateInvestigation(inv domain.Investigation) (*domain.Investigation, error)
+	UpdateInvestigation(inv domain.Investigation) error
+	GetInvestigation(id int64) (*domain.Investigation, error)
+	ListInvestigations(status domain.InvestigationStatus) ([]domain.Investigation, error)
+	DeleteInvestigation(id int64) error
+	AddInvestigationItem(id int64, item domain.InvestigationItem) error
+	RemoveInvestigationItem(id int64, itemType domain.InvestigationItemType, ref string) error
+	AddInvestigationNote(id int64, body string) (*domain.InvestigationNote, error)
+	DeleteInvestigationNote(id, noteID int64) error
+}
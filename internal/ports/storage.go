@@ -2,7 +2,7 @@ package ports
 
 import (
 	"context"
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 // ConfigStore manages account configurations (YAML files)
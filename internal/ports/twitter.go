@@ -2,7 +2,7 @@ package ports
 
 import (
 	"context"
-	"xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/domain"
 )
 
 // TwitterClient abstracts Twitter interaction (API or Browser)
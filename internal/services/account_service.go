@@ -8,8 +8,8 @@ import (
 
 	"github.com/google/uuid"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // AccountService manages Twitter accounts
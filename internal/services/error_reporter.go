@@ -0,0 +1,92 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+// maxRecentErrors bounds the errors kept for GetErrorStats
+const maxRecentErrors = 50
+
+// ErrorReporter counts errors by kind and emits them on the "errors" event topic for
+// the UI and operator alerting. A nil reporter ignores reports.
+type ErrorReporter struct {
+	mu       sync.Mutex
+	eventBus ports.EventBus
+	counts   map[domain.ErrorKind]int64
+	recent   []domain.ErrorEvent // Oldest first
+}
+
+// NewErrorReporter creates an error reporter emitting on the event bus
+func NewErrorReporter(eventBus ports.EventBus) *ErrorReporter {
+	return &ErrorReporter{
+		eventBus: eventBus,
+		counts:   make(map[domain.ErrorKind]int64),
+	}
+}
+
+// Report records an error hit by source
+func (r *ErrorReporter) Report(source string, err error) {
+	if r == nil || err == nil {
+		return
+	}
+	event := domain.ErrorEvent{
+		Kind:      domain.ErrorKindOf(err),
+		Source:    source,
+		Message:   err.Error(),
+		Timestamp: time.Now(),
+	}
+
+	r.mu.Lock()
+	r.counts[event.Kind]++
+	r.recent = append(r.recent, event)
+	if len(r.recent) > maxRecentErrors {
+		r.recent = r.recent[len(r.recent)-maxRecentErrors:]
+	}
+	r.mu.Unlock()
+
+	if r.eventBus != nil {
+		r.eventBus.Emit("errors", event)
+	}
+}
+
+// Counts returns how many errors of each kind were reported
+func (r *ErrorReporter) Counts() map[domain.ErrorKind]int64 {
+	counts := make(map[domain.ErrorKind]int64)
+	if r == nil {
+		return counts
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for kind, n := range r.counts {
+		counts[kind] = n
+	}
+	return counts
+}
+
+// Stats returns the error counts and the most recent errors, newest first
+func (r *ErrorReporter) Stats() domain.ErrorStats {
+	stats := domain.ErrorStats{Counts: r.Counts(), Recent: []domain.ErrorEvent{}}
+	if r == nil {
+		return stats
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.recent) - 1; i >= 0; i-- {
+		stats.Recent = append(stats.Recent, r.recent[i])
+	}
+	return stats
+}
+
+// ErrorReporter returns the reporter other services share to report their errors
+func (s *PolymarketService) ErrorReporter() *ErrorReporter {
+	return s.errReporter
+}
+
+// GetErrorStats returns error counts by kind and the most recent errors
+func (s *PolymarketService) GetErrorStats() domain.ErrorStats {
+	return s.errReporter.Stats()
+}
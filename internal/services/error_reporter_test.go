@@ -0,0 +1,57 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestErrorReporterCountsByKind(t *testing.T) {
+	bus := localbus.New()
+	var emitted []domain.ErrorEvent
+	bus.Listen(func(name string, data interface{}) {
+		if name == "errors" {
+			emitted = append(emitted, data.(domain.ErrorEvent))
+		}
+	})
+	r := NewErrorReporter(bus)
+
+	rateLimited := &domain.UpstreamRateLimited{Service: "gamma"}
+	r.Report("feed", &domain.FeedError{Op: "read", Err: errors.New("EOF")})
+	r.Report("event writer", fmt.Errorf("batch: %w", &domain.StoreError{Op: "save event", Err: errors.New("disk full")}))
+	r.Report("telegram", &domain.NotifyError{Channel: "telegram:default", Err: rateLimited})
+	r.Report("refresh", errors.New("boom"))
+	r.Report("refresh", nil)
+
+	counts := r.Counts()
+	want := map[domain.ErrorKind]int64{
+		domain.ErrorKindFeed: 1, domain.ErrorKindStore: 1, domain.ErrorKindRateLimited: 1, domain.ErrorKindOther: 1,
+	}
+	if len(counts) != len(want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+	for kind, n := range want {
+		if counts[kind] != n {
+			t.Errorf("counts[%s] = %d, want %d", kind, counts[kind], n)
+		}
+	}
+	if !errors.Is(rateLimited, domain.ErrRateLimited) {
+		t.Error("UpstreamRateLimited does not match ErrRateLimited")
+	}
+
+	if len(emitted) != 4 || emitted[1].Source != "event writer" || emitted[1].Message != "batch: save event: disk full" {
+		t.Errorf("emitted %+v, want the 4 errors on the errors topic", emitted)
+	}
+	if stats := r.Stats(); len(stats.Recent) != 4 || stats.Recent[0].Source != "refresh" {
+		t.Errorf("recent = %+v, want newest first", stats.Recent)
+	}
+
+	var none *ErrorReporter
+	none.Report("feed", errors.New("ignored"))
+	if stats := none.Stats(); len(stats.Counts) != 0 || len(stats.Recent) != 0 {
+		t.Errorf("nil reporter stats = %+v, want empty", stats)
+	}
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// maxTrackedAlerts caps how many delivered alerts are kept for acknowledgment.
+	// Acknowledged alerts are dropped first, oldest first.
+	maxTrackedAlerts = 200
+
+	// maxAlertRepings is how many reminders an unacknowledged alert gets
+	maxAlertRepings = 3
+
+	// repingCheckInterval is how often unacknowledged alerts are checked for re-pings
+	repingCheckInterval = time.Minute
+)
+
+// GetAlertAcks returns the tracked alerts, newest first, optionally only the unacknowledged ones
+func (s *NotificationService) GetAlertAcks(unackedOnly bool) domain.AlertAckStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status := domain.AlertAckStatus{Enabled: s.config.AlertAcks, Alerts: []domain.SentAlert{}}
+	for i := len(s.alerts) - 1; i >= 0; i-- {
+		alert := s.alerts[i]
+		if !alert.Acked() {
+			status.Unacked++
+		} else if unackedOnly {
+			continue
+		}
+		status.Alerts = append(status.Alerts, alert)
+	}
+	return status
+}
+
+// UnackedAlertCount returns how many tracked alerts nobody acknowledged yet
+func (s *NotificationService) UnackedAlertCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	unacked := 0
+	for _, alert := range s.alerts {
+		if !alert.Acked() {
+			unacked++
+		}
+	}
+	return unacked
+}
+
+// AckAlert acknowledges an alert on behalf of by, stopping its re-pings
+func (s *NotificationService) AckAlert(id, by string) (*domain.SentAlert, error) {
+	s.mu.Lock()
+	alert := s.findAlertLocked(id)
+	if alert == nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("no tracked alert %q", id)
+	}
+	if !alert.Acked() {
+		alert.AckedAt = time.Now()
+		alert.AckedBy = by
+		log.Printf("[NotificationService] Alert %s acknowledged by %s", id, by)
+	}
+	acked := *alert
+	s.mu.Unlock()
+
+	s.saveAlert(acked)
+	s.eventBus.Emit("notification:alert_ack", acked)
+	return &acked, nil
+}
+
+// UnackAlert reopens an acknowledged alert; re-pings start over from now
+func (s *NotificationService) UnackAlert(id string) (*domain.SentAlert, error) {
+	s.mu.Lock()
+	alert := s.findAlertLocked(id)
+	if alert == nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("no tracked alert %q", id)
+	}
+	alert.AckedAt = time.Time{}
+	alert.AckedBy = ""
+	alert.Repings = 0
+	alert.LastPingAt = time.Now()
+	reopened := *alert
+	s.mu.Unlock()
+
+	s.saveAlert(reopened)
+	s.eventBus.Emit("notification:alert_ack", reopened)
+	return &reopened, nil
+}
+
+// onTelegramAck acknowledges an alert from its Telegram button and returns the answer
+func (s *NotificationService) onTelegramAck(id, user string) string {
+	s.mu.RLock()
+	alert := s.findAlertLocked(id)
+	var ackedBy string
+	if alert != nil {
+		ackedBy = alert.AckedBy
+	}
+	s.mu.RUnlock()
+
+	switch {
+	case alert == nil:
+		return "This alert is no longer tracked."
+	case ackedBy != "":
+		return "Already acknowledged by " + ackedBy + "."
+	}
+	if _, err := s.AckAlert(id, "telegram:"+user); err != nil {
+		return "This alert is no longer tracked."
+	}
+	return "✅ Acknowledged"
+}
+
+// trackAlert starts tracking an alert about to be delivered and returns it with the ID
+// its ack button refers to. Content is returned unchanged while acks are off.
+func (s *NotificationService) trackAlert(content domain.NotificationContent) domain.NotificationContent {
+	if content.EventType == domain.NotificationEventTest {
+		return content
+	}
+	s.mu.Lock()
+	if !s.config.AlertAcks {
+		s.mu.Unlock()
+		return content
+	}
+
+	s.alertSeq++
+	content.AlertID = strconv.FormatInt(time.Now().Unix(), 36) + "-" + strconv.FormatInt(s.alertSeq, 36)
+	now := time.Now()
+	alert := domain.SentAlert{
+		ID:         content.AlertID,
+		EventType:  content.EventType,
+		Title:      content.Title,
+		Message:    content.Message,
+		Priority:   content.Priority,
+		SentAt:     now,
+		LastPingAt: now,
+	}
+	s.alerts = append(s.alerts, alert)
+	evicted := s.evictAlertsLocked()
+	s.mu.Unlock()
+
+	s.saveAlert(alert)
+	for _, id := range evicted {
+		if err := s.store.DeleteSentAlert(id); err != nil {
+			log.Printf("[NotificationService] Failed to delete alert %s: %v", id, err)
+		}
+	}
+	return content
+}
+
+// evictAlertsLocked drops alerts past maxTrackedAlerts, acknowledged ones first, and
+// returns their IDs. Caller must hold mu.
+func (s *NotificationService) evictAlertsLocked() []string {
+	var evicted []string
+	for len(s.alerts) > maxTrackedAlerts {
+		i := slices.IndexFunc(s.alerts, domain.SentAlert.Acked)
+		if i < 0 {
+			i = 0
+			log.Printf("[NotificationService] Dropping unacknowledged alert %s: over %d alerts await acknowledgment",
+				s.alerts[i].ID, maxTrackedAlerts)
+		}
+		evicted = append(evicted, s.alerts[i].ID)
+		s.alerts = slices.Delete(s.alerts, i, i+1)
+	}
+	return evicted
+}
+
+// saveAlert persists an alert's acknowledgment state so it survives a restart
+func (s *NotificationService) saveAlert(alert domain.SentAlert) {
+	if err := s.store.SaveSentAlert(alert); err != nil {
+		log.Printf("[NotificationService] Failed to save alert %s: %v", alert.ID, err)
+	}
+}
+
+// repingWorker periodically reminds chats of critical alerts nobody acknowledged
+func (s *NotificationService) repingWorker(stopCh chan struct{}) {
+	ticker := time.NewTicker(repingCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			for _, content := range s.dueRepings(time.Now()) {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				if err := s.telegram.Send(ctx, content); err != nil {
+					log.Printf("[NotificationService] Failed to re-ping alert %s: %v", content.AlertID, err)
+				}
+				cancel()
+			}
+		}
+	}
+}
+
+// dueRepings returns reminders for the unacknowledged critical alerts whose last ping
+// is older than the re-ping interval, counting them as sent
+func (s *NotificationService) dueRepings(now time.Time) []domain.NotificationContent {
+	s.mu.Lock()
+	interval := time.Duration(s.config.AlertRepingMinutes) * time.Minute
+	if !s.config.Enabled || !s.config.AlertAcks || interval <= 0 || s.maintenance.Enabled {
+		s.mu.Unlock()
+		return nil
+	}
+	var due []domain.NotificationContent
+	var pinged []domain.SentAlert
+	for i := range s.alerts {
+		alert := &s.alerts[i]
+		if alert.Acked() || !alert.Critical() || alert.Repings >= maxAlertRepings || now.Sub(alert.LastPingAt) < interval {
+			continue
+		}
+		alert.Repings++
+		alert.LastPingAt = now
+		pinged = append(pinged, *alert)
+		due = append(due, domain.NotificationContent{
+			EventType: alert.EventType,
+			Title:     alert.Title,
+			Message: fmt.Sprintf("<b>🔁 Reminder %d/%d: not acknowledged after %d min</b>\n\n%s",
+				alert.Repings, maxAlertRepings, int(now.Sub(alert.SentAt).Minutes()), alert.Message),
+			Timestamp: now,
+			Priority:  alert.Priority,
+			AlertID:   alert.ID,
+		})
+	}
+	s.mu.Unlock()
+
+	for _, alert := range pinged {
+		s.saveAlert(alert)
+	}
+	return due
+}
+
+// findAlertLocked returns the tracked alert with the ID, or nil. Caller must hold mu.
+func (s *NotificationService) findAlertLocked(id string) *domain.SentAlert {
+	for i := range s.alerts {
+		if s.alerts[i].ID == id {
+			return &s.alerts[i]
+		}
+	}
+	return nil
+}
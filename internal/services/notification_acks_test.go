@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// newAckService creates a notification service with alert acks on, backed by store
+func newAckService(t *testing.T, store *storage.MemoryPolymarketStore) *NotificationService {
+	t.Helper()
+	config := domain.DefaultNotificationConfig()
+	config.AlertAcks = true
+	if err := store.SaveNotificationConfig(config); err != nil {
+		t.Fatal(err)
+	}
+	return NewNotificationService(store, localbus.New())
+}
+
+func TestAlertAcksSurviveRestart(t *testing.T) {
+	store := storage.NewMemoryPolymarketStore()
+	svc := newAckService(t, store)
+
+	first := svc.trackAlert(domain.NotificationContent{Title: "Fresh wallet", Priority: "high"})
+	svc.trackAlert(domain.NotificationContent{Title: "Big trade"})
+	if _, err := svc.AckAlert(first.AlertID, "api:default"); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := newAckService(t, store)
+	status := restarted.GetAlertAcks(false)
+	if len(status.Alerts) != 2 || status.Unacked != 1 {
+		t.Fatalf("after restart got %d alerts, %d unacked, want 2 and 1", len(status.Alerts), status.Unacked)
+	}
+	if acked := status.Alerts[1]; acked.ID != first.AlertID || acked.AckedBy != "api:default" {
+		t.Errorf("oldest alert after restart = %+v, want %s acked by api:default", acked, first.AlertID)
+	}
+}
+
+func TestAcknowledgedAlertsAreEvictedFirst(t *testing.T) {
+	store := storage.NewMemoryPolymarketStore()
+	svc := newAckService(t, store)
+
+	var ids []string
+	for i := range maxTrackedAlerts {
+		ids = append(ids, svc.trackAlert(domain.NotificationContent{Title: fmt.Sprintf("alert %d", i)}).AlertID)
+	}
+	if _, err := svc.AckAlert(ids[10], "api:default"); err != nil {
+		t.Fatal(err)
+	}
+	svc.trackAlert(domain.NotificationContent{Title: "one more"})
+
+	status := svc.GetAlertAcks(false)
+	if len(status.Alerts) != maxTrackedAlerts || status.Unacked != maxTrackedAlerts {
+		t.Fatalf("got %d alerts, %d unacked, want %d unacked", len(status.Alerts), status.Unacked, maxTrackedAlerts)
+	}
+	if svc.findAlertLocked(ids[0]) == nil {
+		t.Error("oldest unacknowledged alert was dropped while an acknowledged one was tracked")
+	}
+	if svc.findAlertLocked(ids[10]) != nil {
+		t.Error("acknowledged alert is still tracked past the limit")
+	}
+
+	saved, err := store.GetSentAlerts(maxTrackedAlerts + 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != maxTrackedAlerts {
+		t.Errorf("store holds %d alerts, want %d", len(saved), maxTrackedAlerts)
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// maxDeliveryAttempts is how many times an item is sent to a channel before giving up
+	maxDeliveryAttempts = 5
+
+	// firstDeliveryRetry is the wait after a first failed send, doubled after each failure
+	firstDeliveryRetry = 30 * time.Second
+
+	// deliveryRetryInterval is how often failed channel deliveries are checked for retry
+	deliveryRetryInterval = 15 * time.Second
+
+	// deliverySendTimeout bounds one send to one channel
+	deliverySendTimeout = 10 * time.Second
+
+	// maxUndeliveredNotifications caps the undelivered notifications listed and retried at once
+	maxUndeliveredNotifications = 200
+)
+
+// GetUndeliveredNotifications returns the channel deliveries that have not succeeded
+// yet, including those given up on, next attempt first
+func (s *NotificationService) GetUndeliveredNotifications() ([]domain.NotificationDelivery, error) {
+	deliveries, err := s.store.GetUndeliveredNotifications(maxUndeliveredNotifications)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get undelivered notifications: %w", err)
+	}
+	return deliveries, nil
+}
+
+// deliver sends an item's notification to each channel it is routed to, skipping the
+// channels the item's ledger shows as delivered or awaiting a retry. Each channel keeps
+// its own delivery state, so one failing bot is retried alone instead of resending the
+// alert to every chat.
+func (s *NotificationService) deliver(itemType, itemID string, content domain.NotificationContent) {
+	sent := make(map[string]bool)
+	if deliveries, err := s.store.GetNotificationDeliveries(itemType, itemID); err != nil {
+		log.Printf("[NotificationService] Failed to read deliveries of %s %s: %v", itemType, itemID, err)
+	} else {
+		for _, d := range deliveries {
+			sent[d.Channel] = d.Delivered() || d.Attempts > 0
+		}
+	}
+
+	for _, channel := range s.telegram.Channels(content.EventType) {
+		if sent[channel] {
+			continue
+		}
+		delivery := domain.NotificationDelivery{ItemType: itemType, ItemID: itemID, Channel: channel}
+		s.attemptDelivery(&delivery, content)
+	}
+}
+
+// attemptDelivery sends a notification to a delivery's channel once and stores the
+// outcome, scheduling the next attempt after a failure
+func (s *NotificationService) attemptDelivery(delivery *domain.NotificationDelivery, content domain.NotificationContent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deliverySendTimeout)
+	err := s.telegram.SendVia(ctx, delivery.Channel, content)
+	cancel()
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+	if err == nil {
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+		delivery.Content = nil
+	} else {
+		log.Printf("[NotificationService] Failed to send notification (attempt %d/%d): %v",
+			delivery.Attempts, maxDeliveryAttempts, err)
+		s.mu.RLock()
+		reporter := s.errReporter
+		s.mu.RUnlock()
+		reporter.Report("notifications", err)
+
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(deliveryBackoff(delivery.Attempts, err))
+		delivery.Content = &content
+	}
+	if saveErr := s.store.SaveNotificationDelivery(*delivery); saveErr != nil {
+		log.Printf("[NotificationService] Failed to record delivery of %s %s to %s: %v",
+			delivery.ItemType, delivery.ItemID, delivery.Channel, saveErr)
+	}
+	return err
+}
+
+// deliveryBackoff returns the wait before retrying a channel after a number of failed
+// attempts, at least as long as a rate limit asks for
+func deliveryBackoff(attempts int, err error) time.Duration {
+	wait := firstDeliveryRetry << (attempts - 1)
+	var limited *domain.UpstreamRateLimited
+	if errors.As(err, &limited) && limited.RetryAfter > wait {
+		wait = limited.RetryAfter
+	}
+	return wait
+}
+
+// deliveryRetryWorker periodically resends notifications to the channels that failed them
+func (s *NotificationService) deliveryRetryWorker(stopCh chan struct{}) {
+	ticker := time.NewTicker(deliveryRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			s.retryDeliveries(time.Now())
+		}
+	}
+}
+
+// retryDeliveries resends the failed channel deliveries that are due. Nothing is retried
+// while notifications are off or in maintenance mode.
+func (s *NotificationService) retryDeliveries(now time.Time) {
+	s.mu.RLock()
+	paused := !s.config.Enabled || s.maintenance.Enabled
+	s.mu.RUnlock()
+	if paused {
+		return
+	}
+
+	due, err := s.store.GetDueNotificationDeliveries(maxDeliveryAttempts, now, maxUndeliveredNotifications)
+	if err != nil {
+		log.Printf("[NotificationService] Failed to read undelivered notifications: %v", err)
+		return
+	}
+	for _, delivery := range due {
+		if err := s.attemptDelivery(&delivery, *delivery.Content); err != nil && delivery.Attempts >= maxDeliveryAttempts {
+			log.Printf("[NotificationService] Giving up on %s %s for %s after %d attempts",
+				delivery.ItemType, delivery.ItemID, delivery.Channel, delivery.Attempts)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// maxSuppressedAlerts caps how many would-be alerts are kept during maintenance
+const maxSuppressedAlerts = 100
+
+// SetMaintenanceMode turns the notification kill-switch on or off.
+// While on, every outbound notification is recorded instead of delivered.
+// Turning it on again keeps the original start time and recorded alerts.
+func (s *NotificationService) SetMaintenanceMode(enabled bool, reason string) domain.MaintenanceStatus {
+	s.mu.Lock()
+	switch {
+	case enabled && !s.maintenance.Enabled:
+		s.maintenance = domain.MaintenanceStatus{Enabled: true, Reason: reason, Since: time.Now()}
+		log.Printf("[NotificationService] Maintenance mode ON: %s", reason)
+	case enabled:
+		s.maintenance.Reason = reason
+	case s.maintenance.Enabled:
+		log.Printf("[NotificationService] Maintenance mode OFF, %d alerts were suppressed", s.maintenance.SuppressedCount)
+		s.maintenance.Enabled = false
+	}
+	status := s.maintenanceStatusLocked()
+	s.mu.Unlock()
+
+	s.eventBus.Emit("notification:maintenance", status)
+	return status
+}
+
+// GetMaintenanceStatus returns the kill-switch state and the alerts it held back
+func (s *NotificationService) GetMaintenanceStatus() domain.MaintenanceStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.maintenanceStatusLocked()
+}
+
+// suppressForMaintenance records the alert and returns true if maintenance mode is on
+func (s *NotificationService) suppressForMaintenance(content domain.NotificationContent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.maintenance.Enabled {
+		return false
+	}
+
+	s.maintenance.SuppressedCount++
+	s.maintenance.Suppressed = append(s.maintenance.Suppressed, content)
+	if len(s.maintenance.Suppressed) > maxSuppressedAlerts {
+		s.maintenance.Suppressed = s.maintenance.Suppressed[len(s.maintenance.Suppressed)-maxSuppressedAlerts:]
+	}
+	log.Printf("[NotificationService] Maintenance mode, alert recorded but not sent: %s", content.Title)
+	return true
+}
+
+// maintenanceStatusLocked copies the status with newest alerts first. Caller must hold mu.
+func (s *NotificationService) maintenanceStatusLocked() domain.MaintenanceStatus {
+	status := s.maintenance
+	status.Suppressed = make([]domain.NotificationContent, len(s.maintenance.Suppressed))
+	for i, content := range s.maintenance.Suppressed {
+		status.Suppressed[len(s.maintenance.Suppressed)-1-i] = content
+	}
+	return status
+}
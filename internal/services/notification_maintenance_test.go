@@ -0,0 +1,58 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestMaintenanceModeRecordsInsteadOfSending(t *testing.T) {
+	svc := newAckService(t, storage.NewMemoryPolymarketStore())
+
+	on := svc.SetMaintenanceMode(true, "tuning thresholds")
+	if !on.Enabled || on.Reason != "tuning thresholds" || on.Since.IsZero() {
+		t.Fatalf("status = %+v, want maintenance on with its reason", on)
+	}
+	svc.sendNotificationAsync("trade", "trade-1", domain.NotificationContent{Title: "Big trade"})
+	svc.sendNotificationAsync("trade", "trade-2", domain.NotificationContent{Title: "Fresh wallet"})
+	waitFor(t, "suppressed alerts", func() bool { return svc.GetMaintenanceStatus().SuppressedCount == 2 })
+
+	status := svc.GetMaintenanceStatus()
+	if len(status.Suppressed) != 2 {
+		t.Fatalf("recorded %d alerts, want 2", len(status.Suppressed))
+	}
+	if len(svc.GetAlertAcks(false).Alerts) != 0 {
+		t.Error("suppressed alerts were tracked as sent")
+	}
+
+	// Switching on again keeps the start time and the recorded alerts
+	again := svc.SetMaintenanceMode(true, "editing templates")
+	if !again.Since.Equal(on.Since) || again.Reason != "editing templates" || again.SuppressedCount != 2 {
+		t.Errorf("status = %+v, want the original start and alerts with the new reason", again)
+	}
+
+	if off := svc.SetMaintenanceMode(false, ""); off.Enabled || off.SuppressedCount != 2 {
+		t.Errorf("status = %+v, want maintenance off reporting what it held back", off)
+	}
+	if svc.suppressForMaintenance(domain.NotificationContent{Title: "after"}) {
+		t.Error("alert was suppressed with maintenance off")
+	}
+}
+
+func TestMaintenanceKeepsNewestSuppressedAlerts(t *testing.T) {
+	svc := newAckService(t, storage.NewMemoryPolymarketStore())
+	svc.SetMaintenanceMode(true, "")
+	for i := range maxSuppressedAlerts + 5 {
+		svc.suppressForMaintenance(domain.NotificationContent{Title: fmt.Sprintf("alert %d", i)})
+	}
+
+	status := svc.GetMaintenanceStatus()
+	if status.SuppressedCount != maxSuppressedAlerts+5 || len(status.Suppressed) != maxSuppressedAlerts {
+		t.Fatalf("count = %d with %d kept, want %d with %d kept", status.SuppressedCount, len(status.Suppressed), maxSuppressedAlerts+5, maxSuppressedAlerts)
+	}
+	if newest := status.Suppressed[0].Title; newest != fmt.Sprintf("alert %d", maxSuppressedAlerts+4) {
+		t.Errorf("first alert = %q, want the newest", newest)
+	}
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/notification"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// maxPendingTelegramChats bounds pending registrations so a spammed bot can't grow the config
+const maxPendingTelegramChats = 50
+
+// GetPendingTelegramChats returns chats that sent /start and await approval, oldest first
+func (s *NotificationService) GetPendingTelegramChats() []domain.TelegramChatRegistration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]domain.TelegramChatRegistration{}, s.config.TelegramPendingChats...)
+}
+
+// ApproveTelegramChat adds a chat pending on a bot to that bot's chats and welcomes it
+func (s *NotificationService) ApproveTelegramChat(botName, chatID string) error {
+	s.mu.Lock()
+	config := s.config
+	i := pendingChatIndex(config.TelegramPendingChats, botName, chatID)
+	if i < 0 {
+		s.mu.Unlock()
+		return fmt.Errorf("no pending registration for chat %s on bot %s", chatID, botName)
+	}
+	registration := config.TelegramPendingChats[i]
+	config.TelegramPendingChats = slices.Delete(slices.Clone(config.TelegramPendingChats), i, i+1)
+	if botName == "" || botName == domain.DefaultTelegramBot {
+		botName = domain.DefaultTelegramBot
+		if !slices.Contains(config.TelegramChatIDs, chatID) {
+			config.TelegramChatIDs = append(slices.Clone(config.TelegramChatIDs), chatID)
+		}
+	} else {
+		config.TelegramBots = slices.Clone(config.TelegramBots)
+		for j := range config.TelegramBots {
+			if config.TelegramBots[j].Name == botName && !slices.Contains(config.TelegramBots[j].ChatIDs, chatID) {
+				config.TelegramBots[j].ChatIDs = append(slices.Clone(config.TelegramBots[j].ChatIDs), chatID)
+			}
+		}
+	}
+	err := s.saveConfigLocked(config)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[NotificationService] Approved Telegram chat %s (%s) on bot %s", chatID, registration.Name, botName)
+	s.eventBus.Emit("notification:telegram_registration", s.GetPendingTelegramChats())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	if err := s.telegram.SendTo(ctx, botName, chatID, "✅ This chat is approved and will now receive XTools alerts."); err != nil {
+		log.Printf("[NotificationService] Failed to welcome Telegram chat %s: %v", chatID, err)
+	}
+	return nil
+}
+
+// RejectTelegramChat discards a chat's pending registration on a bot
+func (s *NotificationService) RejectTelegramChat(botName, chatID string) error {
+	s.mu.Lock()
+	config := s.config
+	i := pendingChatIndex(config.TelegramPendingChats, botName, chatID)
+	if i < 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	config.TelegramPendingChats = slices.Delete(slices.Clone(config.TelegramPendingChats), i, i+1)
+	err := s.saveConfigLocked(config)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[NotificationService] Rejected Telegram chat %s on bot %s", chatID, botName)
+	s.eventBus.Emit("notification:telegram_registration", s.GetPendingTelegramChats())
+	return nil
+}
+
+// onTelegramStart records a chat that sent /start and returns the reply to send it
+func (s *NotificationService) onTelegramStart(registration domain.TelegramChatRegistration) string {
+	s.mu.Lock()
+	config := s.config
+	registered := false
+	for _, bot := range config.TelegramBotList() {
+		registered = registered || (bot.Name == registration.Bot && slices.Contains(bot.ChatIDs, registration.ChatID))
+	}
+	switch {
+	case registered:
+		s.mu.Unlock()
+		return "This chat already receives XTools alerts."
+	case pendingChatIndex(config.TelegramPendingChats, registration.Bot, registration.ChatID) >= 0:
+		s.mu.Unlock()
+		return "This chat is waiting for approval in XTools."
+	case len(config.TelegramPendingChats) >= maxPendingTelegramChats:
+		s.mu.Unlock()
+		log.Printf("[NotificationService] Ignored Telegram registration from chat %s: too many pending", registration.ChatID)
+		return ""
+	}
+	config.TelegramPendingChats = append(slices.Clone(config.TelegramPendingChats), registration)
+	err := s.saveConfigLocked(config)
+	s.mu.Unlock()
+	if err != nil {
+		log.Printf("[NotificationService] Failed to save Telegram registration: %v", err)
+		return ""
+	}
+
+	log.Printf("[NotificationService] Telegram chat %s (%s) registered on bot %s, pending approval", registration.ChatID, registration.Name, registration.Bot)
+	s.eventBus.Emit("notification:telegram_registration", s.GetPendingTelegramChats())
+	return fmt.Sprintf("Registration received. Approve chat %s in XTools to start receiving alerts.", registration.ChatID)
+}
+
+// restartPolling starts or stops polling every bot according to the config: for /start
+// while registration is open and for ack buttons while alert acks are on
+func (s *NotificationService) restartPolling() {
+	s.mu.Lock()
+	pollers := s.pollers
+	s.pollers = nil
+	bots, open, acks, running := s.config.TelegramBotList(), s.config.TelegramRegistrationOpen, s.config.AlertAcks, s.stopCh != nil
+	s.mu.Unlock()
+
+	for _, poller := range pollers {
+		poller.Stop()
+	}
+	if !(open || acks) || !running {
+		return
+	}
+
+	for _, bot := range bots {
+		name := bot.Name
+		var updates notification.TelegramUpdates
+		if open {
+			updates.OnStart = func(r domain.TelegramChatRegistration) string {
+				r.Bot = name
+				return s.onTelegramStart(r)
+			}
+		}
+		if acks {
+			updates.OnAck = s.onTelegramAck
+		}
+		poller, err := notification.StartTelegramPoller(bot.Token, updates)
+		if err != nil {
+			log.Printf("[NotificationService] Telegram polling unavailable for bot %s: %v", name, err)
+			continue
+		}
+		s.mu.Lock()
+		s.pollers = append(s.pollers, poller)
+		s.mu.Unlock()
+	}
+	if open {
+		log.Printf("[NotificationService] Telegram registration open on %d bots: chats can send /start", len(bots))
+	}
+	if acks {
+		log.Printf("[NotificationService] Listening for alert acks on %d bots", len(bots))
+	}
+}
+
+// saveConfigLocked persists config and makes it current. Caller must hold mu.
+func (s *NotificationService) saveConfigLocked(config domain.NotificationConfig) error {
+	if err := s.store.SaveNotificationConfig(config); err != nil {
+		return fmt.Errorf("failed to save notification config: %w", err)
+	}
+	s.config = config
+	s.telegram.UpdateBots(config.TelegramBotList())
+	return nil
+}
+
+// pendingChatIndex finds a pending registration; ones without a bot predate multiple bots
+// and belong to the default bot
+func pendingChatIndex(pending []domain.TelegramChatRegistration, botName, chatID string) int {
+	if botName == "" {
+		botName = domain.DefaultTelegramBot
+	}
+	return slices.IndexFunc(pending, func(r domain.TelegramChatRegistration) bool {
+		bot := r.Bot
+		if bot == "" {
+			bot = domain.DefaultTelegramBot
+		}
+		return bot == botName && r.ChatID == chatID
+	})
+}
+
+// sameBotTokens reports whether two bot lists poll the same bots
+func sameBotTokens(a, b []domain.TelegramBotConfig) bool {
+	return slices.EqualFunc(a, b, func(x, y domain.TelegramBotConfig) bool {
+		return x.Name == y.Name && x.Token == y.Token
+	})
+}
@@ -6,25 +6,35 @@ import (
 	"sync"
 	"time"
 
-	"xtools/internal/adapters/notification"
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/adapters/notification"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // Notification item types for deduplication
 const (
 	NotifyTypeBigTrade    = "big_trade"
 	NotifyTypeFreshWallet = "fresh_wallet"
+	NotifyTypeDetector    = "detector"
+	NotifyTypeReport      = "report"
+	NotifyTypeWatched     = "watched_wallet"
 )
 
 // NotificationService handles notification orchestration
 type NotificationService struct {
-	mu       sync.RWMutex
-	config   domain.NotificationConfig
-	store    ports.NotificationStore
-	eventBus ports.EventBus
-	telegram *notification.TelegramNotifier
-	stopCh   chan struct{}
+	mu          sync.RWMutex
+	config      domain.NotificationConfig
+	store       ports.NotificationStore
+	eventBus    ports.EventBus
+	telegram    *notification.TelegramRouter
+	transformer ports.NotificationTransformer  // Optional user hook applied before delivery
+	maintenance domain.MaintenanceStatus       // Kill-switch state, kept in memory only
+	pollers     []*notification.TelegramPoller // Poll /start registrations and ack buttons
+	alerts      []domain.SentAlert             // Delivered alerts tracked for acknowledgment, oldest first, saved in the store
+	alertSeq    int64                          // Numbers tracked alert IDs
+	errReporter *ErrorReporter                 // Shared error counts and "errors" topic, may be nil
+	blacklist   ports.WalletBlacklist          // Wallets whose alerts are dropped, may be nil
+	stopCh      chan struct{}
 }
 
 // NewNotificationService creates a new notification service
@@ -43,7 +53,12 @@ func NewNotificationService(store ports.NotificationStore, eventBus ports.EventB
 		config:   config,
 		store:    store,
 		eventBus: eventBus,
-		telegram: notification.NewTelegramNotifier(config.TelegramBotToken, config.TelegramChatIDs),
+		telegram: notification.NewTelegramRouter(config.TelegramBotList()),
+	}
+	if alerts, err := store.GetSentAlerts(maxTrackedAlerts); err != nil {
+		log.Printf("[NotificationService] Failed to load tracked alerts: %v", err)
+	} else {
+		svc.alerts = alerts
 	}
 
 	return svc
@@ -57,6 +72,7 @@ func (s *NotificationService) Start() {
 		return // Already running
 	}
 	s.stopCh = make(chan struct{})
+	stopCh := s.stopCh
 	s.mu.Unlock()
 
 	log.Println("[NotificationService] Starting notification service")
@@ -64,17 +80,26 @@ func (s *NotificationService) Start() {
 	// Subscribe to polymarket events
 	s.eventBus.Subscribe("polymarket:event", s.handlePolymarketEvent)
 	s.eventBus.Subscribe("polymarket:fresh_wallet_detected", s.handleFreshWalletDetected)
+	s.eventBus.Subscribe("polymarket:detector_signal", s.handleDetectorSignal)
+	s.eventBus.Subscribe("polymarket:entity_flow_report", s.handleEntityFlowReport)
+	s.eventBus.Subscribe("polymarket:watched_wallet_trade", s.handleWatchedWalletTrade)
+
+	go s.repingWorker(stopCh)
+	go s.notifiedCleanupWorker(stopCh)
+	go s.deliveryRetryWorker(stopCh)
+	s.restartPolling()
 }
 
 // Stop stops the notification service
 func (s *NotificationService) Stop() {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.stopCh != nil {
 		close(s.stopCh)
 		s.stopCh = nil
 	}
+	s.mu.Unlock()
+
+	s.restartPolling()
 
 	log.Println("[NotificationService] Stopped notification service")
 }
@@ -88,17 +113,31 @@ func (s *NotificationService) GetConfig() domain.NotificationConfig {
 
 // UpdateConfig updates the notification configuration
 func (s *NotificationService) UpdateConfig(config domain.NotificationConfig) error {
+	if err := config.ValidateTelegramBots(); err != nil {
+		return err
+	}
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	// Pending registrations are managed through approve/reject, not the settings form
+	config.TelegramPendingChats = s.config.TelegramPendingChats
+	pollingChanged := !sameBotTokens(config.TelegramBotList(), s.config.TelegramBotList()) ||
+		config.TelegramRegistrationOpen != s.config.TelegramRegistrationOpen ||
+		config.AlertAcks != s.config.AlertAcks
 
 	s.config = config
-	s.telegram.UpdateConfig(config.TelegramBotToken, config.TelegramChatIDs)
+	s.telegram.UpdateBots(config.TelegramBotList())
 
 	// Save to database
 	if err := s.store.SaveNotificationConfig(config); err != nil {
+		s.mu.Unlock()
 		log.Printf("[NotificationService] Failed to save config: %v", err)
 		return err
 	}
+	s.mu.Unlock()
+
+	if pollingChanged {
+		s.restartPolling()
+	}
 
 	log.Printf("[NotificationService] Config updated: Enabled=%v, BigTrades=%v, FreshWallets=%v",
 		config.Enabled, config.NotifyBigTrades, config.NotifyFreshWallets)
@@ -126,7 +165,7 @@ func (s *NotificationService) SendTestNotification(ctx context.Context) error {
 // handlePolymarketEvent handles incoming Polymarket trade events
 func (s *NotificationService) handlePolymarketEvent(data interface{}) {
 	event, ok := data.(domain.PolymarketEvent)
-	if !ok {
+	if !ok || event.Muted || s.isBlacklisted(event.WalletAddress) {
 		return
 	}
 
@@ -164,13 +203,13 @@ func (s *NotificationService) handlePolymarketEvent(data interface{}) {
 
 	// Send big trade notification
 	content := domain.NewBigTradeNotification(event)
-	s.sendNotificationAsync(content)
+	s.sendNotificationAsync(NotifyTypeBigTrade, tradeID, content)
 }
 
 // handleFreshWalletDetected handles fresh wallet detection events
 func (s *NotificationService) handleFreshWalletDetected(data interface{}) {
 	profile, ok := data.(domain.WalletProfile)
-	if !ok {
+	if !ok || s.isBlacklisted(profile.Address) {
 		return
 	}
 
@@ -201,18 +240,169 @@ func (s *NotificationService) handleFreshWalletDetected(data interface{}) {
 
 	// Send fresh wallet notification
 	content := domain.NewFreshWalletNotification(profile)
-	s.sendNotificationAsync(content)
+	s.sendNotificationAsync(NotifyTypeFreshWallet, profile.Address, content)
+}
+
+// handleDetectorSignal handles signals emitted by custom detectors
+func (s *NotificationService) handleDetectorSignal(data interface{}) {
+	signal, ok := data.(domain.DetectorSignal)
+	if !ok || !signal.Alert || s.isBlacklisted(signal.WalletAddress) {
+		return
+	}
+
+	s.mu.RLock()
+	config := s.config
+	s.mu.RUnlock()
+
+	if !config.Enabled || !config.NotifyDetectors {
+		return
+	}
+
+	// One alert per detector signal and trade
+	itemID := signal.Detector + ":" + signal.Signal + ":" + signal.TradeID
+	if signal.TradeID == "" {
+		itemID += signal.WalletAddress + "_" + signal.Timestamp.Format(time.RFC3339Nano)
+	}
+
+	notified, err := s.store.HasNotified(NotifyTypeDetector, itemID)
+	if err != nil {
+		log.Printf("[NotificationService] Error checking notification status: %v", err)
+		return
+	}
+	if notified {
+		return
+	}
+
+	if err := s.store.MarkNotified(NotifyTypeDetector, itemID); err != nil {
+		log.Printf("[NotificationService] Error marking as notified: %v", err)
+		return
+	}
+
+	s.sendNotificationAsync(NotifyTypeDetector, itemID, domain.NewDetectorNotification(signal))
+}
+
+// handleEntityFlowReport sends the daily entity flow report, once per report window
+func (s *NotificationService) handleEntityFlowReport(data interface{}) {
+	report, ok := data.(domain.EntityFlowReport)
+	if !ok {
+		return
+	}
+
+	s.mu.RLock()
+	enabled := s.config.Enabled
+	s.mu.RUnlock()
+	if !enabled {
+		return
+	}
+
+	itemID := "entity_flow:" + report.Until.UTC().Format(time.RFC3339)
+	notified, err := s.store.HasNotified(NotifyTypeReport, itemID)
+	if err != nil {
+		log.Printf("[NotificationService] Error checking notification status: %v", err)
+		return
+	}
+	if notified {
+		return
+	}
+	if err := s.store.MarkNotified(NotifyTypeReport, itemID); err != nil {
+		log.Printf("[NotificationService] Error marking as notified: %v", err)
+		return
+	}
+
+	s.sendNotificationAsync(NotifyTypeReport, itemID, domain.NewEntityFlowNotification(report))
 }
 
-// sendNotificationAsync sends a notification asynchronously
-func (s *NotificationService) sendNotificationAsync(content domain.NotificationContent) {
+// handleWatchedWalletTrade alerts on every trade by a watched wallet, whatever its size
+func (s *NotificationService) handleWatchedWalletTrade(data interface{}) {
+	event, ok := data.(domain.PolymarketEvent)
+	if !ok || s.isBlacklisted(event.WalletAddress) {
+		return
+	}
+
+	s.mu.RLock()
+	config := s.config
+	s.mu.RUnlock()
+
+	if !config.Enabled || !config.NotifyWatchedWallets {
+		return
+	}
+
+	itemID := event.TradeID
+	if itemID == "" {
+		itemID = event.WalletAddress + "_" + event.Timestamp.Format(time.RFC3339Nano)
+	}
+
+	notified, err := s.store.HasNotified(NotifyTypeWatched, itemID)
+	if err != nil {
+		log.Printf("[NotificationService] Error checking notification status: %v", err)
+		return
+	}
+	if notified {
+		return
+	}
+	if err := s.store.MarkNotified(NotifyTypeWatched, itemID); err != nil {
+		log.Printf("[NotificationService] Error marking as notified: %v", err)
+		return
+	}
+
+	s.sendNotificationAsync(NotifyTypeWatched, itemID, domain.NewWatchedWalletNotification(event))
+}
+
+// SetErrorReporter makes delivery failures count towards the shared error stats
+func (s *NotificationService) SetErrorReporter(reporter *ErrorReporter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errReporter = reporter
+}
+
+// SetWalletBlacklist makes trades and signals of blacklisted wallets raise no notifications
+func (s *NotificationService) SetWalletBlacklist(blacklist ports.WalletBlacklist) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blacklist = blacklist
+}
+
+// isBlacklisted reports whether a wallet's alerts are dropped
+func (s *NotificationService) isBlacklisted(address string) bool {
+	s.mu.RLock()
+	blacklist := s.blacklist
+	s.mu.RUnlock()
+	return blacklist != nil && address != "" && blacklist.IsWalletBlacklisted(address)
+}
+
+// SetTransformer installs a hook that can rewrite or suppress notifications before delivery
+func (s *NotificationService) SetTransformer(transformer ports.NotificationTransformer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transformer = transformer
+}
+
+// sendNotificationAsync sends the notification of an item asynchronously, on each
+// routed channel the item has not reached yet
+func (s *NotificationService) sendNotificationAsync(itemType, itemID string, content domain.NotificationContent) {
+	s.mu.RLock()
+	transformer := s.transformer
+	s.mu.RUnlock()
+
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
+		if transformer != nil {
+			transformed, deliver, err := transformer.Transform(content)
+			if err != nil {
+				// Fail open: a broken script must not swallow alerts
+				log.Printf("[NotificationService] Alert transform failed, sending original: %v", err)
+			} else if !deliver {
+				log.Printf("[NotificationService] Alert suppressed by transform: %s", content.Title)
+				return
+			} else {
+				content = transformed
+			}
+		}
 
-		if err := s.telegram.Send(ctx, content); err != nil {
-			log.Printf("[NotificationService] Failed to send notification: %v", err)
+		if s.suppressForMaintenance(content) {
+			return
 		}
+		content = s.trackAlert(content)
+		s.deliver(itemType, itemID, content)
 	}()
 }
 
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// defaultNotificationStatsDays is how far back notification stats go by default
+	defaultNotificationStatsDays = 30
+
+	// maxNotificationStatsDays caps how far back notification stats go
+	maxNotificationStatsDays = 365
+
+	// notifiedCleanupInterval is how often old deduplication records are deleted
+	notifiedCleanupInterval = 24 * time.Hour
+
+	// firstNotifiedCleanupDelay lets startup settle before the first cleanup
+	firstNotifiedCleanupDelay = 5 * time.Minute
+)
+
+// GetNotificationStats counts the items notified over the last days (0 = 30), by
+// type and UTC day
+func (s *NotificationService) GetNotificationStats(days int) (*domain.NotificationStats, error) {
+	if days <= 0 {
+		days = defaultNotificationStatsDays
+	}
+	days = min(days, maxNotificationStatsDays)
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1-days)
+	stats, err := s.store.GetNotificationStats(since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification stats: %w", err)
+	}
+	return stats, nil
+}
+
+// CleanupNotified deletes the deduplication records older than the configured
+// retention now. It runs daily on its own while the service is started.
+func (s *NotificationService) CleanupNotified() (*domain.NotifiedCleanupResult, error) {
+	s.mu.RLock()
+	days := s.config.NotifiedRetentionDays
+	s.mu.RUnlock()
+	if days <= 0 {
+		return nil, fmt.Errorf("notified item retention is not set")
+	}
+
+	now := time.Now()
+	result := &domain.NotifiedCleanupResult{Before: now.AddDate(0, 0, -days), RunAt: now}
+	deleted, err := s.store.DeleteNotifiedBefore(result.Before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean up notified items: %w", err)
+	}
+	result.Deleted = deleted
+	if deleted > 0 {
+		log.Printf("[NotificationService] Deleted %d notified items older than %d days", deleted, days)
+	}
+	return result, nil
+}
+
+// notifiedCleanupWorker applies the notified item retention daily
+func (s *NotificationService) notifiedCleanupWorker(stopCh chan struct{}) {
+	timer := time.NewTimer(firstNotifiedCleanupDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+			s.mu.RLock()
+			enabled := s.config.NotifiedRetentionDays > 0
+			s.mu.RUnlock()
+			if enabled {
+				if _, err := s.CleanupNotified(); err != nil {
+					log.Printf("[NotificationService] %v", err)
+				}
+			}
+			timer.Reset(notifiedCleanupInterval)
+		}
+	}
+}
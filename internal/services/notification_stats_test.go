@@ -0,0 +1,35 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+)
+
+func TestCleanupNotifiedAppliesRetention(t *testing.T) {
+	svc := newAckService(t, storage.NewMemoryPolymarketStore())
+	if _, err := svc.CleanupNotified(); err == nil {
+		t.Error("cleaned up without a retention")
+	}
+
+	svc.store.MarkNotified("big_trade", "t1")
+	svc.mu.Lock()
+	svc.config.NotifiedRetentionDays = 7
+	svc.mu.Unlock()
+	result, err := svc.CleanupNotified()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Deleted != 0 || time.Since(result.Before) < 7*24*time.Hour-time.Minute {
+		t.Errorf("result = %+v, want nothing deleted before a week ago", result)
+	}
+
+	stats, err := svc.GetNotificationStats(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Total != 1 || time.Since(stats.Since) < 29*24*time.Hour || time.Since(stats.Since) > 30*24*time.Hour {
+		t.Errorf("stats = %+v, want the item over the default 30 days", stats)
+	}
+}
@@ -1,33 +1,115 @@
 package services
 
 import (
-	"context"
 	"log"
-	"strings"
+	"path/filepath"
 	"sync"
 	"time"
 
-	"xtools/internal/adapters/polymarket"
-	"xtools/internal/adapters/storage"
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/adapters/httpcache"
+	"github.com/luthebao/poly-xtools/internal/adapters/imagecache"
+	"github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/adapters/webhook"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // PolymarketService handles Polymarket event watching and storage
 type PolymarketService struct {
 	mu             sync.RWMutex
-	store          *storage.PolymarketStore
+	store          ports.PolymarketStore
 	client         *polymarket.WebSocketClient
+	marketFeed     *polymarket.MarketWebSocketClient // Order books of traded outcome tokens
 	walletAnalyzer *polymarket.WalletAnalyzer
+	markets        *polymarket.MarketClient
+	prices         *polymarket.PriceClient
+	identity       *polymarket.Identity
 	eventBus       ports.EventBus
+	webhook        *webhook.Client
+	httpCache      *httpcache.Transport // Conditional request cache for Gamma and profile API metadata, nil without a database path
+	images         *imagecache.Cache    // Market thumbnails on disk, nil without a database path
+	errReporter    *ErrorReporter       // Counts typed errors and emits them on the "errors" topic
 	dbPath         string
+	storeError     string                     // Why events are kept in memory only, set when the database could not be opened
+	backupSeal     *storage.EncryptedDatabase // Encrypts backups when the database is encrypted at rest, nil = plain backups
 	config         domain.PolymarketConfig
 	saveFilter     domain.PolymarketEventFilter // Filter for saving events to DB
+	priorityQueue  []string                     // Wallets queued by RefreshWallets
+	detectors      []ports.Detector             // Custom detectors run on saved events
+	tagRules       []domain.EventTagRule        // Rules that auto-tag incoming events
+	lateEntryRules []domain.LateEntryRule       // Rules that flag large trades near a market's end
+	auditMu        sync.Mutex
+	snapMu         sync.Mutex
+	snapHash       string // Config version last saved as a snapshot
+	autoTune       domain.AutoTuneSettings
+	lastAutoTune   *domain.AutoTuneResult
+	retention      domain.EventRetention // Limits on stored events, enforced by retentionWorker
+	lastPrune      *domain.EventPruneResult
+	presetsMu      sync.Mutex // Serializes filter preset updates
+	mutesMu        sync.Mutex
+	mutes          map[string]domain.MarketMute // Muted market slugs
+	streaksMu      sync.Mutex
+	streaks        map[string]domain.WalletStreak // Cached win streaks by wallet
+	samplingMu     sync.Mutex
+	samplingRules  []domain.EventSamplingRule // Sampling of high-frequency event types
+	samplingState  map[string]*sampleState
+	samplingStats  map[domain.PolymarketEventType]*domain.EventSamplingStats
+	writes         *writeQueue // Events waiting to be written to the database
+	writersWg      sync.WaitGroup
+	dbHealthMu     sync.Mutex
+	dbHealth       dbHealthState
+	sizeMu         sync.Mutex
+	walletSizes    map[string]*sizeStats  // Log notional history per wallet
+	marketSizes    sizeStats              // Log notional history over every wallet
+	walletHours    map[string]*[24]uint32 // Trades per UTC hour per wallet
+	groupsMu       sync.Mutex
+	groups         map[string]*marketGroup // Recent trades per event slug
+	freshWallets   map[string]bool         // Wallets known to be fresh, for group flow
+	priceChecksMu  sync.Mutex
+	priceChecks    map[string]*domain.PriceConsistency // Events priced outside the band, by event slug
+	exitMu         sync.Mutex
+	exitWatches    []domain.WithdrawalWatch  // Winning flagged wallets monitored on-chain for withdrawals
+	withdrawals    []domain.WalletWithdrawal // Detected withdrawals, newest first
+	washMu         sync.Mutex
+	washTrades     map[string][]washTrade      // Recent unmatched trades per market
+	washPairs      map[string]*domain.WashPair // Wallets seen making offsetting trades
+	washWallets    map[string]bool             // Wallets flagged for wash trading
+	spoofMu        sync.Mutex
+	spoofBooks     map[string]*spoofBook // Level sizes and pending large orders per asset
+	spoofSignals   []domain.SpoofingSignal
+	tickMu         sync.Mutex
+	tickSizes      map[string]float64 // Current tick size per asset, from tick_size_change events
+	quotesMu       sync.Mutex
+	quotes         map[string]*quoteState // Latest best bid and ask per asset, flushed by quoteWorker
+	spreadSignals  []domain.SpreadSignal
+	fundMu         sync.Mutex
+	funding        map[string]domain.FundingOrigin // First deposits of fresh wallets, by wallet
+	fundChecked    map[string]time.Time            // Last lookup per wallet, found or not
+	fundQueue      chan string                     // Fresh wallets waiting for a funding lookup
+	enrichQueue    chan string                     // Wallets of alert-worthy trades waiting for an immediate analysis
+	sheetsMu       sync.Mutex
+	sheets         sheetsSink // Google Sheets sink of flagged events
+	caseSyncMu     sync.Mutex
+	caseSync       caseSync // Notion or Airtable sync of alerts and watched wallets
+	entityMu       sync.Mutex
+	entityCache    map[string][]string      // Entity names per market slug, see marketEntityNames
+	entityRules    []domain.EntityAlertRule // Rules that alert on trades in markets mentioning an entity, guarded by mu
+	watchMu        sync.Mutex
+	watched        map[string]bool // Wallets on the default user's watchlist, lowercased
+	watchOrder     []string        // The same wallets in watchlist order
+	userSettingsMu sync.Mutex      // Serializes user settings changes from load to save
+	intelMu        sync.Mutex      // Serializes trusted wallet intel publisher updates
+	blacklistSave  sync.Mutex      // Serializes blacklist changes from copy to persist and swap
+	blacklistMu    sync.Mutex
+	blacklist      map[string]domain.BlacklistedWallet // Wallets left out of analysis and alerts, by lowercased address
+	recentMu       sync.Mutex
+	recent         *recentTrades // Keys of the latest ingested trades, to drop redeliveries
 	stopCh         chan struct{}
 }
 
 // NewPolymarketService creates a new Polymarket service
-func NewPolymarketService(store *storage.PolymarketStore, eventBus ports.EventBus, dbPath string) *PolymarketService {
+func NewPolymarketService(store ports.PolymarketStore, eventBus ports.EventBus, dbPath string) *PolymarketService {
 	// Try to load config from database, fall back to defaults
 	config, err := store.LoadConfig()
 	if err != nil {
@@ -51,14 +133,64 @@ func NewPolymarketService(store *storage.PolymarketStore, eventBus ports.EventBu
 	svc := &PolymarketService{
 		store:          store,
 		eventBus:       eventBus,
+		webhook:        webhook.NewClient(),
+		errReporter:    NewErrorReporter(eventBus),
 		dbPath:         dbPath,
 		config:         config,
 		walletAnalyzer: polymarket.NewWalletAnalyzer(config, store),
+		markets:        polymarket.NewMarketClient(),
+		prices:         polymarket.NewPriceClient(),
+		identity:       polymarket.NewIdentity(config.ClientIdentity),
 		saveFilter:     saveFilter,
+		streaks:        make(map[string]domain.WalletStreak),
+		walletSizes:    make(map[string]*sizeStats),
+		walletHours:    make(map[string]*[24]uint32),
+		groups:         make(map[string]*marketGroup),
+		freshWallets:   make(map[string]bool),
+		priceChecks:    make(map[string]*domain.PriceConsistency),
+		spoofBooks:     make(map[string]*spoofBook),
+		tickSizes:      make(map[string]float64),
+		quotes:         make(map[string]*quoteState),
+		entityCache:    make(map[string][]string),
+		writes:         newWriteQueue(eventWriteQueueCapacity),
+		recent:         newRecentTrades(recentTradeCapacity),
+		fundQueue:      make(chan string, fundingQueueSize),
+		enrichQueue:    make(chan string, priorityEnrichQueueSize),
 	}
+	if dbPath != "" {
+		svc.httpCache = httpcache.New(filepath.Join(filepath.Dir(dbPath), "http-cache"), svc.identity.Transport(nil))
+		svc.images = imagecache.New(filepath.Join(filepath.Dir(dbPath), "image-cache"), int64(config.ImageCacheMaxMB)<<20)
+	}
+	svc.markets.SetTransport(svc.apiTransport())
+	svc.walletAnalyzer.SetTransport(svc.apiTransport())
+	svc.prices.SetTransport(svc.identity.Transport(nil))
+	svc.applyPragmas(config)
+	svc.startEventWriters()
+
+	svc.loadMutes()
+	svc.loadTagRules()
+	svc.loadLateEntryRules()
+	svc.loadEntityAlertRules()
+	svc.loadWatchlist()
+	svc.loadWalletBlacklist()
+	svc.loadAutoTune()
+	svc.loadEventRetention()
+	svc.loadWithdrawals()
+	svc.loadWashPairs()
+	svc.loadFunding()
+	svc.loadEventSamplingRules()
+	svc.loadSheetsSink()
+	svc.loadCaseSync()
 
 	// Create WebSocket client with event callback
 	svc.client = polymarket.NewWebSocketClient(svc.onEvent)
+	svc.client.SetErrorCallback(func(err error) { svc.errReporter.Report("trade feed", err) })
+	svc.client.SetIdentity(svc.identity)
+
+	// Books, price changes and tick sizes come from the market channel, through the same pipeline
+	svc.marketFeed = polymarket.NewMarketWebSocketClient(svc.onEvent)
+	svc.marketFeed.SetErrorCallback(func(err error) { svc.errReporter.Report("market feed", err) })
+	svc.marketFeed.SetIdentity(svc.identity)
 
 	return svc
 }
@@ -73,10 +205,29 @@ func (s *PolymarketService) Start() error {
 	s.stopCh = make(chan struct{})
 	s.mu.Unlock()
 
-	// Start the wallet analysis worker
+	// Start the background workers
 	go s.walletAnalysisWorker()
+	go s.marketResolutionWorker()
+	go s.alertFollowUpWorker()
+	go s.autoTuneWorker()
+	go s.backPressureWorker()
+	go s.dbMaintenanceWorker()
+	go s.retentionWorker()
+	go s.priceConsistencyWorker()
+	go s.withdrawalWorker()
+	go s.fundingWorker()
+	go s.priorityEnrichWorker()
+	go s.backupWorker()
+	go s.sheetsWorker()
+	go s.caseSyncWorker()
+	go s.quoteWorker()
+	go s.entityFlowReportWorker()
+	go s.marketSnapshotWorker()
 
 	// Connect returns immediately and runs in the background
+	if err := s.marketFeed.Connect(); err != nil {
+		return err
+	}
 	return s.client.Connect()
 }
 
@@ -92,6 +243,9 @@ func (s *PolymarketService) Stop() {
 	if s.client != nil {
 		s.client.Disconnect()
 	}
+	if s.marketFeed != nil {
+		s.marketFeed.Disconnect()
+	}
 }
 
 // GetStatus returns the current watcher status
@@ -103,191 +257,25 @@ func (s *PolymarketService) GetStatus() domain.PolymarketWatcherStatus {
 		return domain.PolymarketWatcherStatus{}
 	}
 
-	return s.client.GetStatus()
-}
-
-// GetEvents retrieves events with optional filtering
-func (s *PolymarketService) GetEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
-	return s.store.GetEvents(filter)
-}
-
-// ClearEvents removes all stored events
-func (s *PolymarketService) ClearEvents() error {
-	return s.store.ClearEvents()
-}
-
-// GetDatabaseInfo returns database statistics
-func (s *PolymarketService) GetDatabaseInfo() (*domain.DatabaseInfo, error) {
-	return s.store.GetDatabaseInfo()
-}
-
-// onEvent is called when a new event is received from WebSocket
-func (s *PolymarketService) onEvent(event domain.PolymarketEvent) {
-	s.mu.RLock()
-	filter := s.saveFilter
-	s.mu.RUnlock()
-
-	// Check basic filters only (ignore fresh wallet filter for saving)
-	if !s.matchesBasicFilter(event, filter) {
-		return
-	}
-
-	// Save wallet address to DB for background analysis (if new)
-	if event.WalletAddress != "" {
-		isNew, err := s.store.SaveWalletAddress(event.WalletAddress)
-		if err != nil {
-			log.Printf("[PolymarketService] Failed to save wallet address: %v", err)
-		} else if isNew {
-			log.Printf("[PolymarketService] New wallet queued for analysis: %s", shortenAddress(event.WalletAddress))
-		}
+	status := s.client.GetStatus()
+	status.MutedMarkets = s.GetMutedMarkets()
+	status.EventSampling = s.samplingStatsSnapshot()
+	writeQueue := s.writes.status()
+	status.WriteQueue = &writeQueue
+	if s.marketFeed != nil {
+		marketChannel := s.marketFeed.GetStatus()
+		status.MarketChannel = &marketChannel
 	}
-
-	// Save event to DB and emit to frontend immediately
-	s.saveAndEmit(event)
+	status.StoreError = s.storeError
+	return status
 }
 
-// matchesBasicFilter checks basic filter criteria (doesn't require wallet analysis)
-func (s *PolymarketService) matchesBasicFilter(event domain.PolymarketEvent, filter domain.PolymarketEventFilter) bool {
-	// Check minimum notional value (price * size)
-	notional := parseNotionalValue(event.Price, event.Size)
-	minSize := filter.MinSize
-	if minSize <= 0 {
-		minSize = s.config.MinTradeSize
-	}
-	if notional < minSize {
-		return false
-	}
-
-	// Check event types
-	if len(filter.EventTypes) > 0 {
-		found := false
-		for _, et := range filter.EventTypes {
-			if et == event.EventType {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
-	}
-
-	// Check side
-	if filter.Side != "" && string(event.Side) != string(filter.Side) {
-		return false
-	}
-
-	// Check price range
-	if event.Price != "" {
-		var price float64
-		parseFloat(event.Price, &price)
-		if filter.MinPrice > 0 && price < filter.MinPrice {
-			return false
-		}
-		if filter.MaxPrice > 0 && price > filter.MaxPrice {
-			return false
-		}
-	}
-
-	// Check market name (partial match)
-	if filter.MarketName != "" {
-		marketName := strings.ToLower(filter.MarketName)
-		eventMarket := strings.ToLower(event.MarketName)
-		eventTitle := strings.ToLower(event.EventTitle)
-		if !strings.Contains(eventMarket, marketName) && !strings.Contains(eventTitle, marketName) {
-			return false
-		}
-	}
-
-	return true
-}
-
-// walletAnalysisWorker periodically processes wallets in background
-func (s *PolymarketService) walletAnalysisWorker() {
-	log.Println("[PolymarketService] Starting wallet analysis worker")
-
-	// Process wallets every 10 seconds, batch of 10
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-s.stopCh:
-			log.Println("[PolymarketService] Wallet analysis worker stopped")
-			return
-		case <-ticker.C:
-			s.processWallets()
-		}
-	}
-}
-
-// processWallets fetches and updates wallet trade counts
-func (s *PolymarketService) processWallets() {
-	// Get wallets that need refresh (oldest analyzed first, includes unanalyzed)
-	addresses, err := s.store.GetWalletsForRefresh(10) // Process 10 at a time
-	if err != nil {
-		log.Printf("[PolymarketService] Failed to get wallets for refresh: %v", err)
-		return
-	}
-
-	if len(addresses) == 0 {
-		return
-	}
-
-	log.Printf("[PolymarketService] Refreshing %d wallets", len(addresses))
-
-	for _, address := range addresses {
-		// Check if stopped
-		select {
-		case <-s.stopCh:
-			return
-		default:
-		}
-
-		// Fetch fresh data from API (always re-fetch, ignore cache)
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		profile, err := s.walletAnalyzer.FetchAndUpdateWallet(ctx, address)
-		cancel()
-
-		if err != nil {
-			log.Printf("[PolymarketService] Failed to refresh wallet %s: %v", shortenAddress(address), err)
-			continue
-		}
-
-		if profile == nil || profile.BetCount < 0 {
-			log.Printf("[PolymarketService] Could not get stats for wallet %s", shortenAddress(address))
-			continue
-		}
-
-		// If fresh wallet, emit alert and update counter
-		if profile.IsFresh {
-			s.client.IncrementFreshWalletsFound()
-
-			log.Printf("[PolymarketService] FRESH WALLET DETECTED: %s (trades=%d, joinDate=%s, level=%s)",
-				shortenAddress(address),
-				profile.BetCount,
-				profile.JoinDate,
-				profile.FreshnessLevel)
-
-			// Emit fresh wallet alert with profile
-			s.eventBus.Emit("polymarket:fresh_wallet_detected", *profile)
-		}
-
-		// Small delay between API calls to avoid rate limiting
-		time.Sleep(500 * time.Millisecond)
-	}
-}
-
-func (s *PolymarketService) saveAndEmit(event domain.PolymarketEvent) {
-	// Save to database (async to avoid blocking)
-	go func(e domain.PolymarketEvent) {
-		if err := s.store.SaveEvent(e); err != nil {
-			log.Printf("[PolymarketService] Failed to save event: %v", err)
-		}
-	}(event)
-
-	// Emit to frontend for real-time updates
-	s.eventBus.Emit("polymarket:event", event)
+// SetStoreError reports that the service runs on the in-memory store because the
+// database could not be opened; the status shows it until restart
+func (s *PolymarketService) SetStoreError(reason string) {
+	s.mu.Lock()
+	s.storeError = reason
+	s.mu.Unlock()
 }
 
 // IsRunning returns whether the watcher is currently running
@@ -305,62 +293,16 @@ func (s *PolymarketService) IsRunning() bool {
 // Close shuts down the service
 func (s *PolymarketService) Close() {
 	s.Stop()
-	if s.store != nil {
-		s.store.Close()
-	}
-}
-
-// UpdateConfig updates the service configuration and saves to database
-func (s *PolymarketService) UpdateConfig(config domain.PolymarketConfig) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	s.config = config
-	s.walletAnalyzer = polymarket.NewWalletAnalyzer(config, s.store)
-
-	// Save to database
-	if err := s.store.SaveConfig(config); err != nil {
-		log.Printf("[PolymarketService] Failed to save config: %v", err)
-	} else {
-		log.Printf("[PolymarketService] Config saved to database: InsiderMax=%d, WalletMax=%d, NewbieMax=%d, CustomMax=%d",
-			config.FreshInsiderMaxBets, config.FreshWalletMaxBets, config.FreshNewbieMaxBets, config.CustomFreshMaxBets)
-	}
-}
 
-// GetConfig returns the current configuration
-func (s *PolymarketService) GetConfig() domain.PolymarketConfig {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.config
-}
+	// Let the writers drain queued events before the store goes away
+	s.writes.close()
+	s.writersWg.Wait()
 
-// SetSaveFilter sets the filter for saving events to database and persists it
-func (s *PolymarketService) SetSaveFilter(filter domain.PolymarketEventFilter) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.saveFilter = filter
-
-	// Save to database
-	if err := s.store.SaveFilter(filter); err != nil {
-		log.Printf("[PolymarketService] Failed to save filter: %v", err)
-	} else {
-		log.Printf("[PolymarketService] Filter saved to database: minSize=%.0f, side=%s, freshWalletsOnly=%v",
-			filter.MinSize, filter.Side, filter.FreshWalletsOnly)
+	if s.store != nil {
+		s.store.Close()
 	}
 }
 
-// GetSaveFilter returns the current save filter
-func (s *PolymarketService) GetSaveFilter() domain.PolymarketEventFilter {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.saveFilter
-}
-
-// GetWallets retrieves all wallets from the database
-func (s *PolymarketService) GetWallets(limit int) ([]domain.WalletProfile, error) {
-	return s.store.GetAllWallets(limit)
-}
-
 // Helper functions
 
 func shortenAddress(addr string) string {
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// unusualHourMinTrades is how many trades a wallet needs before its hours are judged
+	unusualHourMinTrades = 10
+
+	// unusualHourMaxShare is the highest share of the wallet's trades in the trade's hour
+	// (and the hours either side) that still counts as unusual
+	unusualHourMaxShare = 0.05
+
+	// unusualHourMinRatio is how many times the wallet's typical size the trade must be
+	unusualHourMinRatio = 2.0
+
+	// unusualHourScoreBoost raises the risk score of a trade that already has signals
+	unusualHourScoreBoost = 0.1
+)
+
+// hourAnomaly is a large trade placed at an hour the wallet rarely trades
+type hourAnomaly struct {
+	hour     int
+	share    float64 // Share of the wallet's earlier trades around this hour
+	trades   int
+	notional float64
+	typical  float64
+}
+
+// GetWalletActiveHours returns the hour-of-day profile of a wallet's trades
+func (s *PolymarketService) GetWalletActiveHours(address string) domain.WalletActiveHours {
+	s.sizeMu.Lock()
+	defer s.sizeMu.Unlock()
+
+	profile := domain.WalletActiveHours{Address: address}
+	if hours, ok := s.walletHours[strings.ToLower(address)]; ok {
+		for h, n := range hours {
+			profile.Hours[h] = int(n)
+			profile.Trades += int(n)
+		}
+	}
+	return profile
+}
+
+// observeTradeHour judges a trade's hour against the wallet's earlier trades, then adds
+// it to the profile. It must run before observeTradeSize so the wallet's typical size
+// excludes the trade. It returns nil unless the trade is large and at an unusual hour.
+func (s *PolymarketService) observeTradeHour(event domain.PolymarketEvent) *hourAnomaly {
+	if event.EventType != domain.PolymarketEventTrade || event.WalletAddress == "" || event.Timestamp.IsZero() {
+		return nil
+	}
+	notional := parseNotionalValue(event.Price, event.Size)
+	hour := event.Timestamp.UTC().Hour()
+
+	s.sizeMu.Lock()
+	defer s.sizeMu.Unlock()
+
+	key := strings.ToLower(event.WalletAddress)
+	hours, ok := s.walletHours[key]
+	if !ok {
+		if len(s.walletHours) >= maxSizeTrackedWallets {
+			s.walletHours = make(map[string]*[24]uint32)
+		}
+		hours = &[24]uint32{}
+		s.walletHours[key] = hours
+	}
+
+	var anomaly *hourAnomaly
+	total := 0
+	for _, n := range hours {
+		total += int(n)
+	}
+	if sizes, ok := s.walletSizes[key]; ok && total >= unusualHourMinTrades && sizes.n > 0 {
+		around := hours[(hour+23)%24] + hours[hour] + hours[(hour+1)%24]
+		share := float64(around) / float64(total)
+		typical := math.Exp(sizes.mean)
+		if share <= unusualHourMaxShare && notional >= unusualHourMinRatio*typical {
+			anomaly = &hourAnomaly{hour: hour, share: share, trades: total, notional: notional, typical: typical}
+		}
+	}
+
+	hours[hour]++
+	return anomaly
+}
+
+// applyUnusualHour adds an unusual hour risk signal. It is a confidence factor: the
+// score only rises for trades that already carry other signals.
+func (s *PolymarketService) applyUnusualHour(event *domain.PolymarketEvent, anomaly *hourAnomaly) {
+	if anomaly == nil {
+		return
+	}
+	message := fmt.Sprintf("Unusual hour for this wallet: %02d:00 UTC (%.0f%% of %d trades), $%.0f vs usual $%.0f",
+		anomaly.hour, anomaly.share*100, anomaly.trades, anomaly.notional, anomaly.typical)
+
+	if len(event.RiskSignals) > 0 && event.RiskScore > 0 {
+		event.RiskScore = math.Min(1, event.RiskScore+unusualHourScoreBoost)
+	}
+	event.RiskSignals = append(event.RiskSignals, "🌙 "+message)
+
+	if !event.Muted {
+		s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+			Detector: "unusual_hour",
+			Signal:   "unusual_hour",
+			Message:  message,
+			Score:    event.RiskScore,
+			Alert:    true,
+			Metadata: map[string]string{
+				"hour":            strconv.Itoa(anomaly.hour),
+				"hourShare":       strconv.FormatFloat(anomaly.share, 'f', 3, 64),
+				"priorTrades":     strconv.Itoa(anomaly.trades),
+				"typicalNotional": strconv.FormatFloat(anomaly.typical, 'f', 2, 64),
+			},
+			TradeID:       event.TradeID,
+			WalletAddress: event.WalletAddress,
+			MarketName:    event.MarketName,
+			MarketLink:    event.MarketLink,
+			Timestamp:     event.Timestamp,
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestUnusualHourForLargeTrades(t *testing.T) {
+	svc, _ := newTestService(t)
+	day := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	observe := func(hour int, notional float64) *hourAnomaly {
+		event := domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, WalletAddress: "0xAbc", Price: "0.5",
+			Size: fmt.Sprint(notional * 2), Timestamp: day.Add(time.Duration(hour) * time.Hour),
+		}
+		anomaly := svc.observeTradeHour(event)
+		svc.observeTradeSize(event)
+		return anomaly
+	}
+
+	// Office hours, 14:00-16:00 UTC
+	for i := range 20 {
+		if anomaly := observe(14+i%3, 100); anomaly != nil {
+			t.Fatalf("trade %d flagged: %+v", i, anomaly)
+		}
+	}
+	if anomaly := observe(15, 5000); anomaly != nil {
+		t.Errorf("large trade at a usual hour flagged: %+v", anomaly)
+	}
+	if anomaly := observe(3, 150); anomaly != nil {
+		t.Errorf("small trade at an unusual hour flagged: %+v", anomaly)
+	}
+
+	anomaly := observe(4, 5000) // 03:00 is 1 of 22 trades around 04:00
+	if anomaly == nil || anomaly.hour != 4 || anomaly.trades != 22 {
+		t.Fatalf("anomaly = %+v, want the large 04:00 trade flagged", anomaly)
+	}
+	if anomaly.share > unusualHourMaxShare+1e-9 || anomaly.share == 0 {
+		t.Errorf("share = %.3f, want the single nearby trade counted", anomaly.share)
+	}
+
+	// A confidence factor: it raises the score only of trades with other signals
+	plain := domain.PolymarketEvent{Muted: true}
+	svc.applyUnusualHour(&plain, anomaly)
+	flagged := domain.PolymarketEvent{RiskScore: 0.7, RiskSignals: []string{"🚨 Fresh Insider (0 bets)"}, Muted: true}
+	svc.applyUnusualHour(&flagged, anomaly)
+	if plain.RiskScore != 0 || len(plain.RiskSignals) != 1 || !strings.HasPrefix(plain.RiskSignals[0], "🌙 Unusual hour for this wallet: 04:00 UTC") {
+		t.Errorf("unflagged trade = %v, score %.2f, want the signal without a score", plain.RiskSignals, plain.RiskScore)
+	}
+	if flagged.RiskScore < 0.79 || len(flagged.RiskSignals) != 2 {
+		t.Errorf("flagged trade score = %.2f, want it raised by the unusual hour", flagged.RiskScore)
+	}
+
+	hours := svc.GetWalletActiveHours("0xabc")
+	if hours.Trades != 23 || hours.Hours[15] != 8 || hours.Hours[4] != 1 {
+		t.Errorf("active hours = %+v, want 23 trades with 8 at 15:00", hours)
+	}
+}
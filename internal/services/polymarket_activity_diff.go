@@ -0,0 +1,80 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetWalletActivitySinceAlert summarizes a wallet's stored trades since its last alert,
+// or returns nil if it was never alerted
+func (s *PolymarketService) GetWalletActivitySinceAlert(address string) (*domain.WalletActivityDiff, error) {
+	last, err := s.store.GetLastWalletAlert(address)
+	if err != nil || last == nil {
+		return nil, err
+	}
+	diff, err := s.store.GetWalletActivitySince(address, last.AlertedAt)
+	if err != nil {
+		return nil, err
+	}
+	diff.LastAlertMarket = last.MarketName
+	return diff, nil
+}
+
+// applyActivityDiff adds what a previously alerted wallet did since that alert to a
+// trade that will alert again, e.g. "+4 trades, +$32k volume, entered 2 new markets"
+func (s *PolymarketService) applyActivityDiff(event *domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || event.WalletAddress == "" || len(event.RiskSignals) == 0 || event.Muted {
+		return
+	}
+	s.mu.RLock()
+	minTradeSize, alertThreshold := s.config.MinTradeSize, s.config.AlertThreshold
+	s.mu.RUnlock()
+	if !meetsAlertThresholds(*event, minTradeSize, alertThreshold) {
+		return
+	}
+
+	diff, err := s.GetWalletActivitySinceAlert(event.WalletAddress)
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to diff activity of %s: %v", shortenAddress(event.WalletAddress), err)
+		return
+	}
+	if diff == nil || diff.Trades == 0 {
+		return
+	}
+	event.RiskSignals = append(event.RiskSignals, "🔁 "+formatActivityDiff(*diff, event.Timestamp))
+}
+
+// formatActivityDiff describes the activity since the last alert, as of now
+func formatActivityDiff(diff domain.WalletActivityDiff, now time.Time) string {
+	parts := []string{
+		fmt.Sprintf("+%d %s", diff.Trades, pluralize(diff.Trades, "trade", "trades")),
+		fmt.Sprintf("+%s volume", formatCompactUSD(diff.Volume)),
+	}
+	if diff.NewMarkets > 0 {
+		parts = append(parts, fmt.Sprintf("entered %d new %s", diff.NewMarkets, pluralize(diff.NewMarkets, "market", "markets")))
+	}
+	return fmt.Sprintf("Since last alert (%s ago): %s", formatAge(now.Sub(diff.LastAlertAt)), strings.Join(parts, ", "))
+}
+
+// formatAge formats a duration coarsely, e.g. "45m", "6h" or "3d"
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dm", max(1, int(d.Minutes())))
+	}
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestFormatActivityDiff(t *testing.T) {
+	now := time.Date(2026, 2, 5, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		diff domain.WalletActivityDiff
+		want string
+	}{
+		{
+			domain.WalletActivityDiff{LastAlertAt: now.Add(-72 * time.Hour), Trades: 4, Volume: 32000, NewMarkets: 2},
+			"Since last alert (3d ago): +4 trades, +$32k volume, entered 2 new markets",
+		},
+		{
+			domain.WalletActivityDiff{LastAlertAt: now.Add(-30 * time.Second), Trades: 1, Volume: 500},
+			"Since last alert (1m ago): +1 trade, +$500 volume",
+		},
+	} {
+		if got := formatActivityDiff(tc.diff, now); got != tc.want {
+			t.Errorf("formatActivityDiff(%+v) = %q, want %q", tc.diff, got, tc.want)
+		}
+	}
+}
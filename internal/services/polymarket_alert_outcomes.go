@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Alert follow-up polling
+	alertFollowUpInterval = 1 * time.Minute
+	alertFollowUpBatch    = 50
+	alertFollowUpMaxAge   = 48 * time.Hour // Alerts older than this are no longer followed up
+
+	// Signal quality defaults
+	defaultSignalQualityDays = 30
+	signalQualityScanLimit   = 10000
+)
+
+// GetAlertOutcomes returns the most recent alerts with their follow-up prices
+func (s *PolymarketService) GetAlertOutcomes(limit int) ([]domain.AlertOutcome, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	return s.store.GetAlertOutcomes(time.Time{}, limit)
+}
+
+// GetSignalQuality aggregates how often alerts of the last days were followed by a
+// price move in the trade's direction, per follow-up horizon
+func (s *PolymarketService) GetSignalQuality(days int) (*domain.SignalQuality, error) {
+	if days <= 0 {
+		days = defaultSignalQualityDays
+	}
+	since := time.Now().AddDate(0, 0, -days)
+	outcomes, err := s.store.GetAlertOutcomes(since, signalQualityScanLimit)
+	if err != nil {
+		return nil, err
+	}
+	quality := buildSignalQuality(outcomes)
+	quality.Since = since
+	return quality, nil
+}
+
+// trackAlert starts following up the price of a flagged trade that meets the alert thresholds
+func (s *PolymarketService) trackAlert(event domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || event.AssetID == "" || len(event.RiskSignals) == 0 || event.Muted {
+		return
+	}
+	s.mu.RLock()
+	config := s.config
+	s.mu.RUnlock()
+	if !meetsAlertThresholds(event, config.MinTradeSize, config.AlertThreshold) {
+		return
+	}
+
+	price, err := strconv.ParseFloat(event.Price, 64)
+	if err != nil || price <= 0 {
+		return
+	}
+
+	market := event.MarketName
+	if market == "" {
+		market = event.EventTitle
+	}
+	_, err = s.store.SaveAlertOutcome(domain.AlertOutcome{
+		TradeID:       event.TradeID,
+		AssetID:       event.AssetID,
+		WalletAddress: event.WalletAddress,
+		MarketName:    market,
+		MarketLink:    event.MarketLink,
+		Outcome:       event.Outcome,
+		Side:          event.Side,
+		EntryPrice:    price,
+		RiskScore:     event.RiskScore,
+		RiskSignals:   event.RiskSignals,
+		AlertedAt:     event.Timestamp,
+		ConfigHash:    s.configVersion(),
+	})
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to track alert: %v", err)
+	}
+}
+
+// alertFollowUpWorker periodically records follow-up prices of recent alerts
+func (s *PolymarketService) alertFollowUpWorker() {
+	ticker := time.NewTicker(alertFollowUpInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.recordAlertFollowUps()
+		}
+	}
+}
+
+// recordAlertFollowUps fetches the price of every alert horizon that has come due
+func (s *PolymarketService) recordAlertFollowUps() {
+	now := time.Now()
+	pending, err := s.store.GetPendingAlertOutcomes(now.Add(-alertFollowUpMaxAge), alertFollowUpBatch)
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to load pending alerts: %v", err)
+		return
+	}
+
+	for _, outcome := range pending {
+		updated := false
+		for _, horizon := range domain.AlertHorizons {
+			at := outcome.AlertedAt.Add(horizon.Duration())
+			if _, recorded := outcome.Prices[horizon]; recorded || at.After(now) {
+				continue
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			price, ok, err := s.prices.GetPriceAt(ctx, outcome.AssetID, at)
+			cancel()
+			if err != nil {
+				log.Printf("[PolymarketService] Failed to fetch price of alert #%d: %v", outcome.ID, err)
+				s.errReporter.Report("alert outcomes", err)
+				break
+			}
+			if !ok {
+				continue
+			}
+			if err := s.store.RecordAlertPrice(outcome.ID, horizon, price); err != nil {
+				log.Printf("[PolymarketService] Failed to record price of alert #%d: %v", outcome.ID, err)
+				break
+			}
+			outcome.Prices[horizon] = price
+			updated = true
+		}
+		if updated {
+			s.eventBus.Emit("polymarket:alert_outcome_updated", outcome)
+		}
+	}
+}
+
+// buildSignalQuality aggregates follow-up prices per horizon
+func buildSignalQuality(outcomes []domain.AlertOutcome) *domain.SignalQuality {
+	quality := &domain.SignalQuality{
+		Alerts:      len(outcomes),
+		Horizons:    make([]domain.HorizonQuality, 0, len(domain.AlertHorizons)),
+		GeneratedAt: time.Now(),
+	}
+	for _, horizon := range domain.AlertHorizons {
+		h := domain.HorizonQuality{Horizon: horizon}
+		var totalMove float64
+		for _, o := range outcomes {
+			move, ok := o.Move(horizon)
+			if !ok {
+				continue
+			}
+			h.Evaluated++
+			totalMove += move
+			if move > 0 {
+				h.Favorable++
+			}
+		}
+		if h.Evaluated > 0 {
+			h.HitRate = float64(h.Favorable) / float64(h.Evaluated)
+			h.AvgMove = totalMove / float64(h.Evaluated)
+		}
+		quality.Horizons = append(quality.Horizons, h)
+	}
+	return quality
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestBuildSignalQuality(t *testing.T) {
+	quality := buildSignalQuality([]domain.AlertOutcome{
+		{Side: domain.OrderSideBuy, EntryPrice: 0.4, Prices: map[domain.AlertHorizon]float64{domain.AlertHorizon15m: 0.5, domain.AlertHorizon1h: 0.3}},
+		{Side: domain.OrderSideSell, EntryPrice: 0.4, Prices: map[domain.AlertHorizon]float64{domain.AlertHorizon15m: 0.3}},
+		{Side: domain.OrderSideBuy, EntryPrice: 0.4, Prices: map[domain.AlertHorizon]float64{}},
+	})
+	if quality.Alerts != 3 || len(quality.Horizons) != 3 {
+		t.Fatalf("quality = %+v, want 3 alerts over 3 horizons", quality)
+	}
+	for i, want := range []domain.HorizonQuality{
+		{Horizon: domain.AlertHorizon15m, Evaluated: 2, Favorable: 2, HitRate: 1, AvgMove: 0.1},
+		{Horizon: domain.AlertHorizon1h, Evaluated: 1, Favorable: 0, HitRate: 0, AvgMove: -0.1},
+		{Horizon: domain.AlertHorizon24h},
+	} {
+		got := quality.Horizons[i]
+		if got.Horizon != want.Horizon || got.Evaluated != want.Evaluated || got.Favorable != want.Favorable ||
+			got.HitRate != want.HitRate || math.Abs(got.AvgMove-want.AvgMove) > 1e-9 {
+			t.Errorf("horizon %s = %+v, want %+v", want.Horizon, got, want)
+		}
+	}
+}
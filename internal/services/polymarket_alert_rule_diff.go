@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// diffAlertRules lists the changes importing a rule set makes to the current one.
+// Rules have no identity, so an edited rule shows as one removed and one added.
+func diffAlertRules(current, imported domain.AlertRuleSet) []domain.AlertRuleChange {
+	changes := []domain.AlertRuleChange{}
+	if imported.Thresholds != nil {
+		old, updated := *current.Thresholds, *imported.Thresholds
+		for _, f := range []struct {
+			name     string
+			old, new float64
+		}{
+			{"min_trade_size", old.MinTradeSize, updated.MinTradeSize},
+			{"alert_threshold", old.AlertThreshold, updated.AlertThreshold},
+			{"fresh_insider_max_bets", float64(old.FreshInsiderMaxBets), float64(updated.FreshInsiderMaxBets)},
+			{"fresh_wallet_max_bets", float64(old.FreshWalletMaxBets), float64(updated.FreshWalletMaxBets)},
+			{"fresh_newbie_max_bets", float64(old.FreshNewbieMaxBets), float64(updated.FreshNewbieMaxBets)},
+			{"custom_fresh_max_bets", float64(old.CustomFreshMaxBets), float64(updated.CustomFreshMaxBets)},
+		} {
+			if f.old != f.new {
+				changes = append(changes, domain.AlertRuleChange{
+					Section: "thresholds", Action: domain.AlertRuleChanged, Item: f.name,
+					Old: strconv.FormatFloat(f.old, 'f', -1, 64), New: strconv.FormatFloat(f.new, 'f', -1, 64),
+				})
+			}
+		}
+	}
+	if imported.TagRules != nil {
+		changes = append(changes, diffRuleItems("tag_rules", summarizeRules(current.TagRules, tagRuleSummary), summarizeRules(imported.TagRules, tagRuleSummary))...)
+	}
+	if imported.LateEntryRules != nil {
+		changes = append(changes, diffRuleItems("late_entry_rules", summarizeRules(current.LateEntryRules, lateEntryRuleSummary), summarizeRules(imported.LateEntryRules, lateEntryRuleSummary))...)
+	}
+	if imported.Watchlist != nil {
+		changes = append(changes, diffRuleItems("watchlist", current.Watchlist, imported.Watchlist)...)
+	}
+	for _, route := range imported.Routes {
+		for _, bot := range current.Routes {
+			if strings.EqualFold(bot.Bot, route.Bot) && routeTypes(bot.Types) != routeTypes(route.Types) {
+				changes = append(changes, domain.AlertRuleChange{
+					Section: "routes", Action: domain.AlertRuleChanged, Item: bot.Bot,
+					Old: routeTypes(bot.Types), New: routeTypes(route.Types),
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// diffRuleItems lists the items only in old as removed and those only in updated as added
+func diffRuleItems(section string, old, updated []string) []domain.AlertRuleChange {
+	var changes []domain.AlertRuleChange
+	for _, item := range old {
+		if !slices.Contains(updated, item) {
+			changes = append(changes, domain.AlertRuleChange{Section: section, Action: domain.AlertRuleRemoved, Item: item})
+		}
+	}
+	for _, item := range updated {
+		if !slices.Contains(old, item) {
+			changes = append(changes, domain.AlertRuleChange{Section: section, Action: domain.AlertRuleAdded, Item: item})
+		}
+	}
+	return changes
+}
+
+// summarizeRules describes each rule of a list
+func summarizeRules[T any](items []T, summary func(T) string) []string {
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		out = append(out, summary(item))
+	}
+	return out
+}
+
+// tagRuleSummary describes a tag rule as its tag and conditions
+func tagRuleSummary(rule domain.EventTagRule) string {
+	var conditions []string
+	if rule.MarketName != "" {
+		conditions = append(conditions, fmt.Sprintf("market_name=%q", rule.MarketName))
+	}
+	if rule.MarketSlug != "" {
+		conditions = append(conditions, "market_slug="+rule.MarketSlug)
+	}
+	if rule.WalletAddress != "" {
+		conditions = append(conditions, "wallet_address="+rule.WalletAddress)
+	}
+	if rule.MinNotional > 0 {
+		conditions = append(conditions, "min_notional="+strconv.FormatFloat(rule.MinNotional, 'f', -1, 64))
+	}
+	if rule.MinRiskScore > 0 {
+		conditions = append(conditions, "min_risk_score="+strconv.FormatFloat(rule.MinRiskScore, 'f', -1, 64))
+	}
+	if rule.Entity != "" {
+		conditions = append(conditions, fmt.Sprintf("entity=%q", rule.Entity))
+	}
+	return rule.Tag + ": " + strings.Join(conditions, " ")
+}
+
+// lateEntryRuleSummary describes a late entry rule as its category, window and notional
+func lateEntryRuleSummary(rule domain.LateEntryRule) string {
+	category := rule.Category
+	if category == "" {
+		category = "any category"
+	}
+	return fmt.Sprintf("%s: window_hours=%s min_notional=%s", category,
+		strconv.FormatFloat(rule.WindowHours, 'f', -1, 64), strconv.FormatFloat(rule.MinNotional, 'f', -1, 64))
+}
+
+// routeTypes lists routed notification types, "all" for none
+func routeTypes(types []domain.NotificationEventType) string {
+	if len(types) == 0 {
+		return "all"
+	}
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, string(t))
+	}
+	slices.Sort(names)
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// maxAlertRuleBytes bounds the size of alert rule files read on import
+const maxAlertRuleBytes = 1 << 20
+
+// ExportAlertRules writes the alert thresholds, tag and late entry rules, the default
+// user's watchlist and the bot routes of the notification config to
+// exports/alert-rules-*.yaml
+func (s *PolymarketService) ExportAlertRules(notifications domain.NotificationConfig) (*domain.AlertRuleExport, error) {
+	set, err := s.currentAlertRules(notifications)
+	if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(set)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(filepath.Dir(s.dbPath), "exports")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	// Exports in the same second get a random suffix instead of overwriting each other
+	f, err := os.CreateTemp(dir, fmt.Sprintf("alert-rules-%s-*.yaml", time.Now().Format("20060102-150405")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alert rule file: %w", err)
+	}
+	path := f.Name()
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write alert rules: %w", err)
+	}
+	log.Printf("[PolymarketService] Exported alert rules to %s", path)
+	return &domain.AlertRuleExport{Path: path, YAML: string(data)}, nil
+}
+
+// PreviewAlertRules reads and validates an alert rule file and lists what importing it
+// would change, without applying anything
+func (s *PolymarketService) PreviewAlertRules(path string, notifications domain.NotificationConfig) (*domain.AlertRuleSet, []domain.AlertRuleChange, error) {
+	current, err := s.currentAlertRules(notifications)
+	if err != nil {
+		return nil, nil, err
+	}
+	set, err := readAlertRules(path, *current.Thresholds)
+	if err != nil {
+		return nil, nil, err
+	}
+	if set.Routes != nil {
+		routed := notifications
+		if err := domain.ApplyRoutes(&routed, set.Routes); err != nil {
+			return nil, nil, err
+		}
+	}
+	return set, diffAlertRules(current, *set), nil
+}
+
+// ApplyAlertRules applies the sections present in a rule set checked by
+// PreviewAlertRules. Routes belong to the notification config and are left to the caller.
+func (s *PolymarketService) ApplyAlertRules(set domain.AlertRuleSet) error {
+	if set.Thresholds != nil {
+		s.mu.RLock()
+		config := s.config
+		s.mu.RUnlock()
+		set.Thresholds.Apply(&config)
+		s.UpdateConfig(config)
+	}
+	if set.TagRules != nil {
+		if err := s.SetTagRules(set.TagRules); err != nil {
+			return err
+		}
+	}
+	if set.LateEntryRules != nil {
+		if err := s.SetLateEntryRules(set.LateEntryRules); err != nil {
+			return err
+		}
+	}
+	if set.Watchlist != nil {
+		err := s.updateUserSettings(domain.DefaultUserID, func(settings *domain.UserSettings) bool {
+			settings.Watchlist = set.Watchlist
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Printf("[PolymarketService] Imported alert rules")
+	return nil
+}
+
+// currentAlertRules returns the rule set in effect, with the routes of a notification config
+func (s *PolymarketService) currentAlertRules(notifications domain.NotificationConfig) (domain.AlertRuleSet, error) {
+	s.mu.RLock()
+	thresholds := domain.AlertThresholdsOf(s.config)
+	tagRules := append([]domain.EventTagRule{}, s.tagRules...)
+	lateEntryRules := append([]domain.LateEntryRule{}, s.lateEntryRules...)
+	s.mu.RUnlock()
+
+	settings, err := s.GetUserSettings(domain.DefaultUserID)
+	if err != nil {
+		return domain.AlertRuleSet{}, err
+	}
+	return domain.AlertRuleSet{
+		Version:        domain.AlertRuleSetVersion,
+		Thresholds:     &thresholds,
+		TagRules:       tagRules,
+		LateEntryRules: lateEntryRules,
+		Watchlist:      settings.Watchlist,
+		Routes:         domain.AlertRoutesOf(notifications),
+	}, nil
+}
+
+// readAlertRules parses an alert rule file, rejecting unknown fields so typos don't
+// silently drop a rule, and normalizes its rules the way the settings forms do.
+// Thresholds the file leaves out keep their current values.
+func readAlertRules(path string, thresholds domain.AlertThresholds) (*domain.AlertRuleSet, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxAlertRuleBytes {
+		return nil, fmt.Errorf("alert rule file is larger than %d MB", maxAlertRuleBytes>>20)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	set := domain.AlertRuleSet{Thresholds: &thresholds}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&set); err != nil {
+		return nil, fmt.Errorf("not an alert rule file: %w", err)
+	}
+	if set.Version != domain.AlertRuleSetVersion {
+		return nil, fmt.Errorf("unsupported alert rule file version %d (want %d)", set.Version, domain.AlertRuleSetVersion)
+	}
+
+	if set.Thresholds != nil {
+		if err := set.Thresholds.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if set.TagRules != nil {
+		if set.TagRules, err = normalizeTagRules(set.TagRules); err != nil {
+			return nil, err
+		}
+	}
+	if set.LateEntryRules != nil {
+		if set.LateEntryRules, err = normalizeLateEntryRules(set.LateEntryRules); err != nil {
+			return nil, err
+		}
+	}
+	if set.Watchlist != nil {
+		watchlist := []string{}
+		for _, address := range set.Watchlist {
+			address = strings.ToLower(strings.TrimSpace(address))
+			if !validWalletAddress(address) {
+				return nil, fmt.Errorf("watchlist: invalid wallet address %q", address)
+			}
+			if !slices.Contains(watchlist, address) {
+				watchlist = append(watchlist, address)
+			}
+		}
+		set.Watchlist = watchlist
+	}
+	return &set, nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestAlertRulesRoundTripThroughYAML(t *testing.T) {
+	svc, dbPath := newSQLiteTestService(t, "")
+	const wallet = "0x00000000000000000000000000000000000000a1"
+	notifications := domain.NotificationConfig{TelegramBots: []domain.TelegramBotConfig{
+		{Name: "whales", Token: "123:abc", ChatIDs: []string{"1"}},
+	}}
+	if err := svc.SetTagRules([]domain.EventTagRule{{Tag: "fed", MarketName: "Fed"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	export, err := svc.ExportAlertRules(notifications)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(export.Path) != filepath.Join(filepath.Dir(dbPath), "exports") || !strings.Contains(export.YAML, "tag: fed") {
+		t.Fatalf("export = %+v, want the tag rule in exports/", export)
+	}
+	if strings.Contains(export.YAML, "123:abc") {
+		t.Error("exported a bot token")
+	}
+
+	// An unchanged export imports without changes
+	if _, changes, err := svc.PreviewAlertRules(export.Path, notifications); err != nil || len(changes) != 0 {
+		t.Errorf("previewing the export = %+v, %v, want no changes", changes, err)
+	}
+
+	write := func(yaml string) string {
+		path := filepath.Join(t.TempDir(), "rules.yaml")
+		if err := os.WriteFile(path, []byte(yaml), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	set, changes, err := svc.PreviewAlertRules(write(`version: 1
+thresholds:
+  min_trade_size: 5000
+tag_rules:
+  - tag: cpi
+    market_name: CPI
+watchlist: [" `+strings.ToUpper(wallet)+`"]
+routes:
+  - bot: Whales
+    types: [fresh_wallet]
+`), notifications)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary []string
+	for _, c := range changes {
+		summary = append(summary, c.Section+" "+c.Action+" "+c.Item)
+	}
+	if want := []string{
+		"thresholds changed min_trade_size",
+		`tag_rules removed fed: market_name="Fed"`,
+		`tag_rules added cpi: market_name="CPI"`,
+		"watchlist added " + wallet,
+		"routes changed whales",
+	}; strings.Join(summary, "|") != strings.Join(want, "|") {
+		t.Errorf("changes = %q, want %q", summary, want)
+	}
+	if set.LateEntryRules != nil || set.Thresholds.AlertThreshold != svc.GetConfig().AlertThreshold {
+		t.Errorf("set = %+v, want the sections left out untouched", set)
+	}
+
+	if err := svc.ApplyAlertRules(*set); err != nil {
+		t.Fatal(err)
+	}
+	if svc.GetConfig().MinTradeSize != 5000 {
+		t.Errorf("min trade size = %v, want 5000", svc.GetConfig().MinTradeSize)
+	}
+	settings, _ := svc.GetUserSettings(domain.DefaultUserID)
+	if len(settings.Watchlist) != 1 || settings.Watchlist[0] != wallet {
+		t.Errorf("watchlist = %v, want the imported wallet normalized", settings.Watchlist)
+	}
+
+	for name, yaml := range map[string]string{
+		"unknown field":   "version: 1\nthreshold:\n  min_trade_size: 1\n",
+		"unknown version": "version: 2\n",
+		"bad threshold":   "version: 1\nthresholds:\n  alert_threshold: 2\n",
+		"bad wallet":      "version: 1\nwatchlist: [0x123]\n",
+		"unknown bot":     "version: 1\nroutes:\n  - bot: dolphins\n",
+		"default bot":     "version: 1\nroutes:\n  - bot: " + domain.DefaultTelegramBot + "\n",
+	} {
+		if _, _, err := svc.PreviewAlertRules(write(yaml), notifications); err == nil {
+			t.Errorf("%s: previewed without an error", name)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// configAuditSettingKey is the settings key the threshold audit trail is persisted under
+	configAuditSettingKey = "config_audit_trail"
+
+	// maxConfigAuditEntries bounds the persisted audit trail
+	maxConfigAuditEntries = 500
+)
+
+// GetConfigAuditTrail returns recorded threshold changes, newest first
+func (s *PolymarketService) GetConfigAuditTrail(limit int) []domain.ConfigChange {
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	var trail []domain.ConfigChange
+	if err := s.store.LoadSetting(configAuditSettingKey, &trail); err != nil {
+		return []domain.ConfigChange{}
+	}
+	if limit > 0 && len(trail) > limit {
+		trail = trail[:limit]
+	}
+	return trail
+}
+
+// recordConfigChanges appends the thresholds that differ between two configs to the audit trail
+func (s *PolymarketService) recordConfigChanges(source, reason string, old, updated domain.PolymarketConfig) {
+	changes := configThresholdChanges(old, updated)
+	if len(changes) == 0 {
+		return
+	}
+
+	now := time.Now()
+	for i := range changes {
+		changes[i].Timestamp = now
+		changes[i].Source = source
+		changes[i].Reason = reason
+	}
+
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	var trail []domain.ConfigChange
+	s.store.LoadSetting(configAuditSettingKey, &trail)
+	trail = append(changes, trail...)
+	if len(trail) > maxConfigAuditEntries {
+		trail = trail[:maxConfigAuditEntries]
+	}
+	if err := s.store.SaveSetting(configAuditSettingKey, trail); err != nil {
+		log.Printf("[PolymarketService] Failed to save config audit trail: %v", err)
+		return
+	}
+	s.eventBus.Emit("polymarket:config_changed", changes)
+}
+
+// configThresholdChanges lists the numeric thresholds that differ between two configs
+func configThresholdChanges(old, updated domain.PolymarketConfig) []domain.ConfigChange {
+	fields := []struct {
+		name     string
+		old, new float64
+	}{
+		{"minTradeSize", old.MinTradeSize, updated.MinTradeSize},
+		{"alertThreshold", old.AlertThreshold, updated.AlertThreshold},
+		{"freshInsiderMaxBets", float64(old.FreshInsiderMaxBets), float64(updated.FreshInsiderMaxBets)},
+		{"freshWalletMaxBets", float64(old.FreshWalletMaxBets), float64(updated.FreshWalletMaxBets)},
+		{"freshNewbieMaxBets", float64(old.FreshNewbieMaxBets), float64(updated.FreshNewbieMaxBets)},
+		{"customFreshMaxBets", float64(old.CustomFreshMaxBets), float64(updated.CustomFreshMaxBets)},
+		{"hotHandMinStreak", float64(old.HotHandMinStreak), float64(updated.HotHandMinStreak)},
+		{"hotHandMaxPrice", old.HotHandMaxPrice, updated.HotHandMaxPrice},
+	}
+
+	var changes []domain.ConfigChange
+	for _, f := range fields {
+		if f.old != f.new {
+			changes = append(changes, domain.ConfigChange{Field: f.name, OldValue: f.old, NewValue: f.new})
+		}
+	}
+	return changes
+}
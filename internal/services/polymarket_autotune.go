@@ -0,0 +1,212 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// autoTuneSettingKey is the settings key auto-tune settings are persisted under
+	autoTuneSettingKey = "auto_tune_settings"
+
+	// autoTuneInterval is how often thresholds are re-tuned while auto-tune is enabled
+	autoTuneInterval = 6 * time.Hour
+
+	// autoTuneScanLimit bounds how many recent trades are replayed
+	autoTuneScanLimit = 20000
+)
+
+// Candidate thresholds auto-tune chooses from
+var (
+	autoTuneMinTradeSizes   = []float64{100, 250, 500, 1000, 2500, 5000, 10000, 25000, 50000}
+	autoTuneAlertThresholds = []float64{0.5, 0.6, 0.7, 0.8, 0.9, 0.95}
+)
+
+// SetAutoTune saves the auto-tune settings
+func (s *PolymarketService) SetAutoTune(settings domain.AutoTuneSettings) error {
+	if settings.TargetAlertsPerDay <= 0 {
+		return fmt.Errorf("target alerts per day must be positive")
+	}
+	if settings.LookbackDays <= 0 {
+		return fmt.Errorf("lookback days must be positive")
+	}
+	if settings.MinTradeSizeFloor <= 0 || settings.MinTradeSizeCeiling < settings.MinTradeSizeFloor {
+		return fmt.Errorf("invalid MinTradeSize bounds: %.0f-%.0f", settings.MinTradeSizeFloor, settings.MinTradeSizeCeiling)
+	}
+
+	if err := s.store.SaveSetting(autoTuneSettingKey, settings); err != nil {
+		return fmt.Errorf("failed to save auto-tune settings: %w", err)
+	}
+
+	s.mu.Lock()
+	s.autoTune = settings
+	s.mu.Unlock()
+
+	log.Printf("[PolymarketService] Auto-tune settings saved: enabled=%v target=%.1f/day", settings.Enabled, settings.TargetAlertsPerDay)
+	return nil
+}
+
+// GetAutoTune returns the auto-tune settings
+func (s *PolymarketService) GetAutoTune() domain.AutoTuneSettings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.autoTune
+}
+
+// GetLastAutoTune returns the result of the most recent auto-tune run, or nil
+func (s *PolymarketService) GetLastAutoTune() *domain.AutoTuneResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastAutoTune
+}
+
+// RunAutoTune replays recent trades and sets the MinTradeSize and alert threshold whose
+// alert rate comes closest to the target without exceeding it. Locked thresholds are kept.
+// It runs regardless of whether scheduled auto-tuning is enabled.
+func (s *PolymarketService) RunAutoTune() (*domain.AutoTuneResult, error) {
+	s.mu.RLock()
+	settings := s.autoTune
+	config := s.config
+	s.mu.RUnlock()
+
+	cutoff := time.Now().AddDate(0, 0, -settings.LookbackDays)
+	events, err := s.store.GetEvents(domain.PolymarketEventFilter{
+		EventTypes: []domain.PolymarketEventType{domain.PolymarketEventTrade},
+		Limit:      autoTuneScanLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trades: %w", err)
+	}
+
+	var flagged []domain.PolymarketEvent
+	oldest := time.Now()
+	for _, e := range events {
+		if e.Timestamp.Before(cutoff) {
+			continue
+		}
+		if e.Timestamp.Before(oldest) {
+			oldest = e.Timestamp
+		}
+		if len(e.RiskSignals) > 0 {
+			flagged = append(flagged, e)
+		}
+	}
+	days := math.Max(1, time.Since(oldest).Hours()/24)
+
+	rate := func(minSize, threshold float64) float64 {
+		count := 0
+		for _, e := range flagged {
+			if meetsAlertThresholds(e, minSize, threshold) {
+				count++
+			}
+		}
+		return float64(count) / days
+	}
+
+	sizes := []float64{config.MinTradeSize}
+	if !settings.LockMinTradeSize {
+		sizes = autoTuneCandidates(autoTuneMinTradeSizes, settings.MinTradeSizeFloor, settings.MinTradeSizeCeiling)
+	}
+	thresholds := []float64{config.AlertThreshold}
+	if !settings.LockAlertThreshold {
+		thresholds = autoTuneAlertThresholds
+	}
+
+	result := &domain.AutoTuneResult{
+		RunAt:          time.Now(),
+		Days:           days,
+		AlertsPerDay:   rate(config.MinTradeSize, config.AlertThreshold),
+		MinTradeSize:   sizes[len(sizes)-1],
+		AlertThreshold: thresholds[len(thresholds)-1],
+	}
+	result.ProjectedAlertsPerDay = rate(result.MinTradeSize, result.AlertThreshold)
+
+	// Pick the combination that comes closest to the target without exceeding it,
+	// preferring the lower threshold and then the smaller size on ties
+	for _, threshold := range thresholds {
+		for _, size := range sizes {
+			r := rate(size, threshold)
+			if r > settings.TargetAlertsPerDay || (result.TargetMet && r <= result.ProjectedAlertsPerDay) {
+				continue
+			}
+			result.MinTradeSize, result.AlertThreshold, result.ProjectedAlertsPerDay = size, threshold, r
+			result.TargetMet = true
+		}
+	}
+
+	if result.MinTradeSize != config.MinTradeSize || result.AlertThreshold != config.AlertThreshold {
+		result.Changed = true
+		config.MinTradeSize = result.MinTradeSize
+		config.AlertThreshold = result.AlertThreshold
+		reason := fmt.Sprintf("%.1f alerts/day over %.1f days, target <= %.1f, projected %.1f",
+			result.AlertsPerDay, days, settings.TargetAlertsPerDay, result.ProjectedAlertsPerDay)
+		s.applyConfig(config, domain.ConfigChangeSourceAutoTune, reason)
+		log.Printf("[PolymarketService] Auto-tune: MinTradeSize=%.0f AlertThreshold=%.2f (%s)",
+			config.MinTradeSize, config.AlertThreshold, reason)
+	}
+
+	s.mu.Lock()
+	s.lastAutoTune = result
+	s.mu.Unlock()
+	s.eventBus.Emit("polymarket:auto_tune", result)
+	return result, nil
+}
+
+// autoTuneWorker re-tunes thresholds periodically while auto-tune is enabled
+func (s *PolymarketService) autoTuneWorker() {
+	ticker := time.NewTicker(autoTuneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			if !s.GetAutoTune().Enabled {
+				continue
+			}
+			if _, err := s.RunAutoTune(); err != nil {
+				log.Printf("[PolymarketService] Auto-tune failed: %v", err)
+			}
+		}
+	}
+}
+
+// meetsAlertThresholds reports whether a flagged trade is large enough to alert on and,
+// if its signals carry a risk score, whether the score reaches the alert threshold
+func meetsAlertThresholds(event domain.PolymarketEvent, minTradeSize, alertThreshold float64) bool {
+	if parseNotionalValue(event.Price, event.Size) < minTradeSize {
+		return false
+	}
+	return event.RiskScore == 0 || event.RiskScore >= alertThreshold
+}
+
+// autoTuneCandidates returns the candidates within bounds, including the bounds themselves
+func autoTuneCandidates(candidates []float64, floor, ceiling float64) []float64 {
+	result := []float64{floor}
+	for _, c := range candidates {
+		if c > floor && c < ceiling {
+			result = append(result, c)
+		}
+	}
+	if ceiling > floor {
+		result = append(result, ceiling)
+	}
+	return result
+}
+
+// loadAutoTune restores persisted auto-tune settings, falling back to defaults
+func (s *PolymarketService) loadAutoTune() {
+	settings := domain.DefaultAutoTuneSettings()
+	if err := s.store.LoadSetting(autoTuneSettingKey, &settings); err != nil {
+		settings = domain.DefaultAutoTuneSettings()
+	}
+
+	s.mu.Lock()
+	s.autoTune = settings
+	s.mu.Unlock()
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestRunAutoTuneTargetsAlertRate(t *testing.T) {
+	svc, _ := newTestService(t)
+	config := svc.GetConfig()
+	config.MinTradeSize, config.AlertThreshold = 100, 0.5
+	svc.UpdateConfig(config)
+
+	// 14 flagged trades in the last day: 10 of $300, 3 of $3,000 and one of $30,000
+	// whose score clears only the lowest thresholds
+	trade := func(i int, size string, score float64) domain.PolymarketEvent {
+		return domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("tx%d", i), Price: "0.5", Size: size,
+			RiskScore: score, RiskSignals: []string{"🚨 Fresh Insider (0 bets)"}, Timestamp: time.Now().Add(-12 * time.Hour),
+		}
+	}
+	var events []domain.PolymarketEvent
+	for i := range 10 {
+		events = append(events, trade(i, "600", 0.9))
+	}
+	for i := range 3 {
+		events = append(events, trade(10+i, "6000", 0.9))
+	}
+	events = append(events, trade(13, "60000", 0.6))
+	if err := svc.store.SaveEvents(events); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := domain.DefaultAutoTuneSettings()
+	settings.TargetAlertsPerDay = 0
+	if err := svc.SetAutoTune(settings); err == nil {
+		t.Error("auto-tune without a target was saved")
+	}
+	settings.TargetAlertsPerDay = 4
+	if err := svc.SetAutoTune(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := svc.RunAutoTune()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Changed || !result.TargetMet || result.AlertsPerDay != 14 || result.ProjectedAlertsPerDay != 4 ||
+		result.MinTradeSize != 500 || result.AlertThreshold != 0.5 {
+		t.Errorf("result = %+v, want MinTradeSize 500 projecting 4 of 14 alerts/day", result)
+	}
+	if config := svc.GetConfig(); config.MinTradeSize != 500 {
+		t.Errorf("MinTradeSize = %.0f, want the tuned 500", config.MinTradeSize)
+	}
+	trail := svc.GetConfigAuditTrail(0)
+	if len(trail) != 2 || trail[0].Source != domain.ConfigChangeSourceAutoTune || trail[0].Field != "minTradeSize" || trail[0].OldValue != 100 {
+		t.Errorf("audit trail = %+v, want the auto-tuned MinTradeSize after the manual setup", trail)
+	}
+
+	// A locked MinTradeSize keeps the manual value
+	config = svc.GetConfig()
+	config.MinTradeSize = 5000
+	svc.UpdateConfig(config)
+	settings.LockMinTradeSize = true
+	settings.TargetAlertsPerDay = 0.5
+	svc.SetAutoTune(settings)
+	result, _ = svc.RunAutoTune()
+	if result.MinTradeSize != 5000 || result.AlertThreshold != 0.7 || !result.TargetMet {
+		t.Errorf("result = %+v, want the locked MinTradeSize and a stricter threshold", result)
+	}
+	if trail := svc.GetConfigAuditTrail(0); len(trail) != 4 || trail[1].Source != domain.ConfigChangeSourceUser {
+		t.Errorf("audit trail = %+v, want the manual change between the auto-tuned ones", trail)
+	}
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Database write queue
+	eventWriteQueueCapacity = 5000
+
+	// Batched writes when the config leaves them unset: a batch is written once it holds
+	// defaultEventBatchSize events or its first event waited defaultEventFlushInterval
+	defaultEventBatchSize     = 200
+	defaultEventFlushInterval = 500 * time.Millisecond
+
+	// Back-pressure monitoring
+	backPressureCheckInterval = 10 * time.Second
+	backPressureHighWater     = 0.8         // Queue fill ratio that counts as back-pressure
+	backPressureAlertAfter    = time.Minute // Sustained back-pressure before the operator is alerted
+)
+
+// startEventWriters starts the worker that drains the write queue into the store. A
+// single writer batches inserts into transactions, which SQLite handles far better than
+// concurrent single-row writes.
+func (s *PolymarketService) startEventWriters() {
+	s.writersWg.Add(1)
+	go s.eventWriter()
+}
+
+// eventWriter saves queued events in batches until the queue is closed and drained,
+// so Close persists every queued event
+func (s *PolymarketService) eventWriter() {
+	defer s.writersWg.Done()
+	for {
+		size, interval := s.eventBatchSettings()
+		batch, ok := s.writes.popBatch(size, interval)
+		if !ok {
+			return
+		}
+		s.saveEventBatch(batch)
+		for _, event := range batch {
+			s.trackAlert(event)
+			s.sinkToSheets(event)
+			s.syncAlertCase(event)
+			s.publishTradingViewAlert(event)
+		}
+		s.writes.done(len(batch))
+	}
+}
+
+// saveEventBatch writes a batch in one transaction. If the transaction fails, events are
+// saved one by one so a single bad event doesn't lose the rest.
+func (s *PolymarketService) saveEventBatch(batch []domain.PolymarketEvent) {
+	err := s.store.SaveEvents(batch)
+	if err == nil {
+		return
+	}
+	if len(batch) > 1 {
+		log.Printf("[PolymarketService] Failed to save batch of %d events, retrying one by one: %v", len(batch), err)
+		for _, event := range batch {
+			if err = s.store.SaveEvent(event); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to save event: %v", err)
+		s.errReporter.Report("event writer", err)
+	}
+}
+
+// eventBatchSettings returns the configured batch size and flush interval
+func (s *PolymarketService) eventBatchSettings() (int, time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	size, interval := s.config.EventBatchSize, time.Duration(s.config.EventFlushIntervalMs)*time.Millisecond
+	if size <= 0 {
+		size = defaultEventBatchSize
+	}
+	if interval <= 0 {
+		interval = defaultEventFlushInterval
+	}
+	return size, interval
+}
+
+// backPressureWorker periodically checks whether storage keeps up with incoming events
+func (s *PolymarketService) backPressureWorker() {
+	ticker := time.NewTicker(backPressureCheckInterval)
+	defer ticker.Stop()
+
+	var overflow int64
+	alerted := false
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			overflow, alerted = s.checkBackPressure(overflow, alerted)
+		}
+	}
+}
+
+// checkBackPressure tracks back-pressure episodes: the queue is near full or events were
+// dropped or blocked since the last check. The operator is alerted once per sustained
+// episode. It returns the overflow total and alert state for the next check.
+func (s *PolymarketService) checkBackPressure(lastOverflow int64, alerted bool) (int64, bool) {
+	status := s.writes.status()
+	overflow := status.BlockedTrades
+	for _, n := range status.Dropped {
+		overflow += n
+	}
+	pressured := float64(status.Depth) >= backPressureHighWater*float64(status.Capacity) || overflow > lastOverflow
+
+	if !pressured {
+		if status.BackPressure {
+			s.writes.setPressureSince(time.Time{})
+			if alerted {
+				log.Printf("[PolymarketService] Write back-pressure cleared after %s", time.Since(status.BackPressureSince).Round(time.Second))
+				s.eventBus.Emit("polymarket:backpressure_cleared", s.writes.status())
+			}
+		}
+		return overflow, false
+	}
+
+	now := time.Now()
+	if !status.BackPressure {
+		s.writes.setPressureSince(now)
+		return overflow, alerted
+	}
+	if alerted || now.Sub(status.BackPressureSince) < backPressureAlertAfter {
+		return overflow, alerted
+	}
+
+	message := fmt.Sprintf("Storage can't keep up for %s: %d/%d events queued, %d dropped or blocked",
+		now.Sub(status.BackPressureSince).Round(time.Second), status.Depth, status.Capacity, overflow)
+	log.Printf("[PolymarketService] BACK-PRESSURE: %s", message)
+	s.eventBus.Emit("polymarket:backpressure", status)
+	s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+		Detector: "system",
+		Signal:   "backpressure",
+		Message:  message,
+		Score:    1,
+		Alert:    true,
+		Metadata: map[string]string{
+			"depth":         strconv.Itoa(status.Depth),
+			"capacity":      strconv.Itoa(status.Capacity),
+			"blockedTrades": strconv.FormatInt(status.BlockedTrades, 10),
+		},
+		Timestamp: now,
+	})
+	return overflow, true
+}
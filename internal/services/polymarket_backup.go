@@ -0,0 +1,293 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// defaultBackupKeep is how many scheduled backups are kept when the config leaves it unset
+	defaultBackupKeep = 7
+
+	// backupCheckInterval is how often the backup schedule is checked
+	backupCheckInterval = 10 * time.Minute
+
+	// backupFilePrefix and backupFileExt name backup files, e.g. xtools-20260101-120000.db
+	backupFilePrefix = "xtools-"
+	backupFileExt    = ".db"
+	sealedBackupExt  = ".enc" // Appended to backups encrypted with the database key
+)
+
+// SetBackupEncryption encrypts backups with the at-rest encryption of the database, so
+// no plaintext copy is written while it is on. Sealed backups end in ".enc". The API
+// response cache is turned off for the same reason.
+func (s *PolymarketService) SetBackupEncryption(encryption *storage.EncryptedDatabase) {
+	s.mu.Lock()
+	s.backupSeal = encryption
+	s.mu.Unlock()
+	if encryption != nil && s.httpCache != nil {
+		s.httpCache.Disable()
+	}
+}
+
+// BackupDatabase snapshots the database while the watcher keeps running. An empty
+// path writes a timestamped file to the backup directory.
+func (s *PolymarketService) BackupDatabase(path string) (*domain.DatabaseBackup, error) {
+	return s.backupDatabase(path, false)
+}
+
+// RestoreDatabase replaces the stored data with a backup and reloads the settings
+// kept in it. The watcher must be stopped so no events are written meanwhile.
+func (s *PolymarketService) RestoreDatabase(path string) error {
+	if s.GetStatus().IsRunning {
+		return fmt.Errorf("stop the watcher before restoring a backup")
+	}
+	if path == "" {
+		return fmt.Errorf("backup path is required")
+	}
+	if storage.IsSealedBackup(path) {
+		plain, err := s.openSealedBackup(path)
+		if err != nil {
+			return err
+		}
+		defer removeBackup(plain)
+		path = plain
+	}
+	if err := s.store.Restore(path); err != nil {
+		return err
+	}
+	if err := s.reloadStoredState(); err != nil {
+		s.errReporter.Report("database restore", err)
+		return fmt.Errorf("restored %s but its settings can't be read: %w", path, err)
+	}
+
+	log.Printf("[PolymarketService] Restored database from %s", path)
+	s.eventBus.Emit("polymarket:database_restored", path)
+	return nil
+}
+
+// GetBackups returns the backups in the backup directory, newest first
+func (s *PolymarketService) GetBackups() ([]domain.DatabaseBackup, error) {
+	dir := s.backupDir()
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []domain.DatabaseBackup{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	backups := []domain.DatabaseBackup{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, backupFilePrefix) ||
+			!strings.HasSuffix(strings.TrimSuffix(name, sealedBackupExt), backupFileExt) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		backups = append(backups, backupInfo(path))
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+// backupDatabase writes a backup and, for scheduled ones, deletes those beyond BackupKeep
+func (s *PolymarketService) backupDatabase(path string, scheduled bool) (*domain.DatabaseBackup, error) {
+	if path == "" {
+		dir := s.backupDir()
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, fmt.Errorf("failed to create backup directory: %w", err)
+		}
+		path = filepath.Join(dir, backupFilePrefix+time.Now().Format("20060102-150405")+backupFileExt)
+	}
+
+	start := time.Now()
+	if err := s.store.Backup(path); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	seal := s.backupSeal
+	s.mu.RUnlock()
+	if seal != nil {
+		for _, p := range []string{path, path + storage.AnalysisBackupSuffix} {
+			if err := seal.SealBackup(p); err != nil {
+				removeBackup(path)
+				return nil, fmt.Errorf("failed to encrypt backup: %w", err)
+			}
+		}
+		path += sealedBackupExt
+	}
+	backup := backupInfo(path)
+	backup.DurationMs = time.Since(start).Milliseconds()
+	backup.Scheduled = scheduled
+	log.Printf("[PolymarketService] Backed up database to %s (%d bytes) in %dms", path, backup.SizeBytes, backup.DurationMs)
+
+	if scheduled {
+		s.pruneBackups()
+	}
+	s.eventBus.Emit("polymarket:database_backup", backup)
+	return &backup, nil
+}
+
+// backupWorker takes scheduled backups when the newest one is older than the interval
+func (s *PolymarketService) backupWorker() {
+	ticker := time.NewTicker(backupCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			interval, _ := s.backupSchedule()
+			if interval <= 0 {
+				continue
+			}
+			backups, err := s.GetBackups()
+			if err != nil || (len(backups) > 0 && time.Since(backups[0].CreatedAt) < interval) {
+				continue
+			}
+			if _, err := s.backupDatabase("", true); err != nil {
+				log.Printf("[PolymarketService] Scheduled backup failed: %v", err)
+				s.errReporter.Report("database backup", err)
+			}
+		}
+	}
+}
+
+// pruneBackups deletes the oldest backups beyond BackupKeep
+func (s *PolymarketService) pruneBackups() {
+	_, keep := s.backupSchedule()
+	backups, err := s.GetBackups()
+	if err != nil {
+		return
+	}
+	for i := keep; i < len(backups); i++ {
+		for _, path := range []string{backups[i].Path, storage.AnalysisBackupPath(backups[i].Path)} {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Printf("[PolymarketService] Failed to delete old backup %s: %v", path, err)
+			}
+		}
+	}
+}
+
+// reloadStoredState re-reads the configuration and settings after a restore. A config
+// or filter the backup has but can't be read, e.g. sealed with another key, is an
+// error rather than silently keeping the previous one.
+func (s *PolymarketService) reloadStoredState() error {
+	config, configErr := s.store.LoadConfig()
+	if configErr != nil && !errors.Is(configErr, sql.ErrNoRows) {
+		return fmt.Errorf("failed to load config: %w", configErr)
+	}
+	filter, filterErr := s.store.LoadFilter()
+	if filterErr != nil && !errors.Is(filterErr, sql.ErrNoRows) {
+		return fmt.Errorf("failed to load save filter: %w", filterErr)
+	}
+
+	s.mu.Lock()
+	if configErr == nil {
+		s.config = config
+		s.walletAnalyzer = polymarket.NewWalletAnalyzer(config, s.store)
+		s.walletAnalyzer.SetTransport(s.apiTransport())
+		s.identity.Set(config.ClientIdentity)
+		s.applyPragmas(config)
+	}
+	if filterErr == nil {
+		s.saveFilter = filter
+	}
+	s.mu.Unlock()
+
+	s.loadMutes()
+	s.loadTagRules()
+	s.loadLateEntryRules()
+	s.loadEntityAlertRules()
+	s.loadWatchlist()
+	s.loadWalletBlacklist()
+	s.loadAutoTune()
+	s.loadEventRetention()
+	s.loadEventSamplingRules()
+	s.loadSheetsSink()
+	s.loadCaseSync()
+	s.loadWithdrawals()
+	s.loadWashPairs()
+	s.loadFunding()
+	s.resetStreaks()
+	return nil
+}
+
+// backupSchedule returns the configured backup interval (0 = off) and how many to keep
+func (s *PolymarketService) backupSchedule() (time.Duration, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keep := s.config.BackupKeep
+	if keep <= 0 {
+		keep = defaultBackupKeep
+	}
+	return time.Duration(s.config.BackupIntervalHours) * time.Hour, keep
+}
+
+// backupDir returns the configured backup directory, or "backups" next to the database
+func (s *PolymarketService) backupDir() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.config.BackupDir != "" {
+		return s.config.BackupDir
+	}
+	return filepath.Join(filepath.Dir(s.dbPath), "backups")
+}
+
+// backupInfo describes the backup file at path
+func backupInfo(path string) domain.DatabaseBackup {
+	backup := domain.DatabaseBackup{Path: path}
+	for _, p := range []string{path, storage.AnalysisBackupPath(path)} {
+		if stat, err := os.Stat(p); err == nil {
+			backup.SizeBytes += stat.Size()
+			if backup.CreatedAt.IsZero() {
+				backup.CreatedAt = stat.ModTime()
+			}
+		}
+	}
+	return backup
+}
+
+// openSealedBackup decrypts a sealed backup, and its analysis database copy when there
+// is one, next to the database and returns the plaintext backup's path
+func (s *PolymarketService) openSealedBackup(path string) (string, error) {
+	s.mu.RLock()
+	seal := s.backupSeal
+	s.mu.RUnlock()
+	if seal == nil {
+		return "", fmt.Errorf("backup %s is encrypted; start with the database key (XTOOLS_DB_KEY) to restore it", path)
+	}
+
+	plain := filepath.Join(filepath.Dir(s.dbPath), fmt.Sprintf("restore-%d%s", time.Now().UnixNano(), backupFileExt))
+	if err := seal.OpenBackup(path, plain); err != nil {
+		return "", fmt.Errorf("failed to decrypt backup: %w", err)
+	}
+	analysis := storage.AnalysisBackupPath(path)
+	if _, err := os.Stat(analysis); err == nil {
+		if err := seal.OpenBackup(analysis, plain+storage.AnalysisBackupSuffix); err != nil {
+			removeBackup(plain)
+			return "", fmt.Errorf("failed to decrypt analysis backup: %w", err)
+		}
+	}
+	return plain, nil
+}
+
+// removeBackup deletes a plaintext backup and its analysis database copy
+func removeBackup(path string) {
+	os.Remove(path)
+	os.Remove(path + storage.AnalysisBackupSuffix)
+}
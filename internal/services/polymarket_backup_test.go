@@ -0,0 +1,149 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// newSQLiteTestService returns a service on a SQLite database in a temporary directory,
+// with settings encrypted under key when it is set
+func newSQLiteTestService(t *testing.T, key string) (*PolymarketService, string) {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "xtools.db")
+	store, err := storage.NewPolymarketStoreWithOptions(dbPath, storage.PolymarketStoreOptions{SettingsKey: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewPolymarketService(store, localbus.New(), dbPath)
+	t.Cleanup(svc.Close)
+	return svc, dbPath
+}
+
+func TestBackupSealedWithDatabaseKey(t *testing.T) {
+	svc, dbPath := newSQLiteTestService(t, "secret")
+	svc.SetBackupEncryption(storage.NewEncryptedDatabase(dbPath, "secret"))
+	if _, err := svc.MuteMarket("fed-cut", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := svc.BackupDatabase("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(backup.Path, ".db.enc") {
+		t.Fatalf("backup written to %s, want a sealed .db.enc file", backup.Path)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(backup.Path))
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".enc") {
+			t.Errorf("plaintext file %s left in the backup directory", entry.Name())
+		}
+	}
+	if backups, _ := svc.GetBackups(); len(backups) != 1 || backups[0].Path != backup.Path {
+		t.Errorf("GetBackups = %+v, want the sealed backup", backups)
+	}
+
+	// Restoring decrypts the backup and brings the mute back
+	if err := svc.UnmuteMarket("fed-cut"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RestoreDatabase(backup.Path); err != nil {
+		t.Fatal(err)
+	}
+	if mutes := svc.GetMutedMarkets(); len(mutes) != 1 || mutes[0].Slug != "fed-cut" {
+		t.Errorf("mutes after restore = %+v, want fed-cut", mutes)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(filepath.Dir(dbPath), "restore-*")); len(matches) != 0 {
+		t.Errorf("decrypted restore copies left behind: %v", matches)
+	}
+}
+
+func TestRestoreSealedBackupNeedsKey(t *testing.T) {
+	svc, dbPath := newSQLiteTestService(t, "")
+	path := filepath.Join(filepath.Dir(dbPath), "xtools-1.db.enc")
+	if err := os.WriteFile(path, []byte("XTDBENC1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RestoreDatabase(path); err == nil || !strings.Contains(err.Error(), "database key") {
+		t.Fatalf("restore without a key: got %v, want a database key error", err)
+	}
+}
+
+func TestRestoreRekeysSettings(t *testing.T) {
+	// A backup taken before the key was set keeps its settings in the clear
+	plain, plainPath := newSQLiteTestService(t, "")
+	if _, err := plain.MuteMarket("fed-cut", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := plain.BackupDatabase(filepath.Join(filepath.Dir(plainPath), "plain.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc, dbPath := newSQLiteTestService(t, "secret")
+	if err := svc.RestoreDatabase(backup.Path); err != nil {
+		t.Fatal(err)
+	}
+	if mutes := svc.GetMutedMarkets(); len(mutes) != 1 {
+		t.Fatalf("mutes after restore = %+v, want fed-cut", mutes)
+	}
+	if _, err := svc.MuteMarket("btc-100k", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	// Settings saved after the restore are sealed with the restored database's salt, so
+	// the next start with the key reads them
+	reopened, err := storage.NewPolymarketStoreWithOptions(dbPath, storage.PolymarketStoreOptions{SettingsKey: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	var mutes []domain.MarketMute
+	if err := reopened.LoadSetting(marketMutesSettingKey, &mutes); err != nil || len(mutes) != 2 {
+		t.Errorf("mutes read on the next start = %+v, %v; want 2", mutes, err)
+	}
+}
+
+func TestRestoreRefusesOtherKey(t *testing.T) {
+	other, otherPath := newSQLiteTestService(t, "other")
+	if _, err := other.MuteMarket("fed-cut", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := other.BackupDatabase(filepath.Join(filepath.Dir(otherPath), "other.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc, _ := newSQLiteTestService(t, "secret")
+	if _, err := svc.MuteMarket("btc-100k", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RestoreDatabase(backup.Path); err == nil || !strings.Contains(err.Error(), "different database key") {
+		t.Fatalf("restore of a backup sealed with another key: got %v, want a key mismatch", err)
+	}
+	// The live database is untouched
+	if mutes := svc.GetMutedMarkets(); len(mutes) != 1 || mutes[0].Slug != "btc-100k" {
+		t.Errorf("mutes after refused restore = %+v, want btc-100k", mutes)
+	}
+}
+
+func TestRestoreWithoutKeyFailsLoudly(t *testing.T) {
+	sealed, sealedPath := newSQLiteTestService(t, "secret")
+	sealed.UpdateConfig(sealed.GetConfig())
+	backup, err := sealed.BackupDatabase(filepath.Join(filepath.Dir(sealedPath), "sealed.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc, _ := newSQLiteTestService(t, "")
+	if err := svc.RestoreDatabase(backup.Path); err == nil || !strings.Contains(err.Error(), "settings can't be read") {
+		t.Fatalf("restore of a sealed backup without the key: got %v, want a settings error", err)
+	}
+}
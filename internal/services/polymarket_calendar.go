@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/report"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// calendarMarketLimit bounds how many watched markets are looked up per export
+	calendarMarketLimit = 200
+
+	// calendarLookupTimeout bounds the Gamma API lookups of one export
+	calendarLookupTimeout = 60 * time.Second
+)
+
+// GetUpcomingResolutions returns the future end dates of markets with flagged trades
+// or open investigations, soonest first
+func (s *PolymarketService) GetUpcomingResolutions() ([]domain.MarketResolution, error) {
+	resolutions, _, err := s.upcomingResolutions()
+	return resolutions, err
+}
+
+// ExportResolutionCalendar writes upcoming resolutions to an iCal file next to the
+// database. The path is stable so a calendar subscribed to it stays current.
+func (s *PolymarketService) ExportResolutionCalendar() (*domain.CalendarExport, error) {
+	resolutions, skipped, err := s.upcomingResolutions()
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := report.NewWriter(filepath.Join(filepath.Dir(s.dbPath), "reports")).WriteCalendar(resolutions, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[PolymarketService] Exported %d market resolutions to %s (%d skipped)", len(resolutions), path, skipped)
+	return &domain.CalendarExport{Path: path, Markets: len(resolutions), Skipped: skipped}, nil
+}
+
+// upcomingResolutions collects watched markets and looks up their end dates.
+// It also returns how many watched markets had no known future end date.
+func (s *PolymarketService) upcomingResolutions() ([]domain.MarketResolution, int, error) {
+	watched, err := s.watchedMarkets()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), calendarLookupTimeout)
+	defer cancel()
+
+	now := time.Now()
+	resolutions := []domain.MarketResolution{}
+	skipped := 0
+	for _, r := range watched {
+		info, err := s.markets.GetMarket(ctx, r.Slug)
+		if err != nil {
+			log.Printf("[PolymarketService] Failed to look up market %s: %v", r.Slug, err)
+		}
+		if info == nil || info.Closed || !info.EndDate.After(now) {
+			skipped++
+			continue
+		}
+		r.EndDate = info.EndDate
+		r.GameStartTime = info.GameStartTime
+		if info.Question != "" {
+			r.Name = info.Question
+		}
+		resolutions = append(resolutions, *r)
+	}
+
+	sort.Slice(resolutions, func(i, j int) bool {
+		return resolutions[i].EndDate.Before(resolutions[j].EndDate)
+	})
+	return resolutions, skipped, nil
+}
+
+// watchedMarkets returns unmuted markets with flagged stored trades or in an
+// investigation that is not closed, most recently flagged first
+func (s *PolymarketService) watchedMarkets() ([]*domain.MarketResolution, error) {
+	events, err := s.store.GetEvents(domain.PolymarketEventFilter{
+		EventTypes: []domain.PolymarketEventType{domain.PolymarketEventTrade},
+		Limit:      reportTradeScanLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trades: %w", err)
+	}
+	investigations, err := s.store.ListInvestigations("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load investigations: %w", err)
+	}
+
+	bySlug := make(map[string]*domain.MarketResolution)
+	var order []*domain.MarketResolution
+	market := func(slug string) *domain.MarketResolution {
+		key := strings.ToLower(slug)
+		if r, ok := bySlug[key]; ok {
+			return r
+		}
+		r := &domain.MarketResolution{Slug: slug, Name: slug, Link: "https://polymarket.com/event/" + slug}
+		bySlug[key] = r
+		order = append(order, r)
+		return r
+	}
+
+	for _, e := range events {
+		if e.MarketSlug == "" || (!e.IsFreshWallet && len(e.RiskSignals) == 0) || s.isMarketMuted(e) {
+			continue
+		}
+		r := market(e.MarketSlug)
+		if r.FlaggedTrades == 0 {
+			if e.MarketName != "" {
+				r.Name = e.MarketName
+			}
+			if e.MarketLink != "" {
+				r.Link = e.MarketLink
+			}
+		}
+		r.FlaggedTrades++
+		r.FlaggedNotional += parseNotionalValue(e.Price, e.Size)
+		if e.Timestamp.After(r.LastFlaggedAt) {
+			r.LastFlaggedAt = e.Timestamp
+		}
+	}
+
+	for _, inv := range investigations {
+		if inv.Status == domain.InvestigationClosed {
+			continue
+		}
+		for _, item := range inv.Items {
+			if item.Type != domain.InvestigationItemMarket || s.isMarketMuted(domain.PolymarketEvent{MarketSlug: item.Ref}) {
+				continue
+			}
+			r := market(item.Ref)
+			r.Investigations = append(r.Investigations, inv.Name)
+		}
+	}
+
+	if len(order) > calendarMarketLimit {
+		order = order[:calendarMarketLimit]
+	}
+	return order, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// alertCaseRecord describes a flagged trade for case tracking
+func alertCaseRecord(event domain.PolymarketEvent) domain.CaseRecord {
+	price, _ := strconv.ParseFloat(event.Price, 64)
+	market := event.MarketName
+	if market == "" {
+		market = event.EventTitle
+	}
+	fields := map[string]any{
+		"time":      event.Timestamp,
+		"market":    market,
+		"outcome":   event.Outcome,
+		"side":      string(event.Side),
+		"price":     price,
+		"notional":  parseNotionalValue(event.Price, event.Size),
+		"wallet":    event.WalletAddress,
+		"riskScore": event.RiskScore,
+		"signals":   event.RiskSignals,
+		"link":      event.MarketLink,
+	}
+	if event.WalletProfile != nil {
+		fields["betCount"] = event.WalletProfile.BetCount
+		fields["freshness"] = string(event.WalletProfile.FreshnessLevel)
+	}
+	return domain.CaseRecord{Kind: domain.CaseRecordAlert, Key: event.TradeID, Fields: fields}
+}
+
+// walletCaseRecord describes a watched wallet with the investigations it appears in.
+// Status is the most active status among them, so a wallet only reads "closed" once
+// every investigation watching it is closed.
+func walletCaseRecord(address string, profile *domain.WalletProfile, investigations []domain.Investigation) domain.CaseRecord {
+	fields := map[string]any{
+		"wallet":  address,
+		"profile": "https://polymarket.com/profile/" + address,
+	}
+	if profile != nil {
+		fields["betCount"] = profile.BetCount
+		fields["freshness"] = string(profile.FreshnessLevel)
+		fields["joinDate"] = profile.JoinDate
+		fields["analyzedAt"] = profile.AnalyzedAt
+	}
+
+	names := []string{}
+	status := domain.InvestigationClosed
+	for _, inv := range investigations {
+		if !investigationHasWallet(inv, address) {
+			continue
+		}
+		if inv.Status != domain.InvestigationClosed {
+			names = append(names, inv.Name)
+		}
+		if inv.Status == domain.InvestigationOpen || (inv.Status == domain.InvestigationMonitoring && status == domain.InvestigationClosed) {
+			status = inv.Status
+		}
+	}
+	fields["investigations"] = names
+	fields["status"] = string(status)
+	return domain.CaseRecord{Kind: domain.CaseRecordWallet, Key: address, Fields: fields}
+}
+
+// watchedWallets returns the wallets of investigations that are not closed
+func watchedWallets(investigations []domain.Investigation) []string {
+	seen := make(map[string]bool)
+	var wallets []string
+	for _, inv := range investigations {
+		if inv.Status == domain.InvestigationClosed {
+			continue
+		}
+		for _, item := range inv.Items {
+			if item.Type == domain.InvestigationItemWallet && !seen[strings.ToLower(item.Ref)] {
+				seen[strings.ToLower(item.Ref)] = true
+				wallets = append(wallets, item.Ref)
+			}
+		}
+	}
+	return wallets
+}
+
+func investigationHasWallet(inv domain.Investigation, address string) bool {
+	for _, item := range inv.Items {
+		if item.Type == domain.InvestigationItemWallet && strings.EqualFold(item.Ref, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapCaseRecord renames record fields to the target's properties; unmapped fields are left out
+func mapCaseRecord(record domain.CaseRecord, mapping map[string]string) map[string]any {
+	properties := make(map[string]any, len(mapping))
+	for field, property := range mapping {
+		if property == "" {
+			continue
+		}
+		if field == "key" {
+			properties[property] = record.Key
+		} else if value, ok := record.Fields[field]; ok {
+			properties[property] = value
+		}
+	}
+	return properties
+}
+
+// validateCaseSyncFields checks that a field mapping names known fields and maps the key
+func validateCaseSyncFields(kind domain.CaseRecordKind, mapping map[string]string) error {
+	known := make(map[string]bool)
+	for _, field := range domain.CaseRecordFields[kind] {
+		known[field] = true
+	}
+	for field := range mapping {
+		if !known[field] {
+			return fmt.Errorf("unknown %s field %q (available: %s)", kind, field, strings.Join(domain.CaseRecordFields[kind], ", "))
+		}
+	}
+	if strings.TrimSpace(mapping["key"]) == "" {
+		return fmt.Errorf("%s field mapping must map \"key\" to a property", kind)
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/casetracker"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+const (
+	// caseSyncSettingKey is the settings key the case sync configuration is persisted under
+	caseSyncSettingKey = "case_sync"
+
+	caseSyncInterval   = 15 * time.Second
+	caseSyncMaxPending = 5000 // Records beyond this are dropped while the tool is unreachable
+)
+
+// caseSync is the state of syncing alerts and watched wallets to Notion or Airtable
+type caseSync struct {
+	config  domain.CaseSyncConfig
+	tracker ports.CaseTracker
+	pending map[string]domain.CaseRecord // By kind and key, so only the latest version is sent
+	status  domain.CaseSyncStatus
+}
+
+// SetCaseSync configures syncing to Notion or Airtable
+func (s *PolymarketService) SetCaseSync(config domain.CaseSyncConfig) error {
+	config.Token = strings.TrimSpace(config.Token)
+	config.BaseID = strings.TrimSpace(config.BaseID)
+	for _, target := range []*domain.CaseSyncTarget{&config.Alerts, &config.Wallets} {
+		target.Database = strings.TrimSpace(target.Database)
+	}
+	if len(config.Alerts.Fields) == 0 {
+		config.Alerts.Fields = domain.DefaultCaseSyncFields(domain.CaseRecordAlert)
+	}
+	if len(config.Wallets.Fields) == 0 {
+		config.Wallets.Fields = domain.DefaultCaseSyncFields(domain.CaseRecordWallet)
+	}
+
+	var tracker ports.CaseTracker
+	if config.Enabled {
+		if config.Alerts.Database == "" && config.Wallets.Database == "" {
+			return fmt.Errorf("set a database or table for alerts or wallets")
+		}
+		for kind, target := range map[domain.CaseRecordKind]domain.CaseSyncTarget{
+			domain.CaseRecordAlert: config.Alerts, domain.CaseRecordWallet: config.Wallets,
+		} {
+			if err := validateCaseSyncFields(kind, target.Fields); err != nil {
+				return err
+			}
+		}
+		var err error
+		if tracker, err = casetracker.New(config); err != nil {
+			return err
+		}
+	}
+
+	if err := s.store.SaveSetting(caseSyncSettingKey, config); err != nil {
+		return fmt.Errorf("failed to save case sync: %w", err)
+	}
+	s.caseSyncMu.Lock()
+	s.caseSync = caseSync{config: config, tracker: tracker, pending: make(map[string]domain.CaseRecord)}
+	s.caseSyncMu.Unlock()
+
+	log.Printf("[PolymarketService] Case sync enabled=%v provider=%s", config.Enabled, config.Provider)
+	return nil
+}
+
+// GetCaseSync returns the case sync configuration
+func (s *PolymarketService) GetCaseSync() domain.CaseSyncConfig {
+	s.caseSyncMu.Lock()
+	defer s.caseSyncMu.Unlock()
+	return s.caseSync.config
+}
+
+// GetCaseSyncStatus returns the progress of syncing to Notion or Airtable
+func (s *PolymarketService) GetCaseSyncStatus() domain.CaseSyncStatus {
+	s.caseSyncMu.Lock()
+	defer s.caseSyncMu.Unlock()
+	status := s.caseSync.status
+	status.Enabled = s.caseSync.tracker != nil
+	status.Pending = len(s.caseSync.pending)
+	return status
+}
+
+// SyncCasesNow queues every watched wallet and syncs pending records immediately
+func (s *PolymarketService) SyncCasesNow() (*domain.CaseSyncStatus, error) {
+	if !s.caseSyncWants(domain.CaseRecordWallet) && !s.caseSyncWants(domain.CaseRecordAlert) {
+		return nil, fmt.Errorf("case sync is not enabled")
+	}
+	if s.caseSyncWants(domain.CaseRecordWallet) {
+		investigations, err := s.store.ListInvestigations("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load investigations: %w", err)
+		}
+		for _, address := range watchedWallets(investigations) {
+			s.queueCaseWallet(address, investigations)
+		}
+	}
+	s.flushCaseSync()
+	status := s.GetCaseSyncStatus()
+	return &status, nil
+}
+
+// syncAlertCase queues a flagged trade that meets the alert thresholds
+func (s *PolymarketService) syncAlertCase(event domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || len(event.RiskSignals) == 0 || event.Muted || event.TradeID == "" {
+		return
+	}
+	if !s.caseSyncWants(domain.CaseRecordAlert) {
+		return
+	}
+	s.mu.RLock()
+	config := s.config
+	s.mu.RUnlock()
+	if !meetsAlertThresholds(event, config.MinTradeSize, config.AlertThreshold) {
+		return
+	}
+	s.queueCaseRecord(alertCaseRecord(event))
+}
+
+// syncInvestigationWallets queues the wallets of an investigation after it changed
+func (s *PolymarketService) syncInvestigationWallets(inv *domain.Investigation) {
+	if !s.caseSyncWants(domain.CaseRecordWallet) {
+		return
+	}
+	var addresses []string
+	for _, item := range inv.Items {
+		if item.Type == domain.InvestigationItemWallet {
+			addresses = append(addresses, item.Ref)
+		}
+	}
+	if len(addresses) == 0 {
+		return
+	}
+	investigations, err := s.store.ListInvestigations("")
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to load investigations for case sync: %v", err)
+		return
+	}
+	for _, address := range addresses {
+		s.queueCaseWallet(address, investigations)
+	}
+}
+
+// syncWalletCase queues a re-analyzed wallet if an investigation that is not closed watches it
+func (s *PolymarketService) syncWalletCase(address string) {
+	if !s.caseSyncWants(domain.CaseRecordWallet) {
+		return
+	}
+	investigations, err := s.store.ListInvestigations("")
+	if err != nil {
+		return
+	}
+	for _, watched := range watchedWallets(investigations) {
+		if strings.EqualFold(watched, address) {
+			s.queueCaseWallet(watched, investigations)
+			return
+		}
+	}
+}
+
+// caseSyncWorker periodically syncs pending records
+func (s *PolymarketService) caseSyncWorker() {
+	ticker := time.NewTicker(caseSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.flushCaseSync()
+		}
+	}
+}
+
+// flushCaseSync sends pending records per kind; failed records are queued again
+// unless a newer version was queued meanwhile
+func (s *PolymarketService) flushCaseSync() {
+	s.caseSyncMu.Lock()
+	tracker, config, pending := s.caseSync.tracker, s.caseSync.config, s.caseSync.pending
+	s.caseSync.pending = make(map[string]domain.CaseRecord)
+	s.caseSyncMu.Unlock()
+	if tracker == nil || len(pending) == 0 {
+		return
+	}
+
+	byKind := make(map[domain.CaseRecordKind][]domain.CaseRecord)
+	for _, record := range pending {
+		byKind[record.Kind] = append(byKind[record.Kind], record)
+	}
+
+	for kind, records := range byKind {
+		target := config.Alerts
+		if kind == domain.CaseRecordWallet {
+			target = config.Wallets
+		}
+		properties := make([]map[string]any, 0, len(records))
+		for _, record := range records {
+			properties = append(properties, mapCaseRecord(record, target.Fields))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		err := tracker.Upsert(ctx, target.Database, target.Fields["key"], properties)
+		cancel()
+
+		s.caseSyncMu.Lock()
+		if err != nil {
+			if s.caseSync.status.LastError == "" {
+				log.Printf("[PolymarketService] Case sync of %d %s records failed: %v", len(records), kind, err)
+			}
+			s.caseSync.status.Failed += int64(len(records))
+			s.caseSync.status.LastError = err.Error()
+			for _, record := range records {
+				key := string(record.Kind) + ":" + record.Key
+				if _, newer := s.caseSync.pending[key]; !newer && len(s.caseSync.pending) < caseSyncMaxPending {
+					s.caseSync.pending[key] = record
+				}
+			}
+		} else {
+			s.caseSync.status.Synced += int64(len(records))
+			s.caseSync.status.LastSyncAt = time.Now()
+			s.caseSync.status.LastError = ""
+		}
+		s.caseSyncMu.Unlock()
+	}
+}
+
+// caseSyncWants reports whether records of a kind are synced
+func (s *PolymarketService) caseSyncWants(kind domain.CaseRecordKind) bool {
+	s.caseSyncMu.Lock()
+	defer s.caseSyncMu.Unlock()
+	if s.caseSync.tracker == nil {
+		return false
+	}
+	if kind == domain.CaseRecordWallet {
+		return s.caseSync.config.Wallets.Database != ""
+	}
+	return s.caseSync.config.Alerts.Database != ""
+}
+
+// queueCaseRecord adds a record to the next sync, replacing an older version of it
+func (s *PolymarketService) queueCaseRecord(record domain.CaseRecord) {
+	key := string(record.Kind) + ":" + record.Key
+	s.caseSyncMu.Lock()
+	defer s.caseSyncMu.Unlock()
+	if _, queued := s.caseSync.pending[key]; !queued && len(s.caseSync.pending) >= caseSyncMaxPending {
+		return
+	}
+	s.caseSync.pending[key] = record
+}
+
+// queueCaseWallet builds and queues the record of a watched wallet
+func (s *PolymarketService) queueCaseWallet(address string, investigations []domain.Investigation) {
+	profile, _ := s.store.GetWallet(address)
+	s.queueCaseRecord(walletCaseRecord(address, profile, investigations))
+}
+
+// loadCaseSync restores the persisted case sync configuration
+func (s *PolymarketService) loadCaseSync() {
+	s.caseSync.pending = make(map[string]domain.CaseRecord)
+	if err := s.store.LoadSetting(caseSyncSettingKey, &s.caseSync.config); err != nil || !s.caseSync.config.Enabled {
+		return
+	}
+	tracker, err := casetracker.New(s.caseSync.config)
+	if err != nil {
+		log.Printf("[PolymarketService] Case sync disabled: %v", err)
+		return
+	}
+	s.caseSync.tracker = tracker
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Category novelty defaults when the config leaves them unset
+	defaultNoveltyMinUSD    = 5000.0
+	defaultNoveltyMinTrades = 5
+
+	// noveltyLookupTimeout bounds the market lookup done while handling a trade
+	noveltyLookupTimeout = 3 * time.Second
+
+	// Risk scores of a first trade in a new category, higher when every earlier trade
+	// was in one category (e.g. a sports-only bettor)
+	noveltyScore           = 0.6
+	noveltySpecialistScore = 0.75
+)
+
+// GetWalletCategories returns the market categories of a wallet's stored trades, most
+// traded first
+func (s *PolymarketService) GetWalletCategories(address string) ([]domain.WalletCategoryStats, error) {
+	return s.store.GetWalletCategories(address)
+}
+
+// applyCategoryNovelty adds the trade's market category to the wallet's category
+// history. A large trade in a category the wallet never traded before, after enough
+// trades elsewhere, is flagged: a bettor moving into an unfamiliar field with size
+// may know something about it.
+func (s *PolymarketService) applyCategoryNovelty(event *domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || event.WalletAddress == "" || event.MarketSlug == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), noveltyLookupTimeout)
+	info, err := s.markets.GetMarket(ctx, event.MarketSlug)
+	cancel()
+	if err != nil || info == nil {
+		return
+	}
+	category := strings.TrimSpace(info.Category)
+	if category == "" {
+		return
+	}
+
+	notional := parseNotionalValue(event.Price, event.Size)
+	minUSD, minTrades := s.noveltyThresholds()
+	if notional >= minUSD {
+		if history, err := s.store.GetWalletCategories(event.WalletAddress); err == nil {
+			s.flagCategoryNovelty(event, category, notional, history, minTrades)
+		}
+	}
+
+	if err := s.store.RecordWalletCategory(event.WalletAddress, category, notional, event.Timestamp); err != nil {
+		log.Printf("[PolymarketService] Failed to record wallet category: %v", err)
+	}
+}
+
+// flagCategoryNovelty adds a risk signal when a category is missing from the wallet's
+// history of at least minTrades trades, and requests an alert for it
+func (s *PolymarketService) flagCategoryNovelty(event *domain.PolymarketEvent, category string, notional float64, history []domain.WalletCategoryStats, minTrades int) {
+	prior := 0
+	for _, c := range history {
+		if strings.EqualFold(c.Category, category) {
+			return
+		}
+		prior += c.Trades
+	}
+	if prior < minTrades {
+		return
+	}
+
+	// History is ordered most traded first
+	usual := history[0].Category
+	score := noveltyScore
+	message := fmt.Sprintf("First bet in %s (%s; %d earlier trades, mostly %s)", category, formatCompactUSD(notional), prior, usual)
+	if len(history) == 1 {
+		score = noveltySpecialistScore
+		message = fmt.Sprintf("First bet in %s by a %s-only wallet (%s; %d earlier trades)", category, usual, formatCompactUSD(notional), prior)
+	}
+	event.RiskSignals = append(event.RiskSignals, "🧭 "+message)
+	if score > event.RiskScore {
+		event.RiskScore = score
+	}
+
+	if !event.Muted {
+		s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+			Detector: "category_novelty",
+			Signal:   "first_category_trade",
+			Message:  message,
+			Score:    score,
+			Alert:    true,
+			Metadata: map[string]string{
+				"category":      category,
+				"usualCategory": usual,
+				"categories":    strconv.Itoa(len(history)),
+				"priorTrades":   strconv.Itoa(prior),
+				"notional":      strconv.FormatFloat(notional, 'f', 2, 64),
+			},
+			TradeID:       event.TradeID,
+			WalletAddress: event.WalletAddress,
+			MarketName:    event.MarketName,
+			MarketLink:    event.MarketLink,
+			Timestamp:     event.Timestamp,
+		})
+	}
+}
+
+// noveltyThresholds returns the configured thresholds, falling back to defaults
+func (s *PolymarketService) noveltyThresholds() (float64, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	minUSD, minTrades := s.config.NoveltyMinUSD, s.config.NoveltyMinTrades
+	if minUSD <= 0 {
+		minUSD = defaultNoveltyMinUSD
+	}
+	if minTrades <= 0 {
+		minTrades = defaultNoveltyMinTrades
+	}
+	return minUSD, minTrades
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestCategoryNoveltyFlagsFirstLargeTradeInACategory(t *testing.T) {
+	svc, rec := newTestService(t)
+	svc.markets.SetTransport(gammaMarkets{
+		"lakers":    `{"slug": "lakers", "category": "Sports"}`,
+		"celtics":   `{"slug": "celtics", "category": "Sports"}`,
+		"ceasefire": `{"slug": "ceasefire", "category": "Geopolitics"}`,
+		"fed":       `{"slug": "fed", "category": "Economics"}`,
+	})
+
+	n := 0
+	trade := func(wallet, slug, size string) domain.PolymarketEvent {
+		n++
+		event := domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("tx-%d", n), WalletAddress: wallet,
+			MarketSlug: slug, Price: "0.5", Size: size, Timestamp: time.Now(),
+		}
+		svc.applyCategoryNovelty(&event)
+		return event
+	}
+
+	// Too little history to tell what is unusual
+	for range 4 {
+		trade("0xa", "lakers", "100")
+	}
+	if event := trade("0xa", "fed", "20000"); len(event.RiskSignals) != 0 {
+		t.Errorf("flagged a wallet with 4 earlier trades: %v", event.RiskSignals)
+	}
+
+	// A sports-only bettor betting big on geopolitics
+	for range 5 {
+		trade("0xb", "celtics", "100")
+	}
+	if event := trade("0xb", "ceasefire", "4000"); len(event.RiskSignals) != 0 {
+		t.Errorf("flagged a $2k trade under the $5k minimum: %v", event.RiskSignals)
+	}
+	for range 5 {
+		trade("0xc", "lakers", "100")
+	}
+	event := trade("0xc", "ceasefire", "20000")
+	if len(event.RiskSignals) != 1 || event.RiskScore != noveltySpecialistScore ||
+		!strings.Contains(event.RiskSignals[0], "First bet in Geopolitics by a Sports-only wallet") {
+		t.Errorf("trade = %+v, want the specialist novelty signal", event)
+	}
+
+	// Once traded, the category is no longer new
+	if event := trade("0xc", "ceasefire", "20000"); len(event.RiskSignals) != 0 {
+		t.Errorf("flagged a second trade in the category: %v", event.RiskSignals)
+	}
+	if event := trade("0xc", "fed", "20000"); event.RiskScore != noveltyScore || !strings.Contains(event.RiskSignals[0], "mostly Sports") {
+		t.Errorf("trade = %+v, want the novelty signal of a wallet with two categories", event)
+	}
+
+	signals := rec.of("polymarket:detector_signal")
+	if len(signals) != 2 || signals[0].(domain.DetectorSignal).Metadata["usualCategory"] != "Sports" {
+		t.Errorf("signals = %+v, want the two novelty alerts", signals)
+	}
+	if categories, _ := svc.GetWalletCategories("0xc"); len(categories) != 3 || categories[0].Trades != 5 {
+		t.Errorf("categories = %+v, want Sports first of 3", categories)
+	}
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// UpdateConfig updates the service configuration and saves to database
+func (s *PolymarketService) UpdateConfig(config domain.PolymarketConfig) {
+	s.applyConfig(config, domain.ConfigChangeSourceUser, "")
+}
+
+// applyConfig swaps in a configuration, saves it and records threshold changes in the audit trail
+func (s *PolymarketService) applyConfig(config domain.PolymarketConfig, source, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.recordConfigChanges(source, reason, s.config, config)
+	thresholdsChanged := freshnessThresholdsChanged(s.config, config)
+	hotHandChanged := s.config.HotHandMinStreak != config.HotHandMinStreak || s.config.HotHandMaxPrice != config.HotHandMaxPrice
+	s.config = config
+	s.walletAnalyzer = polymarket.NewWalletAnalyzer(config, s.store)
+	s.walletAnalyzer.SetTransport(s.apiTransport())
+	s.walletAnalyzer.SetBlacklist(s.blacklistedAddresses())
+	s.identity.Set(config.ClientIdentity)
+	s.applyPragmas(config)
+	if s.images != nil {
+		s.images.SetMaxBytes(int64(config.ImageCacheMaxMB) << 20)
+	}
+
+	// Re-evaluate stored wallets in the background so queries match the new thresholds
+	if thresholdsChanged {
+		go s.RecomputeFreshness()
+	}
+	if hotHandChanged {
+		s.resetStreaks()
+	}
+
+	// Save to database
+	if err := s.store.SaveConfig(config); err != nil {
+		log.Printf("[PolymarketService] Failed to save config: %v", err)
+	} else {
+		log.Printf("[PolymarketService] Config saved to database: InsiderMax=%d, WalletMax=%d, NewbieMax=%d, CustomMax=%d",
+			config.FreshInsiderMaxBets, config.FreshWalletMaxBets, config.FreshNewbieMaxBets, config.CustomFreshMaxBets)
+	}
+}
+
+// apiTransport returns the transport for Gamma and profile API requests: the identity's
+// headers, behind the conditional request cache when there is one
+func (s *PolymarketService) apiTransport() http.RoundTripper {
+	if s.httpCache != nil {
+		return s.httpCache
+	}
+	return s.identity.Transport(nil)
+}
+
+// GetConfig returns the current configuration
+func (s *PolymarketService) GetConfig() domain.PolymarketConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+// SetSaveFilter sets the filter for saving events to database and persists it
+func (s *PolymarketService) SetSaveFilter(filter domain.PolymarketEventFilter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saveFilter = filter
+
+	// Save to database
+	if err := s.store.SaveFilter(filter); err != nil {
+		log.Printf("[PolymarketService] Failed to save filter: %v", err)
+	} else {
+		log.Printf("[PolymarketService] Filter saved to database: minSize=%.0f, side=%s, freshWalletsOnly=%v",
+			filter.MinSize, filter.Side, filter.FreshWalletsOnly)
+	}
+}
+
+// GetSaveFilter returns the current save filter
+func (s *PolymarketService) GetSaveFilter() domain.PolymarketEventFilter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.saveFilter
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// defaultConfigSnapshotLimit is how many config versions GetConfigSnapshots returns by default
+const defaultConfigSnapshotLimit = 50
+
+// GetConfigSnapshot returns the config version an alert was raised under
+func (s *PolymarketService) GetConfigSnapshot(hash string) (*domain.ConfigSnapshot, error) {
+	snapshot, err := s.store.GetConfigSnapshot(hash)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("no config snapshot %q", hash)
+	}
+	return snapshot, nil
+}
+
+// GetConfigSnapshots returns the config versions alerts were raised under, newest first
+func (s *PolymarketService) GetConfigSnapshots(limit int) ([]domain.ConfigSnapshot, error) {
+	if limit <= 0 {
+		limit = defaultConfigSnapshotLimit
+	}
+	return s.store.GetConfigSnapshots(limit)
+}
+
+// DiffConfigSnapshot lists the thresholds that changed between a config version
+// (old values) and the current config (new values)
+func (s *PolymarketService) DiffConfigSnapshot(hash string) ([]domain.ConfigChange, error) {
+	snapshot, err := s.GetConfigSnapshot(hash)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	current := s.config
+	s.mu.RUnlock()
+
+	changes := configThresholdChanges(snapshot.Config, current)
+	if changes == nil {
+		changes = []domain.ConfigChange{}
+	}
+	return changes, nil
+}
+
+// configVersion returns the hash of the current config and tag rules, saving a
+// snapshot the first time a version raises an alert
+func (s *PolymarketService) configVersion() string {
+	s.mu.RLock()
+	snapshot := domain.NewConfigSnapshot(s.config, s.tagRules)
+	s.mu.RUnlock()
+
+	s.snapMu.Lock()
+	defer s.snapMu.Unlock()
+	if snapshot.Hash == s.snapHash {
+		return snapshot.Hash
+	}
+	snapshot.CreatedAt = time.Now()
+	if err := s.store.SaveConfigSnapshot(snapshot); err != nil {
+		log.Printf("[PolymarketService] Failed to save config snapshot: %v", err)
+		return snapshot.Hash
+	}
+	s.snapHash = snapshot.Hash
+	return snapshot.Hash
+}
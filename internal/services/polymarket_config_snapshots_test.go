@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestAlertsRecordTheirConfigVersion(t *testing.T) {
+	svc, _ := newSQLiteTestService(t, "")
+	setConfig := func(minTradeSize float64, secret string) {
+		svc.mu.Lock()
+		svc.config.MinTradeSize, svc.config.WalletWebhookSecret = minTradeSize, secret
+		svc.mu.Unlock()
+	}
+	n := 0
+	alert := func() string {
+		n++
+		svc.trackAlert(domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("tx-%d", n), AssetID: "111", WalletAddress: "0xa",
+			Price: "0.5", Size: "10000", RiskSignals: []string{"🚨 Fresh Insider (0 bets)"}, Timestamp: time.Now(),
+		})
+		outcomes, err := svc.store.GetAlertOutcomes(time.Now().Add(-time.Hour), 1)
+		if err != nil || len(outcomes) != 1 {
+			t.Fatalf("outcomes = %+v, %v, want the alert", outcomes, err)
+		}
+		return outcomes[0].ConfigHash
+	}
+
+	setConfig(1000, "secret-1")
+	first := alert()
+	if first == "" || alert() != first {
+		t.Fatal("alerts under the same config got different versions")
+	}
+	setConfig(1000, "secret-2")
+	if alert() != first {
+		t.Error("a webhook secret changed the config version")
+	}
+	setConfig(2000, "secret-2")
+	second := alert()
+	if second == first {
+		t.Fatal("a threshold change kept the config version")
+	}
+
+	snapshots, err := svc.GetConfigSnapshots(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Hash != second {
+		t.Fatalf("snapshots = %+v, want both versions newest first", snapshots)
+	}
+	snapshot, err := svc.GetConfigSnapshot(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Config.MinTradeSize != 1000 || snapshot.Config.WalletWebhookSecret != "" {
+		t.Errorf("snapshot config = %+v, want the old threshold without secrets", snapshot.Config)
+	}
+	changes, err := svc.DiffConfigSnapshot(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Field != "minTradeSize" || changes[0].OldValue != 1000 || changes[0].NewValue != 2000 {
+		t.Errorf("changes = %+v, want minTradeSize from 1000 to 2000", changes)
+	}
+	if _, err := svc.GetConfigSnapshot("unknown"); err == nil {
+		t.Error("found a snapshot for an unknown hash")
+	}
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// WAL checkpoints when the config leaves them unset: every defaultWALCheckpointInterval,
+	// or earlier once the -wal files grow past defaultWALCheckpointMB
+	defaultWALCheckpointInterval = 10 * time.Minute
+	defaultWALCheckpointMB       = 64
+
+	// walSizeCheckInterval is how often the WAL size is compared to the limit
+	walSizeCheckInterval = time.Minute
+
+	// Integrity checks run shortly after start, then daily
+	firstIntegrityCheckDelay = 5 * time.Minute
+	integrityCheckInterval   = 24 * time.Hour
+)
+
+// dbHealthState is the outcome of the latest maintenance runs
+type dbHealthState struct {
+	lastCheckpointAt   time.Time
+	integrityStatus    string
+	integrityCheckedAt time.Time
+}
+
+// GetDatabaseInfo returns database statistics, WAL and page health, maintenance state
+// and the size of every table. Counting the tables scans the whole database, so
+// background work reads the store's GetDatabaseInfo instead.
+func (s *PolymarketService) GetDatabaseInfo() (*domain.DatabaseInfo, error) {
+	info, err := s.store.GetDatabaseInfo()
+	if info == nil {
+		return nil, err
+	}
+	if err == nil {
+		info.Tables, err = s.store.GetTableStats()
+	}
+
+	s.dbHealthMu.Lock()
+	info.LastCheckpointAt = s.dbHealth.lastCheckpointAt
+	info.IntegrityStatus = s.dbHealth.integrityStatus
+	info.IntegrityCheckedAt = s.dbHealth.integrityCheckedAt
+	s.dbHealthMu.Unlock()
+	return info, err
+}
+
+// CheckDatabaseIntegrity runs SQLite's integrity_check now and returns "ok" or the
+// problems found. Problems are announced as an operator alert.
+func (s *PolymarketService) CheckDatabaseIntegrity() (string, error) {
+	status, err := s.store.CheckIntegrity()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	s.dbHealthMu.Lock()
+	s.dbHealth.integrityStatus = status
+	s.dbHealth.integrityCheckedAt = now
+	s.dbHealthMu.Unlock()
+
+	if status != "ok" {
+		log.Printf("[PolymarketService] DATABASE INTEGRITY: %s", status)
+		if s.dbPath != "" {
+			if err := storage.MarkForRecovery(s.dbPath, status); err != nil {
+				log.Printf("[PolymarketService] Failed to mark database for recovery: %v", err)
+			}
+		}
+		s.eventBus.Emit("polymarket:db_integrity_failed", status)
+		s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+			Detector:  "system",
+			Signal:    "db_integrity",
+			Message:   "Database integrity check failed, it will be rebuilt on next start: " + status,
+			Score:     1,
+			Alert:     true,
+			Timestamp: now,
+		})
+	}
+	return status, nil
+}
+
+// OptimizeDatabase checkpoints the WAL, refreshes planner statistics and vacuums the
+// database to reclaim unused pages. Writes wait while it runs.
+func (s *PolymarketService) OptimizeDatabase() (*domain.DatabaseOptimizeResult, error) {
+	before, err := s.store.GetDatabaseInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database info: %w", err)
+	}
+
+	start := time.Now()
+	if err := s.store.Optimize(); err != nil {
+		return nil, err
+	}
+	s.markCheckpointed()
+
+	after, err := s.GetDatabaseInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database info: %w", err)
+	}
+	result := &domain.DatabaseOptimizeResult{
+		BytesBefore: before.SizeBytes + before.AnalysisSizeBytes + before.WALSizeBytes,
+		BytesAfter:  after.SizeBytes + after.AnalysisSizeBytes + after.WALSizeBytes,
+		DurationMs:  time.Since(start).Milliseconds(),
+		Info:        after,
+	}
+	log.Printf("[PolymarketService] Optimized database in %dms: %d -> %d bytes",
+		result.DurationMs, result.BytesBefore, result.BytesAfter)
+	return result, nil
+}
+
+// CompressRawData compresses the raw payloads of events saved before compression, then
+// optimizes the database so the file actually shrinks. Writes wait while it vacuums.
+func (s *PolymarketService) CompressRawData() (*domain.RawDataCompression, error) {
+	start := time.Now()
+	result, err := s.store.CompressRawData()
+	if err != nil {
+		return result, err
+	}
+	log.Printf("[PolymarketService] Compressed raw data of %d events in %s: %d -> %d bytes",
+		result.RowsCompressed, time.Since(start).Round(time.Millisecond), result.BytesBefore, result.BytesAfter)
+	if result.RowsCompressed == 0 {
+		return result, nil
+	}
+	optimized, err := s.OptimizeDatabase()
+	if err != nil {
+		return result, fmt.Errorf("raw data compressed, but the database wasn't vacuumed: %w", err)
+	}
+	result.Optimize = optimized
+	return result, nil
+}
+
+// dbMaintenanceWorker checkpoints the WAL on schedule or when it grows too large, and
+// checks integrity periodically
+func (s *PolymarketService) dbMaintenanceWorker() {
+	check := time.NewTicker(walSizeCheckInterval)
+	defer check.Stop()
+	integrity := time.NewTimer(firstIntegrityCheckDelay)
+	defer integrity.Stop()
+
+	lastCheckpoint := time.Now()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-check.C:
+			interval, maxBytes := s.walCheckpointSettings()
+			walSize := s.store.WALSize()
+			if time.Since(lastCheckpoint) < interval && walSize < maxBytes {
+				continue
+			}
+			if err := s.store.CheckpointWAL(); err != nil {
+				log.Printf("[PolymarketService] %v", err)
+				continue
+			}
+			if walSize >= maxBytes {
+				log.Printf("[PolymarketService] Checkpointed WAL early at %d MB", walSize>>20)
+			}
+			lastCheckpoint = time.Now()
+			s.markCheckpointed()
+		case <-integrity.C:
+			if _, err := s.CheckDatabaseIntegrity(); err != nil {
+				log.Printf("[PolymarketService] %v", err)
+			}
+			integrity.Reset(integrityCheckInterval)
+		}
+	}
+}
+
+// walCheckpointSettings returns the configured checkpoint interval and WAL size limit
+func (s *PolymarketService) walCheckpointSettings() (time.Duration, int64) {
+	s.mu.RLock()
+	minutes, mb := s.config.WALCheckpointMinutes, s.config.WALCheckpointMB
+	s.mu.RUnlock()
+
+	interval := time.Duration(minutes) * time.Minute
+	if minutes <= 0 {
+		interval = defaultWALCheckpointInterval
+	}
+	if mb <= 0 {
+		mb = defaultWALCheckpointMB
+	}
+	return interval, int64(mb) << 20
+}
+
+// applyPragmas passes the configured busy timeout and synchronous mode to the store
+func (s *PolymarketService) applyPragmas(config domain.PolymarketConfig) {
+	if err := s.store.SetPragmas(config.BusyTimeoutMs, config.Synchronous); err != nil {
+		log.Printf("[PolymarketService] Failed to apply SQLite settings: %v", err)
+	}
+}
+
+// markCheckpointed records a completed WAL checkpoint
+func (s *PolymarketService) markCheckpointed() {
+	s.dbHealthMu.Lock()
+	s.dbHealth.lastCheckpointAt = time.Now()
+	s.dbHealthMu.Unlock()
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWALCheckpointSettings(t *testing.T) {
+	svc, _ := newTestService(t)
+	if interval, maxBytes := svc.walCheckpointSettings(); interval != defaultWALCheckpointInterval || maxBytes != defaultWALCheckpointMB<<20 {
+		t.Errorf("defaults = %v and %d bytes, want %v and %d MB", interval, maxBytes, defaultWALCheckpointInterval, defaultWALCheckpointMB)
+	}
+
+	svc.mu.Lock()
+	svc.config.WALCheckpointMinutes, svc.config.WALCheckpointMB = 30, 8
+	svc.mu.Unlock()
+	if interval, maxBytes := svc.walCheckpointSettings(); interval != 30*time.Minute || maxBytes != 8<<20 {
+		t.Errorf("configured = %v and %d bytes, want 30m and 8 MB", interval, maxBytes)
+	}
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"log"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+// RegisterDetector adds a custom detector that runs on every saved event
+func (s *PolymarketService) RegisterDetector(detector ports.Detector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.detectors = append(s.detectors, detector)
+	log.Printf("[PolymarketService] Registered detector: %s", detector.Name())
+}
+
+// runDetectors runs custom detectors on an event, merging their signals into it.
+// Detectors receive a copy enriched with the stored wallet profile when available.
+func (s *PolymarketService) runDetectors(event *domain.PolymarketEvent) {
+	s.mu.RLock()
+	detectors := s.detectors
+	s.mu.RUnlock()
+
+	if len(detectors) == 0 {
+		return
+	}
+
+	enriched := *event
+	if enriched.WalletProfile == nil && enriched.WalletAddress != "" {
+		if profile, err := s.store.GetWallet(enriched.WalletAddress); err == nil && profile.BetCount >= 0 {
+			enriched.WalletProfile = profile
+		}
+	}
+
+	for _, detector := range detectors {
+		signals, err := detector.Detect(enriched)
+		if err != nil {
+			log.Printf("[PolymarketService] Detector %s failed: %v", detector.Name(), err)
+			continue
+		}
+
+		for _, signal := range signals {
+			label := signal.Message
+			if label == "" {
+				label = signal.Signal
+			}
+			event.RiskSignals = append(event.RiskSignals, "🧩 "+label)
+			if signal.Score > event.RiskScore {
+				event.RiskScore = signal.Score
+			}
+
+			if !event.Muted {
+				s.eventBus.Emit("polymarket:detector_signal", signal)
+			}
+		}
+	}
+}
+
+// applyLongshotSignal flags large bets placed at extreme prices
+func applyLongshotSignal(event *domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade {
+		return
+	}
+	if signal := polymarket.LongshotSignal(*event); signal != "" {
+		event.RiskSignals = append(event.RiskSignals, signal)
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// defaultEntityFlowWindow is the window entity flow covers without explicit bounds
+	defaultEntityFlowWindow = 24 * time.Hour
+
+	// entityFlowReportCheckInterval is how often the daily entity flow report is checked for
+	entityFlowReportCheckInterval = 5 * time.Minute
+)
+
+// GetEntityFlow sums the trade flow into the markets mentioning each entity between
+// since and until (default the last 24 hours): volume, fresh-wallet flow and
+// smart-money flow, the most fresh-wallet flow first
+func (s *PolymarketService) GetEntityFlow(since, until time.Time, limit int) (*domain.EntityFlowReport, error) {
+	now := time.Now()
+	if until.IsZero() {
+		until = now
+	}
+	if since.IsZero() {
+		since = until.Add(-defaultEntityFlowWindow)
+	}
+	if !since.Before(until) {
+		return nil, fmt.Errorf("since must be before until")
+	}
+
+	flows, err := s.store.GetEntityTradeFlows(since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entity flow: %w", err)
+	}
+
+	type totals struct {
+		flow    domain.EntityFlow
+		markets map[string]bool
+		wallets map[string]bool
+		fresh   map[string]bool
+		smart   map[string]bool
+	}
+	byEntity := make(map[domain.MarketEntity]*totals)
+	smart := make(map[string]bool)
+	for _, f := range flows {
+		t, ok := byEntity[f.Entity]
+		if !ok {
+			t = &totals{
+				flow:    domain.EntityFlow{MarketEntity: f.Entity},
+				markets: make(map[string]bool),
+				wallets: make(map[string]bool),
+				fresh:   make(map[string]bool),
+				smart:   make(map[string]bool),
+			}
+			byEntity[f.Entity] = t
+		}
+		t.flow.Volume += f.Volume
+		t.flow.Trades += f.Trades
+		t.markets[f.MarketSlug] = true
+		if f.Wallet == "" {
+			continue
+		}
+		t.wallets[f.Wallet] = true
+		if f.Fresh {
+			t.flow.FreshWalletFlow += f.Volume
+			t.fresh[f.Wallet] = true
+		}
+		isSmart, known := smart[f.Wallet]
+		if !known {
+			isSmart = s.isSmartMoney(f.Wallet)
+			smart[f.Wallet] = isSmart
+		}
+		if isSmart {
+			t.flow.SmartMoneyFlow += f.Volume
+			t.smart[f.Wallet] = true
+		}
+	}
+
+	report := &domain.EntityFlowReport{Since: since, Until: until, GeneratedAt: now, Entities: []domain.EntityFlow{}}
+	for _, t := range byEntity {
+		t.flow.Markets = len(t.markets)
+		t.flow.Wallets = len(t.wallets)
+		t.flow.FreshWallets = len(t.fresh)
+		t.flow.SmartMoneyWallets = len(t.smart)
+		if t.flow.Volume > 0 {
+			t.flow.FreshShare = t.flow.FreshWalletFlow / t.flow.Volume
+		}
+		report.Entities = append(report.Entities, t.flow)
+	}
+	sort.Slice(report.Entities, func(i, j int) bool {
+		a, b := report.Entities[i], report.Entities[j]
+		if a.FreshWalletFlow != b.FreshWalletFlow {
+			return a.FreshWalletFlow > b.FreshWalletFlow
+		}
+		if a.Volume != b.Volume {
+			return a.Volume > b.Volume
+		}
+		return a.Name < b.Name
+	})
+	if limit := entityListLimit(limit); len(report.Entities) > limit {
+		report.Entities = report.Entities[:limit]
+	}
+	return report, nil
+}
+
+// entityFlowReportWorker sends the entity flow of the past 24 hours once a day at the
+// configured UTC hour, when the report is turned on
+func (s *PolymarketService) entityFlowReportWorker() {
+	ticker := time.NewTicker(entityFlowReportCheckInterval)
+	defer ticker.Stop()
+
+	var lastUntil time.Time
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			lastUntil = s.sendEntityFlowReport(time.Now().UTC(), lastUntil)
+		}
+	}
+}
+
+// sendEntityFlowReport emits the report of the 24 hours up to today's report hour once
+// that hour has passed, unless it was already emitted, and returns the end of the last
+// report emitted. The notification is deduplicated by report window, so a restart
+// doesn't send it twice.
+func (s *PolymarketService) sendEntityFlowReport(now, lastUntil time.Time) time.Time {
+	s.mu.RLock()
+	enabled, hour := s.config.EntityFlowReport, s.config.EntityFlowReportHour
+	s.mu.RUnlock()
+	if hour < 0 || hour > 23 {
+		hour = 0
+	}
+	until := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC)
+	if !enabled || now.Before(until) || until.Equal(lastUntil) {
+		return lastUntil
+	}
+
+	report, err := s.GetEntityFlow(until.Add(-defaultEntityFlowWindow), until, 0)
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to build entity flow report: %v", err)
+		return lastUntil
+	}
+	s.eventBus.Emit("polymarket:entity_flow_report", *report)
+	log.Printf("[PolymarketService] Sent entity flow report for %d entities", len(report.Entities))
+	return until
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestEntityFlowSumsFreshAndSmartMoney(t *testing.T) {
+	svc, rec := newTestService(t)
+	now := time.Now().UTC()
+	trump := domain.MarketEntity{Type: domain.MarketEntityPerson, Name: "Donald Trump"}
+	nvda := domain.MarketEntity{Type: domain.MarketEntityTicker, Name: "NVDA"}
+	svc.store.SaveMarketEntities("tariffs", "Trump tariffs by June?", []domain.MarketEntity{trump})
+	svc.store.SaveMarketEntities("pardon", "Trump pardons?", []domain.MarketEntity{trump})
+	svc.store.SaveMarketEntities("nvda", "NVDA up?", []domain.MarketEntity{nvda})
+
+	trade := func(id, slug, wallet, size string, fresh bool, at time.Time) domain.PolymarketEvent {
+		return domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: id, AssetID: "1", MarketSlug: slug, WalletAddress: wallet,
+			Price: "0.5", Size: size, IsFreshWallet: fresh, Timestamp: at,
+		}
+	}
+	svc.store.SaveEvents([]domain.PolymarketEvent{
+		trade("a", "tariffs", "0xfresh", "2000", true, now.Add(-time.Hour)),
+		trade("b", "pardon", "0xfresh", "4000", true, now.Add(-2*time.Hour)),
+		trade("c", "pardon", "0xsmart", "6000", false, now.Add(-3*time.Hour)),
+		trade("d", "nvda", "0xsmart", "50000", false, now.Add(-time.Hour)),
+		trade("e", "tariffs", "0xold", "9999", true, now.Add(-48*time.Hour)), // Outside the window
+	})
+	svc.streaksMu.Lock()
+	svc.streaks["0xsmart"] = domain.WalletStreak{ResolvedBets: 10, Wins: 8}
+	svc.streaksMu.Unlock()
+
+	report, err := svc.GetEntityFlow(time.Time{}, time.Time{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Entities) != 2 {
+		t.Fatalf("entities = %+v, want Trump and NVDA", report.Entities)
+	}
+	// Trump leads on fresh-wallet flow though NVDA had more volume
+	got := report.Entities[0]
+	if got.MarketEntity != trump || got.Markets != 2 || got.Trades != 3 || got.Wallets != 2 || got.Volume != 6000 ||
+		got.FreshWalletFlow != 3000 || got.FreshWallets != 1 || got.SmartMoneyFlow != 3000 || got.SmartMoneyWallets != 1 || got.FreshShare != 0.5 {
+		t.Errorf("Trump flow = %+v, want $6k over 2 markets, half fresh and half smart", got)
+	}
+	if report.Entities[1].SmartMoneyFlow != 25000 {
+		t.Errorf("NVDA flow = %+v, want $25k smart money", report.Entities[1])
+	}
+	if _, err := svc.GetEntityFlow(now, now.Add(-time.Hour), 0); err == nil {
+		t.Error("accepted a window ending before it starts")
+	}
+
+	// The daily report goes out once after its hour, and only when turned on
+	svc.mu.Lock()
+	svc.config.EntityFlowReportHour = 8
+	svc.mu.Unlock()
+	morning := time.Date(now.Year(), now.Month(), now.Day(), 9, 0, 0, 0, time.UTC)
+	if last := svc.sendEntityFlowReport(morning, time.Time{}); !last.IsZero() {
+		t.Error("sent the report while it is turned off")
+	}
+	svc.mu.Lock()
+	svc.config.EntityFlowReport = true
+	svc.mu.Unlock()
+	if last := svc.sendEntityFlowReport(morning.Add(-2*time.Hour), time.Time{}); !last.IsZero() {
+		t.Error("sent the report before its hour")
+	}
+	last := svc.sendEntityFlowReport(morning, time.Time{})
+	if want := morning.Add(-time.Hour); !last.Equal(want) {
+		t.Errorf("report window ends at %v, want %v", last, want)
+	}
+	svc.sendEntityFlowReport(morning.Add(time.Hour), last)
+	if reports := rec.of("polymarket:entity_flow_report"); len(reports) != 1 {
+		t.Errorf("emitted %d reports, want 1 per day", len(reports))
+	}
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// ExportEvents writes the stored events matching filter to w as CSV or JSONL, newest
+// first, and returns how many were written. A filter limit of 0 exports every match.
+func (s *PolymarketService) ExportEvents(filter domain.PolymarketEventFilter, format domain.EventExportFormat, w io.Writer) (int, error) {
+	if !format.Valid() {
+		return 0, fmt.Errorf("unsupported export format: %q", format)
+	}
+	return s.store.ExportEvents(filter, format, w)
+}
+
+// ExportEventsFile exports the events matching filter to a file in the exports
+// directory next to the database
+func (s *PolymarketService) ExportEventsFile(filter domain.PolymarketEventFilter, format domain.EventExportFormat) (*domain.EventExport, error) {
+	if format == "" {
+		format = domain.EventExportCSV
+	}
+	if !format.Valid() {
+		return nil, fmt.Errorf("unsupported export format: %q", format)
+	}
+	dir := filepath.Join(filepath.Dir(s.dbPath), "exports")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("events-%s.%s", time.Now().Format("20060102-150405"), format))
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export: %w", err)
+	}
+
+	rows, err := s.ExportEvents(filter, format, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	log.Printf("[PolymarketService] Exported %d events to %s", rows, path)
+	return &domain.EventExport{Path: path, Rows: rows}, nil
+}
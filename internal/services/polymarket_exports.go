@@ -0,0 +1,175 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/report"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// eventExportsSettingKey is the settings key export definitions are persisted under
+	eventExportsSettingKey = "event_export_definitions"
+
+	// eventExportLimit bounds the events of one export when the filter sets no limit
+	eventExportLimit = 50000
+)
+
+// SaveEventExport creates or replaces an export definition. Column templates are
+// compiled first so a broken definition is rejected instead of saved.
+func (s *PolymarketService) SaveEventExport(def domain.EventExportDefinition) error {
+	def.Name = strings.TrimSpace(def.Name)
+	if def.Name == "" {
+		return fmt.Errorf("export name is required")
+	}
+	if len(def.Columns) == 0 {
+		def.Columns = domain.DefaultExportColumns()
+	}
+	if _, err := report.CompileExportColumns(def.Columns); err != nil {
+		return err
+	}
+
+	defs := s.GetEventExports()
+	replaced := false
+	for i := range defs {
+		if strings.EqualFold(defs[i].Name, def.Name) {
+			defs[i] = def
+			replaced = true
+		}
+	}
+	if !replaced {
+		defs = append(defs, def)
+	}
+	if err := s.store.SaveSetting(eventExportsSettingKey, defs); err != nil {
+		return fmt.Errorf("failed to save export definitions: %w", err)
+	}
+
+	log.Printf("[PolymarketService] Saved event export %q with %d columns", def.Name, len(def.Columns))
+	return nil
+}
+
+// DeleteEventExport removes an export definition
+func (s *PolymarketService) DeleteEventExport(name string) error {
+	defs := s.GetEventExports()
+	kept := defs[:0]
+	for _, def := range defs {
+		if !strings.EqualFold(def.Name, name) {
+			kept = append(kept, def)
+		}
+	}
+	if len(kept) == len(defs) {
+		return nil
+	}
+	if err := s.store.SaveSetting(eventExportsSettingKey, kept); err != nil {
+		return fmt.Errorf("failed to save export definitions: %w", err)
+	}
+	log.Printf("[PolymarketService] Deleted event export %q", name)
+	return nil
+}
+
+// GetEventExports returns the saved export definitions sorted by name
+func (s *PolymarketService) GetEventExports() []domain.EventExportDefinition {
+	defs := []domain.EventExportDefinition{}
+	if err := s.store.LoadSetting(eventExportsSettingKey, &defs); err != nil {
+		return []domain.EventExportDefinition{}
+	}
+	sort.Slice(defs, func(i, j int) bool { return strings.ToLower(defs[i].Name) < strings.ToLower(defs[j].Name) })
+	return defs
+}
+
+// RunEventExport runs a saved export and writes its CSV next to the database
+func (s *PolymarketService) RunEventExport(name string) (*domain.EventExport, error) {
+	var def *domain.EventExportDefinition
+	for _, d := range s.GetEventExports() {
+		if strings.EqualFold(d.Name, name) {
+			def = &d
+			break
+		}
+	}
+	if def == nil {
+		return nil, fmt.Errorf("export %q not found", name)
+	}
+
+	columns, err := report.CompileExportColumns(def.Columns)
+	if err != nil {
+		return nil, err
+	}
+	filter := def.Filter
+	if filter.Limit <= 0 || filter.Limit > eventExportLimit {
+		filter.Limit = eventExportLimit
+	}
+	events, err := s.store.GetEvents(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+
+	rows := make([]domain.ExportRow, 0, len(events))
+	wallets := make(map[string]*domain.WalletProfile)
+	for _, event := range events {
+		rows = append(rows, s.exportRow(event, wallets))
+	}
+
+	export, err := columns.WriteCSV(filepath.Join(filepath.Dir(s.dbPath), "exports"), def.Name, rows, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[PolymarketService] Exported %d events to %s (%d failed cells)", export.Rows, export.Path, export.FailedCells)
+	return export, nil
+}
+
+// exportRow computes the template data of an event. Wallet profiles are looked up
+// once per address through the wallets cache.
+func (s *PolymarketService) exportRow(event domain.PolymarketEvent, wallets map[string]*domain.WalletProfile) domain.ExportRow {
+	price, _ := strconv.ParseFloat(event.Price, 64)
+	size, _ := strconv.ParseFloat(event.Size, 64)
+	market := event.MarketName
+	if market == "" {
+		market = event.EventTitle
+	}
+	row := domain.ExportRow{
+		Time:               event.Timestamp,
+		Type:               string(event.EventType),
+		Market:             market,
+		MarketLink:         event.MarketLink,
+		Wallet:             event.WalletAddress,
+		Trader:             event.TraderName,
+		Side:               string(event.Side),
+		Outcome:            event.Outcome,
+		Price:              price,
+		Size:               size,
+		Notional:           price * size,
+		ImpliedProbability: price,
+		BetCount:           -1,
+		FreshnessLabel:     "Unknown",
+		RiskScore:          event.RiskScore,
+		RiskSignals:        event.RiskSignals,
+		Tags:               event.Tags,
+	}
+
+	profile := event.WalletProfile
+	if event.WalletAddress != "" && (profile == nil || profile.FreshnessLevel == "") {
+		cached, ok := wallets[event.WalletAddress]
+		if !ok {
+			cached, _ = s.store.GetWallet(event.WalletAddress)
+			wallets[event.WalletAddress] = cached
+		}
+		if cached != nil {
+			profile = cached
+		}
+	}
+	if profile != nil {
+		row.BetCount = profile.BetCount
+		row.Freshness = string(profile.FreshnessLevel)
+		row.FreshnessLabel = "Established"
+		if label, ok := domain.FreshnessLabels[profile.FreshnessLevel]; ok {
+			row.FreshnessLabel = label
+		}
+	}
+	return row
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// filterPresetsSettingKey is the settings key filter presets are persisted under
+	filterPresetsSettingKey = "filter_presets"
+
+	// maxFilterPresets caps how many filter presets can be saved
+	maxFilterPresets = 50
+)
+
+// GetFilterPresets returns the saved filter presets by name
+func (s *PolymarketService) GetFilterPresets() ([]domain.FilterPreset, error) {
+	s.presetsMu.Lock()
+	defer s.presetsMu.Unlock()
+	return s.loadFilterPresetsLocked()
+}
+
+// GetFilterPreset returns the preset with the name, matched case-insensitively
+func (s *PolymarketService) GetFilterPreset(name string) (*domain.FilterPreset, error) {
+	presets, err := s.GetFilterPresets()
+	if err != nil {
+		return nil, err
+	}
+	if i := findFilterPreset(presets, name); i >= 0 {
+		return &presets[i], nil
+	}
+	return nil, fmt.Errorf("no filter preset named %q", strings.TrimSpace(name))
+}
+
+// SaveFilterPreset creates a preset or replaces the filter of the one with the same
+// name. The filter's offset isn't saved; its limit is.
+func (s *PolymarketService) SaveFilterPreset(name string, filter domain.PolymarketEventFilter) (*domain.FilterPreset, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("preset name is required")
+	}
+	filter.Offset = 0
+
+	s.presetsMu.Lock()
+	presets, err := s.loadFilterPresetsLocked()
+	if err != nil {
+		s.presetsMu.Unlock()
+		return nil, err
+	}
+	now := time.Now()
+	preset := domain.FilterPreset{Name: name, Filter: filter, CreatedAt: now, UpdatedAt: now}
+	if i := findFilterPreset(presets, name); i >= 0 {
+		preset.CreatedAt = presets[i].CreatedAt
+		presets[i] = preset
+	} else if len(presets) >= maxFilterPresets {
+		s.presetsMu.Unlock()
+		return nil, fmt.Errorf("at most %d filter presets can be saved", maxFilterPresets)
+	} else {
+		presets = append(presets, preset)
+	}
+	err = s.saveFilterPresetsLocked(presets)
+	s.presetsMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[PolymarketService] Filter preset %q saved", name)
+	s.eventBus.Emit("polymarket:filter_presets_changed", presets)
+	return &preset, nil
+}
+
+// RenameFilterPreset renames a preset, keeping its filter
+func (s *PolymarketService) RenameFilterPreset(name, newName string) (*domain.FilterPreset, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, fmt.Errorf("preset name is required")
+	}
+
+	s.presetsMu.Lock()
+	presets, err := s.loadFilterPresetsLocked()
+	if err != nil {
+		s.presetsMu.Unlock()
+		return nil, err
+	}
+	i := findFilterPreset(presets, name)
+	if i < 0 {
+		s.presetsMu.Unlock()
+		return nil, fmt.Errorf("no filter preset named %q", strings.TrimSpace(name))
+	}
+	if j := findFilterPreset(presets, newName); j >= 0 && j != i {
+		s.presetsMu.Unlock()
+		return nil, fmt.Errorf("a filter preset named %q already exists", presets[j].Name)
+	}
+	presets[i].Name = newName
+	presets[i].UpdatedAt = time.Now()
+	preset := presets[i]
+	err = s.saveFilterPresetsLocked(presets)
+	s.presetsMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	s.eventBus.Emit("polymarket:filter_presets_changed", presets)
+	return &preset, nil
+}
+
+// DeleteFilterPreset deletes a preset; deleting an unknown preset is not an error
+func (s *PolymarketService) DeleteFilterPreset(name string) error {
+	s.presetsMu.Lock()
+	presets, err := s.loadFilterPresetsLocked()
+	if err != nil {
+		s.presetsMu.Unlock()
+		return err
+	}
+	i := findFilterPreset(presets, name)
+	if i < 0 {
+		s.presetsMu.Unlock()
+		return nil
+	}
+	presets = append(presets[:i], presets[i+1:]...)
+	err = s.saveFilterPresetsLocked(presets)
+	s.presetsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[PolymarketService] Filter preset %q deleted", strings.TrimSpace(name))
+	s.eventBus.Emit("polymarket:filter_presets_changed", presets)
+	return nil
+}
+
+// ApplyFilterPreset returns the stored events matching a preset's filter. A limit or
+// offset above 0 overrides the preset's paging.
+func (s *PolymarketService) ApplyFilterPreset(name string, limit, offset int) ([]domain.PolymarketEvent, error) {
+	preset, err := s.GetFilterPreset(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEvents(preset.PageFilter(limit, offset))
+}
+
+// loadFilterPresetsLocked reads the presets, sorted by name. Caller must hold presetsMu.
+func (s *PolymarketService) loadFilterPresetsLocked() ([]domain.FilterPreset, error) {
+	presets := []domain.FilterPreset{}
+	err := s.store.LoadSetting(filterPresetsSettingKey, &presets)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load filter presets: %w", err)
+	}
+	sort.Slice(presets, func(i, j int) bool { return strings.ToLower(presets[i].Name) < strings.ToLower(presets[j].Name) })
+	return presets, nil
+}
+
+// saveFilterPresetsLocked persists the presets. Caller must hold presetsMu.
+func (s *PolymarketService) saveFilterPresetsLocked(presets []domain.FilterPreset) error {
+	if err := s.store.SaveSetting(filterPresetsSettingKey, presets); err != nil {
+		return fmt.Errorf("failed to save filter presets: %w", err)
+	}
+	return nil
+}
+
+// findFilterPreset returns the index of the preset with the name, or -1
+func findFilterPreset(presets []domain.FilterPreset, name string) int {
+	name = strings.TrimSpace(name)
+	for i, preset := range presets {
+		if strings.EqualFold(preset.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
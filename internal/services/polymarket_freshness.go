@@ -0,0 +1,50 @@
+package services
+
+import (
+	"log"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// RecomputeFreshness re-evaluates all stored wallets and event fresh wallet flags
+// against the current config thresholds
+func (s *PolymarketService) RecomputeFreshness() (*domain.FreshnessRecomputeResult, error) {
+	s.mu.RLock()
+	analyzer := s.walletAnalyzer
+	s.mu.RUnlock()
+
+	result, err := s.store.RecomputeFreshness(analyzer.ClassifyBetCount)
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to recompute freshness: %v", err)
+		return nil, err
+	}
+
+	log.Printf("[PolymarketService] Freshness recomputed: scanned=%d changed=%d fresh=%d events=%d (%dms)",
+		result.WalletsScanned, result.WalletsChanged, result.FreshWallets, result.EventsUpdated, result.DurationMs)
+	s.eventBus.Emit("polymarket:freshness_recomputed", *result)
+	s.publishFreshnessChanges(result.Changes)
+
+	return result, nil
+}
+
+// publishFreshnessChanges publishes a wallet update, with its webhook and case sync, for
+// every wallet a recompute moved to another freshness level
+func (s *PolymarketService) publishFreshnessChanges(changes []domain.WalletFreshnessChange) {
+	for _, change := range changes {
+		profile, err := s.store.GetWallet(change.Address)
+		if err != nil || profile == nil {
+			continue
+		}
+		previous := *profile
+		previous.FreshnessLevel = change.PreviousLevel
+		s.publishWalletUpdate(*profile, &previous)
+	}
+}
+
+// freshnessThresholdsChanged reports whether a config change affects wallet freshness
+func freshnessThresholdsChanged(old, updated domain.PolymarketConfig) bool {
+	return old.FreshInsiderMaxBets != updated.FreshInsiderMaxBets ||
+		old.FreshWalletMaxBets != updated.FreshWalletMaxBets ||
+		old.FreshNewbieMaxBets != updated.FreshNewbieMaxBets ||
+		old.CustomFreshMaxBets != updated.CustomFreshMaxBets
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestRecomputeFreshnessPublishesChangedWallets(t *testing.T) {
+	svc, rec := newTestService(t)
+	wallets := []domain.WalletProfile{
+		// Classified under older thresholds: 2 bets now makes an insider
+		{Address: "0x00000000000000000000000000000000000000d1", BetCount: 2, FreshnessLevel: domain.FreshnessNewbie, IsFresh: true},
+		// Already up to date
+		{Address: "0x00000000000000000000000000000000000000d2", BetCount: 500, FreshnessLevel: domain.FreshnessNone},
+	}
+	for _, w := range wallets {
+		if err := svc.store.SaveWallet(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := svc.RecomputeFreshness()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.WalletsChanged != 1 {
+		t.Fatalf("wallets changed = %d, want 1", result.WalletsChanged)
+	}
+
+	updates := rec.of("polymarket:wallet_updated")
+	if len(updates) != 1 {
+		t.Fatalf("got %d wallet updates, want 1", len(updates))
+	}
+	update := updates[0].(domain.WalletUpdate)
+	if update.Type != domain.WalletUpdateFreshnessChanged || update.Wallet.Address != wallets[0].Address ||
+		update.PreviousFreshnessLevel != domain.FreshnessNewbie || update.Wallet.FreshnessLevel != domain.FreshnessInsider {
+		t.Errorf("wallet update = %+v, want %s moved from newbie to insider", update, wallets[0].Address)
+	}
+}
+
+func TestThresholdChangeRecomputesFreshness(t *testing.T) {
+	svc, rec := newTestService(t)
+	if err := svc.store.SaveWallet(domain.WalletProfile{Address: "0xa", BetCount: 30, FreshnessLevel: domain.FreshnessNone}); err != nil {
+		t.Fatal(err)
+	}
+
+	config := svc.GetConfig()
+	config.MinTradeSize++
+	svc.UpdateConfig(config)
+	time.Sleep(50 * time.Millisecond)
+	if len(rec.of("polymarket:freshness_recomputed")) != 0 {
+		t.Error("a change that keeps the thresholds recomputed freshness")
+	}
+
+	config.FreshNewbieMaxBets = 50
+	svc.UpdateConfig(config)
+	waitFor(t, "the recompute", func() bool { return len(rec.of("polymarket:freshness_recomputed")) == 1 })
+	if w, _ := svc.store.GetWallet("0xa"); w == nil || !w.IsFresh {
+		t.Errorf("wallet = %+v, want it fresh under the raised threshold", w)
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// fundingOriginsSettingKey is the settings key found funding origins are persisted under
+	fundingOriginsSettingKey = "funding_origins"
+
+	// fundingLabelsFile holds the user's funding labels, next to the database
+	fundingLabelsFile = "funding_labels.json"
+
+	// defaultFundingLookbackHours is used when the config leaves the lookback unset
+	defaultFundingLookbackHours = 72
+
+	// fundingRecheckInterval is how long a wallet without a deposit in the lookback
+	// isn't searched again
+	fundingRecheckInterval = 24 * time.Hour
+
+	// fundingQueueSize bounds the fresh wallets waiting for a lookup; more are dropped
+	fundingQueueSize = 100
+
+	// maxFundingOrigins bounds the persisted origins and the recheck bookkeeping
+	maxFundingOrigins = 5000
+)
+
+// GetWalletFunding returns where a wallet's first USDC deposit came from, searching the
+// chain when it isn't known yet. Returns nil when there was no deposit in the lookback.
+func (s *PolymarketService) GetWalletFunding(address string) (*domain.FundingOrigin, error) {
+	wallet := strings.ToLower(strings.TrimSpace(address))
+	if wallet == "" {
+		return nil, fmt.Errorf("wallet address is required")
+	}
+	if origin := s.fundingOrigin(wallet); origin != nil {
+		return origin, nil
+	}
+	return s.lookupFunding(wallet)
+}
+
+// fundingOrigin returns a wallet's known funding origin, or nil
+func (s *PolymarketService) fundingOrigin(wallet string) *domain.FundingOrigin {
+	s.fundMu.Lock()
+	defer s.fundMu.Unlock()
+	if origin, ok := s.funding[strings.ToLower(wallet)]; ok {
+		return &origin
+	}
+	return nil
+}
+
+// queueFundingLookup has the funding worker search a fresh wallet's first deposit
+func (s *PolymarketService) queueFundingLookup(address string) {
+	if s.chainClient() == nil {
+		return
+	}
+	wallet := strings.ToLower(address)
+	s.fundMu.Lock()
+	_, known := s.funding[wallet]
+	recent := time.Since(s.fundChecked[wallet]) < fundingRecheckInterval
+	s.fundMu.Unlock()
+	if known || recent {
+		return
+	}
+	select {
+	case s.fundQueue <- wallet:
+	default: // Queue full; the wallet is queued again when it is next found fresh
+	}
+}
+
+// fundingWorker searches the funding origin of queued fresh wallets
+func (s *PolymarketService) fundingWorker() {
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case wallet := <-s.fundQueue:
+			if _, err := s.lookupFunding(wallet); err != nil {
+				log.Printf("[PolymarketService] Funding lookup failed: %v", err)
+				s.errReporter.Report("funding lookup", err)
+			}
+		}
+	}
+}
+
+// lookupFunding searches a wallet's first USDC deposit within the lookback and labels
+// its sender. Returns nil when there is none.
+func (s *PolymarketService) lookupFunding(wallet string) (*domain.FundingOrigin, error) {
+	chain := s.chainClient()
+	if chain == nil {
+		return nil, fmt.Errorf("funding lookups need a Polygon RPC URL")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	latest, err := chain.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the latest block: %w", err)
+	}
+	from := latest - min(latest, uint64(s.fundingLookback()/polygonBlockTime))
+	origin, err := chain.FirstDeposit(ctx, wallet, from, latest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search deposits of %s: %w", shortenAddress(wallet), err)
+	}
+
+	s.fundMu.Lock()
+	if len(s.fundChecked) >= maxFundingOrigins {
+		s.fundChecked = make(map[string]time.Time)
+	}
+	s.fundChecked[wallet] = time.Now()
+	s.fundMu.Unlock()
+	if origin == nil {
+		return nil, nil
+	}
+
+	if origin.At, err = chain.BlockTime(ctx, origin.Block); err != nil {
+		origin.At = time.Now().Add(-time.Duration(latest-origin.Block) * polygonBlockTime)
+	}
+	if label, ok := s.fundingLabels().Lookup(origin.From); ok {
+		origin.Name, origin.Kind = label.Name, label.Kind
+		log.Printf("[PolymarketService] Wallet %s was funded from %s (%s)", shortenAddress(wallet), label.Name, label.Kind)
+	}
+
+	s.fundMu.Lock()
+	if len(s.funding) >= maxFundingOrigins {
+		s.evictFundingLocked()
+	}
+	s.funding[wallet] = *origin
+	s.saveFundingLocked()
+	s.fundMu.Unlock()
+
+	s.eventBus.Emit("polymarket:wallet_funding", *origin)
+	return origin, nil
+}
+
+// applyFundingOrigin names the exchange or bridge that funded the wallet of a trade
+// that will alert, e.g. "Funded with $2k straight from Binance (KYC exchange)"
+func (s *PolymarketService) applyFundingOrigin(event *domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || event.WalletAddress == "" || len(event.RiskSignals) == 0 || event.Muted {
+		return
+	}
+	origin := s.fundingOrigin(event.WalletAddress)
+	if origin == nil || !origin.Labeled() {
+		return
+	}
+	s.mu.RLock()
+	minTradeSize, alertThreshold := s.config.MinTradeSize, s.config.AlertThreshold
+	s.mu.RUnlock()
+	if !meetsAlertThresholds(*event, minTradeSize, alertThreshold) {
+		return
+	}
+	event.RiskSignals = append(event.RiskSignals, fundingMessage(*origin))
+}
+
+// fundingMessage describes a labeled funding origin
+func fundingMessage(origin domain.FundingOrigin) string {
+	if origin.Kind == domain.FundingBridge {
+		return fmt.Sprintf("🌉 Funded with %s via %s (bridge, no KYC)", formatCompactUSD(origin.AmountUSD), origin.Name)
+	}
+	return fmt.Sprintf("🏦 Funded with %s straight from %s (KYC exchange)", formatCompactUSD(origin.AmountUSD), origin.Name)
+}
+
+// fundingLabels returns the built-in funding labels with the user's file applied. The
+// file is read on every lookup, so edits apply without a restart.
+func (s *PolymarketService) fundingLabels() polymarket.FundingLabels {
+	path := ""
+	if s.dbPath != "" {
+		path = filepath.Join(filepath.Dir(s.dbPath), fundingLabelsFile)
+	}
+	labels, err := polymarket.LoadFundingLabels(path)
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to load funding labels: %v", err)
+	}
+	return labels
+}
+
+// fundingLookback returns how far back deposits are searched
+func (s *PolymarketService) fundingLookback() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hours := s.config.FundingLookbackHours
+	if hours <= 0 {
+		hours = defaultFundingLookbackHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// evictFundingLocked drops the origin of the wallet funded longest ago. Caller must hold fundMu.
+func (s *PolymarketService) evictFundingLocked() {
+	oldest := ""
+	for wallet, origin := range s.funding {
+		if oldest == "" || origin.At.Before(s.funding[oldest].At) {
+			oldest = wallet
+		}
+	}
+	delete(s.funding, oldest)
+}
+
+// saveFundingLocked persists the found origins. Caller must hold fundMu.
+func (s *PolymarketService) saveFundingLocked() {
+	origins := make([]domain.FundingOrigin, 0, len(s.funding))
+	for _, origin := range s.funding {
+		origins = append(origins, origin)
+	}
+	if err := s.store.SaveSetting(fundingOriginsSettingKey, origins); err != nil {
+		log.Printf("[PolymarketService] Failed to save funding origins: %v", err)
+	}
+}
+
+// loadFunding restores the found origins
+func (s *PolymarketService) loadFunding() {
+	var origins []domain.FundingOrigin
+	s.store.LoadSetting(fundingOriginsSettingKey, &origins)
+
+	s.fundMu.Lock()
+	s.funding = make(map[string]domain.FundingOrigin, len(origins))
+	for _, origin := range origins {
+		s.funding[origin.Wallet] = origin
+	}
+	s.fundChecked = make(map[string]time.Time)
+	s.fundMu.Unlock()
+}
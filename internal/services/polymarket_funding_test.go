@@ -0,0 +1,51 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestWalletFundingLabelsTheSender(t *testing.T) {
+	svc, dbPath := newSQLiteTestService(t, "")
+	wallet := "0x" + strings.Repeat("a", 40)
+	coinbase := "0x" + strings.Repeat("c", 40)
+	exchange := "0x4bfb41d5b3570defd03c39a9a4d8de6bd8b8982e"
+	latest := uint64(60_000_000)
+	rpc := fakePolygon(t, latest,
+		usdcTransfer(exchange, wallet, 900, latest-3000), // Trade proceeds, not funding
+		usdcTransfer(coinbase, wallet, 2000, latest-2000),
+		usdcTransfer(coinbase, wallet, 5000, latest-1000),
+	)
+	svc.mu.Lock()
+	svc.config.PolygonRPCURL = rpc.URL
+	svc.mu.Unlock()
+	labels := `{"0x` + strings.ToUpper(coinbase[2:]) + `": {"name": "Coinbase", "kind": "exchange"}}`
+	if err := os.WriteFile(filepath.Join(filepath.Dir(dbPath), fundingLabelsFile), []byte(labels), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	origin, err := svc.GetWalletFunding(" 0x" + strings.ToUpper(wallet[2:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if origin == nil || origin.From != coinbase || origin.Name != "Coinbase" || origin.Kind != domain.FundingExchange || origin.AmountUSD != 2000 || origin.At.IsZero() {
+		t.Fatalf("origin = %+v, want the first $2k deposit from Coinbase", origin)
+	}
+
+	// Alerting trades by the wallet name the exchange, also after a restart
+	restarted := NewPolymarketService(svc.store, localbus.New(), "")
+	t.Cleanup(restarted.Close)
+	event := domain.PolymarketEvent{
+		EventType: domain.PolymarketEventTrade, WalletAddress: wallet, Price: "0.5", Size: "100000",
+		RiskSignals: []string{"🚨 Fresh Insider (0 bets)"},
+	}
+	restarted.applyFundingOrigin(&event)
+	if len(event.RiskSignals) != 2 || event.RiskSignals[1] != "🏦 Funded with $2k straight from Coinbase (KYC exchange)" {
+		t.Errorf("signals = %q, want the funding origin", event.RiskSignals)
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// defaultLiveGameHours is how long after its start a game counts as live
+	defaultLiveGameHours = 3
+
+	// gameLookupTimeout bounds the market lookup done while handling a trade
+	gameLookupTimeout = 3 * time.Second
+
+	// preGameFreshScore is the risk score of a pre-game bet by a fresh wallet
+	preGameFreshScore = 0.75
+)
+
+// scheduleLocations caches loaded time zones by name, so trades don't read the zone
+// database each time
+var scheduleLocations sync.Map
+
+// gameScheduleSettings returns how long games count as live, whether in-game trades
+// alert and the time zone game times are shown in
+func (s *PolymarketService) gameScheduleSettings() (liveFor time.Duration, liveAlerts bool, loc *time.Location) {
+	s.mu.RLock()
+	hours, liveAlerts, zone := s.config.LiveGameHours, s.config.LiveGameAlerts, s.config.ScheduleTimezone
+	s.mu.RUnlock()
+
+	if hours <= 0 {
+		hours = defaultLiveGameHours
+	}
+	return time.Duration(hours * float64(time.Hour)), liveAlerts, scheduleLocation(zone)
+}
+
+// scheduleLocation returns a time zone by IANA name, the system's for an empty or
+// unknown name
+func scheduleLocation(name string) *time.Location {
+	if name == "" {
+		return time.Local
+	}
+	if loc, ok := scheduleLocations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = time.Local
+	}
+	scheduleLocations.Store(name, loc)
+	return loc
+}
+
+// applyGameSchedule times a trade on a sports market against the game's scheduled
+// start. In-game trades mostly follow the score, so they are tagged and recorded
+// without alerts unless LiveGameAlerts is on. Pre-game trades are tagged, and a
+// pre-game bet by a fresh wallet, the classic informed bet, raises an alert.
+func (s *PolymarketService) applyGameSchedule(event *domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || event.MarketSlug == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gameLookupTimeout)
+	info, err := s.markets.GetMarket(ctx, event.MarketSlug)
+	cancel()
+	if err != nil || info == nil || info.GameStartTime.IsZero() {
+		return
+	}
+
+	liveFor, liveAlerts, loc := s.gameScheduleSettings()
+	phase := info.GamePhaseAt(event.Timestamp, liveFor)
+	if phase == "" {
+		return
+	}
+	if !containsString(event.Tags, string(phase)) {
+		event.Tags = append(event.Tags, string(phase))
+	}
+
+	if phase == domain.GamePhaseInGame {
+		if !liveAlerts {
+			event.Muted = true
+		}
+		return
+	}
+	if event.WalletAddress == "" {
+		return
+	}
+	profile, err := s.store.GetWallet(event.WalletAddress)
+	if err != nil || profile == nil || !profile.IsFresh {
+		return
+	}
+
+	start := info.GameStartTime.In(loc)
+	message := fmt.Sprintf("Pre-game bet by fresh wallet (%s, %s before the %s start)",
+		formatCompactUSD(parseNotionalValue(event.Price, event.Size)), formatAge(start.Sub(event.Timestamp)),
+		start.Format("Mon 15:04 MST"))
+	event.RiskSignals = append(event.RiskSignals, "🏟 "+message)
+	event.RiskScore = math.Max(event.RiskScore, preGameFreshScore)
+
+	if !event.Muted {
+		s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+			Detector: "game_schedule",
+			Signal:   "pre_game_fresh_wallet",
+			Message:  message,
+			Score:    preGameFreshScore,
+			Alert:    true,
+			Metadata: map[string]string{
+				"gameStartTime": info.GameStartTime.UTC().Format(time.RFC3339),
+				"freshness":     string(profile.FreshnessLevel),
+			},
+			TradeID:       event.TradeID,
+			WalletAddress: event.WalletAddress,
+			MarketName:    event.MarketName,
+			MarketLink:    event.MarketLink,
+			Timestamp:     event.Timestamp,
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestApplyGameScheduleTimesTradesAgainstKickOff(t *testing.T) {
+	svc, rec := newTestService(t)
+	svc.markets.SetTransport(gammaMarkets{
+		"lakers-celtics": `{"slug": "lakers-celtics", "gameStartTime": "2026-01-05 18:00:00+00"}`,
+		"fed-cut":        `{"slug": "fed-cut", "endDate": "2026-03-18T18:00:00Z"}`,
+	})
+	svc.mu.Lock()
+	svc.config.ScheduleTimezone = "Europe/Berlin"
+	svc.mu.Unlock()
+	if err := svc.store.SaveWallet(domain.WalletProfile{Address: "0xfresh", IsFresh: true, FreshnessLevel: domain.FreshnessInsider}); err != nil {
+		t.Fatal(err)
+	}
+
+	kickOff := time.Date(2026, 1, 5, 18, 0, 0, 0, time.UTC)
+	trade := func(slug, wallet string, at time.Time) domain.PolymarketEvent {
+		event := domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: slug + at.String(), MarketSlug: slug,
+			WalletAddress: wallet, Price: "0.5", Size: "10000", Timestamp: at,
+		}
+		svc.applyGameSchedule(&event)
+		return event
+	}
+
+	// A fresh wallet's pre-game bet alerts, with the start in the configured time zone
+	event := trade("lakers-celtics", "0xfresh", kickOff.Add(-2*time.Hour))
+	if !slices.Contains(event.Tags, string(domain.GamePhasePreGame)) || event.RiskScore != preGameFreshScore || event.Muted {
+		t.Errorf("pre-game bet by a fresh wallet = %+v, want it tagged pre-game and scored", event)
+	}
+	if len(event.RiskSignals) != 1 || !strings.Contains(event.RiskSignals[0], "2h before the Mon 19:00 CET start") {
+		t.Errorf("signals = %v, want the start in Berlin time", event.RiskSignals)
+	}
+	if signals := rec.of("polymarket:detector_signal"); len(signals) != 1 || signals[0].(domain.DetectorSignal).Signal != "pre_game_fresh_wallet" {
+		t.Errorf("signals = %+v, want the pre-game alert", signals)
+	}
+
+	// Other wallets' pre-game bets are tagged only
+	if event := trade("lakers-celtics", "0xold", kickOff.Add(-time.Hour)); len(event.RiskSignals) != 0 || !slices.Contains(event.Tags, "pre-game") {
+		t.Errorf("pre-game bet by a known wallet = %+v, want only the tag", event)
+	}
+
+	// In-game trades are recorded without alerts unless live alerts are on
+	if event := trade("lakers-celtics", "0xfresh", kickOff.Add(time.Hour)); !event.Muted || len(event.RiskSignals) != 0 || !slices.Contains(event.Tags, "in-game") {
+		t.Errorf("in-game trade = %+v, want it tagged and muted", event)
+	}
+	svc.mu.Lock()
+	svc.config.LiveGameAlerts = true
+	svc.mu.Unlock()
+	if event := trade("lakers-celtics", "0xfresh", kickOff.Add(time.Hour)); event.Muted {
+		t.Error("in-game trade muted with live alerts on")
+	}
+
+	// Trades after the game and on markets without one are left alone
+	for name, event := range map[string]domain.PolymarketEvent{
+		"after the game": trade("lakers-celtics", "0xfresh", kickOff.Add(4*time.Hour)),
+		"no game":        trade("fed-cut", "0xfresh", kickOff),
+	} {
+		if len(event.Tags) != 0 || len(event.RiskSignals) != 0 || event.Muted {
+			t.Errorf("%s: trade = %+v, want it untouched", name, event)
+		}
+	}
+	if signals := rec.of("polymarket:detector_signal"); len(signals) != 1 {
+		t.Errorf("emitted %d signals, want only the pre-game alert", len(signals))
+	}
+}
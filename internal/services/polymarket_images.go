@@ -0,0 +1,12 @@
+package services
+
+import "net/http"
+
+// MarketImages serves cached market thumbnails by their original URL (?url=), or
+// returns nil when the service runs without a database path
+func (s *PolymarketService) MarketImages() http.Handler {
+	if s.images == nil {
+		return nil
+	}
+	return s.images
+}
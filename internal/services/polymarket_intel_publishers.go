@@ -0,0 +1,113 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// intelPublishersSettingKey is the settings key trusted wallet intel publishers are
+// persisted under
+const intelPublishersSettingKey = "wallet_intel_trusted_publishers"
+
+// TrustIntelPublisher pins a publisher's public key, so wallet intel bundles it signed
+// can be imported. Trusting a pinned key again renames it.
+func (s *PolymarketService) TrustIntelPublisher(publicKey, name string) error {
+	publicKey = strings.TrimSpace(publicKey)
+	if key, err := base64.StdEncoding.DecodeString(publicKey); err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid publisher key: want a base64 Ed25519 public key")
+	}
+
+	s.intelMu.Lock()
+	defer s.intelMu.Unlock()
+
+	publishers, err := s.loadIntelPublishers()
+	if err != nil {
+		return err
+	}
+	entry := domain.TrustedIntelPublisher{PublicKey: publicKey, Name: strings.TrimSpace(name), AddedAt: time.Now().UTC()}
+	found := false
+	for i, p := range publishers {
+		if p.PublicKey == publicKey {
+			entry.AddedAt = p.AddedAt
+			publishers[i] = entry
+			found = true
+		}
+	}
+	if !found {
+		publishers = append(publishers, entry)
+	}
+	if err := s.store.SaveSetting(intelPublishersSettingKey, publishers); err != nil {
+		return fmt.Errorf("failed to save trusted publishers: %w", err)
+	}
+	log.Printf("[PolymarketService] Trusted wallet intel publisher %s", walletIntelPublisher(domain.WalletIntelBundle{Publisher: entry.Name, PublicKey: publicKey}))
+	return nil
+}
+
+// UntrustIntelPublisher unpins a publisher's key. Intel imported from it is kept.
+func (s *PolymarketService) UntrustIntelPublisher(publicKey string) error {
+	publicKey = strings.TrimSpace(publicKey)
+
+	s.intelMu.Lock()
+	defer s.intelMu.Unlock()
+
+	publishers, err := s.loadIntelPublishers()
+	if err != nil {
+		return err
+	}
+	kept := publishers[:0]
+	for _, p := range publishers {
+		if p.PublicKey != publicKey {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == len(publishers) {
+		return nil
+	}
+	if err := s.store.SaveSetting(intelPublishersSettingKey, kept); err != nil {
+		return fmt.Errorf("failed to save trusted publishers: %w", err)
+	}
+	return nil
+}
+
+// GetTrustedIntelPublishers returns the pinned publisher keys, oldest first
+func (s *PolymarketService) GetTrustedIntelPublishers() ([]domain.TrustedIntelPublisher, error) {
+	s.intelMu.Lock()
+	defer s.intelMu.Unlock()
+	return s.loadIntelPublishers()
+}
+
+// checkIntelPublisher rejects bundles not signed by a trusted publisher or by this
+// install's own signing key
+func (s *PolymarketService) checkIntelPublisher(bundle domain.WalletIntelBundle) error {
+	publishers, err := s.GetTrustedIntelPublishers()
+	if err != nil {
+		return err
+	}
+	for _, p := range publishers {
+		if p.PublicKey == bundle.PublicKey {
+			return nil
+		}
+	}
+	if own, err := s.walletIntelKey(); err == nil && base64.StdEncoding.EncodeToString(own.Public().(ed25519.PublicKey)) == bundle.PublicKey {
+		return nil
+	}
+	return fmt.Errorf("wallet intel from %s is signed by an untrusted key %s: add it as a trusted publisher to import it",
+		walletIntelPublisher(bundle), bundle.PublicKey)
+}
+
+// loadIntelPublishers reads the trusted publishers; the caller holds intelMu
+func (s *PolymarketService) loadIntelPublishers() ([]domain.TrustedIntelPublisher, error) {
+	publishers := []domain.TrustedIntelPublisher{}
+	if err := s.store.LoadSetting(intelPublishersSettingKey, &publishers); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load trusted publishers: %w", err)
+	}
+	return publishers, nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// CreateInvestigation opens a new named investigation
+func (s *PolymarketService) CreateInvestigation(name, description string) (*domain.Investigation, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("investigation name is required")
+	}
+
+	inv, err := s.store.CreateInvestigation(domain.Investigation{
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		Status:      domain.InvestigationOpen,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create investigation: %w", err)
+	}
+
+	log.Printf("[PolymarketService] Opened investigation #%d: %s", inv.ID, inv.Name)
+	s.eventBus.Emit("polymarket:investigation_updated", inv)
+	return inv, nil
+}
+
+// UpdateInvestigation changes the name, description or status of an investigation
+func (s *PolymarketService) UpdateInvestigation(inv domain.Investigation) (*domain.Investigation, error) {
+	inv.Name = strings.TrimSpace(inv.Name)
+	if inv.Name == "" {
+		return nil, fmt.Errorf("investigation name is required")
+	}
+	if !inv.Status.Valid() {
+		return nil, fmt.Errorf("invalid investigation status: %q", inv.Status)
+	}
+	inv.Description = strings.TrimSpace(inv.Description)
+
+	if err := s.store.UpdateInvestigation(inv); err != nil {
+		return nil, err
+	}
+	return s.emitInvestigation(inv.ID)
+}
+
+// GetInvestigation returns an investigation with its items and notes
+func (s *PolymarketService) GetInvestigation(id int64) (*domain.Investigation, error) {
+	return s.store.GetInvestigation(id)
+}
+
+// ListInvestigations returns investigations, optionally only those with the given status
+func (s *PolymarketService) ListInvestigations(status domain.InvestigationStatus) ([]domain.Investigation, error) {
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("invalid investigation status: %q", status)
+	}
+	return s.store.ListInvestigations(status)
+}
+
+// DeleteInvestigation removes an investigation with its items and notes
+func (s *PolymarketService) DeleteInvestigation(id int64) error {
+	if err := s.store.DeleteInvestigation(id); err != nil {
+		return err
+	}
+	log.Printf("[PolymarketService] Deleted investigation #%d", id)
+	s.eventBus.Emit("polymarket:investigation_deleted", id)
+	return nil
+}
+
+// AddInvestigationItem attaches a wallet address, market slug or event ID to an investigation
+func (s *PolymarketService) AddInvestigationItem(id int64, itemType domain.InvestigationItemType, ref string) (*domain.Investigation, error) {
+	ref, err := normalizeInvestigationRef(itemType, ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.AddInvestigationItem(id, domain.InvestigationItem{Type: itemType, Ref: ref}); err != nil {
+		return nil, err
+	}
+	return s.emitInvestigation(id)
+}
+
+// RemoveInvestigationItem detaches a wallet, market or event from an investigation
+func (s *PolymarketService) RemoveInvestigationItem(id int64, itemType domain.InvestigationItemType, ref string) (*domain.Investigation, error) {
+	ref, err := normalizeInvestigationRef(itemType, ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.RemoveInvestigationItem(id, itemType, ref); err != nil {
+		return nil, err
+	}
+	return s.emitInvestigation(id)
+}
+
+// AddInvestigationNote appends a note to an investigation
+func (s *PolymarketService) AddInvestigationNote(id int64, body string) (*domain.Investigation, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, fmt.Errorf("note is empty")
+	}
+	if _, err := s.store.AddInvestigationNote(id, body); err != nil {
+		return nil, err
+	}
+	return s.emitInvestigation(id)
+}
+
+// DeleteInvestigationNote removes a note from an investigation
+func (s *PolymarketService) DeleteInvestigationNote(id, noteID int64) (*domain.Investigation, error) {
+	if err := s.store.DeleteInvestigationNote(id, noteID); err != nil {
+		return nil, err
+	}
+	return s.emitInvestigation(id)
+}
+
+// emitInvestigation reloads an investigation and notifies the frontend of the change
+func (s *PolymarketService) emitInvestigation(id int64) (*domain.Investigation, error) {
+	inv, err := s.store.GetInvestigation(id)
+	if err != nil {
+		return nil, err
+	}
+	s.eventBus.Emit("polymarket:investigation_updated", inv)
+	s.syncInvestigationWallets(inv)
+	return inv, nil
+}
+
+// normalizeInvestigationRef validates an item reference for its type
+func normalizeInvestigationRef(itemType domain.InvestigationItemType, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", fmt.Errorf("%s reference is required", itemType)
+	}
+
+	switch itemType {
+	case domain.InvestigationItemWallet:
+		return ref, nil
+	case domain.InvestigationItemMarket:
+		return strings.ToLower(ref), nil
+	case domain.InvestigationItemEvent:
+		if _, err := strconv.ParseInt(ref, 10, 64); err != nil {
+			return "", fmt.Errorf("invalid event ID: %q", ref)
+		}
+		return ref, nil
+	}
+	return "", fmt.Errorf("invalid investigation item type: %q", itemType)
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestInvestigationLifecycle(t *testing.T) {
+	memory, _ := newTestService(t)
+	sqlite, _ := newSQLiteTestService(t, "")
+	for name, svc := range map[string]*PolymarketService{"memory": memory, "sqlite": sqlite} {
+		if _, err := svc.CreateInvestigation("  ", ""); err == nil {
+			t.Errorf("%s: investigation without a name was created", name)
+		}
+		inv, err := svc.CreateInvestigation(" Super Bowl insiders ", " props ")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if inv.Name != "Super Bowl insiders" || inv.Description != "props" || inv.Status != domain.InvestigationOpen {
+			t.Errorf("%s: created %+v, want a trimmed open investigation", name, inv)
+		}
+		other, _ := svc.CreateInvestigation("Fed leak", "")
+
+		for _, item := range []struct {
+			itemType domain.InvestigationItemType
+			ref      string
+		}{
+			{domain.InvestigationItemWallet, "0xabc"},
+			{domain.InvestigationItemMarket, "Super-Bowl-MVP"},
+			{domain.InvestigationItemMarket, "super-bowl-mvp"}, // Already attached
+			{domain.InvestigationItemEvent, "42"},
+		} {
+			if _, err := svc.AddInvestigationItem(inv.ID, item.itemType, item.ref); err != nil {
+				t.Fatalf("%s: add %s %s: %v", name, item.itemType, item.ref, err)
+			}
+		}
+		for _, bad := range []struct {
+			itemType domain.InvestigationItemType
+			ref      string
+		}{{domain.InvestigationItemEvent, "latest"}, {"tweet", "1"}, {domain.InvestigationItemWallet, " "}} {
+			if _, err := svc.AddInvestigationItem(inv.ID, bad.itemType, bad.ref); err == nil {
+				t.Errorf("%s: %s %q was attached", name, bad.itemType, bad.ref)
+			}
+		}
+		if _, err := svc.AddInvestigationNote(inv.ID, " "); err == nil {
+			t.Errorf("%s: empty note was added", name)
+		}
+		inv, err = svc.AddInvestigationNote(inv.ID, "Same funder as 0xdef")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(inv.Items) != 3 || len(inv.Notes) != 1 {
+			t.Fatalf("%s: investigation has %d items and %d notes, want 3 and 1", name, len(inv.Items), len(inv.Notes))
+		}
+
+		inv.Status = domain.InvestigationMonitoring
+		if _, err := svc.UpdateInvestigation(*inv); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		inv.Status = "archived"
+		if _, err := svc.UpdateInvestigation(*inv); err == nil {
+			t.Errorf("%s: unknown status was accepted", name)
+		}
+		monitoring, err := svc.ListInvestigations(domain.InvestigationMonitoring)
+		if err != nil || len(monitoring) != 1 || monitoring[0].ID != inv.ID {
+			t.Errorf("%s: monitoring = %+v, %v, want only the updated investigation", name, monitoring, err)
+		}
+		if all, _ := svc.ListInvestigations(""); len(all) != 2 {
+			t.Errorf("%s: listed %d investigations, want 2", name, len(all))
+		}
+
+		inv, _ = svc.RemoveInvestigationItem(inv.ID, domain.InvestigationItemMarket, "SUPER-BOWL-MVP")
+		inv, _ = svc.DeleteInvestigationNote(inv.ID, inv.Notes[0].ID)
+		if len(inv.Items) != 2 || len(inv.Notes) != 0 {
+			t.Errorf("%s: %d items and %d notes after removal, want 2 and 0", name, len(inv.Items), len(inv.Notes))
+		}
+
+		if err := svc.DeleteInvestigation(other.ID); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, err := svc.GetInvestigation(other.ID); !errors.Is(err, domain.ErrInvestigationNotFound) {
+			t.Errorf("%s: deleted investigation: %v, want ErrInvestigationNotFound", name, err)
+		}
+		if _, err := svc.AddInvestigationNote(other.ID, "late"); !errors.Is(err, domain.ErrInvestigationNotFound) {
+			t.Errorf("%s: note on a deleted investigation: %v, want ErrInvestigationNotFound", name, err)
+		}
+	}
+}
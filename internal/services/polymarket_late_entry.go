@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// lateEntryRulesSettingKey is the settings key late entry rules are persisted under
+	lateEntryRulesSettingKey = "late_entry_rules"
+
+	// lateEntryLookupTimeout bounds the market lookup done while handling a trade
+	lateEntryLookupTimeout = 3 * time.Second
+)
+
+// SetLateEntryRules replaces the rules that flag large trades close to a market's end
+func (s *PolymarketService) SetLateEntryRules(rules []domain.LateEntryRule) error {
+	normalized, err := normalizeLateEntryRules(rules)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.SaveSetting(lateEntryRulesSettingKey, normalized); err != nil {
+		return fmt.Errorf("failed to save late entry rules: %w", err)
+	}
+
+	s.mu.Lock()
+	s.lateEntryRules = normalized
+	s.mu.Unlock()
+
+	log.Printf("[PolymarketService] Saved %d late entry rules", len(normalized))
+	return nil
+}
+
+// normalizeLateEntryRules trims rule categories and checks windows and notionals
+func normalizeLateEntryRules(rules []domain.LateEntryRule) ([]domain.LateEntryRule, error) {
+	normalized := make([]domain.LateEntryRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.WindowHours <= 0 {
+			return nil, fmt.Errorf("late entry rule %d: window must be positive", i+1)
+		}
+		if rule.MinNotional < 0 {
+			return nil, fmt.Errorf("late entry rule %d: minimum notional cannot be negative", i+1)
+		}
+		rule.Category = strings.TrimSpace(rule.Category)
+		normalized = append(normalized, rule)
+	}
+	return normalized, nil
+}
+
+// GetLateEntryRules returns the rules that flag large trades close to a market's end
+func (s *PolymarketService) GetLateEntryRules() []domain.LateEntryRule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]domain.LateEntryRule{}, s.lateEntryRules...)
+}
+
+// applyLateEntry flags a large trade placed within a rule's window before the
+// market's scheduled end and requests an alert for it
+func (s *PolymarketService) applyLateEntry(event *domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || event.MarketSlug == "" {
+		return
+	}
+
+	s.mu.RLock()
+	rules := s.lateEntryRules
+	s.mu.RUnlock()
+
+	// Skip the market lookup for trades too small for any rule
+	notional := parseNotionalValue(event.Price, event.Size)
+	if len(rules) == 0 || notional < minLateEntryNotional(rules) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lateEntryLookupTimeout)
+	info, err := s.markets.GetMarket(ctx, event.MarketSlug)
+	cancel()
+	if err != nil || info == nil || info.Closed || info.EndDate.IsZero() {
+		return
+	}
+
+	remaining := info.EndDate.Sub(event.Timestamp)
+	rule, ok := matchLateEntryRule(rules, info.Category)
+	if !ok || remaining <= 0 || remaining.Hours() > rule.WindowHours || notional < rule.MinNotional {
+		return
+	}
+
+	message := fmt.Sprintf("Late Entry ($%.2f, %.1fh before end)", notional, remaining.Hours())
+	event.RiskSignals = append(event.RiskSignals, "⏰ "+message)
+	score := math.Min(1, 0.6+0.4*(1-remaining.Hours()/rule.WindowHours))
+	if score > event.RiskScore {
+		event.RiskScore = score
+	}
+
+	if !event.Muted {
+		s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+			Detector: "late_entry",
+			Signal:   "late_entry",
+			Message:  message,
+			Score:    score,
+			Alert:    true,
+			Metadata: map[string]string{
+				"category": info.Category,
+				"endDate":  info.EndDate.UTC().Format(time.RFC3339),
+			},
+			TradeID:       event.TradeID,
+			WalletAddress: event.WalletAddress,
+			MarketName:    event.MarketName,
+			MarketLink:    event.MarketLink,
+			Timestamp:     event.Timestamp,
+		})
+	}
+}
+
+// matchLateEntryRule returns the rule for a market category; a rule naming the
+// category takes precedence over a catch-all rule
+func matchLateEntryRule(rules []domain.LateEntryRule, category string) (domain.LateEntryRule, bool) {
+	var fallback *domain.LateEntryRule
+	for i, rule := range rules {
+		if rule.Category == "" {
+			if fallback == nil {
+				fallback = &rules[i]
+			}
+			continue
+		}
+		if strings.EqualFold(rule.Category, category) {
+			return rule, true
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return domain.LateEntryRule{}, false
+}
+
+// minLateEntryNotional returns the smallest notional any rule flags
+func minLateEntryNotional(rules []domain.LateEntryRule) float64 {
+	min := math.Inf(1)
+	for _, rule := range rules {
+		min = math.Min(min, rule.MinNotional)
+	}
+	return min
+}
+
+// loadLateEntryRules restores persisted late entry rules, falling back to defaults
+func (s *PolymarketService) loadLateEntryRules() {
+	rules := domain.DefaultLateEntryRules()
+	if err := s.store.LoadSetting(lateEntryRulesSettingKey, &rules); err != nil {
+		rules = domain.DefaultLateEntryRules()
+	}
+
+	s.mu.Lock()
+	s.lateEntryRules = rules
+	s.mu.Unlock()
+}
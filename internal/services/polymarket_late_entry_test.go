@@ -0,0 +1,87 @@
+package services
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// gammaMarkets answers Gamma API market lookups with canned markets by slug
+type gammaMarkets map[string]string
+
+func (g gammaMarkets) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := "[]"
+	if market, ok := g[req.URL.Query().Get("slug")]; ok {
+		body = "[" + market + "]"
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestApplyLateEntryFlagsLargeTradesBeforeEnd(t *testing.T) {
+	svc, rec := newTestService(t)
+	svc.markets.SetTransport(gammaMarkets{
+		"fed-cut":  `{"slug": "fed-cut", "category": "Economics", "endDate": "2026-03-18T18:00:00Z"}`,
+		"election": `{"slug": "election", "category": "Politics", "endDate": "2026-11-03T12:00:00Z"}`,
+		"closed":   `{"slug": "closed", "category": "Politics", "endDate": "2026-11-03T12:00:00Z", "closed": true}`,
+	})
+	if err := svc.SetLateEntryRules([]domain.LateEntryRule{{WindowHours: 0}}); err == nil {
+		t.Error("rule without a window was saved")
+	}
+	if err := svc.SetLateEntryRules([]domain.LateEntryRule{
+		{WindowHours: 6, MinNotional: 1000},
+		{Category: " politics ", WindowHours: 48, MinNotional: 20000},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	trade := func(slug, size string, end string, before time.Duration) domain.PolymarketEvent {
+		ts, _ := time.Parse(time.RFC3339, end)
+		return domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: slug + size, MarketSlug: slug,
+			Price: "0.5", Size: size, Timestamp: ts.Add(-before),
+		}
+	}
+	for _, tc := range []struct {
+		name    string
+		event   domain.PolymarketEvent
+		flagged bool
+	}{
+		{"catch-all rule", trade("fed-cut", "4000", "2026-03-18T18:00:00Z", 3*time.Hour), true},
+		{"outside the window", trade("fed-cut", "4000", "2026-03-18T18:00:00Z", 7*time.Hour), false},
+		{"after the end", trade("fed-cut", "4000", "2026-03-18T18:00:00Z", -time.Hour), false},
+		{"category rule", trade("election", "60000", "2026-11-03T12:00:00Z", 30*time.Hour), true},
+		{"below the category minimum", trade("election", "20000", "2026-11-03T12:00:00Z", 3*time.Hour), false},
+		{"closed market", trade("closed", "60000", "2026-11-03T12:00:00Z", 3*time.Hour), false},
+		{"unknown market", trade("unknown", "60000", "2026-11-03T12:00:00Z", 3*time.Hour), false},
+	} {
+		event := tc.event
+		svc.applyLateEntry(&event)
+		if flagged := len(event.RiskSignals) > 0; flagged != tc.flagged {
+			t.Errorf("%s: signals = %v, want flagged %v", tc.name, event.RiskSignals, tc.flagged)
+		}
+	}
+
+	signals := rec.of("polymarket:detector_signal")
+	if len(signals) != 2 {
+		t.Fatalf("emitted %d detector signals, want 2", len(signals))
+	}
+	if signal := signals[0].(domain.DetectorSignal); signal.Detector != "late_entry" || !signal.Alert || signal.Metadata["category"] != "Economics" {
+		t.Errorf("signal = %+v, want an alerting late entry signal for Economics", signal)
+	}
+
+	restarted := NewPolymarketService(svc.store, localbus.New(), "")
+	t.Cleanup(restarted.Close)
+	if rules := restarted.GetLateEntryRules(); len(rules) != 2 || rules[1].Category != "politics" {
+		t.Errorf("rules after restart = %+v, want the saved, trimmed rules", rules)
+	}
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// entityAlertRulesSettingKey is the settings key entity alert rules are persisted under
+	entityAlertRulesSettingKey = "entity_alert_rules"
+
+	// maxEntityCacheMarkets bounds the markets whose entities are kept in memory; the
+	// cache starts over when it is full
+	maxEntityCacheMarkets = 10000
+
+	// Default and maximum number of markets or entities listed
+	defaultEntityListLimit = 100
+	maxEntityListLimit     = 1000
+)
+
+// GetMarketEntities returns the entities stored for a market
+func (s *PolymarketService) GetMarketEntities(slug string) ([]domain.MarketEntity, error) {
+	return s.store.GetMarketEntities(slug)
+}
+
+// GetEntityMarkets returns the markets whose titles mention an entity, given by name,
+// alias or ticker
+func (s *PolymarketService) GetEntityMarkets(entity string, limit int) ([]domain.EntityMarket, error) {
+	entity = domain.CanonicalEntity(entity)
+	if entity == "" {
+		return nil, fmt.Errorf("entity is required")
+	}
+	return s.store.GetEntityMarkets(entity, entityListLimit(limit))
+}
+
+// GetEntityCounts returns the entities of the stored markets, mentioned by the most
+// markets first
+func (s *PolymarketService) GetEntityCounts(limit int) ([]domain.MarketEntityCount, error) {
+	return s.store.GetEntityCounts(entityListLimit(limit))
+}
+
+// entityListLimit applies the default and maximum list length
+func entityListLimit(limit int) int {
+	if limit <= 0 {
+		return defaultEntityListLimit
+	}
+	return min(limit, maxEntityListLimit)
+}
+
+// TagStoredMarkets finds the entities of every market with stored events, for markets
+// stored before tagging existed or after the dictionary grew
+func (s *PolymarketService) TagStoredMarkets() (*domain.MarketTaggingResult, error) {
+	titles, err := s.store.GetStoredMarketTitles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stored markets: %w", err)
+	}
+
+	result := &domain.MarketTaggingResult{Markets: len(titles)}
+	for _, t := range titles {
+		entities := domain.ExtractMarketEntities(t.MarketName, t.EventTitle)
+		if err := s.store.SaveMarketEntities(t.MarketSlug, t.MarketName, entities); err != nil {
+			return nil, fmt.Errorf("failed to save entities of %s: %w", t.MarketSlug, err)
+		}
+		if len(entities) > 0 {
+			result.Tagged++
+			result.Entities += len(entities)
+		}
+	}
+
+	s.entityMu.Lock()
+	s.entityCache = make(map[string][]string)
+	s.entityMu.Unlock()
+
+	log.Printf("[PolymarketService] Tagged %d of %d stored markets with %d entities", result.Tagged, result.Markets, result.Entities)
+	return result, nil
+}
+
+// SetEntityAlertRules replaces the rules that alert on trades in markets mentioning an entity
+func (s *PolymarketService) SetEntityAlertRules(rules []domain.EntityAlertRule) error {
+	normalized, err := normalizeEntityAlertRules(rules)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.SaveSetting(entityAlertRulesSettingKey, normalized); err != nil {
+		return fmt.Errorf("failed to save entity alert rules: %w", err)
+	}
+
+	s.mu.Lock()
+	s.entityRules = normalized
+	s.mu.Unlock()
+
+	log.Printf("[PolymarketService] Saved %d entity alert rules", len(normalized))
+	return nil
+}
+
+// normalizeEntityAlertRules puts rule entities under their canonical names and checks notionals
+func normalizeEntityAlertRules(rules []domain.EntityAlertRule) ([]domain.EntityAlertRule, error) {
+	normalized := make([]domain.EntityAlertRule, 0, len(rules))
+	for i, rule := range rules {
+		rule.Entity = domain.CanonicalEntity(rule.Entity)
+		if rule.Entity == "" {
+			return nil, fmt.Errorf("entity alert rule %d: entity is required", i+1)
+		}
+		if rule.MinNotional < 0 {
+			return nil, fmt.Errorf("entity alert rule %d: minimum notional cannot be negative", i+1)
+		}
+		normalized = append(normalized, rule)
+	}
+	return normalized, nil
+}
+
+// GetEntityAlertRules returns the rules that alert on trades in markets mentioning an entity
+func (s *PolymarketService) GetEntityAlertRules() []domain.EntityAlertRule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]domain.EntityAlertRule{}, s.entityRules...)
+}
+
+// loadEntityAlertRules restores persisted entity alert rules
+func (s *PolymarketService) loadEntityAlertRules() {
+	var rules []domain.EntityAlertRule
+	if err := s.store.LoadSetting(entityAlertRulesSettingKey, &rules); err != nil {
+		rules = nil
+	}
+	s.mu.Lock()
+	s.entityRules = rules
+	s.mu.Unlock()
+}
+
+// applyMarketEntities sets the entities the event's market mentions, tagging the
+// market the first time it is seen, and requests an alert for a trade matching an
+// entity alert rule
+func (s *PolymarketService) applyMarketEntities(event *domain.PolymarketEvent) {
+	if event.MarketSlug == "" || event.MarketName == "" {
+		return
+	}
+	event.Entities = s.marketEntityNames(*event)
+	if event.EventType != domain.PolymarketEventTrade || event.Muted || len(event.Entities) == 0 {
+		return
+	}
+
+	s.mu.RLock()
+	rules := s.entityRules
+	s.mu.RUnlock()
+
+	notional := parseNotionalValue(event.Price, event.Size)
+	for _, rule := range rules {
+		if notional < rule.MinNotional || !containsFold(event.Entities, rule.Entity) {
+			continue
+		}
+		s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+			Detector: "market_entity",
+			Signal:   "watched_entity",
+			Message:  fmt.Sprintf("Trade on a market mentioning %s (%s)", rule.Entity, formatCompactUSD(notional)),
+			Alert:    true,
+			Metadata: map[string]string{
+				"entity":   rule.Entity,
+				"entities": strings.Join(event.Entities, ", "),
+			},
+			TradeID:       event.TradeID,
+			WalletAddress: event.WalletAddress,
+			MarketName:    event.MarketName,
+			MarketLink:    event.MarketLink,
+			Timestamp:     event.Timestamp,
+		})
+		// One alert per trade, for the first rule it matches
+		return
+	}
+}
+
+// marketEntityNames returns the names of the entities an event's market mentions,
+// extracting and storing them when the market is not cached yet
+func (s *PolymarketService) marketEntityNames(event domain.PolymarketEvent) []string {
+	s.entityMu.Lock()
+	names, ok := s.entityCache[event.MarketSlug]
+	s.entityMu.Unlock()
+	if ok {
+		return names
+	}
+
+	entities := domain.ExtractMarketEntities(event.MarketName, event.EventTitle)
+	if err := s.store.SaveMarketEntities(event.MarketSlug, event.MarketName, entities); err != nil {
+		log.Printf("[PolymarketService] Failed to save market entities: %v", err)
+	}
+	for _, e := range entities {
+		if !containsFold(names, e.Name) {
+			names = append(names, e.Name)
+		}
+	}
+
+	s.entityMu.Lock()
+	if len(s.entityCache) >= maxEntityCacheMarkets {
+		s.entityCache = make(map[string][]string)
+	}
+	s.entityCache[event.MarketSlug] = names
+	s.entityMu.Unlock()
+	return names
+}
+
+// containsFold reports whether values holds value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestExtractMarketEntities(t *testing.T) {
+	for _, tc := range []struct {
+		title string
+		want  string
+	}{
+		{"Will Nvidia be the largest company by market cap?", "[{company Nvidia} {ticker NVDA}]"},
+		{"Will Trump visit Venezuela before the U.S. midterms?", "[{country United States} {country Venezuela} {person Donald Trump}]"},
+		{"Will $ZKJ close above $200?", "[{ticker ZKJ}]"}, // Unknown cashtags are tagged too
+		{"Bitcoin above 100k on Friday?", "[{ticker BTC}]"},
+		{"Will the us open be rained out?", "[]"}, // Capitals-only aliases must match exactly
+		{"Will the trumpet player win?", "[]"},
+	} {
+		if got := fmt.Sprint(domain.ExtractMarketEntities(tc.title)); got != tc.want {
+			t.Errorf("ExtractMarketEntities(%q) = %s, want %s", tc.title, got, tc.want)
+		}
+	}
+}
+
+func TestEntityAlertRulesFlagMatchingTrades(t *testing.T) {
+	svc, rec := newTestService(t)
+	if err := svc.SetEntityAlertRules([]domain.EntityAlertRule{{Entity: "nvidia", MinNotional: -1}}); err == nil {
+		t.Error("saved a rule with a negative notional")
+	}
+	if err := svc.SetEntityAlertRules([]domain.EntityAlertRule{{Entity: " $nvda ", MinNotional: 1000}, {Entity: "Venezuelan"}}); err != nil {
+		t.Fatal(err)
+	}
+	if rules := svc.GetEntityAlertRules(); len(rules) != 2 || rules[0].Entity != "NVDA" || rules[1].Entity != "Venezuela" {
+		t.Errorf("rules = %+v, want canonical entity names", rules)
+	}
+
+	trade := func(slug, name, size string) domain.PolymarketEvent {
+		event := domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: slug + size, MarketSlug: slug, MarketName: name,
+			Price: "0.5", Size: size,
+		}
+		svc.applyMarketEntities(&event)
+		return event
+	}
+	if event := trade("nvda", "Nvidia beats earnings?", "1000"); fmt.Sprint(event.Entities) != "[Nvidia NVDA]" {
+		t.Errorf("entities = %v, want Nvidia and its ticker", event.Entities)
+	}
+	trade("nvda", "Nvidia beats earnings?", "4000")
+	trade("maduro", "Will Venezuela hold elections?", "2")
+	trade("fed", "Fed cuts in March?", "100000")
+
+	signals := rec.of("polymarket:detector_signal")
+	if len(signals) != 2 {
+		t.Fatalf("emitted %d signals, want the $2,000 Nvidia trade and the Venezuela trade", len(signals))
+	}
+	if signal := signals[0].(domain.DetectorSignal); signal.Metadata["entity"] != "NVDA" || signal.Message != "Trade on a market mentioning NVDA ($2k)" {
+		t.Errorf("signal = %+v, want the NVDA alert", signal)
+	}
+	if markets, err := svc.GetEntityMarkets("Nvidia", 0); err != nil || len(markets) != 1 || markets[0].MarketSlug != "nvda" {
+		t.Errorf("markets = %+v, %v, want the tagged market", markets, err)
+	}
+}
+
+func TestTagStoredMarkets(t *testing.T) {
+	svc, _ := newTestService(t)
+	svc.store.SaveEvents([]domain.PolymarketEvent{
+		{EventType: domain.PolymarketEventTrade, TradeID: "a", AssetID: "1", MarketSlug: "maduro", MarketName: "Snap election in 2026?", EventTitle: "Venezuela politics", Price: "0.5", Size: "10"},
+		{EventType: domain.PolymarketEventTrade, TradeID: "b", AssetID: "1", MarketSlug: "rain", MarketName: "Rain tomorrow?", Price: "0.5", Size: "10"},
+	})
+
+	result, err := svc.TagStoredMarkets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Markets != 2 || result.Tagged != 1 || result.Entities != 1 {
+		t.Errorf("result = %+v, want 1 of 2 markets tagged with 1 entity", result)
+	}
+	if entities, _ := svc.GetMarketEntities("maduro"); len(entities) != 1 || entities[0].Name != "Venezuela" {
+		t.Errorf("entities = %+v, want Venezuela from the event title", entities)
+	}
+}
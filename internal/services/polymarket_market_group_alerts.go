@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Group flow defaults when the config leaves them unset
+	defaultGroupFlowMinUSD      = 50000.0
+	defaultGroupFlowWindowHours = 24
+
+	// groupFlowRealertRatio is how much the flow must grow after an alert to alert again
+	groupFlowRealertRatio = 2.0
+)
+
+// checkGroupFlowLocked returns the group's summary if its fresh-wallet flow should
+// alert: on first crossing the threshold, then each time it doubles. Caller must hold groupsMu.
+func (s *PolymarketService) checkGroupFlowLocked(g *marketGroup) *domain.MarketGroup {
+	minFlow, window := s.groupFlowThresholds()
+	g.expire(time.Now().Add(-window))
+	summary := s.summarizeGroupLocked(g, window)
+
+	switch {
+	case summary.FreshWalletFlow < minFlow:
+		if summary.FreshWalletFlow < minFlow/2 {
+			g.alertedFlow = 0 // The flow faded; alert again if it returns
+		}
+		return nil
+	case g.alertedFlow > 0 && summary.FreshWalletFlow < g.alertedFlow*groupFlowRealertRatio:
+		return nil
+	}
+	g.alertedFlow = summary.FreshWalletFlow
+	return &summary
+}
+
+// emitGroupFlowAlert sends an event-level fresh-wallet flow alert
+func (s *PolymarketService) emitGroupFlowAlert(group *domain.MarketGroup, tradeID string) {
+	if group == nil || s.isMarketMuted(domain.PolymarketEvent{EventSlug: group.EventSlug}) {
+		return
+	}
+	title := group.EventTitle
+	if title == "" {
+		title = group.EventSlug
+	}
+	message := fmt.Sprintf("%s fresh-wallet flow into the '%s' event (%d wallets across %d markets, last %dh)",
+		formatCompactUSD(group.FreshWalletFlow), title, group.FreshWallets, len(group.Markets), group.WindowHours)
+	minFlow, _ := s.groupFlowThresholds()
+
+	s.eventBus.Emit("polymarket:group_flow", *group)
+	s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+		Detector: "market_group",
+		Signal:   "group_fresh_flow",
+		Message:  message,
+		Score:    math.Min(1, 0.6+0.1*math.Log2(group.FreshWalletFlow/minFlow)),
+		Alert:    true,
+		Metadata: map[string]string{
+			"eventSlug":       group.EventSlug,
+			"freshWalletFlow": strconv.FormatFloat(group.FreshWalletFlow, 'f', 2, 64),
+			"volume":          strconv.FormatFloat(group.Volume, 'f', 2, 64),
+			"freshWallets":    strconv.Itoa(group.FreshWallets),
+			"markets":         strconv.Itoa(len(group.Markets)),
+		},
+		TradeID:    tradeID,
+		MarketName: title,
+		MarketLink: group.Link,
+		Timestamp:  group.LastTradeAt,
+	})
+}
+
+// groupFlowThresholds returns the configured flow threshold and window
+func (s *PolymarketService) groupFlowThresholds() (float64, time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	minFlow, hours := s.config.GroupFlowMinUSD, s.config.GroupFlowWindowHours
+	if minFlow <= 0 {
+		minFlow = defaultGroupFlowMinUSD
+	}
+	if hours <= 0 {
+		hours = defaultGroupFlowWindowHours
+	}
+	return minFlow, time.Duration(hours) * time.Hour
+}
+
+// formatCompactUSD formats an amount as e.g. "$120k" or "$1.2M"
+func formatCompactUSD(v float64) string {
+	switch {
+	case v >= 1e6:
+		return "$" + strconv.FormatFloat(v/1e6, 'f', 1, 64) + "M"
+	case v >= 1e3:
+		return "$" + strconv.FormatFloat(v/1e3, 'f', 0, 64) + "k"
+	default:
+		return "$" + strconv.FormatFloat(v, 'f', 0, 64)
+	}
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// maxTrackedGroups and maxGroupTrades bound the in-memory trade history
+const (
+	maxTrackedGroups = 2000
+	maxGroupTrades   = 5000
+)
+
+// groupTrade is a saved trade counted towards its event's group
+type groupTrade struct {
+	wallet     string
+	market     string
+	marketName string
+	notional   float64
+	at         time.Time
+}
+
+// marketGroup is the recent trade history of one event's markets
+type marketGroup struct {
+	slug        string
+	title       string
+	link        string
+	trades      []groupTrade // Oldest first
+	alertedFlow float64      // Fresh-wallet flow at the last alert, 0 until the threshold is crossed
+}
+
+// GetMarketGroups returns the tracked events with the most fresh-wallet flow first
+func (s *PolymarketService) GetMarketGroups(limit int) []domain.MarketGroup {
+	_, window := s.groupFlowThresholds()
+	now := time.Now()
+
+	s.groupsMu.Lock()
+	groups := make([]domain.MarketGroup, 0, len(s.groups))
+	for _, g := range s.groups {
+		g.expire(now.Add(-window))
+		if len(g.trades) > 0 {
+			groups = append(groups, s.summarizeGroupLocked(g, window))
+		}
+	}
+	s.groupsMu.Unlock()
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].FreshWalletFlow != groups[j].FreshWalletFlow {
+			return groups[i].FreshWalletFlow > groups[j].FreshWalletFlow
+		}
+		return groups[i].Volume > groups[j].Volume
+	})
+	if limit > 0 && len(groups) > limit {
+		groups = groups[:limit]
+	}
+	return groups
+}
+
+// GetMarketGroup returns the trades of one event's markets in the window
+func (s *PolymarketService) GetMarketGroup(eventSlug string) (*domain.MarketGroup, error) {
+	_, window := s.groupFlowThresholds()
+
+	s.groupsMu.Lock()
+	defer s.groupsMu.Unlock()
+	g, ok := s.groups[strings.ToLower(eventSlug)]
+	if !ok {
+		return nil, fmt.Errorf("no recent trades in event %q", eventSlug)
+	}
+	g.expire(time.Now().Add(-window))
+	summary := s.summarizeGroupLocked(g, window)
+	return &summary, nil
+}
+
+// observeMarketGroup adds a saved trade to its event's group and alerts when the
+// group's fresh-wallet flow crosses the threshold
+func (s *PolymarketService) observeMarketGroup(event domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || event.EventSlug == "" || event.WalletAddress == "" {
+		return
+	}
+	notional := parseNotionalValue(event.Price, event.Size)
+	if notional <= 0 {
+		return
+	}
+	wallet := strings.ToLower(event.WalletAddress)
+	fresh := event.IsFreshWallet
+	if !fresh {
+		if profile, err := s.store.GetWallet(wallet); err == nil && profile != nil {
+			fresh = profile.IsFresh
+		}
+	}
+
+	slug := strings.ToLower(event.EventSlug)
+	s.groupsMu.Lock()
+	if fresh {
+		s.markFreshLocked(wallet)
+	}
+	g, ok := s.groups[slug]
+	if !ok {
+		s.evictGroupLocked()
+		g = &marketGroup{slug: slug, link: "https://polymarket.com/event/" + event.EventSlug}
+		s.groups[slug] = g
+	}
+	if event.EventTitle != "" {
+		g.title = event.EventTitle
+	}
+	g.trades = append(g.trades, groupTrade{
+		wallet:     wallet,
+		market:     event.MarketSlug,
+		marketName: event.MarketName,
+		notional:   notional,
+		at:         time.Now(),
+	})
+	if len(g.trades) > maxGroupTrades {
+		g.trades = g.trades[len(g.trades)-maxGroupTrades:]
+	}
+	alert := s.checkGroupFlowLocked(g)
+	s.groupsMu.Unlock()
+
+	s.emitGroupFlowAlert(alert, event.TradeID)
+}
+
+// markGroupWalletFresh counts a wallet found fresh after its trades were seen towards
+// the groups it traded in
+func (s *PolymarketService) markGroupWalletFresh(address string) {
+	wallet := strings.ToLower(address)
+
+	s.groupsMu.Lock()
+	s.markFreshLocked(wallet)
+	var alerts []*domain.MarketGroup
+	for _, g := range s.groups {
+		for _, t := range g.trades {
+			if t.wallet == wallet {
+				if alert := s.checkGroupFlowLocked(g); alert != nil {
+					alerts = append(alerts, alert)
+				}
+				break
+			}
+		}
+	}
+	s.groupsMu.Unlock()
+
+	for _, alert := range alerts {
+		s.emitGroupFlowAlert(alert, "")
+	}
+}
+
+// summarizeGroupLocked aggregates a group's trades. Caller must hold groupsMu.
+func (s *PolymarketService) summarizeGroupLocked(g *marketGroup, window time.Duration) domain.MarketGroup {
+	summary := domain.MarketGroup{
+		EventSlug:   g.slug,
+		EventTitle:  g.title,
+		Link:        g.link,
+		Trades:      len(g.trades),
+		WindowHours: int(window.Hours()),
+		Markets:     []domain.MarketGroupMarket{},
+	}
+	markets := make(map[string]*domain.MarketGroupMarket)
+	freshWallets := make(map[string]bool)
+	for _, t := range g.trades {
+		m, ok := markets[t.market]
+		if !ok {
+			m = &domain.MarketGroupMarket{Slug: t.market, Name: t.marketName}
+			markets[t.market] = m
+		}
+		m.Volume += t.notional
+		m.Trades++
+		summary.Volume += t.notional
+		if s.freshWallets[t.wallet] {
+			m.FreshWalletFlow += t.notional
+			summary.FreshWalletFlow += t.notional
+			freshWallets[t.wallet] = true
+		}
+		if t.at.After(summary.LastTradeAt) {
+			summary.LastTradeAt = t.at
+		}
+	}
+	summary.FreshWallets = len(freshWallets)
+	for _, m := range markets {
+		summary.Markets = append(summary.Markets, *m)
+	}
+	sort.Slice(summary.Markets, func(i, j int) bool {
+		if summary.Markets[i].FreshWalletFlow != summary.Markets[j].FreshWalletFlow {
+			return summary.Markets[i].FreshWalletFlow > summary.Markets[j].FreshWalletFlow
+		}
+		return summary.Markets[i].Volume > summary.Markets[j].Volume
+	})
+	return summary
+}
+
+// markFreshLocked remembers a fresh wallet, bounded like the size history. Caller must hold groupsMu.
+func (s *PolymarketService) markFreshLocked(wallet string) {
+	if len(s.freshWallets) >= maxSizeTrackedWallets {
+		s.freshWallets = make(map[string]bool)
+	}
+	s.freshWallets[wallet] = true
+}
+
+// evictGroupLocked makes room for a new group by dropping the one traded least
+// recently. Caller must hold groupsMu.
+func (s *PolymarketService) evictGroupLocked() {
+	if len(s.groups) < maxTrackedGroups {
+		return
+	}
+	var oldest *marketGroup
+	for _, g := range s.groups {
+		if oldest == nil || g.lastTradeAt().Before(oldest.lastTradeAt()) {
+			oldest = g
+		}
+	}
+	delete(s.groups, oldest.slug)
+}
+
+// expire drops trades older than cutoff
+func (g *marketGroup) expire(cutoff time.Time) {
+	i := 0
+	for i < len(g.trades) && g.trades[i].at.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		g.trades = append(g.trades[:0], g.trades[i:]...)
+	}
+}
+
+func (g *marketGroup) lastTradeAt() time.Time {
+	if len(g.trades) == 0 {
+		return time.Time{}
+	}
+	return g.trades[len(g.trades)-1].at
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestMarketGroupFreshFlowAlerts(t *testing.T) {
+	svc, rec := newTestService(t)
+	svc.mu.Lock()
+	svc.config.GroupFlowMinUSD = 100000
+	svc.mu.Unlock()
+
+	n := 0
+	trade := func(wallet, market string, notional float64, fresh bool) {
+		n++
+		svc.observeMarketGroup(domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("tx-%d", n), EventSlug: "GOP-Nominee",
+			EventTitle: "Republican nominee", MarketSlug: market, MarketName: market, WalletAddress: wallet,
+			IsFreshWallet: fresh, Price: "0.5", Size: strconv.FormatFloat(notional*2, 'f', -1, 64),
+		})
+	}
+
+	trade("0xold", "trump", 500000, false)
+	trade("0xa", "desantis", 40000, true)
+	trade("0xb", "haley", 30000, true)
+	if alerts := rec.of("polymarket:group_flow"); len(alerts) != 0 {
+		t.Fatalf("alerted at $70k fresh flow: %+v", alerts)
+	}
+
+	// A wallet found fresh later counts towards the groups it traded in
+	trade("0xc", "haley", 50000, false)
+	svc.markGroupWalletFresh("0xC")
+	alerts := rec.of("polymarket:group_flow")
+	if len(alerts) != 1 {
+		t.Fatalf("emitted %d group alerts, want 1 once the flow reached $120k", len(alerts))
+	}
+	group := alerts[0].(domain.MarketGroup)
+	if group.EventSlug != "gop-nominee" || group.FreshWalletFlow != 120000 || group.FreshWallets != 3 || group.Volume != 620000 {
+		t.Errorf("group = %+v, want $120k fresh flow from 3 wallets", group)
+	}
+	if len(group.Markets) != 3 || group.Markets[0].Slug != "haley" || group.Markets[0].FreshWalletFlow != 80000 {
+		t.Errorf("markets = %+v, want haley first with $80k fresh flow", group.Markets)
+	}
+	signals := rec.of("polymarket:detector_signal")
+	if len(signals) != 1 || !strings.HasPrefix(signals[0].(domain.DetectorSignal).Message, "$120k fresh-wallet flow into the 'Republican nominee' event") {
+		t.Errorf("signals = %+v, want the event-level alert", signals)
+	}
+
+	// It alerts again only once the flow doubles
+	trade("0xa", "desantis", 100000, true)
+	if len(rec.of("polymarket:group_flow")) != 1 {
+		t.Error("alerted again before the flow doubled")
+	}
+	trade("0xd", "desantis", 30000, true)
+	if len(rec.of("polymarket:group_flow")) != 2 {
+		t.Error("did not alert again once the flow doubled")
+	}
+
+	if groups := svc.GetMarketGroups(10); len(groups) != 1 || groups[0].Trades != 6 {
+		t.Errorf("groups = %+v, want the one event with 6 trades", groups)
+	}
+	if _, err := svc.GetMarketGroup("unknown"); err == nil {
+		t.Error("got an untracked group")
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// marketSnapshotCheckInterval is how often the snapshot schedule is checked
+	marketSnapshotCheckInterval = time.Minute
+
+	// marketSnapshotMarketLimit bounds how many watched markets are captured per run
+	marketSnapshotMarketLimit = 200
+
+	// marketSnapshotTimeout bounds the API lookups of one run
+	marketSnapshotTimeout = 2 * time.Minute
+
+	// Snapshot defaults when the config leaves them unset
+	defaultSnapshotHolders  = 10
+	defaultSnapshotKeepDays = 90
+
+	// Default and maximum number of snapshots listed
+	defaultSnapshotListLimit = 100
+	maxSnapshotListLimit     = 5000
+)
+
+// GetMarketSnapshots returns stored market snapshots, newest first. With a market and
+// until and a limit of 1, it returns the market's state as of that time.
+func (s *PolymarketService) GetMarketSnapshots(filter domain.MarketSnapshotFilter) ([]domain.MarketSnapshot, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSnapshotListLimit
+	}
+	filter.Limit = min(filter.Limit, maxSnapshotListLimit)
+	return s.store.GetMarketSnapshots(filter)
+}
+
+// CaptureMarketSnapshots stores a snapshot of every watched market now: prices,
+// volume, liquidity and top holders where available
+func (s *PolymarketService) CaptureMarketSnapshots() (*domain.MarketSnapshotCapture, error) {
+	watched, err := s.watchedMarkets()
+	if err != nil {
+		return nil, err
+	}
+	if len(watched) > marketSnapshotMarketLimit {
+		watched = watched[:marketSnapshotMarketLimit]
+	}
+	holders, _ := s.snapshotSchedule()
+
+	ctx, cancel := context.WithTimeout(context.Background(), marketSnapshotTimeout)
+	defer cancel()
+
+	result := &domain.MarketSnapshotCapture{Markets: len(watched), CapturedAt: time.Now()}
+	var snapshots []domain.MarketSnapshot
+	for _, r := range watched {
+		snapshot, err := s.markets.GetMarketSnapshot(ctx, r.Slug, holders)
+		if err != nil {
+			log.Printf("[PolymarketService] Failed to snapshot market %s: %v", r.Slug, err)
+		}
+		if snapshot == nil {
+			result.Failed++
+			continue
+		}
+		if snapshot.MarketName == "" {
+			snapshot.MarketName = r.Name
+		}
+		if len(snapshot.TopHolders) > 0 {
+			result.Holders++
+		}
+		snapshots = append(snapshots, *snapshot)
+	}
+
+	if err := s.store.SaveMarketSnapshots(snapshots); err != nil {
+		return nil, fmt.Errorf("failed to save market snapshots: %w", err)
+	}
+	result.Captured = len(snapshots)
+	s.eventBus.Emit("polymarket:market_snapshots", *result)
+	log.Printf("[PolymarketService] Captured %d of %d watched markets (%d with holders)", result.Captured, result.Markets, result.Holders)
+	return result, nil
+}
+
+// marketSnapshotWorker captures the watched markets every configured interval and
+// deletes snapshots past their retention
+func (s *PolymarketService) marketSnapshotWorker() {
+	ticker := time.NewTicker(marketSnapshotCheckInterval)
+	defer ticker.Stop()
+
+	// Pick up the schedule where the last run before a restart left it
+	var lastCapture time.Time
+	if latest, err := s.store.GetMarketSnapshots(domain.MarketSnapshotFilter{Limit: 1}); err == nil && len(latest) > 0 {
+		lastCapture = latest[0].CapturedAt
+	}
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.mu.RLock()
+			interval := time.Duration(s.config.MarketSnapshotMinutes) * time.Minute
+			s.mu.RUnlock()
+			if interval <= 0 || time.Since(lastCapture) < interval {
+				continue
+			}
+			lastCapture = time.Now()
+			if _, err := s.CaptureMarketSnapshots(); err != nil {
+				log.Printf("[PolymarketService] Scheduled market snapshots failed: %v", err)
+				s.errReporter.Report("market snapshots", err)
+			}
+			s.pruneMarketSnapshots()
+		}
+	}
+}
+
+// pruneMarketSnapshots deletes snapshots older than MarketSnapshotKeepDays
+func (s *PolymarketService) pruneMarketSnapshots() {
+	_, keepDays := s.snapshotSchedule()
+	deleted, err := s.store.PruneMarketSnapshots(time.Now().AddDate(0, 0, -keepDays))
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to prune market snapshots: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("[PolymarketService] Pruned %d market snapshots older than %d days", deleted, keepDays)
+	}
+}
+
+// snapshotSchedule returns the top holders kept per outcome and the days snapshots are
+// kept, with defaults applied
+func (s *PolymarketService) snapshotSchedule() (int, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	holders, keepDays := s.config.MarketSnapshotHolders, s.config.MarketSnapshotKeepDays
+	if holders == 0 {
+		holders = defaultSnapshotHolders
+	}
+	if keepDays <= 0 {
+		keepDays = defaultSnapshotKeepDays
+	}
+	return max(holders, 0), keepDays
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+// The desktop app falls back to the in-memory store when the database can't be opened;
+// settings must still round trip through it for the session
+func TestMemoryStoreKeepsSettings(t *testing.T) {
+	const wallet = "0x00000000000000000000000000000000000000cc"
+	svc, _ := newTestService(t)
+
+	if _, err := svc.MuteMarket("us-recession-in-2025", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.WatchWallet(wallet); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.BlacklistWallet(wallet, "market maker"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.TagWallet(wallet, "mm-bot"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reloading reads everything back from the store
+	if err := svc.reloadStoredState(); err != nil {
+		t.Fatal(err)
+	}
+	if mutes := svc.GetMutedMarkets(); len(mutes) != 1 || mutes[0].Slug != "us-recession-in-2025" {
+		t.Errorf("muted markets = %+v, want the recession market", mutes)
+	}
+	if watched := svc.GetWatchedWallets(); len(watched) != 1 || watched[0] != wallet {
+		t.Errorf("watchlist = %v, want %s", watched, wallet)
+	}
+	if !svc.IsWalletBlacklisted(wallet) {
+		t.Errorf("wallet is no longer blacklisted")
+	}
+	if tags, err := svc.GetWalletTags([]string{wallet}); err != nil || len(tags[wallet]) != 1 {
+		t.Errorf("wallet tags = %v, %v; want mm-bot", tags[wallet], err)
+	}
+}
+
+func TestStatusReportsStoreFallback(t *testing.T) {
+	svc, _ := newTestService(t)
+	if status := svc.GetStatus(); status.StoreError != "" {
+		t.Fatalf("store error before fallback = %q", status.StoreError)
+	}
+	svc.SetStoreError("database unavailable, trades are kept in memory only: disk I/O error")
+	if status := svc.GetStatus(); status.StoreError == "" {
+		t.Errorf("status doesn't report the in-memory fallback")
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// marketMutesSettingKey is the settings key active mutes are persisted under
+const marketMutesSettingKey = "market_mutes"
+
+// MuteMarket silences notifications for a market (matched by market or event slug)
+// for the given duration. Muting an already muted market replaces its expiry.
+func (s *PolymarketService) MuteMarket(slug string, duration time.Duration) (*domain.MarketMute, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return nil, fmt.Errorf("market slug is required")
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("mute duration must be positive")
+	}
+
+	now := time.Now()
+	mute := domain.MarketMute{Slug: slug, MutedAt: now, Until: now.Add(duration)}
+
+	s.mutesMu.Lock()
+	if s.mutes == nil {
+		s.mutes = make(map[string]domain.MarketMute)
+	}
+	s.mutes[slug] = mute
+	err := s.saveMutesLocked()
+	s.mutesMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save market mutes: %w", err)
+	}
+
+	log.Printf("[PolymarketService] Muted market %s until %s", slug, mute.Until.Format(time.RFC3339))
+	s.eventBus.Emit("polymarket:market_muted", mute)
+	return &mute, nil
+}
+
+// UnmuteMarket removes a market mute before it expires
+func (s *PolymarketService) UnmuteMarket(slug string) error {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+
+	s.mutesMu.Lock()
+	mute, ok := s.mutes[slug]
+	if !ok {
+		s.mutesMu.Unlock()
+		return nil
+	}
+	delete(s.mutes, slug)
+	err := s.saveMutesLocked()
+	s.mutesMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to save market mutes: %w", err)
+	}
+
+	log.Printf("[PolymarketService] Unmuted market %s", slug)
+	s.eventBus.Emit("polymarket:market_unmuted", mute)
+	return nil
+}
+
+// GetMutedMarkets returns active mutes, soonest to expire first
+func (s *PolymarketService) GetMutedMarkets() []domain.MarketMute {
+	s.expireMutes()
+
+	s.mutesMu.Lock()
+	mutes := make([]domain.MarketMute, 0, len(s.mutes))
+	for _, mute := range s.mutes {
+		mutes = append(mutes, mute)
+	}
+	s.mutesMu.Unlock()
+
+	sort.Slice(mutes, func(i, j int) bool { return mutes[i].Until.Before(mutes[j].Until) })
+	return mutes
+}
+
+// isMarketMuted reports whether an event belongs to a muted market
+func (s *PolymarketService) isMarketMuted(event domain.PolymarketEvent) bool {
+	s.expireMutes()
+
+	s.mutesMu.Lock()
+	defer s.mutesMu.Unlock()
+
+	if len(s.mutes) == 0 {
+		return false
+	}
+	for _, slug := range []string{event.MarketSlug, event.EventSlug} {
+		if _, ok := s.mutes[strings.ToLower(slug)]; ok && slug != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// expireMutes drops mutes whose expiry has passed (auto-unmute)
+func (s *PolymarketService) expireMutes() {
+	now := time.Now()
+
+	s.mutesMu.Lock()
+	var expired []domain.MarketMute
+	for slug, mute := range s.mutes {
+		if !now.Before(mute.Until) {
+			expired = append(expired, mute)
+			delete(s.mutes, slug)
+		}
+	}
+	if len(expired) > 0 {
+		if err := s.saveMutesLocked(); err != nil {
+			log.Printf("[PolymarketService] Failed to save market mutes: %v", err)
+		}
+	}
+	s.mutesMu.Unlock()
+
+	for _, mute := range expired {
+		log.Printf("[PolymarketService] Mute expired for market %s", mute.Slug)
+		s.eventBus.Emit("polymarket:market_unmuted", mute)
+	}
+}
+
+// loadMutes restores persisted mutes; expired ones are dropped on first check
+func (s *PolymarketService) loadMutes() {
+	var mutes []domain.MarketMute
+	if err := s.store.LoadSetting(marketMutesSettingKey, &mutes); err != nil {
+		return
+	}
+
+	s.mutesMu.Lock()
+	s.mutes = make(map[string]domain.MarketMute, len(mutes))
+	for _, mute := range mutes {
+		s.mutes[mute.Slug] = mute
+	}
+	s.mutesMu.Unlock()
+}
+
+// saveMutesLocked persists active mutes. Caller must hold mutesMu.
+func (s *PolymarketService) saveMutesLocked() error {
+	mutes := make([]domain.MarketMute, 0, len(s.mutes))
+	for _, mute := range s.mutes {
+		mutes = append(mutes, mute)
+	}
+	return s.store.SaveSetting(marketMutesSettingKey, mutes)
+}
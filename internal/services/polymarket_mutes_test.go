@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestMuteMarketSilencesUntilExpiry(t *testing.T) {
+	svc, rec := newTestService(t)
+	if _, err := svc.MuteMarket(" ", time.Hour); err == nil {
+		t.Error("MuteMarket without a slug succeeded")
+	}
+	if _, err := svc.MuteMarket("super-bowl", 0); err == nil {
+		t.Error("MuteMarket without a duration succeeded")
+	}
+
+	if _, err := svc.MuteMarket("Super-Bowl-MVP", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.MuteMarket("fed-cut", 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if !svc.isMarketMuted(domain.PolymarketEvent{EventSlug: "super-bowl-mvp"}) || !svc.isMarketMuted(domain.PolymarketEvent{MarketSlug: "fed-cut"}) {
+		t.Error("muted market or event slug is not muted")
+	}
+	if svc.isMarketMuted(domain.PolymarketEvent{MarketSlug: "other"}) {
+		t.Error("unmuted market is muted")
+	}
+	if mutes := svc.GetMutedMarkets(); len(mutes) != 2 || mutes[0].Slug != "fed-cut" {
+		t.Errorf("mutes = %+v, want both, soonest to expire first", mutes)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if svc.isMarketMuted(domain.PolymarketEvent{MarketSlug: "fed-cut"}) {
+		t.Error("expired mute still silences the market")
+	}
+	unmuted := rec.of("polymarket:market_unmuted")
+	if len(unmuted) != 1 || unmuted[0].(domain.MarketMute).Slug != "fed-cut" {
+		t.Errorf("unmuted events = %v, want fed-cut auto-unmuted", unmuted)
+	}
+
+	if err := svc.UnmuteMarket("SUPER-BOWL-MVP"); err != nil {
+		t.Fatal(err)
+	}
+	if len(svc.GetMutedMarkets()) != 0 || len(rec.of("polymarket:market_unmuted")) != 2 {
+		t.Error("UnmuteMarket did not remove the mute")
+	}
+}
+
+func TestMutesSurviveRestart(t *testing.T) {
+	svc, _ := newTestService(t)
+	mute, err := svc.MuteMarket("super-bowl-mvp", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := NewPolymarketService(svc.store, localbus.New(), "")
+	t.Cleanup(restarted.Close)
+	mutes := restarted.GetMutedMarkets()
+	if len(mutes) != 1 || mutes[0].Slug != mute.Slug || !mutes[0].Until.Equal(mute.Until) {
+		t.Errorf("mutes after restart = %+v, want %+v", mutes, mute)
+	}
+}
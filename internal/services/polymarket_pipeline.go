@@ -0,0 +1,188 @@
+package services
+
+import (
+	"log"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// Ingest runs an event through the same pipeline as live WebSocket events,
+// e.g. to replay recorded events or generate load
+func (s *PolymarketService) Ingest(event domain.PolymarketEvent) {
+	s.onEvent(event)
+}
+
+// onEvent is called when a new event is received from WebSocket
+func (s *PolymarketService) onEvent(event domain.PolymarketEvent) {
+	// The feed redelivers trades after a reconnect; drop them before anything counts
+	// them, alerts on them or queues them for the database
+	if s.seenTrade(event) {
+		return
+	}
+
+	// Put book prices on the asset's current tick grid before anything compares them
+	s.observeTickSize(event)
+	s.normalizePrices(&event)
+
+	// Quotes and book deltas follow every book update, including those sampling drops
+	s.observeQuote(event)
+	spoof := s.observeBookDelta(event)
+
+	// Thin out high-frequency updates; raw counts still reach the status metrics
+	if !s.sampleEvent(event) {
+		return
+	}
+
+	// Size and hour history include trades the save filter drops, but not wash trades
+	wash := s.observeWashTrade(event)
+	unusualHour := s.observeTradeHour(event)
+	var outlier *sizeOutlier
+	if !wash {
+		outlier = s.observeTradeSize(event)
+	}
+
+	// Every trade by a watched wallet is alerted, whatever the save filter keeps
+	s.emitWatchedTrade(event)
+
+	s.mu.RLock()
+	filter := s.saveFilter
+	s.mu.RUnlock()
+
+	// Check basic filters only (ignore fresh wallet filter for saving)
+	if !s.matchesBasicFilter(event, filter) {
+		return
+	}
+
+	// Follow the book of every outcome token traded at a size worth saving
+	if event.EventType == domain.PolymarketEventTrade && event.AssetID != event.ConditionID {
+		s.marketFeed.Track(event.AssetID)
+	}
+
+	// Save wallet address to DB for background analysis (if new)
+	if event.WalletAddress != "" {
+		isNew, err := s.store.SaveWalletAddress(event.WalletAddress)
+		if err != nil {
+			log.Printf("[PolymarketService] Failed to save wallet address: %v", err)
+			s.errReporter.Report("wallet queue", err)
+		} else if isNew {
+			log.Printf("[PolymarketService] New wallet queued for analysis: %s", shortenAddress(event.WalletAddress))
+		}
+		// Volume totals back the wallet list's volume filter and sort
+		if event.EventType == domain.PolymarketEventTrade {
+			if err := s.store.UpdateWalletTradeStats(event.WalletAddress, parseNotionalValue(event.Price, event.Size)); err != nil {
+				log.Printf("[PolymarketService] Failed to update wallet trade stats: %v", err)
+			}
+		}
+		if event.TraderName != "" {
+			if err := s.store.SetWalletTraderName(event.WalletAddress, event.TraderName); err != nil {
+				log.Printf("[PolymarketService] Failed to save trader name: %v", err)
+			}
+		}
+	}
+
+	// Muted markets are still recorded, but must not trigger notifications
+	event.Muted = s.isMarketMuted(event)
+
+	// In-game trades on sports markets are recorded without alerts
+	s.applyGameSchedule(&event)
+
+	// Add built-in and custom detector signals before the event is stored
+	applyLongshotSignal(&event)
+	s.applyLateEntry(&event)
+	s.applyCategoryNovelty(&event)
+	s.applyMarketEntities(&event)
+	s.runDetectors(&event)
+	s.applyHotHand(&event)
+	s.applySizeOutlier(&event, outlier)
+	s.applyUnusualHour(&event, unusualHour)
+	s.applySpoofing(&event, spoof)
+	s.applyTagRules(&event)
+	applyWashTag(&event, wash)
+	s.applyFundingOrigin(&event)
+
+	// Tell a resurfacing wallet's alert what it did since the last one
+	s.applyActivityDiff(&event)
+
+	// Sum flow across the event's markets for event-level alerts
+	if !wash {
+		s.observeMarketGroup(event)
+	}
+
+	// Save event to DB and emit to frontend immediately
+	s.saveAndEmit(event)
+}
+
+// matchesBasicFilter checks basic filter criteria (doesn't require wallet analysis)
+func (s *PolymarketService) matchesBasicFilter(event domain.PolymarketEvent, filter domain.PolymarketEventFilter) bool {
+	// Check minimum notional value (price * size)
+	notional := parseNotionalValue(event.Price, event.Size)
+	minSize := filter.MinSize
+	if minSize <= 0 {
+		minSize = s.config.MinTradeSize
+	}
+	if notional < minSize {
+		return false
+	}
+
+	// Check event types
+	if len(filter.EventTypes) > 0 {
+		found := false
+		for _, et := range filter.EventTypes {
+			if et == event.EventType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	// Check side
+	if filter.Side != "" && string(event.Side) != string(filter.Side) {
+		return false
+	}
+
+	// Check price range
+	if event.Price != "" {
+		var price float64
+		parseFloat(event.Price, &price)
+		if filter.MinPrice > 0 && price < filter.MinPrice {
+			return false
+		}
+		if filter.MaxPrice > 0 && price > filter.MaxPrice {
+			return false
+		}
+	}
+
+	// Check market name (partial match)
+	if filter.MarketName != "" {
+		marketName := strings.ToLower(filter.MarketName)
+		eventMarket := strings.ToLower(event.MarketName)
+		eventTitle := strings.ToLower(event.EventTitle)
+		if !strings.Contains(eventMarket, marketName) && !strings.Contains(eventTitle, marketName) {
+			return false
+		}
+	}
+
+	// Noisy markets and known market makers are never saved
+	return !filter.Excludes(event)
+}
+
+func (s *PolymarketService) saveAndEmit(event domain.PolymarketEvent) {
+	// Alerts take the fast path; everything else is batched
+	if s.isAlertWorthy(event) {
+		s.saveAndEmitPriority(event)
+		return
+	}
+
+	// Queue for the database writers; may drop low-priority events under back-pressure
+	s.writes.push(event)
+	if s.images != nil && event.MarketImage != "" {
+		s.images.Prefetch(event.MarketImage)
+	}
+
+	// Emit to frontend for real-time updates
+	s.eventBus.Emit("polymarket:event", event)
+}
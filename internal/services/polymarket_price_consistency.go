@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Price sum band defaults when the config leaves them unset
+	defaultPriceSumMin = 0.95
+	defaultPriceSumMax = 1.05
+
+	// priceConsistencyInterval is how often the most active events are re-priced
+	priceConsistencyInterval = 5 * time.Minute
+
+	// priceConsistencyEvents is how many of the most traded events each check covers
+	priceConsistencyEvents = 50
+
+	// persistentPriceChecks is how many consecutive inconsistent checks flag an event,
+	// so a single stale book doesn't alert
+	persistentPriceChecks = 3
+)
+
+// GetPriceInconsistencies returns the events whose outcome prices have stayed outside
+// the band, largest gap first
+func (s *PolymarketService) GetPriceInconsistencies() []domain.PriceConsistency {
+	s.priceChecksMu.Lock()
+	defer s.priceChecksMu.Unlock()
+
+	result := []domain.PriceConsistency{}
+	for _, c := range s.priceChecks {
+		if c.Checks >= persistentPriceChecks {
+			result = append(result, *c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return math.Abs(result[i].AdjustedSum-1) > math.Abs(result[j].AdjustedSum-1)
+	})
+	return result
+}
+
+// CheckPriceConsistency re-prices the most traded events now and returns the
+// persistent inconsistencies
+func (s *PolymarketService) CheckPriceConsistency() []domain.PriceConsistency {
+	now := time.Now()
+	for _, group := range s.GetMarketGroups(priceConsistencyEvents) {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		event, err := s.markets.GetEvent(ctx, group.EventSlug)
+		cancel()
+		if err != nil {
+			s.errReporter.Report("price consistency", err)
+			continue
+		}
+		if event != nil {
+			s.observeEventPrices(event, now)
+		}
+	}
+
+	// Forget events that dropped out of the checked set
+	s.priceChecksMu.Lock()
+	for slug, c := range s.priceChecks {
+		if now.Sub(c.CheckedAt) > persistentPriceChecks*priceConsistencyInterval {
+			delete(s.priceChecks, slug)
+		}
+	}
+	s.priceChecksMu.Unlock()
+
+	return s.GetPriceInconsistencies()
+}
+
+// observeEventPrices records one check of an event's price sum and alerts once an
+// inconsistency has persisted
+func (s *PolymarketService) observeEventPrices(event *domain.EventMarkets, now time.Time) {
+	slug := strings.ToLower(event.Slug)
+	var open []domain.EventMarketPrice
+	for _, m := range event.Markets {
+		if !m.Closed && m.Price > 0 {
+			open = append(open, m)
+		}
+	}
+	sum, under, over := 0.0, 0.0, 0.0
+	for _, m := range open {
+		sum += m.Price
+		under += m.Price * (1 + m.FeeRate) // Cost of buying every outcome
+		over += m.Price * (1 - m.FeeRate)  // Proceeds of selling every outcome
+	}
+
+	minSum, maxSum := s.priceSumBand()
+	direction, adjusted := "", sum
+	switch {
+	case !event.NegRisk || event.Closed || len(open) < 2:
+		// Outcomes aren't exclusive or there's nothing to compare
+	case under < minSum:
+		direction, adjusted = "under", under
+	case over > maxSum:
+		direction, adjusted = "over", over
+	}
+
+	s.priceChecksMu.Lock()
+	c, ok := s.priceChecks[slug]
+	if direction == "" {
+		if ok {
+			delete(s.priceChecks, slug) // Back in band, a new episode alerts again
+		}
+		s.priceChecksMu.Unlock()
+		return
+	}
+	if !ok || c.Direction != direction {
+		c = &domain.PriceConsistency{
+			EventSlug: event.Slug,
+			Link:      "https://polymarket.com/event/" + event.Slug,
+			Direction: direction,
+			Since:     now,
+		}
+		s.priceChecks[slug] = c
+	}
+	c.EventTitle = event.Title
+	c.Markets = open
+	c.PriceSum = sum
+	c.AdjustedSum = adjusted
+	c.CheckedAt = now
+	c.Checks++
+	alert := c.Checks >= persistentPriceChecks && !c.Alerted
+	if alert {
+		c.Alerted = true
+	}
+	snapshot := *c
+	s.priceChecksMu.Unlock()
+
+	if alert {
+		s.emitPriceConsistencyAlert(snapshot, minSum, maxSum)
+	}
+}
+
+// emitPriceConsistencyAlert sends an arbitrage/structure alert for an event
+func (s *PolymarketService) emitPriceConsistencyAlert(c domain.PriceConsistency, minSum, maxSum float64) {
+	if s.isMarketMuted(domain.PolymarketEvent{EventSlug: c.EventSlug}) {
+		return
+	}
+	title := c.EventTitle
+	if title == "" {
+		title = c.EventSlug
+	}
+	gap, side, bound := minSum-c.AdjustedSum, "below", minSum
+	if c.Direction == "over" {
+		gap, side, bound = c.AdjustedSum-maxSum, "above", maxSum
+	}
+	message := fmt.Sprintf("Outcome prices in '%s' sum to %.3f after fees (%s %.2f) across %d markets for %d checks",
+		title, c.AdjustedSum, side, bound, len(c.Markets), c.Checks)
+	log.Printf("[PolymarketService] %s", message)
+
+	s.eventBus.Emit("polymarket:price_inconsistency", c)
+	s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+		Detector: "price_consistency",
+		Signal:   "price_sum_" + c.Direction,
+		Message:  message,
+		Score:    math.Min(1, 0.6+4*gap),
+		Alert:    true,
+		Metadata: map[string]string{
+			"eventSlug":   c.EventSlug,
+			"priceSum":    strconv.FormatFloat(c.PriceSum, 'f', 4, 64),
+			"adjustedSum": strconv.FormatFloat(c.AdjustedSum, 'f', 4, 64),
+			"direction":   c.Direction,
+			"markets":     strconv.Itoa(len(c.Markets)),
+		},
+		MarketName: title,
+		MarketLink: c.Link,
+		Timestamp:  c.CheckedAt,
+	})
+}
+
+// priceConsistencyWorker re-prices the most traded events periodically
+func (s *PolymarketService) priceConsistencyWorker() {
+	ticker := time.NewTicker(priceConsistencyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.CheckPriceConsistency()
+		}
+	}
+}
+
+// priceSumBand returns the configured band a fee-adjusted price sum must stay within
+func (s *PolymarketService) priceSumBand() (float64, float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	minSum, maxSum := s.config.PriceSumMin, s.config.PriceSumMax
+	if minSum <= 0 {
+		minSum = defaultPriceSumMin
+	}
+	if maxSum <= 0 {
+		maxSum = defaultPriceSumMax
+	}
+	return minSum, maxSum
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestCheckPriceConsistencyFlagsPersistentGaps(t *testing.T) {
+	svc, rec := newTestService(t)
+	events := gammaMarkets{
+		"oscars": `{"slug": "oscars", "title": "Best Picture", "negRisk": true, "markets": [
+			{"slug": "a", "bestBid": 0.30, "bestAsk": 0.32, "takerBaseFee": 100},
+			{"slug": "b", "bestBid": 0.30, "bestAsk": 0.32, "takerBaseFee": 100},
+			{"slug": "c", "outcomePrices": "[\"0.2\", \"0.8\"]", "takerBaseFee": 100},
+			{"slug": "d", "bestBid": 0.5, "bestAsk": 0.5, "closed": true}]}`,
+		"rain": `{"slug": "rain", "title": "Rain days", "markets": [
+			{"slug": "x", "bestBid": 0.1, "bestAsk": 0.1},
+			{"slug": "y", "bestBid": 0.1, "bestAsk": 0.1}]}`,
+	}
+	svc.markets.SetTransport(events)
+	for _, slug := range []string{"oscars", "rain"} {
+		svc.observeMarketGroup(domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: slug, EventSlug: slug, MarketSlug: slug + "-market",
+			WalletAddress: "0xa", Price: "0.5", Size: "100",
+		})
+	}
+
+	// A gap alerts only once it persists, and once per episode
+	for i := 1; i <= persistentPriceChecks+1; i++ {
+		flagged := svc.CheckPriceConsistency()
+		if want := i >= persistentPriceChecks; (len(flagged) == 1) != want {
+			t.Fatalf("check %d flagged %+v, want flagged = %v", i, flagged, want)
+		}
+	}
+	alerts := rec.of("polymarket:price_inconsistency")
+	if len(alerts) != 1 {
+		t.Fatalf("emitted %d alerts, want 1", len(alerts))
+	}
+	c := alerts[0].(domain.PriceConsistency)
+	if c.EventSlug != "oscars" || c.Direction != "under" || len(c.Markets) != 3 || c.PriceSum < 0.819 || c.PriceSum > 0.821 || c.AdjustedSum < 0.828 || c.AdjustedSum > 0.829 {
+		t.Errorf("alert = %+v, want oscars under-priced at 0.82, 0.8282 after fees", c)
+	}
+
+	// Back in band clears the event
+	events["oscars"] = `{"slug": "oscars", "negRisk": true, "markets": [{"slug": "a", "bestBid": 0.5, "bestAsk": 0.5}, {"slug": "b", "bestBid": 0.49, "bestAsk": 0.49}]}`
+	if flagged := svc.CheckPriceConsistency(); len(flagged) != 0 {
+		t.Errorf("flagged = %+v after prices recovered", flagged)
+	}
+}
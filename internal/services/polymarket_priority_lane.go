@@ -0,0 +1,77 @@
+package services
+
+import (
+	"log"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// priorityEnrichQueueSize bounds the wallets of alert-worthy trades waiting for an
+// immediate analysis; more are left to the background worker
+const priorityEnrichQueueSize = 100
+
+// isAlertWorthy reports whether an event would raise an alert: an unmuted trade by a
+// fresh wallet or with risk signals, over the alert thresholds. These skip the write
+// queue, so a storage backlog of book and price updates can't delay them.
+func (s *PolymarketService) isAlertWorthy(event domain.PolymarketEvent) bool {
+	if event.EventType != domain.PolymarketEventTrade || event.Muted {
+		return false
+	}
+	if len(event.RiskSignals) == 0 && !event.IsFreshWallet {
+		return false
+	}
+	s.mu.RLock()
+	minTradeSize, alertThreshold := s.config.MinTradeSize, s.config.AlertThreshold
+	s.mu.RUnlock()
+	return meetsAlertThresholds(event, minTradeSize, alertThreshold)
+}
+
+// saveAndEmitPriority is the fast path of saveAndEmit for alert-worthy events: they are
+// emitted first, so notifications go out at once, then written directly instead of
+// waiting behind the write queue, and their alert follow-ups run without waiting for
+// a batch. A wallet not analyzed yet is analyzed ahead of the background queue.
+func (s *PolymarketService) saveAndEmitPriority(event domain.PolymarketEvent) {
+	s.eventBus.Emit("polymarket:event", event)
+	s.queuePriorityEnrich(event.WalletAddress)
+	if s.images != nil && event.MarketImage != "" {
+		s.images.Prefetch(event.MarketImage)
+	}
+
+	s.writes.skip()
+	if err := s.store.SaveEvent(event); err != nil {
+		log.Printf("[PolymarketService] Failed to save priority event: %v", err)
+		s.errReporter.Report("event writer", err)
+	}
+	s.trackAlert(event)
+	s.sinkToSheets(event)
+	s.syncAlertCase(event)
+	s.publishTradingViewAlert(event)
+}
+
+// queuePriorityEnrich queues a wallet for immediate analysis unless it was analyzed before
+func (s *PolymarketService) queuePriorityEnrich(address string) {
+	if address == "" {
+		return
+	}
+	if profile, err := s.store.GetWallet(address); err == nil && profile != nil && !profile.AnalyzedAt.IsZero() {
+		return
+	}
+	select {
+	case s.enrichQueue <- strings.ToLower(address):
+	default: // Queue full; the background worker gets to the wallet
+	}
+}
+
+// priorityEnrichWorker analyzes the wallets of alert-worthy trades as they are queued,
+// so fresh wallet alerts don't wait for the next background batch
+func (s *PolymarketService) priorityEnrichWorker() {
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case address := <-s.enrichQueue:
+			s.refreshWallet(address)
+		}
+	}
+}
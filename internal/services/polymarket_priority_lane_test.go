@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestAlertWorthyTradesSkipTheWriteQueue(t *testing.T) {
+	svc, rec := newTestService(t)
+	svc.mu.Lock()
+	svc.config.MinTradeSize, svc.config.AlertThreshold = 1000, 0.7
+	svc.config.EventBatchSize, svc.config.EventFlushIntervalMs = 1000, int(time.Hour/time.Millisecond)
+	svc.mu.Unlock()
+
+	trade := func(id, size string, fresh bool, score float64) domain.PolymarketEvent {
+		return domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: id, WalletAddress: "0x" + id, AssetID: "111",
+			Price: "0.5", Size: size, IsFreshWallet: fresh, RiskScore: score,
+		}
+	}
+	for _, tc := range []struct {
+		name  string
+		event domain.PolymarketEvent
+		want  bool
+	}{
+		{"fresh wallet", trade("a", "10000", true, 0), true},
+		{"risk signals over the threshold", func() domain.PolymarketEvent {
+			e := trade("b", "10000", false, 0.8)
+			e.RiskSignals = []string{"signal"}
+			return e
+		}(), true},
+		{"under the alert threshold", trade("c", "10000", true, 0.5), false},
+		{"under the minimum size", trade("d", "1000", true, 0), false},
+		{"no signals", trade("e", "10000", false, 0), false},
+		{"muted", func() domain.PolymarketEvent {
+			e := trade("f", "10000", true, 0)
+			e.Muted = true
+			return e
+		}(), false},
+		{"not a trade", domain.PolymarketEvent{EventType: domain.PolymarketEventBook, AssetID: "111", IsFreshWallet: true, Price: "0.5", Size: "10000"}, false},
+	} {
+		if got := svc.isAlertWorthy(tc.event); got != tc.want {
+			t.Errorf("%s: alert-worthy = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	// The alert-worthy trade is stored at once; the other waits for a batch
+	svc.saveAndEmit(trade("fresh", "10000", true, 0))
+	svc.saveAndEmit(trade("plain", "10000", false, 0))
+	stored, _ := svc.store.GetEvents(domain.PolymarketEventFilter{Limit: 10})
+	if len(stored) != 1 || stored[0].TradeID != "fresh" {
+		t.Errorf("stored %+v before a flush, want only the alert-worthy trade", stored)
+	}
+	if status := svc.writes.status(); status.Priority != 1 || status.Depth != 1 {
+		t.Errorf("write queue = %+v, want 1 priority write and 1 queued event", status)
+	}
+	if emitted := rec.of("polymarket:event"); len(emitted) != 2 {
+		t.Errorf("emitted %d events, want both", len(emitted))
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// publicSnapshotSaltKey is the settings key of the secret wallet hashes are salted with
+	publicSnapshotSaltKey = "public_snapshot_salt"
+
+	// Smart money is a wallet with at least this many resolved bets, this share of them won
+	smartMoneyMinBets    = 5
+	smartMoneyMinWinRate = 0.6
+
+	// publicTopWallets is how many hashed wallets a snapshot lists
+	publicTopWallets = 20
+
+	// publicWalletMinTrades keeps wallets with fewer trades in the window off the list:
+	// a single trade's size and time point straight at it on-chain
+	publicWalletMinTrades = 3
+)
+
+// publicVolumeBuckets are the upper bounds of the volume ranges wallets are shown in
+var publicVolumeBuckets = []struct {
+	max   float64
+	label string
+}{
+	{1e3, "<1k"},
+	{1e4, "1k-10k"},
+	{1e5, "10k-100k"},
+	{1e6, "100k-1M"},
+}
+
+// publicWallet is a wallet's exact flow, which never leaves the service
+type publicWallet struct {
+	volume float64
+	trades int
+	fresh  bool
+	smart  bool
+}
+
+// publicFlow is one wallet's trades in a group, copied out of the group lock
+type publicFlow struct {
+	wallet   string
+	notional float64
+	fresh    bool
+}
+
+// GetPublicSnapshot aggregates the recent trade flow of the tracked events for public
+// display: fresh-wallet flow and smart-money index per event, wallets hashed
+func (s *PolymarketService) GetPublicSnapshot(limit int) domain.PublicSnapshot {
+	_, window := s.groupFlowThresholds()
+	now := time.Now()
+	snapshot := domain.PublicSnapshot{
+		GeneratedAt: now,
+		WindowHours: int(window.Hours()),
+		Markets:     []domain.PublicMarketStats{},
+		TopWallets:  []domain.PublicWalletStats{},
+	}
+
+	flows := make(map[*domain.PublicMarketStats][]publicFlow)
+	s.groupsMu.Lock()
+	for _, g := range s.groups {
+		g.expire(now.Add(-window))
+		if len(g.trades) == 0 {
+			continue
+		}
+		market := &domain.PublicMarketStats{EventSlug: g.slug, EventTitle: g.title, Link: g.link, Trades: len(g.trades)}
+		trades := make([]publicFlow, 0, len(g.trades))
+		for _, t := range g.trades {
+			trades = append(trades, publicFlow{wallet: t.wallet, notional: t.notional, fresh: s.freshWallets[t.wallet]})
+		}
+		flows[market] = trades
+	}
+	s.groupsMu.Unlock()
+
+	smart := make(map[string]bool)
+	wallets := make(map[string]*publicWallet)
+	smartVolume := 0.0
+	for market, trades := range flows {
+		freshWallets := make(map[string]bool)
+		for _, t := range trades {
+			isSmart, known := smart[t.wallet]
+			if !known {
+				isSmart = s.isSmartMoney(t.wallet)
+				smart[t.wallet] = isSmart
+			}
+			market.Volume += t.notional
+			if t.fresh {
+				market.FreshWalletFlow += t.notional
+				freshWallets[t.wallet] = true
+			}
+			if isSmart {
+				market.SmartMoneyFlow += t.notional
+			}
+			w, ok := wallets[t.wallet]
+			if !ok {
+				w = &publicWallet{fresh: t.fresh, smart: isSmart}
+				wallets[t.wallet] = w
+			}
+			w.volume += t.notional
+			w.trades++
+		}
+		market.FreshWallets = len(freshWallets)
+		if market.Volume > 0 {
+			market.SmartMoneyIndex = market.SmartMoneyFlow / market.Volume
+		}
+		snapshot.Volume += market.Volume
+		snapshot.FreshWalletFlow += market.FreshWalletFlow
+		smartVolume += market.SmartMoneyFlow
+		snapshot.Markets = append(snapshot.Markets, *market)
+	}
+	if snapshot.Volume > 0 {
+		snapshot.SmartMoneyIndex = smartVolume / snapshot.Volume
+	}
+
+	sort.Slice(snapshot.Markets, func(i, j int) bool {
+		if snapshot.Markets[i].FreshWalletFlow != snapshot.Markets[j].FreshWalletFlow {
+			return snapshot.Markets[i].FreshWalletFlow > snapshot.Markets[j].FreshWalletFlow
+		}
+		return snapshot.Markets[i].Volume > snapshot.Markets[j].Volume
+	})
+	if limit > 0 && len(snapshot.Markets) > limit {
+		snapshot.Markets = snapshot.Markets[:limit]
+	}
+
+	snapshot.TopWallets = topPublicWallets(wallets, s.publicSalt())
+	return snapshot
+}
+
+// topPublicWallets lists the largest wallets with enough trades, hashed, with their
+// volume as a range
+func topPublicWallets(wallets map[string]*publicWallet, salt []byte) []domain.PublicWalletStats {
+	addresses := make([]string, 0, len(wallets))
+	for address, w := range wallets {
+		if w.trades >= publicWalletMinTrades {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool { return wallets[addresses[i]].volume > wallets[addresses[j]].volume })
+	if len(addresses) > publicTopWallets {
+		addresses = addresses[:publicTopWallets]
+	}
+
+	top := make([]domain.PublicWalletStats, 0, len(addresses))
+	for _, address := range addresses {
+		w := wallets[address]
+		top = append(top, domain.PublicWalletStats{
+			WalletHash:   hashWallet(salt, address),
+			VolumeBucket: volumeBucket(w.volume),
+			Fresh:        w.fresh,
+			Smart:        w.smart,
+		})
+	}
+	return top
+}
+
+// volumeBucket returns the public volume range of a notional
+func volumeBucket(volume float64) string {
+	for _, b := range publicVolumeBuckets {
+		if volume < b.max {
+			return b.label
+		}
+	}
+	return "1M+"
+}
+
+// isSmartMoney reports whether a wallet has a winning record in resolved markets.
+// Only cached streaks are used, so building a snapshot doesn't query the database.
+func (s *PolymarketService) isSmartMoney(wallet string) bool {
+	s.streaksMu.Lock()
+	streak, ok := s.streaks[wallet]
+	s.streaksMu.Unlock()
+	return ok && streak.ResolvedBets >= smartMoneyMinBets &&
+		float64(streak.Wins) >= smartMoneyMinWinRate*float64(streak.ResolvedBets)
+}
+
+// publicSalt returns the secret wallet hashes are keyed with, creating it on first use.
+// Hashes stay stable across restarts but can't be matched to addresses without it.
+func (s *PolymarketService) publicSalt() []byte {
+	var salt string
+	if err := s.store.LoadSetting(publicSnapshotSaltKey, &salt); err == nil && salt != "" {
+		if b, err := hex.DecodeString(salt); err == nil {
+			return b
+		}
+	}
+	b := make([]byte, 32)
+	rand.Read(b)
+	if err := s.store.SaveSetting(publicSnapshotSaltKey, hex.EncodeToString(b)); err != nil {
+		log.Printf("[PolymarketService] Failed to save public snapshot salt: %v", err)
+	}
+	return b
+}
+
+// hashWallet returns a short keyed hash of a wallet address
+func hashWallet(salt []byte, wallet string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(wallet))
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
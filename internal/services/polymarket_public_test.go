@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestPublicSnapshotHidesExactWalletFlow(t *testing.T) {
+	svc, _ := newTestService(t)
+	trade := func(wallet, size string) domain.PolymarketEvent {
+		return domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, EventSlug: "us-recession-in-2025", EventTitle: "US recession in 2025?",
+			WalletAddress: wallet, Price: "0.5", Size: size,
+		}
+	}
+	// A regular trader, and a whale with a single trade
+	for i := 0; i < publicWalletMinTrades; i++ {
+		svc.observeMarketGroup(trade("0x00000000000000000000000000000000000000e1", "4000"))
+	}
+	svc.observeMarketGroup(trade("0x00000000000000000000000000000000000000e2", "100000"))
+
+	snapshot := svc.GetPublicSnapshot(10)
+	if len(snapshot.TopWallets) != 1 {
+		t.Fatalf("top wallets = %+v, want only the wallet with %d trades", snapshot.TopWallets, publicWalletMinTrades)
+	}
+	if got := snapshot.TopWallets[0].VolumeBucket; got != "1k-10k" {
+		t.Errorf("volume bucket = %q, want 1k-10k for $6000", got)
+	}
+	if snapshot.Volume != 56000 {
+		t.Errorf("snapshot volume = %v, want every trade counted", snapshot.Volume)
+	}
+}
+
+func TestVolumeBucket(t *testing.T) {
+	for volume, want := range map[float64]string{0: "<1k", 999: "<1k", 1000: "1k-10k", 250000: "100k-1M", 5e6: "1M+"} {
+		if got := volumeBucket(volume); got != want {
+			t.Errorf("volumeBucket(%v) = %q, want %q", volume, got, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// GetEvents retrieves events with optional filtering
+func (s *PolymarketService) GetEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	return s.store.GetEvents(filter)
+}
+
+// GetEventCount returns how many events match a filter, ignoring its limit and offset
+func (s *PolymarketService) GetEventCount(filter domain.PolymarketEventFilter) (int64, error) {
+	return s.store.GetEventCount(filter)
+}
+
+// GetEventPage returns a page of events with the number matching the filter in total,
+// their notional and fresh wallet count, read in one store round trip
+func (s *PolymarketService) GetEventPage(filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error) {
+	page, err := s.store.GetEventPage(filter)
+	if err != nil {
+		return nil, err
+	}
+	if page.Events == nil {
+		page.Events = []domain.PolymarketEvent{}
+	}
+	return page, nil
+}
+
+// SearchEvents finds stored events by words of their market name or event title, in
+// any order, newest first
+func (s *PolymarketService) SearchEvents(query string, limit int) ([]domain.PolymarketEvent, error) {
+	return s.store.SearchEvents(query, limit)
+}
+
+// GetMarketAggregates totals the stored trades matching the filter per market and
+// hour or day, for charts that shouldn't pull raw events
+func (s *PolymarketService) GetMarketAggregates(filter domain.PolymarketEventFilter, bucket domain.AggregateBucket) ([]domain.MarketAggregate, error) {
+	if !bucket.Valid() {
+		return nil, fmt.Errorf("invalid aggregate bucket: %q", bucket)
+	}
+	return s.store.GetMarketAggregates(filter, bucket)
+}
+
+// ClearEvents removes all stored events
+func (s *PolymarketService) ClearEvents() error {
+	if err := s.store.ClearEvents(); err != nil {
+		return err
+	}
+	s.eventBus.Emit("polymarket:events_cleared", nil)
+	return nil
+}
+
+// GetWallets retrieves all wallets from the database
+func (s *PolymarketService) GetWallets(limit int) ([]domain.WalletProfile, error) {
+	return s.store.GetAllWallets(limit)
+}
+
+// QueryWallets retrieves a filtered, sorted page of wallets
+func (s *PolymarketService) QueryWallets(filter domain.WalletFilter) (*domain.WalletPage, error) {
+	return s.store.QueryWallets(filter)
+}
+
+// SearchWallets finds wallets by partial address, trader name or tag
+func (s *PolymarketService) SearchWallets(query string, limit int) ([]domain.WalletSearchResult, error) {
+	return s.store.SearchWallets(query, limit)
+}
+
+// GetWalletStats returns the distribution of seen wallets
+func (s *PolymarketService) GetWalletStats() (*domain.WalletStats, error) {
+	return s.store.GetWalletStats()
+}
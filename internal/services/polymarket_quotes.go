@@ -0,0 +1,258 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// quoteFlushInterval is how often changed quotes are written to the database
+	quoteFlushInterval = 5 * time.Second
+
+	// maxQuoteAssets bounds the per-asset quote state
+	maxQuoteAssets = 50000
+
+	// Spread widening defaults when the config leaves them unset
+	defaultSpreadWidenRatio = 3.0
+	defaultSpreadMinWidth   = 0.05
+
+	// spreadBaselineWeight is the weight of each update in a book's usual spread
+	spreadBaselineWeight = 0.05
+
+	// spreadMinUpdates is how many updates a book needs before its usual spread is trusted
+	spreadMinUpdates = 20
+
+	// spreadMinTicks is how many ticks a spread must widen by to alert, so a book quoted
+	// one tick wide on a coarse grid doesn't read as pulled liquidity
+	spreadMinTicks = 2
+
+	// spreadAlertCooldown keeps a widened book from alerting again right away
+	spreadAlertCooldown = 10 * time.Minute
+
+	// maxSpreadSignals bounds the signal history
+	maxSpreadSignals = 100
+)
+
+// quoteState is the latest quote of an asset and its usual spread
+type quoteState struct {
+	quote     domain.MarketQuote
+	baseline  float64 // Moving average of the spread
+	updates   int
+	alertedAt time.Time
+	dirty     bool // Changed since the last flush
+}
+
+// GetQuotes returns the latest best bid and ask of the given assets, or of every asset
+// when none are given, most recently updated first
+func (s *PolymarketService) GetQuotes(assetIDs []string) ([]domain.MarketQuote, error) {
+	s.flushQuotes()
+	return s.store.GetQuotes(assetIDs)
+}
+
+// GetSpreadSignals returns the books recently flagged for a widened spread, newest first
+func (s *PolymarketService) GetSpreadSignals() []domain.SpreadSignal {
+	s.quotesMu.Lock()
+	defer s.quotesMu.Unlock()
+
+	signals := make([]domain.SpreadSignal, len(s.spreadSignals))
+	for i, signal := range s.spreadSignals {
+		signals[len(signals)-1-i] = signal
+	}
+	return signals
+}
+
+// observeQuote keeps the best bid and ask of book and price change events, and returns
+// a signal when the spread jumps far above the book's usual width. Like the spoofing
+// check it sees updates that sampling and the save filter drop.
+func (s *PolymarketService) observeQuote(event domain.PolymarketEvent) *domain.SpreadSignal {
+	if event.AssetID == "" || (event.EventType != domain.PolymarketEventBook && event.EventType != domain.PolymarketEventPriceChange) {
+		return nil
+	}
+	bid, bidErr := strconv.ParseFloat(event.BestBid, 64)
+	ask, askErr := strconv.ParseFloat(event.BestAsk, 64)
+	if bidErr != nil || askErr != nil || bid <= 0 || ask <= 0 || ask < bid {
+		return nil
+	}
+	at := event.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	ratio, minWidth := s.spreadThresholds()
+	minWiden := spreadMinTicks * s.tickSize(event.AssetID, domain.DefaultTickSize)
+	spread := domain.QuoteSpread(bid, ask)
+
+	var evicted []domain.MarketQuote
+	s.quotesMu.Lock()
+	state := s.quotes[event.AssetID]
+	if state == nil {
+		if len(s.quotes) >= maxQuoteAssets {
+			evicted = s.takeChangedQuotesLocked()
+			s.quotes = make(map[string]*quoteState)
+		}
+		state = &quoteState{}
+		s.quotes[event.AssetID] = state
+	}
+	conditionID := event.ConditionID
+	if conditionID == "" {
+		conditionID = state.quote.ConditionID
+	}
+	state.quote = domain.MarketQuote{
+		AssetID:     event.AssetID,
+		ConditionID: conditionID,
+		BestBid:     bid,
+		BestAsk:     ask,
+		Spread:      spread,
+		UpdatedAt:   at,
+	}
+	state.dirty = true
+
+	var signal *domain.SpreadSignal
+	if state.updates >= spreadMinUpdates && spread >= minWidth-1e-9 && spread >= ratio*state.baseline &&
+		spread-state.baseline >= minWiden-1e-9 && at.Sub(state.alertedAt) >= spreadAlertCooldown {
+		state.alertedAt = at
+		signal = &domain.SpreadSignal{
+			AssetID:     event.AssetID,
+			ConditionID: conditionID,
+			MarketName:  event.MarketName,
+			BestBid:     bid,
+			BestAsk:     ask,
+			Spread:      spread,
+			Baseline:    state.baseline,
+			Ratio:       spread / math.Max(state.baseline, 1e-9),
+			DetectedAt:  at,
+		}
+		s.spreadSignals = append(s.spreadSignals, *signal)
+		if len(s.spreadSignals) > maxSpreadSignals {
+			s.spreadSignals = s.spreadSignals[len(s.spreadSignals)-maxSpreadSignals:]
+		}
+	}
+	if state.updates == 0 {
+		state.baseline = spread
+	} else {
+		state.baseline += spreadBaselineWeight * (spread - state.baseline)
+	}
+	state.updates++
+	s.quotesMu.Unlock()
+
+	s.saveQuotes(evicted)
+
+	if signal != nil {
+		s.emitSpreadSignal(*signal, event)
+	}
+	return signal
+}
+
+// resetSpreadBaseline forgets an asset's usual spread, e.g. once its tick size changed
+// and spreads on the old grid no longer compare
+func (s *PolymarketService) resetSpreadBaseline(assetID string) {
+	s.quotesMu.Lock()
+	if state := s.quotes[assetID]; state != nil {
+		state.baseline, state.updates = 0, 0
+	}
+	s.quotesMu.Unlock()
+}
+
+// emitSpreadSignal sends a widened spread as a liquidity signal, and as an alert when
+// spread alerts are enabled
+func (s *PolymarketService) emitSpreadSignal(signal domain.SpreadSignal, event domain.PolymarketEvent) {
+	s.eventBus.Emit("polymarket:spread_widening", signal)
+	if s.isMarketMuted(event) {
+		return
+	}
+	s.mu.RLock()
+	alert := s.config.SpreadAlerts
+	s.mu.RUnlock()
+
+	s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+		Detector: "spread",
+		Signal:   "spread_widening",
+		Message:  spreadMessage(signal),
+		Score:    math.Min(1, 0.5+0.05*signal.Ratio),
+		Alert:    alert,
+		Metadata: map[string]string{
+			"assetId":  signal.AssetID,
+			"bestBid":  strconv.FormatFloat(signal.BestBid, 'f', -1, 64),
+			"bestAsk":  strconv.FormatFloat(signal.BestAsk, 'f', -1, 64),
+			"spread":   strconv.FormatFloat(signal.Spread, 'f', 4, 64),
+			"baseline": strconv.FormatFloat(signal.Baseline, 'f', 4, 64),
+		},
+		MarketName: event.MarketName,
+		MarketLink: event.MarketLink,
+		Timestamp:  signal.DetectedAt,
+	})
+}
+
+// spreadMessage describes a widened spread
+func spreadMessage(signal domain.SpreadSignal) string {
+	return fmt.Sprintf("Spread widened to %.1f¢ (%g / %g), %.1fx its usual %.1f¢: liquidity was pulled",
+		signal.Spread*100, signal.BestBid, signal.BestAsk, signal.Ratio, signal.Baseline*100)
+}
+
+// spreadThresholds returns the configured widening ratio and narrowest flagged spread
+func (s *PolymarketService) spreadThresholds() (float64, float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ratio, minWidth := s.config.SpreadWidenRatio, s.config.SpreadMinWidth
+	if ratio <= 0 {
+		ratio = defaultSpreadWidenRatio
+	}
+	if minWidth <= 0 {
+		minWidth = defaultSpreadMinWidth
+	}
+	return ratio, minWidth
+}
+
+// quoteWorker writes changed quotes to the database every few seconds
+func (s *PolymarketService) quoteWorker() {
+	ticker := time.NewTicker(quoteFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			s.flushQuotes()
+			return
+		case <-ticker.C:
+			s.flushQuotes()
+		}
+	}
+}
+
+// flushQuotes writes the quotes changed since the last flush
+func (s *PolymarketService) flushQuotes() {
+	s.quotesMu.Lock()
+	batch := s.takeChangedQuotesLocked()
+	s.quotesMu.Unlock()
+	s.saveQuotes(batch)
+}
+
+// takeChangedQuotesLocked returns the quotes changed since the last flush and marks them
+// written; the caller holds quotesMu
+func (s *PolymarketService) takeChangedQuotesLocked() []domain.MarketQuote {
+	var batch []domain.MarketQuote
+	for _, state := range s.quotes {
+		if state.dirty {
+			batch = append(batch, state.quote)
+			state.dirty = false
+		}
+	}
+	return batch
+}
+
+// saveQuotes writes quotes to the database. Quotes that fail to save are written
+// again on their next update.
+func (s *PolymarketService) saveQuotes(batch []domain.MarketQuote) {
+	if len(batch) == 0 {
+		return
+	}
+	if err := s.store.SaveQuotes(batch); err != nil {
+		log.Printf("[PolymarketService] Failed to save %d quotes: %v", len(batch), err)
+		s.errReporter.Report("quotes", err)
+	}
+}
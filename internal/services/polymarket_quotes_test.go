@@ -0,0 +1,55 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestQuotesFillFromMarketChannel(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	ingestFrame(t, svc, `[{"event_type":"book","asset_id":"111","market":"0xabc","timestamp":"1760000000000",
+		"bids":[{"price":"0.48","size":"100"},{"price":"0.50","size":"250"}],
+		"asks":[{"price":"0.55","size":"80"},{"price":"0.53","size":"40"}]}]`)
+	ingestFrame(t, svc, `{"event_type":"price_change","market":"0xdef","timestamp":"1760000001000",
+		"price_changes":[{"asset_id":"222","price":"0.3","size":"10","side":"BUY","best_bid":"0.3","best_ask":"0.32"}]}`)
+
+	quotes, err := svc.GetQuotes(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) != 2 {
+		t.Fatalf("got %d quotes, want 2", len(quotes))
+	}
+	byAsset := make(map[string]string)
+	for _, q := range quotes {
+		byAsset[q.AssetID] = fmt.Sprintf("%s %.2f/%.2f", q.ConditionID, q.BestBid, q.BestAsk)
+	}
+	want := map[string]string{"111": "0xabc 0.50/0.53", "222": "0xdef 0.30/0.32"}
+	for asset, quote := range want {
+		if byAsset[asset] != quote {
+			t.Errorf("quote of %s = %q, want %q", asset, byAsset[asset], quote)
+		}
+	}
+}
+
+func TestQuotesSpreadWideningSignal(t *testing.T) {
+	svc, rec := newTestService(t)
+
+	frame := `{"event_type":"price_change","market":"0xabc","timestamp":"%d",
+		"price_changes":[{"asset_id":"111","price":"0.5","size":"10","side":"BUY","best_bid":"%s","best_ask":"%s"}]}`
+	at := int64(1760000000000)
+	for i := 0; i < spreadMinUpdates; i++ {
+		at += 1000
+		ingestFrame(t, svc, fmt.Sprintf(frame, at, "0.50", "0.51"))
+	}
+	ingestFrame(t, svc, fmt.Sprintf(frame, at+1000, "0.40", "0.60"))
+
+	signals := svc.GetSpreadSignals()
+	if len(signals) != 1 || signals[0].AssetID != "111" {
+		t.Fatalf("got signals %+v, want one for asset 111", signals)
+	}
+	if len(rec.of("polymarket:spread_widening")) != 1 {
+		t.Errorf("spread widening was not emitted")
+	}
+}
@@ -0,0 +1,52 @@
+package services
+
+import "github.com/luthebao/poly-xtools/internal/domain"
+
+// recentTradeCapacity bounds the trade keys remembered to drop redelivered trades. A
+// reconnect replays seconds of trades, far fewer than this.
+const recentTradeCapacity = 20000
+
+// recentTrades remembers the keys of the latest trades, forgetting the oldest first
+type recentTrades struct {
+	keys map[string]struct{}
+	ring []string // Keys in arrival order; next is overwritten first once full
+	next int
+}
+
+func newRecentTrades(capacity int) *recentTrades {
+	return &recentTrades{
+		keys: make(map[string]struct{}, capacity),
+		ring: make([]string, 0, capacity),
+	}
+}
+
+// add remembers a key, reporting false when it was already remembered
+func (r *recentTrades) add(key string) bool {
+	if _, ok := r.keys[key]; ok {
+		return false
+	}
+	if len(r.ring) < cap(r.ring) {
+		r.ring = append(r.ring, key)
+	} else {
+		delete(r.keys, r.ring[r.next])
+		r.ring[r.next] = key
+		r.next = (r.next + 1) % len(r.ring)
+	}
+	r.keys[key] = struct{}{}
+	return true
+}
+
+// seenTrade reports whether a trade was already ingested, and remembers it otherwise.
+// Its key includes the wallet and outcome token, see domain.PolymarketEvent.TradeKey.
+func (s *PolymarketService) seenTrade(event domain.PolymarketEvent) bool {
+	if event.EventType != domain.PolymarketEventTrade {
+		return false
+	}
+	key := event.TradeKey()
+	if key == "" {
+		return false
+	}
+	s.recentMu.Lock()
+	defer s.recentMu.Unlock()
+	return !s.recent.add(key)
+}
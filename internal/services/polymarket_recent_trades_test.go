@@ -0,0 +1,45 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestSeenTradeKeysEachFill(t *testing.T) {
+	svc, _ := newTestService(t)
+	fill := func(wallet, asset string) domain.PolymarketEvent {
+		return domain.PolymarketEvent{EventType: domain.PolymarketEventTrade, TradeID: "0xtx", WalletAddress: wallet, AssetID: asset}
+	}
+
+	if svc.seenTrade(fill("0xa", "111")) {
+		t.Fatal("first fill reported as seen")
+	}
+	// Other fills of the same transaction are different trades
+	if svc.seenTrade(fill("0xb", "111")) || svc.seenTrade(fill("0xa", "222")) {
+		t.Error("another fill of the transaction reported as seen")
+	}
+	if !svc.seenTrade(fill("0xa", "111")) {
+		t.Error("redelivered fill not reported as seen")
+	}
+	if svc.seenTrade(domain.PolymarketEvent{EventType: domain.PolymarketEventBook, AssetID: "111"}) {
+		t.Error("book update reported as seen")
+	}
+}
+
+func TestRecentTradesForgetsOldest(t *testing.T) {
+	recent := newRecentTrades(3)
+	for i := 0; i < 4; i++ {
+		recent.add(fmt.Sprint(i))
+	}
+	if !recent.add("0") {
+		t.Error("oldest key still remembered past capacity")
+	}
+	if recent.add("3") {
+		t.Error("newest key forgotten")
+	}
+	if len(recent.keys) != 3 {
+		t.Errorf("remembering %d keys, want 3", len(recent.keys))
+	}
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// walletRefreshConcurrency is how many profile API requests a refresh batch runs at once
+	walletRefreshConcurrency = 5
+
+	// walletRefreshStagger spaces out the start of each request in a batch
+	walletRefreshStagger = 100 * time.Millisecond
+)
+
+// RefreshWallets forces re-analysis of the given wallets ahead of the background queue.
+// High priority wallets are picked up on the next worker tick; immediate priority
+// wallets are refreshed right away, even if the watcher is not running.
+// Addresses are lowercased as on the watchlist. Returns the number of wallets scheduled.
+func (s *PolymarketService) RefreshWallets(addresses []string, priority domain.WalletRefreshPriority) (int, error) {
+	normalized := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if !validWalletAddress(address) {
+			return 0, fmt.Errorf("invalid wallet address: %q", address)
+		}
+		normalized = append(normalized, strings.ToLower(address))
+	}
+	addresses = mergeAddresses(nil, normalized)
+	if len(addresses) == 0 {
+		return 0, nil
+	}
+
+	// Make sure every wallet exists in the DB so the refresh result can be stored
+	for _, address := range addresses {
+		if _, err := s.store.SaveWalletAddress(address); err != nil {
+			return 0, fmt.Errorf("failed to save wallet address: %w", err)
+		}
+	}
+
+	switch priority {
+	case domain.WalletRefreshPriorityImmediate:
+		log.Printf("[PolymarketService] Refreshing %d wallets immediately", len(addresses))
+		go s.refreshWalletBatch(addresses, nil)
+	case domain.WalletRefreshPriorityHigh, "":
+		s.mu.Lock()
+		s.priorityQueue = mergeAddresses(s.priorityQueue, addresses)
+		queued := len(s.priorityQueue)
+		s.mu.Unlock()
+		log.Printf("[PolymarketService] Queued %d wallets for priority refresh (%d pending)", len(addresses), queued)
+	default:
+		return 0, fmt.Errorf("unknown refresh priority: %s", priority)
+	}
+
+	return len(addresses), nil
+}
+
+// refreshWalletBatch refreshes wallets with bounded concurrency; the profile API takes
+// one address per request. It returns once every started refresh is done, or early
+// (after waiting for those in flight) when stop is closed.
+func (s *PolymarketService) refreshWalletBatch(addresses []string, stop <-chan struct{}) {
+	sem := make(chan struct{}, walletRefreshConcurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for i, address := range addresses {
+		select {
+		case <-stop:
+			return
+		case sem <- struct{}{}:
+		}
+
+		// Stagger request starts to avoid rate limiting
+		if i > 0 {
+			time.Sleep(walletRefreshStagger)
+		}
+
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.refreshWallet(address)
+		}(address)
+	}
+}
+
+// takePriorityWallets removes and returns all wallets queued by RefreshWallets
+func (s *PolymarketService) takePriorityWallets() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addresses := s.priorityQueue
+	s.priorityQueue = nil
+	return addresses
+}
+
+// mergeAddresses appends extra to base, skipping empty addresses and duplicates in any
+// case. The first spelling is kept, so stored wallets are refreshed under their own key.
+func mergeAddresses(base, extra []string) []string {
+	seen := make(map[string]bool, len(base)+len(extra))
+	merged := make([]string, 0, len(base)+len(extra))
+	for _, list := range [][]string{base, extra} {
+		for _, address := range list {
+			key := strings.ToLower(strings.TrimSpace(address))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, address)
+		}
+	}
+	return merged
+}
+
+// walletAnalysisWorker periodically processes wallets in background
+func (s *PolymarketService) walletAnalysisWorker() {
+	log.Println("[PolymarketService] Starting wallet analysis worker")
+
+	// Process wallets every 10 seconds, batch of 10
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			log.Println("[PolymarketService] Wallet analysis worker stopped")
+			return
+		case <-ticker.C:
+			s.expireMutes()
+			s.processWallets()
+		}
+	}
+}
+
+// processWallets fetches and updates wallet trade counts
+func (s *PolymarketService) processWallets() {
+	// Wallets queued via RefreshWallets and the whole watchlist go ahead of the background
+	// queue; refreshWalletBatch bounds how many are fetched at once
+	addresses := mergeAddresses(s.takePriorityWallets(), s.GetWatchedWallets())
+
+	// Get wallets that need refresh (oldest analyzed first, includes unanalyzed)
+	background, err := s.store.GetWalletsForRefresh(10, s.blacklistedAddresses()) // Process 10 at a time
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to get wallets for refresh: %v", err)
+	}
+	addresses = mergeAddresses(addresses, background)
+
+	if len(addresses) == 0 {
+		return
+	}
+
+	log.Printf("[PolymarketService] Refreshing %d wallets", len(addresses))
+
+	s.refreshWalletBatch(addresses, s.stopCh)
+}
+
+// refreshWallet re-fetches a single wallet and emits a fresh wallet alert if needed
+func (s *PolymarketService) refreshWallet(address string) {
+	if s.IsWalletBlacklisted(address) {
+		return
+	}
+
+	s.mu.RLock()
+	analyzer := s.walletAnalyzer
+	s.mu.RUnlock()
+
+	// Remember the stored classification to detect freshness changes
+	previous, _ := s.store.GetWallet(address)
+
+	// Fetch fresh data from API (always re-fetch, ignore cache)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	profile, err := analyzer.FetchAndUpdateWallet(ctx, address)
+	cancel()
+
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to refresh wallet %s: %v", shortenAddress(address), err)
+		s.errReporter.Report("wallet refresh", err)
+		return
+	}
+
+	if profile == nil || profile.BetCount < 0 {
+		log.Printf("[PolymarketService] Could not get stats for wallet %s", shortenAddress(address))
+		return
+	}
+
+	s.publishWalletUpdate(*profile, previous)
+
+	// If fresh wallet, emit alert and update counter
+	if profile.IsFresh {
+		s.client.IncrementFreshWalletsFound()
+
+		log.Printf("[PolymarketService] FRESH WALLET DETECTED: %s (trades=%d, joinDate=%s, level=%s)",
+			shortenAddress(address),
+			profile.BetCount,
+			profile.JoinDate,
+			profile.FreshnessLevel)
+
+		// Emit fresh wallet alert with profile
+		s.eventBus.Emit("polymarket:fresh_wallet_detected", *profile)
+		s.markGroupWalletFresh(address)
+		s.queueFundingLookup(address)
+	}
+}
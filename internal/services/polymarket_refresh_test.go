@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestRefreshWalletsNormalizesAddresses(t *testing.T) {
+	svc, _ := newTestService(t)
+	lower := "0x" + strings.Repeat("ab", 20)
+	mixed := "0x" + strings.Repeat("aB", 20)
+
+	n, err := svc.RefreshWallets([]string{" " + mixed + " ", lower, mixed}, domain.WalletRefreshPriorityHigh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("scheduled %d wallets, want the one wallet once", n)
+	}
+	if queued := svc.takePriorityWallets(); len(queued) != 1 || queued[0] != lower {
+		t.Errorf("priority queue = %v, want [%s]", queued, lower)
+	}
+	if wallets, _ := svc.GetWallets(10); len(wallets) != 1 {
+		t.Errorf("got %d stored wallets, want one", len(wallets))
+	}
+
+	if _, err := svc.RefreshWallets([]string{lower, "0x123"}, domain.WalletRefreshPriorityHigh); err == nil {
+		t.Error("refreshing an invalid address succeeded")
+	}
+}
+
+func TestMergeAddressesSkipsCaseDuplicates(t *testing.T) {
+	stored := "0x" + strings.Repeat("Ab", 20)
+	merged := mergeAddresses([]string{stored, ""}, []string{strings.ToLower(stored), "0x" + strings.Repeat("cd", 20)})
+	if len(merged) != 2 || merged[0] != stored {
+		t.Errorf("merged = %v, want the stored spelling and the other wallet", merged)
+	}
+}
+
+// slowProfiles answers profile API requests after a delay, recording peak concurrency
+type slowProfiles struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	fetched  int
+}
+
+func (p *slowProfiles) RoundTrip(req *http.Request) (*http.Response, error) {
+	p.mu.Lock()
+	p.inFlight++
+	p.peak = max(p.peak, p.inFlight)
+	p.mu.Unlock()
+
+	time.Sleep(300 * time.Millisecond)
+
+	p.mu.Lock()
+	p.inFlight--
+	p.fetched++
+	p.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"trades": 40}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestRefreshWalletBatchFetchesInParallel(t *testing.T) {
+	svc, _ := newTestService(t)
+	profiles := &slowProfiles{}
+	svc.mu.Lock()
+	svc.walletAnalyzer.SetTransport(profiles)
+	svc.mu.Unlock()
+
+	var addresses []string
+	for i := range 10 {
+		addresses = append(addresses, fmt.Sprintf("0x%040x", i+1))
+	}
+	start := time.Now()
+	svc.refreshWalletBatch(addresses, nil)
+	elapsed := time.Since(start)
+
+	if profiles.fetched != len(addresses) {
+		t.Errorf("fetched %d profiles, want %d", profiles.fetched, len(addresses))
+	}
+	if profiles.peak < 2 || profiles.peak > walletRefreshConcurrency {
+		t.Errorf("peak concurrency = %d, want parallel fetches bounded by %d", profiles.peak, walletRefreshConcurrency)
+	}
+	if elapsed >= 10*300*time.Millisecond/2 {
+		t.Errorf("batch took %s, want well under the serial 3s", elapsed)
+	}
+}
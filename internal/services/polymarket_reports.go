@@ -0,0 +1,235 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/report"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// reportTradeScanLimit bounds how many recent trades are scanned for a wallet report
+const reportTradeScanLimit = 5000
+
+// addressPattern matches full EVM addresses left in free text
+var addressPattern = regexp.MustCompile(`0x[0-9a-fA-F]{40}`)
+
+// GenerateReport writes a self-contained report for a wallet or investigation
+// into the reports directory next to the database
+func (s *PolymarketService) GenerateReport(req domain.ReportRequest) (*domain.ReportFile, error) {
+	var r *domain.ShareableReport
+	var err error
+	switch req.Kind {
+	case domain.ReportKindWallet:
+		r, err = s.buildWalletReport(strings.TrimSpace(req.Wallet))
+	case domain.ReportKindInvestigation:
+		r, err = s.buildInvestigationReport(req.InvestigationID)
+	default:
+		return nil, fmt.Errorf("invalid report kind: %q", req.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.RedactAddresses {
+		redactReport(r)
+	}
+
+	file, err := report.NewWriter(filepath.Join(filepath.Dir(s.dbPath), "reports")).Write(*r, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[PolymarketService] Generated %s report: %s", req.Kind, file.Path)
+	return file, nil
+}
+
+// buildWalletReport collects a wallet's profile and its recent stored trades
+func (s *PolymarketService) buildWalletReport(address string) (*domain.ShareableReport, error) {
+	if address == "" {
+		return nil, fmt.Errorf("wallet address is required")
+	}
+
+	wallet := s.reportWallet(address)
+	events, err := s.store.GetEvents(domain.PolymarketEventFilter{
+		EventTypes: []domain.PolymarketEventType{domain.PolymarketEventTrade},
+		Limit:      reportTradeScanLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trades: %w", err)
+	}
+	var trades []domain.PolymarketEvent
+	for _, e := range events {
+		if strings.EqualFold(e.WalletAddress, address) {
+			trades = append(trades, e)
+		}
+	}
+
+	r := &domain.ShareableReport{
+		Kind:        domain.ReportKindWallet,
+		Title:       "Wallet report " + address,
+		GeneratedAt: time.Now(),
+		Wallets:     []domain.ReportWallet{wallet},
+	}
+	addReportTrades(r, trades)
+	return r, nil
+}
+
+// buildInvestigationReport collects an investigation's wallets, markets, events and notes
+func (s *PolymarketService) buildInvestigationReport(id int64) (*domain.ShareableReport, error) {
+	inv, err := s.store.GetInvestigation(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &domain.ShareableReport{
+		Kind:        domain.ReportKindInvestigation,
+		Title:       inv.Name,
+		Description: inv.Description,
+		Status:      string(inv.Status),
+		GeneratedAt: time.Now(),
+		Wallets:     []domain.ReportWallet{},
+	}
+
+	var eventIDs []int64
+	for _, item := range inv.Items {
+		switch item.Type {
+		case domain.InvestigationItemWallet:
+			r.Wallets = append(r.Wallets, s.reportWallet(item.Ref))
+		case domain.InvestigationItemMarket:
+			r.Markets = append(r.Markets, item.Ref)
+		case domain.InvestigationItemEvent:
+			if id, err := strconv.ParseInt(item.Ref, 10, 64); err == nil {
+				eventIDs = append(eventIDs, id)
+			}
+		}
+	}
+
+	events, err := s.store.GetEventsByIDs(eventIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+	addReportTrades(r, events)
+
+	for _, note := range inv.Notes {
+		r.Notes = append(r.Notes, domain.ReportNote{Timestamp: note.CreatedAt, Body: note.Body})
+	}
+	return r, nil
+}
+
+// reportWallet describes a wallet from its stored profile, name and tags
+func (s *PolymarketService) reportWallet(address string) domain.ReportWallet {
+	wallet := domain.ReportWallet{Label: address, BetCount: -1}
+	if profile, err := s.store.GetWallet(address); err == nil {
+		wallet.BetCount = profile.BetCount
+		wallet.JoinDate = profile.JoinDate
+		wallet.FreshnessLevel = profile.FreshnessLevel
+	}
+	if results, err := s.store.SearchWallets(address, 1); err == nil && len(results) > 0 &&
+		strings.EqualFold(results[0].Wallet.Address, address) {
+		wallet.TraderName = results[0].TraderName
+		wallet.Tags = results[0].Tags
+	}
+	return wallet
+}
+
+// addReportTrades appends the trade events to a report and totals their notional
+func addReportTrades(r *domain.ShareableReport, events []domain.PolymarketEvent) {
+	r.Trades = []domain.ReportTrade{}
+	for _, e := range events {
+		var price float64
+		parseFloat(e.Price, &price)
+		notional := parseNotionalValue(e.Price, e.Size)
+
+		market := e.MarketName
+		if market == "" {
+			market = e.EventTitle
+		}
+		r.Trades = append(r.Trades, domain.ReportTrade{
+			Timestamp:   e.Timestamp,
+			Wallet:      e.WalletAddress,
+			Market:      market,
+			MarketLink:  e.MarketLink,
+			Outcome:     e.Outcome,
+			Side:        e.Side,
+			Price:       price,
+			Notional:    notional,
+			RiskScore:   e.RiskScore,
+			RiskSignals: e.RiskSignals,
+		})
+		r.TotalNotional += notional
+	}
+}
+
+// redactReport replaces wallet addresses with stable labels ("Wallet A") and drops
+// trader names, including any that appear in titles and notes
+func redactReport(r *domain.ShareableReport) {
+	labels := make(map[string]string)
+	label := func(address string) string {
+		key := strings.ToLower(address)
+		if l, ok := labels[key]; ok {
+			return l
+		}
+		l := "Wallet " + redactionSuffix(len(labels))
+		labels[key] = l
+		return l
+	}
+
+	var names []string
+	for i := range r.Wallets {
+		if r.Wallets[i].TraderName != "" {
+			names = append(names, r.Wallets[i].TraderName)
+		}
+		r.Wallets[i].Label = label(r.Wallets[i].Label)
+		r.Wallets[i].TraderName = ""
+	}
+	for i := range r.Trades {
+		if r.Trades[i].Wallet != "" {
+			r.Trades[i].Wallet = label(r.Trades[i].Wallet)
+		}
+	}
+
+	redactText := func(text string) string {
+		for address, l := range labels {
+			text = replaceFold(text, address, l)
+		}
+		for _, name := range names {
+			text = replaceFold(text, name, "[trader]")
+		}
+		return addressPattern.ReplaceAllString(text, "[address]")
+	}
+	r.Title = redactText(r.Title)
+	r.Description = redactText(r.Description)
+	for i := range r.Notes {
+		r.Notes[i].Body = redactText(r.Notes[i].Body)
+	}
+	for i := range r.Trades {
+		signals := make([]string, len(r.Trades[i].RiskSignals))
+		for j, signal := range r.Trades[i].RiskSignals {
+			signals[j] = redactText(signal)
+		}
+		r.Trades[i].RiskSignals = signals
+	}
+	r.Redacted = true
+}
+
+// redactionSuffix returns A..Z, then numbers for larger reports
+func redactionSuffix(i int) string {
+	if i < 26 {
+		return string(rune('A' + i))
+	}
+	return strconv.Itoa(i + 1)
+}
+
+// replaceFold replaces every case-insensitive occurrence of old in s
+func replaceFold(s, old, replacement string) string {
+	if old == "" {
+		return s
+	}
+	re := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(old))
+	return re.ReplaceAllLiteralString(s, replacement)
+}
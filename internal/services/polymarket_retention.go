@@ -0,0 +1,160 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// eventRetentionSettingKey is the settings key the retention policy is persisted under
+	eventRetentionSettingKey = "event_retention"
+
+	// retentionInterval is how often events beyond the retention policy are pruned
+	retentionInterval = time.Hour
+
+	// firstRetentionDelay lets startup settle before the first prune
+	firstRetentionDelay = 2 * time.Minute
+)
+
+// SetEventRetention saves the retention policy. Events beyond it are pruned on the
+// next hourly run, or now with PruneEvents.
+func (s *PolymarketService) SetEventRetention(retention domain.EventRetention) error {
+	if retention.MaxAgeDays < 0 || retention.MaxEvents < 0 {
+		return fmt.Errorf("retention limits must not be negative")
+	}
+	if err := s.store.SaveSetting(eventRetentionSettingKey, retention); err != nil {
+		return fmt.Errorf("failed to save event retention: %w", err)
+	}
+
+	s.mu.Lock()
+	s.retention = retention
+	s.mu.Unlock()
+
+	log.Printf("[PolymarketService] Event retention saved: maxAgeDays=%d maxEvents=%d keepTagged=%v archive=%v",
+		retention.MaxAgeDays, retention.MaxEvents, retention.KeepTagged, retention.Archive)
+	return nil
+}
+
+// GetEventRetention returns the retention policy
+func (s *PolymarketService) GetEventRetention() domain.EventRetention {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.retention
+}
+
+// GetLastEventPrune returns the result of the most recent pruning run, or nil
+func (s *PolymarketService) GetLastEventPrune() *domain.EventPruneResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastPrune
+}
+
+// PruneEvents deletes the events beyond the retention policy now, or with Archive set
+// moves those past the age limit to the archive. Freed pages are reused by new events;
+// OptimizeDatabase shrinks the file.
+func (s *PolymarketService) PruneEvents() (*domain.EventPruneResult, error) {
+	retention := s.GetEventRetention()
+	start := time.Now()
+	result := &domain.EventPruneResult{RunAt: start}
+
+	if retention.Enabled() {
+		var before time.Time
+		if retention.MaxAgeDays > 0 {
+			before = start.AddDate(0, 0, -retention.MaxAgeDays)
+		}
+		if retention.Archive && !before.IsZero() {
+			archived, err := s.store.ArchiveEvents(before)
+			result.Archived = archived
+			if err != nil {
+				return nil, fmt.Errorf("archived %d events before failing: %w", archived, err)
+			}
+			before = time.Time{} // Only the count limit still deletes
+		}
+		deleted, err := s.store.PruneEvents(before, retention.MaxEvents, retention.KeepTagged)
+		result.Deleted = deleted
+		if err != nil {
+			return nil, fmt.Errorf("pruned %d events before failing: %w", deleted, err)
+		}
+	}
+	if count, err := s.store.GetEventCount(domain.PolymarketEventFilter{}); err == nil {
+		result.Remaining = count
+	}
+	result.DurationMs = time.Since(start).Milliseconds()
+
+	s.mu.Lock()
+	s.lastPrune = result
+	s.mu.Unlock()
+
+	if result.Deleted > 0 || result.Archived > 0 {
+		log.Printf("[PolymarketService] Pruned %d and archived %d events in %dms, %d remaining",
+			result.Deleted, result.Archived, result.DurationMs, result.Remaining)
+		s.eventBus.Emit("polymarket:events_pruned", *result)
+	}
+	return result, nil
+}
+
+// ArchiveEvents moves events older than before to the archive table now, keeping the
+// events table small without losing history. Events added to an investigation stay.
+func (s *PolymarketService) ArchiveEvents(before time.Time) (*domain.EventPruneResult, error) {
+	if before.IsZero() {
+		return nil, fmt.Errorf("archive cutoff is required")
+	}
+	start := time.Now()
+	archived, err := s.store.ArchiveEvents(before)
+	if err != nil {
+		return nil, fmt.Errorf("archived %d events before failing: %w", archived, err)
+	}
+	result := &domain.EventPruneResult{RunAt: start, Archived: archived}
+	if count, err := s.store.GetEventCount(domain.PolymarketEventFilter{}); err == nil {
+		result.Remaining = count
+	}
+	result.DurationMs = time.Since(start).Milliseconds()
+
+	if archived > 0 {
+		log.Printf("[PolymarketService] Archived %d events older than %s in %dms",
+			archived, before.Format(time.RFC3339), result.DurationMs)
+		s.eventBus.Emit("polymarket:events_archived", *result)
+	}
+	return result, nil
+}
+
+// GetArchivedEvents retrieves archived events with the filtering of GetEvents
+func (s *PolymarketService) GetArchivedEvents(filter domain.PolymarketEventFilter) ([]domain.PolymarketEvent, error) {
+	return s.store.GetArchivedEvents(filter)
+}
+
+// retentionWorker prunes events beyond the retention policy periodically
+func (s *PolymarketService) retentionWorker() {
+	timer := time.NewTimer(firstRetentionDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-timer.C:
+			if s.GetEventRetention().Enabled() {
+				if _, err := s.PruneEvents(); err != nil {
+					log.Printf("[PolymarketService] Event pruning failed: %v", err)
+					s.errReporter.Report("event retention", err)
+				}
+			}
+			timer.Reset(retentionInterval)
+		}
+	}
+}
+
+// loadEventRetention restores the saved retention policy
+func (s *PolymarketService) loadEventRetention() {
+	retention := domain.DefaultEventRetention()
+	if err := s.store.LoadSetting(eventRetentionSettingKey, &retention); err != nil {
+		retention = domain.DefaultEventRetention()
+	}
+
+	s.mu.Lock()
+	s.retention = retention
+	s.mu.Unlock()
+}
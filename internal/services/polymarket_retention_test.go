@@ -0,0 +1,62 @@
+package services
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// tableStatsCounter counts the whole-database table scans asked of a store
+type tableStatsCounter struct {
+	*storage.MemoryPolymarketStore
+	scans atomic.Int32
+}
+
+func (s *tableStatsCounter) GetTableStats() ([]domain.DatabaseTableStats, error) {
+	s.scans.Add(1)
+	return s.MemoryPolymarketStore.GetTableStats()
+}
+
+func TestRetentionDoesNotScanTables(t *testing.T) {
+	store := &tableStatsCounter{MemoryPolymarketStore: storage.NewMemoryPolymarketStore()}
+	svc := NewPolymarketService(store, localbus.New(), "")
+	t.Cleanup(svc.Close)
+
+	now := time.Now()
+	for i, age := range []time.Duration{72 * time.Hour, 48 * time.Hour, time.Hour} {
+		event := domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("0xtx%d", i), Timestamp: now.Add(-age),
+			Price: "0.5", Size: "100",
+		}
+		if err := store.SaveEvent(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := svc.ArchiveEvents(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Archived != 2 || result.Remaining != 1 {
+		t.Errorf("archive = %d archived, %d remaining, want 2 and 1", result.Archived, result.Remaining)
+	}
+	if _, err := svc.PruneEvents(); err != nil {
+		t.Fatal(err)
+	}
+	if scans := store.scans.Load(); scans != 0 {
+		t.Errorf("retention scanned every table %d times", scans)
+	}
+
+	info, err := svc.GetDatabaseInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Tables) == 0 || store.scans.Load() != 1 {
+		t.Errorf("database info has %d tables after %d scans, want the tables from one scan", len(info.Tables), store.scans.Load())
+	}
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// eventSamplingSettingKey is the settings key sampling rules are persisted under
+	eventSamplingSettingKey = "event_sampling_rules"
+
+	// maxSampledAssets bounds the per-asset sampling state
+	maxSampledAssets = 50000
+)
+
+// sampleState tracks the updates of one event type and asset since the last kept one
+type sampleState struct {
+	skipped  int
+	price    float64
+	hasPrice bool
+}
+
+// SetEventSamplingRules replaces the per event type sampling rules. Trades cannot be
+// sampled since every trade is analyzed.
+func (s *PolymarketService) SetEventSamplingRules(rules []domain.EventSamplingRule) error {
+	normalized := make([]domain.EventSamplingRule, 0, len(rules))
+	seen := make(map[domain.PolymarketEventType]bool)
+	for i, rule := range rules {
+		if rule.EventType == "" {
+			return fmt.Errorf("sampling rule %d: event type is required", i+1)
+		}
+		if rule.EventType == domain.PolymarketEventTrade {
+			return fmt.Errorf("sampling rule %d: trades cannot be sampled", i+1)
+		}
+		if seen[rule.EventType] {
+			return fmt.Errorf("sampling rule %d: duplicate rule for %s", i+1, rule.EventType)
+		}
+		if rule.EveryN < 0 || rule.MinTickChange < 0 || rule.TickSize < 0 {
+			return fmt.Errorf("sampling rule %d: values cannot be negative", i+1)
+		}
+		if rule.EveryN == 0 && rule.MinTickChange == 0 {
+			return fmt.Errorf("sampling rule %d: set every N or a minimum tick change", i+1)
+		}
+		if rule.TickSize == 0 {
+			rule.TickSize = domain.DefaultTickSize
+		}
+		seen[rule.EventType] = true
+		normalized = append(normalized, rule)
+	}
+
+	if err := s.store.SaveSetting(eventSamplingSettingKey, normalized); err != nil {
+		return fmt.Errorf("failed to save sampling rules: %w", err)
+	}
+
+	s.setSamplingRules(normalized)
+	log.Printf("[PolymarketService] Saved %d event sampling rules", len(normalized))
+	return nil
+}
+
+// GetEventSamplingRules returns the per event type sampling rules
+func (s *PolymarketService) GetEventSamplingRules() []domain.EventSamplingRule {
+	s.samplingMu.Lock()
+	defer s.samplingMu.Unlock()
+	return append([]domain.EventSamplingRule{}, s.samplingRules...)
+}
+
+// sampleEvent counts a raw event and reports whether it should be kept
+func (s *PolymarketService) sampleEvent(event domain.PolymarketEvent) bool {
+	s.samplingMu.Lock()
+	defer s.samplingMu.Unlock()
+
+	stats, ok := s.samplingStats[event.EventType]
+	if !ok {
+		stats = &domain.EventSamplingStats{EventType: event.EventType}
+		s.samplingStats[event.EventType] = stats
+	}
+	stats.Received++
+
+	rule, ok := s.samplingRule(event.EventType)
+	if !ok || rule.EveryN == 1 {
+		stats.Kept++
+		return true
+	}
+
+	key := string(event.EventType) + ":" + event.AssetID
+	price, hasPrice := samplePrice(event)
+	tick := s.tickSize(event.AssetID, rule.TickSize)
+	state, seen := s.samplingState[key]
+	if !seen {
+		if len(s.samplingState) >= maxSampledAssets {
+			s.samplingState = make(map[string]*sampleState)
+		}
+		state = &sampleState{}
+		s.samplingState[key] = state
+	} else {
+		state.skipped++
+		periodic := rule.EveryN > 1 && state.skipped >= rule.EveryN
+		moved := rule.MinTickChange > 0 && hasPrice && state.hasPrice &&
+			math.Abs(price-state.price)/tick > float64(rule.MinTickChange)+1e-9
+		if !periodic && !moved {
+			return false
+		}
+	}
+
+	state.skipped = 0
+	if hasPrice {
+		state.price, state.hasPrice = price, true
+	}
+	stats.Kept++
+	return true
+}
+
+// samplingRule returns the rule for an event type; the caller holds samplingMu
+func (s *PolymarketService) samplingRule(eventType domain.PolymarketEventType) (domain.EventSamplingRule, bool) {
+	for _, rule := range s.samplingRules {
+		if rule.EventType == eventType {
+			return rule, true
+		}
+	}
+	return domain.EventSamplingRule{}, false
+}
+
+// samplingStatsSnapshot returns the raw and kept counts per event type
+func (s *PolymarketService) samplingStatsSnapshot() []domain.EventSamplingStats {
+	s.samplingMu.Lock()
+	stats := make([]domain.EventSamplingStats, 0, len(s.samplingStats))
+	for _, st := range s.samplingStats {
+		stats = append(stats, *st)
+	}
+	s.samplingMu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].EventType < stats[j].EventType })
+	return stats
+}
+
+// setSamplingRules swaps the rules and restarts per-asset sampling
+func (s *PolymarketService) setSamplingRules(rules []domain.EventSamplingRule) {
+	s.samplingMu.Lock()
+	s.samplingRules = rules
+	s.samplingState = make(map[string]*sampleState)
+	if s.samplingStats == nil {
+		s.samplingStats = make(map[domain.PolymarketEventType]*domain.EventSamplingStats)
+	}
+	s.samplingMu.Unlock()
+}
+
+// samplePrice returns the event's price, or the book midpoint when it carries none
+func samplePrice(event domain.PolymarketEvent) (float64, bool) {
+	if price, err := strconv.ParseFloat(event.Price, 64); err == nil && price > 0 {
+		return price, true
+	}
+	bid, bidErr := strconv.ParseFloat(event.BestBid, 64)
+	ask, askErr := strconv.ParseFloat(event.BestAsk, 64)
+	if bidErr != nil || askErr != nil || bid <= 0 || ask <= 0 {
+		return 0, false
+	}
+	return (bid + ask) / 2, true
+}
+
+// loadEventSamplingRules restores persisted sampling rules, falling back to defaults
+func (s *PolymarketService) loadEventSamplingRules() {
+	rules := domain.DefaultEventSamplingRules()
+	if err := s.store.LoadSetting(eventSamplingSettingKey, &rules); err != nil {
+		rules = domain.DefaultEventSamplingRules()
+	}
+	s.setSamplingRules(rules)
+}
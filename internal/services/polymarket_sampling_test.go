@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestSampleEventKeepsEveryNthOrMovedUpdate(t *testing.T) {
+	svc, _ := newTestService(t)
+	for name, rules := range map[string][]domain.EventSamplingRule{
+		"trades":    {{EventType: domain.PolymarketEventTrade, EveryN: 2}},
+		"duplicate": {{EventType: domain.PolymarketEventBook, EveryN: 2}, {EventType: domain.PolymarketEventBook, EveryN: 3}},
+		"no rule":   {{EventType: domain.PolymarketEventBook}},
+	} {
+		if err := svc.SetEventSamplingRules(rules); err == nil {
+			t.Errorf("%s: sampling rules were saved", name)
+		}
+	}
+	if err := svc.SetEventSamplingRules([]domain.EventSamplingRule{
+		{EventType: domain.PolymarketEventPriceChange, EveryN: 3, MinTickChange: 2},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	change := func(asset, price string) domain.PolymarketEvent {
+		return domain.PolymarketEvent{EventType: domain.PolymarketEventPriceChange, AssetID: asset, Price: price}
+	}
+	for i, tc := range []struct {
+		event domain.PolymarketEvent
+		kept  bool
+	}{
+		{change("a", "0.50"), true}, // First update of an asset
+		{change("a", "0.51"), false},
+		{change("b", "0.30"), true},
+		{change("a", "0.52"), false}, // Two ticks is not more than two
+		{change("a", "0.51"), true},  // Third since the last kept one
+		{change("a", "0.54"), true},  // Moved three ticks
+		{domain.PolymarketEvent{EventType: domain.PolymarketEventBook, AssetID: "a"}, true},
+		{domain.PolymarketEvent{EventType: domain.PolymarketEventBook, AssetID: "a"}, true}, // Books have no rule
+	} {
+		if kept := svc.sampleEvent(tc.event); kept != tc.kept {
+			t.Errorf("update %d (%s %s at %s): kept = %v, want %v", i, tc.event.EventType, tc.event.AssetID, tc.event.Price, kept, tc.kept)
+		}
+	}
+
+	stats := svc.samplingStatsSnapshot()
+	want := []domain.EventSamplingStats{
+		{EventType: domain.PolymarketEventBook, Received: 2, Kept: 2},
+		{EventType: domain.PolymarketEventPriceChange, Received: 6, Kept: 4},
+	}
+	if len(stats) != len(want) || stats[0] != want[0] || stats[1] != want[1] {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+	if rules := svc.GetEventSamplingRules(); len(rules) != 1 || rules[0].TickSize != domain.DefaultTickSize {
+		t.Errorf("rules = %+v, want the default tick size filled in", rules)
+	}
+}
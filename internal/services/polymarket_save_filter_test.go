@@ -0,0 +1,31 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestSaveFilterExclusions(t *testing.T) {
+	svc, _ := newTestService(t)
+	filter := domain.PolymarketEventFilter{
+		MinSize:            100,
+		ExcludeMarketNames: []string{"Up or Down"},
+		ExcludeWallets:     []string{"0xMaker"},
+	}
+
+	for _, tc := range []struct {
+		event domain.PolymarketEvent
+		want  bool
+	}{
+		{domain.PolymarketEvent{MarketName: "Fed cuts in March?", WalletAddress: "0xa"}, true},
+		{domain.PolymarketEvent{MarketName: "Bitcoin up or down - 3pm", WalletAddress: "0xa"}, false},
+		{domain.PolymarketEvent{MarketName: "BTC 3pm", EventTitle: "Bitcoin Up or Down", WalletAddress: "0xa"}, false},
+		{domain.PolymarketEvent{MarketName: "Fed cuts in March?", WalletAddress: "0xmaker"}, false},
+	} {
+		tc.event.EventType, tc.event.Price, tc.event.Size = domain.PolymarketEventTrade, "0.5", "1000"
+		if got := svc.matchesBasicFilter(tc.event, filter); got != tc.want {
+			t.Errorf("saving %s by %s = %v, want %v", tc.event.MarketName, tc.event.WalletAddress, got, tc.want)
+		}
+	}
+}
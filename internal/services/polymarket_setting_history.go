@@ -0,0 +1,45 @@
+package services
+
+import (
+	"log"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// defaultSettingHistoryLimit is how many previous values GetSettingHistory returns by default
+const defaultSettingHistoryLimit = 100
+
+// GetSettingHistory returns the values settings held before they were overwritten,
+// for one key or every key when key is empty, newest first
+func (s *PolymarketService) GetSettingHistory(key string, limit int) ([]domain.SettingHistoryEntry, error) {
+	if limit <= 0 {
+		limit = defaultSettingHistoryLimit
+	}
+	return s.store.GetSettingHistory(key, limit)
+}
+
+// RollbackSetting writes a previous value back to its key and reloads the settings,
+// so e.g. a mistaken threshold or rule change takes effect undone. The value it
+// replaces is kept in the history, so a rollback can be undone too.
+func (s *PolymarketService) RollbackSetting(id int64) (*domain.SettingHistoryEntry, error) {
+	s.mu.RLock()
+	before := s.config
+	s.mu.RUnlock()
+
+	entry, err := s.store.RestoreSetting(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reloadStoredState(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	after := s.config
+	s.mu.RUnlock()
+	s.recordConfigChanges(domain.ConfigChangeSourceUser, "rolled back", before, after)
+	log.Printf("[PolymarketService] Rolled back setting %s to its value from before %s",
+		entry.Key, entry.ReplacedAt.Format("2006-01-02 15:04:05"))
+	s.eventBus.Emit("polymarket:setting_rolled_back", entry)
+	return entry, nil
+}
@@ -0,0 +1,29 @@
+package services
+
+import "testing"
+
+func TestRollbackSettingRestoresConfig(t *testing.T) {
+	svc, rec := newTestService(t)
+	config := svc.GetConfig()
+	before := config.MinTradeSize
+	svc.UpdateConfig(config) // Stored for the first time: nothing to record
+	config.MinTradeSize = before + 5000
+	svc.UpdateConfig(config)
+
+	history, err := svc.GetSettingHistory("config", 0)
+	if err != nil || len(history) == 0 {
+		t.Fatalf("history = %+v, %v, want the config before the update", history, err)
+	}
+	if _, err := svc.RollbackSetting(history[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := svc.GetConfig().MinTradeSize; got != before {
+		t.Errorf("min trade size = %v after the rollback, want %v", got, before)
+	}
+	if len(rec.of("polymarket:setting_rolled_back")) != 1 {
+		t.Error("the rollback was not announced")
+	}
+	if _, err := svc.RollbackSetting(9999); err == nil {
+		t.Error("rolled back an unknown entry")
+	}
+}
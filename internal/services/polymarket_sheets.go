@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/sheets"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// sheetsSinkSettingKey is the settings key the sink configuration is persisted under
+	sheetsSinkSettingKey = "sheets_sink"
+
+	// Appends are batched to stay well below the Sheets API write quota
+	sheetsFlushInterval = 5 * time.Second
+	sheetsMaxBatch      = 500
+	sheetsMaxPending    = 10000 // Oldest rows are dropped beyond this while the sheet is unreachable
+)
+
+// sheetsSink is the state of the Google Sheets sink
+type sheetsSink struct {
+	config  domain.SheetsSinkConfig
+	client  *sheets.Client
+	pending [][]any
+	status  domain.SheetsSinkStatus
+}
+
+// SetSheetsSink configures the Google Sheets sink. When enabled, the credentials are
+// loaded and a header row is appended to the sheet to check access.
+func (s *PolymarketService) SetSheetsSink(config domain.SheetsSinkConfig) error {
+	config.CredentialsFile = strings.TrimSpace(config.CredentialsFile)
+	config.SpreadsheetID = strings.TrimSpace(config.SpreadsheetID)
+	config.SheetName = strings.TrimSpace(config.SheetName)
+	if config.SheetName == "" {
+		config.SheetName = "Sheet1"
+	}
+
+	var client *sheets.Client
+	if config.Enabled {
+		if config.CredentialsFile == "" || config.SpreadsheetID == "" {
+			return fmt.Errorf("credentials file and spreadsheet ID are required")
+		}
+		var err error
+		if client, err = sheets.NewClient(config.CredentialsFile); err != nil {
+			return err
+		}
+		header := make([]any, len(domain.SheetsSinkColumns))
+		for i, col := range domain.SheetsSinkColumns {
+			header[i] = col
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := client.AppendRows(ctx, config.SpreadsheetID, config.SheetName, [][]any{header}); err != nil {
+			return fmt.Errorf("cannot write to the sheet (is it shared with %s?): %w", client.ClientEmail(), err)
+		}
+	}
+
+	if err := s.store.SaveSetting(sheetsSinkSettingKey, config); err != nil {
+		return fmt.Errorf("failed to save sheets sink: %w", err)
+	}
+	s.setSheetsSink(config, client)
+	log.Printf("[PolymarketService] Google Sheets sink enabled=%v sheet=%s", config.Enabled, config.SheetName)
+	return nil
+}
+
+// GetSheetsSink returns the Google Sheets sink configuration
+func (s *PolymarketService) GetSheetsSink() domain.SheetsSinkConfig {
+	s.sheetsMu.Lock()
+	defer s.sheetsMu.Unlock()
+	return s.sheets.config
+}
+
+// GetSheetsSinkStatus returns the Google Sheets sink's progress
+func (s *PolymarketService) GetSheetsSinkStatus() domain.SheetsSinkStatus {
+	s.sheetsMu.Lock()
+	defer s.sheetsMu.Unlock()
+	status := s.sheets.status
+	status.Enabled = s.sheets.client != nil
+	status.Pending = len(s.sheets.pending)
+	if s.sheets.client != nil {
+		status.ClientEmail = s.sheets.client.ClientEmail()
+	}
+	return status
+}
+
+// sinkToSheets queues a flagged event for the next append
+func (s *PolymarketService) sinkToSheets(event domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || (!event.IsFreshWallet && len(event.RiskSignals) == 0) || event.Muted {
+		return
+	}
+
+	s.sheetsMu.Lock()
+	defer s.sheetsMu.Unlock()
+	if s.sheets.client == nil {
+		return
+	}
+	if s.sheets.config.AlertsOnly {
+		s.mu.RLock()
+		config := s.config
+		s.mu.RUnlock()
+		if len(event.RiskSignals) == 0 || !meetsAlertThresholds(event, config.MinTradeSize, config.AlertThreshold) {
+			return
+		}
+	}
+
+	if len(s.sheets.pending) >= sheetsMaxPending {
+		s.sheets.pending = s.sheets.pending[1:]
+		s.sheets.status.Dropped++
+	}
+	s.sheets.pending = append(s.sheets.pending, sheetsRow(event))
+}
+
+// sheetsWorker periodically appends queued rows to the sheet
+func (s *PolymarketService) sheetsWorker() {
+	ticker := time.NewTicker(sheetsFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			s.flushSheets()
+		}
+	}
+}
+
+// flushSheets appends a batch of queued rows; on failure they stay queued for the next flush
+func (s *PolymarketService) flushSheets() {
+	s.sheetsMu.Lock()
+	client, config := s.sheets.client, s.sheets.config
+	batch := s.sheets.pending
+	if len(batch) > sheetsMaxBatch {
+		batch = batch[:sheetsMaxBatch]
+	}
+	dropped := s.sheets.status.Dropped
+	s.sheetsMu.Unlock()
+	if client == nil || len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	err := client.AppendRows(ctx, config.SpreadsheetID, config.SheetName, batch)
+	cancel()
+
+	s.sheetsMu.Lock()
+	defer s.sheetsMu.Unlock()
+	if err != nil {
+		if s.sheets.status.LastError == "" {
+			log.Printf("[PolymarketService] Google Sheets append failed: %v", err)
+		}
+		s.sheets.status.LastError = err.Error()
+		return
+	}
+	if s.sheets.client != client {
+		return // Reconfigured while appending
+	}
+	// Rows dropped from the front while appending were part of the batch
+	sent := len(batch) - int(s.sheets.status.Dropped-dropped)
+	if sent < 0 {
+		sent = 0
+	}
+	s.sheets.pending = s.sheets.pending[sent:]
+	s.sheets.status.Appended += int64(len(batch))
+	s.sheets.status.LastAppendAt = time.Now()
+	s.sheets.status.LastError = ""
+}
+
+// sheetsRow formats an event in the order of domain.SheetsSinkColumns
+func sheetsRow(event domain.PolymarketEvent) []any {
+	price, _ := strconv.ParseFloat(event.Price, 64)
+	size, _ := strconv.ParseFloat(event.Size, 64)
+	market := event.MarketName
+	if market == "" {
+		market = event.EventTitle
+	}
+	betCount, freshness := "", ""
+	if event.WalletProfile != nil {
+		betCount = strconv.Itoa(event.WalletProfile.BetCount)
+		freshness = string(event.WalletProfile.FreshnessLevel)
+	}
+	return []any{
+		event.Timestamp.UTC().Format("2006-01-02 15:04:05"),
+		market,
+		event.Outcome,
+		string(event.Side),
+		price,
+		size,
+		price * size,
+		event.WalletAddress,
+		betCount,
+		freshness,
+		event.RiskScore,
+		strings.Join(event.RiskSignals, ", "),
+		strings.Join(event.Tags, ", "),
+		event.MarketLink,
+	}
+}
+
+// setSheetsSink swaps the sink configuration, discarding rows queued for the old sheet
+func (s *PolymarketService) setSheetsSink(config domain.SheetsSinkConfig, client *sheets.Client) {
+	s.sheetsMu.Lock()
+	s.sheets = sheetsSink{config: config, client: client}
+	s.sheetsMu.Unlock()
+}
+
+// loadSheetsSink restores the persisted sink configuration
+func (s *PolymarketService) loadSheetsSink() {
+	var config domain.SheetsSinkConfig
+	if err := s.store.LoadSetting(sheetsSinkSettingKey, &config); err != nil {
+		return
+	}
+	var client *sheets.Client
+	if config.Enabled {
+		var err error
+		if client, err = sheets.NewClient(config.CredentialsFile); err != nil {
+			log.Printf("[PolymarketService] Google Sheets sink disabled: %v", err)
+		}
+	}
+	s.setSheetsSink(config, client)
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Size outlier defaults when the config leaves them unset
+	defaultSizeOutlierMinZ      = 3.0
+	defaultSizeOutlierMinTrades = 5
+
+	// sizeOutlierMinRatio is how many times the wallet's typical size a trade must be
+	sizeOutlierMinRatio = 4.0
+
+	// sizeOutlierMinStdDev floors the spread so wallets that always bet the same amount
+	// aren't flagged for small changes
+	sizeOutlierMinStdDev = 0.25
+
+	// maxSizeTrackedWallets bounds the per-wallet size history
+	maxSizeTrackedWallets = 200000
+)
+
+// sizeStats is a running mean and variance of log notional (Welford's algorithm)
+type sizeStats struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+func (st *sizeStats) add(x float64) {
+	st.n++
+	delta := x - st.mean
+	st.mean += delta / float64(st.n)
+	st.m2 += delta * (x - st.mean)
+}
+
+func (st *sizeStats) stdDev() float64 {
+	if st.n < 2 {
+		return 0
+	}
+	return math.Sqrt(st.m2 / float64(st.n-1))
+}
+
+// sizeOutlier is a trade judged against the wallet's and the market's size history
+type sizeOutlier struct {
+	notional float64
+	typical  float64 // Wallet's geometric mean notional
+	walletZ  float64
+	marketZ  float64
+	trades   int
+}
+
+// GetWalletSizeProfile returns the trade sizes seen from a wallet since the app started
+func (s *PolymarketService) GetWalletSizeProfile(address string) domain.WalletSizeProfile {
+	s.sizeMu.Lock()
+	defer s.sizeMu.Unlock()
+
+	profile := domain.WalletSizeProfile{Address: address, MarketTrades: s.marketSizes.n}
+	if s.marketSizes.n > 0 {
+		profile.MarketTypical = math.Exp(s.marketSizes.mean)
+	}
+	if st, ok := s.walletSizes[strings.ToLower(address)]; ok {
+		profile.Trades = st.n
+		profile.TypicalNotional = math.Exp(st.mean)
+		profile.LogStdDev = st.stdDev()
+	}
+	return profile
+}
+
+// observeTradeSize judges a trade against the wallet's earlier trades, then adds it to
+// the history. Every trade counts, including those below the save filter, so a wallet's
+// usual small bets are known when a large one arrives. It returns nil unless the trade
+// is a size outlier.
+func (s *PolymarketService) observeTradeSize(event domain.PolymarketEvent) *sizeOutlier {
+	if event.EventType != domain.PolymarketEventTrade || event.WalletAddress == "" {
+		return nil
+	}
+	notional := parseNotionalValue(event.Price, event.Size)
+	if notional <= 0 {
+		return nil
+	}
+	x := math.Log(notional)
+	minZ, minTrades := s.sizeOutlierThresholds()
+
+	s.sizeMu.Lock()
+	defer s.sizeMu.Unlock()
+
+	key := strings.ToLower(event.WalletAddress)
+	st, ok := s.walletSizes[key]
+	if !ok {
+		if len(s.walletSizes) >= maxSizeTrackedWallets {
+			s.walletSizes = make(map[string]*sizeStats)
+		}
+		st = &sizeStats{}
+		s.walletSizes[key] = st
+	}
+
+	var outlier *sizeOutlier
+	if st.n >= minTrades {
+		z := (x - st.mean) / math.Max(st.stdDev(), sizeOutlierMinStdDev)
+		typical := math.Exp(st.mean)
+		if z >= minZ && notional >= sizeOutlierMinRatio*typical {
+			outlier = &sizeOutlier{notional: notional, typical: typical, walletZ: z, trades: st.n}
+			if sd := s.marketSizes.stdDev(); sd > 0 {
+				outlier.marketZ = (x - s.marketSizes.mean) / sd
+			}
+		}
+	}
+
+	st.add(x)
+	s.marketSizes.add(x)
+	return outlier
+}
+
+// applySizeOutlier adds a size outlier risk signal to the trade and requests an alert
+func (s *PolymarketService) applySizeOutlier(event *domain.PolymarketEvent, outlier *sizeOutlier) {
+	if outlier == nil {
+		return
+	}
+	minZ, _ := s.sizeOutlierThresholds()
+	score := math.Min(1, 0.6+0.1*(outlier.walletZ-minZ))
+	message := fmt.Sprintf("Size outlier for this wallet: $%.0f vs usual $%.0f (z=%.1f)", outlier.notional, outlier.typical, outlier.walletZ)
+
+	event.RiskSignals = append(event.RiskSignals, "📏 "+message)
+	if score > event.RiskScore {
+		event.RiskScore = score
+	}
+
+	if !event.Muted {
+		s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+			Detector: "size_outlier",
+			Signal:   "size_outlier",
+			Message:  message,
+			Score:    score,
+			Alert:    true,
+			Metadata: map[string]string{
+				"notional":        strconv.FormatFloat(outlier.notional, 'f', 2, 64),
+				"typicalNotional": strconv.FormatFloat(outlier.typical, 'f', 2, 64),
+				"walletZ":         strconv.FormatFloat(outlier.walletZ, 'f', 2, 64),
+				"marketZ":         strconv.FormatFloat(outlier.marketZ, 'f', 2, 64),
+				"priorTrades":     strconv.Itoa(outlier.trades),
+			},
+			TradeID:       event.TradeID,
+			WalletAddress: event.WalletAddress,
+			MarketName:    event.MarketName,
+			MarketLink:    event.MarketLink,
+			Timestamp:     event.Timestamp,
+		})
+	}
+}
+
+// sizeOutlierThresholds returns the configured thresholds, falling back to defaults
+func (s *PolymarketService) sizeOutlierThresholds() (float64, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	minZ, minTrades := s.config.SizeOutlierMinZ, s.config.SizeOutlierMinTrades
+	if minZ <= 0 {
+		minZ = defaultSizeOutlierMinZ
+	}
+	if minTrades <= 0 {
+		minTrades = defaultSizeOutlierMinTrades
+	}
+	return minZ, minTrades
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestSizeOutlierForWalletHistory(t *testing.T) {
+	svc, rec := newTestService(t)
+	trade := func(wallet string, notional float64) domain.PolymarketEvent {
+		return domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("%s-%v", wallet, notional),
+			WalletAddress: wallet, Price: "0.5", Size: fmt.Sprint(notional * 2),
+		}
+	}
+
+	// A wallet is judged only after enough trades
+	if outlier := svc.observeTradeSize(trade("0xNEW", 50)); outlier != nil {
+		t.Errorf("first trade flagged: %+v", outlier)
+	}
+	if outlier := svc.observeTradeSize(trade("0xnew", 8000)); outlier != nil {
+		t.Errorf("second trade flagged: %+v", outlier)
+	}
+
+	for _, notional := range []float64{40, 60, 50, 45, 55} {
+		if outlier := svc.observeTradeSize(trade("0xabc", notional)); outlier != nil {
+			t.Fatalf("usual trade of $%.0f flagged", notional)
+		}
+	}
+	if outlier := svc.observeTradeSize(trade("0xabc", 120)); outlier != nil {
+		t.Errorf("trade of 2.4x the usual size flagged: %+v", outlier)
+	}
+
+	event := trade("0xABC", 8000)
+	outlier := svc.observeTradeSize(event)
+	if outlier == nil || outlier.trades != 6 || outlier.walletZ < defaultSizeOutlierMinZ {
+		t.Fatalf("outlier = %+v, want $8,000 flagged against 6 prior trades", outlier)
+	}
+	svc.applySizeOutlier(&event, outlier)
+	if len(event.RiskSignals) != 1 || !strings.HasPrefix(event.RiskSignals[0], "📏 Size outlier for this wallet: $8000") || event.RiskScore < 0.6 {
+		t.Errorf("event signals = %v, score %.2f, want the size outlier signal", event.RiskSignals, event.RiskScore)
+	}
+	if signals := rec.of("polymarket:detector_signal"); len(signals) != 1 || signals[0].(domain.DetectorSignal).Metadata["priorTrades"] != "6" {
+		t.Errorf("detector signals = %+v, want one size outlier alert", signals)
+	}
+
+	profile := svc.GetWalletSizeProfile("0xAbC")
+	if profile.Trades != 7 || profile.MarketTrades != 9 || profile.TypicalNotional < 50 || profile.TypicalNotional > 200 {
+		t.Errorf("profile = %+v, want 7 of 9 trades with a geometric mean typical size", profile)
+	}
+}
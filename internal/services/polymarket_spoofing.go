@@ -0,0 +1,251 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Spoofing defaults when the config leaves them unset
+	defaultSpoofMinUSD          = 10000.0
+	defaultSpoofMinCycles       = 3
+	defaultSpoofLifetimeSeconds = 60
+
+	// spoofCycleWindow is how recent place-then-cancel cycles must be to count together,
+	// and how long a flagged book stays quiet before it can alert again
+	spoofCycleWindow = 10 * time.Minute
+
+	// spoofTouchTicks is how many ticks from the best bid or ask an order may rest
+	spoofTouchTicks = 2
+
+	// spoofCancelRatio is the share of an order that must disappear to count as cancelled
+	spoofCancelRatio = 0.8
+
+	// Bounds on the in-memory book state and signal history
+	maxSpoofBooks   = 5000
+	maxSpoofSignals = 100
+)
+
+// spoofOrder is a large size increase at a level near the touch, waiting to see
+// whether it trades or is pulled
+type spoofOrder struct {
+	shares float64
+	at     time.Time
+	filled bool // A trade printed at the level while the order rested
+}
+
+// spoofCycle is an order pulled without trading
+type spoofCycle struct {
+	price    float64
+	notional float64
+	lifetime time.Duration
+	at       time.Time
+}
+
+// spoofBook is the level sizes and pending large orders of one outcome's book
+type spoofBook struct {
+	levels    map[string]float64 // Size per side and price
+	orders    map[string]*spoofOrder
+	cycles    map[domain.OrderSide][]spoofCycle
+	alertedAt time.Time
+}
+
+// GetSpoofingSignals returns the books recently flagged for spoofing, newest first
+func (s *PolymarketService) GetSpoofingSignals() []domain.SpoofingSignal {
+	s.spoofMu.Lock()
+	defer s.spoofMu.Unlock()
+
+	signals := make([]domain.SpoofingSignal, len(s.spoofSignals))
+	for i, signal := range s.spoofSignals {
+		signal.Levels = append([]float64{}, signal.Levels...)
+		signals[len(signals)-1-i] = signal
+	}
+	return signals
+}
+
+// observeBookDelta follows an outcome's book through its level updates and trades,
+// and returns a signal when large orders near the touch keep being pulled untraded.
+// Like the quotes it sees updates that sampling and the save filter drop, since a
+// pulled order leaves a level with little or no size.
+func (s *PolymarketService) observeBookDelta(event domain.PolymarketEvent) *domain.SpoofingSignal {
+	if event.AssetID == "" {
+		return nil
+	}
+	at := event.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	price, err := strconv.ParseFloat(event.Price, 64)
+	if err != nil && event.EventType != domain.PolymarketEventBook {
+		return nil
+	}
+	minUSD, minCycles, lifetime := s.spoofThresholds()
+	touch := spoofTouchTicks * s.tickSize(event.AssetID, domain.DefaultTickSize)
+
+	s.spoofMu.Lock()
+	book := s.spoofBooks[event.AssetID]
+	switch event.EventType {
+	case domain.PolymarketEventTrade, domain.PolymarketEventLastTradePrice:
+		if book != nil {
+			book.fill(price)
+		}
+		s.spoofMu.Unlock()
+		return nil
+	case domain.PolymarketEventBook:
+		// A snapshot re-baselines the levels; later updates are measured from it
+		if book == nil {
+			book = s.newSpoofBookLocked(event.AssetID)
+		}
+		book.levels = bookLevels(event.RawData)
+		s.spoofMu.Unlock()
+		return nil
+	case domain.PolymarketEventPriceChange:
+	default:
+		s.spoofMu.Unlock()
+		return nil
+	}
+
+	if book == nil {
+		book = s.newSpoofBookLocked(event.AssetID)
+	}
+	size, _ := strconv.ParseFloat(event.Size, 64)
+	key := string(event.Side) + "|" + strconv.FormatFloat(price, 'f', 4, 64)
+	prev, known := book.levels[key]
+	if size > 0 {
+		book.levels[key] = size
+	} else {
+		delete(book.levels, key)
+	}
+	if !known {
+		s.spoofMu.Unlock()
+		return nil // No baseline for the level yet
+	}
+
+	delta := size - prev
+	switch order := book.orders[key]; {
+	case delta*price >= minUSD && nearTouch(event, price, touch):
+		book.orders[key] = &spoofOrder{shares: delta, at: at}
+	case delta < 0 && order != nil:
+		delete(book.orders, key)
+		if !order.filled && at.Sub(order.at) <= lifetime && -delta >= spoofCancelRatio*order.shares {
+			book.cycles[event.Side] = append(book.cycles[event.Side], spoofCycle{
+				price:    price,
+				notional: order.shares * price,
+				lifetime: at.Sub(order.at),
+				at:       at,
+			})
+		}
+	}
+
+	signal := book.check(event, at, minCycles)
+	if signal != nil {
+		s.spoofSignals = append(s.spoofSignals, *signal)
+		if len(s.spoofSignals) > maxSpoofSignals {
+			s.spoofSignals = s.spoofSignals[len(s.spoofSignals)-maxSpoofSignals:]
+		}
+	}
+	s.spoofMu.Unlock()
+
+	if signal != nil {
+		s.emitSpoofingSignal(*signal, event)
+	}
+	return signal
+}
+
+// newSpoofBookLocked starts following an asset's book; the caller holds spoofMu
+func (s *PolymarketService) newSpoofBookLocked(assetID string) *spoofBook {
+	if len(s.spoofBooks) >= maxSpoofBooks {
+		s.spoofBooks = make(map[string]*spoofBook)
+	}
+	book := &spoofBook{
+		levels: make(map[string]float64),
+		orders: make(map[string]*spoofOrder),
+		cycles: make(map[domain.OrderSide][]spoofCycle),
+	}
+	s.spoofBooks[assetID] = book
+	return book
+}
+
+// bookLevels returns the level sizes of a book event's snapshot, keyed like the levels
+// of a spoofBook
+func bookLevels(rawData string) map[string]float64 {
+	levels := make(map[string]float64)
+	msg, err := polymarket.DecodeBook(rawData)
+	if err != nil {
+		return levels
+	}
+	for side, sideLevels := range map[domain.OrderSide][]polymarket.BookLevel{domain.OrderSideBuy: msg.Bids, domain.OrderSideSell: msg.Asks} {
+		for _, level := range sideLevels {
+			if price, size := level.Values(); size > 0 {
+				levels[string(side)+"|"+strconv.FormatFloat(price, 'f', 4, 64)] = size
+			}
+		}
+	}
+	return levels
+}
+
+// fill marks the orders resting at a traded price as filled
+func (b *spoofBook) fill(price float64) {
+	level := "|" + strconv.FormatFloat(price, 'f', 4, 64)
+	for _, side := range []domain.OrderSide{domain.OrderSideBuy, domain.OrderSideSell} {
+		if order := b.orders[string(side)+level]; order != nil {
+			order.filled = true
+		}
+	}
+}
+
+// check returns a signal when the side of the updated level has enough recent cycles
+func (b *spoofBook) check(event domain.PolymarketEvent, at time.Time, minCycles int) *domain.SpoofingSignal {
+	cycles := b.cycles[event.Side]
+	i := 0
+	for i < len(cycles) && at.Sub(cycles[i].at) > spoofCycleWindow {
+		i++
+	}
+	cycles = cycles[i:]
+	b.cycles[event.Side] = cycles
+	if len(cycles) < minCycles || at.Sub(b.alertedAt) < spoofCycleWindow {
+		return nil
+	}
+
+	signal := &domain.SpoofingSignal{
+		AssetID:    event.AssetID,
+		MarketSlug: event.MarketSlug,
+		MarketName: event.MarketName,
+		Side:       event.Side,
+		Cycles:     len(cycles),
+		FirstAt:    cycles[0].at,
+		DetectedAt: at,
+	}
+	levels := make(map[float64]bool)
+	var lifetime time.Duration
+	for _, c := range cycles {
+		if !levels[c.price] {
+			levels[c.price] = true
+			signal.Levels = append(signal.Levels, c.price)
+		}
+		signal.Notional = math.Max(signal.Notional, c.notional)
+		lifetime += c.lifetime
+	}
+	sort.Float64s(signal.Levels)
+	signal.AvgLifetime = lifetime.Seconds() / float64(len(cycles))
+	signal.Layering = len(signal.Levels) > 1
+
+	b.alertedAt = at
+	b.cycles[event.Side] = nil
+	return signal
+}
+
+// nearTouch reports whether a level is within reach of the best price on its side
+func nearTouch(event domain.PolymarketEvent, price, distance float64) bool {
+	if event.Side == domain.OrderSideSell {
+		ask, err := strconv.ParseFloat(event.BestAsk, 64)
+		return err == nil && ask > 0 && price <= ask+distance
+	}
+	bid, err := strconv.ParseFloat(event.BestBid, 64)
+	return err == nil && bid > 0 && price >= bid-distance
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// applySpoofing adds a spoofing signal to the book update that completed it
+func (s *PolymarketService) applySpoofing(event *domain.PolymarketEvent, signal *domain.SpoofingSignal) {
+	if signal == nil {
+		return
+	}
+	event.RiskSignals = append(event.RiskSignals, "🎭 "+spoofingMessage(*signal))
+	if score := spoofingScore(*signal); score > event.RiskScore {
+		event.RiskScore = score
+	}
+}
+
+// emitSpoofingSignal sends a flagged book as a market-manipulation signal, and as an
+// alert when spoofing alerts are enabled
+func (s *PolymarketService) emitSpoofingSignal(signal domain.SpoofingSignal, event domain.PolymarketEvent) {
+	s.eventBus.Emit("polymarket:spoofing", signal)
+	if s.isMarketMuted(event) {
+		return
+	}
+	s.mu.RLock()
+	alert := s.config.SpoofAlerts
+	s.mu.RUnlock()
+
+	kind := "spoofing"
+	if signal.Layering {
+		kind = "layering"
+	}
+	levels := make([]string, len(signal.Levels))
+	for i, l := range signal.Levels {
+		levels[i] = strconv.FormatFloat(l, 'f', -1, 64)
+	}
+	s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+		Detector: "spoofing",
+		Signal:   kind,
+		Message:  spoofingMessage(signal),
+		Score:    spoofingScore(signal),
+		Alert:    alert,
+		Metadata: map[string]string{
+			"assetId":     signal.AssetID,
+			"side":        string(signal.Side),
+			"cycles":      strconv.Itoa(signal.Cycles),
+			"levels":      strings.Join(levels, ","),
+			"notional":    strconv.FormatFloat(signal.Notional, 'f', 2, 64),
+			"avgLifetime": strconv.FormatFloat(signal.AvgLifetime, 'f', 1, 64),
+		},
+		MarketName: event.MarketName,
+		MarketLink: event.MarketLink,
+		Timestamp:  signal.DetectedAt,
+	})
+}
+
+// spoofingMessage describes a flagged book
+func spoofingMessage(signal domain.SpoofingSignal) string {
+	side := "bid"
+	if signal.Side == domain.OrderSideSell {
+		side = "ask"
+	}
+	message := fmt.Sprintf("%d orders up to %s placed near the %s and pulled untraded after %.0fs on average",
+		signal.Cycles, formatCompactUSD(signal.Notional), side, signal.AvgLifetime)
+	if signal.Layering {
+		return fmt.Sprintf("Layering: %s, across %d price levels", message, len(signal.Levels))
+	}
+	return "Spoofing: " + message
+}
+
+// spoofingScore rises with the number of cycles beyond the threshold
+func spoofingScore(signal domain.SpoofingSignal) float64 {
+	score := 0.6 + 0.05*float64(signal.Cycles)
+	if signal.Layering {
+		score += 0.1
+	}
+	return math.Min(1, score)
+}
+
+// spoofThresholds returns the configured order size, cycles to flag and order lifetime
+func (s *PolymarketService) spoofThresholds() (float64, int, time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	minUSD, cycles, seconds := s.config.SpoofMinUSD, s.config.SpoofMinCycles, s.config.SpoofLifetimeSeconds
+	if minUSD <= 0 {
+		minUSD = defaultSpoofMinUSD
+	}
+	if cycles <= 0 {
+		cycles = defaultSpoofMinCycles
+	}
+	if seconds <= 0 {
+		seconds = defaultSpoofLifetimeSeconds
+	}
+	return minUSD, cycles, time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestSpoofingFromMarketChannel(t *testing.T) {
+	svc, rec := newTestService(t)
+
+	at := int64(1760000000000)
+	ingestFrame(t, svc, fmt.Sprintf(`{"event_type":"book","asset_id":"111","market":"0xabc","timestamp":"%d",
+		"bids":[{"price":"0.50","size":"100"}],"asks":[{"price":"0.52","size":"100"}]}`, at))
+
+	// A 30000 share bid at the touch is placed and pulled within seconds, three times
+	level := `{"event_type":"price_change","market":"0xabc","timestamp":"%d",
+		"price_changes":[{"asset_id":"111","price":"0.50","size":"%s","side":"BUY","best_bid":"0.50","best_ask":"0.52"}]}`
+	for i := 0; i < defaultSpoofMinCycles; i++ {
+		at += 5000
+		ingestFrame(t, svc, fmt.Sprintf(level, at, "30100"))
+		at += 5000
+		ingestFrame(t, svc, fmt.Sprintf(level, at, "100"))
+	}
+
+	signals := svc.GetSpoofingSignals()
+	if len(signals) != 1 {
+		t.Fatalf("got %d spoofing signals, want 1", len(signals))
+	}
+	if got := signals[0]; got.AssetID != "111" || got.Side != domain.OrderSideBuy || got.Cycles != defaultSpoofMinCycles {
+		t.Errorf("got signal %+v", got)
+	}
+	if len(rec.of("polymarket:detector_signal")) == 0 {
+		t.Errorf("spoofing signal was not emitted")
+	}
+}
+
+func TestSpoofingIgnoresFilledOrders(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	at := int64(1760000000000)
+	ingestFrame(t, svc, fmt.Sprintf(`{"event_type":"book","asset_id":"111","market":"0xabc","timestamp":"%d",
+		"bids":[{"price":"0.50","size":"100"}],"asks":[]}`, at))
+	level := `{"event_type":"price_change","market":"0xabc","timestamp":"%d",
+		"price_changes":[{"asset_id":"111","price":"0.50","size":"%s","side":"BUY","best_bid":"0.50","best_ask":"0.52"}]}`
+	for i := 0; i < defaultSpoofMinCycles; i++ {
+		at += 5000
+		ingestFrame(t, svc, fmt.Sprintf(level, at, "30100"))
+		ingestFrame(t, svc, fmt.Sprintf(`{"event_type":"last_trade_price","asset_id":"111","market":"0xabc",
+			"price":"0.50","size":"30000","side":"SELL","timestamp":"%d"}`, at+1000))
+		at += 5000
+		ingestFrame(t, svc, fmt.Sprintf(level, at, "100"))
+	}
+
+	if signals := svc.GetSpoofingSignals(); len(signals) != 0 {
+		t.Fatalf("got %d spoofing signals for orders that traded, want 0", len(signals))
+	}
+}
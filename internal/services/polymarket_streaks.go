@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Hot hand defaults when the config leaves them unset
+	defaultHotHandMinStreak = 6
+	defaultHotHandMaxPrice  = 0.35
+
+	// Market resolution polling
+	marketResolutionInterval = 10 * time.Minute
+	marketRecheckInterval    = 1 * time.Hour // Minimum time between checks of an unresolved market
+	marketResolutionBatch    = 20
+
+	// maxCachedStreaks bounds the in-memory streak cache
+	maxCachedStreaks = 10000
+)
+
+// GetWalletStreak returns a wallet's run of correct bets in resolved markets
+func (s *PolymarketService) GetWalletStreak(address string) (*domain.WalletStreak, error) {
+	streak, err := s.walletStreak(address)
+	if err != nil {
+		return nil, err
+	}
+	return &streak, nil
+}
+
+// ResolveMarkets checks traded markets for resolutions and updates the streaks of
+// wallets that bet on them. It returns the number of newly resolved markets.
+func (s *PolymarketService) ResolveMarkets() (int, error) {
+	slugs, err := s.store.GetMarketsToResolve(marketResolutionBatch, time.Now().Add(-marketRecheckInterval))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load markets to resolve: %w", err)
+	}
+
+	resolved := 0
+	for _, slug := range slugs {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		info, err := s.markets.GetMarket(ctx, slug)
+		cancel()
+		if err != nil {
+			log.Printf("[PolymarketService] Failed to look up market %s: %v", slug, err)
+			s.errReporter.Report("market resolution", err)
+			continue
+		}
+
+		now := time.Now()
+		outcome := domain.MarketOutcome{Slug: slug, WinningOutcome: -1, CheckedAt: now}
+		if info != nil && info.Closed && info.WinningOutcome >= 0 {
+			outcome.Resolved = true
+			outcome.WinningOutcome = info.WinningOutcome
+			outcome.ResolvedAt = now
+			if !info.EndDate.IsZero() && info.EndDate.Before(now) {
+				outcome.ResolvedAt = info.EndDate
+			}
+		}
+		if err := s.store.SaveMarketResolution(outcome); err != nil {
+			log.Printf("[PolymarketService] Failed to save resolution of %s: %v", slug, err)
+			continue
+		}
+		if outcome.Resolved {
+			resolved++
+			s.updateMarketStreaks(slug)
+			s.watchWithdrawals(slug, outcome.ResolvedAt)
+		}
+	}
+
+	if resolved > 0 {
+		log.Printf("[PolymarketService] Resolved %d of %d checked markets", resolved, len(slugs))
+	}
+	return resolved, nil
+}
+
+// marketResolutionWorker periodically checks traded markets for resolutions
+func (s *PolymarketService) marketResolutionWorker() {
+	ticker := time.NewTicker(marketResolutionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			if _, err := s.ResolveMarkets(); err != nil {
+				log.Printf("[PolymarketService] %v", err)
+			}
+		}
+	}
+}
+
+// updateMarketStreaks recomputes the streaks of a resolved market's wallets and
+// announces wallets that just got a hot hand
+func (s *PolymarketService) updateMarketStreaks(slug string) {
+	wallets, err := s.store.GetMarketWallets(slug)
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to load wallets of %s: %v", slug, err)
+		return
+	}
+
+	for _, address := range wallets {
+		s.streaksMu.Lock()
+		previous, known := s.streaks[address]
+		s.streaksMu.Unlock()
+
+		streak, err := s.computeWalletStreak(address)
+		if err != nil {
+			log.Printf("[PolymarketService] Failed to compute streak of %s: %v", shortenAddress(address), err)
+			continue
+		}
+		if streak.HotHand && (!known || !previous.HotHand) {
+			log.Printf("[PolymarketService] HOT HAND: %s has %d longshots in a row correct",
+				shortenAddress(address), streak.LongshotStreak)
+			s.eventBus.Emit("polymarket:hot_hand_detected", streak)
+		}
+	}
+}
+
+// walletStreak returns a cached streak, computing it on first use
+func (s *PolymarketService) walletStreak(address string) (domain.WalletStreak, error) {
+	s.streaksMu.Lock()
+	streak, ok := s.streaks[address]
+	s.streaksMu.Unlock()
+	if ok {
+		return streak, nil
+	}
+	return s.computeWalletStreak(address)
+}
+
+// computeWalletStreak rebuilds a wallet's streak from its resolved bets and caches it
+func (s *PolymarketService) computeWalletStreak(address string) (domain.WalletStreak, error) {
+	bets, err := s.store.GetResolvedBets(address)
+	if err != nil {
+		return domain.WalletStreak{}, err
+	}
+	minStreak, maxPrice := s.hotHandThresholds()
+	streak := buildWalletStreak(address, bets, minStreak, maxPrice)
+
+	s.streaksMu.Lock()
+	if len(s.streaks) >= maxCachedStreaks {
+		s.streaks = make(map[string]domain.WalletStreak)
+	}
+	s.streaks[address] = streak
+	s.streaksMu.Unlock()
+	return streak, nil
+}
+
+// resetStreaks drops cached streaks, e.g. after the hot hand thresholds change
+func (s *PolymarketService) resetStreaks() {
+	s.streaksMu.Lock()
+	s.streaks = make(map[string]domain.WalletStreak)
+	s.streaksMu.Unlock()
+}
+
+// applyHotHand adds a hot hand risk signal to trades by wallets on a longshot streak
+// and requests an alert for them
+func (s *PolymarketService) applyHotHand(event *domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || event.WalletAddress == "" {
+		return
+	}
+	streak, err := s.walletStreak(event.WalletAddress)
+	if err != nil || !streak.HotHand {
+		return
+	}
+
+	minStreak, _ := s.hotHandThresholds()
+	score := math.Min(1, 0.8+0.05*float64(streak.LongshotStreak-minStreak))
+	message := fmt.Sprintf("Hot hand: %d longshots in a row correct", streak.LongshotStreak)
+
+	event.RiskSignals = append(event.RiskSignals, "🔥 "+message)
+	if score > event.RiskScore {
+		event.RiskScore = score
+	}
+
+	if !event.Muted {
+		s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+			Detector: "hot_hand",
+			Signal:   "hot_hand",
+			Message:  message,
+			Score:    score,
+			Alert:    true,
+			Metadata: map[string]string{
+				"longshotStreak": strconv.Itoa(streak.LongshotStreak),
+				"currentStreak":  strconv.Itoa(streak.CurrentStreak),
+				"resolvedBets":   strconv.Itoa(streak.ResolvedBets),
+			},
+			TradeID:       event.TradeID,
+			WalletAddress: event.WalletAddress,
+			MarketName:    event.MarketName,
+			MarketLink:    event.MarketLink,
+			Timestamp:     event.Timestamp,
+		})
+	}
+}
+
+// hotHandThresholds returns the configured streak length and longshot price
+func (s *PolymarketService) hotHandThresholds() (int, float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	minStreak, maxPrice := s.config.HotHandMinStreak, s.config.HotHandMaxPrice
+	if minStreak <= 0 {
+		minStreak = defaultHotHandMinStreak
+	}
+	if maxPrice <= 0 {
+		maxPrice = defaultHotHandMaxPrice
+	}
+	return minStreak, maxPrice
+}
+
+// buildWalletStreak summarizes resolved bets ordered oldest resolution first.
+// Only bets at or below maxPrice count toward (or break) the longshot streak.
+func buildWalletStreak(address string, bets []domain.ResolvedBet, minStreak int, maxPrice float64) domain.WalletStreak {
+	streak := domain.WalletStreak{Address: address, ResolvedBets: len(bets)}
+	for _, bet := range bets {
+		if bet.Won {
+			streak.Wins++
+			streak.CurrentStreak++
+		} else {
+			streak.CurrentStreak = 0
+		}
+		if bet.Price <= maxPrice {
+			if bet.Won {
+				streak.LongshotStreak++
+			} else {
+				streak.LongshotStreak = 0
+			}
+		}
+		if streak.CurrentStreak > streak.BestStreak {
+			streak.BestStreak = streak.CurrentStreak
+		}
+		streak.LastResolvedAt = bet.ResolvedAt
+	}
+	streak.HotHand = streak.LongshotStreak >= minStreak
+	return streak
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestBuildWalletStreak(t *testing.T) {
+	bet := func(price float64, won bool) domain.ResolvedBet { return domain.ResolvedBet{Price: price, Won: won} }
+	bets := []domain.ResolvedBet{
+		bet(0.2, true), bet(0.9, true), bet(0.8, true), bet(0.3, false), // Lost longshot resets both streaks
+		bet(0.1, true), bet(0.7, false), bet(0.2, true), bet(0.3, true), // Favorites don't break the longshot streak
+	}
+
+	streak := buildWalletStreak("0xa", bets, 3, 0.35)
+	want := domain.WalletStreak{Address: "0xa", ResolvedBets: 8, Wins: 6, CurrentStreak: 2, LongshotStreak: 3, BestStreak: 3, HotHand: true}
+	if streak != want {
+		t.Errorf("streak = %+v, want %+v", streak, want)
+	}
+	if streak := buildWalletStreak("0xa", bets, 4, 0.35); streak.HotHand {
+		t.Error("hot hand below the minimum streak")
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Default and maximum duration of a CPU profile
+	defaultCPUProfileSeconds = 30
+	maxCPUProfileSeconds     = 120
+)
+
+// GetSystemStatus returns process memory, goroutine, cache and write queue statistics
+func (s *PolymarketService) GetSystemStatus() domain.SystemStatus {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	s.mutesMu.Lock()
+	mutes := len(s.mutes)
+	s.mutesMu.Unlock()
+	s.streaksMu.Lock()
+	streaks := len(s.streaks)
+	s.streaksMu.Unlock()
+	s.samplingMu.Lock()
+	sampled := len(s.samplingState)
+	s.samplingMu.Unlock()
+	s.mu.RLock()
+	priority := len(s.priorityQueue)
+	s.mu.RUnlock()
+	writes := s.writes.status()
+
+	status := domain.SystemStatus{
+		RSSBytes:        processRSS(),
+		HeapAllocBytes:  mem.HeapAlloc,
+		HeapObjects:     mem.HeapObjects,
+		SysBytes:        mem.Sys,
+		NumGC:           mem.NumGC,
+		Goroutines:      runtime.NumGoroutine(),
+		WriteQueueDepth: int64(writes.Depth + writes.InFlight),
+		CacheSizes: map[string]int{
+			"walletProfiles":  s.walletAnalyzer.CacheSize(),
+			"markets":         s.markets.CacheSize(),
+			"walletStreaks":   streaks,
+			"sampledAssets":   sampled,
+			"mutedMarkets":    mutes,
+			"priorityWallets": priority,
+		},
+		Errors:      s.errReporter.Counts(),
+		CollectedAt: time.Now(),
+	}
+	if s.httpCache != nil {
+		status.HTTPCacheHits, status.HTTPCacheMisses = s.httpCache.Stats()
+	}
+	if s.images != nil {
+		images := s.images.Stats()
+		status.ImageCache = &images
+	}
+	return status
+}
+
+// WriteProfile writes a pprof profile next to the database. name is "cpu" or any
+// runtime profile such as "heap", "goroutine", "allocs", "block" or "mutex". CPU
+// profiles are sampled for the given seconds (default 30, at most 120).
+func (s *PolymarketService) WriteProfile(name string, seconds int) (*domain.ProfileExport, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	var profile *pprof.Profile
+	if name != "cpu" {
+		if profile = pprof.Lookup(name); profile == nil {
+			return nil, fmt.Errorf("unknown profile: %s", name)
+		}
+	}
+
+	dir := filepath.Join(filepath.Dir(s.dbPath), "profiles")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.pprof", name, time.Now().Format("20060102-150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create profile file: %w", err)
+	}
+	defer f.Close()
+
+	if profile != nil {
+		err = profile.WriteTo(f, 0)
+	} else {
+		err = writeCPUProfile(f, seconds)
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write %s profile: %w", name, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat profile: %w", err)
+	}
+	log.Printf("[PolymarketService] Wrote %s profile to %s", name, path)
+	return &domain.ProfileExport{Profile: name, Path: path, SizeBytes: info.Size()}, nil
+}
+
+// writeCPUProfile samples the CPU for the given seconds
+func writeCPUProfile(f *os.File, seconds int) error {
+	if seconds <= 0 {
+		seconds = defaultCPUProfileSeconds
+	}
+	if seconds > maxCPUProfileSeconds {
+		seconds = maxCPUProfileSeconds
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		return err
+	}
+	time.Sleep(time.Duration(seconds) * time.Second)
+	pprof.StopCPUProfile()
+	return nil
+}
+
+// processRSS returns the resident set size on Linux, 0 elsewhere
+func processRSS() uint64 {
+	data, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return 0
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return pages * uint64(os.Getpagesize())
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetSystemStatus(t *testing.T) {
+	svc, _ := newTestService(t)
+	if _, err := svc.MuteMarket("fed-cut", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	status := svc.GetSystemStatus()
+	if status.Goroutines == 0 || status.HeapAllocBytes == 0 || status.CollectedAt.IsZero() {
+		t.Errorf("status = %+v, want runtime stats", status)
+	}
+	for _, cache := range []string{"walletProfiles", "markets", "walletStreaks", "sampledAssets", "mutedMarkets", "priorityWallets"} {
+		if _, ok := status.CacheSizes[cache]; !ok {
+			t.Errorf("cache sizes = %v, missing %s", status.CacheSizes, cache)
+		}
+	}
+	if status.CacheSizes["mutedMarkets"] != 1 {
+		t.Errorf("muted markets = %d, want 1", status.CacheSizes["mutedMarkets"])
+	}
+}
+
+func TestWriteProfileNextToDatabase(t *testing.T) {
+	svc, dbPath := newSQLiteTestService(t, "")
+	if _, err := svc.WriteProfile("flame", 0); err == nil {
+		t.Error("unknown profile was written")
+	}
+
+	export, err := svc.WriteProfile(" Heap ", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export.Profile != "heap" || filepath.Dir(export.Path) != filepath.Join(filepath.Dir(dbPath), "profiles") {
+		t.Errorf("export = %+v, want a heap profile in the profiles directory", export)
+	}
+	if info, err := os.Stat(export.Path); err != nil || info.Size() != export.SizeBytes || export.SizeBytes == 0 {
+		t.Errorf("profile file = %v, %v, want %d bytes", info, err, export.SizeBytes)
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// eventTagRulesSettingKey is the settings key tag rules are persisted under
+const eventTagRulesSettingKey = "event_tag_rules"
+
+// SetTagRules replaces the rules that automatically tag incoming events
+func (s *PolymarketService) SetTagRules(rules []domain.EventTagRule) error {
+	normalized, err := normalizeTagRules(rules)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.SaveSetting(eventTagRulesSettingKey, normalized); err != nil {
+		return fmt.Errorf("failed to save tag rules: %w", err)
+	}
+
+	s.mu.Lock()
+	s.tagRules = normalized
+	s.mu.Unlock()
+
+	log.Printf("[PolymarketService] Saved %d event tag rules", len(normalized))
+	return nil
+}
+
+// normalizeTagRules normalizes the tags of rules and checks each has a condition
+func normalizeTagRules(rules []domain.EventTagRule) ([]domain.EventTagRule, error) {
+	normalized := make([]domain.EventTagRule, 0, len(rules))
+	for i, rule := range rules {
+		rule.Tag = domain.NormalizeTag(rule.Tag)
+		if rule.Entity != "" {
+			rule.Entity = domain.CanonicalEntity(rule.Entity)
+		}
+		if rule.Tag == "" {
+			return nil, fmt.Errorf("tag rule %d: tag is required", i+1)
+		}
+		if !rule.HasConditions() {
+			return nil, fmt.Errorf("tag rule %q: at least one condition is required", rule.Tag)
+		}
+		normalized = append(normalized, rule)
+	}
+	return normalized, nil
+}
+
+// GetTagRules returns the rules that automatically tag incoming events
+func (s *PolymarketService) GetTagRules() []domain.EventTagRule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]domain.EventTagRule{}, s.tagRules...)
+}
+
+// TagEvent manually attaches a label to a stored event
+func (s *PolymarketService) TagEvent(eventID int64, tag string) error {
+	tag = domain.NormalizeTag(tag)
+	if err := s.store.TagEvent(eventID, tag); err != nil {
+		return err
+	}
+	s.eventBus.Emit("polymarket:event_tagged", map[string]any{"eventId": eventID, "tag": tag})
+	return nil
+}
+
+// UntagEvent removes a label from a stored event
+func (s *PolymarketService) UntagEvent(eventID int64, tag string) error {
+	tag = domain.NormalizeTag(tag)
+	if err := s.store.UntagEvent(eventID, tag); err != nil {
+		return err
+	}
+	s.eventBus.Emit("polymarket:event_untagged", map[string]any{"eventId": eventID, "tag": tag})
+	return nil
+}
+
+// GetEventTags returns every tag in use with its event count
+func (s *PolymarketService) GetEventTags() ([]domain.EventTagCount, error) {
+	return s.store.GetEventTags()
+}
+
+// applyTagRules adds the tags of every matching rule to an event before it is stored
+func (s *PolymarketService) applyTagRules(event *domain.PolymarketEvent) {
+	s.mu.RLock()
+	rules := s.tagRules
+	s.mu.RUnlock()
+
+	for _, rule := range rules {
+		if tagRuleMatches(rule, *event) && !containsString(event.Tags, rule.Tag) {
+			event.Tags = append(event.Tags, rule.Tag)
+		}
+	}
+}
+
+// tagRuleMatches reports whether an event satisfies all conditions of a rule
+func tagRuleMatches(rule domain.EventTagRule, event domain.PolymarketEvent) bool {
+	if rule.MarketName != "" {
+		name := strings.ToLower(rule.MarketName)
+		if !strings.Contains(strings.ToLower(event.MarketName), name) && !strings.Contains(strings.ToLower(event.EventTitle), name) {
+			return false
+		}
+	}
+	if rule.MarketSlug != "" && !strings.EqualFold(rule.MarketSlug, event.MarketSlug) && !strings.EqualFold(rule.MarketSlug, event.EventSlug) {
+		return false
+	}
+	if rule.WalletAddress != "" && !strings.EqualFold(rule.WalletAddress, event.WalletAddress) {
+		return false
+	}
+	if rule.MinNotional > 0 && parseNotionalValue(event.Price, event.Size) < rule.MinNotional {
+		return false
+	}
+	if rule.MinRiskScore > 0 && event.RiskScore < rule.MinRiskScore {
+		return false
+	}
+	if rule.Entity != "" && !containsFold(event.Entities, rule.Entity) {
+		return false
+	}
+	return true
+}
+
+// loadTagRules restores persisted tag rules
+func (s *PolymarketService) loadTagRules() {
+	var rules []domain.EventTagRule
+	if err := s.store.LoadSetting(eventTagRulesSettingKey, &rules); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.tagRules = rules
+	s.mu.Unlock()
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestTagRulesLabelIncomingEvents(t *testing.T) {
+	svc, _ := newTestService(t)
+	for _, rules := range [][]domain.EventTagRule{
+		{{Tag: " ", MarketName: "super bowl"}},
+		{{Tag: "noise"}},
+	} {
+		if err := svc.SetTagRules(rules); err == nil {
+			t.Errorf("SetTagRules(%+v) succeeded, want an error", rules)
+		}
+	}
+	if err := svc.SetTagRules([]domain.EventTagRule{{Tag: "Super Bowl Insider", MarketName: "super bowl", MinNotional: 1000}}); err != nil {
+		t.Fatal(err)
+	}
+	if rules := svc.GetTagRules(); len(rules) != 1 || rules[0].Tag != "super-bowl-insider" {
+		t.Fatalf("rules = %+v, want the tag normalized", rules)
+	}
+
+	trade := func(id, market, size string) domain.PolymarketEvent {
+		return domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: id, WalletAddress: "0x" + id, AssetID: "111",
+			MarketName: market, Side: domain.OrderSideBuy, Price: "0.5", Size: size,
+		}
+	}
+	svc.Ingest(trade("a", "Super Bowl MVP", "10000")) // $5,000: tagged
+	svc.Ingest(trade("b", "Super Bowl MVP", "1000"))  // $500: too small for the rule
+	svc.Ingest(trade("c", "Fed rate cut", "10000"))   // Another market
+	waitFor(t, "the trades to be stored", func() bool {
+		count, _ := svc.GetEventCount(domain.PolymarketEventFilter{})
+		return count == 3
+	})
+
+	tagged, err := svc.GetEvents(domain.PolymarketEventFilter{Tag: "Super Bowl Insider", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagged) != 1 || tagged[0].TradeID != "a" {
+		t.Fatalf("tagged events = %+v, want only the large Super Bowl trade", tagged)
+	}
+
+	// Manual labels sit next to the rule's
+	others, _ := svc.GetEvents(domain.PolymarketEventFilter{MarketName: "fed", Limit: 10})
+	if err := svc.TagEvent(others[0].ID, "Case 7"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.TagEvent(tagged[0].ID, "case-7"); err != nil {
+		t.Fatal(err)
+	}
+	counts, err := svc.GetEventTags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]int64)
+	for _, c := range counts {
+		got[c.Tag] = c.Count
+	}
+	if len(got) != 2 || got["case-7"] != 2 || got["super-bowl-insider"] != 1 {
+		t.Errorf("tag counts = %+v, want case-7 on 2 events and the rule's tag on 1", counts)
+	}
+	if err := svc.UntagEvent(tagged[0].ID, "Case 7"); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := svc.GetEvents(domain.PolymarketEventFilter{Tag: "case-7", Limit: 10}); len(events) != 1 {
+		t.Errorf("%d events tagged case-7 after untagging one, want 1", len(events))
+	}
+
+	restarted := NewPolymarketService(svc.store, localbus.New(), "")
+	t.Cleanup(restarted.Close)
+	if rules := restarted.GetTagRules(); len(rules) != 1 {
+		t.Errorf("rules after restart = %+v, want the saved rule", rules)
+	}
+}
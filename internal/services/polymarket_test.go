@@ -0,0 +1,76 @@
+package services
+
+import (
+	"io"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// Services are tested against the in-memory store, which must stay a complete store
+var _ ports.PolymarketStore = (*storage.MemoryPolymarketStore)(nil)
+
+// emitted records the events a service emits on its bus
+type emitted struct {
+	mu     sync.Mutex
+	events map[string][]interface{}
+}
+
+// of returns what was emitted under an event name
+func (e *emitted) of(eventName string) []interface{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]interface{}{}, e.events[eventName]...)
+}
+
+// newTestService returns a service backed by the in-memory store, not connected to
+// Polymarket, and the events it emits
+func newTestService(t testing.TB) (*PolymarketService, *emitted) {
+	t.Helper()
+	bus := localbus.New()
+	rec := &emitted{events: make(map[string][]interface{})}
+	bus.Listen(func(eventName string, data interface{}) {
+		rec.mu.Lock()
+		rec.events[eventName] = append(rec.events[eventName], data)
+		rec.mu.Unlock()
+	})
+	svc := NewPolymarketService(storage.NewMemoryPolymarketStore(), bus, "")
+	t.Cleanup(svc.Close)
+	return svc, rec
+}
+
+// ingestFrame runs a WebSocket frame through the pipeline as the feed clients do
+func ingestFrame(t testing.TB, svc *PolymarketService, frame string) {
+	t.Helper()
+	messages, err := polymarket.DecodeMessage([]byte(frame))
+	if err != nil {
+		t.Fatalf("decode %s: %v", frame, err)
+	}
+	for _, msg := range messages {
+		for _, event := range msg.Events() {
+			svc.Ingest(event)
+		}
+	}
+}
+
+// waitFor polls until cond holds, failing the test after a few seconds
+func waitFor(t testing.TB, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !cond(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+	}
+}
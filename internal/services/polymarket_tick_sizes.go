@@ -0,0 +1,99 @@
+package services
+
+import (
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// maxTickSizeAssets bounds the per-asset tick sizes
+const maxTickSizeAssets = 50000
+
+// observeTickSize records the new tick size of a tick_size_change event. The asset's
+// sampling state, book levels and usual spread are reset, so prices on the old grid
+// aren't mistaken for moves, pulled orders or a widened spread.
+func (s *PolymarketService) observeTickSize(event domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTickSizeChange || event.AssetID == "" {
+		return
+	}
+	msg, err := polymarket.DecodeTickSizeChange(event.RawData)
+	if err != nil || msg.TickSize() <= 0 {
+		log.Printf("[PolymarketService] Ignoring tick size change without a tick size for %s", event.AssetID)
+		return
+	}
+	tick := msg.TickSize()
+
+	s.tickMu.Lock()
+	old, known := s.tickSizes[event.AssetID]
+	if !known && len(s.tickSizes) >= maxTickSizeAssets {
+		s.tickSizes = make(map[string]float64)
+	}
+	s.tickSizes[event.AssetID] = tick
+	s.tickMu.Unlock()
+	if known && old == tick {
+		return
+	}
+
+	s.samplingMu.Lock()
+	for _, rule := range s.samplingRules {
+		delete(s.samplingState, string(rule.EventType)+":"+event.AssetID)
+	}
+	s.samplingMu.Unlock()
+
+	s.spoofMu.Lock()
+	if book := s.spoofBooks[event.AssetID]; book != nil {
+		book.levels = make(map[string]float64)
+		book.orders = make(map[string]*spoofOrder)
+	}
+	s.spoofMu.Unlock()
+
+	s.resetSpreadBaseline(event.AssetID)
+
+	log.Printf("[PolymarketService] Tick size of %s changed to %s", event.AssetID, strconv.FormatFloat(tick, 'f', -1, 64))
+}
+
+// tickSize returns the current tick size of an asset, or fallback when none was announced
+func (s *PolymarketService) tickSize(assetID string, fallback float64) float64 {
+	s.tickMu.Lock()
+	defer s.tickMu.Unlock()
+	if tick, ok := s.tickSizes[assetID]; ok {
+		return tick
+	}
+	return fallback
+}
+
+// normalizePrices rounds the prices of a market channel event to its asset's tick size,
+// so the same level always has the same text. Prices of assets whose tick size isn't
+// known yet, and trade prices, are kept as sent.
+func (s *PolymarketService) normalizePrices(event *domain.PolymarketEvent) {
+	switch event.EventType {
+	case domain.PolymarketEventBook, domain.PolymarketEventPriceChange, domain.PolymarketEventLastTradePrice:
+	default:
+		return
+	}
+	tick := s.tickSize(event.AssetID, 0)
+	if tick <= 0 {
+		return
+	}
+	event.Price = roundToTick(event.Price, tick)
+	event.BestBid = roundToTick(event.BestBid, tick)
+	event.BestAsk = roundToTick(event.BestAsk, tick)
+}
+
+// roundToTick rounds a price to the nearest tick, formatted with the tick's decimals;
+// empty or invalid prices are returned unchanged
+func roundToTick(price string, tick float64) string {
+	value, err := strconv.ParseFloat(price, 64)
+	if err != nil || value < 0 {
+		return price
+	}
+	decimals := 0
+	if _, frac, ok := strings.Cut(strconv.FormatFloat(tick, 'f', -1, 64), "."); ok {
+		decimals = len(frac)
+	}
+	return strconv.FormatFloat(math.Round(value/tick)*tick, 'f', decimals, 64)
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+)
+
+// quoteFrame is a price change of asset 111 quoted at the given best bid and ask
+func quoteFrame(at int64, bid, ask string) string {
+	return fmt.Sprintf(`{"event_type":"price_change","market":"0xabc","timestamp":"%d",
+		"price_changes":[{"asset_id":"111","price":"%s","size":"10","side":"BUY","best_bid":"%s","best_ask":"%s"}]}`,
+		at, bid, bid, ask)
+}
+
+func TestTickSizeChangeNormalizesPrices(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	ingestFrame(t, svc, `{"event_type":"tick_size_change","asset_id":"111","market":"0xabc",
+		"old_tick_size":"0.01","new_tick_size":"0.001","timestamp":"1760000000000"}`)
+	if got := svc.tickSize("111", 0); got != 0.001 {
+		t.Fatalf("tick size = %g, want 0.001", got)
+	}
+	ingestFrame(t, svc, quoteFrame(1760000001000, "0.97149", "0.9731"))
+
+	quotes, _ := svc.GetQuotes([]string{"111"})
+	if len(quotes) != 1 || quotes[0].BestBid != 0.971 || quotes[0].BestAsk != 0.973 {
+		t.Fatalf("got quotes %+v, want 0.971/0.973", quotes)
+	}
+}
+
+func TestTickSizeChangeDoesNotFlagSpread(t *testing.T) {
+	svc, _ := newTestService(t)
+	config := svc.GetConfig()
+	config.SpreadMinWidth = 0.005
+	svc.UpdateConfig(config)
+
+	// A book quoted two ticks wide on the 0.001 grid near the top of the range
+	ingestFrame(t, svc, `{"event_type":"tick_size_change","asset_id":"111","market":"0xabc",
+		"old_tick_size":"0.01","new_tick_size":"0.001","timestamp":"1760000000000"}`)
+	at := int64(1760000000000)
+	for i := 0; i < spreadMinUpdates; i++ {
+		at += 1000
+		ingestFrame(t, svc, quoteFrame(at, "0.971", "0.973"))
+	}
+
+	// Back on the 0.01 grid the tightest quote is one tick wide, five times the old spread
+	ingestFrame(t, svc, fmt.Sprintf(`{"event_type":"tick_size_change","asset_id":"111","market":"0xabc",
+		"old_tick_size":"0.001","new_tick_size":"0.01","timestamp":"%d"}`, at+1000))
+	for i := 0; i < spreadMinUpdates+5; i++ {
+		at += 1000
+		ingestFrame(t, svc, quoteFrame(at, "0.96", "0.97"))
+	}
+	if signals := svc.GetSpreadSignals(); len(signals) != 0 {
+		t.Fatalf("got %d spread signals after a tick size change, want 0", len(signals))
+	}
+
+	// Liquidity pulled on the new grid still alerts
+	ingestFrame(t, svc, quoteFrame(at+1000, "0.90", "0.97"))
+	if signals := svc.GetSpreadSignals(); len(signals) != 1 {
+		t.Fatalf("got %d spread signals for a widened book, want 1", len(signals))
+	}
+}
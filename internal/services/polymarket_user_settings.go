@@ -0,0 +1,238 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// userSettingsKey is the per-user settings key, namespaced by userSettingKey
+const userSettingsKey = "settings"
+
+// validUserID limits user IDs to what is safe in a settings key
+var validUserID = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// userSettingKey namespaces a settings key to a user, so one user's settings never
+// overwrite another's or the deployment-wide ones
+func userSettingKey(user, key string) string {
+	return "user:" + user + ":" + key
+}
+
+// normalizeUserID lowercases a user ID, defaulting to domain.DefaultUserID
+func normalizeUserID(user string) (string, error) {
+	user = strings.ToLower(strings.TrimSpace(user))
+	if user == "" {
+		return domain.DefaultUserID, nil
+	}
+	if !validUserID.MatchString(user) {
+		return "", fmt.Errorf("invalid user %q: use up to 32 letters, digits, '-' or '_'", user)
+	}
+	return user, nil
+}
+
+// GetUserSettings returns a user's settings, empty if they saved none
+func (s *PolymarketService) GetUserSettings(user string) (domain.UserSettings, error) {
+	user, err := normalizeUserID(user)
+	if err != nil {
+		return domain.UserSettings{}, err
+	}
+	settings := domain.UserSettings{User: user}
+	if err := s.store.LoadSetting(userSettingKey(user, userSettingsKey), &settings); err != nil {
+		settings = domain.UserSettings{User: user}
+	}
+	settings.User = user
+	settings.MutedMarkets = activeMutes(settings.MutedMarkets, time.Now())
+	if settings.Watchlist == nil {
+		settings.Watchlist = []string{}
+	}
+	return settings, nil
+}
+
+// SaveUserSettings replaces a user's settings
+func (s *PolymarketService) SaveUserSettings(user string, settings domain.UserSettings) (*domain.UserSettings, error) {
+	user, err := normalizeUserID(user)
+	if err != nil {
+		return nil, err
+	}
+	s.userSettingsMu.Lock()
+	defer s.userSettingsMu.Unlock()
+	return s.saveUserSettings(user, settings)
+}
+
+// updateUserSettings changes a user's settings and saves them, holding userSettingsMu
+// from load to save so concurrent changes are not lost. Nothing is saved when change
+// returns false.
+func (s *PolymarketService) updateUserSettings(user string, change func(settings *domain.UserSettings) bool) error {
+	s.userSettingsMu.Lock()
+	defer s.userSettingsMu.Unlock()
+
+	settings, err := s.GetUserSettings(user)
+	if err != nil {
+		return err
+	}
+	if !change(&settings) {
+		return nil
+	}
+	_, err = s.saveUserSettings(settings.User, settings)
+	return err
+}
+
+// saveUserSettings replaces the settings of a normalized user; the caller holds
+// userSettingsMu
+func (s *PolymarketService) saveUserSettings(user string, settings domain.UserSettings) (*domain.UserSettings, error) {
+	settings.User = user
+	settings.UpdatedAt = time.Now()
+	settings.Filter.Limit, settings.Filter.Offset = 0, 0
+
+	for i := range settings.MutedMarkets {
+		mute := &settings.MutedMarkets[i]
+		mute.Slug = strings.ToLower(strings.TrimSpace(mute.Slug))
+		if mute.MutedAt.IsZero() {
+			mute.MutedAt = settings.UpdatedAt
+		}
+	}
+	settings.MutedMarkets = activeMutes(settings.MutedMarkets, settings.UpdatedAt)
+
+	seen := make(map[string]bool)
+	watchlist := []string{}
+	for _, address := range settings.Watchlist {
+		address = strings.ToLower(strings.TrimSpace(address))
+		if address != "" && !seen[address] {
+			seen[address] = true
+			watchlist = append(watchlist, address)
+		}
+	}
+	settings.Watchlist = watchlist
+
+	if err := s.store.SaveSetting(userSettingKey(user, userSettingsKey), settings); err != nil {
+		return nil, fmt.Errorf("failed to save settings of user %s: %w", user, err)
+	}
+	log.Printf("[PolymarketService] Saved settings of user %s (%d mutes, %d watched wallets)",
+		user, len(settings.MutedMarkets), len(settings.Watchlist))
+	if user == domain.DefaultUserID {
+		s.setWatchlist(settings.Watchlist)
+	}
+	s.eventBus.Emit("polymarket:user_settings_updated", settings)
+	return &settings, nil
+}
+
+// GetUserEvents returns events as a user sees them: filter fields left empty take the
+// user's defaults, and events in the user's muted markets are left out. With
+// watchlistOnly, only trades by wallets on the user's watchlist are kept. Events are
+// dropped after paging, so a page may hold fewer than filter.Limit events.
+func (s *PolymarketService) GetUserEvents(user string, filter domain.PolymarketEventFilter, watchlistOnly bool) ([]domain.PolymarketEvent, error) {
+	settings, err := s.GetUserSettings(user)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.GetEvents(withUserDefaults(filter, settings.Filter))
+	if err != nil {
+		return nil, err
+	}
+	if len(settings.MutedMarkets) == 0 && !watchlistOnly {
+		return events, nil
+	}
+
+	muted := make(map[string]bool, len(settings.MutedMarkets))
+	for _, mute := range settings.MutedMarkets {
+		muted[mute.Slug] = true
+	}
+	watched := make(map[string]bool, len(settings.Watchlist))
+	for _, address := range settings.Watchlist {
+		watched[address] = true
+	}
+	kept := events[:0]
+	for _, event := range events {
+		if muted[strings.ToLower(event.MarketSlug)] || muted[strings.ToLower(event.EventSlug)] {
+			continue
+		}
+		if watchlistOnly && !watched[strings.ToLower(event.WalletAddress)] {
+			continue
+		}
+		kept = append(kept, event)
+	}
+	return kept, nil
+}
+
+// GetUserEventCount returns how many events match a filter with a user's defaults filled
+// in. Muted markets and the watchlist are applied to returned events only, so they
+// aren't reflected in the count.
+func (s *PolymarketService) GetUserEventCount(user string, filter domain.PolymarketEventFilter) (int64, error) {
+	settings, err := s.GetUserSettings(user)
+	if err != nil {
+		return 0, err
+	}
+	return s.GetEventCount(withUserDefaults(filter, settings.Filter))
+}
+
+// GetUserEventPage returns a page of events with its totals, with a user's defaults
+// filled in. Like GetUserEventCount, muted markets and the watchlist are not applied,
+// so the page always agrees with its totals.
+func (s *PolymarketService) GetUserEventPage(user string, filter domain.PolymarketEventFilter) (*domain.PolymarketEventPage, error) {
+	settings, err := s.GetUserSettings(user)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEventPage(withUserDefaults(filter, settings.Filter))
+}
+
+// withUserDefaults fills the filter fields a query left empty from a user's defaults
+func withUserDefaults(filter, defaults domain.PolymarketEventFilter) domain.PolymarketEventFilter {
+	if len(filter.EventTypes) == 0 {
+		filter.EventTypes = defaults.EventTypes
+	}
+	if filter.MarketName == "" {
+		filter.MarketName = defaults.MarketName
+	}
+	if filter.MinPrice == 0 {
+		filter.MinPrice = defaults.MinPrice
+	}
+	if filter.MaxPrice == 0 {
+		filter.MaxPrice = defaults.MaxPrice
+	}
+	if filter.Side == "" {
+		filter.Side = defaults.Side
+	}
+	if filter.Outcome == "" {
+		filter.Outcome = defaults.Outcome
+	}
+	if filter.OutcomeIndex == nil {
+		filter.OutcomeIndex = defaults.OutcomeIndex
+	}
+	if filter.MinSize == 0 {
+		filter.MinSize = defaults.MinSize
+	}
+	if filter.MinRiskScore == 0 {
+		filter.MinRiskScore = defaults.MinRiskScore
+	}
+	if filter.MaxWalletNonce == 0 {
+		filter.MaxWalletNonce = defaults.MaxWalletNonce
+	}
+	if filter.Tag == "" {
+		filter.Tag = defaults.Tag
+	}
+	if filter.SortBy == "" {
+		filter.SortBy, filter.SortDir = defaults.SortBy, defaults.SortDir
+	}
+	filter.FreshWalletsOnly = filter.FreshWalletsOnly || defaults.FreshWalletsOnly
+	// A user's exclusions always apply, on top of the query's own
+	filter.ExcludeMarketNames = slices.Concat(filter.ExcludeMarketNames, defaults.ExcludeMarketNames)
+	filter.ExcludeWallets = slices.Concat(filter.ExcludeWallets, defaults.ExcludeWallets)
+	return filter
+}
+
+// activeMutes drops mutes that expired by now
+func activeMutes(mutes []domain.MarketMute, now time.Time) []domain.MarketMute {
+	active := []domain.MarketMute{}
+	for _, mute := range mutes {
+		if mute.Slug != "" && now.Before(mute.Until) {
+			active = append(active, mute)
+		}
+	}
+	return active
+}
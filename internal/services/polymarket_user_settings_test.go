@@ -0,0 +1,75 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestUserEventsApplyMutesAndWatchlist(t *testing.T) {
+	svc, _ := newTestService(t)
+	if _, err := svc.SaveUserSettings("../admin", domain.UserSettings{}); err == nil {
+		t.Error("settings saved for an invalid user")
+	}
+
+	saved, err := svc.SaveUserSettings(" Alice ", domain.UserSettings{
+		Filter: domain.PolymarketEventFilter{MinSize: 1000, Limit: 5},
+		MutedMarkets: []domain.MarketMute{
+			{Slug: " Rain ", Until: time.Now().Add(time.Hour)},
+			{Slug: "snow", Until: time.Now().Add(-time.Hour)}, // Already expired
+		},
+		Watchlist: []string{"0xA", "0xa ", ""},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.User != "alice" || saved.Filter.Limit != 0 || len(saved.MutedMarkets) != 1 || saved.MutedMarkets[0].Slug != "rain" || len(saved.Watchlist) != 1 {
+		t.Errorf("saved = %+v, want normalized settings", saved)
+	}
+	if settings, _ := svc.GetUserSettings("bob"); settings.User != "bob" || len(settings.Watchlist) != 0 || settings.Filter.MinSize != 0 {
+		t.Errorf("bob's settings = %+v, want none of alice's", settings)
+	}
+	if watched := svc.GetWatchedWallets(); len(watched) != 0 {
+		t.Errorf("deployment watchlist = %v, want only the default user's watchlist watched", watched)
+	}
+
+	var events []domain.PolymarketEvent
+	for _, e := range []struct {
+		id, wallet, slug, size string
+	}{
+		{"1", "0xa", "rain", "5000"}, // Muted
+		{"2", "0xa", "wind", "5000"},
+		{"3", "0xb", "wind", "5000"}, // Not watched
+		{"4", "0xa", "wind", "100"},  // Below alice's minimum size
+	} {
+		events = append(events, domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: e.id, WalletAddress: e.wallet,
+			MarketSlug: e.slug, Price: "1", Size: e.size, Timestamp: time.Now(),
+		})
+	}
+	if err := svc.store.SaveEvents(events); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := svc.GetUserEvents("alice", domain.PolymarketEventFilter{Limit: 10}, false)
+	if err != nil || len(all) != 2 {
+		t.Errorf("alice's events = %d, %v, want the 2 large unmuted trades", len(all), err)
+	}
+	if watched, _ := svc.GetUserEvents("alice", domain.PolymarketEventFilter{Limit: 10}, true); len(watched) != 1 || watched[0].TradeID != "2" {
+		t.Errorf("alice's watched events = %+v, want trade 2", watched)
+	}
+	if count, _ := svc.GetUserEventCount("alice", domain.PolymarketEventFilter{}); count != 3 {
+		t.Errorf("alice's event count = %d, want her size filter applied but not her mutes", count)
+	}
+	if bobs, _ := svc.GetUserEvents("bob", domain.PolymarketEventFilter{Limit: 10}, false); len(bobs) != 4 {
+		t.Errorf("bob's events = %d, want all 4", len(bobs))
+	}
+
+	if _, err := svc.SaveUserSettings("", domain.UserSettings{Watchlist: []string{"0xC"}}); err != nil {
+		t.Fatal(err)
+	}
+	if watched := svc.GetWatchedWallets(); len(watched) != 1 || watched[0] != "0xc" {
+		t.Errorf("deployment watchlist = %v, want the default user's watchlist", watched)
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// walletBlacklistSettingKey is the settings key the wallet blacklist is persisted under
+const walletBlacklistSettingKey = "wallet_blacklist"
+
+// BlacklistWallet adds a wallet to the blacklist: it is no longer analyzed, saving
+// profile API calls, and its trades and signals raise no notifications. Blacklisting a
+// listed wallet again updates its reason.
+func (s *PolymarketService) BlacklistWallet(address, reason string) error {
+	if !validWalletAddress(address) {
+		return fmt.Errorf("invalid wallet address: %q", address)
+	}
+	address = strings.ToLower(address)
+
+	// Held until the new blacklist is swapped in, so concurrent changes are not lost
+	s.blacklistSave.Lock()
+	defer s.blacklistSave.Unlock()
+
+	s.blacklistMu.Lock()
+	blacklist := make(map[string]domain.BlacklistedWallet, len(s.blacklist)+1)
+	for k, v := range s.blacklist {
+		blacklist[k] = v
+	}
+	s.blacklistMu.Unlock()
+
+	entry, ok := blacklist[address]
+	if !ok {
+		entry = domain.BlacklistedWallet{Address: address, AddedAt: time.Now().UTC()}
+	}
+	entry.Reason = strings.TrimSpace(reason)
+	blacklist[address] = entry
+	if err := s.saveWalletBlacklist(blacklist); err != nil {
+		return err
+	}
+	log.Printf("[PolymarketService] Blacklisted wallet %s", shortenAddress(address))
+	return nil
+}
+
+// UnblacklistWallet removes a wallet from the blacklist
+func (s *PolymarketService) UnblacklistWallet(address string) error {
+	address = strings.ToLower(strings.TrimSpace(address))
+
+	s.blacklistSave.Lock()
+	defer s.blacklistSave.Unlock()
+
+	s.blacklistMu.Lock()
+	if _, ok := s.blacklist[address]; !ok {
+		s.blacklistMu.Unlock()
+		return nil
+	}
+	blacklist := make(map[string]domain.BlacklistedWallet, len(s.blacklist))
+	for k, v := range s.blacklist {
+		if k != address {
+			blacklist[k] = v
+		}
+	}
+	s.blacklistMu.Unlock()
+
+	return s.saveWalletBlacklist(blacklist)
+}
+
+// GetWalletBlacklist returns the blacklisted wallets, most recently added first
+func (s *PolymarketService) GetWalletBlacklist() []domain.BlacklistedWallet {
+	s.blacklistMu.Lock()
+	wallets := make([]domain.BlacklistedWallet, 0, len(s.blacklist))
+	for _, w := range s.blacklist {
+		wallets = append(wallets, w)
+	}
+	s.blacklistMu.Unlock()
+
+	sort.Slice(wallets, func(i, j int) bool {
+		if !wallets[i].AddedAt.Equal(wallets[j].AddedAt) {
+			return wallets[i].AddedAt.After(wallets[j].AddedAt)
+		}
+		return wallets[i].Address < wallets[j].Address
+	})
+	return wallets
+}
+
+// IsWalletBlacklisted reports whether a wallet is on the blacklist
+func (s *PolymarketService) IsWalletBlacklisted(address string) bool {
+	if address == "" {
+		return false
+	}
+	s.blacklistMu.Lock()
+	defer s.blacklistMu.Unlock()
+	_, ok := s.blacklist[strings.ToLower(address)]
+	return ok
+}
+
+// blacklistedAddresses returns the blacklisted wallets' addresses
+func (s *PolymarketService) blacklistedAddresses() []string {
+	s.blacklistMu.Lock()
+	defer s.blacklistMu.Unlock()
+
+	addresses := make([]string, 0, len(s.blacklist))
+	for address := range s.blacklist {
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// saveWalletBlacklist persists the blacklist and applies it
+func (s *PolymarketService) saveWalletBlacklist(blacklist map[string]domain.BlacklistedWallet) error {
+	wallets := make([]domain.BlacklistedWallet, 0, len(blacklist))
+	for _, w := range blacklist {
+		wallets = append(wallets, w)
+	}
+	if err := s.store.SaveSetting(walletBlacklistSettingKey, wallets); err != nil {
+		return fmt.Errorf("failed to save wallet blacklist: %w", err)
+	}
+	s.setWalletBlacklist(blacklist)
+	s.eventBus.Emit("polymarket:wallet_blacklist_updated", s.GetWalletBlacklist())
+	return nil
+}
+
+// loadWalletBlacklist restores the persisted wallet blacklist
+func (s *PolymarketService) loadWalletBlacklist() {
+	var wallets []domain.BlacklistedWallet
+	if err := s.store.LoadSetting(walletBlacklistSettingKey, &wallets); err != nil {
+		wallets = nil
+	}
+	blacklist := make(map[string]domain.BlacklistedWallet, len(wallets))
+	for _, w := range wallets {
+		blacklist[strings.ToLower(w.Address)] = w
+	}
+	s.setWalletBlacklist(blacklist)
+}
+
+// setWalletBlacklist replaces the blacklist and hands it to the wallet analyzer
+func (s *PolymarketService) setWalletBlacklist(blacklist map[string]domain.BlacklistedWallet) {
+	s.blacklistMu.Lock()
+	s.blacklist = blacklist
+	s.blacklistMu.Unlock()
+
+	s.mu.RLock()
+	analyzer := s.walletAnalyzer
+	s.mu.RUnlock()
+	analyzer.SetBlacklist(s.blacklistedAddresses())
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// slowSettingsStore takes a while to save settings, as a database would, so concurrent
+// changes overlap
+type slowSettingsStore struct {
+	*storage.MemoryPolymarketStore
+}
+
+func (s slowSettingsStore) SaveSetting(key string, value interface{}) error {
+	time.Sleep(time.Millisecond)
+	return s.MemoryPolymarketStore.SaveSetting(key, value)
+}
+
+func TestConcurrentBlacklistChangesAreKept(t *testing.T) {
+	svc := NewPolymarketService(slowSettingsStore{storage.NewMemoryPolymarketStore()}, localbus.New(), "")
+	t.Cleanup(svc.Close)
+
+	addresses := make([]string, 50)
+	for i := range addresses {
+		addresses[i] = fmt.Sprintf("0x%040x", i+1)
+	}
+
+	var wg sync.WaitGroup
+	for _, address := range addresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := svc.BlacklistWallet(address, "market maker"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := len(svc.GetWalletBlacklist()); got != len(addresses) {
+		t.Fatalf("got %d blacklisted wallets after concurrent adds, want %d", got, len(addresses))
+	}
+
+	for _, address := range addresses[:25] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := svc.UnblacklistWallet(address); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	for i, address := range addresses {
+		if listed, want := svc.IsWalletBlacklisted(address), i >= 25; listed != want {
+			t.Errorf("IsWalletBlacklisted(%s) = %v after concurrent removals, want %v", address, listed, want)
+		}
+	}
+
+	// The persisted blacklist matches the one in use
+	svc.loadWalletBlacklist()
+	if got := len(svc.GetWalletBlacklist()); got != 25 {
+		t.Errorf("got %d blacklisted wallets after reload, want 25", got)
+	}
+}
+
+// dropTransform suppresses every notification, so tests deliver nothing
+type dropTransform struct{}
+
+func (dropTransform) Transform(content domain.NotificationContent) (domain.NotificationContent, bool, error) {
+	return content, false, nil
+}
+
+func TestBlacklistedWalletsAreSkipped(t *testing.T) {
+	store := storage.NewMemoryPolymarketStore()
+	svc := NewPolymarketService(store, localbus.New(), "")
+	t.Cleanup(svc.Close)
+
+	listed := "0x" + strings.Repeat("A", 40)
+	other := "0x" + strings.Repeat("b", 40)
+	if err := svc.BlacklistWallet("0xnope", "bot"); err == nil {
+		t.Error("blacklisted an invalid address")
+	}
+	if err := svc.BlacklistWallet(listed, " market maker "); err != nil {
+		t.Fatal(err)
+	}
+	if blacklist := svc.GetWalletBlacklist(); len(blacklist) != 1 || blacklist[0].Address != strings.ToLower(listed) || blacklist[0].Reason != "market maker" {
+		t.Fatalf("blacklist = %+v, want the lowercased wallet with its trimmed reason", blacklist)
+	}
+
+	// The wallet analyzer makes no profile API calls for it
+	analyzer := svc.walletAnalyzer
+	if _, err := analyzer.AnalyzeWallet(context.Background(), strings.ToLower(listed)); !errors.Is(err, domain.ErrWalletBlacklisted) {
+		t.Errorf("AnalyzeWallet() error = %v, want ErrWalletBlacklisted", err)
+	}
+	if signal, err := analyzer.AnalyzeTrade(context.Background(), &domain.PolymarketEvent{WalletAddress: listed}); signal != nil || err != nil {
+		t.Errorf("AnalyzeTrade() = %+v, %v, want no signal", signal, err)
+	}
+	store.SaveWalletAddress(listed)
+	store.SaveWalletAddress(other)
+	if refresh, _ := store.GetWalletsForRefresh(10, svc.blacklistedAddresses()); len(refresh) != 1 || refresh[0] != other {
+		t.Errorf("wallets for refresh = %v, want only %s", refresh, other)
+	}
+
+	// Its trades raise no notifications
+	config := domain.DefaultNotificationConfig()
+	config.Enabled, config.NotifyBigTrades = true, true
+	store.SaveNotificationConfig(config)
+	notifications := NewNotificationService(store, localbus.New())
+	notifications.SetTransformer(dropTransform{})
+	notifications.SetWalletBlacklist(svc)
+	notifications.handlePolymarketEvent(domain.PolymarketEvent{TradeID: "listed", WalletAddress: listed})
+	notifications.handlePolymarketEvent(domain.PolymarketEvent{TradeID: "other", WalletAddress: other})
+	for tradeID, want := range map[string]bool{"listed": false, "other": true} {
+		if notified, _ := store.HasNotified(NotifyTypeBigTrade, tradeID); notified != want {
+			t.Errorf("trade %s notified = %v, want %v", tradeID, notified, want)
+		}
+	}
+
+	// The blacklist survives a restart and can be lifted
+	restarted := NewPolymarketService(store, localbus.New(), "")
+	t.Cleanup(restarted.Close)
+	if !restarted.IsWalletBlacklisted(listed) {
+		t.Fatal("blacklist was not restored")
+	}
+	if err := restarted.UnblacklistWallet(strings.ToLower(listed)); err != nil {
+		t.Fatal(err)
+	}
+	if restarted.IsWalletBlacklisted(listed) || restarted.walletAnalyzer.IsBlacklisted(listed) {
+		t.Error("wallet is still blacklisted after removal")
+	}
+}
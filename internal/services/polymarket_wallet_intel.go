@@ -0,0 +1,291 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// walletIntelKeySettingKey is the settings key the bundle signing key is persisted under
+	walletIntelKeySettingKey = "wallet_intel_signing_key"
+
+	// maxWalletIntelWallets bounds the wallets of one bundle
+	maxWalletIntelWallets = 10000
+
+	// maxWalletIntelBytes bounds the size of an imported bundle
+	maxWalletIntelBytes = 20 << 20
+)
+
+// TagWallet attaches a label to a wallet, e.g. "insider" or "market-maker"
+func (s *PolymarketService) TagWallet(address, tag string) error {
+	if !validWalletAddress(address) {
+		return fmt.Errorf("invalid wallet address: %q", address)
+	}
+	if err := s.store.TagWallet(address, tag); err != nil {
+		return err
+	}
+	s.eventBus.Emit("polymarket:wallet_tagged", map[string]any{"address": strings.ToLower(address), "tag": domain.NormalizeTag(tag)})
+	return nil
+}
+
+// UntagWallet removes a label from a wallet
+func (s *PolymarketService) UntagWallet(address, tag string) error {
+	if err := s.store.UntagWallet(address, tag); err != nil {
+		return err
+	}
+	s.eventBus.Emit("polymarket:wallet_untagged", map[string]any{"address": strings.ToLower(address), "tag": domain.NormalizeTag(tag)})
+	return nil
+}
+
+// GetWalletTags returns the tags of the given wallets by lowercased address, or of
+// every tagged wallet when none are given
+func (s *PolymarketService) GetWalletTags(addresses []string) (map[string][]string, error) {
+	return s.store.GetWalletTags(addresses)
+}
+
+// GetWalletIntel returns what other users shared about a wallet in imported bundles
+func (s *PolymarketService) GetWalletIntel(address string) ([]domain.ImportedWalletIntel, error) {
+	return s.store.GetImportedWalletIntel(address)
+}
+
+// ExportWalletIntel writes the tags, freshness, win rate and flags of the selected
+// wallets to a signed bundle in the exports folder, for other users to import
+func (s *PolymarketService) ExportWalletIntel(req domain.WalletIntelExportRequest) (*domain.WalletIntelExport, error) {
+	tags, err := s.store.GetWalletTags(req.Addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wallet tags: %w", err)
+	}
+	addresses := req.Addresses
+	if len(addresses) == 0 {
+		for address := range tags {
+			addresses = append(addresses, address)
+		}
+	}
+
+	tag := domain.NormalizeTag(req.Tag)
+	seen := make(map[string]bool, len(addresses))
+	var wallets []domain.WalletIntel
+	for _, address := range addresses {
+		address = strings.ToLower(address)
+		if seen[address] || !validWalletAddress(address) {
+			continue
+		}
+		seen[address] = true
+		if tag != "" && !slices.Contains(tags[address], tag) {
+			continue
+		}
+		wallets = append(wallets, s.walletIntel(address, tags[address]))
+	}
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("no wallets to export")
+	}
+	if len(wallets) > maxWalletIntelWallets {
+		return nil, fmt.Errorf("at most %d wallets can be exported at once", maxWalletIntelWallets)
+	}
+	sort.Slice(wallets, func(i, j int) bool { return wallets[i].Address < wallets[j].Address })
+
+	key, err := s.walletIntelKey()
+	if err != nil {
+		return nil, err
+	}
+	bundle := domain.WalletIntelBundle{
+		Version:   domain.WalletIntelVersion,
+		Publisher: strings.TrimSpace(req.Publisher),
+		PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Wallets:   wallets,
+	}
+	signed, err := bundle.SignedBytes()
+	if err != nil {
+		return nil, err
+	}
+	bundle.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, signed))
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(filepath.Dir(s.dbPath), "exports")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	// Exports in the same second get a random suffix instead of overwriting each other
+	f, err := os.CreateTemp(dir, fmt.Sprintf("wallet-intel-%s-*.json", time.Now().Format("20060102-150405")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet intel file: %w", err)
+	}
+	path := f.Name()
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write wallet intel: %w", err)
+	}
+	log.Printf("[PolymarketService] Exported intel on %d wallets to %s", len(wallets), path)
+	return &domain.WalletIntelExport{Path: path, Wallets: len(wallets), PublicKey: bundle.PublicKey}, nil
+}
+
+// ImportWalletIntel imports a signed bundle another user exported. New wallets are
+// queued for analysis and the intel, tags included, is kept per publisher next to the
+// local analysis and tags, which it never changes. Bundles whose signature doesn't
+// match, or signed by a key that isn't a trusted publisher, are rejected.
+func (s *PolymarketService) ImportWalletIntel(path string) (*domain.WalletIntelImportResult, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxWalletIntelBytes {
+		return nil, fmt.Errorf("wallet intel file is larger than %d MB", maxWalletIntelBytes>>20)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var bundle domain.WalletIntelBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("not a wallet intel file: %w", err)
+	}
+	if err := verifyWalletIntel(bundle); err != nil {
+		return nil, err
+	}
+	if err := s.checkIntelPublisher(bundle); err != nil {
+		return nil, err
+	}
+	if len(bundle.Wallets) > maxWalletIntelWallets {
+		return nil, fmt.Errorf("wallet intel file has more than %d wallets", maxWalletIntelWallets)
+	}
+
+	result := &domain.WalletIntelImportResult{Publisher: bundle.Publisher, PublicKey: bundle.PublicKey}
+	now := time.Now()
+	imported := make([]domain.ImportedWalletIntel, 0, len(bundle.Wallets))
+	for _, w := range bundle.Wallets {
+		w.Address = strings.ToLower(w.Address)
+		if !validWalletAddress(w.Address) {
+			result.Skipped++
+			continue
+		}
+		isNew, err := s.store.SaveWalletAddress(w.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save wallet %s: %w", w.Address, err)
+		}
+		if isNew {
+			result.NewWallets++
+		}
+		tags := w.Tags[:0]
+		for _, tag := range w.Tags {
+			if tag = domain.NormalizeTag(tag); tag != "" && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		w.Tags = tags
+		result.Tags += len(tags)
+		imported = append(imported, domain.ImportedWalletIntel{
+			WalletIntel: w,
+			Publisher:   bundle.Publisher,
+			PublicKey:   bundle.PublicKey,
+			ImportedAt:  now,
+		})
+	}
+	if err := s.store.SaveImportedWalletIntel(imported); err != nil {
+		return nil, fmt.Errorf("failed to save wallet intel: %w", err)
+	}
+	result.Wallets = len(imported)
+
+	log.Printf("[PolymarketService] Imported intel on %d wallets from %s (%d new, %d tags)",
+		result.Wallets, walletIntelPublisher(bundle), result.NewWallets, result.Tags)
+	s.eventBus.Emit("polymarket:wallet_intel_imported", result)
+	return result, nil
+}
+
+// walletIntel collects what is known locally about a wallet
+func (s *PolymarketService) walletIntel(address string, tags []string) domain.WalletIntel {
+	intel := domain.WalletIntel{Address: address, Tags: tags, WashTrader: s.IsWashTrader(address)}
+	if profile, err := s.store.GetWallet(address); err == nil && profile != nil && profile.BetCount >= 0 {
+		intel.Freshness = profile.FreshnessLevel
+		intel.BetCount = profile.BetCount
+	}
+	if streak, err := s.walletStreak(address); err == nil && streak.ResolvedBets > 0 {
+		intel.ResolvedBets = streak.ResolvedBets
+		intel.Wins = streak.Wins
+		intel.WinRate = float64(streak.Wins) / float64(streak.ResolvedBets)
+		intel.HotHand = streak.HotHand
+	}
+	return intel
+}
+
+// walletIntelKey returns the key bundles are signed with, creating it on first use
+func (s *PolymarketService) walletIntelKey() (ed25519.PrivateKey, error) {
+	var seed string
+	err := s.store.LoadSetting(walletIntelKeySettingKey, &seed)
+	if err == nil {
+		if raw, decodeErr := base64.StdEncoding.DecodeString(seed); decodeErr == nil && len(raw) == ed25519.SeedSize {
+			return ed25519.NewKeyFromSeed(raw), nil
+		}
+		return nil, fmt.Errorf("stored wallet intel signing key is invalid")
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load wallet intel signing key: %w", err)
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.SaveSetting(walletIntelKeySettingKey, base64.StdEncoding.EncodeToString(key.Seed())); err != nil {
+		return nil, fmt.Errorf("failed to save wallet intel signing key: %w", err)
+	}
+	log.Printf("[PolymarketService] Created wallet intel signing key")
+	return key, nil
+}
+
+// verifyWalletIntel checks a bundle's version and that it is signed by its public key
+func verifyWalletIntel(bundle domain.WalletIntelBundle) error {
+	if bundle.Version != domain.WalletIntelVersion {
+		return fmt.Errorf("unsupported wallet intel version: %d", bundle.Version)
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(bundle.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("wallet intel file has an invalid public key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(bundle.Signature)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("wallet intel file is not signed")
+	}
+	signed, err := bundle.SignedBytes()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, signed, signature) {
+		return fmt.Errorf("wallet intel signature doesn't match: the file was changed after it was signed")
+	}
+	return nil
+}
+
+// walletIntelPublisher names a bundle's publisher for logs
+func walletIntelPublisher(bundle domain.WalletIntelBundle) string {
+	if bundle.Publisher != "" {
+		return bundle.Publisher
+	}
+	return "key " + bundle.PublicKey[:8]
+}
+
+// validWalletAddress reports whether s is a 0x-prefixed 20-byte hex address
+func validWalletAddress(address string) bool {
+	return len(address) == 42 && addressPattern.MatchString(address)
+}
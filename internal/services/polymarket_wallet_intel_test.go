@@ -0,0 +1,73 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestImportWalletIntelNeedsTrustedPublisher(t *testing.T) {
+	const wallet = "0x00000000000000000000000000000000000000aa"
+	publisher, _ := newSQLiteTestService(t, "")
+	if err := publisher.TagWallet(wallet, "insider"); err != nil {
+		t.Fatal(err)
+	}
+	export, err := publisher.ExportWalletIntel(domain.WalletIntelExportRequest{Publisher: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc, _ := newSQLiteTestService(t, "")
+	if _, err := svc.ImportWalletIntel(export.Path); err == nil || !strings.Contains(err.Error(), "untrusted key") {
+		t.Fatalf("import from an unknown key: got %v, want it rejected", err)
+	}
+
+	if err := svc.TrustIntelPublisher(export.PublicKey, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := svc.ImportWalletIntel(export.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Wallets != 1 || result.Tags != 1 {
+		t.Errorf("import result = %+v, want 1 wallet with 1 tag", result)
+	}
+
+	// The publisher's tags stay with the intel, apart from the local tags
+	if tags, _ := svc.GetWalletTags([]string{wallet}); len(tags[wallet]) != 0 {
+		t.Errorf("local tags after import = %v, want none", tags[wallet])
+	}
+	intel, err := svc.GetWalletIntel(wallet)
+	if err != nil || len(intel) != 1 || len(intel[0].Tags) != 1 || intel[0].Tags[0] != "insider" {
+		t.Errorf("imported intel = %+v, %v; want the insider tag from alice", intel, err)
+	}
+
+	if err := svc.UntrustIntelPublisher(export.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ImportWalletIntel(export.Path); err == nil {
+		t.Errorf("import after untrusting the key: want it rejected")
+	}
+}
+
+func TestImportOwnWalletIntel(t *testing.T) {
+	svc, _ := newSQLiteTestService(t, "")
+	if err := svc.TagWallet("0x00000000000000000000000000000000000000bb", "follow"); err != nil {
+		t.Fatal(err)
+	}
+	export, err := svc.ExportWalletIntel(domain.WalletIntelExportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ImportWalletIntel(export.Path); err != nil {
+		t.Errorf("import of an own bundle: %v", err)
+	}
+}
+
+func TestTrustIntelPublisherValidatesKey(t *testing.T) {
+	svc, _ := newTestService(t)
+	if err := svc.TrustIntelPublisher("not-a-key", ""); err == nil {
+		t.Errorf("want an invalid key rejected")
+	}
+}
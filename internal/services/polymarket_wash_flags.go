@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// applyWashTag tags a trade by a wallet flagged for wash trading
+func applyWashTag(event *domain.PolymarketEvent, wash bool) {
+	if wash && !containsString(event.Tags, domain.WashTradeTag) {
+		event.Tags = append(event.Tags, domain.WashTradeTag)
+	}
+}
+
+// emitWashAlert announces a wallet, or pair of wallets, newly flagged for wash trading
+func (s *PolymarketService) emitWashAlert(pair domain.WashPair, event domain.PolymarketEvent) {
+	window, _ := s.washThresholds()
+	signal := "self_wash"
+	message := fmt.Sprintf("%s traded against itself %d times within %s (%s matched)",
+		shortenAddress(pair.Wallet), pair.Matches, window, formatCompactUSD(pair.Volume))
+	if pair.PairedWith != "" {
+		signal = "paired_wash"
+		message = fmt.Sprintf("%s and %s traded against each other %d times within %s (%s matched)",
+			shortenAddress(pair.Wallet), shortenAddress(pair.PairedWith), pair.Matches, window, formatCompactUSD(pair.Volume))
+	}
+	log.Printf("[PolymarketService] WASH TRADING: %s", message)
+
+	s.eventBus.Emit("polymarket:wash_trading", pair)
+	if event.Muted || s.isMarketMuted(event) {
+		return
+	}
+	s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+		Detector: "wash_trading",
+		Signal:   signal,
+		Message:  message,
+		Score:    math.Min(1, 0.6+0.05*float64(pair.Matches)),
+		Alert:    true,
+		Metadata: map[string]string{
+			"pairedWith": pair.PairedWith,
+			"matches":    strconv.Itoa(pair.Matches),
+			"volume":     strconv.FormatFloat(pair.Volume, 'f', 2, 64),
+			"markets":    strings.Join(pair.Markets, ","),
+		},
+		TradeID:       event.TradeID,
+		WalletAddress: pair.Wallet,
+		MarketName:    event.MarketName,
+		MarketLink:    event.MarketLink,
+		Timestamp:     pair.LastSeenAt,
+	})
+}
+
+// forgetWashFlow drops the trades of newly flagged wallets from the event groups and
+// size histories, so flow they already added doesn't count towards alerts
+func (s *PolymarketService) forgetWashFlow(wallets ...string) {
+	drop := make(map[string]bool, len(wallets))
+	for _, w := range wallets {
+		if w != "" {
+			drop[w] = true
+		}
+	}
+
+	s.groupsMu.Lock()
+	for _, g := range s.groups {
+		kept := g.trades[:0]
+		for _, t := range g.trades {
+			if !drop[t.wallet] {
+				kept = append(kept, t)
+			}
+		}
+		g.trades = kept
+	}
+	s.groupsMu.Unlock()
+
+	s.sizeMu.Lock()
+	for w := range drop {
+		delete(s.walletSizes, w)
+	}
+	s.sizeMu.Unlock()
+}
+
+// washThresholds returns the configured matching window and matches to flag
+func (s *PolymarketService) washThresholds() (time.Duration, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seconds, matches := s.config.WashWindowSeconds, s.config.WashMinMatches
+	if seconds <= 0 {
+		seconds = defaultWashWindowSeconds
+	}
+	if matches <= 0 {
+		matches = defaultWashMinMatches
+	}
+	return time.Duration(seconds) * time.Second, matches
+}
+
+// dropUnflaggedWashPairsLocked makes room for new pairs. Caller must hold washMu.
+func (s *PolymarketService) dropUnflaggedWashPairsLocked() {
+	for key, p := range s.washPairs {
+		if !p.Flagged {
+			delete(s.washPairs, key)
+		}
+	}
+}
+
+// rebuildWashWalletsLocked recomputes the flagged wallets from the pairs. Caller must hold washMu.
+func (s *PolymarketService) rebuildWashWalletsLocked() {
+	s.washWallets = make(map[string]bool)
+	for _, p := range s.washPairs {
+		if !p.Flagged {
+			continue
+		}
+		s.washWallets[p.Wallet] = true
+		if p.PairedWith != "" {
+			s.washWallets[p.PairedWith] = true
+		}
+	}
+}
+
+// saveWashPairsLocked persists the flagged pairs. Caller must hold washMu.
+func (s *PolymarketService) saveWashPairsLocked() {
+	flagged := []domain.WashPair{}
+	for _, p := range s.washPairs {
+		if p.Flagged {
+			flagged = append(flagged, *p)
+		}
+	}
+	if err := s.store.SaveSetting(washPairsSettingKey, flagged); err != nil {
+		log.Printf("[PolymarketService] Failed to save wash trading pairs: %v", err)
+	}
+}
+
+// loadWashPairs restores the flagged pairs
+func (s *PolymarketService) loadWashPairs() {
+	var flagged []domain.WashPair
+	s.store.LoadSetting(washPairsSettingKey, &flagged)
+
+	s.washMu.Lock()
+	defer s.washMu.Unlock()
+	s.washTrades = make(map[string][]washTrade)
+	s.washPairs = make(map[string]*domain.WashPair, len(flagged))
+	for i := range flagged {
+		p := flagged[i]
+		s.washPairs[p.Wallet+"|"+p.PairedWith] = &p
+	}
+	s.rebuildWashWalletsLocked()
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Wash trading defaults when the config leaves them unset
+	defaultWashWindowSeconds = 10
+	defaultWashMinMatches    = 3
+
+	// washSizeTolerance is how far apart in shares two trades may be and still offset
+	washSizeTolerance = 0.05
+
+	// washMinNotional ignores dust trades, which offset by chance in busy markets
+	washMinNotional = 50.0
+
+	// Bounds on the in-memory wash trading state
+	maxWashMarkets      = 5000
+	maxWashMarketTrades = 200
+	maxWashPairs        = 20000
+	maxWashPairMarkets  = 10
+
+	washPairsSettingKey = "wash_pairs"
+)
+
+// washTrade is a recent trade waiting for an offsetting one
+type washTrade struct {
+	wallet   string
+	asset    string
+	side     domain.OrderSide
+	shares   float64
+	notional float64
+	at       time.Time
+}
+
+// offsets reports whether two trades cancel out: the same outcome bought and sold, or
+// both outcomes bought (or sold) in equal size
+func (t washTrade) offsets(other washTrade) bool {
+	if math.Abs(t.shares-other.shares) > washSizeTolerance*math.Max(t.shares, other.shares) {
+		return false
+	}
+	if t.asset == other.asset {
+		return t.side != other.side
+	}
+	return t.side == other.side
+}
+
+// GetWashPairs returns the wallets seen trading against themselves or each other, most
+// matches first. With flaggedOnly, only pairs that matched often enough to be tagged.
+func (s *PolymarketService) GetWashPairs(flaggedOnly bool) []domain.WashPair {
+	s.washMu.Lock()
+	pairs := make([]domain.WashPair, 0, len(s.washPairs))
+	for _, p := range s.washPairs {
+		if p.Flagged || !flaggedOnly {
+			pair := *p
+			pair.Markets = append([]string{}, p.Markets...)
+			pairs = append(pairs, pair)
+		}
+	}
+	s.washMu.Unlock()
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Matches != pairs[j].Matches {
+			return pairs[i].Matches > pairs[j].Matches
+		}
+		return pairs[i].LastSeenAt.After(pairs[j].LastSeenAt)
+	})
+	return pairs
+}
+
+// IsWashTrader reports whether a wallet is flagged for wash trading
+func (s *PolymarketService) IsWashTrader(address string) bool {
+	s.washMu.Lock()
+	defer s.washMu.Unlock()
+	return s.washWallets[strings.ToLower(address)]
+}
+
+// ClearWashTrader unflags a wallet, e.g. a market maker wrongly matched, and forgets
+// the pairs it was seen in
+func (s *PolymarketService) ClearWashTrader(address string) error {
+	wallet := strings.ToLower(address)
+
+	s.washMu.Lock()
+	defer s.washMu.Unlock()
+	if !s.washWallets[wallet] {
+		return fmt.Errorf("wallet %s is not flagged for wash trading", address)
+	}
+	for key, p := range s.washPairs {
+		if p.Wallet == wallet || p.PairedWith == wallet {
+			delete(s.washPairs, key)
+		}
+	}
+	s.rebuildWashWalletsLocked()
+	s.saveWashPairsLocked()
+	return nil
+}
+
+// observeWashTrade matches a trade against the market's recent trades and returns
+// whether its wallet is flagged for wash trading. Flagged wallets' trades are tagged
+// and kept out of the size and event flow signals.
+func (s *PolymarketService) observeWashTrade(event domain.PolymarketEvent) bool {
+	if event.EventType != domain.PolymarketEventTrade || event.WalletAddress == "" {
+		return false
+	}
+	trade := washTrade{
+		wallet:   strings.ToLower(event.WalletAddress),
+		asset:    event.AssetID,
+		side:     event.Side,
+		notional: parseNotionalValue(event.Price, event.Size),
+		at:       event.Timestamp,
+	}
+	trade.shares, _ = strconv.ParseFloat(event.Size, 64)
+	if trade.at.IsZero() {
+		trade.at = time.Now()
+	}
+	market := event.ConditionID
+	if market == "" {
+		market = event.MarketSlug
+	}
+	window, minMatches := s.washThresholds()
+
+	s.washMu.Lock()
+	var flagged *domain.WashPair
+	if market != "" && trade.notional >= washMinNotional && trade.shares > 0 {
+		flagged = s.matchWashTradeLocked(market, trade, window, minMatches, event.MarketSlug)
+	}
+	wash := s.washWallets[trade.wallet]
+	s.washMu.Unlock()
+
+	if flagged != nil {
+		s.forgetWashFlow(flagged.Wallet, flagged.PairedWith)
+		s.emitWashAlert(*flagged, event)
+	}
+	return wash
+}
+
+// matchWashTradeLocked pairs a trade with an earlier offsetting one in the window, or
+// keeps it for later trades to match. Returns the pair if this match flagged it.
+// Caller must hold washMu.
+func (s *PolymarketService) matchWashTradeLocked(market string, trade washTrade, window time.Duration, minMatches int, slug string) *domain.WashPair {
+	recent, ok := s.washTrades[market]
+	if !ok && len(s.washTrades) >= maxWashMarkets {
+		s.washTrades = make(map[string][]washTrade)
+	}
+	i := 0
+	for i < len(recent) && trade.at.Sub(recent[i].at) > window {
+		i++
+	}
+	recent = recent[i:]
+
+	for j, earlier := range recent {
+		if !earlier.offsets(trade) {
+			continue
+		}
+		s.washTrades[market] = append(recent[:j:j], recent[j+1:]...)
+		return s.recordWashMatchLocked(earlier, trade, minMatches, slug)
+	}
+	recent = append(recent, trade)
+	if len(recent) > maxWashMarketTrades {
+		recent = recent[len(recent)-maxWashMarketTrades:]
+	}
+	s.washTrades[market] = recent
+	return nil
+}
+
+// recordWashMatchLocked counts an offsetting pair of trades. Caller must hold washMu.
+func (s *PolymarketService) recordWashMatchLocked(a, b washTrade, minMatches int, slug string) *domain.WashPair {
+	wallet, paired := a.wallet, b.wallet
+	if paired < wallet {
+		wallet, paired = paired, wallet
+	}
+	if paired == wallet {
+		paired = ""
+	}
+	key := wallet + "|" + paired
+
+	p, ok := s.washPairs[key]
+	if !ok {
+		if len(s.washPairs) >= maxWashPairs {
+			s.dropUnflaggedWashPairsLocked()
+		}
+		p = &domain.WashPair{Wallet: wallet, PairedWith: paired, FirstSeenAt: a.at}
+		s.washPairs[key] = p
+	}
+	p.Matches++
+	p.Volume += a.notional + b.notional
+	p.LastSeenAt = b.at
+	if slug != "" && (len(p.Markets) == 0 || p.Markets[len(p.Markets)-1] != slug) {
+		p.Markets = append(p.Markets, slug)
+		if len(p.Markets) > maxWashPairMarkets {
+			p.Markets = p.Markets[1:]
+		}
+	}
+
+	if p.Flagged || p.Matches < minMatches {
+		return nil
+	}
+	p.Flagged = true
+	s.washWallets[wallet] = true
+	if paired != "" {
+		s.washWallets[paired] = true
+	}
+	s.saveWashPairsLocked()
+	flagged := *p
+	return &flagged
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestWashTradingFlagsOffsettingPairs(t *testing.T) {
+	svc, rec := newTestService(t)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	n := 0
+	trade := func(wallet, asset string, side domain.OrderSide, size string, at time.Duration) bool {
+		n++
+		return svc.observeWashTrade(domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: fmt.Sprintf("tx-%d", n), WalletAddress: wallet,
+			ConditionID: "cond", MarketSlug: "fed-cut", AssetID: asset, Side: side, Price: "0.5", Size: size,
+			Timestamp: start.Add(at),
+		})
+	}
+
+	// Not offsetting: too far apart, too different in size, or dust
+	trade("0xA", "yes", domain.OrderSideBuy, "1000", 0)
+	trade("0xB", "yes", domain.OrderSideSell, "1000", time.Minute)
+	trade("0xA", "yes", domain.OrderSideBuy, "1000", 2*time.Minute)
+	trade("0xB", "yes", domain.OrderSideSell, "1200", 2*time.Minute+time.Second)
+	trade("0xA", "yes", domain.OrderSideBuy, "20", 3*time.Minute)
+	trade("0xB", "yes", domain.OrderSideSell, "20", 3*time.Minute+time.Second)
+	if pairs := svc.GetWashPairs(false); len(pairs) != 0 {
+		t.Fatalf("pairs = %+v, want no matches", pairs)
+	}
+
+	// A sell of the same outcome or a buy of the other offsets
+	trade("0xA", "yes", domain.OrderSideBuy, "1000", 10*time.Minute)
+	trade("0xB", "yes", domain.OrderSideSell, "1010", 10*time.Minute+2*time.Second)
+	trade("0xB", "yes", domain.OrderSideBuy, "1000", 11*time.Minute)
+	trade("0xA", "no", domain.OrderSideBuy, "1000", 11*time.Minute+time.Second)
+	if svc.IsWashTrader("0xa") || len(rec.of("polymarket:wash_trading")) != 0 {
+		t.Fatal("flagged after 2 matches")
+	}
+	trade("0xA", "yes", domain.OrderSideSell, "1000", 12*time.Minute)
+	if !trade("0xb", "yes", domain.OrderSideBuy, "1000", 12*time.Minute+time.Second) {
+		t.Fatal("the third match did not flag the wallet")
+	}
+
+	pairs := svc.GetWashPairs(true)
+	if len(pairs) != 1 || pairs[0].Wallet != "0xa" || pairs[0].PairedWith != "0xb" || pairs[0].Matches != 3 || pairs[0].Volume != 3005 {
+		t.Fatalf("flagged pairs = %+v, want 0xa and 0xb with 3 matches", pairs)
+	}
+	alerts := rec.of("polymarket:detector_signal")
+	if len(rec.of("polymarket:wash_trading")) != 1 || len(alerts) != 1 || alerts[0].(domain.DetectorSignal).Signal != "paired_wash" {
+		t.Errorf("alerts = %+v, want one paired wash alert", alerts)
+	}
+
+	// Flagged wallets' trades are tagged, and the flags survive a restart
+	event := domain.PolymarketEvent{WalletAddress: "0xB"}
+	applyWashTag(&event, svc.IsWashTrader(event.WalletAddress))
+	if len(event.Tags) != 1 || event.Tags[0] != domain.WashTradeTag {
+		t.Errorf("tags = %v, want the wash trade tag", event.Tags)
+	}
+	restarted := NewPolymarketService(svc.store, localbus.New(), "")
+	t.Cleanup(restarted.Close)
+	if !restarted.IsWashTrader("0xb") {
+		t.Error("the flag was lost on restart")
+	}
+
+	if err := svc.ClearWashTrader("0xB"); err != nil {
+		t.Fatal(err)
+	}
+	if svc.IsWashTrader("0xa") || len(svc.GetWashPairs(false)) != 0 {
+		t.Error("clearing a wallet kept its pair flagged")
+	}
+	if err := svc.ClearWashTrader("0xB"); err == nil {
+		t.Error("cleared a wallet that isn't flagged")
+	}
+}
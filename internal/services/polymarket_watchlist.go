@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// WatchWallet adds a wallet to the watchlist of the default user, the deployment's
+// watchlist: it is refreshed every analysis cycle and each of its trades is alerted
+func (s *PolymarketService) WatchWallet(address string) error {
+	if !validWalletAddress(address) {
+		return fmt.Errorf("invalid wallet address: %q", address)
+	}
+	address = strings.ToLower(address)
+	return s.updateUserSettings(domain.DefaultUserID, func(settings *domain.UserSettings) bool {
+		if slices.Contains(settings.Watchlist, address) {
+			return false
+		}
+		settings.Watchlist = append(settings.Watchlist, address)
+		return true
+	})
+}
+
+// UnwatchWallet removes a wallet from the default user's watchlist
+func (s *PolymarketService) UnwatchWallet(address string) error {
+	address = strings.ToLower(strings.TrimSpace(address))
+	return s.updateUserSettings(domain.DefaultUserID, func(settings *domain.UserSettings) bool {
+		if !slices.Contains(settings.Watchlist, address) {
+			return false
+		}
+		settings.Watchlist = slices.DeleteFunc(settings.Watchlist, func(a string) bool { return a == address })
+		return true
+	})
+}
+
+// GetWatchedWallets returns the wallets on the default user's watchlist
+func (s *PolymarketService) GetWatchedWallets() []string {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	return append([]string{}, s.watchOrder...)
+}
+
+// loadWatchlist restores the watched wallets from the default user's settings
+func (s *PolymarketService) loadWatchlist() {
+	settings, err := s.GetUserSettings(domain.DefaultUserID)
+	if err != nil {
+		return
+	}
+	s.setWatchlist(settings.Watchlist)
+}
+
+// setWatchlist replaces the watched wallets, given lowercased
+func (s *PolymarketService) setWatchlist(addresses []string) {
+	watched := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		watched[address] = true
+	}
+
+	s.watchMu.Lock()
+	s.watched = watched
+	s.watchOrder = append([]string{}, addresses...)
+	s.watchMu.Unlock()
+}
+
+// isWatched reports whether a wallet is on the watchlist
+func (s *PolymarketService) isWatched(address string) bool {
+	if address == "" {
+		return false
+	}
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+	return s.watched[strings.ToLower(address)]
+}
+
+// emitWatchedTrade emits every trade by a watched wallet as its own event, ahead of the
+// save filter so small trades are not missed. Trades on muted markets are left out.
+func (s *PolymarketService) emitWatchedTrade(event domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || !s.isWatched(event.WalletAddress) || s.isMarketMuted(event) {
+		return
+	}
+	s.eventBus.Emit("polymarket:watched_wallet_trade", event)
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+)
+
+// profileRecorder answers profile API requests and records the wallets asked for
+type profileRecorder struct {
+	mu      sync.Mutex
+	fetched map[string]bool
+}
+
+func (p *profileRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	p.mu.Lock()
+	p.fetched[req.URL.Query().Get("proxyAddress")] = true
+	p.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"trades": 40}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestProcessWalletsRefreshesWholeWatchlist(t *testing.T) {
+	svc, _ := newTestService(t)
+	recorder := &profileRecorder{fetched: make(map[string]bool)}
+	svc.mu.Lock()
+	svc.walletAnalyzer.SetTransport(recorder)
+	svc.mu.Unlock()
+
+	var watched []string
+	for i := range 12 {
+		address := fmt.Sprintf("0x%040x", i+1)
+		if err := svc.WatchWallet(address); err != nil {
+			t.Fatal(err)
+		}
+		watched = append(watched, address)
+	}
+
+	svc.processWallets()
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	for _, address := range watched {
+		if !recorder.fetched[address] {
+			t.Errorf("watched wallet %s was not refreshed in the cycle", address)
+		}
+	}
+}
+
+func TestConcurrentWatchlistChangesAreKept(t *testing.T) {
+	svc := NewPolymarketService(slowSettingsStore{storage.NewMemoryPolymarketStore()}, localbus.New(), "")
+	t.Cleanup(svc.Close)
+
+	addresses := make([]string, 40)
+	for i := range addresses {
+		addresses[i] = fmt.Sprintf("0x%040x", i+1)
+	}
+
+	var wg sync.WaitGroup
+	for _, address := range addresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := svc.WatchWallet(address); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := len(svc.GetWatchedWallets()); got != len(addresses) {
+		t.Fatalf("got %d watched wallets after concurrent adds, want %d", got, len(addresses))
+	}
+
+	for _, address := range addresses[:20] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := svc.UnwatchWallet(address); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	settings, err := svc.GetUserSettings("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, address := range addresses {
+		if watched, want := svc.isWatched(address), i >= 20; watched != want {
+			t.Errorf("isWatched(%s) = %v after concurrent removals, want %v", address, watched, want)
+		}
+		if saved, want := slices.Contains(settings.Watchlist, address), i >= 20; saved != want {
+			t.Errorf("saved watchlist has %s = %v, want %v", address, saved, want)
+		}
+	}
+}
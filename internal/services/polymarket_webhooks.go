@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// publishWalletUpdate emits polymarket:wallet_updated and posts it to the wallet webhook.
+// previous is the stored profile before the analysis, nil if the wallet was never analyzed.
+func (s *PolymarketService) publishWalletUpdate(profile domain.WalletProfile, previous *domain.WalletProfile) {
+	update := domain.WalletUpdate{
+		Type:      domain.WalletUpdateAnalyzed,
+		Wallet:    profile,
+		Timestamp: time.Now(),
+	}
+	if previous != nil && previous.BetCount >= 0 && previous.FreshnessLevel != profile.FreshnessLevel {
+		update.Type = domain.WalletUpdateFreshnessChanged
+		update.PreviousFreshnessLevel = previous.FreshnessLevel
+	}
+
+	s.eventBus.Emit("polymarket:wallet_updated", update)
+	s.syncWalletCase(profile.Address)
+
+	s.mu.RLock()
+	url, secret := s.config.WalletWebhookURL, s.config.WalletWebhookSecret
+	s.mu.RUnlock()
+	if url == "" {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		if err := s.webhook.Post(ctx, url, secret, update); err != nil {
+			log.Printf("[PolymarketService] Wallet webhook failed for %s: %v", shortenAddress(profile.Address), err)
+		}
+	}()
+}
+
+// publishTradingViewAlert posts a flagged trade meeting the alert thresholds to the
+// TradingView-compatible signal webhook
+func (s *PolymarketService) publishTradingViewAlert(event domain.PolymarketEvent) {
+	if event.EventType != domain.PolymarketEventTrade || len(event.RiskSignals) == 0 || event.Muted {
+		return
+	}
+	s.mu.RLock()
+	config := s.config
+	s.mu.RUnlock()
+	if config.SignalWebhookURL == "" || !meetsAlertThresholds(event, config.MinTradeSize, config.AlertThreshold) {
+		return
+	}
+
+	alert := domain.NewTradingViewAlert(event, config.SignalWebhookPassphrase, time.Now())
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		if err := s.webhook.Post(ctx, config.SignalWebhookURL, "", alert); err != nil {
+			log.Printf("[PolymarketService] Signal webhook failed for trade %s: %v", event.TradeID, err)
+		}
+	}()
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/webhook"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestWalletUpdatesArePostedToWebhook(t *testing.T) {
+	type delivery struct {
+		update    domain.WalletUpdate
+		signature string
+		body      []byte
+	}
+	received := make(chan delivery, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var update domain.WalletUpdate
+		json.Unmarshal(body, &update)
+		received <- delivery{update, r.Header.Get(webhook.SignatureHeader), body}
+	}))
+	defer ts.Close()
+
+	svc, emitted := newTestService(t)
+	svc.mu.Lock()
+	svc.config.WalletWebhookURL, svc.config.WalletWebhookSecret = ts.URL, "s3cret"
+	svc.mu.Unlock()
+
+	previous := domain.WalletProfile{Address: "0xabc", BetCount: 2, FreshnessLevel: domain.FreshnessInsider}
+	profile := domain.WalletProfile{Address: "0xabc", BetCount: 15, FreshnessLevel: domain.FreshnessNewbie}
+	svc.publishWalletUpdate(profile, &previous)
+
+	select {
+	case got := <-received:
+		if got.update.Type != domain.WalletUpdateFreshnessChanged || got.update.PreviousFreshnessLevel != domain.FreshnessInsider {
+			t.Errorf("update = %+v, want a freshness change from insider", got.update)
+		}
+		if got.update.Wallet.Address != "0xabc" || got.update.Wallet.FreshnessLevel != domain.FreshnessNewbie {
+			t.Errorf("wallet = %+v, want the new profile", got.update.Wallet)
+		}
+		if got.signature != webhook.Sign("s3cret", got.body) {
+			t.Errorf("signature = %q, want the body signed with the secret", got.signature)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+	if len(emitted.of("polymarket:wallet_updated")) != 1 {
+		t.Error("polymarket:wallet_updated was not emitted")
+	}
+
+	// A first analysis is not a freshness change
+	svc.publishWalletUpdate(profile, nil)
+	select {
+	case got := <-received:
+		if got.update.Type != domain.WalletUpdateAnalyzed {
+			t.Errorf("first analysis type = %s, want %s", got.update.Type, domain.WalletUpdateAnalyzed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not called for the first analysis")
+	}
+}
+
+func TestFlaggedTradesArePostedAsTradingViewAlerts(t *testing.T) {
+	received := make(chan domain.TradingViewAlert, 2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert domain.TradingViewAlert
+		json.NewDecoder(r.Body).Decode(&alert)
+		received <- alert
+	}))
+	defer ts.Close()
+
+	svc, _ := newTestService(t)
+	svc.mu.Lock()
+	svc.config.SignalWebhookURL, svc.config.SignalWebhookPassphrase = ts.URL, "open sesame"
+	svc.config.MinTradeSize, svc.config.AlertThreshold = 1000, 0.5
+	svc.mu.Unlock()
+
+	trade := domain.PolymarketEvent{
+		EventType: domain.PolymarketEventTrade, TradeID: "tx-1", MarketSlug: "fed-cut", MarketName: "Fed cuts in March?",
+		Outcome: "Yes", Side: domain.OrderSideSell, Price: "0.25", Size: "8000", WalletAddress: "0xabc",
+		RiskScore: 0.9, RiskSignals: []string{"🚨 Fresh Insider (0 bets)"}, Timestamp: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	small := trade
+	small.Size = "100"
+	unflagged := trade
+	unflagged.RiskSignals = nil
+	svc.publishTradingViewAlert(small)
+	svc.publishTradingViewAlert(unflagged)
+	svc.publishTradingViewAlert(trade)
+
+	select {
+	case alert := <-received:
+		if alert.Passphrase != "open sesame" || alert.Ticker != "FED-CUT:YES" || alert.Exchange != "POLYMARKET" ||
+			alert.Action != "sell" || alert.Strategy.MarketPosition != "short" || alert.Close != 0.25 ||
+			alert.Notional != 2000 || alert.Time != "2026-03-01T12:00:00Z" || alert.Strategy.OrderID != "tx-1" {
+			t.Errorf("alert = %+v, want the short sell of FED-CUT:YES", alert)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("signal webhook was not called")
+	}
+	select {
+	case alert := <-received:
+		t.Errorf("posted %+v, want only trades meeting the alert thresholds", alert)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
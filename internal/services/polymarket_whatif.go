@@ -0,0 +1,94 @@
+package services
+
+import (
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// Default candidate thresholds for the what-if analyzer
+var (
+	defaultWhatIfInsiderMaxBets = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	defaultWhatIfMinTradeSizes  = []float64{100, 250, 500, 1000, 2500, 5000, 10000}
+)
+
+const defaultWhatIfDays = 30
+
+// AnalyzeThresholds replays stored trades against candidate insider/min-size thresholds
+// and reports the alert volume and hit rate each combination would have produced.
+// A hit is an alert where the last stored price of the same asset moved in the trade's favor.
+func (s *PolymarketService) AnalyzeThresholds(opts domain.ThresholdAnalysisOptions) (*domain.ThresholdAnalysis, error) {
+	if len(opts.InsiderMaxBets) == 0 {
+		opts.InsiderMaxBets = defaultWhatIfInsiderMaxBets
+	}
+	if len(opts.MinTradeSizes) == 0 {
+		opts.MinTradeSizes = defaultWhatIfMinTradeSizes
+	}
+	if opts.Days <= 0 {
+		opts.Days = defaultWhatIfDays
+	}
+
+	samples, err := s.store.GetTradeSamples(time.Now().AddDate(0, 0, -opts.Days))
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &domain.ThresholdAnalysis{
+		TradesAnalyzed: len(samples),
+		GeneratedAt:    time.Now(),
+	}
+	if len(samples) == 0 {
+		return analysis, nil
+	}
+
+	analysis.From = samples[0].Timestamp
+	analysis.To = samples[len(samples)-1].Timestamp
+	analysis.Days = analysis.To.Sub(analysis.From).Hours() / 24
+	days := analysis.Days
+	if days < 1 {
+		days = 1
+	}
+
+	// Samples are oldest first, so the last write wins
+	lastPrice := make(map[string]float64)
+	lastSeen := make(map[string]time.Time)
+	for _, sample := range samples {
+		lastPrice[sample.AssetID] = sample.Price
+		lastSeen[sample.AssetID] = sample.Timestamp
+	}
+
+	for _, maxBets := range opts.InsiderMaxBets {
+		for _, minSize := range opts.MinTradeSizes {
+			scenario := domain.ThresholdScenario{InsiderMaxBets: maxBets, MinTradeSize: minSize}
+			for _, sample := range samples {
+				if sample.BetCount < 0 || sample.BetCount > maxBets || sample.Notional < minSize {
+					continue
+				}
+				scenario.Alerts++
+
+				if !lastSeen[sample.AssetID].After(sample.Timestamp) {
+					continue // No later price to judge against
+				}
+				scenario.Evaluated++
+				if isFavorableMove(sample.Side, sample.Price, lastPrice[sample.AssetID]) {
+					scenario.Hits++
+				}
+			}
+			scenario.AlertsPerDay = float64(scenario.Alerts) / days
+			if scenario.Evaluated > 0 {
+				scenario.HitRate = float64(scenario.Hits) / float64(scenario.Evaluated)
+			}
+			analysis.Scenarios = append(analysis.Scenarios, scenario)
+		}
+	}
+
+	return analysis, nil
+}
+
+// isFavorableMove reports whether the price moved in favor of a trade on the given side
+func isFavorableMove(side domain.OrderSide, entry, latest float64) bool {
+	if side == domain.OrderSideSell {
+		return latest < entry
+	}
+	return latest > entry
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+func TestAnalyzeThresholdsReplaysStoredTrades(t *testing.T) {
+	svc, _ := newTestService(t)
+	now := time.Now()
+	trade := func(id string, at time.Time, bets int, side domain.OrderSide, price, size string) domain.PolymarketEvent {
+		e := domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: id, WalletAddress: "0x" + id, AssetID: "yes-token",
+			Side: side, Price: price, Size: size, Timestamp: at,
+		}
+		if bets >= 0 {
+			e.WalletProfile = &domain.WalletProfile{Address: e.WalletAddress, BetCount: bets}
+		}
+		return e
+	}
+	for _, e := range []domain.PolymarketEvent{
+		trade("a", now.Add(-48*time.Hour), 1, domain.OrderSideBuy, "0.4", "1000"),   // $400, price later rose: a hit
+		trade("b", now.Add(-24*time.Hour), 8, domain.OrderSideBuy, "0.8", "5000"),   // $4,000, price later fell: a miss
+		trade("c", now.Add(-time.Hour), -1, domain.OrderSideSell, "0.7", "10"),      // Not analyzed, only sets the last price
+		trade("old", now.AddDate(0, 0, -10), 1, domain.OrderSideBuy, "0.1", "9000"), // Outside the window
+	} {
+		if err := svc.store.SaveEvent(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	analysis, err := svc.AnalyzeThresholds(domain.ThresholdAnalysisOptions{
+		InsiderMaxBets: []int{2, 10}, MinTradeSizes: []float64{100, 1000}, Days: 7,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.TradesAnalyzed != 3 || math.Abs(analysis.Days-47.0/24) > 0.01 {
+		t.Errorf("analyzed %d trades over %.2f days, want 3 over 47h", analysis.TradesAnalyzed, analysis.Days)
+	}
+	want := []domain.ThresholdScenario{
+		{InsiderMaxBets: 2, MinTradeSize: 100, Alerts: 1, Evaluated: 1, Hits: 1, HitRate: 1},
+		{InsiderMaxBets: 2, MinTradeSize: 1000},
+		{InsiderMaxBets: 10, MinTradeSize: 100, Alerts: 2, Evaluated: 2, Hits: 1, HitRate: 0.5},
+		{InsiderMaxBets: 10, MinTradeSize: 1000, Alerts: 1, Evaluated: 1},
+	}
+	if len(analysis.Scenarios) != len(want) {
+		t.Fatalf("got %d scenarios, want %d", len(analysis.Scenarios), len(want))
+	}
+	for i, got := range analysis.Scenarios {
+		perDay := float64(want[i].Alerts) / analysis.Days
+		got.AlertsPerDay, want[i].AlertsPerDay = math.Round(got.AlertsPerDay*1000), math.Round(perDay*1000)
+		if got != want[i] {
+			t.Errorf("scenario %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestAnalyzeThresholdsDefaults(t *testing.T) {
+	svc, _ := newTestService(t)
+	analysis, err := svc.AnalyzeThresholds(domain.ThresholdAnalysisOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.TradesAnalyzed != 0 || len(analysis.Scenarios) != 0 {
+		t.Errorf("analysis of an empty store = %+v, want no scenarios", analysis)
+	}
+	svc.store.SaveEvent(domain.PolymarketEvent{
+		EventType: domain.PolymarketEventTrade, TradeID: "a", WalletAddress: "0xa", Price: "0.5", Size: "10", Timestamp: time.Now(),
+	})
+	if analysis, _ := svc.AnalyzeThresholds(domain.ThresholdAnalysisOptions{}); len(analysis.Scenarios) != 70 {
+		t.Errorf("got %d scenarios, want the 10 × 7 default grid", len(analysis.Scenarios))
+	}
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// CheckWithdrawals scans the open watches on-chain for USDC leaving the wallets and
+// returns the withdrawals found. Watches end at the first withdrawal or their deadline.
+func (s *PolymarketService) CheckWithdrawals() ([]domain.WalletWithdrawal, error) {
+	chain := s.chainClient()
+	if chain == nil {
+		return nil, fmt.Errorf("withdrawal monitoring needs a Polygon RPC URL")
+	}
+	watches := s.GetWithdrawalWatches()
+	if len(watches) == 0 {
+		return []domain.WalletWithdrawal{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	latest, err := chain.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the latest block: %w", err)
+	}
+	latestAt, err := chain.BlockTime(ctx, latest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the latest block: %w", err)
+	}
+	blockAt := func(t time.Time) uint64 {
+		behind := uint64(max(0, latestAt.Sub(t)/polygonBlockTime))
+		return latest - min(latest, behind)
+	}
+	_, minUSD := s.withdrawalThresholds()
+
+	found := []domain.WalletWithdrawal{}
+	updated := make(map[string]*domain.WithdrawalWatch) // nil = ended
+	for _, watch := range watches {
+		key := watch.Wallet + "|" + watch.MarketSlug
+		if watch.FromBlock == 0 {
+			watch.FromBlock = blockAt(watch.ResolvedAt.Add(-10 * time.Minute)) // Margin for block time drift
+		}
+		to := min(latest, blockAt(watch.Deadline))
+		from := max(watch.FromBlock, watch.ScannedTo+1)
+
+		var withdrawals []domain.WalletWithdrawal
+		if from <= to {
+			transfers, err := chain.Withdrawals(ctx, watch.Wallet, from, to)
+			if err != nil {
+				log.Printf("[PolymarketService] Withdrawal scan of %s failed: %v", shortenAddress(watch.Wallet), err)
+				s.errReporter.Report("withdrawal monitor", err)
+				continue
+			}
+			watch.ScannedTo = to
+			for _, w := range transfers {
+				if w.AmountUSD < minUSD {
+					continue
+				}
+				if w.At, err = chain.BlockTime(ctx, w.Block); err != nil {
+					w.At = latestAt.Add(-time.Duration(latest-w.Block) * polygonBlockTime)
+				}
+				w.MarketSlug = watch.MarketSlug
+				w.HoursAfter = math.Max(0, w.At.Sub(watch.ResolvedAt).Hours())
+				withdrawals = append(withdrawals, w)
+			}
+		}
+
+		switch {
+		case len(withdrawals) > 0:
+			updated[key] = nil
+			found = append(found, withdrawals...)
+			s.emitWithdrawalAlert(watch, withdrawals)
+		case time.Now().After(watch.Deadline) && watch.ScannedTo >= blockAt(watch.Deadline):
+			updated[key] = nil // Held through the window
+		default:
+			w := watch
+			updated[key] = &w
+		}
+	}
+
+	s.exitMu.Lock()
+	kept := s.exitWatches[:0]
+	for _, watch := range s.exitWatches {
+		next, ok := updated[watch.Wallet+"|"+watch.MarketSlug]
+		switch {
+		case !ok:
+			kept = append(kept, watch) // Added while scanning
+		case next != nil:
+			kept = append(kept, *next)
+		}
+	}
+	s.exitWatches = kept
+	for i := len(found) - 1; i >= 0; i-- {
+		s.withdrawals = append([]domain.WalletWithdrawal{found[i]}, s.withdrawals...)
+	}
+	if len(s.withdrawals) > maxWalletWithdrawals {
+		s.withdrawals = s.withdrawals[:maxWalletWithdrawals]
+	}
+	s.saveWithdrawalsLocked()
+	s.exitMu.Unlock()
+	return found, nil
+}
+
+// emitWithdrawalAlert announces a flagged winner moving USDC out after the resolution
+func (s *PolymarketService) emitWithdrawalAlert(watch domain.WithdrawalWatch, withdrawals []domain.WalletWithdrawal) {
+	total := 0.0
+	for _, w := range withdrawals {
+		total += w.AmountUSD
+	}
+	first := withdrawals[0]
+	message := fmt.Sprintf("%s withdrew %s %.1fh after winning %s",
+		shortenAddress(watch.Wallet), formatCompactUSD(total), first.HoursAfter, watch.MarketSlug)
+	log.Printf("[PolymarketService] WITHDRAWAL: %s", message)
+
+	s.eventBus.Emit("polymarket:wallet_withdrawal", withdrawals)
+	if s.isMarketMuted(domain.PolymarketEvent{MarketSlug: watch.MarketSlug}) {
+		return
+	}
+	window, _ := s.withdrawalThresholds()
+	s.eventBus.Emit("polymarket:detector_signal", domain.DetectorSignal{
+		Detector: "withdrawal",
+		Signal:   "withdrew_after_win",
+		Message:  message,
+		Score:    math.Min(1, 0.7+0.3*(1-first.HoursAfter/window.Hours())),
+		Alert:    true,
+		Metadata: map[string]string{
+			"amountUsd":  strconv.FormatFloat(total, 'f', 2, 64),
+			"hoursAfter": strconv.FormatFloat(first.HoursAfter, 'f', 1, 64),
+			"txHash":     first.TxHash,
+			"to":         first.To,
+		},
+		WalletAddress: watch.Wallet,
+		MarketName:    watch.MarketSlug,
+		MarketLink:    "https://polymarket.com/event/" + watch.MarketSlug,
+		Timestamp:     first.At,
+	})
+}
+
+// withdrawalWorker scans the open watches periodically
+func (s *PolymarketService) withdrawalWorker() {
+	ticker := time.NewTicker(withdrawalCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			if s.chainClient() == nil || len(s.GetWithdrawalWatches()) == 0 {
+				continue
+			}
+			if _, err := s.CheckWithdrawals(); err != nil {
+				log.Printf("[PolymarketService] Withdrawal check failed: %v", err)
+				s.errReporter.Report("withdrawal monitor", err)
+			}
+		}
+	}
+}
+
+// chainClient returns a Polygon client for the configured RPC URL, or nil without one
+func (s *PolymarketService) chainClient() *polymarket.ChainClient {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.config.PolygonRPCURL == "" {
+		return nil
+	}
+	return polymarket.NewChainClient(s.config.PolygonRPCURL)
+}
+
+// withdrawalThresholds returns the configured watch window and minimum withdrawal
+func (s *PolymarketService) withdrawalThresholds() (time.Duration, float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hours, minUSD := s.config.WithdrawalWindowHours, s.config.WithdrawalMinUSD
+	if hours <= 0 {
+		hours = defaultWithdrawalWindowHours
+	}
+	if minUSD <= 0 {
+		minUSD = defaultWithdrawalMinUSD
+	}
+	return time.Duration(hours) * time.Hour, minUSD
+}
+
+// saveWithdrawalsLocked persists the watches and withdrawals. Caller must hold exitMu.
+func (s *PolymarketService) saveWithdrawalsLocked() {
+	if err := s.store.SaveSetting(withdrawalWatchesSettingKey, s.exitWatches); err != nil {
+		log.Printf("[PolymarketService] Failed to save withdrawal watches: %v", err)
+	}
+	if err := s.store.SaveSetting(walletWithdrawalsSettingKey, s.withdrawals); err != nil {
+		log.Printf("[PolymarketService] Failed to save wallet withdrawals: %v", err)
+	}
+}
+
+// loadWithdrawals restores the saved watches and withdrawals
+func (s *PolymarketService) loadWithdrawals() {
+	var watches []domain.WithdrawalWatch
+	var withdrawals []domain.WalletWithdrawal
+	s.store.LoadSetting(withdrawalWatchesSettingKey, &watches)
+	s.store.LoadSetting(walletWithdrawalsSettingKey, &withdrawals)
+
+	s.exitMu.Lock()
+	s.exitWatches = watches
+	s.withdrawals = withdrawals
+	s.exitMu.Unlock()
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+const (
+	// Settings keys the open watches and detected withdrawals are persisted under
+	withdrawalWatchesSettingKey = "withdrawal_watches"
+	walletWithdrawalsSettingKey = "wallet_withdrawals"
+
+	// Withdrawal monitoring defaults when the config leaves them unset
+	defaultWithdrawalWindowHours = 48
+	defaultWithdrawalMinUSD      = 500.0
+
+	// withdrawalCheckInterval is how often open watches are scanned on-chain
+	withdrawalCheckInterval = 15 * time.Minute
+
+	// polygonBlockTime is the average Polygon block time, used to map times to blocks
+	polygonBlockTime = 2 * time.Second
+
+	// maxWithdrawalWatches and maxWalletWithdrawals bound the persisted state
+	maxWithdrawalWatches = 500
+	maxWalletWithdrawals = 2000
+)
+
+// GetWithdrawalWatches returns the winning flagged wallets still monitored for withdrawals
+func (s *PolymarketService) GetWithdrawalWatches() []domain.WithdrawalWatch {
+	s.exitMu.Lock()
+	defer s.exitMu.Unlock()
+	return append([]domain.WithdrawalWatch{}, s.exitWatches...)
+}
+
+// GetWalletLifecycle returns a wallet's dossier of joining, betting, winning and withdrawing
+func (s *PolymarketService) GetWalletLifecycle(address string) (*domain.WalletLifecycle, error) {
+	wallet := strings.ToLower(strings.TrimSpace(address))
+	if wallet == "" {
+		return nil, fmt.Errorf("wallet address is required")
+	}
+	lifecycle := &domain.WalletLifecycle{Wallet: wallet, Withdrawals: []domain.WalletWithdrawal{}}
+
+	if profile, err := s.store.GetWallet(wallet); err == nil && profile != nil {
+		lifecycle.JoinDate = profile.JoinDate
+	}
+	lifecycle.Funding = s.fundingOrigin(wallet)
+	bets, err := s.store.GetResolvedBets(wallet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load resolved bets: %w", err)
+	}
+	for _, bet := range bets {
+		if lifecycle.FirstBetAt.IsZero() || bet.PlacedAt.Before(lifecycle.FirstBetAt) {
+			lifecycle.FirstBetAt = bet.PlacedAt
+		}
+		if bet.Won && bet.ResolvedAt.After(lifecycle.WonAt) {
+			lifecycle.WonAt = bet.ResolvedAt
+			lifecycle.WonMarket = bet.MarketSlug
+		}
+	}
+
+	s.exitMu.Lock()
+	for _, w := range s.withdrawals {
+		if w.Wallet == wallet {
+			lifecycle.Withdrawals = append(lifecycle.Withdrawals, w)
+			if w.At.After(lifecycle.WithdrewAt) {
+				lifecycle.WithdrewAt = w.At
+			}
+		}
+	}
+	for _, watch := range s.exitWatches {
+		lifecycle.Watching = lifecycle.Watching || watch.Wallet == wallet
+	}
+	s.exitMu.Unlock()
+
+	window, _ := s.withdrawalThresholds()
+	lifecycle.Complete = lifecycle.JoinDate != "" && !lifecycle.FirstBetAt.IsZero() && !lifecycle.WonAt.IsZero() &&
+		lifecycle.WithdrewAt.After(lifecycle.WonAt) && lifecycle.WithdrewAt.Sub(lifecycle.WonAt) <= window
+	return lifecycle, nil
+}
+
+// watchWithdrawals starts monitoring the flagged wallets that won a resolved market.
+// Flagged wallets are fresh ones and those in open investigations.
+func (s *PolymarketService) watchWithdrawals(slug string, resolvedAt time.Time) {
+	if s.chainClient() == nil {
+		return
+	}
+	wallets, err := s.store.GetMarketWallets(slug)
+	if err != nil {
+		log.Printf("[PolymarketService] Failed to load wallets of %s: %v", slug, err)
+		return
+	}
+	investigated := make(map[string]bool)
+	if investigations, err := s.store.ListInvestigations(""); err == nil {
+		for _, address := range watchedWallets(investigations) {
+			investigated[strings.ToLower(address)] = true
+		}
+	}
+
+	window, _ := s.withdrawalThresholds()
+	var added []domain.WithdrawalWatch
+	for _, address := range wallets {
+		wallet := strings.ToLower(address)
+		if !investigated[wallet] {
+			if profile, err := s.store.GetWallet(wallet); err != nil || profile == nil || !profile.IsFresh {
+				continue
+			}
+		}
+		if !s.wonMarket(wallet, slug) {
+			continue
+		}
+		added = append(added, domain.WithdrawalWatch{
+			Wallet:     wallet,
+			MarketSlug: slug,
+			ResolvedAt: resolvedAt,
+			Deadline:   resolvedAt.Add(window),
+		})
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	s.exitMu.Lock()
+	for _, watch := range added {
+		if !hasWithdrawalWatch(s.exitWatches, watch.Wallet, watch.MarketSlug) {
+			s.exitWatches = append(s.exitWatches, watch)
+		}
+	}
+	if len(s.exitWatches) > maxWithdrawalWatches {
+		s.exitWatches = s.exitWatches[len(s.exitWatches)-maxWithdrawalWatches:]
+	}
+	s.saveWithdrawalsLocked()
+	s.exitMu.Unlock()
+	log.Printf("[PolymarketService] Watching %d winning flagged wallets of %s for withdrawals", len(added), slug)
+}
+
+// wonMarket reports whether a wallet's bet on a resolved market won
+func (s *PolymarketService) wonMarket(wallet, slug string) bool {
+	bets, err := s.store.GetResolvedBets(wallet)
+	if err != nil {
+		return false
+	}
+	for _, bet := range bets {
+		if bet.MarketSlug == slug && bet.Won {
+			return true
+		}
+	}
+	return false
+}
+
+func hasWithdrawalWatch(watches []domain.WithdrawalWatch, wallet, slug string) bool {
+	for _, w := range watches {
+		if w.Wallet == wallet && w.MarketSlug == slug {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// fakePolygon answers the JSON-RPC calls of the chain client. Blocks are two seconds
+// apart, ending at latest now, and eth_getLogs returns the logs matching the filter.
+func fakePolygon(t *testing.T, latest uint64, logs ...map[string]any) *httptest.Server {
+	now := time.Now()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		var result any
+		switch req.Method {
+		case "eth_blockNumber":
+			result = fmt.Sprintf("0x%x", latest)
+		case "eth_getBlockByNumber":
+			var hex string
+			json.Unmarshal(req.Params[0], &hex)
+			block, _ := strconv.ParseUint(strings.TrimPrefix(hex, "0x"), 16, 64)
+			at := now.Add(-time.Duration(latest-block) * polygonBlockTime)
+			result = map[string]string{"timestamp": fmt.Sprintf("0x%x", at.Unix())}
+		case "eth_getLogs":
+			var filter struct {
+				FromBlock string `json:"fromBlock"`
+				ToBlock   string `json:"toBlock"`
+				Topics    []string
+			}
+			json.Unmarshal(req.Params[0], &filter)
+			from, _ := strconv.ParseUint(strings.TrimPrefix(filter.FromBlock, "0x"), 16, 64)
+			to, _ := strconv.ParseUint(strings.TrimPrefix(filter.ToBlock, "0x"), 16, 64)
+			matched := []map[string]any{}
+			for _, l := range logs {
+				block, _ := strconv.ParseUint(strings.TrimPrefix(l["blockNumber"].(string), "0x"), 16, 64)
+				if block >= from && block <= to && topicsMatch(l["topics"].([]string), filter.Topics) {
+					matched = append(matched, l)
+				}
+			}
+			result = matched
+		default:
+			t.Errorf("unexpected RPC method %s", req.Method)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// topicsMatch reports whether a log's topics match a filter, where empty topics match any
+func topicsMatch(topics, filter []string) bool {
+	for i, want := range filter {
+		if want != "" && (i >= len(topics) || topics[i] != want) {
+			return false
+		}
+	}
+	return true
+}
+
+// usdcTransfer is a USDC.e Transfer log from sender to recipient
+func usdcTransfer(wallet, to string, usd float64, block uint64) map[string]any {
+	pad := func(address string) string { return "0x000000000000000000000000" + strings.TrimPrefix(address, "0x") }
+	return map[string]any{
+		"address":         "0x2791bca1f2de4661ed88a30c99a7a9449aa84174",
+		"topics":          []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", pad(wallet), pad(to)},
+		"data":            fmt.Sprintf("0x%064x", uint64(usd*1e6)),
+		"blockNumber":     fmt.Sprintf("0x%x", block),
+		"transactionHash": fmt.Sprintf("0xtx%d", block),
+	}
+}
+
+func TestCheckWithdrawalsEndsWatches(t *testing.T) {
+	svc, rec := newTestService(t)
+	winner := "0x" + strings.Repeat("a", 40)
+	holder := "0x" + strings.Repeat("b", 40)
+	exchange := "0x4bfb41d5b3570defd03c39a9a4d8de6bd8b8982e"
+	latest := uint64(60_000_000)
+	rpc := fakePolygon(t, latest,
+		usdcTransfer(winner, exchange, 9000, latest-1000),                   // Trading, not a withdrawal
+		usdcTransfer(winner, "0x"+strings.Repeat("c", 40), 100, latest-900), // Below the minimum
+		usdcTransfer(winner, "0x"+strings.Repeat("d", 40), 25000, latest-900),
+	)
+	svc.mu.Lock()
+	svc.config.PolygonRPCURL = rpc.URL
+	svc.mu.Unlock()
+
+	now := time.Now()
+	svc.exitMu.Lock()
+	svc.exitWatches = []domain.WithdrawalWatch{
+		{Wallet: winner, MarketSlug: "fed-cut", ResolvedAt: now.Add(-time.Hour), Deadline: now.Add(47 * time.Hour)},
+		{Wallet: holder, MarketSlug: "fed-cut", ResolvedAt: now.Add(-50 * time.Hour), Deadline: now.Add(-2 * time.Hour)},
+	}
+	svc.exitMu.Unlock()
+
+	found, err := svc.CheckWithdrawals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].AmountUSD != 25000 || found[0].Token != "USDC.e" || found[0].MarketSlug != "fed-cut" ||
+		found[0].HoursAfter < 0.4 || found[0].HoursAfter > 0.6 {
+		t.Fatalf("found = %+v, want the $25k withdrawal half an hour after the resolution", found)
+	}
+	if watches := svc.GetWithdrawalWatches(); len(watches) != 0 {
+		t.Errorf("watches = %+v, want the withdrawal and the expired watch ended", watches)
+	}
+	if len(rec.of("polymarket:wallet_withdrawal")) != 1 || len(rec.of("polymarket:detector_signal")) != 1 {
+		t.Error("the withdrawal was not alerted")
+	}
+
+	lifecycle, err := svc.GetWalletLifecycle(" " + strings.ToUpper(winner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lifecycle.Withdrawals) != 1 || lifecycle.Watching || lifecycle.Complete {
+		t.Errorf("lifecycle = %+v, want the withdrawal recorded without the earlier stages", lifecycle)
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// eventPriority ranks event types for the write queue's overflow policy;
+// the lowest ranked queued events are dropped first
+func eventPriority(eventType domain.PolymarketEventType) int {
+	switch eventType {
+	case domain.PolymarketEventBook:
+		return 0
+	case domain.PolymarketEventPriceChange:
+		return 1
+	case domain.PolymarketEventTickSizeChange:
+		return 2
+	case domain.PolymarketEventLastTradePrice:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// writeQueue is a bounded queue of events waiting to be written to the database.
+// When full, the lowest priority event (queued or incoming) is dropped; trades are
+// never dropped and instead wait for space, pushing back on the producer.
+type writeQueue struct {
+	mu            sync.Mutex
+	notEmpty      *sync.Cond
+	notFull       *sync.Cond
+	items         []domain.PolymarketEvent
+	capacity      int
+	inFlight      int
+	dropped       map[domain.PolymarketEventType]int64
+	blockedTrades int64
+	skipped       int64
+	pressureSince time.Time // Start of the current back-pressure episode, zero if none
+	closed        bool
+}
+
+func newWriteQueue(capacity int) *writeQueue {
+	q := &writeQueue{
+		capacity: capacity,
+		dropped:  make(map[domain.PolymarketEventType]int64),
+	}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// push queues an event, applying the overflow policy when the queue is full
+func (q *writeQueue) push(event domain.PolymarketEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		q.dropped[event.EventType]++
+		return
+	}
+
+	waited := false
+	for len(q.items) >= q.capacity && !q.closed {
+		lowest := q.lowestPriorityLocked()
+		incoming := eventPriority(event.EventType)
+		queued := eventPriority(q.items[lowest].EventType)
+		if event.EventType != domain.PolymarketEventTrade && incoming <= queued {
+			q.dropped[event.EventType]++
+			return
+		}
+		if q.items[lowest].EventType != domain.PolymarketEventTrade {
+			q.dropped[q.items[lowest].EventType]++
+			q.items = append(q.items[:lowest], q.items[lowest+1:]...)
+			break
+		}
+		// Only trades are queued: wait for a writer to make room
+		if !waited {
+			q.blockedTrades++
+			waited = true
+		}
+		q.notFull.Wait()
+	}
+	if q.closed {
+		q.dropped[event.EventType]++
+		return
+	}
+
+	q.items = append(q.items, event)
+	q.notEmpty.Signal()
+}
+
+// popBatch waits for the next event, then up to linger for more until max are queued,
+// and takes them; ok is false once the queue is closed and drained. Callers must call
+// done with the batch size after writing it.
+func (q *writeQueue) popBatch(max int, linger time.Duration) (batch []domain.PolymarketEvent, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.items) == 0 && !q.closed {
+		q.notEmpty.Wait()
+	}
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	if len(q.items) < max && !q.closed && linger > 0 {
+		expired := false
+		timer := time.AfterFunc(linger, func() {
+			q.mu.Lock()
+			expired = true
+			q.mu.Unlock()
+			q.notEmpty.Broadcast()
+		})
+		for len(q.items) < max && !q.closed && !expired {
+			q.notEmpty.Wait()
+		}
+		timer.Stop()
+	}
+
+	n := min(len(q.items), max)
+	batch = make([]domain.PolymarketEvent, n)
+	copy(batch, q.items)
+	clear(q.items[:n])
+	q.items = q.items[n:]
+	q.inFlight += n
+	q.notFull.Broadcast()
+	return batch, true
+}
+
+// done marks n events taken by popBatch as written
+func (q *writeQueue) done(n int) {
+	q.mu.Lock()
+	q.inFlight -= n
+	q.mu.Unlock()
+}
+
+// skip counts an event written directly instead of through the queue
+func (q *writeQueue) skip() {
+	q.mu.Lock()
+	q.skipped++
+	q.mu.Unlock()
+}
+
+// close stops accepting events and wakes writers so they drain what is queued
+func (q *writeQueue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
+
+// status returns queue depth and overflow counters
+func (q *writeQueue) status() domain.WriteQueueStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	dropped := make(map[domain.PolymarketEventType]int64, len(q.dropped))
+	for t, n := range q.dropped {
+		dropped[t] = n
+	}
+	return domain.WriteQueueStatus{
+		Depth:             len(q.items),
+		Capacity:          q.capacity,
+		InFlight:          q.inFlight,
+		Dropped:           dropped,
+		BlockedTrades:     q.blockedTrades,
+		Priority:          q.skipped,
+		BackPressure:      !q.pressureSince.IsZero(),
+		BackPressureSince: q.pressureSince,
+	}
+}
+
+// setPressureSince records the start of a back-pressure episode, or its end when zero
+func (q *writeQueue) setPressureSince(since time.Time) {
+	q.mu.Lock()
+	q.pressureSince = since
+	q.mu.Unlock()
+}
+
+// lowestPriorityLocked returns the index of the oldest lowest priority event. Caller must hold mu.
+func (q *writeQueue) lowestPriorityLocked() int {
+	lowest := 0
+	for i := range q.items {
+		if eventPriority(q.items[i].EventType) < eventPriority(q.items[lowest].EventType) {
+			lowest = i
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/localbus"
+	"github.com/luthebao/poly-xtools/internal/adapters/storage"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+)
+
+func TestWriteQueueDropsLowestPriorityAndNeverTrades(t *testing.T) {
+	q := newWriteQueue(3)
+	push := func(eventType domain.PolymarketEventType, id string) {
+		q.push(domain.PolymarketEvent{EventType: eventType, TradeID: id})
+	}
+	push(domain.PolymarketEventBook, "book")
+	push(domain.PolymarketEventPriceChange, "change")
+	push(domain.PolymarketEventTrade, "trade-1")
+	push(domain.PolymarketEventBook, "late book")            // Not above anything queued: dropped
+	push(domain.PolymarketEventTrade, "trade-2")             // Replaces the book
+	push(domain.PolymarketEventLastTradePrice, "last price") // Replaces the price change
+	push(domain.PolymarketEventTrade, "trade-3")             // Replaces the last trade price
+
+	status := q.status()
+	if status.Depth != 3 || status.Dropped[domain.PolymarketEventBook] != 2 ||
+		status.Dropped[domain.PolymarketEventPriceChange] != 1 || status.Dropped[domain.PolymarketEventLastTradePrice] != 1 {
+		t.Fatalf("status = %+v, want 3 queued after dropping 2 books, a price change and a last trade price", status)
+	}
+
+	// A full queue of trades makes the next trade wait for a writer
+	pushed := make(chan struct{})
+	go func() {
+		push(domain.PolymarketEventTrade, "trade-4")
+		close(pushed)
+	}()
+	waitFor(t, "the trade to block", func() bool { return q.status().BlockedTrades == 1 })
+
+	batch, ok := q.popBatch(2, 0)
+	if !ok || len(batch) != 2 || batch[0].TradeID != "trade-1" || batch[1].TradeID != "trade-2" {
+		t.Fatalf("batch = %+v, want the two oldest trades", batch)
+	}
+	<-pushed
+	if status := q.status(); status.Depth != 2 || status.InFlight != 2 {
+		t.Errorf("status = %+v, want 2 queued and 2 in flight", status)
+	}
+	q.done(2)
+
+	q.close()
+	push(domain.PolymarketEventTrade, "after close")
+	batch, ok = q.popBatch(10, time.Second)
+	if !ok || len(batch) != 2 || batch[1].TradeID != "trade-4" {
+		t.Errorf("batch = %+v, want the queued trades drained after close", batch)
+	}
+	if _, ok := q.popBatch(10, 0); ok {
+		t.Error("popBatch succeeded on a closed, drained queue")
+	}
+	if q.status().Dropped[domain.PolymarketEventTrade] != 1 {
+		t.Error("trade pushed after close was not counted as dropped")
+	}
+}
+
+func TestPopBatchLingersForAFullBatch(t *testing.T) {
+	q := newWriteQueue(10)
+	q.push(domain.PolymarketEvent{TradeID: "t1"})
+
+	// A full batch is taken as soon as it is queued, long before the linger ends
+	popped := make(chan []domain.PolymarketEvent)
+	go func() {
+		batch, _ := q.popBatch(3, time.Minute)
+		popped <- batch
+	}()
+	q.push(domain.PolymarketEvent{TradeID: "t2"})
+	q.push(domain.PolymarketEvent{TradeID: "t3"})
+	q.push(domain.PolymarketEvent{TradeID: "t4"})
+	select {
+	case batch := <-popped:
+		if len(batch) != 3 || batch[2].TradeID != "t3" {
+			t.Errorf("batch = %+v, want the first three events", batch)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("popBatch waited out the linger with a full batch queued")
+	}
+	q.done(3)
+
+	// A partial batch is taken once the linger ends
+	start := time.Now()
+	batch, ok := q.popBatch(3, 20*time.Millisecond)
+	if !ok || len(batch) != 1 || time.Since(start) < 20*time.Millisecond {
+		t.Errorf("batch = %+v after %v, want the last event after the linger", batch, time.Since(start))
+	}
+}
+
+func TestCloseFlushesQueuedEvents(t *testing.T) {
+	svc, dbPath := newSQLiteTestService(t, "")
+	svc.mu.Lock()
+	svc.config.EventBatchSize, svc.config.EventFlushIntervalMs = 1000, int(time.Hour/time.Millisecond)
+	svc.mu.Unlock()
+	for _, id := range []string{"a", "b", "c"} {
+		svc.Ingest(domain.PolymarketEvent{
+			EventType: domain.PolymarketEventTrade, TradeID: id, WalletAddress: "0x" + id, AssetID: "111",
+			Side: domain.OrderSideBuy, Price: "0.5", Size: "10000",
+		})
+	}
+	svc.Close()
+
+	store, err := storage.NewPolymarketStore(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if count, _ := store.GetEventCount(domain.PolymarketEventFilter{}); count != 3 {
+		t.Errorf("stored %d events after Close, want the 3 queued trades", count)
+	}
+}
+
+// failingBatchStore rejects every batch, as when one event in it breaks the transaction
+type failingBatchStore struct{ ports.PolymarketStore }
+
+func (failingBatchStore) SaveEvents([]domain.PolymarketEvent) error {
+	return errors.New("batch failed")
+}
+
+func TestFailedBatchIsSavedOneByOne(t *testing.T) {
+	svc := NewPolymarketService(failingBatchStore{storage.NewMemoryPolymarketStore()}, localbus.New(), "")
+	t.Cleanup(svc.Close)
+
+	svc.saveEventBatch([]domain.PolymarketEvent{
+		{EventType: domain.PolymarketEventTrade, TradeID: "a", AssetID: "111", Price: "0.5", Size: "10"},
+		{EventType: domain.PolymarketEventTrade, TradeID: "b", AssetID: "111", Price: "0.5", Size: "10"},
+	})
+	if count, _ := svc.store.GetEventCount(domain.PolymarketEventFilter{}); count != 2 {
+		t.Errorf("stored %d events, want both saved one by one", count)
+	}
+}
+
+func TestCheckBackPressureAlertsOncePerEpisode(t *testing.T) {
+	svc, rec := newTestService(t)
+	drop := func(n int64) {
+		svc.writes.mu.Lock()
+		svc.writes.dropped[domain.PolymarketEventBook] += n
+		svc.writes.mu.Unlock()
+	}
+
+	drop(5)
+	overflow, alerted := svc.checkBackPressure(0, false)
+	if overflow != 5 || alerted || !svc.writes.status().BackPressure {
+		t.Fatalf("overflow = %d, alerted = %v, want the episode started without an alert", overflow, alerted)
+	}
+
+	svc.writes.setPressureSince(time.Now().Add(-2 * backPressureAlertAfter))
+	drop(1)
+	if overflow, alerted = svc.checkBackPressure(overflow, alerted); !alerted {
+		t.Fatal("sustained back-pressure was not alerted")
+	}
+	drop(1)
+	overflow, alerted = svc.checkBackPressure(overflow, alerted)
+	if len(rec.of("polymarket:backpressure")) != 1 || len(rec.of("polymarket:detector_signal")) != 1 {
+		t.Errorf("emitted %d back-pressure alerts, want one per episode", len(rec.of("polymarket:backpressure")))
+	}
+
+	if _, alerted = svc.checkBackPressure(overflow, alerted); alerted || svc.writes.status().BackPressure {
+		t.Error("back-pressure did not clear once nothing more was dropped")
+	}
+	if len(rec.of("polymarket:backpressure_cleared")) != 1 {
+		t.Error("clearing an alerted episode was not announced")
+	}
+}
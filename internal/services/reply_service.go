@@ -7,8 +7,8 @@ import (
 
 	"github.com/google/uuid"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // ReplyService handles reply generation and posting
@@ -7,8 +7,8 @@ import (
 	"strings"
 	"time"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
 )
 
 // SearchService handles tweet searching and filtering
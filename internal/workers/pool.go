@@ -4,10 +4,10 @@ import (
 	"sync"
 	"time"
 
-	"xtools/internal/adapters/ratelimit"
-	"xtools/internal/domain"
-	"xtools/internal/ports"
-	"xtools/internal/services"
+	"github.com/luthebao/poly-xtools/internal/adapters/ratelimit"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/services"
 )
 
 // WorkerPool manages workers for all accounts
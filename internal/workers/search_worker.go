@@ -6,9 +6,9 @@ import (
 	"sync"
 	"time"
 
-	"xtools/internal/domain"
-	"xtools/internal/ports"
-	"xtools/internal/services"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/internal/ports"
+	"github.com/luthebao/poly-xtools/internal/services"
 )
 
 // SearchWorker runs periodic tweet searches for an account
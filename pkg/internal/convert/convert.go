@@ -0,0 +1,34 @@
+// Package convert maps the public packages' types to the app's domain types
+package convert
+
+import (
+	"strconv"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/pkg/polymarket"
+)
+
+// Event returns the domain event of a trade
+func Event(trade polymarket.Trade) domain.PolymarketEvent {
+	return domain.PolymarketEvent{
+		EventType:     domain.PolymarketEventTrade,
+		TradeID:       trade.TxHash,
+		WalletAddress: trade.Wallet,
+		TraderName:    trade.TraderName,
+		AssetID:       trade.AssetID,
+		ConditionID:   trade.ConditionID,
+		MarketSlug:    trade.MarketSlug,
+		MarketName:    trade.MarketName,
+		EventSlug:     trade.EventSlug,
+		EventTitle:    trade.EventTitle,
+		Outcome:       trade.Outcome,
+		OutcomeIndex:  trade.OutcomeIndex,
+		Side:          domain.OrderSide(trade.Side),
+		Price:         strconv.FormatFloat(trade.Price, 'f', -1, 64),
+		Size:          strconv.FormatFloat(trade.Size, 'f', -1, 64),
+		Timestamp:     trade.Timestamp,
+		IsFreshWallet: trade.FreshWallet,
+		RiskScore:     trade.RiskScore,
+		RiskSignals:   trade.RiskSignals,
+	}
+}
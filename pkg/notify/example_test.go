@@ -0,0 +1,25 @@
+package notify_test
+
+import (
+	"context"
+	"os"
+
+	"github.com/luthebao/poly-xtools/pkg/notify"
+	"github.com/luthebao/poly-xtools/pkg/polymarket"
+	"github.com/luthebao/poly-xtools/pkg/walletintel"
+)
+
+// Alert on Telegram when a fresh wallet trades
+func Example() {
+	ctx := context.Background()
+	telegram := notify.NewTelegram(os.Getenv("TELEGRAM_BOT_TOKEN"), []string{os.Getenv("TELEGRAM_CHAT_ID")})
+	analyzer := walletintel.NewAnalyzer(walletintel.DefaultConfig(), nil)
+
+	client := polymarket.NewClient(func(trade polymarket.Trade) {
+		if signal, _ := analyzer.AnalyzeTrade(ctx, &trade); signal != nil {
+			telegram.Send(ctx, notify.BigTrade(trade, signal.Profile))
+		}
+	})
+	client.Connect()
+	defer client.Disconnect()
+}
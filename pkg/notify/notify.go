@@ -0,0 +1,97 @@
+// Package notify exposes alert formatting and Telegram delivery for embedding in other Go programs.
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/adapters/notification"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/pkg/internal/convert"
+	"github.com/luthebao/poly-xtools/pkg/polymarket"
+	"github.com/luthebao/poly-xtools/pkg/walletintel"
+)
+
+// Content is a formatted notification
+type Content struct {
+	Kind      string // e.g. "big_trade" or "fresh_wallet"
+	Title     string
+	Message   string // Telegram HTML
+	Timestamp time.Time
+	Priority  string            // "high", "medium" or "low"
+	Metadata  map[string]string // Trade and wallet details, e.g. "walletAddress"
+}
+
+// Sender delivers notifications to a channel
+type Sender interface {
+	Send(ctx context.Context, content Content) error
+}
+
+// BigTrade formats a big trade alert. profile, e.g. a Signal's, adds the wallet's bet
+// count and join date; it may be nil.
+func BigTrade(trade polymarket.Trade, profile *walletintel.Profile) Content {
+	event := convert.Event(trade)
+	if profile != nil {
+		event.WalletProfile = &domain.WalletProfile{Address: profile.Address, BetCount: profile.BetCount, JoinDate: profile.JoinDate}
+	}
+	return newContent(domain.NewBigTradeNotification(event))
+}
+
+// FreshWallet formats a fresh wallet alert
+func FreshWallet(profile walletintel.Profile) Content {
+	return newContent(domain.NewFreshWalletNotification(domain.WalletProfile{
+		Address:        profile.Address,
+		BetCount:       profile.BetCount,
+		JoinDate:       profile.JoinDate,
+		FreshnessLevel: domain.FreshnessLevel(profile.FreshnessLevel),
+		IsFresh:        profile.IsFresh,
+		AnalyzedAt:     profile.AnalyzedAt,
+	}))
+}
+
+// newContent copies a formatted domain notification
+func newContent(c domain.NotificationContent) Content {
+	return Content{
+		Kind:      string(c.EventType),
+		Title:     c.Title,
+		Message:   c.Message,
+		Timestamp: c.Timestamp,
+		Priority:  c.Priority,
+		Metadata:  c.Metadata,
+	}
+}
+
+// Telegram sends notifications to one or more Telegram chats
+type Telegram struct {
+	notifier *notification.TelegramNotifier
+}
+
+// NewTelegram creates a Telegram sender for the given bot token and chat IDs
+func NewTelegram(botToken string, chatIDs []string) *Telegram {
+	return &Telegram{notifier: notification.NewTelegramNotifier(botToken, chatIDs)}
+}
+
+// Send delivers content to all configured chats
+func (t *Telegram) Send(ctx context.Context, content Content) error {
+	return t.notifier.Send(ctx, domain.NotificationContent{
+		EventType: domain.NotificationEventType(content.Kind),
+		Title:     content.Title,
+		Message:   content.Message,
+		Timestamp: content.Timestamp,
+		Priority:  content.Priority,
+		Metadata:  content.Metadata,
+	})
+}
+
+// SendTest sends a test message to verify the configuration
+func (t *Telegram) SendTest(ctx context.Context) error {
+	return t.notifier.SendTest(ctx)
+}
+
+// IsConfigured returns true if a bot token and at least one chat ID are set
+func (t *Telegram) IsConfigured() bool {
+	return t.notifier.IsConfigured()
+}
+
+// Ensure Telegram implements Sender
+var _ Sender = (*Telegram)(nil)
@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/luthebao/poly-xtools/pkg/polymarket"
+	"github.com/luthebao/poly-xtools/pkg/walletintel"
+)
+
+func TestBigTrade(t *testing.T) {
+	trade := polymarket.Trade{
+		TxHash:     "0xhash",
+		Wallet:     "0x6af75d4e4aaf700450efbac3708cce1665810ff1",
+		EventTitle: "US recession in 2025?",
+		Outcome:    "Yes",
+		Side:       polymarket.SideBuy,
+		Price:      0.25,
+		Size:       10000,
+	}
+	content := BigTrade(trade, &walletintel.Profile{BetCount: 2, JoinDate: "Oct 2026"})
+
+	if content.Kind == "" || content.Title == "" {
+		t.Errorf("content = %+v, want a kind and title", content)
+	}
+	if !strings.Contains(content.Message, "US recession in 2025?") {
+		t.Errorf("message %q doesn't name the market", content.Message)
+	}
+	if content.Metadata["betCount"] != "2" || content.Metadata["tradeId"] != "0xhash" {
+		t.Errorf("metadata = %v, want the bet count and trade ID", content.Metadata)
+	}
+}
@@ -0,0 +1,49 @@
+// Package polymarket exposes the Polymarket live trade feed client for embedding
+// in other Go programs. Its types are owned here, so the app's internals can change
+// without breaking callers; walletintel and notify take them as they are.
+package polymarket
+
+import (
+	internal "github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// TradeHandler is called for every trade received from the feed.
+// It runs on the read loop, so slow handlers delay subsequent trades.
+type TradeHandler func(trade Trade)
+
+// Client streams trades from the Polymarket live data WebSocket with automatic reconnects
+type Client struct {
+	ws *internal.WebSocketClient
+}
+
+// NewClient creates a client that delivers every trade to handler
+func NewClient(handler TradeHandler) *Client {
+	return &Client{
+		ws: internal.NewWebSocketClient(func(event domain.PolymarketEvent) {
+			if handler != nil && event.EventType == domain.PolymarketEventTrade {
+				handler(newTrade(event))
+			}
+		}),
+	}
+}
+
+// Connect starts the connection loop in the background and returns immediately
+func (c *Client) Connect() error {
+	return c.ws.Connect()
+}
+
+// Disconnect closes the connection and stops reconnecting
+func (c *Client) Disconnect() {
+	c.ws.Disconnect()
+}
+
+// IsConnected returns whether the client is currently connected
+func (c *Client) IsConnected() bool {
+	return c.ws.IsConnected()
+}
+
+// Status returns the current connection status
+func (c *Client) Status() Status {
+	return newStatus(c.ws.GetStatus())
+}
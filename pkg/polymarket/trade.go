@@ -0,0 +1,98 @@
+package polymarket
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// Side is the direction of a trade
+type Side string
+
+// Trade sides
+const (
+	SideBuy  Side = "BUY"
+	SideSell Side = "SELL"
+)
+
+// Trade is a trade received from the live data feed
+type Trade struct {
+	TxHash       string // Several trades can share a transaction
+	Wallet       string // Proxy wallet of the trader
+	TraderName   string
+	AssetID      string // Outcome token traded
+	ConditionID  string
+	MarketSlug   string
+	MarketName   string
+	EventSlug    string
+	EventTitle   string
+	Outcome      string
+	OutcomeIndex int
+	Side         Side
+	Price        float64 // Probability price, 0 to 1
+	Size         float64 // Shares
+	Timestamp    time.Time
+
+	// Set by walletintel's Analyzer.AnalyzeTrade when the wallet is fresh
+	FreshWallet bool
+	RiskScore   float64
+	RiskSignals []string
+}
+
+// Notional returns the trade's value in USDC
+func (t Trade) Notional() float64 {
+	return t.Price * t.Size
+}
+
+// Status reports the connection state and counters of a Client
+type Status struct {
+	Connected      bool
+	Connecting     bool
+	ConnectedAt    time.Time
+	TradesReceived int64
+	LastTradeAt    time.Time
+	ReconnectCount int
+	Error          string
+	Endpoint       string
+}
+
+// newTrade copies a feed event into a Trade
+func newTrade(event domain.PolymarketEvent) Trade {
+	price, _ := strconv.ParseFloat(event.Price, 64)
+	size, _ := strconv.ParseFloat(event.Size, 64)
+	return Trade{
+		TxHash:       event.TradeID,
+		Wallet:       event.WalletAddress,
+		TraderName:   event.TraderName,
+		AssetID:      event.AssetID,
+		ConditionID:  event.ConditionID,
+		MarketSlug:   event.MarketSlug,
+		MarketName:   event.MarketName,
+		EventSlug:    event.EventSlug,
+		EventTitle:   event.EventTitle,
+		Outcome:      event.Outcome,
+		OutcomeIndex: event.OutcomeIndex,
+		Side:         Side(event.Side),
+		Price:        price,
+		Size:         size,
+		Timestamp:    event.Timestamp,
+		FreshWallet:  event.IsFreshWallet,
+		RiskScore:    event.RiskScore,
+		RiskSignals:  event.RiskSignals,
+	}
+}
+
+// newStatus copies the feed client's status
+func newStatus(status domain.PolymarketWatcherStatus) Status {
+	return Status{
+		Connected:      status.IsRunning,
+		Connecting:     status.IsConnecting,
+		ConnectedAt:    status.ConnectedAt,
+		TradesReceived: status.TradesReceived,
+		LastTradeAt:    status.LastEventAt,
+		ReconnectCount: status.ReconnectCount,
+		Error:          status.ErrorMessage,
+		Endpoint:       status.WebSocketEndpoint,
+	}
+}
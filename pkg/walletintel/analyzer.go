@@ -0,0 +1,86 @@
+// Package walletintel exposes the fresh wallet analyzer for embedding in other Go programs.
+package walletintel
+
+import (
+	"context"
+
+	internal "github.com/luthebao/poly-xtools/internal/adapters/polymarket"
+	"github.com/luthebao/poly-xtools/internal/domain"
+	"github.com/luthebao/poly-xtools/pkg/internal/convert"
+	"github.com/luthebao/poly-xtools/pkg/polymarket"
+)
+
+// Store persists analyzed profiles between runs. It is optional. GetWallet returns
+// nil without an error for unknown wallets.
+type Store interface {
+	GetWallet(address string) (*Profile, error)
+	SaveWallet(profile Profile) error
+}
+
+// storeAdapter lets the internal analyzer use a Store
+type storeAdapter struct {
+	store Store
+}
+
+func (s storeAdapter) GetWallet(address string) (*domain.WalletProfile, error) {
+	profile, err := s.store.GetWallet(address)
+	if err != nil || profile == nil {
+		return nil, err
+	}
+	p := profile.domainProfile()
+	return &p, nil
+}
+
+func (s storeAdapter) SaveWallet(profile domain.WalletProfile) error {
+	return s.store.SaveWallet(*newProfile(&profile))
+}
+
+// Analyzer classifies wallets by their Polymarket bet count
+type Analyzer struct {
+	analyzer *internal.WalletAnalyzer
+}
+
+// NewAnalyzer creates an analyzer; store may be nil to keep profiles in memory only
+func NewAnalyzer(config Config, store Store) *Analyzer {
+	var walletStore internal.WalletStore
+	if store != nil {
+		walletStore = storeAdapter{store: store}
+	}
+	return &Analyzer{analyzer: internal.NewWalletAnalyzer(config.domainConfig(), walletStore)}
+}
+
+// Analyze returns a wallet's profile, using cached or stored data when available
+func (a *Analyzer) Analyze(ctx context.Context, address string) (*Profile, error) {
+	profile, err := a.analyzer.AnalyzeWallet(ctx, address)
+	return newProfile(profile), err
+}
+
+// Refresh always fetches the latest profile from Polymarket and updates the store
+func (a *Analyzer) Refresh(ctx context.Context, address string) (*Profile, error) {
+	profile, err := a.analyzer.FetchAndUpdateWallet(ctx, address)
+	return newProfile(profile), err
+}
+
+// AnalyzeTrade checks a trade for a fresh wallet and annotates it in place.
+// Returns nil when the trade is below the size threshold or the wallet is not fresh.
+func (a *Analyzer) AnalyzeTrade(ctx context.Context, trade *polymarket.Trade) (*Signal, error) {
+	event := convert.Event(*trade)
+	signal, err := a.analyzer.AnalyzeTrade(ctx, &event)
+	if err != nil || signal == nil {
+		return nil, err
+	}
+	trade.FreshWallet = event.IsFreshWallet
+	trade.RiskScore = event.RiskScore
+	trade.RiskSignals = event.RiskSignals
+	return &Signal{
+		Confidence: signal.Confidence,
+		Factors:    signal.Factors,
+		Triggered:  signal.Triggered,
+		Profile:    newProfile(event.WalletProfile),
+	}, nil
+}
+
+// Classify returns the freshness level for a bet count under the analyzer's config
+func (a *Analyzer) Classify(betCount int) FreshnessLevel {
+	return FreshnessLevel(a.analyzer.ClassifyBetCount(betCount))
+}
@@ -0,0 +1,102 @@
+package walletintel
+
+import (
+	"time"
+
+	"github.com/luthebao/poly-xtools/internal/domain"
+)
+
+// FreshnessLevel is the freshness category of a wallet
+type FreshnessLevel string
+
+// Freshness levels, from most to least suspicious
+const (
+	FreshnessNone    FreshnessLevel = ""
+	FreshnessInsider FreshnessLevel = "insider" // At most Config.InsiderMaxBets bets
+	FreshnessWallet  FreshnessLevel = "fresh"   // At most Config.WalletMaxBets bets
+	FreshnessNewbie  FreshnessLevel = "newbie"  // At most Config.NewbieMaxBets bets
+	FreshnessCustom  FreshnessLevel = "fresher" // At most Config.CustomMaxBets bets
+)
+
+// Profile contains analyzed wallet information
+type Profile struct {
+	Address        string
+	BetCount       int    // Bets placed on Polymarket
+	JoinDate       string // When the wallet joined Polymarket, e.g. "Dec 2025"
+	FreshnessLevel FreshnessLevel
+	IsFresh        bool
+	AnalyzedAt     time.Time
+	Estimated      bool // The profile API was unreachable; BetCount was counted from stored trades
+}
+
+// Signal is the fresh wallet signal attached to an analyzed trade
+type Signal struct {
+	Confidence float64            // 0 to 1
+	Factors    map[string]float64 // What the confidence is made of
+	Triggered  bool
+	Profile    *Profile // The trading wallet
+}
+
+// Config holds the analyzer thresholds
+type Config struct {
+	MinTradeSize   float64 // Smaller trades (in USDC) are not analyzed
+	InsiderMaxBets int
+	WalletMaxBets  int
+	NewbieMaxBets  int
+	CustomMaxBets  int // 0 disables the custom level
+}
+
+// DefaultConfig returns the default analyzer thresholds
+func DefaultConfig() Config {
+	d := domain.DefaultPolymarketConfig()
+	return Config{
+		MinTradeSize:   d.MinTradeSize,
+		InsiderMaxBets: d.FreshInsiderMaxBets,
+		WalletMaxBets:  d.FreshWalletMaxBets,
+		NewbieMaxBets:  d.FreshNewbieMaxBets,
+		CustomMaxBets:  d.CustomFreshMaxBets,
+	}
+}
+
+// domainConfig returns the app config carrying the analyzer thresholds
+func (c Config) domainConfig() domain.PolymarketConfig {
+	config := domain.DefaultPolymarketConfig()
+	config.MinTradeSize = c.MinTradeSize
+	config.FreshInsiderMaxBets = c.InsiderMaxBets
+	config.FreshWalletMaxBets = c.WalletMaxBets
+	config.FreshNewbieMaxBets = c.NewbieMaxBets
+	config.CustomFreshMaxBets = c.CustomMaxBets
+	return config
+}
+
+// newProfile copies an analyzed domain profile; nil stays nil
+func newProfile(p *domain.WalletProfile) *Profile {
+	if p == nil {
+		return nil
+	}
+	return &Profile{
+		Address:        p.Address,
+		BetCount:       p.BetCount,
+		JoinDate:       p.JoinDate,
+		FreshnessLevel: FreshnessLevel(p.FreshnessLevel),
+		IsFresh:        p.IsFresh,
+		AnalyzedAt:     p.AnalyzedAt,
+		Estimated:      p.Source == domain.WalletSourceLocal,
+	}
+}
+
+// domainProfile returns the domain profile of a Profile
+func (p Profile) domainProfile() domain.WalletProfile {
+	profile := domain.WalletProfile{
+		Address:        p.Address,
+		BetCount:       p.BetCount,
+		JoinDate:       p.JoinDate,
+		FreshnessLevel: domain.FreshnessLevel(p.FreshnessLevel),
+		IsFresh:        p.IsFresh,
+		AnalyzedAt:     p.AnalyzedAt,
+	}
+	if p.Estimated {
+		profile.Source = domain.WalletSourceLocal
+	}
+	return profile
+}
@@ -0,0 +1,11 @@
+#! /bin/bash
+# Builds the headless daemon image for amd64 and arm64.
+# Extra arguments go to buildx, e.g. --push to publish or --output type=local,dest=out
+
+echo -e "Start running the script..."
+cd ../
+
+echo -e "Start building the daemon image..."
+docker buildx build --platform linux/amd64,linux/arm64 --target daemon -t "${IMAGE:-xtoolsd:latest}" "$@" .
+
+echo -e "End running the script!"